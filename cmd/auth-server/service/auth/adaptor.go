@@ -69,6 +69,10 @@ func AdaptAuthOptions(a *meta.ResourceAttribute) (client.ActionID, []client.Reso
 		return genNetworkInterfaceResource(a)
 	case meta.Eip:
 		return genEipResource(a)
+	case meta.LoadBalancer:
+		return genLoadBalancerResource(a)
+	case meta.DiskSnapshot:
+		return genDiskSnapshotResource(a)
 	case meta.CloudResource:
 		return genCloudResResource(a)
 	case meta.Quota:
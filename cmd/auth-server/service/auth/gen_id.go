@@ -434,6 +434,16 @@ func genEipResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resour
 	}
 }
 
+// genLoadBalancerResource generate load balancer related iam resource.
+func genLoadBalancerResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genDiskSnapshotResource generate disk snapshot related iam resource.
+func genDiskSnapshotResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
 // genCloudResResource generate all cloud resource related iam resource.
 func genCloudResResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
 	res := client.Resource{
@@ -49,7 +49,8 @@ func WaitTaskToEnd(kt *kit.Kit, cli *taskserver.Client, id string) error {
 			return err
 		}
 
-		if flow.State == enumor.FlowFailed {
+		if flow.State == enumor.FlowFailed || flow.State == enumor.FlowCompensated ||
+			flow.State == enumor.FlowCompensateFailed {
 			// 临时方案，选取一个错误当作错误原因
 			req := &core.ListReq{
 				Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
@@ -78,6 +79,10 @@ func WaitTaskToEnd(kt *kit.Kit, cli *taskserver.Client, id string) error {
 			return nil
 		}
 
+		if flow.State == enumor.FlowCancel {
+			return fmt.Errorf("async task: %s has been canceled", id)
+		}
+
 		time.Sleep(500 * time.Millisecond)
 	}
 }
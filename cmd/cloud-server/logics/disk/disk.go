@@ -47,7 +47,8 @@ import (
 type Interface interface {
 	DetachDisk(kt *kit.Kit, vendor enumor.Vendor, cvmID, diskID string) error
 	DeleteDisk(kt *kit.Kit, vendor enumor.Vendor, diskID string) error
-	DeleteRecycledDisk(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo) (*core.BatchOperateResult, error)
+	DeleteRecycledDisk(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo,
+		snapshotRecords map[string]string) (*DeleteRecycledDiskResult, error)
 
 	BatchGetDiskInfo(kt *kit.Kit, cvmDetail map[string]*recycle.CvmDetail) (err error)
 	BatchDetach(kt *kit.Kit, cvmRecycleMap map[string]*recycle.CvmDetail) (failed []string, err error)
@@ -134,9 +135,17 @@ func (d *disk) DeleteDisk(kt *kit.Kit, vendor enumor.Vendor, diskID string) erro
 	}
 }
 
-// DeleteRecycledDisk batch delete recycled disk.
-func (d *disk) DeleteRecycledDisk(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo) (
-	*core.BatchOperateResult, error) {
+// DeleteRecycledDiskResult delete recycled disk result.
+type DeleteRecycledDiskResult struct {
+	core.BatchOperateResult
+	// Waiting 等待保留的快照可用，暂未销毁的云盘ID
+	Waiting []string
+}
+
+// DeleteRecycledDisk batch delete recycled disk. snapshotRecords 为需要保留快照的云盘ID到回收记录ID的映射，
+// 这些云盘销毁前先为云盘创建快照，快照可用前不销毁云盘，返回结果中记录为等待中。
+func (d *disk) DeleteRecycledDisk(kt *kit.Kit, basicInfoMap map[string]types.CloudResourceBasicInfo,
+	snapshotRecords map[string]string) (*DeleteRecycledDiskResult, error) {
 
 	if len(basicInfoMap) == 0 {
 		return nil, nil
@@ -166,11 +175,24 @@ func (d *disk) DeleteRecycledDisk(kt *kit.Kit, basicInfoMap map[string]types.Clo
 		return nil, errf.New(errf.InvalidParameter, "recycled disk is attached, cannot be deleted")
 	}
 
-	res := new(core.BatchOperateResult)
+	res := new(DeleteRecycledDiskResult)
 
 	// delete disk
 	for _, id := range ids {
 		info := basicInfoMap[id]
+		if recordID, exists := snapshotRecords[id]; exists {
+			available, err := d.keepRecycleSnapshot(kt, info, recordID)
+			if err != nil {
+				res.Failed = &core.FailedInfo{ID: id, Error: err}
+				return res, err
+			}
+
+			if !available {
+				res.Waiting = append(res.Waiting, id)
+				continue
+			}
+		}
+
 		err = d.DeleteDisk(kt, info.Vendor, id)
 		if err != nil {
 			res.Failed = &core.FailedInfo{ID: id, Error: err}
@@ -179,7 +201,7 @@ func (d *disk) DeleteRecycledDisk(kt *kit.Kit, basicInfoMap map[string]types.Clo
 		res.Succeeded = append(res.Succeeded, id)
 	}
 
-	return nil, nil
+	return res, nil
 }
 
 func (d *disk) fillAwsDisks(kt *kit.Kit, cvmDetails []*recycle.CvmDetail) error {
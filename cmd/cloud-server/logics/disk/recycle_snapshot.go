@@ -0,0 +1,230 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	recyclerecord "hcm/pkg/api/core/recycle-record"
+	dsrecord "hcm/pkg/api/data-service/recycle-record"
+	hcsnapshot "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/times"
+)
+
+// snapshotAvailableStatus 各云厂商快照可用的状态
+var snapshotAvailableStatus = map[enumor.Vendor]string{
+	enumor.TCloud: "NORMAL",
+	enumor.Aws:    "completed",
+	enumor.HuaWei: "available",
+	enumor.Gcp:    "READY",
+	enumor.Azure:  "Succeeded",
+}
+
+// snapshotFailedStatus 各云厂商快照创建失败的状态
+var snapshotFailedStatus = map[enumor.Vendor]string{
+	enumor.Aws:    "error",
+	enumor.HuaWei: "error",
+	enumor.Gcp:    "FAILED",
+	enumor.Azure:  "Failed",
+}
+
+// keepRecycleSnapshot 销毁云盘前为云盘保留快照，返回快照是否已经可用。创建的快照ID记录在回收记录中，并将回收时间
+// 设置为当前时间，由定时回收任务在快照可用后销毁云盘。快照未可用时不等待，再次回收时复用回收记录中记录的该云盘的快照，
+// 不再重复创建。
+func (d *disk) keepRecycleSnapshot(kt *kit.Kit, info types.CloudResourceBasicInfo, recordID string) (bool, error) {
+	record, err := d.getRecycleRecord(kt, recordID)
+	if err != nil {
+		return false, err
+	}
+
+	opt, err := recyclerecord.ParseDiskRecycleOptions(record.Detail)
+	if err != nil {
+		logs.Errorf("parse disk recycle options failed, err: %v, record: %s, rid: %s", err, recordID, kt.Rid)
+		return false, err
+	}
+
+	if len(opt.SnapshotID) != 0 {
+		snapshot, err := d.getDiskSnapshot(kt, info.ID, opt.SnapshotID)
+		if err != nil {
+			return false, err
+		}
+		if snapshot != nil {
+			return d.isSnapshotAvailable(kt, snapshot)
+		}
+	}
+
+	snapshotID, err := d.createRecycleSnapshot(kt, info.Vendor, info.ID)
+	if err != nil {
+		return false, err
+	}
+
+	updateReq := &dsrecord.BatchUpdateReq{Data: []dsrecord.UpdateReq{{
+		ID:         recordID,
+		Detail:     recyclerecord.DiskRecycleOptions{KeepSnapshot: true, SnapshotID: snapshotID},
+		RecycledAt: times.ConvStdTimeFormat(times.ConvStdTimeNow()),
+	}}}
+	if err = d.client.DataService().Global.RecycleRecord.BatchUpdateRecycleRecord(kt, updateReq); err != nil {
+		logs.Errorf("record recycle snapshot failed, err: %v, record: %s, snapshot: %s, rid: %s", err,
+			recordID, snapshotID, kt.Rid)
+		return false, err
+	}
+
+	return false, nil
+}
+
+// isSnapshotAvailable 判断快照是否可用，快照创建中时同步一次快照以刷新快照状态，快照创建失败时返回错误。
+func (d *disk) isSnapshotAvailable(kt *kit.Kit, snapshot *coresnapshot.BaseDiskSnapshot) (bool, error) {
+	if snapshot.Status != snapshotAvailableStatus[snapshot.Vendor] {
+		if err := d.syncDiskSnapshot(kt, snapshot); err != nil {
+			logs.Errorf("sync recycle snapshot failed, err: %v, snapshot: %s, rid: %s", err, snapshot.ID, kt.Rid)
+			return false, err
+		}
+
+		synced, err := d.getDiskSnapshot(kt, snapshot.DiskID, snapshot.ID)
+		if err != nil {
+			return false, err
+		}
+
+		if synced == nil {
+			return false, fmt.Errorf("recycle snapshot: %s of disk: %s not found", snapshot.ID, snapshot.DiskID)
+		}
+		snapshot = synced
+	}
+
+	if failed, exists := snapshotFailedStatus[snapshot.Vendor]; exists && snapshot.Status == failed {
+		return false, fmt.Errorf("recycle snapshot: %s of disk: %s is %s", snapshot.ID, snapshot.DiskID,
+			snapshot.Status)
+	}
+
+	return snapshot.Status == snapshotAvailableStatus[snapshot.Vendor], nil
+}
+
+func (d *disk) getRecycleRecord(kt *kit.Kit, recordID string) (*recyclerecord.RecycleRecord, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", recordID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	res, err := d.client.DataService().Global.RecycleRecord.ListRecycleRecord(kt, req)
+	if err != nil {
+		logs.Errorf("list recycle record failed, err: %v, id: %s, rid: %s", err, recordID, kt.Rid)
+		return nil, err
+	}
+
+	if len(res.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "recycle record: %s not found", recordID)
+	}
+
+	return &res.Details[0], nil
+}
+
+// getDiskSnapshot 按照云盘ID和快照ID查询快照，快照不存在时返回nil
+func (d *disk) getDiskSnapshot(kt *kit.Kit, diskID, snapshotID string) (*coresnapshot.BaseDiskSnapshot, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
+			"id":      snapshotID,
+			"disk_id": diskID,
+		}),
+		Page: core.NewDefaultBasePage(),
+	}
+	res, err := d.client.DataService().Global.DiskSnapshot.List(kt, req)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, disk: %s, snapshot: %s, rid: %s", err, diskID,
+			snapshotID, kt.Rid)
+		return nil, err
+	}
+
+	if len(res.Details) == 0 {
+		return nil, nil
+	}
+
+	return &res.Details[0], nil
+}
+
+// createRecycleSnapshot 销毁云盘前为云盘创建快照，快照名称为 recycle-{云盘ID}-{时间}，返回快照ID。
+func (d *disk) createRecycleSnapshot(kt *kit.Kit, vendor enumor.Vendor, diskID string) (string, error) {
+	req := &hcsnapshot.DiskSnapshotCreateReq{
+		DiskID: diskID,
+		Name:   fmt.Sprintf("recycle-%s-%s", diskID, time.Now().Format("20060102150405")),
+	}
+
+	var result *core.CreateResult
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		result, err = d.client.HCService().TCloud.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	case enumor.Aws:
+		result, err = d.client.HCService().Aws.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	case enumor.HuaWei:
+		result, err = d.client.HCService().HuaWei.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	case enumor.Gcp:
+		result, err = d.client.HCService().Gcp.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	case enumor.Azure:
+		result, err = d.client.HCService().Azure.DiskSnapshot.CreateDiskSnapshot(kt, req)
+	default:
+		return "", errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", vendor))
+	}
+	if err != nil {
+		logs.Errorf("create snapshot before deleting disk failed, err: %v, disk: %s, rid: %s", err, diskID, kt.Rid)
+		return "", err
+	}
+
+	return result.ID, nil
+}
+
+// syncDiskSnapshot 同步快照所在账号和地域下的快照
+func (d *disk) syncDiskSnapshot(kt *kit.Kit, snapshot *coresnapshot.BaseDiskSnapshot) error {
+	switch snapshot.Vendor {
+	case enumor.TCloud:
+		req := &sync.TCloudSyncReq{AccountID: snapshot.AccountID, Region: snapshot.Region}
+		return d.client.HCService().TCloud.DiskSnapshot.SyncDiskSnapshot(kt, req)
+	case enumor.Aws:
+		req := &sync.AwsSyncReq{AccountID: snapshot.AccountID, Region: snapshot.Region}
+		return d.client.HCService().Aws.DiskSnapshot.SyncDiskSnapshot(kt, req)
+	case enumor.HuaWei:
+		req := &sync.HuaWeiSyncReq{AccountID: snapshot.AccountID, Region: snapshot.Region}
+		return d.client.HCService().HuaWei.DiskSnapshot.SyncDiskSnapshot(kt, req)
+	case enumor.Gcp:
+		req := &sync.GcpGlobalSyncReq{AccountID: snapshot.AccountID}
+		return d.client.HCService().Gcp.DiskSnapshot.SyncDiskSnapshot(kt, req)
+	case enumor.Azure:
+		ext, err := d.client.DataService().Azure.DiskSnapshot.Get(kt, snapshot.ID)
+		if err != nil {
+			return err
+		}
+		if ext.Extension == nil {
+			return fmt.Errorf("azure snapshot: %s extension is empty", snapshot.ID)
+		}
+		req := &sync.AzureSyncReq{AccountID: snapshot.AccountID, ResourceGroupName: ext.Extension.ResourceGroupName}
+		return d.client.HCService().Azure.DiskSnapshot.SyncDiskSnapshot(kt, req)
+	default:
+		return errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("no support vendor: %s", snapshot.Vendor))
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"net/http"
+	"testing"
+
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	faketcloud "hcm/pkg/adaptor/fake/tcloud"
+	typedisk "hcm/pkg/adaptor/types/disk"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	recyclerecord "hcm/pkg/api/core/recycle-record"
+	dataservice "hcm/pkg/api/data-service"
+	hcsnapshot "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/cc"
+	fakeclient "hcm/pkg/client/fake"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/json"
+)
+
+const (
+	testAccountID = "account"
+	testRegion    = "ap-guangzhou"
+)
+
+// recycleSnapshotEnv 回收快照的测试环境，hc-service的快照创建和同步接口由模拟的腾讯云实现
+type recycleSnapshotEnv struct {
+	cloud       *faketcloud.FakeTCloud
+	dataServer  *fakeclient.Server
+	hcServer    *fakeclient.Server
+	disk        *disk
+	createCount int
+	syncCount   int
+}
+
+func newRecycleSnapshotEnv(t *testing.T) *recycleSnapshotEnv {
+	t.Helper()
+
+	cloud, err := faketcloud.New(faketcloud.Option{})
+	if err != nil {
+		t.Fatalf("new fake tcloud failed, err: %v", err)
+	}
+
+	env := &recycleSnapshotEnv{
+		cloud:      cloud,
+		dataServer: fakeclient.NewServer(fakeclient.DataServicePrefix),
+		hcServer:   fakeclient.NewServer(fakeclient.HCServicePrefix),
+	}
+	cli := fakeclient.NewClientSet(map[cc.Name]http.Handler{
+		cc.DataServiceName: env.dataServer,
+		cc.HCServiceName:   env.hcServer,
+	})
+	env.disk = &disk{client: cli}
+
+	// 创建快照时云上快照立即可用，db中的快照状态在同步前仍为创建中，用于验证快照可用前不销毁云盘
+	env.hcServer.Handle(http.MethodPost, "/vendors/tcloud/disk_snapshots/create", func(body []byte) (interface{},
+		error) {
+
+		env.createCount++
+		req := new(hcsnapshot.DiskSnapshotCreateReq)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+
+		diskInfo, err := cli.DataService().TCloud.RetrieveDisk(kit.New().Ctx, nil, req.DiskID)
+		if err != nil {
+			return nil, err
+		}
+
+		cloudID, err := cloud.CreateSnapshot(kit.New(), &typesnapshot.TCloudCreateOption{Region: diskInfo.Region,
+			CloudDiskID: diskInfo.CloudID, Name: &req.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		ids, err := env.dataServer.Add("disk_snapshots", coresnapshot.BaseDiskSnapshot{CloudID: cloudID,
+			Vendor: enumor.TCloud, AccountID: diskInfo.AccountID, Region: diskInfo.Region, DiskID: diskInfo.ID,
+			CloudDiskID: diskInfo.CloudID, Status: "CREATING"})
+		if err != nil {
+			return nil, err
+		}
+
+		return core.CreateResult{ID: ids[0]}, nil
+	})
+
+	env.hcServer.Handle(http.MethodPost, "/vendors/tcloud/disk_snapshots/sync", func([]byte) (interface{}, error) {
+		env.syncCount++
+		snapshots := make([]coresnapshot.BaseDiskSnapshot, 0)
+		if err := env.dataServer.List("disk_snapshots", &snapshots); err != nil {
+			return nil, err
+		}
+
+		params := &synctcloud.SyncBaseParams{AccountID: testAccountID, Region: testRegion}
+		for _, one := range snapshots {
+			params.CloudIDs = append(params.CloudIDs, one.CloudID)
+		}
+		syncCli := synctcloud.NewClient(cli.DataService(), cloud)
+		_, err := syncCli.DiskSnapshot(kit.New(), params, new(synctcloud.SyncDiskSnapshotOption))
+		return nil, err
+	})
+
+	return env
+}
+
+// addRecycledDisk 云上创建云盘并记录到db，同时创建保留快照的云盘回收记录，返回云盘基础信息和回收记录ID
+func (env *recycleSnapshotEnv) addRecycledDisk(t *testing.T) (types.CloudResourceBasicInfo, string) {
+	t.Helper()
+
+	result, err := env.cloud.CreateDisk(kit.New(), &typedisk.TCloudDiskCreateOption{Region: testRegion,
+		Zone: testRegion + "-1", DiskType: "CLOUD_PREMIUM", DiskSize: converter.ValToPtr(uint64(20)),
+		DiskChargeType: "POSTPAID_BY_HOUR"})
+	if err != nil {
+		t.Fatalf("create disk failed, err: %v", err)
+	}
+
+	diskIDs, err := env.dataServer.Add("disks", map[string]interface{}{"cloud_id": result.SuccessCloudIDs[0],
+		"vendor": enumor.TCloud, "account_id": testAccountID, "region": testRegion, "bk_biz_id": 1})
+	if err != nil {
+		t.Fatalf("add disk failed, err: %v", err)
+	}
+
+	recordIDs, err := env.dataServer.Add("recycle_records", recyclerecord.RecycleRecord{
+		BaseRecycleRecord: recyclerecord.BaseRecycleRecord{Vendor: enumor.TCloud, ResType: enumor.DiskCloudResType,
+			ResID: diskIDs[0], AccountID: testAccountID, Region: testRegion},
+		Detail: recyclerecord.DiskRecycleOptions{KeepSnapshot: true},
+	})
+	if err != nil {
+		t.Fatalf("add recycle record failed, err: %v", err)
+	}
+
+	info := types.CloudResourceBasicInfo{ID: diskIDs[0], Vendor: enumor.TCloud, AccountID: testAccountID,
+		Region: testRegion}
+	return info, recordIDs[0]
+}
+
+func (env *recycleSnapshotEnv) getRecordSnapshotID(t *testing.T, recordID string) string {
+	t.Helper()
+
+	record, err := env.disk.getRecycleRecord(kit.New(), recordID)
+	if err != nil {
+		t.Fatalf("get recycle record failed, err: %v", err)
+	}
+
+	opt, err := recyclerecord.ParseDiskRecycleOptions(record.Detail)
+	if err != nil {
+		t.Fatalf("parse disk recycle options failed, err: %v", err)
+	}
+
+	if !opt.KeepSnapshot {
+		t.Errorf("recycle record should keep snapshot, detail: %+v", opt)
+	}
+	return opt.SnapshotID
+}
+
+func TestKeepRecycleSnapshot(t *testing.T) {
+	kt := kit.New()
+	env := newRecycleSnapshotEnv(t)
+	info, recordID := env.addRecycledDisk(t)
+
+	// 首次回收时创建快照并记录到回收记录，不等待快照可用，由定时回收任务处理该回收记录
+	available, err := env.disk.keepRecycleSnapshot(kt, info, recordID)
+	if err != nil {
+		t.Fatalf("keep recycle snapshot failed, err: %v", err)
+	}
+	if available || env.createCount != 1 || env.syncCount != 0 {
+		t.Errorf("snapshot should be created without waiting, available: %v, create: %d, sync: %d", available,
+			env.createCount, env.syncCount)
+	}
+
+	snapshotID := env.getRecordSnapshotID(t, recordID)
+	record, err := env.disk.getRecycleRecord(kt, recordID)
+	if err != nil {
+		t.Fatalf("get recycle record failed, err: %v", err)
+	}
+	if len(record.RecycledAt) == 0 {
+		t.Errorf("recycle record should be recycled by timing task after snapshot created")
+	}
+
+	// 回收重试时复用回收记录中的快照，同步一次快照状态后快照可用
+	if available, err = env.disk.keepRecycleSnapshot(kt, info, recordID); err != nil {
+		t.Fatalf("retry keep recycle snapshot failed, err: %v", err)
+	}
+	if !available || env.createCount != 1 || env.syncCount != 1 ||
+		env.dataServer.Count("disk_snapshots") != 1 {
+
+		t.Errorf("recycle snapshot should be reused and available, available: %v, create: %d, sync: %d, "+
+			"snapshot count: %d", available, env.createCount, env.syncCount, env.dataServer.Count("disk_snapshots"))
+	}
+
+	snapshot, err := env.disk.getDiskSnapshot(kt, info.ID, snapshotID)
+	if err != nil || snapshot == nil {
+		t.Fatalf("recycle snapshot is not found, err: %v", err)
+	}
+	if snapshot.Status != typesnapshot.TCloudSnapshotNormal {
+		t.Errorf("recycle snapshot should be available, status: %s", snapshot.Status)
+	}
+
+	// 快照已经可用时不再同步快照
+	if available, err = env.disk.keepRecycleSnapshot(kt, info, recordID); err != nil || !available {
+		t.Fatalf("keep available recycle snapshot failed, available: %v, err: %v", available, err)
+	}
+	if env.syncCount != 1 {
+		t.Errorf("available recycle snapshot should not be synced, sync: %d", env.syncCount)
+	}
+
+	// 回收记录中的快照已被删除时，重新创建快照并更新回收记录
+	deleteReq := &dataservice.BatchDeleteReq{Filter: tools.EqualExpression("id", snapshotID)}
+	if err = env.disk.client.DataService().Global.DiskSnapshot.BatchDelete(kt, deleteReq); err != nil {
+		t.Fatalf("delete recycle snapshot failed, err: %v", err)
+	}
+	if available, err = env.disk.keepRecycleSnapshot(kt, info, recordID); err != nil || available {
+		t.Fatalf("keep recycle snapshot after snapshot deleted failed, available: %v, err: %v", available, err)
+	}
+	newSnapshotID := env.getRecordSnapshotID(t, recordID)
+	if env.createCount != 2 || len(newSnapshotID) == 0 || newSnapshotID == snapshotID {
+		t.Errorf("recycle snapshot should be recreated, create: %d, snapshot: %s", env.createCount, newSnapshotID)
+	}
+}
@@ -19,7 +19,10 @@
 
 package aws
 
-import logicsaccount "hcm/cmd/cloud-server/logics/account"
+import (
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	"hcm/cmd/cloud-server/service/common"
+)
 
 // CheckReq ...
 func (a *ApplicationOfCreateAwsDisk) CheckReq() error {
@@ -31,5 +34,11 @@ func (a *ApplicationOfCreateAwsDisk) CheckReq() error {
 		return err
 	}
 
+	_, err := common.ValidateDiskSourceSnapshot(a.Cts.Kit, a.Client.DataService(), a.req.AccountID, a.req.BkBizID,
+		a.req.SnapshotID)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -102,7 +102,7 @@ func (svc *svc) AssignResourceToBiz(cts *rest.Contexts) (interface{}, error) {
 		req.ResTypes = []enumor.CloudResourceType{enumor.CvmCloudResType, enumor.DiskCloudResType,
 			enumor.EipCloudResType, enumor.NetworkInterfaceCloudResType, enumor.SecurityGroupCloudResType,
 			enumor.GcpFirewallRuleCloudResType, enumor.VpcCloudResType, enumor.SubnetCloudResType,
-			enumor.RouteTableCloudResType}
+			enumor.RouteTableCloudResType, enumor.LoadBalancerCloudResType, enumor.DiskSnapshotCloudResType}
 	}
 
 	// check if all vpc has cloud area id
@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ValidateDiskSourceSnapshot 校验用于创建云盘的快照的归属，快照需与云盘属于同一账号，快照已分配业务时需与云盘属于
+// 同一业务。返回快照的基础信息用于鉴权，未指定快照时返回nil
+func ValidateDiskSourceSnapshot(kt *kit.Kit, cli *dataservice.Client, accountID string, bizID int64,
+	snapshotID *string) (*types.CloudResourceBasicInfo, error) {
+
+	if snapshotID == nil || len(*snapshotID) == 0 {
+		return nil, nil
+	}
+
+	info, err := cli.Global.Cloud.GetResBasicInfo(kt, enumor.DiskSnapshotCloudResType, *snapshotID)
+	if err != nil {
+		logs.Errorf("get disk snapshot basic info failed, err: %v, id: %s, rid: %s", err, *snapshotID, kt.Rid)
+		return nil, err
+	}
+
+	if info.AccountID != accountID {
+		return nil, errf.Newf(errf.InvalidParameter, "snapshot: %s not belongs to account: %s", *snapshotID,
+			accountID)
+	}
+
+	if info.BkBizID != constant.UnassignedBiz && info.BkBizID != 0 && info.BkBizID != bizID {
+		return nil, errf.Newf(errf.InvalidParameter, "snapshot: %s not belongs to biz: %d", *snapshotID, bizID)
+	}
+
+	return info, nil
+}
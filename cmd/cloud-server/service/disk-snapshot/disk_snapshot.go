@@ -0,0 +1,385 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+
+	cssnapshot "hcm/pkg/api/cloud-server/disk-snapshot"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	dssnapshot "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	hcsnapshot "hcm/pkg/api/hc-service/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// ListDiskSnapshot list disk snapshot.
+func (svc *snapshotSvc) ListDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.listDiskSnapshot(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizDiskSnapshot list biz disk snapshot.
+func (svc *snapshotSvc) ListBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.listDiskSnapshot(cts, handler.ListBizAuthRes)
+}
+
+func (svc *snapshotSvc) listDiskSnapshot(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.DiskSnapshot, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &cssnapshot.DiskSnapshotListResult{Count: 0, Details: make([]cssnapshot.DiskSnapshotResult, 0)}, nil
+	}
+	req.Filter = expr
+	if req.Filter == nil {
+		req.Filter = tools.AllExpression()
+	}
+
+	result, err := svc.client.DataService().Global.DiskSnapshot.List(cts.Kit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]cssnapshot.DiskSnapshotResult, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, cssnapshot.DiskSnapshotResult{
+			BaseDiskSnapshot: one,
+			Cost:             snapshotCost(one.Vendor, one.Size),
+		})
+	}
+
+	return &cssnapshot.DiskSnapshotListResult{Count: result.Count, Details: details}, nil
+}
+
+// GetDiskSnapshot get disk snapshot.
+func (svc *snapshotSvc) GetDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.getDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// GetBizDiskSnapshot get biz disk snapshot.
+func (svc *snapshotSvc) GetBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.getDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) getDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.DiskSnapshotCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.DiskSnapshot,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return withCost(svc.client.DataService().TCloud.DiskSnapshot.Get(cts.Kit, id))
+	case enumor.Aws:
+		return withCost(svc.client.DataService().Aws.DiskSnapshot.Get(cts.Kit, id))
+	case enumor.Azure:
+		return withCost(svc.client.DataService().Azure.DiskSnapshot.Get(cts.Kit, id))
+	case enumor.Gcp:
+		return withCost(svc.client.DataService().Gcp.DiskSnapshot.Get(cts.Kit, id))
+	case enumor.HuaWei:
+		return withCost(svc.client.DataService().HuaWei.DiskSnapshot.Get(cts.Kit, id))
+	default:
+		return nil, errf.Newf(errf.Unknown, "id: %s vendor: %s not support", id, basicInfo.Vendor)
+	}
+}
+
+// withCost 为快照详情补充快照费用
+func withCost[Ext coresnapshot.Extension](snapshot *coresnapshot.DiskSnapshot[Ext], err error) (
+	*cssnapshot.DiskSnapshotExtResult[Ext], error) {
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cssnapshot.DiskSnapshotExtResult[Ext]{
+		DiskSnapshot: *snapshot,
+		Cost:         snapshotCost(snapshot.Vendor, snapshot.Size),
+	}, nil
+}
+
+// CreateDiskSnapshot create disk snapshot.
+func (svc *snapshotSvc) CreateDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// CreateBizDiskSnapshot create biz disk snapshot.
+func (svc *snapshotSvc) CreateBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) createDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cssnapshot.DiskSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 快照归属于源云盘的账号和业务，因此按源云盘校验业务和鉴权
+	diskInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.DiskCloudResType,
+		req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.DiskSnapshot,
+		Action: meta.Create, BasicInfo: diskInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &hcsnapshot.DiskSnapshotCreateReq{DiskID: req.DiskID, Name: req.Name, Memo: req.Memo}
+	switch diskInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.HCService().TCloud.DiskSnapshot.CreateDiskSnapshot(cts.Kit, createReq)
+	case enumor.Aws:
+		return svc.client.HCService().Aws.DiskSnapshot.CreateDiskSnapshot(cts.Kit, createReq)
+	case enumor.Azure:
+		return svc.client.HCService().Azure.DiskSnapshot.CreateDiskSnapshot(cts.Kit, createReq)
+	case enumor.Gcp:
+		return svc.client.HCService().Gcp.DiskSnapshot.CreateDiskSnapshot(cts.Kit, createReq)
+	case enumor.HuaWei:
+		return svc.client.HCService().HuaWei.DiskSnapshot.CreateDiskSnapshot(cts.Kit, createReq)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", diskInfo.Vendor)
+	}
+}
+
+// BatchDeleteDiskSnapshot batch delete disk snapshot.
+func (svc *snapshotSvc) BatchDeleteDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteDiskSnapshot(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizDiskSnapshot batch delete biz disk snapshot.
+func (svc *snapshotSvc) BatchDeleteBizDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteDiskSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) batchDeleteDiskSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.DiskSnapshotCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.DiskSnapshot,
+		Action: meta.Delete, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create delete audit.
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.DiskSnapshotAuditResType, req.IDs); err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 快照按账号调用hc-service删除
+	accountIDsMap := make(map[string][]string)
+	vendorMap := make(map[string]enumor.Vendor)
+	for _, id := range req.IDs {
+		info, exist := basicInfoMap[id]
+		if !exist {
+			return nil, errf.Newf(errf.InvalidParameter, "disk snapshot: %s not found", id)
+		}
+
+		accountIDsMap[info.AccountID] = append(accountIDsMap[info.AccountID], id)
+		vendorMap[info.AccountID] = info.Vendor
+	}
+
+	for accountID, ids := range accountIDsMap {
+		for _, partIDs := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+			if err = svc.deleteDiskSnapshot(cts.Kit, vendorMap[accountID], accountID, partIDs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (svc *snapshotSvc) deleteDiskSnapshot(kt *kit.Kit, vendor enumor.Vendor, accountID string,
+	ids []string) error {
+
+	delReq := &hcsnapshot.DiskSnapshotBatchDeleteReq{AccountID: accountID, IDs: ids}
+
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		err = svc.client.HCService().TCloud.DiskSnapshot.BatchDeleteDiskSnapshot(kt, delReq)
+	case enumor.Aws:
+		err = svc.client.HCService().Aws.DiskSnapshot.BatchDeleteDiskSnapshot(kt, delReq)
+	case enumor.Azure:
+		err = svc.client.HCService().Azure.DiskSnapshot.BatchDeleteDiskSnapshot(kt, delReq)
+	case enumor.Gcp:
+		err = svc.client.HCService().Gcp.DiskSnapshot.BatchDeleteDiskSnapshot(kt, delReq)
+	case enumor.HuaWei:
+		err = svc.client.HCService().HuaWei.DiskSnapshot.BatchDeleteDiskSnapshot(kt, delReq)
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support", vendor)
+	}
+	if err != nil {
+		logs.Errorf("[%s] request hc-service to delete disk snapshot failed, err: %v, ids: %v, rid: %s", vendor,
+			err, ids, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// AssignDiskSnapshotToBiz assign disk snapshot to biz.
+func (svc *snapshotSvc) AssignDiskSnapshotToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(cssnapshot.AssignDiskSnapshotToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// authorize
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.DiskSnapshotCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.DiskSnapshot,
+			Action: meta.Assign, ResourceID: info.AccountID}, BizID: req.BkBizID})
+	}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	// 已分配业务的快照不允许重复分配
+	if err = svc.checkDiskSnapshotsInBiz(cts.Kit, req.IDs, constant.UnassignedBiz); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// create assign audit.
+	err = svc.audit.ResBizAssignAudit(cts.Kit, enumor.DiskSnapshotAuditResType, req.IDs, req.BkBizID)
+	if err != nil {
+		logs.Errorf("create assign audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	items := make([]dssnapshot.DiskSnapshotUpdateField, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		items = append(items, dssnapshot.DiskSnapshotUpdateField{ID: id, BkBizID: req.BkBizID})
+	}
+	updateReq := &dssnapshot.DiskSnapshotBatchUpdateReq{Items: items}
+	if err = svc.client.DataService().Global.DiskSnapshot.BatchUpdate(cts.Kit, updateReq); err != nil {
+		logs.Errorf("assign disk snapshot to biz failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *snapshotSvc) checkDiskSnapshotsInBiz(kt *kit.Kit, ids []string, bizID int64) error {
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: ids},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: bizID},
+			},
+		},
+		Page: core.NewCountPage(),
+	}
+	result, err := svc.client.DataService().Global.DiskSnapshot.List(kt, req)
+	if err != nil {
+		logs.Errorf("count disk snapshots that are not in biz failed, err: %v, req: %+v, rid: %s", err, req,
+			kt.Rid)
+		return err
+	}
+
+	if result.Count != 0 {
+		return fmt.Errorf("%d disk snapshots are already assigned", result.Count)
+	}
+
+	return nil
+}
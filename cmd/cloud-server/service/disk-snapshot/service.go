@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot defines disk snapshot service.
+package disksnapshot
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitDiskSnapshotService initialize the disk snapshot service.
+func InitDiskSnapshotService(c *capability.Capability) {
+	svc := &snapshotSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListDiskSnapshot", http.MethodPost, "/disk_snapshots/list", svc.ListDiskSnapshot)
+	h.Add("GetDiskSnapshot", http.MethodGet, "/disk_snapshots/{id}", svc.GetDiskSnapshot)
+	h.Add("CreateDiskSnapshot", http.MethodPost, "/disk_snapshots/create", svc.CreateDiskSnapshot)
+	h.Add("BatchDeleteDiskSnapshot", http.MethodDelete, "/disk_snapshots/batch", svc.BatchDeleteDiskSnapshot)
+	h.Add("AssignDiskSnapshotToBiz", http.MethodPost, "/disk_snapshots/assign/bizs", svc.AssignDiskSnapshotToBiz)
+
+	// disk snapshot apis in biz
+	h.Add("ListBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/list", svc.ListBizDiskSnapshot)
+	h.Add("GetBizDiskSnapshot", http.MethodGet, "/bizs/{bk_biz_id}/disk_snapshots/{id}", svc.GetBizDiskSnapshot)
+	h.Add("CreateBizDiskSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/disk_snapshots/create",
+		svc.CreateBizDiskSnapshot)
+	h.Add("BatchDeleteBizDiskSnapshot", http.MethodDelete, "/bizs/{bk_biz_id}/disk_snapshots/batch",
+		svc.BatchDeleteBizDiskSnapshot)
+
+	h.Load(c.WebService)
+}
+
+type snapshotSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
+
+// snapshotCost 计算快照费用，费用为快照大小(GB)与所属云厂商快照单价的乘积，单价未配置时费用为0。
+func snapshotCost(vendor enumor.Vendor, size uint64) float64 {
+	return float64(size) * cc.CloudServer().DiskSnapshot.UnitPrice[string(vendor)]
+}
@@ -27,9 +27,9 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/meta"
-	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
 )
 
 // CreateDisk create disk.
@@ -55,21 +55,22 @@ func (svc *diskSvc) CreateDisk(cts *rest.Contexts) (interface{}, error) {
 
 	switch info.Vendor {
 	case enumor.TCloud:
-		return svc.createTCloudDisk(cts.Kit, req.Data)
+		return svc.createTCloudDisk(cts, req.Data)
 	case enumor.Aws:
-		return svc.createAwsDisk(cts.Kit, req.Data)
+		return svc.createAwsDisk(cts, req.Data)
 	case enumor.HuaWei:
-		return svc.createHuaWeiDisk(cts.Kit, req.Data)
+		return svc.createHuaWeiDisk(cts, req.Data)
 	case enumor.Gcp:
-		return svc.createGcpDisk(cts.Kit, req.Data)
+		return svc.createGcpDisk(cts, req.Data)
 	case enumor.Azure:
-		return svc.createAzureDisk(cts.Kit, req.Data)
+		return svc.createAzureDisk(cts, req.Data)
 	default:
 		return nil, fmt.Errorf("vendor: %s not support", info.Vendor)
 	}
 }
 
-func (svc *diskSvc) createTCloudDisk(kt *kit.Kit, body json.RawMessage) (interface{}, error) {
+func (svc *diskSvc) createTCloudDisk(cts *rest.Contexts, body json.RawMessage) (interface{}, error) {
+	kt := cts.Kit
 	req := new(csdisk.TCloudDiskCreateReq)
 	if err := json.Unmarshal(body, req); err != nil {
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
@@ -79,6 +80,10 @@ func (svc *diskSvc) createTCloudDisk(kt *kit.Kit, body json.RawMessage) (interfa
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := svc.authSourceSnapshot(cts, req.AccountID, req.BkBizID, req.SnapshotID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().TCloud.Disk.CreateDisk(kt.Ctx, kt.Header(),
 		common.ConvTCloudDiskCreateReq(req))
 	if err != nil {
@@ -89,7 +94,8 @@ func (svc *diskSvc) createTCloudDisk(kt *kit.Kit, body json.RawMessage) (interfa
 	return result, nil
 }
 
-func (svc *diskSvc) createHuaWeiDisk(kt *kit.Kit, body json.RawMessage) (interface{}, error) {
+func (svc *diskSvc) createHuaWeiDisk(cts *rest.Contexts, body json.RawMessage) (interface{}, error) {
+	kt := cts.Kit
 	req := new(csdisk.HuaWeiDiskCreateReq)
 	if err := json.Unmarshal(body, req); err != nil {
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
@@ -99,6 +105,10 @@ func (svc *diskSvc) createHuaWeiDisk(kt *kit.Kit, body json.RawMessage) (interfa
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := svc.authSourceSnapshot(cts, req.AccountID, req.BkBizID, req.SnapshotID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().HuaWei.Disk.CreateDisk(kt.Ctx, kt.Header(),
 		common.ConvHuaWeiDiskCreateReq(req))
 	if err != nil {
@@ -109,7 +119,8 @@ func (svc *diskSvc) createHuaWeiDisk(kt *kit.Kit, body json.RawMessage) (interfa
 	return nil, nil
 }
 
-func (svc *diskSvc) createAwsDisk(kt *kit.Kit, body json.RawMessage) (interface{}, error) {
+func (svc *diskSvc) createAwsDisk(cts *rest.Contexts, body json.RawMessage) (interface{}, error) {
+	kt := cts.Kit
 	req := new(csdisk.AwsDiskCreateReq)
 	if err := json.Unmarshal(body, req); err != nil {
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
@@ -119,6 +130,10 @@ func (svc *diskSvc) createAwsDisk(kt *kit.Kit, body json.RawMessage) (interface{
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := svc.authSourceSnapshot(cts, req.AccountID, req.BkBizID, req.SnapshotID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().Aws.Disk.CreateDisk(kt.Ctx, kt.Header(),
 		common.ConvAwsDiskCreateReq(req))
 	if err != nil {
@@ -129,7 +144,8 @@ func (svc *diskSvc) createAwsDisk(kt *kit.Kit, body json.RawMessage) (interface{
 	return nil, nil
 }
 
-func (svc *diskSvc) createGcpDisk(kt *kit.Kit, body json.RawMessage) (interface{}, error) {
+func (svc *diskSvc) createGcpDisk(cts *rest.Contexts, body json.RawMessage) (interface{}, error) {
+	kt := cts.Kit
 	req := new(csdisk.GcpDiskCreateReq)
 	if err := json.Unmarshal(body, req); err != nil {
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
@@ -139,6 +155,10 @@ func (svc *diskSvc) createGcpDisk(kt *kit.Kit, body json.RawMessage) (interface{
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := svc.authSourceSnapshot(cts, req.AccountID, req.BkBizID, req.SnapshotID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().Gcp.Disk.CreateDisk(kt.Ctx, kt.Header(),
 		common.ConvGcpDiskCreateReq(req))
 	if err != nil {
@@ -149,7 +169,8 @@ func (svc *diskSvc) createGcpDisk(kt *kit.Kit, body json.RawMessage) (interface{
 	return nil, nil
 }
 
-func (svc *diskSvc) createAzureDisk(kt *kit.Kit, body json.RawMessage) (interface{}, error) {
+func (svc *diskSvc) createAzureDisk(cts *rest.Contexts, body json.RawMessage) (interface{}, error) {
+	kt := cts.Kit
 	req := new(csdisk.AzureDiskCreateReq)
 	if err := json.Unmarshal(body, req); err != nil {
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
@@ -159,6 +180,10 @@ func (svc *diskSvc) createAzureDisk(kt *kit.Kit, body json.RawMessage) (interfac
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	if err := svc.authSourceSnapshot(cts, req.AccountID, req.BkBizID, req.SnapshotID); err != nil {
+		return nil, err
+	}
+
 	result, err := svc.client.HCService().Azure.Disk.CreateDisk(kt.Ctx, kt.Header(),
 		common.ConvAzureDiskCreateReq(req))
 	if err != nil {
@@ -168,3 +193,18 @@ func (svc *diskSvc) createAzureDisk(kt *kit.Kit, body json.RawMessage) (interfac
 
 	return nil, nil
 }
+
+// authSourceSnapshot 校验用于创建云盘的快照的归属，并校验快照的查看权限
+func (svc *diskSvc) authSourceSnapshot(cts *rest.Contexts, accountID string, bizID int64, snapshotID *string) error {
+	info, err := common.ValidateDiskSourceSnapshot(cts.Kit, svc.client.DataService(), accountID, bizID, snapshotID)
+	if err != nil {
+		return err
+	}
+
+	if info == nil {
+		return nil
+	}
+
+	return handler.ResOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: meta.DiskSnapshot, Action: meta.Find, BasicInfo: info})
+}
@@ -362,10 +362,11 @@ func (svc *diskSvc) batchDeleteRecycledDisk(cts *rest.Contexts,
 		return nil, err
 	}
 
-	opRet := new(core.BatchOperateResult)
+	opRet := new(csdisk.DiskDeleteRecycleResult)
 	var recycleErr error
 	for _, record := range records.Details {
-		recycleErr = svc.destroyOneRecord(cts, validHandler, record)
+		var waiting bool
+		waiting, recycleErr = svc.destroyOneRecord(cts, validHandler, record)
 		if recycleErr != nil {
 			logs.Errorf("fail to destroy disk recycle record(%s), err: %v, rid:%s", record.ID, recycleErr, cts.Kit.Rid)
 
@@ -377,6 +378,12 @@ func (svc *diskSvc) batchDeleteRecycledDisk(cts *rest.Contexts,
 			// TODO: 目前遇到错误就停止处理后面任务，转成异步任务后优化成多个错误互相不影响
 			break
 		}
+
+		// 等待保留的快照可用的回收记录保持待回收状态，由定时回收任务在快照可用后销毁云盘
+		if waiting {
+			opRet.Waiting = append(opRet.Waiting, record.ID)
+			continue
+		}
 		opRet.Succeeded = append(opRet.Succeeded, record.ID)
 	}
 	// 标记成功
@@ -386,8 +393,9 @@ func (svc *diskSvc) batchDeleteRecycledDisk(cts *rest.Contexts,
 	return opRet, recycleErr
 }
 
+// destroyOneRecord 销毁回收记录对应的云盘，返回云盘是否在等待保留的快照可用
 func (svc *diskSvc) destroyOneRecord(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
-	record corerr.RecycleRecord) error {
+	record corerr.RecycleRecord) (bool, error) {
 
 	basicInfoReq := cloud.ListResourceBasicInfoReq{
 		ResourceType: enumor.DiskCloudResType,
@@ -397,19 +405,31 @@ func (svc *diskSvc) destroyOneRecord(cts *rest.Contexts, validHandler handler.Va
 
 	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// validate biz and authorize
 	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Disk,
 		Action: meta.Destroy, BasicInfos: basicInfoMap})
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	if _, err := svc.diskLgc.DeleteRecycledDisk(cts.Kit, basicInfoMap); err != nil {
-		return err
+	opt, err := corerr.ParseDiskRecycleOptions(record.Detail)
+	if err != nil {
+		logs.Errorf("parse disk recycle options failed, err: %v, record: %s, rid: %s", err, record.ID, cts.Kit.Rid)
+		return false, err
 	}
 
-	return nil
+	snapshotRecords := make(map[string]string)
+	if opt.KeepSnapshot {
+		snapshotRecords[record.ResID] = record.ID
+	}
+
+	res, err := svc.diskLgc.DeleteRecycledDisk(cts.Kit, basicInfoMap, snapshotRecords)
+	if err != nil {
+		return false, err
+	}
+
+	return res != nil && len(res.Waiting) != 0, nil
 }
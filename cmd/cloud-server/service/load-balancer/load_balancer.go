@@ -0,0 +1,401 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	"hcm/cmd/cloud-server/service/common"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	cslb "hcm/pkg/api/cloud-server/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// ListLoadBalancer list load balancer.
+func (svc *lbSvc) ListLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLoadBalancer(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizLoadBalancer list biz load balancer.
+func (svc *lbSvc) ListBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLoadBalancer(cts, handler.ListBizAuthRes)
+}
+
+func (svc *lbSvc) listLoadBalancer(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.LoadBalancer, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &dslb.LoadBalancerListResult{Count: 0, Details: make([]corelb.BaseLoadBalancer, 0)}, nil
+	}
+	req.Filter = expr
+	if req.Filter == nil {
+		req.Filter = tools.AllExpression()
+	}
+
+	return svc.client.DataService().Global.LoadBalancer.List(cts.Kit, req)
+}
+
+// GetLoadBalancer get load balancer.
+func (svc *lbSvc) GetLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.getLoadBalancer(cts, handler.ResOperateAuth)
+}
+
+// GetBizLoadBalancer get biz load balancer.
+func (svc *lbSvc) GetBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.getLoadBalancer(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) getLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.LoadBalancerCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.DataService().TCloud.LoadBalancer.Get(cts.Kit, id)
+	case enumor.Aws:
+		return svc.client.DataService().Aws.LoadBalancer.Get(cts.Kit, id)
+	default:
+		return nil, errf.Newf(errf.Unknown, "id: %s vendor: %s not support", id, basicInfo.Vendor)
+	}
+}
+
+// AssignLoadBalancerToBiz assign load balancer to biz.
+func (svc *lbSvc) AssignLoadBalancerToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(cslb.AssignLoadBalancerToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// authorize
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.LoadBalancerCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.LoadBalancer,
+			Action: meta.Assign, ResourceID: info.AccountID}, BizID: req.BkBizID})
+	}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	// 已分配业务的负载均衡不允许重复分配
+	if err = svc.checkLoadBalancersInBiz(cts.Kit, req.IDs, constant.UnassignedBiz); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// create assign audit.
+	err = svc.audit.ResBizAssignAudit(cts.Kit, enumor.LoadBalancerAuditResType, req.IDs, req.BkBizID)
+	if err != nil {
+		logs.Errorf("create assign audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	items := make([]dslb.LoadBalancerUpdateField, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		items = append(items, dslb.LoadBalancerUpdateField{ID: id, BkBizID: req.BkBizID})
+	}
+	updateReq := &dslb.LoadBalancerBatchUpdateReq{Items: items}
+	if err = svc.client.DataService().Global.LoadBalancer.BatchUpdate(cts.Kit, updateReq); err != nil {
+		logs.Errorf("assign load balancer to biz failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *lbSvc) checkLoadBalancersInBiz(kt *kit.Kit, ids []string, bizID int64) error {
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: ids},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: bizID},
+			},
+		},
+		Page: core.NewCountPage(),
+	}
+	result, err := svc.client.DataService().Global.LoadBalancer.List(kt, req)
+	if err != nil {
+		logs.Errorf("count load balancers that are not in biz failed, err: %v, req: %+v, rid: %s", err, req,
+			kt.Rid)
+		return err
+	}
+
+	if result.Count != 0 {
+		return fmt.Errorf("%d load balancers are already assigned", result.Count)
+	}
+
+	return nil
+}
+
+// BatchDeleteLoadBalancer batch delete load balancer.
+func (svc *lbSvc) BatchDeleteLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteLoadBalancer(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizLoadBalancer batch delete biz load balancer.
+func (svc *lbSvc) BatchDeleteBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteLoadBalancer(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) batchDeleteLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.LoadBalancerCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Delete, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create delete audit.
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.LoadBalancerAuditResType, req.IDs); err != nil {
+		logs.Errorf("create delete audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 负载均衡按账号调用hc-service删除，同一账号下的负载均衡可批量删除
+	accountIDsMap := make(map[string][]string)
+	vendorMap := make(map[string]enumor.Vendor)
+	for _, id := range req.IDs {
+		info, exist := basicInfoMap[id]
+		if !exist {
+			return nil, errf.Newf(errf.InvalidParameter, "load balancer: %s not found", id)
+		}
+
+		accountIDsMap[info.AccountID] = append(accountIDsMap[info.AccountID], id)
+		vendorMap[info.AccountID] = info.Vendor
+	}
+
+	for accountID, ids := range accountIDsMap {
+		for _, partIDs := range slice.Split(ids, typelb.TCloudDeleteLimit) {
+			if err = svc.deleteLoadBalancer(cts.Kit, vendorMap[accountID], accountID, partIDs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (svc *lbSvc) deleteLoadBalancer(kt *kit.Kit, vendor enumor.Vendor, accountID string, ids []string) error {
+	delReq := &hclb.LoadBalancerBatchDeleteReq{AccountID: accountID, IDs: ids}
+
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		err = svc.client.HCService().TCloud.LoadBalancer.BatchDeleteLoadBalancer(kt, delReq)
+	case enumor.Aws:
+		err = svc.client.HCService().Aws.LoadBalancer.BatchDeleteLoadBalancer(kt, delReq)
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support", vendor)
+	}
+	if err != nil {
+		logs.Errorf("[%s] request hc-service to delete load balancer failed, err: %v, ids: %v, rid: %s", vendor,
+			err, ids, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// CreateLoadBalancer create load balancer.
+func (svc *lbSvc) CreateLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.createLoadBalancer(cts, constant.UnassignedBiz, handler.ResOperateAuth)
+}
+
+// CreateBizLoadBalancer create biz load balancer.
+func (svc *lbSvc) CreateBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	bkBizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	return svc.createLoadBalancer(cts, bkBizID, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) createLoadBalancer(cts *rest.Contexts, bkBizID int64,
+	validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+
+	accountID, err := common.ExtractAccountID(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// validate authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Create, BasicInfo: common.GetCloudResourceBasicInfo(accountID, bkBizID)})
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.AccountCloudResType,
+		accountID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch info.Vendor {
+	case enumor.TCloud:
+		return svc.createTCloudLoadBalancer(cts, bkBizID)
+	case enumor.Aws:
+		return svc.createAwsLoadBalancer(cts, bkBizID)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", info.Vendor)
+	}
+}
+
+func (svc *lbSvc) createTCloudLoadBalancer(cts *rest.Contexts, bkBizID int64) (interface{}, error) {
+	req := new(cslb.TCloudLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	createReq := &hclb.TCloudLoadBalancerCreateReq{
+		AccountID:          req.AccountID,
+		BkBizID:            bkBizID,
+		TCloudCreateOption: req.TCloudCreateOption,
+	}
+	return svc.client.HCService().TCloud.LoadBalancer.CreateLoadBalancer(cts.Kit, createReq)
+}
+
+func (svc *lbSvc) createAwsLoadBalancer(cts *rest.Contexts, bkBizID int64) (interface{}, error) {
+	req := new(cslb.AwsLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	createReq := &hclb.AwsLoadBalancerCreateReq{
+		AccountID:       req.AccountID,
+		BkBizID:         bkBizID,
+		AwsCreateOption: req.AwsCreateOption,
+	}
+	return svc.client.HCService().Aws.LoadBalancer.CreateLoadBalancer(cts.Kit, createReq)
+}
+
+// getLoadBalancerBasicInfo get load balancer basic info and validate biz and authorize.
+func (svc *lbSvc) getLoadBalancerBasicInfo(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	*types.CloudResourceBasicInfo, error) {
+
+	lbID := cts.PathParameter("lb_id").String()
+	if len(lbID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "lb_id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.LoadBalancerCloudResType, lbID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Find, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	return basicInfo, nil
+}
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitLoadBalancerService initialize the load balancer service.
+func InitLoadBalancerService(c *capability.Capability) {
+	svc := &lbSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListLoadBalancer)
+	h.Add("GetLoadBalancer", http.MethodGet, "/load_balancers/{id}", svc.GetLoadBalancer)
+	h.Add("AssignLoadBalancerToBiz", http.MethodPost, "/load_balancers/assign/bizs", svc.AssignLoadBalancerToBiz)
+	h.Add("BatchDeleteLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteLoadBalancer)
+	h.Add("CreateLoadBalancer", http.MethodPost, "/load_balancers/create", svc.CreateLoadBalancer)
+	h.Add("ListListener", http.MethodPost, "/load_balancers/{lb_id}/listeners/list", svc.ListListener)
+	h.Add("ListTarget", http.MethodPost, "/load_balancers/{lb_id}/targets/list", svc.ListTarget)
+
+	// load balancer apis in biz
+	h.Add("ListBizLoadBalancer", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/list", svc.ListBizLoadBalancer)
+	h.Add("GetBizLoadBalancer", http.MethodGet, "/bizs/{bk_biz_id}/load_balancers/{id}", svc.GetBizLoadBalancer)
+	h.Add("BatchDeleteBizLoadBalancer", http.MethodDelete, "/bizs/{bk_biz_id}/load_balancers/batch",
+		svc.BatchDeleteBizLoadBalancer)
+	h.Add("CreateBizLoadBalancer", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/create",
+		svc.CreateBizLoadBalancer)
+	h.Add("ListBizListener", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/{lb_id}/listeners/list",
+		svc.ListBizListener)
+	h.Add("ListBizTarget", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/{lb_id}/targets/list",
+		svc.ListBizTarget)
+
+	h.Load(c.WebService)
+}
+
+type lbSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
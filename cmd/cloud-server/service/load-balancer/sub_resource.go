@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListListener list listener of load balancer.
+func (svc *lbSvc) ListListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.listListener(cts, handler.ResOperateAuth)
+}
+
+// ListBizListener list listener of biz load balancer.
+func (svc *lbSvc) ListBizListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.listListener(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) listListener(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req, err := svc.decodeLbSubResListReq(cts, validHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.LoadBalancer.ListListener(cts.Kit, req)
+}
+
+// ListTarget list backend target of load balancer.
+func (svc *lbSvc) ListTarget(cts *rest.Contexts) (interface{}, error) {
+	return svc.listTarget(cts, handler.ResOperateAuth)
+}
+
+// ListBizTarget list backend target of biz load balancer.
+func (svc *lbSvc) ListBizTarget(cts *rest.Contexts) (interface{}, error) {
+	return svc.listTarget(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) listTarget(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req, err := svc.decodeLbSubResListReq(cts, validHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.LoadBalancer.ListTarget(cts.Kit, req)
+}
+
+// decodeLbSubResListReq 解析负载均衡子资源的查询请求，校验负载均衡的查看权限后，将查询条件限定在该负载均衡下
+func (svc *lbSvc) decodeLbSubResListReq(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	*core.ListReq, error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfo, err := svc.getLoadBalancerBasicInfo(cts, validHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := tools.And(tools.EqualExpression("lb_id", basicInfo.ID), req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	req.Filter = expr
+
+	return req, nil
+}
@@ -20,6 +20,7 @@
 package recycle
 
 import (
+	"errors"
 	"time"
 
 	"hcm/cmd/cloud-server/logics"
@@ -61,7 +62,7 @@ func RecycleTiming(c *client.ClientSet, state serviced.State, conf cc.Recycle, e
 	go r.recycleTiming(enumor.CvmCloudResType, r.recycleCvmWorker, conf)
 }
 
-type recycleWorker func(kt *kit.Kit, info *types.CloudResourceBasicInfo) error
+type recycleWorker func(kt *kit.Kit, info *types.CloudResourceBasicInfo, record recyclerecord.RecycleRecord) error
 
 func (r *recycle) recycleTiming(resType enumor.CloudResourceType, worker recycleWorker, conf cc.Recycle) {
 	for {
@@ -89,7 +90,7 @@ func (r *recycle) recycleTiming(resType enumor.CloudResourceType, worker recycle
 		listReq := &core.ListReq{
 			Filter: expr,
 			Page:   core.NewDefaultBasePage(),
-			Fields: []string{"id", "res_id", "bk_biz_id"},
+			Fields: []string{"id", "res_id", "bk_biz_id", "detail"},
 		}
 		recordRes, err := r.client.DataService().Global.RecycleRecord.ListRecycleRecord(kt, listReq)
 		if err != nil {
@@ -130,23 +131,36 @@ func (r *recycle) recycleTiming(resType enumor.CloudResourceType, worker recycle
 		}
 
 		// recycle resources one by one
+		waiting := 0
 		for _, record := range recordRes.Details {
 			if !r.state.IsMaster() {
 				logs.Infof("recycle %s res(id: %s), but is not master, skip, rid: %s", resType, record.ResID, kt.Rid)
 				time.Sleep(time.Minute)
 				break
 			}
-			r.execWorker(kt, worker, record, basicInfoMap)
+			if r.execWorker(kt, worker, record, basicInfoMap) {
+				waiting++
+			}
 		}
 
-		logs.Infof("finished recycle %s, count: %d, rid: %s", resType, len(recordRes.Details), kt.Rid)
+		logs.Infof("finished recycle %s, count: %d, waiting: %d, rid: %s", resType, len(recordRes.Details), waiting,
+			kt.Rid)
+
+		// 全部资源都在等待回收条件满足时（如等待保留的快照可用），等待一段时间后再重新检查
+		if waiting == len(recordRes.Details) {
+			time.Sleep(time.Minute)
+		}
 	}
 }
 
+// errRecycleWaiting 资源暂时不满足回收条件（如等待保留的快照可用），回收记录保持待回收状态，下次定时回收时再处理
+var errRecycleWaiting = errors.New("resource is waiting for recycle condition")
+
 const maxRetryCount = 3
 
+// execWorker 执行资源回收，返回资源是否在等待回收条件满足，等待中的资源不标记回收结果
 func (r *recycle) execWorker(kt *kit.Kit, worker recycleWorker, record recyclerecord.RecycleRecord,
-	basicInfoMap map[string]types.CloudResourceBasicInfo) {
+	basicInfoMap map[string]types.CloudResourceBasicInfo) bool {
 
 	basicInfo, exists := basicInfoMap[record.ResID]
 	if !exists {
@@ -154,7 +168,7 @@ func (r *recycle) execWorker(kt *kit.Kit, worker recycleWorker, record recyclere
 			kt.Rid)
 		logicsrecycle.MarkRecordFailed(kt, r.client.DataService(),
 			errf.New(errf.RecordNotFound, "Recourse Not Found"), []string{record.ID})
-		return
+		return false
 	}
 
 	rty := retry.NewRetryPolicy(maxRetryCount, [2]uint{500, 15000})
@@ -163,30 +177,65 @@ func (r *recycle) execWorker(kt *kit.Kit, worker recycleWorker, record recyclere
 	// 类型为cvm且在业务下回收的，需要检查是否在cmdb 待回收模块中
 	// 因为cvm记录中的BkBizID已经在加入业务的时候被清掉了，所以要以recycle_record中的为准
 	basicInfo.BkBizID = record.BkBizID
+	waiting := false
 	err = rty.BaseExec(kt, func() error {
-		return worker(kt, &basicInfo)
+		err := worker(kt, &basicInfo, record)
+		if errors.Is(err, errRecycleWaiting) {
+			waiting = true
+			return nil
+		}
+		return err
 	})
 	if err != nil {
 		// Failed after retry
 		logicsrecycle.MarkRecordFailed(kt, r.client.DataService(), err, []string{record.ID})
-		return
+		return false
 	}
+
+	if waiting {
+		logs.V(3).Infof("[%s]recycle res(id: %s) is waiting, rid: %s", record.ResType, record.ID, kt.Rid)
+		return true
+	}
+
 	// Success
 	logs.V(3).Infof("[%s]recycle res(id: %s) success,  rid: %s", record.ResType, record.ID, kt.Rid)
 
 	logicsrecycle.MarkRecordSuccess(kt, r.client.DataService(), []string{record.ID})
+	return false
 }
 
-func (r *recycle) recycleDiskWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
-	res, err := r.logics.Disk.DeleteRecycledDisk(kt, map[string]types.CloudResourceBasicInfo{info.ID: *info})
+func (r *recycle) recycleDiskWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo,
+	record recyclerecord.RecycleRecord) error {
+
+	opt, err := recyclerecord.ParseDiskRecycleOptions(record.Detail)
+	if err != nil {
+		logs.Errorf("parse disk recycle options failed, err: %v, record: %s, rid: %s", err, record.ID, kt.Rid)
+		return err
+	}
+
+	snapshotRecords := make(map[string]string)
+	if opt.KeepSnapshot {
+		snapshotRecords[info.ID] = record.ID
+	}
+
+	res, err := r.logics.Disk.DeleteRecycledDisk(kt, map[string]types.CloudResourceBasicInfo{info.ID: *info},
+		snapshotRecords)
 	if err != nil {
 		logs.Errorf("delete disk failed, err: %v, res: %+v, disk: %s, rid: %s", err, res, info.ID, kt.Rid)
 		return err
 	}
+
+	if res != nil && len(res.Waiting) != 0 {
+		logs.Infof("disk: %s is waiting for recycle snapshot available, record: %s, rid: %s", info.ID, record.ID,
+			kt.Rid)
+		return errRecycleWaiting
+	}
 	return nil
 }
 
-func (r *recycle) recycleCvmWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+func (r *recycle) recycleCvmWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo,
+	_ recyclerecord.RecycleRecord) error {
+
 	// 实际销毁CVM
 	res, err := r.logics.Cvm.DestroyRecycledCvm(kt, map[string]types.CloudResourceBasicInfo{info.ID: *info}, nil)
 	if err != nil {
@@ -40,10 +40,12 @@ import (
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/cmd/cloud-server/service/cvm"
 	"hcm/cmd/cloud-server/service/disk"
+	disksnapshot "hcm/cmd/cloud-server/service/disk-snapshot"
 	"hcm/cmd/cloud-server/service/eip"
 	"hcm/cmd/cloud-server/service/firewall"
 	"hcm/cmd/cloud-server/service/image"
 	instancetype "hcm/cmd/cloud-server/service/instance-type"
+	loadbalancer "hcm/cmd/cloud-server/service/load-balancer"
 	networkinterface "hcm/cmd/cloud-server/service/network-interface"
 	"hcm/cmd/cloud-server/service/recycle"
 	"hcm/cmd/cloud-server/service/region"
@@ -256,6 +258,7 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	firewall.InitFirewallService(c)
 	vpc.InitVpcService(c)
 	disk.InitDiskService(c)
+	disksnapshot.InitDiskSnapshotService(c)
 	subnet.InitSubnetService(c)
 	image.InitImageService(c)
 	routetable.InitRouteTableService(c)
@@ -266,6 +269,7 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	eip.InitEipService(c)
 	instancetype.InitInstanceTypeService(c)
 	networkinterface.InitNetworkInterfaceService(c)
+	loadbalancer.InitLoadBalancerService(c)
 	subaccount.InitService(c)
 
 	application.InitApplicationService(c, bkHcmUrl)
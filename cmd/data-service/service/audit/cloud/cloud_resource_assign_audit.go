@@ -112,6 +112,10 @@ func (ad Audit) buildAssignAuditInfo(kt *kit.Kit, resType enumor.AuditResourceTy
 		audits, err = ad.networkInterface.NetworkInterfaceAssignAuditBuild(kt, assigns)
 	case enumor.RouteTableAuditResType:
 		audits, err = ad.routeTable.RouteTableAssignAuditBuild(kt, assigns)
+	case enumor.LoadBalancerAuditResType:
+		audits, err = ad.loadBalancerAssignAuditBuild(kt, assigns)
+	case enumor.DiskSnapshotAuditResType:
+		audits, err = ad.diskSnapshotAssignAuditBuild(kt, assigns)
 	default:
 		return nil, fmt.Errorf("cloud resource type: %s not support", resType)
 	}
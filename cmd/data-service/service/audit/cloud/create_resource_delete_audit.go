@@ -95,6 +95,10 @@ func (ad Audit) buildDeleteAuditInfo(kt *kit.Kit, resType enumor.AuditResourceTy
 		audits, err = ad.eipDeleteAuditBuild(kt, deletes)
 	case enumor.DiskAuditResType:
 		audits, err = ad.diskDeleteAuditBuild(kt, deletes)
+	case enumor.LoadBalancerAuditResType:
+		audits, err = ad.loadBalancerDeleteAuditBuild(kt, deletes)
+	case enumor.DiskSnapshotAuditResType:
+		audits, err = ad.diskSnapshotDeleteAuditBuild(kt, deletes)
 
 	default:
 		return nil, fmt.Errorf("cloud resource type: %s not support", resType)
@@ -177,6 +177,8 @@ var assignResAuditTypeMap = map[enumor.CloudResourceType]enumor.AuditResourceTyp
 	enumor.RouteTableCloudResType:       enumor.RouteTableAuditResType,
 	enumor.GcpFirewallRuleCloudResType:  enumor.GcpFirewallRuleAuditResType,
 	enumor.NetworkInterfaceCloudResType: enumor.NetworkInterfaceAuditResType,
+	enumor.LoadBalancerCloudResType:     enumor.LoadBalancerAuditResType,
+	enumor.DiskSnapshotCloudResType:     enumor.DiskSnapshotAuditResType,
 }
 
 // AssignResourceToBiz assign an account's cloud resource to biz, **only for ui**.
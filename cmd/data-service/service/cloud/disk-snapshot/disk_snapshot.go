@@ -0,0 +1,361 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataservice "hcm/pkg/api/data-service"
+	dssnapshot "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesnapshot "hcm/pkg/dal/table/cloud/disk-snapshot"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateDiskSnapshot batch create disk snapshot.
+func (svc *service) BatchCreateDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssnapshot.DiskSnapshotBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]tablesnapshot.DiskSnapshotTable, 0, len(req.Items))
+		for _, item := range req.Items {
+			bizID := item.BkBizID
+			if bizID == 0 {
+				bizID = constant.UnassignedBiz
+			}
+
+			models = append(models, tablesnapshot.DiskSnapshotTable{
+				CloudID:          item.CloudID,
+				Name:             item.Name,
+				Vendor:           item.Vendor,
+				AccountID:        item.AccountID,
+				BkBizID:          bizID,
+				Region:           item.Region,
+				Zone:             item.Zone,
+				DiskID:           item.DiskID,
+				CloudDiskID:      item.CloudDiskID,
+				Size:             item.Size,
+				Status:           item.Status,
+				CloudCreatedTime: item.CloudCreatedTime,
+				Extension:        tabletype.JsonField(item.Extension),
+				Memo:             item.Memo,
+				Creator:          cts.Kit.User,
+				Reviser:          cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.DiskSnapshot().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create disk snapshot failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create disk snapshot commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create disk snapshot but return id type not string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateDiskSnapshot batch update disk snapshot.
+func (svc *service) BatchUpdateDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(dssnapshot.DiskSnapshotBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, item := range req.Items {
+			model := &tablesnapshot.DiskSnapshotTable{
+				Name:             item.Name,
+				BkBizID:          item.BkBizID,
+				DiskID:           item.DiskID,
+				Size:             item.Size,
+				Status:           item.Status,
+				CloudCreatedTime: item.CloudCreatedTime,
+				Extension:        tabletype.JsonField(item.Extension),
+				Memo:             item.Memo,
+				Reviser:          cts.Kit.User,
+			}
+
+			if err := svc.dao.DiskSnapshot().UpdateByIDWithTx(cts.Kit, txn, item.ID, model); err != nil {
+				logs.Errorf("update disk snapshot by id: %s failed, err: %v, rid: %s", item.ID, err, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update disk snapshot commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListDiskSnapshot list disk snapshot.
+func (svc *service) ListDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.DiskSnapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list disk snapshot failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dssnapshot.DiskSnapshotListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coresnapshot.BaseDiskSnapshot, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, convBaseDiskSnapshot(one))
+	}
+
+	return &dssnapshot.DiskSnapshotListResult{Details: details}, nil
+}
+
+// GetDiskSnapshot get disk snapshot with extension.
+func (svc *service) GetDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	opt := &types.ListOption{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{"id": id, "vendor": vendor}),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.DiskSnapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("get disk snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "disk snapshot: %s not found", id)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convDiskSnapshot[coresnapshot.TCloudExtension](result.Details[0])
+	case enumor.Aws:
+		return convDiskSnapshot[coresnapshot.AwsExtension](result.Details[0])
+	case enumor.Azure:
+		return convDiskSnapshot[coresnapshot.AzureExtension](result.Details[0])
+	case enumor.Gcp:
+		return convDiskSnapshot[coresnapshot.GcpExtension](result.Details[0])
+	case enumor.HuaWei:
+		return convDiskSnapshot[coresnapshot.HuaWeiExtension](result.Details[0])
+	default:
+		return nil, fmt.Errorf("unsupported vendor: %s for disk snapshot", vendor)
+	}
+}
+
+// ListDiskSnapshotExt list disk snapshot with extension.
+func (svc *service) ListDiskSnapshotExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.DiskSnapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list disk snapshot failed, err: %v", err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convDiskSnapshotListExt[coresnapshot.TCloudExtension](result.Count, result.Details)
+	case enumor.Aws:
+		return convDiskSnapshotListExt[coresnapshot.AwsExtension](result.Count, result.Details)
+	case enumor.Azure:
+		return convDiskSnapshotListExt[coresnapshot.AzureExtension](result.Count, result.Details)
+	case enumor.Gcp:
+		return convDiskSnapshotListExt[coresnapshot.GcpExtension](result.Count, result.Details)
+	case enumor.HuaWei:
+		return convDiskSnapshotListExt[coresnapshot.HuaWeiExtension](result.Count, result.Details)
+	default:
+		return nil, fmt.Errorf("unsupported vendor: %s for disk snapshot", vendor)
+	}
+}
+
+// BatchDeleteDiskSnapshot batch delete disk snapshot.
+func (svc *service) BatchDeleteDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	listResp, err := svc.dao.DiskSnapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list disk snapshot failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		ids[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.DiskSnapshot().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", ids)); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete disk snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func convBaseDiskSnapshot(one tablesnapshot.DiskSnapshotTable) coresnapshot.BaseDiskSnapshot {
+	return coresnapshot.BaseDiskSnapshot{
+		ID:               one.ID,
+		CloudID:          one.CloudID,
+		Name:             one.Name,
+		Vendor:           one.Vendor,
+		AccountID:        one.AccountID,
+		BkBizID:          one.BkBizID,
+		Region:           one.Region,
+		Zone:             one.Zone,
+		DiskID:           one.DiskID,
+		CloudDiskID:      one.CloudDiskID,
+		Size:             one.Size,
+		Status:           one.Status,
+		CloudCreatedTime: one.CloudCreatedTime,
+		Memo:             one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+func convDiskSnapshot[T coresnapshot.Extension](one tablesnapshot.DiskSnapshotTable) (
+	*coresnapshot.DiskSnapshot[T], error) {
+
+	extension := new(T)
+	if len(one.Extension) != 0 {
+		if err := json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+			return nil, fmt.Errorf("unmarshal disk snapshot extension failed, err: %v", err)
+		}
+	}
+
+	return &coresnapshot.DiskSnapshot[T]{
+		BaseDiskSnapshot: convBaseDiskSnapshot(one),
+		Extension:        extension,
+	}, nil
+}
+
+func convDiskSnapshotListExt[T coresnapshot.Extension](count uint64, models []tablesnapshot.DiskSnapshotTable) (
+	*dssnapshot.DiskSnapshotListExtResult[T], error) {
+
+	details := make([]coresnapshot.DiskSnapshot[T], 0, len(models))
+	for _, one := range models {
+		snapshot, err := convDiskSnapshot[T](one)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, *snapshot)
+	}
+
+	return &dssnapshot.DiskSnapshotListExtResult[T]{Count: count, Details: details}, nil
+}
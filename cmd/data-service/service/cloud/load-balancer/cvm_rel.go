@@ -0,0 +1,134 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateCvmRel batch create load balancer and cvm relation.
+func (svc *service) BatchCreateCvmRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslb.CvmRelBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		rels := make([]tablelb.CvmRelTable, len(req.Rels))
+		for index, one := range req.Rels {
+			rels[index] = tablelb.CvmRelTable{
+				LbID:    one.LbID,
+				CvmID:   one.CvmID,
+				Creator: cts.Kit.User,
+			}
+		}
+
+		if err := svc.dao.LbCvmRel().BatchCreateWithTx(cts.Kit, txn, rels); err != nil {
+			return nil, fmt.Errorf("batch create load balancer cvm rels failed, err: %v", err)
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create load balancer cvm rels failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListCvmRel list load balancer and cvm relations.
+func (svc *service) ListCvmRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.LbCvmRel().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer cvm rels failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer cvm rels failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dslb.CvmRelListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corelb.CvmRel, len(result.Details))
+	for index, one := range result.Details {
+		details[index] = corelb.CvmRel{
+			ID:        one.ID,
+			LbID:      one.LbID,
+			CvmID:     one.CvmID,
+			Creator:   one.Creator,
+			CreatedAt: one.CreatedAt.String(),
+		}
+	}
+
+	return &dslb.CvmRelListResult{Details: details}, nil
+}
+
+// BatchDeleteCvmRel batch delete load balancer and cvm relations.
+func (svc *service) BatchDeleteCvmRel(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.LbCvmRel().DeleteWithTx(cts.Kit, txn, req.Filter)
+	})
+	if err != nil {
+		logs.Errorf("delete load balancer cvm rels failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,288 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateListener batch create load balancer listener.
+func (svc *service) BatchCreateListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslb.ListenerBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]tablelb.ListenerTable, 0, len(req.Items))
+		for _, item := range req.Items {
+			models = append(models, tablelb.ListenerTable{
+				CloudID:   item.CloudID,
+				Name:      item.Name,
+				Vendor:    item.Vendor,
+				AccountID: item.AccountID,
+				LbID:      item.LbID,
+				CloudLbID: item.CloudLbID,
+				Protocol:  item.Protocol,
+				Port:      item.Port,
+				Extension: tabletype.JsonField(item.Extension),
+				Creator:   cts.Kit.User,
+				Reviser:   cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.LbListener().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create listener failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create listener commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create listener but return id type not string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateListener batch update load balancer listener.
+func (svc *service) BatchUpdateListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslb.ListenerBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, item := range req.Items {
+			model := &tablelb.ListenerTable{
+				Name:      item.Name,
+				Protocol:  item.Protocol,
+				Port:      item.Port,
+				Extension: tabletype.JsonField(item.Extension),
+				Reviser:   cts.Kit.User,
+			}
+
+			if err := svc.dao.LbListener().UpdateByIDWithTx(cts.Kit, txn, item.ID, model); err != nil {
+				logs.Errorf("update listener by id: %s failed, err: %v, rid: %s", item.ID, err, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update listener commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListListener list load balancer listener.
+func (svc *service) ListListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.LbListener().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list listener failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dslb.ListenerListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corelb.BaseListener, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, convBaseListener(one))
+	}
+
+	return &dslb.ListenerListResult{Details: details}, nil
+}
+
+// ListListenerExt list load balancer listener with extension.
+func (svc *service) ListListenerExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.LbListener().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list listener failed, err: %v", err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convListenerListExt[corelb.TCloudListenerExtension](result.Count, result.Details)
+	case enumor.Aws:
+		return convListenerListExt[corelb.AwsListenerExtension](result.Count, result.Details)
+	default:
+		return nil, fmt.Errorf("unsupported vendor: %s for listener", vendor)
+	}
+}
+
+// BatchDeleteListener batch delete load balancer listener, targets bound to the listener are deleted together.
+func (svc *service) BatchDeleteListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	listResp, err := svc.dao.LbListener().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list listener failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		ids[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		targetFilter := tools.ContainersExpression("listener_id", ids)
+		if err := svc.dao.LbTarget().DeleteWithTx(cts.Kit, txn, targetFilter); err != nil {
+			return nil, err
+		}
+
+		if err := svc.dao.LbListener().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", ids)); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func convBaseListener(one tablelb.ListenerTable) corelb.BaseListener {
+	return corelb.BaseListener{
+		ID:        one.ID,
+		CloudID:   one.CloudID,
+		Name:      one.Name,
+		Vendor:    one.Vendor,
+		AccountID: one.AccountID,
+		LbID:      one.LbID,
+		CloudLbID: one.CloudLbID,
+		Protocol:  one.Protocol,
+		Port:      one.Port,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+func convListenerListExt[T corelb.ListenerExtension](count uint64, models []tablelb.ListenerTable) (
+	*dslb.ListenerListExtResult[T], error) {
+
+	details := make([]corelb.Listener[T], 0, len(models))
+	for _, one := range models {
+		extension := new(T)
+		if len(one.Extension) != 0 {
+			if err := json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+				return nil, fmt.Errorf("unmarshal listener extension failed, err: %v", err)
+			}
+		}
+
+		details = append(details, corelb.Listener[T]{BaseListener: convBaseListener(one), Extension: extension})
+	}
+
+	return &dslb.ListenerListExtResult[T]{Count: count, Details: details}, nil
+}
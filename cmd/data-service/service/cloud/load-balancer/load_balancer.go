@@ -0,0 +1,375 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateLoadBalancer batch create load balancer.
+func (svc *service) BatchCreateLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslb.LoadBalancerBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]tablelb.LoadBalancerTable, 0, len(req.Items))
+		for _, item := range req.Items {
+			bizID := item.BkBizID
+			if bizID == 0 {
+				bizID = constant.UnassignedBiz
+			}
+
+			models = append(models, tablelb.LoadBalancerTable{
+				CloudID:          item.CloudID,
+				Name:             item.Name,
+				Vendor:           item.Vendor,
+				AccountID:        item.AccountID,
+				BkBizID:          bizID,
+				Region:           item.Region,
+				Zones:            item.Zones,
+				VpcID:            item.VpcID,
+				CloudVpcID:       item.CloudVpcID,
+				NetworkType:      item.NetworkType,
+				IPVersion:        item.IPVersion,
+				Status:           item.Status,
+				Domain:           item.Domain,
+				PublicIPs:        item.PublicIPs,
+				PrivateIPs:       item.PrivateIPs,
+				CloudCreatedTime: item.CloudCreatedTime,
+				Extension:        tabletype.JsonField(item.Extension),
+				Memo:             item.Memo,
+				Creator:          cts.Kit.User,
+				Reviser:          cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.LoadBalancer().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create load balancer failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create load balancer commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create load balancer but return id type not string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateLoadBalancer batch update load balancer.
+func (svc *service) BatchUpdateLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslb.LoadBalancerBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, item := range req.Items {
+			model := &tablelb.LoadBalancerTable{
+				Name:             item.Name,
+				BkBizID:          item.BkBizID,
+				Zones:            item.Zones,
+				VpcID:            item.VpcID,
+				CloudVpcID:       item.CloudVpcID,
+				NetworkType:      item.NetworkType,
+				IPVersion:        item.IPVersion,
+				Status:           item.Status,
+				Domain:           item.Domain,
+				PublicIPs:        item.PublicIPs,
+				PrivateIPs:       item.PrivateIPs,
+				CloudCreatedTime: item.CloudCreatedTime,
+				Extension:        tabletype.JsonField(item.Extension),
+				Memo:             item.Memo,
+				Reviser:          cts.Kit.User,
+			}
+
+			if err := svc.dao.LoadBalancer().UpdateByIDWithTx(cts.Kit, txn, item.ID, model); err != nil {
+				logs.Errorf("update load balancer by id: %s failed, err: %v, rid: %s", item.ID, err, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update load balancer commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListLoadBalancer list load balancer.
+func (svc *service) ListLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.LoadBalancer().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dslb.LoadBalancerListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corelb.BaseLoadBalancer, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, convBaseLoadBalancer(one))
+	}
+
+	return &dslb.LoadBalancerListResult{Details: details}, nil
+}
+
+// GetLoadBalancer get load balancer with extension.
+func (svc *service) GetLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	opt := &types.ListOption{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{"id": id, "vendor": vendor}),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.LoadBalancer().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("get load balancer failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "load balancer: %s not found", id)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convLoadBalancer[corelb.TCloudExtension](result.Details[0])
+	case enumor.Aws:
+		return convLoadBalancer[corelb.AwsExtension](result.Details[0])
+	default:
+		return nil, fmt.Errorf("unsupported vendor: %s for load balancer", vendor)
+	}
+}
+
+// ListLoadBalancerExt list load balancer with extension.
+func (svc *service) ListLoadBalancerExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.LoadBalancer().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer failed, err: %v", err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convLoadBalancerListExt[corelb.TCloudExtension](result.Count, result.Details)
+	case enumor.Aws:
+		return convLoadBalancerListExt[corelb.AwsExtension](result.Count, result.Details)
+	default:
+		return nil, fmt.Errorf("unsupported vendor: %s for load balancer", vendor)
+	}
+}
+
+// BatchDeleteLoadBalancer batch delete load balancer, listeners and targets of the load balancer are deleted together.
+func (svc *service) BatchDeleteLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	listResp, err := svc.dao.LoadBalancer().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		ids[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		lbFilter := tools.ContainersExpression("lb_id", ids)
+		if err := svc.dao.LbTarget().DeleteWithTx(cts.Kit, txn, lbFilter); err != nil {
+			return nil, err
+		}
+
+		if err := svc.dao.LbListener().DeleteWithTx(cts.Kit, txn, lbFilter); err != nil {
+			return nil, err
+		}
+
+		if err := svc.dao.LbCvmRel().DeleteWithTx(cts.Kit, txn, lbFilter); err != nil {
+			return nil, err
+		}
+
+		lbIDFilter := tools.ContainersExpression("id", ids)
+		if err := svc.dao.LoadBalancer().DeleteWithTx(cts.Kit, txn, lbIDFilter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func convBaseLoadBalancer(one tablelb.LoadBalancerTable) corelb.BaseLoadBalancer {
+	return corelb.BaseLoadBalancer{
+		ID:               one.ID,
+		CloudID:          one.CloudID,
+		Name:             one.Name,
+		Vendor:           one.Vendor,
+		AccountID:        one.AccountID,
+		BkBizID:          one.BkBizID,
+		Region:           one.Region,
+		Zones:            one.Zones,
+		VpcID:            one.VpcID,
+		CloudVpcID:       one.CloudVpcID,
+		NetworkType:      one.NetworkType,
+		IPVersion:        one.IPVersion,
+		Status:           one.Status,
+		Domain:           one.Domain,
+		PublicIPs:        one.PublicIPs,
+		PrivateIPs:       one.PrivateIPs,
+		CloudCreatedTime: one.CloudCreatedTime,
+		Memo:             one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+func convLoadBalancer[T corelb.Extension](one tablelb.LoadBalancerTable) (*corelb.LoadBalancer[T], error) {
+	extension := new(T)
+	if len(one.Extension) != 0 {
+		if err := json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+			return nil, fmt.Errorf("unmarshal load balancer extension failed, err: %v", err)
+		}
+	}
+
+	return &corelb.LoadBalancer[T]{
+		BaseLoadBalancer: convBaseLoadBalancer(one),
+		Extension:        extension,
+	}, nil
+}
+
+func convLoadBalancerListExt[T corelb.Extension](count uint64, models []tablelb.LoadBalancerTable) (
+	*dslb.LoadBalancerListExtResult[T], error) {
+
+	details := make([]corelb.LoadBalancer[T], 0, len(models))
+	for _, one := range models {
+		lb, err := convLoadBalancer[T](one)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, *lb)
+	}
+
+	return &dslb.LoadBalancerListExtResult[T]{Count: count, Details: details}, nil
+}
@@ -0,0 +1,218 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateTarget batch create load balancer target.
+func (svc *service) BatchCreateTarget(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslb.TargetBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]tablelb.TargetTable, 0, len(req.Items))
+		for _, item := range req.Items {
+			models = append(models, tablelb.TargetTable{
+				CloudID:            item.CloudID,
+				Vendor:             item.Vendor,
+				AccountID:          item.AccountID,
+				LbID:               item.LbID,
+				CloudLbID:          item.CloudLbID,
+				ListenerID:         item.ListenerID,
+				CloudListenerID:    item.CloudListenerID,
+				CloudRuleID:        item.CloudRuleID,
+				TargetGroupID:      item.TargetGroupID,
+				CloudTargetGroupID: item.CloudTargetGroupID,
+				InstType:           item.InstType,
+				CloudInstID:        item.CloudInstID,
+				PrivateIPAddresses: item.PrivateIPAddresses,
+				PublicIPAddresses:  item.PublicIPAddresses,
+				Port:               item.Port,
+				Weight:             item.Weight,
+				Creator:            cts.Kit.User,
+				Reviser:            cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.LbTarget().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create target failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create target commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create target but return id type not string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateTarget batch update load balancer target.
+func (svc *service) BatchUpdateTarget(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslb.TargetBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, item := range req.Items {
+			model := &tablelb.TargetTable{
+				LbID:               item.LbID,
+				CloudLbID:          item.CloudLbID,
+				ListenerID:         item.ListenerID,
+				TargetGroupID:      item.TargetGroupID,
+				InstType:           item.InstType,
+				PrivateIPAddresses: item.PrivateIPAddresses,
+				PublicIPAddresses:  item.PublicIPAddresses,
+				Weight:             item.Weight,
+				Reviser:            cts.Kit.User,
+			}
+
+			if err := svc.dao.LbTarget().UpdateByIDWithTx(cts.Kit, txn, item.ID, model); err != nil {
+				logs.Errorf("update target by id: %s failed, err: %v, rid: %s", item.ID, err, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update target commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListTarget list load balancer target.
+func (svc *service) ListTarget(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.LbTarget().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list target failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list target failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dslb.TargetListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corelb.Target, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corelb.Target{
+			ID:                 one.ID,
+			CloudID:            one.CloudID,
+			Vendor:             one.Vendor,
+			AccountID:          one.AccountID,
+			LbID:               one.LbID,
+			CloudLbID:          one.CloudLbID,
+			ListenerID:         one.ListenerID,
+			CloudListenerID:    one.CloudListenerID,
+			CloudRuleID:        one.CloudRuleID,
+			TargetGroupID:      one.TargetGroupID,
+			CloudTargetGroupID: one.CloudTargetGroupID,
+			InstType:           one.InstType,
+			CloudInstID:        one.CloudInstID,
+			PrivateIPAddresses: one.PrivateIPAddresses,
+			PublicIPAddresses:  one.PublicIPAddresses,
+			Port:               one.Port,
+			Weight:             one.Weight,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dslb.TargetListResult{Details: details}, nil
+}
+
+// BatchDeleteTarget batch delete load balancer target.
+func (svc *service) BatchDeleteTarget(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.LbTarget().DeleteWithTx(cts.Kit, txn, req.Filter); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete target failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
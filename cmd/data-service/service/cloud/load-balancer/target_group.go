@@ -0,0 +1,300 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateTargetGroup batch create load balancer target group.
+func (svc *service) BatchCreateTargetGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslb.TargetGroupBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]tablelb.TargetGroupTable, 0, len(req.Items))
+		for _, item := range req.Items {
+			models = append(models, tablelb.TargetGroupTable{
+				CloudID:    item.CloudID,
+				Name:       item.Name,
+				Vendor:     item.Vendor,
+				AccountID:  item.AccountID,
+				Region:     item.Region,
+				VpcID:      item.VpcID,
+				CloudVpcID: item.CloudVpcID,
+				Protocol:   item.Protocol,
+				Port:       item.Port,
+				TargetType: item.TargetType,
+				Extension:  tabletype.JsonField(item.Extension),
+				Creator:    cts.Kit.User,
+				Reviser:    cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.LbTargetGroup().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create target group failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create target group commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create target group but return id type not string, id type: %v",
+			reflect.TypeOf(result).String())
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateTargetGroup batch update load balancer target group.
+func (svc *service) BatchUpdateTargetGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslb.TargetGroupBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, item := range req.Items {
+			model := &tablelb.TargetGroupTable{
+				Name:       item.Name,
+				VpcID:      item.VpcID,
+				CloudVpcID: item.CloudVpcID,
+				Protocol:   item.Protocol,
+				Port:       item.Port,
+				TargetType: item.TargetType,
+				Extension:  tabletype.JsonField(item.Extension),
+				Reviser:    cts.Kit.User,
+			}
+
+			if err := svc.dao.LbTargetGroup().UpdateByIDWithTx(cts.Kit, txn, item.ID, model); err != nil {
+				logs.Errorf("update target group by id: %s failed, err: %v, rid: %s", item.ID, err, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update target group commit txn failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListTargetGroup list load balancer target group.
+func (svc *service) ListTargetGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.LbTargetGroup().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list target group failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list target group failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dslb.TargetGroupListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corelb.BaseTargetGroup, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, convBaseTargetGroup(one))
+	}
+
+	return &dslb.TargetGroupListResult{Details: details}, nil
+}
+
+// ListTargetGroupExt list load balancer target group with extension.
+func (svc *service) ListTargetGroupExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.LbTargetGroup().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list target group failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list target group failed, err: %v", err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convTargetGroupListExt[corelb.TCloudTargetGroupExtension](result.Count, result.Details)
+	case enumor.Aws:
+		return convTargetGroupListExt[corelb.AwsTargetGroupExtension](result.Count, result.Details)
+	default:
+		return nil, fmt.Errorf("unsupported vendor: %s for target group", vendor)
+	}
+}
+
+// BatchDeleteTargetGroup batch delete load balancer target group, targets registered to the target group are
+// deleted together.
+func (svc *service) BatchDeleteTargetGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id"},
+	}
+	listResp, err := svc.dao.LbTargetGroup().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list target group failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list target group failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		ids[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		targetFilter := tools.ContainersExpression("target_group_id", ids)
+		if err := svc.dao.LbTarget().DeleteWithTx(cts.Kit, txn, targetFilter); err != nil {
+			return nil, err
+		}
+
+		tgFilter := tools.ContainersExpression("id", ids)
+		if err := svc.dao.LbTargetGroup().DeleteWithTx(cts.Kit, txn, tgFilter); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete target group failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func convBaseTargetGroup(one tablelb.TargetGroupTable) corelb.BaseTargetGroup {
+	return corelb.BaseTargetGroup{
+		ID:         one.ID,
+		CloudID:    one.CloudID,
+		Name:       one.Name,
+		Vendor:     one.Vendor,
+		AccountID:  one.AccountID,
+		Region:     one.Region,
+		VpcID:      one.VpcID,
+		CloudVpcID: one.CloudVpcID,
+		Protocol:   one.Protocol,
+		Port:       one.Port,
+		TargetType: one.TargetType,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+func convTargetGroupListExt[T corelb.TargetGroupExtension](count uint64, models []tablelb.TargetGroupTable) (
+	*dslb.TargetGroupListExtResult[T], error) {
+
+	details := make([]corelb.TargetGroup[T], 0, len(models))
+	for _, one := range models {
+		extension := new(T)
+		if len(one.Extension) != 0 {
+			if err := json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+				return nil, fmt.Errorf("unmarshal target group extension failed, err: %v", err)
+			}
+		}
+
+		details = append(details, corelb.TargetGroup[T]{
+			BaseTargetGroup: convBaseTargetGroup(one),
+			Extension:       extension,
+		})
+	}
+
+	return &dslb.TargetGroupListExtResult[T]{Count: count, Details: details}, nil
+}
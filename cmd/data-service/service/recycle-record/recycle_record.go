@@ -27,6 +27,7 @@ import (
 	"hcm/pkg/api/core"
 	protocore "hcm/pkg/api/core/recycle-record"
 	protodata "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
@@ -321,6 +322,14 @@ func (svc *recycleRecordSvc) BatchUpdateRecycleRecord(cts *rest.Contexts) (inter
 	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		for _, updateReq := range req.Data {
 			record.Status = string(updateReq.Status)
+			record.RecycledAt = time.Time{}
+			if len(updateReq.RecycledAt) != 0 {
+				recycledAt, err := time.Parse(constant.TimeStdFormat, updateReq.RecycledAt)
+				if err != nil {
+					return nil, errf.NewFromErr(errf.InvalidParameter, err)
+				}
+				record.RecycledAt = recycledAt
+			}
 
 			if updateReq.Detail != nil {
 				updatedDetail, err := json.UpdateMerge(updateReq.Detail, string(detailMap[updateReq.ID]))
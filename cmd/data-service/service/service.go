@@ -39,9 +39,11 @@ import (
 	"hcm/cmd/data-service/service/cloud/cvm"
 	"hcm/cmd/data-service/service/cloud/disk"
 	diskcvmrel "hcm/cmd/data-service/service/cloud/disk-cvm-rel"
+	disksnapshot "hcm/cmd/data-service/service/cloud/disk-snapshot"
 	"hcm/cmd/data-service/service/cloud/eip"
 	eipcvmrel "hcm/cmd/data-service/service/cloud/eip-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/image"
+	loadbalancer "hcm/cmd/data-service/service/cloud/load-balancer"
 	networkinterface "hcm/cmd/data-service/service/cloud/network-interface"
 	networkcvmrel "hcm/cmd/data-service/service/cloud/network-interface-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/region"
@@ -209,6 +211,8 @@ func (s *Service) apiSet() *restful.Container {
 	recyclerecord.InitRecycleRecordService(capability)
 	bill.InitBillConfigService(capability)
 	subaccount.InitService(capability)
+	loadbalancer.InitService(capability)
+	disksnapshot.InitService(capability)
 	sync.InitService(capability)
 	user.InitService(capability)
 
@@ -20,22 +20,65 @@
 package cloudadaptor
 
 import (
+	"fmt"
+
 	"hcm/pkg/adaptor"
 	"hcm/pkg/adaptor/aws"
 	"hcm/pkg/adaptor/azure"
+	faketcloud "hcm/pkg/adaptor/fake/tcloud"
 	"hcm/pkg/adaptor/gcp"
 	"hcm/pkg/adaptor/huawei"
 	"hcm/pkg/adaptor/tcloud"
+	"hcm/pkg/cc"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/kit"
+	"hcm/pkg/logs"
 )
 
 // NewCloudAdaptorClient new cloud adaptor client.
-func NewCloudAdaptorClient(dataCli *dataservice.Client) *CloudAdaptorClient {
+func NewCloudAdaptorClient(dataCli *dataservice.Client, fakeCloud cc.FakeCloud) (*CloudAdaptorClient, error) {
+	ad, err := newAdaptor(fakeCloud)
+	if err != nil {
+		return nil, err
+	}
+
 	return &CloudAdaptorClient{
-		adaptor:   adaptor.New(),
+		adaptor:   ad,
 		secretCli: NewSecretClient(dataCli),
+	}, nil
+}
+
+// newAdaptor new adaptor, the tencent cloud operations are served by in-memory fake tcloud if it is enabled.
+func newAdaptor(opt cc.FakeCloud) (*adaptor.Adaptor, error) {
+	if !opt.TCloud.Enable {
+		return adaptor.New(), nil
 	}
+
+	fake, err := faketcloud.New(faketcloud.Option{
+		Regions:       opt.TCloud.Regions,
+		ZoneCount:     opt.TCloud.ZoneCount,
+		MainAccountID: opt.TCloud.MainAccountID,
+		SubAccountID:  opt.TCloud.SubAccountID,
+		Quota: faketcloud.Quota{
+			Vpc:           opt.TCloud.Quota.Vpc,
+			Subnet:        opt.TCloud.Quota.Subnet,
+			SecurityGroup: opt.TCloud.Quota.SecurityGroup,
+			Cvm:           opt.TCloud.Quota.Cvm,
+			Disk:          opt.TCloud.Quota.Disk,
+			Eip:           opt.TCloud.Quota.Eip,
+			LoadBalancer:  opt.TCloud.Quota.LoadBalancer,
+		},
+		Fault: faketcloud.Fault{
+			ThrottleRate:  opt.TCloud.ThrottleRate,
+			AsyncFailRate: opt.TCloud.AsyncFailRate,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("new fake tcloud failed, err: %v", err)
+	}
+
+	logs.Warnf("fake tcloud is enabled, all tcloud operations are served by in-memory fake tcloud")
+	return adaptor.NewWithFakeTCloud(fake), nil
 }
 
 // CloudAdaptorClient define cloud adaptor client used to request cloud api.
@@ -60,7 +103,7 @@ func (cli *CloudAdaptorClient) TCloud(kt *kit.Kit, accountID string) (tcloud.TCl
 }
 
 // Aws return aws client.
-func (cli *CloudAdaptorClient) Aws(kt *kit.Kit, accountID string) (*aws.Aws, error) {
+func (cli *CloudAdaptorClient) Aws(kt *kit.Kit, accountID string) (aws.Aws, error) {
 	secret, cloudAccountID, err := cli.secretCli.AwsSecret(kt, accountID)
 	if err != nil {
 		return nil, err
@@ -70,7 +113,7 @@ func (cli *CloudAdaptorClient) Aws(kt *kit.Kit, accountID string) (*aws.Aws, err
 }
 
 // HuaWei return huawei client.
-func (cli *CloudAdaptorClient) HuaWei(kt *kit.Kit, accountID string) (*huawei.HuaWei, error) {
+func (cli *CloudAdaptorClient) HuaWei(kt *kit.Kit, accountID string) (huawei.HuaWei, error) {
 	secret, err := cli.secretCli.HuaWeiSecret(kt, accountID)
 	if err != nil {
 		return nil, err
@@ -80,7 +123,7 @@ func (cli *CloudAdaptorClient) HuaWei(kt *kit.Kit, accountID string) (*huawei.Hu
 }
 
 // Gcp return gcp client.
-func (cli *CloudAdaptorClient) Gcp(kt *kit.Kit, accountID string) (*gcp.Gcp, error) {
+func (cli *CloudAdaptorClient) Gcp(kt *kit.Kit, accountID string) (gcp.Gcp, error) {
 	cred, err := cli.secretCli.GcpCredential(kt, accountID)
 	if err != nil {
 		return nil, err
@@ -90,7 +133,7 @@ func (cli *CloudAdaptorClient) Gcp(kt *kit.Kit, accountID string) (*gcp.Gcp, err
 }
 
 // GcpProxy return gcp proxy client.
-func (cli *CloudAdaptorClient) GcpProxy(kt *kit.Kit, accountID string) (*gcp.Gcp, error) {
+func (cli *CloudAdaptorClient) GcpProxy(kt *kit.Kit, accountID string) (gcp.Gcp, error) {
 	cred, err := cli.secretCli.GcpRegisterCredential(kt, accountID)
 	if err != nil {
 		return nil, err
@@ -100,7 +143,7 @@ func (cli *CloudAdaptorClient) GcpProxy(kt *kit.Kit, accountID string) (*gcp.Gcp
 }
 
 // Azure return azure client.
-func (cli *CloudAdaptorClient) Azure(kt *kit.Kit, accountID string) (*azure.Azure, error) {
+func (cli *CloudAdaptorClient) Azure(kt *kit.Kit, accountID string) (azure.Azure, error) {
 	cred, err := cli.secretCli.AzureCredential(kt, accountID)
 	if err != nil {
 		return nil, err
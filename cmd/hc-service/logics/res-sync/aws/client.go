@@ -29,7 +29,7 @@ import (
 
 // Interface support resource sync.
 type Interface interface {
-	CloudCli() *aws.Aws
+	CloudCli() aws.Aws
 
 	Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption) (*SyncResult, error)
 	CvmWithRelRes(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmWithRelResOption) (*SyncResult, error)
@@ -38,9 +38,15 @@ type Interface interface {
 	Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption) (*SyncResult, error)
 	RemoveDiskDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
 
+	DiskSnapshot(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskSnapshotOption) (*SyncResult, error)
+	RemoveDiskSnapshotDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+
 	Eip(kt *kit.Kit, params *SyncBaseParams, opt *SyncEipOption) (*SyncResult, error)
 	RemoveEipDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
 
+	LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLoadBalancerOption) (*SyncResult, error)
+	RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
+
 	RouteTable(kt *kit.Kit, params *SyncBaseParams, opt *SyncRouteTableOption) (*SyncResult, error)
 	RemoveRouteTableDeleteFromCloud(kt *kit.Kit, accountID string, region string) error
 
@@ -70,7 +76,7 @@ type Interface interface {
 var _ Interface = new(client)
 
 // NewClient new client.
-func NewClient(dbCli *dataservice.Client, cloudCli *aws.Aws) Interface {
+func NewClient(dbCli *dataservice.Client, cloudCli aws.Aws) Interface {
 	return &client{
 		dbCli:    dbCli,
 		cloudCli: cloudCli,
@@ -79,12 +85,12 @@ func NewClient(dbCli *dataservice.Client, cloudCli *aws.Aws) Interface {
 
 type client struct {
 	accountID string
-	cloudCli  *aws.Aws
+	cloudCli  aws.Aws
 	dbCli     *dataservice.Client
 }
 
 // CloudCli ...
-func (cli *client) CloudCli() *aws.Aws {
+func (cli *client) CloudCli() aws.Aws {
 	return cli.cloudCli
 }
 
@@ -0,0 +1,529 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	"hcm/pkg/adaptor/aws"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// SyncLoadBalancerOption ...
+type SyncLoadBalancerOption struct {
+	// BkBizID 负载均衡创建时，通过同步写入DB，需要传入业务ID
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncLoadBalancerOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// LoadBalancer 同步负载均衡，并同步负载均衡下的监听器和后端服务。
+func (cli *client) LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLoadBalancerOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lbFromCloud, err := cli.listLoadBalancerFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	lbFromDB, err := cli.listLoadBalancerFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lbFromCloud) == 0 && len(lbFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typelb.AwsLoadBalancer,
+		corelb.LoadBalancer[corelb.AwsExtension]](lbFromCloud, lbFromDB, isLoadBalancerChange)
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteLoadBalancer(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createLoadBalancer(kt, params.AccountID, params.Region, addSlice, opt.BkBizID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateLoadBalancer(kt, params.AccountID, params.Region, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(lbFromCloud) > 0 {
+		if err = cli.syncLoadBalancerSubRes(kt, params, lbFromCloud); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// RemoveLoadBalancerDeleteFromCloud ...
+func (cli *client) RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.Aws},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.LoadBalancer.List(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list load balancer failed, err: %v, req: %v, rid: %s",
+				enumor.Aws, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0, len(resultFromDB.Details))
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listLoadBalancerFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.GetCloudID())
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteLoadBalancer(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+// deleteLoadBalancer 删除db中的负载均衡，负载均衡下的监听器、后端服务以及与主机的关联关系由data-service一并删除。
+func (cli *client) deleteLoadBalancer(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete load balancer, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delFromCloud, err := cli.listLoadBalancerFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delFromCloud) > 0 {
+		logs.Errorf("[%s] validate load balancer not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.Aws, checkParams, len(delFromCloud), kt.Rid)
+		return fmt.Errorf("validate load balancer not exist failed, before delete")
+	}
+
+	deleteReq := &dataservice.BatchDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.Aws},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: delCloudIDs},
+			},
+		},
+	}
+	if err = cli.dbCli.Global.LoadBalancer.BatchDelete(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete load balancer failed, err: %v, rid: %s",
+			enumor.Aws, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync load balancer to delete load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createLoadBalancer(kt *kit.Kit, accountID string, region string,
+	addSlice []typelb.AwsLoadBalancer, bizID int64) error {
+
+	if len(addSlice) == 0 {
+		return fmt.Errorf("create load balancer, load balancers is required")
+	}
+
+	vpcMap, err := cli.getLoadBalancerVpcMap(kt, accountID, region, addSlice)
+	if err != nil {
+		return err
+	}
+
+	items := make([]dslb.LoadBalancerCreateField, 0, len(addSlice))
+	for _, one := range addSlice {
+		item, err := convAwsLoadBalancer(one, vpcMap)
+		if err != nil {
+			logs.Errorf("[%s] convert load balancer %s failed, err: %v, rid: %s", enumor.Aws,
+				one.GetCloudID(), err, kt.Rid)
+			return err
+		}
+		item.AccountID = accountID
+		item.Region = region
+		item.BkBizID = bizID
+		items = append(items, *item)
+	}
+
+	for _, part := range slice.Split(items, constant.BatchOperationMaxLimit) {
+		createReq := &dslb.LoadBalancerBatchCreateReq{Items: part}
+		if _, err = cli.dbCli.Global.LoadBalancer.BatchCreate(kt, createReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch create load balancer failed, err: %v, rid: %s",
+				enumor.Aws, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync load balancer to create load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateLoadBalancer(kt *kit.Kit, accountID string, region string,
+	updateMap map[string]typelb.AwsLoadBalancer) error {
+
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update load balancer, load balancers is required")
+	}
+
+	vpcMap, err := cli.getLoadBalancerVpcMap(kt, accountID, region, converter.MapValueToSlice(updateMap))
+	if err != nil {
+		return err
+	}
+
+	items := make([]dslb.LoadBalancerUpdateField, 0, len(updateMap))
+	for id, one := range updateMap {
+		field, err := convAwsLoadBalancer(one, vpcMap)
+		if err != nil {
+			logs.Errorf("[%s] convert load balancer %s failed, err: %v, rid: %s", enumor.Aws,
+				one.GetCloudID(), err, kt.Rid)
+			return err
+		}
+
+		items = append(items, dslb.LoadBalancerUpdateField{
+			ID:               id,
+			Name:             field.Name,
+			Zones:            field.Zones,
+			VpcID:            field.VpcID,
+			CloudVpcID:       field.CloudVpcID,
+			NetworkType:      field.NetworkType,
+			IPVersion:        field.IPVersion,
+			Status:           field.Status,
+			Domain:           field.Domain,
+			PublicIPs:        field.PublicIPs,
+			PrivateIPs:       field.PrivateIPs,
+			CloudCreatedTime: field.CloudCreatedTime,
+			Extension:        field.Extension,
+		})
+	}
+
+	for _, part := range slice.Split(items, constant.BatchOperationMaxLimit) {
+		updateReq := &dslb.LoadBalancerBatchUpdateReq{Items: part}
+		if err = cli.dbCli.Global.LoadBalancer.BatchUpdate(kt, updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch update load balancer failed, err: %v, rid: %s",
+				enumor.Aws, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync load balancer to update load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+// getLoadBalancerVpcMap 获取负载均衡所属vpc在db中的信息，vpc未同步时负载均衡的vpc_id为空，不影响负载均衡同步。
+func (cli *client) getLoadBalancerVpcMap(kt *kit.Kit, accountID string, region string,
+	lbs []typelb.AwsLoadBalancer) (map[string]*common.VpcDB, error) {
+
+	cloudVpcIDs := make([]string, 0, len(lbs))
+	for _, one := range lbs {
+		if len(converter.PtrToVal(one.VpcId)) != 0 {
+			cloudVpcIDs = append(cloudVpcIDs, converter.PtrToVal(one.VpcId))
+		}
+	}
+
+	if len(cloudVpcIDs) == 0 {
+		return make(map[string]*common.VpcDB), nil
+	}
+
+	return cli.getVpcMap(kt, accountID, region, slice.Unique(cloudVpcIDs))
+}
+
+func (cli *client) listLoadBalancerFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typelb.AwsLoadBalancer,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := make([]typelb.AwsLoadBalancer, 0, len(params.CloudIDs))
+	for _, part := range slice.Split(params.CloudIDs, typelb.AwsLoadBalancerQueryLimit) {
+		opt := &typelb.AwsListOption{
+			Region:   params.Region,
+			CloudIDs: part,
+		}
+		resp, err := cli.cloudCli.ListLoadBalancer(kt, opt)
+		if err != nil {
+			// 批量查询中只要有一个负载均衡不存在，aws就会返回错误，此时逐个查询以过滤掉已删除的负载均衡
+			if strings.Contains(err.Error(), aws.ErrLoadBalancerNotFound) {
+				exists, err := cli.listLoadBalancerOneByOne(kt, params.Region, part)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, exists...)
+				continue
+			}
+
+			logs.Errorf("[%s] list load balancer from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+				enumor.Aws, err, params.AccountID, opt, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+	}
+
+	return result, nil
+}
+
+func (cli *client) listLoadBalancerOneByOne(kt *kit.Kit, region string, cloudIDs []string) (
+	[]typelb.AwsLoadBalancer, error) {
+
+	result := make([]typelb.AwsLoadBalancer, 0, len(cloudIDs))
+	for _, cloudID := range cloudIDs {
+		opt := &typelb.AwsListOption{Region: region, CloudIDs: []string{cloudID}}
+		resp, err := cli.cloudCli.ListLoadBalancer(kt, opt)
+		if err != nil {
+			if strings.Contains(err.Error(), aws.ErrLoadBalancerNotFound) {
+				continue
+			}
+
+			logs.Errorf("[%s] list load balancer from cloud failed, err: %v, opt: %v, rid: %s", enumor.Aws, err,
+				opt, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+	}
+
+	return result, nil
+}
+
+func (cli *client) listLoadBalancerFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]corelb.LoadBalancer[corelb.AwsExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.Aws},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aws.LoadBalancer.ListExt(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list load balancer from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// convAwsLoadBalancer 将云上负载均衡转换为db创建字段，账号、地域和业务由调用方填充。
+func convAwsLoadBalancer(one typelb.AwsLoadBalancer, vpcMap map[string]*common.VpcDB) (
+	*dslb.LoadBalancerCreateField, error) {
+
+	if one.LoadBalancer == nil {
+		return nil, fmt.Errorf("load balancer is nil")
+	}
+
+	networkType := enumor.InternalLoadBalancer
+	if converter.PtrToVal(one.Scheme) == elbv2.LoadBalancerSchemeEnumInternetFacing {
+		networkType = enumor.PublicLoadBalancer
+	}
+
+	zones, subnetIDs := make([]string, 0), make([]string, 0)
+	publicIPs, privateIPs := make([]string, 0), make([]string, 0)
+	for _, zone := range one.AvailabilityZones {
+		if zone == nil {
+			continue
+		}
+
+		zones = append(zones, converter.PtrToVal(zone.ZoneName))
+		subnetIDs = append(subnetIDs, converter.PtrToVal(zone.SubnetId))
+		for _, address := range zone.LoadBalancerAddresses {
+			if address == nil {
+				continue
+			}
+
+			if len(converter.PtrToVal(address.IpAddress)) != 0 {
+				publicIPs = append(publicIPs, *address.IpAddress)
+			}
+
+			if len(converter.PtrToVal(address.PrivateIPv4Address)) != 0 {
+				privateIPs = append(privateIPs, *address.PrivateIPv4Address)
+			}
+		}
+	}
+
+	extension := &corelb.AwsExtension{
+		Type:                  one.Type,
+		Scheme:                one.Scheme,
+		CanonicalHostedZoneID: one.CanonicalHostedZoneId,
+		CloudSubnetIDs:        subnetIDs,
+		CloudSecurityGroupIDs: converter.PtrToSlice(one.SecurityGroups),
+	}
+
+	status := ""
+	if one.State != nil {
+		status = converter.PtrToVal(one.State.Code)
+		extension.StateReason = one.State.Reason
+	}
+
+	extMsg, err := core.MarshalStruct(extension)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudVpcID := converter.PtrToVal(one.VpcId)
+	vpcID := ""
+	if vpc, exist := vpcMap[cloudVpcID]; exist {
+		vpcID = vpc.VpcID
+	}
+
+	createdTime := ""
+	if one.CreatedTime != nil {
+		createdTime = times.ConvStdTimeFormat(*one.CreatedTime)
+	}
+
+	return &dslb.LoadBalancerCreateField{
+		CloudID:          one.GetCloudID(),
+		Name:             converter.PtrToVal(one.LoadBalancerName),
+		Vendor:           enumor.Aws,
+		Zones:            zones,
+		VpcID:            vpcID,
+		CloudVpcID:       cloudVpcID,
+		NetworkType:      networkType,
+		IPVersion:        converter.PtrToVal(one.IpAddressType),
+		Status:           status,
+		Domain:           converter.PtrToVal(one.DNSName),
+		PublicIPs:        publicIPs,
+		PrivateIPs:       privateIPs,
+		CloudCreatedTime: createdTime,
+		Extension:        extMsg,
+	}, nil
+}
+
+func isLoadBalancerChange(cloud typelb.AwsLoadBalancer, db corelb.LoadBalancer[corelb.AwsExtension]) bool {
+	field, err := convAwsLoadBalancer(cloud, nil)
+	if err != nil {
+		return true
+	}
+
+	if field.Name != db.Name || field.Status != db.Status || field.Domain != db.Domain ||
+		field.CloudVpcID != db.CloudVpcID || field.NetworkType != db.NetworkType || field.IPVersion != db.IPVersion {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(field.Zones, db.Zones) || !assert.IsStringSliceEqual(field.PublicIPs,
+		db.PublicIPs) || !assert.IsStringSliceEqual(field.PrivateIPs, db.PrivateIPs) {
+		return true
+	}
+
+	dbExt, err := core.MarshalStruct(db.Extension)
+	if err != nil {
+		return true
+	}
+
+	return string(dbExt) != string(field.Extension)
+}
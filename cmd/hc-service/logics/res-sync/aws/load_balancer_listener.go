@@ -0,0 +1,624 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	cvmrelmgr "hcm/cmd/hc-service/logics/res-sync/cvm-rel-manager"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// awsTargetInstTypeMap aws目标组的目标类型与后端服务实例类型的映射
+var awsTargetInstTypeMap = map[string]enumor.TargetInstType{
+	elbv2.TargetTypeEnumInstance: enumor.CvmTargetInstType,
+	elbv2.TargetTypeEnumIp:       enumor.IpTargetInstType,
+	elbv2.TargetTypeEnumLambda:   enumor.LambdaTargetInstType,
+	elbv2.TargetTypeEnumAlb:      enumor.AlbTargetInstType,
+}
+
+// syncLoadBalancerSubRes 同步负载均衡下的监听器、监听器转发的目标组以及目标组中的后端服务，负载均衡需已同步到db。
+func (cli *client) syncLoadBalancerSubRes(kt *kit.Kit, params *SyncBaseParams, lbs []typelb.AwsLoadBalancer) error {
+	cloudIDs := make([]string, 0, len(lbs))
+	for _, one := range lbs {
+		cloudIDs = append(cloudIDs, one.GetCloudID())
+	}
+
+	lbFromDB, err := cli.listLoadBalancerFromDB(kt, &SyncBaseParams{AccountID: params.AccountID,
+		Region: params.Region, CloudIDs: cloudIDs})
+	if err != nil {
+		return err
+	}
+
+	for _, lb := range lbFromDB {
+		listenerFromCloud, err := cli.syncListener(kt, params.Region, lb)
+		if err != nil {
+			return err
+		}
+
+		tgCloudIDs := make([]string, 0)
+		for _, listener := range listenerFromCloud {
+			tgCloudIDs = append(tgCloudIDs, awsListenerTargetGroupIDs(listener)...)
+		}
+
+		if len(tgCloudIDs) == 0 {
+			continue
+		}
+
+		tgFromDB, err := cli.syncTargetGroup(kt, params.Region, lb, slice.Unique(tgCloudIDs))
+		if err != nil {
+			return err
+		}
+
+		for _, tg := range tgFromDB {
+			if err = cli.syncTarget(kt, params.Region, lb, tg); err != nil {
+				return err
+			}
+		}
+	}
+
+	return cli.syncLoadBalancerCvmRel(kt, lbFromDB)
+}
+
+// syncListener 同步负载均衡下的监听器，返回云上的监听器。
+func (cli *client) syncListener(kt *kit.Kit, region string, lb corelb.LoadBalancer[corelb.AwsExtension]) (
+	[]typelb.AwsListener, error) {
+
+	opt := &typelb.AwsListenerListOption{Region: region, CloudLoadBalancerID: lb.CloudID}
+	listenerFromCloud, err := cli.cloudCli.ListListener(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list listener from cloud failed, err: %v, lb: %s, rid: %s", enumor.Aws, err,
+			lb.CloudID, kt.Rid)
+		return nil, err
+	}
+
+	listenerFromDB, err := cli.listListenerFromDB(kt, lb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typelb.AwsListener,
+		corelb.Listener[corelb.AwsListenerExtension]](listenerFromCloud, listenerFromDB, isListenerChange)
+
+	for _, part := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		deleteReq := &dataservice.BatchDeleteReq{Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "lb_id", Op: filter.Equal.Factory(), Value: lb.ID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: part},
+			},
+		}}
+		if err = cli.dbCli.Global.LoadBalancer.BatchDeleteListener(kt, deleteReq); err != nil {
+			logs.Errorf("[%s] request dataservice to delete listener failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	createItems := make([]dslb.ListenerCreateField, 0, len(addSlice))
+	for _, one := range addSlice {
+		extension, err := convAwsListenerExtension(one)
+		if err != nil {
+			return nil, err
+		}
+
+		createItems = append(createItems, dslb.ListenerCreateField{
+			CloudID:   one.GetCloudID(),
+			Vendor:    enumor.Aws,
+			AccountID: lb.AccountID,
+			LbID:      lb.ID,
+			CloudLbID: lb.CloudID,
+			Protocol:  converter.PtrToVal(one.Protocol),
+			Port:      converter.PtrToVal(one.Port),
+			Extension: extension,
+		})
+	}
+
+	for _, part := range slice.Split(createItems, constant.BatchOperationMaxLimit) {
+		if _, err = cli.dbCli.Global.LoadBalancer.BatchCreateListener(kt,
+			&dslb.ListenerBatchCreateReq{Items: part}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to create listener failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	updateItems := make([]dslb.ListenerUpdateField, 0, len(updateMap))
+	for id, one := range updateMap {
+		extension, err := convAwsListenerExtension(one)
+		if err != nil {
+			return nil, err
+		}
+
+		updateItems = append(updateItems, dslb.ListenerUpdateField{
+			ID:        id,
+			Protocol:  converter.PtrToVal(one.Protocol),
+			Port:      converter.PtrToVal(one.Port),
+			Extension: extension,
+		})
+	}
+
+	for _, part := range slice.Split(updateItems, constant.BatchOperationMaxLimit) {
+		if err = cli.dbCli.Global.LoadBalancer.BatchUpdateListener(kt,
+			&dslb.ListenerBatchUpdateReq{Items: part}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to update listener failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	return listenerFromCloud, nil
+}
+
+// syncTargetGroup 同步负载均衡监听器转发的目标组，目标组可被多个负载均衡共用，这里只新增和更新，返回db中的目标组。
+func (cli *client) syncTargetGroup(kt *kit.Kit, region string, lb corelb.LoadBalancer[corelb.AwsExtension],
+	cloudIDs []string) ([]corelb.TargetGroup[corelb.AwsTargetGroupExtension], error) {
+
+	tgFromCloud := make([]typelb.AwsTargetGroup, 0, len(cloudIDs))
+	for _, part := range slice.Split(cloudIDs, typelb.AwsLoadBalancerQueryLimit) {
+		opt := &typelb.AwsListOption{Region: region, CloudIDs: part}
+		result, err := cli.cloudCli.ListTargetGroup(kt, opt)
+		if err != nil {
+			logs.Errorf("[%s] list target group from cloud failed, err: %v, opt: %v, rid: %s", enumor.Aws, err,
+				opt, kt.Rid)
+			return nil, err
+		}
+		tgFromCloud = append(tgFromCloud, result.Details...)
+	}
+
+	tgFromDB, err := cli.listTargetGroupFromDB(kt, lb.AccountID, cloudIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	addSlice, updateMap, _ := common.Diff[typelb.AwsTargetGroup, corelb.TargetGroup[corelb.AwsTargetGroupExtension]](
+		tgFromCloud, tgFromDB, isTargetGroupChange)
+
+	vpcMap, err := cli.getTargetGroupVpcMap(kt, lb.AccountID, region, tgFromCloud)
+	if err != nil {
+		return nil, err
+	}
+
+	createItems := make([]dslb.TargetGroupCreateField, 0, len(addSlice))
+	for _, one := range addSlice {
+		extension, err := convAwsTargetGroupExtension(one)
+		if err != nil {
+			return nil, err
+		}
+
+		createItems = append(createItems, dslb.TargetGroupCreateField{
+			CloudID:    one.GetCloudID(),
+			Name:       converter.PtrToVal(one.TargetGroupName),
+			Vendor:     enumor.Aws,
+			AccountID:  lb.AccountID,
+			Region:     region,
+			VpcID:      vpcMap[converter.PtrToVal(one.VpcId)],
+			CloudVpcID: converter.PtrToVal(one.VpcId),
+			Protocol:   converter.PtrToVal(one.Protocol),
+			Port:       converter.PtrToVal(one.Port),
+			TargetType: converter.PtrToVal(one.TargetType),
+			Extension:  extension,
+		})
+	}
+
+	for _, part := range slice.Split(createItems, constant.BatchOperationMaxLimit) {
+		if _, err = cli.dbCli.Global.LoadBalancer.BatchCreateTargetGroup(kt,
+			&dslb.TargetGroupBatchCreateReq{Items: part}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to create target group failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	updateItems := make([]dslb.TargetGroupUpdateField, 0, len(updateMap))
+	for id, one := range updateMap {
+		extension, err := convAwsTargetGroupExtension(one)
+		if err != nil {
+			return nil, err
+		}
+
+		updateItems = append(updateItems, dslb.TargetGroupUpdateField{
+			ID:         id,
+			Name:       converter.PtrToVal(one.TargetGroupName),
+			VpcID:      vpcMap[converter.PtrToVal(one.VpcId)],
+			CloudVpcID: converter.PtrToVal(one.VpcId),
+			Protocol:   converter.PtrToVal(one.Protocol),
+			Port:       converter.PtrToVal(one.Port),
+			TargetType: converter.PtrToVal(one.TargetType),
+			Extension:  extension,
+		})
+	}
+
+	for _, part := range slice.Split(updateItems, constant.BatchOperationMaxLimit) {
+		if err = cli.dbCli.Global.LoadBalancer.BatchUpdateTargetGroup(kt,
+			&dslb.TargetGroupBatchUpdateReq{Items: part}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to update target group failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if tgFromDB, err = cli.listTargetGroupFromDB(kt, lb.AccountID, cloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return tgFromDB, nil
+}
+
+// syncTarget 同步目标组中注册的后端服务。
+func (cli *client) syncTarget(kt *kit.Kit, region string, lb corelb.LoadBalancer[corelb.AwsExtension],
+	tg corelb.TargetGroup[corelb.AwsTargetGroupExtension]) error {
+
+	tgID, tgCloudID := tg.ID, tg.CloudID
+	opt := &typelb.AwsTargetListOption{Region: region, CloudTargetGroupID: tgCloudID}
+	targetFromCloud, err := cli.cloudCli.ListTarget(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list target from cloud failed, err: %v, target group: %s, rid: %s", enumor.Aws, err,
+			tgCloudID, kt.Rid)
+		return err
+	}
+
+	targetFromDB, err := cli.listTargetFromDB(kt, tgID)
+	if err != nil {
+		return err
+	}
+
+	// 后端服务的实例和端口已包含在云上ID中，云上变化时表现为删除和新增，无需更新
+	addSlice, _, delCloudIDs := common.Diff[typelb.AwsTarget, corelb.Target](targetFromCloud, targetFromDB,
+		func(typelb.AwsTarget, corelb.Target) bool { return false })
+
+	for _, part := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		deleteReq := &dataservice.BatchDeleteReq{Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "target_group_id", Op: filter.Equal.Factory(), Value: tgID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: part},
+			},
+		}}
+		if err = cli.dbCli.Global.LoadBalancer.BatchDeleteTarget(kt, deleteReq); err != nil {
+			logs.Errorf("[%s] request dataservice to delete target failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	createItems := make([]dslb.TargetCreateField, 0, len(addSlice))
+	for _, one := range addSlice {
+		item := dslb.TargetCreateField{
+			CloudID:            one.GetCloudID(),
+			Vendor:             enumor.Aws,
+			AccountID:          lb.AccountID,
+			LbID:               lb.ID,
+			CloudLbID:          lb.CloudID,
+			TargetGroupID:      tgID,
+			CloudTargetGroupID: tgCloudID,
+			CloudInstID:        one.InstanceKey(),
+			Port:               one.TargetPort(),
+		}
+
+		if instType, exist := awsTargetInstTypeMap[tg.TargetType]; exist {
+			item.InstType = instType
+		}
+
+		createItems = append(createItems, item)
+	}
+
+	for _, part := range slice.Split(createItems, constant.BatchOperationMaxLimit) {
+		if _, err = cli.dbCli.Global.LoadBalancer.BatchCreateTarget(kt,
+			&dslb.TargetBatchCreateReq{Items: part}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to create target failed, err: %v, rid: %s", enumor.Aws,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) getTargetGroupVpcMap(kt *kit.Kit, accountID string, region string,
+	tgs []typelb.AwsTargetGroup) (map[string]string, error) {
+
+	cloudVpcIDs := make([]string, 0, len(tgs))
+	for _, one := range tgs {
+		if len(converter.PtrToVal(one.VpcId)) != 0 {
+			cloudVpcIDs = append(cloudVpcIDs, converter.PtrToVal(one.VpcId))
+		}
+	}
+
+	result := make(map[string]string)
+	if len(cloudVpcIDs) == 0 {
+		return result, nil
+	}
+
+	vpcMap, err := cli.getVpcMap(kt, accountID, region, slice.Unique(cloudVpcIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	for cloudID, vpc := range vpcMap {
+		result[cloudID] = vpc.VpcID
+	}
+
+	return result, nil
+}
+
+// syncLoadBalancerCvmRel 根据db中类型为主机的后端服务，同步负载均衡和主机的关联关系。
+func (cli *client) syncLoadBalancerCvmRel(kt *kit.Kit, lbs []corelb.LoadBalancer[corelb.AwsExtension]) error {
+	if len(lbs) == 0 {
+		return nil
+	}
+
+	lbIDs := make([]string, 0, len(lbs))
+	for _, one := range lbs {
+		lbIDs = append(lbIDs, one.ID)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "lb_id", Op: filter.In.Factory(), Value: lbIDs},
+				&filter.AtomRule{Field: "inst_type", Op: filter.Equal.Factory(), Value: enumor.CvmTargetInstType},
+			},
+		},
+		Fields: []string{"lb_id", "cloud_lb_id", "cloud_inst_id"},
+		Page:   core.NewDefaultBasePage(),
+	}
+	mgr := cvmrelmgr.NewCvmRelManager(cli.dbCli)
+	lbWithCvm := make(map[string]struct{})
+	for {
+		resp, err := cli.dbCli.Global.LoadBalancer.ListTarget(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list cvm target from db failed, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
+			return err
+		}
+
+		for _, one := range resp.Details {
+			mgr.CvmAppendAssResCloudID(one.CloudInstID, enumor.LoadBalancerCloudResType, one.CloudLbID)
+			lbWithCvm[one.LbID] = struct{}{}
+		}
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	syncRelOpt := &cvmrelmgr.SyncRelOption{
+		Vendor:  enumor.Aws,
+		ResType: enumor.LoadBalancerCloudResType,
+	}
+	if err := mgr.SyncRel(kt, syncRelOpt); err != nil {
+		logs.Errorf("[%s] sync load balancer cvm rel failed, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
+		return err
+	}
+
+	// 不再有主机后端服务的负载均衡不会出现在关联关系管理器中，需单独清理其残留的关联关系
+	noCvmLbIDs := make([]string, 0)
+	for _, id := range lbIDs {
+		if _, exist := lbWithCvm[id]; !exist {
+			noCvmLbIDs = append(noCvmLbIDs, id)
+		}
+	}
+
+	for _, part := range slice.Split(noCvmLbIDs, constant.BatchOperationMaxLimit) {
+		deleteReq := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("lb_id", part)}
+		if err := cli.dbCli.Global.LoadBalancer.BatchDeleteCvmRel(kt, deleteReq); err != nil {
+			logs.Errorf("[%s] delete load balancer cvm rel failed, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) listListenerFromDB(kt *kit.Kit, lbID string) (
+	[]corelb.Listener[corelb.AwsListenerExtension], error) {
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("lb_id", lbID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result := make([]corelb.Listener[corelb.AwsListenerExtension], 0)
+	for {
+		resp, err := cli.dbCli.Aws.LoadBalancer.ListListenerExt(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list listener from db failed, err: %v, lbID: %s, rid: %s", enumor.Aws, err,
+				lbID, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+func (cli *client) listTargetGroupFromDB(kt *kit.Kit, accountID string, cloudIDs []string) (
+	[]corelb.TargetGroup[corelb.AwsTargetGroupExtension], error) {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.Aws},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aws.LoadBalancer.ListTargetGroupExt(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list target group from db failed, err: %v, account: %s, rid: %s", enumor.Aws, err,
+			accountID, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func (cli *client) listTargetFromDB(kt *kit.Kit, tgID string) ([]corelb.Target, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("target_group_id", tgID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result := make([]corelb.Target, 0)
+	for {
+		resp, err := cli.dbCli.Global.LoadBalancer.ListTarget(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list target from db failed, err: %v, tgID: %s, rid: %s", enumor.Aws, err,
+				tgID, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// awsListenerTargetGroupIDs 获取监听器默认动作转发的目标组
+func awsListenerTargetGroupIDs(one typelb.AwsListener) []string {
+	if one.Listener == nil {
+		return make([]string, 0)
+	}
+
+	cloudIDs := make([]string, 0)
+	for _, action := range one.DefaultActions {
+		if action == nil {
+			continue
+		}
+
+		if len(converter.PtrToVal(action.TargetGroupArn)) != 0 {
+			cloudIDs = append(cloudIDs, *action.TargetGroupArn)
+		}
+
+		if action.ForwardConfig == nil {
+			continue
+		}
+
+		for _, tg := range action.ForwardConfig.TargetGroups {
+			if tg != nil && len(converter.PtrToVal(tg.TargetGroupArn)) != 0 {
+				cloudIDs = append(cloudIDs, *tg.TargetGroupArn)
+			}
+		}
+	}
+
+	return slice.Unique(cloudIDs)
+}
+
+func convAwsListenerExtension(one typelb.AwsListener) (core.ExtMessage, error) {
+	extension := &corelb.AwsListenerExtension{
+		SslPolicy:           one.SslPolicy,
+		AlpnPolicy:          converter.PtrToSlice(one.AlpnPolicy),
+		CloudTargetGroupIDs: awsListenerTargetGroupIDs(one),
+	}
+
+	extension.CloudCertIDs = make([]string, 0, len(one.Certificates))
+	for _, cert := range one.Certificates {
+		if cert != nil && cert.CertificateArn != nil {
+			extension.CloudCertIDs = append(extension.CloudCertIDs, *cert.CertificateArn)
+		}
+	}
+
+	return core.MarshalStruct(extension)
+}
+
+func convAwsTargetGroupExtension(one typelb.AwsTargetGroup) (core.ExtMessage, error) {
+	extension := &corelb.AwsTargetGroupExtension{
+		CloudLbIDs:          converter.PtrToSlice(one.LoadBalancerArns),
+		HealthCheckEnabled:  one.HealthCheckEnabled,
+		HealthCheckProtocol: one.HealthCheckProtocol,
+		HealthCheckPath:     one.HealthCheckPath,
+		IpAddressType:       one.IpAddressType,
+	}
+
+	return core.MarshalStruct(extension)
+}
+
+func isListenerChange(cloud typelb.AwsListener, db corelb.Listener[corelb.AwsListenerExtension]) bool {
+	if converter.PtrToVal(cloud.Protocol) != db.Protocol || converter.PtrToVal(cloud.Port) != db.Port {
+		return true
+	}
+
+	cloudExt, err := convAwsListenerExtension(cloud)
+	if err != nil {
+		return true
+	}
+
+	dbExt, err := core.MarshalStruct(db.Extension)
+	if err != nil {
+		return true
+	}
+
+	return string(cloudExt) != string(dbExt)
+}
+
+func isTargetGroupChange(cloud typelb.AwsTargetGroup, db corelb.TargetGroup[corelb.AwsTargetGroupExtension]) bool {
+	if converter.PtrToVal(cloud.TargetGroupName) != db.Name || converter.PtrToVal(cloud.Protocol) != db.Protocol ||
+		converter.PtrToVal(cloud.Port) != db.Port || converter.PtrToVal(cloud.VpcId) != db.CloudVpcID ||
+		converter.PtrToVal(cloud.TargetType) != db.TargetType {
+		return true
+	}
+
+	cloudExt, err := convAwsTargetGroupExtension(cloud)
+	if err != nil {
+		return true
+	}
+
+	dbExt, err := core.MarshalStruct(db.Extension)
+	if err != nil {
+		return true
+	}
+
+	return string(cloudExt) != string(dbExt)
+}
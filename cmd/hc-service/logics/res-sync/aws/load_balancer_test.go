@@ -0,0 +1,207 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"hcm/pkg/adaptor/aws"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	fakeclient "hcm/pkg/client/fake"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+const (
+	testAccountID = "account"
+	testRegion    = "us-east-1"
+	testLbID      = "arn:aws:elasticloadbalancing:us-east-1:000000000000:loadbalancer/app/lb/1"
+)
+
+// fakeLoadBalancerCloud 模拟aws负载均衡、监听器、目标组和目标，未实现的接口调用时panic
+type fakeLoadBalancerCloud struct {
+	aws.Aws
+	lbs       map[string]typelb.AwsLoadBalancer
+	listeners map[string][]typelb.AwsListener
+	tgs       map[string]typelb.AwsTargetGroup
+	targets   map[string][]string
+}
+
+// ListLoadBalancer 与aws一致，查询的负载均衡中有不存在的负载均衡时返回错误
+func (f *fakeLoadBalancerCloud) ListLoadBalancer(_ *kit.Kit, opt *typelb.AwsListOption) (*typelb.AwsListResult,
+	error) {
+
+	result := &typelb.AwsListResult{Details: make([]typelb.AwsLoadBalancer, 0)}
+	for _, id := range opt.CloudIDs {
+		lb, exists := f.lbs[id]
+		if !exists {
+			return nil, errors.New(aws.ErrLoadBalancerNotFound + ": " + id)
+		}
+		result.Details = append(result.Details, lb)
+	}
+
+	return result, nil
+}
+
+// ListListener list listeners of the load balancer.
+func (f *fakeLoadBalancerCloud) ListListener(_ *kit.Kit, opt *typelb.AwsListenerListOption) ([]typelb.AwsListener,
+	error) {
+
+	return f.listeners[opt.CloudLoadBalancerID], nil
+}
+
+// ListTargetGroup list target groups.
+func (f *fakeLoadBalancerCloud) ListTargetGroup(_ *kit.Kit, opt *typelb.AwsListOption) (
+	*typelb.AwsTargetGroupListResult, error) {
+
+	result := &typelb.AwsTargetGroupListResult{Details: make([]typelb.AwsTargetGroup, 0)}
+	for _, id := range opt.CloudIDs {
+		if tg, exists := f.tgs[id]; exists {
+			result.Details = append(result.Details, tg)
+		}
+	}
+
+	return result, nil
+}
+
+// ListTarget list instance targets registered to the target group.
+func (f *fakeLoadBalancerCloud) ListTarget(_ *kit.Kit, opt *typelb.AwsTargetListOption) ([]typelb.AwsTarget,
+	error) {
+
+	targets := make([]typelb.AwsTarget, 0)
+	for _, id := range f.targets[opt.CloudTargetGroupID] {
+		targets = append(targets, typelb.AwsTarget{CloudTargetGroupID: opt.CloudTargetGroupID,
+			TargetHealthDescription: &elbv2.TargetHealthDescription{Target: &elbv2.TargetDescription{
+				Id: converter.ValToPtr(id), Port: converter.ValToPtr(int64(80))}}})
+	}
+
+	return targets, nil
+}
+
+func newTargetGroup(name string) typelb.AwsTargetGroup {
+	return typelb.AwsTargetGroup{TargetGroup: &elbv2.TargetGroup{TargetGroupArn: converter.ValToPtr(name + "-arn"),
+		TargetGroupName: converter.ValToPtr(name), Protocol: converter.ValToPtr("HTTP"),
+		Port: converter.ValToPtr(int64(80)), TargetType: converter.ValToPtr(elbv2.TargetTypeEnumInstance),
+		LoadBalancerArns: []*string{converter.ValToPtr(testLbID)}}}
+}
+
+func newListener(tg typelb.AwsTargetGroup) typelb.AwsListener {
+	return typelb.AwsListener{Listener: &elbv2.Listener{ListenerArn: converter.ValToPtr(testLbID + "/listener"),
+		LoadBalancerArn: converter.ValToPtr(testLbID), Protocol: converter.ValToPtr("HTTP"),
+		Port: converter.ValToPtr(int64(80)), DefaultActions: []*elbv2.Action{{
+			Type: converter.ValToPtr(elbv2.ActionTypeEnumForward), TargetGroupArn: tg.TargetGroupArn}}}}
+}
+
+func TestSyncLoadBalancer(t *testing.T) {
+	kt := kit.New()
+	tg1, tg2 := newTargetGroup("tg1"), newTargetGroup("tg2")
+	cloud := &fakeLoadBalancerCloud{
+		lbs: map[string]typelb.AwsLoadBalancer{testLbID: {LoadBalancer: &elbv2.LoadBalancer{
+			LoadBalancerArn: converter.ValToPtr(testLbID), LoadBalancerName: converter.ValToPtr("lb"),
+			Scheme: converter.ValToPtr(elbv2.LoadBalancerSchemeEnumInternetFacing),
+			Type:   converter.ValToPtr(elbv2.LoadBalancerTypeEnumApplication)}}},
+		listeners: map[string][]typelb.AwsListener{testLbID: {newListener(tg1)}},
+		tgs:       map[string]typelb.AwsTargetGroup{*tg1.TargetGroupArn: tg1, *tg2.TargetGroupArn: tg2},
+		targets:   map[string][]string{*tg1.TargetGroupArn: {"i-1", "i-2"}, *tg2.TargetGroupArn: {"i-3"}},
+	}
+
+	server := fakeclient.NewServer(fakeclient.DataServicePrefix)
+	cli := NewClient(fakeclient.NewDataServiceClient(server), cloud)
+
+	params := &SyncBaseParams{AccountID: testAccountID, Region: testRegion, CloudIDs: []string{testLbID}}
+	_, err := cli.LoadBalancer(kt, params, new(SyncLoadBalancerOption))
+	if err != nil {
+		t.Fatalf("sync load balancer failed, err: %v", err)
+	}
+	assertCount(t, server, map[string]int{"load_balancers": 1, "load_balancers/listeners": 1,
+		"load_balancers/target_groups": 1, "load_balancers/targets": 2})
+
+	// 目标组中注销和注册目标后，db中的目标同步删除和新增
+	cloud.targets[*tg1.TargetGroupArn] = []string{"i-1", "i-4"}
+	if _, err = cli.LoadBalancer(kt, params, new(SyncLoadBalancerOption)); err != nil {
+		t.Fatalf("sync changed targets failed, err: %v", err)
+	}
+	assertTargets(t, server, "i-1", "i-4")
+
+	// 监听器转发到新的目标组后，新目标组和其中的目标同步新增，原目标组可能被其他负载均衡使用，不删除
+	cloud.listeners[testLbID] = []typelb.AwsListener{newListener(tg2)}
+	if _, err = cli.LoadBalancer(kt, params, new(SyncLoadBalancerOption)); err != nil {
+		t.Fatalf("sync changed listener failed, err: %v", err)
+	}
+	assertCount(t, server, map[string]int{"load_balancers/listeners": 1, "load_balancers/target_groups": 2})
+	assertTargets(t, server, "i-1", "i-4", "i-3")
+
+	listeners := make([]corelb.Listener[corelb.AwsListenerExtension], 0)
+	if err = server.List("load_balancers/listeners", &listeners); err != nil {
+		t.Fatalf("list listeners failed, err: %v", err)
+	}
+	tgIDs := listeners[0].Extension.CloudTargetGroupIDs
+	if len(tgIDs) != 1 || tgIDs[0] != *tg2.TargetGroupArn {
+		t.Errorf("listener target groups is not updated, target groups: %v", tgIDs)
+	}
+
+	// 云上删除监听器和负载均衡后，db中的监听器和负载均衡被删除
+	cloud.listeners[testLbID] = nil
+	if _, err = cli.LoadBalancer(kt, params, new(SyncLoadBalancerOption)); err != nil {
+		t.Fatalf("sync deleted listener failed, err: %v", err)
+	}
+	assertCount(t, server, map[string]int{"load_balancers/listeners": 0})
+
+	delete(cloud.lbs, testLbID)
+	if err = cli.RemoveLoadBalancerDeleteFromCloud(kt, testAccountID, testRegion); err != nil {
+		t.Fatalf("remove load balancer deleted from cloud failed, err: %v", err)
+	}
+	assertCount(t, server, map[string]int{"load_balancers": 0})
+}
+
+func assertCount(t *testing.T, server *fakeclient.Server, expects map[string]int) {
+	t.Helper()
+
+	for table, expect := range expects {
+		if count := server.Count(table); count != expect {
+			t.Errorf("%s count should be %d, but got %d", table, expect, count)
+		}
+	}
+}
+
+func assertTargets(t *testing.T, server *fakeclient.Server, instIDs ...string) {
+	t.Helper()
+
+	targets := make([]corelb.Target, 0)
+	if err := server.List("load_balancers/targets", &targets); err != nil {
+		t.Fatalf("list targets failed, err: %v", err)
+	}
+
+	got := make(map[string]struct{}, len(targets))
+	for _, one := range targets {
+		got[one.CloudInstID] = struct{}{}
+	}
+
+	for _, id := range instIDs {
+		if _, exists := got[id]; !exists || len(got) != len(instIDs) {
+			t.Errorf("targets should be %v, but got %v", instIDs, got)
+			return
+		}
+	}
+}
@@ -24,9 +24,11 @@ import (
 	"hcm/pkg/adaptor/types/account"
 	typescvm "hcm/pkg/adaptor/types/cvm"
 	typesdisk "hcm/pkg/adaptor/types/disk"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
 	typeseip "hcm/pkg/adaptor/types/eip"
 	firewallrule "hcm/pkg/adaptor/types/firewall-rule"
 	typesimage "hcm/pkg/adaptor/types/image"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
 	typesni "hcm/pkg/adaptor/types/network-interface"
 	typesregion "hcm/pkg/adaptor/types/region"
 	typesresourcegroup "hcm/pkg/adaptor/types/resource-group"
@@ -38,7 +40,9 @@ import (
 	cloudcore "hcm/pkg/api/core/cloud"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
 	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
 	coreimage "hcm/pkg/api/core/cloud/image"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
 	corecloudni "hcm/pkg/api/core/cloud/network-interface"
 	coreregion "hcm/pkg/api/core/cloud/region"
 	coreresourcegroup "hcm/pkg/api/core/cloud/resource-group"
@@ -93,6 +97,12 @@ type CloudResType interface {
 		typesdisk.GcpDisk |
 		typesdisk.AzureDisk |
 
+		typesnapshot.TCloudSnapshot |
+		typesnapshot.HuaWeiSnapshot |
+		typesnapshot.AwsSnapshot |
+		typesnapshot.GcpSnapshot |
+		typesnapshot.AzureSnapshot |
+
 		securitygroup.TCloudSG |
 		securitygroup.HuaWeiSG |
 		securitygroup.AwsSG |
@@ -133,6 +143,14 @@ type CloudResType interface {
 		account.AzureAccount |
 		account.GcpAccount |
 
+		typelb.TCloudLoadBalancer |
+		typelb.TCloudListener |
+		typelb.TCloudTarget |
+		typelb.AwsLoadBalancer |
+		typelb.AwsListener |
+		typelb.AwsTargetGroup |
+		typelb.AwsTarget |
+
 		corerecyclerecord.EipBindInfo |
 		corerecyclerecord.DiskAttachInfo
 }
@@ -179,6 +197,12 @@ type DBResType interface {
 		*coredisk.Disk[coredisk.GcpExtension] |
 		*coredisk.Disk[coredisk.AzureExtension] |
 
+		coresnapshot.DiskSnapshot[coresnapshot.TCloudExtension] |
+		coresnapshot.DiskSnapshot[coresnapshot.HuaWeiExtension] |
+		coresnapshot.DiskSnapshot[coresnapshot.AwsExtension] |
+		coresnapshot.DiskSnapshot[coresnapshot.GcpExtension] |
+		coresnapshot.DiskSnapshot[coresnapshot.AzureExtension] |
+
 		cloudcore.SecurityGroup[cloudcore.TCloudSecurityGroupExtension] |
 		cloudcore.SecurityGroup[cloudcore.HuaWeiSecurityGroupExtension] |
 		cloudcore.SecurityGroup[cloudcore.AwsSecurityGroupExtension] |
@@ -219,6 +243,13 @@ type DBResType interface {
 		coresubaccount.SubAccount[coresubaccount.AzureExtension] |
 		coresubaccount.SubAccount[coresubaccount.GcpExtension] |
 
+		corelb.LoadBalancer[corelb.TCloudExtension] |
+		corelb.LoadBalancer[corelb.AwsExtension] |
+		corelb.Listener[corelb.TCloudListenerExtension] |
+		corelb.Listener[corelb.AwsListenerExtension] |
+		corelb.TargetGroup[corelb.AwsTargetGroupExtension] |
+		corelb.Target |
+
 		corerecyclerecord.EipBindInfo |
 		corerecyclerecord.DiskAttachInfo
 }
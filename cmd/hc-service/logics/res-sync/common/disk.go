@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// GetDiskMap 根据云盘云上ID查询云盘在db中的信息，key为云盘云上ID，云盘未同步时不在返回结果中。
+func GetDiskMap(kt *kit.Kit, dbCli *dataservice.Client, vendor enumor.Vendor, accountID string,
+	cloudDiskIDs []string) (map[string]*DiskDB, error) {
+
+	diskMap := make(map[string]*DiskDB)
+	for _, parts := range slice.Split(slice.Unique(cloudDiskIDs), int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id", "bk_biz_id"},
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+					&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: parts},
+				},
+			},
+			Page: core.NewDefaultBasePage(),
+		}
+		result, err := dbCli.Global.ListDisk(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list disk from db failed, err: %v, account: %s, rid: %s", vendor, err, accountID,
+				kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			diskMap[one.CloudID] = &DiskDB{DiskID: one.ID, BkBizID: one.BkBizID}
+		}
+	}
+
+	return diskMap, nil
+}
+
+// SnapshotBizID 快照所属业务，快照未分配业务时跟随云盘所属业务，云盘被删除后快照保留原有业务。
+func SnapshotBizID(dbBizID int64, disk *DiskDB) int64 {
+	if dbBizID != constant.UnassignedBiz && dbBizID != 0 {
+		return dbBizID
+	}
+
+	if disk == nil {
+		return constant.UnassignedBiz
+	}
+
+	return disk.BkBizID
+}
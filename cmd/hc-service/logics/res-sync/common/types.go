@@ -25,3 +25,9 @@ type VpcDB struct {
 	VpcID      string
 	BkCloudID  int64
 }
+
+// DiskDB 云盘在db中的信息
+type DiskDB struct {
+	DiskID  string
+	BkBizID int64
+}
@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvmrelmgr
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// syncCvmLoadBalancerRel 同步主机和负载均衡的关联关系，关联关系来源于负载均衡中类型为主机的后端服务，
+// 后端服务绑定的主机可能尚未同步到db，此类主机的关联关系将被跳过，待主机同步后再次同步负载均衡时补齐。
+func (mgr *CvmRelManger) syncCvmLoadBalancerRel(kt *kit.Kit, cvmMap map[string]string, opt *SyncRelOption) error {
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	lbMap, err := mgr.getLoadBalancerMap(kt)
+	if err != nil {
+		logs.Errorf("get load balancer map failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if len(lbMap) == 0 {
+		return nil
+	}
+
+	cvmRelMapFromCloud := make(map[string][]string)
+	for cvmCloudID, valueMap := range mgr.cvmAssResMap {
+		cvmID, exist := cvmMap[cvmCloudID]
+		if !exist {
+			logs.Warnf("cvm: %s not found in db, skip sync its load balancer rel, rid: %s", cvmCloudID, kt.Rid)
+			continue
+		}
+
+		cvmRelMapFromCloud[cvmID] = make([]string, 0)
+		for _, lbCloudID := range valueMap[enumor.LoadBalancerCloudResType] {
+			lbID, exist := lbMap[lbCloudID]
+			if !exist {
+				logs.Warnf("load balancer: %s not found in db, rid: %s", lbCloudID, kt.Rid)
+				continue
+			}
+
+			cvmRelMapFromCloud[cvmID] = append(cvmRelMapFromCloud[cvmID], lbID)
+		}
+	}
+
+	lbIDs := make([]string, 0, len(lbMap))
+	for _, id := range lbMap {
+		lbIDs = append(lbIDs, id)
+	}
+
+	cvmRelMapFromDB, err := mgr.getCvmLoadBalancerRelMapFromDB(kt, lbIDs)
+	if err != nil {
+		logs.Errorf("get load_balancer_cvm_rel map from db failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	addRels, delIDs := diffCvmWithAssResRel(cvmRelMapFromCloud, cvmRelMapFromDB)
+
+	if len(addRels) > 0 {
+		if err = mgr.createCvmLoadBalancerRel(kt, addRels); err != nil {
+			return err
+		}
+	}
+
+	if len(delIDs) > 0 {
+		if err = mgr.deleteCvmLoadBalancerRel(kt, delIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (mgr *CvmRelManger) deleteCvmLoadBalancerRel(kt *kit.Kit, ids []uint64) error {
+
+	split := slice.Split(ids, constant.BatchOperationMaxLimit)
+	for _, partIDs := range split {
+		batchDeleteReq := &dataservice.BatchDeleteReq{
+			Filter: tools.ContainersExpression("id", partIDs),
+		}
+
+		if err := mgr.dataCli.Global.LoadBalancer.BatchDeleteCvmRel(kt, batchDeleteReq); err != nil {
+			logs.Errorf("batch delete load_balancer_cvm_rel failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("delete cvm load balancer rel success, count: %d, rid: %s", len(ids), kt.Rid)
+
+	return nil
+}
+
+func (mgr *CvmRelManger) createCvmLoadBalancerRel(kt *kit.Kit, addRels []cvmRelInfo) error {
+	split := slice.Split(addRels, constant.BatchOperationMaxLimit)
+
+	for _, part := range split {
+		lists := make([]dslb.CvmRelCreateField, 0, len(part))
+		for _, one := range part {
+			lists = append(lists, dslb.CvmRelCreateField{
+				LbID:  one.AssResID,
+				CvmID: one.CvmID,
+			})
+		}
+
+		createReq := &dslb.CvmRelBatchCreateReq{
+			Rels: lists,
+		}
+
+		if err := mgr.dataCli.Global.LoadBalancer.BatchCreateCvmRel(kt, createReq); err != nil {
+			logs.Errorf("batch create load_balancer_cvm_rel failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("create cvm load balancer rel success, count: %d, rid: %s", len(addRels), kt.Rid)
+
+	return nil
+}
+
+// getCvmLoadBalancerRelMapFromDB 按负载均衡查询关联关系，以便清理主机已从负载均衡中移除的关联关系
+func (mgr *CvmRelManger) getCvmLoadBalancerRelMapFromDB(kt *kit.Kit, lbIDs []string) (
+	map[string]map[string]cvmRelInfo, error) {
+
+	result := make(map[string]map[string]cvmRelInfo)
+	for _, partIDs := range slice.Split(lbIDs, int(core.DefaultMaxPageLimit)) {
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("lb_id", partIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			respResult, err := mgr.dataCli.Global.LoadBalancer.ListCvmRel(kt, listReq)
+			if err != nil {
+				logs.Errorf("list load balancer cvm rel failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+
+			for _, rel := range respResult.Details {
+				if _, exist := result[rel.CvmID]; !exist {
+					result[rel.CvmID] = make(map[string]cvmRelInfo)
+				}
+
+				result[rel.CvmID][rel.LbID] = cvmRelInfo{
+					RelID:    rel.ID,
+					AssResID: rel.LbID,
+				}
+			}
+
+			if uint(len(respResult.Details)) < listReq.Page.Limit {
+				break
+			}
+
+			listReq.Page.Start += uint32(listReq.Page.Limit)
+		}
+	}
+
+	return result, nil
+}
+
+func (mgr *CvmRelManger) getLoadBalancerMap(kt *kit.Kit) (map[string]string, error) {
+	cloudIDs := slice.Unique(mgr.getAllCvmAssResCloudIDs(enumor.LoadBalancerCloudResType))
+
+	lbMap := make(map[string]string)
+	split := slice.Split(cloudIDs, int(core.DefaultMaxPageLimit))
+	for _, partCloudIDs := range split {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: tools.ContainersExpression("cloud_id", partCloudIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		result, err := mgr.dataCli.Global.LoadBalancer.List(kt, req)
+		if err != nil {
+			logs.Errorf("list load balancer failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			lbMap[one.CloudID] = one.ID
+		}
+	}
+
+	return lbMap, nil
+}
@@ -86,6 +86,8 @@ func (mgr *CvmRelManger) SyncRel(kt *kit.Kit, opt *SyncRelOption) error {
 		err = mgr.syncCvmSGRel(kt, cvmMap, opt)
 	case enumor.EipCloudResType:
 		err = mgr.syncCvmEipRel(kt, cvmMap, opt)
+	case enumor.LoadBalancerCloudResType:
+		err = mgr.syncCvmLoadBalancerRel(kt, cvmMap, opt)
 	}
 	if err != nil {
 		logs.Errorf("sync cvm_%s_rel failed, err: %v, rid: %s", opt.ResType, err, kt.Rid)
@@ -299,13 +301,15 @@ func (mgr *CvmRelManger) validateSyncRelParams(vendor enumor.Vendor, resType enu
 	switch vendor {
 	case enumor.TCloud:
 		switch resType {
-		case enumor.SecurityGroupCloudResType, enumor.DiskCloudResType, enumor.EipCloudResType:
+		case enumor.SecurityGroupCloudResType, enumor.DiskCloudResType, enumor.EipCloudResType,
+			enumor.LoadBalancerCloudResType:
 		default:
 			return fmt.Errorf("vendor: %s cvm and %s are not associated", vendor, resType)
 		}
 	case enumor.Aws:
 		switch resType {
-		case enumor.SecurityGroupCloudResType, enumor.DiskCloudResType, enumor.EipCloudResType:
+		case enumor.SecurityGroupCloudResType, enumor.DiskCloudResType, enumor.EipCloudResType,
+			enumor.LoadBalancerCloudResType:
 		default:
 			return fmt.Errorf("vendor: %s cvm and %s are not associated", vendor, resType)
 		}
@@ -0,0 +1,419 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataservice "hcm/pkg/api/data-service"
+	dssnapshot "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncDiskSnapshotOption ...
+type SyncDiskSnapshotOption struct {
+}
+
+// Validate ...
+func (opt SyncDiskSnapshotOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// DiskSnapshot 同步云盘快照，快照的云盘ID和业务根据db中的源云盘填充。
+func (cli *client) DiskSnapshot(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskSnapshotOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	snapshotFromCloud, err := cli.listDiskSnapshotFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotFromDB, err := cli.listDiskSnapshotFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapshotFromCloud) == 0 && len(snapshotFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typesnapshot.HuaWeiSnapshot,
+		coresnapshot.DiskSnapshot[coresnapshot.HuaWeiExtension]](snapshotFromCloud, snapshotFromDB,
+		isDiskSnapshotChange)
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteDiskSnapshot(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if err = cli.createDiskSnapshot(kt, params.AccountID, params.Region, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateDiskSnapshot(kt, params.AccountID, updateMap, snapshotFromDB); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// RemoveDiskSnapshotDeleteFromCloud ...
+func (cli *client) RemoveDiskSnapshotDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.HuaWei},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.DiskSnapshot.List(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list disk snapshot failed, err: %v, req: %v, rid: %s",
+				enumor.HuaWei, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0, len(resultFromDB.Details))
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listDiskSnapshotFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.GetCloudID())
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteDiskSnapshot(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) deleteDiskSnapshot(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete disk snapshot, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delFromCloud, err := cli.listDiskSnapshotFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delFromCloud) > 0 {
+		logs.Errorf("[%s] validate disk snapshot not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.HuaWei, checkParams, len(delFromCloud), kt.Rid)
+		return fmt.Errorf("validate disk snapshot not exist failed, before delete")
+	}
+
+	deleteReq := &dataservice.BatchDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.HuaWei},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: delCloudIDs},
+			},
+		},
+	}
+	if err = cli.dbCli.Global.DiskSnapshot.BatchDelete(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete disk snapshot failed, err: %v, rid: %s",
+			enumor.HuaWei, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync disk snapshot to delete disk snapshot success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createDiskSnapshot(kt *kit.Kit, accountID string, region string,
+	addSlice []typesnapshot.HuaWeiSnapshot) error {
+
+	if len(addSlice) == 0 {
+		return fmt.Errorf("create disk snapshot, disk snapshots is required")
+	}
+
+	cloudDiskIDs := make([]string, 0, len(addSlice))
+	for _, one := range addSlice {
+		cloudDiskIDs = append(cloudDiskIDs, one.VolumeId)
+	}
+	diskMap, err := common.GetDiskMap(kt, cli.dbCli, enumor.HuaWei, accountID, cloudDiskIDs)
+	if err != nil {
+		return err
+	}
+
+	items := make([]dssnapshot.DiskSnapshotCreateField, 0, len(addSlice))
+	for _, one := range addSlice {
+		item, err := convHuaWeiDiskSnapshot(one)
+		if err != nil {
+			logs.Errorf("[%s] convert disk snapshot %s failed, err: %v, rid: %s", enumor.HuaWei,
+				one.GetCloudID(), err, kt.Rid)
+			return err
+		}
+		item.AccountID = accountID
+		item.Region = region
+
+		disk := diskMap[item.CloudDiskID]
+		item.BkBizID = common.SnapshotBizID(constant.UnassignedBiz, disk)
+		if disk != nil {
+			item.DiskID = disk.DiskID
+		}
+		items = append(items, *item)
+	}
+
+	for _, part := range slice.Split(items, constant.BatchOperationMaxLimit) {
+		req := &dssnapshot.DiskSnapshotBatchCreateReq{Items: part}
+		if _, err = cli.dbCli.Global.DiskSnapshot.BatchCreate(kt, req); err != nil {
+			logs.Errorf("[%s] request dataservice to batch create disk snapshot failed, err: %v, rid: %s",
+				enumor.HuaWei, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync disk snapshot to create disk snapshot success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(addSlice), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateDiskSnapshot(kt *kit.Kit, accountID string,
+	updateMap map[string]typesnapshot.HuaWeiSnapshot,
+	snapshotFromDB []coresnapshot.DiskSnapshot[coresnapshot.HuaWeiExtension]) error {
+
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update disk snapshot, disk snapshots is required")
+	}
+
+	dbMap := make(map[string]coresnapshot.DiskSnapshot[coresnapshot.HuaWeiExtension], len(snapshotFromDB))
+	for _, one := range snapshotFromDB {
+		dbMap[one.ID] = one
+	}
+
+	cloudDiskIDs := make([]string, 0, len(updateMap))
+	for _, one := range updateMap {
+		cloudDiskIDs = append(cloudDiskIDs, one.VolumeId)
+	}
+	diskMap, err := common.GetDiskMap(kt, cli.dbCli, enumor.HuaWei, accountID, cloudDiskIDs)
+	if err != nil {
+		return err
+	}
+
+	items := make([]dssnapshot.DiskSnapshotUpdateField, 0, len(updateMap))
+	for id, one := range updateMap {
+		field, err := convHuaWeiDiskSnapshot(one)
+		if err != nil {
+			logs.Errorf("[%s] convert disk snapshot %s failed, err: %v, rid: %s", enumor.HuaWei,
+				one.GetCloudID(), err, kt.Rid)
+			return err
+		}
+
+		item := dssnapshot.DiskSnapshotUpdateField{
+			ID:               id,
+			Name:             field.Name,
+			Size:             field.Size,
+			Status:           field.Status,
+			CloudCreatedTime: field.CloudCreatedTime,
+			Extension:        field.Extension,
+		}
+		if disk := diskMap[field.CloudDiskID]; disk != nil {
+			item.DiskID = disk.DiskID
+			item.BkBizID = common.SnapshotBizID(dbMap[id].BkBizID, disk)
+		}
+		items = append(items, item)
+	}
+
+	for _, part := range slice.Split(items, constant.BatchOperationMaxLimit) {
+		updateReq := &dssnapshot.DiskSnapshotBatchUpdateReq{Items: part}
+		if err = cli.dbCli.Global.DiskSnapshot.BatchUpdate(kt, updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch update disk snapshot failed, err: %v, rid: %s",
+				enumor.HuaWei, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync disk snapshot to update disk snapshot success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) listDiskSnapshotFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typesnapshot.HuaWeiSnapshot,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typesnapshot.HuaWeiListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+	}
+	result, err := cli.cloudCli.ListSnapshot(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list disk snapshot from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.HuaWei, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listDiskSnapshotFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]coresnapshot.DiskSnapshot[coresnapshot.HuaWeiExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.HuaWei},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.HuaWei.DiskSnapshot.ListExt(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list disk snapshot from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.HuaWei, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// convHuaWeiDiskSnapshot 将云上快照转换为db创建字段，账号、地域、云盘ID和业务由调用方填充。
+func convHuaWeiDiskSnapshot(one typesnapshot.HuaWeiSnapshot) (*dssnapshot.DiskSnapshotCreateField, error) {
+	ext, err := core.MarshalStruct(&coresnapshot.HuaWeiExtension{
+		Description: one.Description,
+		Progress:    one.OsExtendedSnapshotAttributesprogress,
+		ServiceType: one.ServiceType,
+		UpdatedAt:   one.UpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	field := &dssnapshot.DiskSnapshotCreateField{
+		CloudID:          one.GetCloudID(),
+		Name:             converter.PtrToVal(one.Name),
+		Vendor:           enumor.HuaWei,
+		CloudDiskID:      one.VolumeId,
+		Size:             uint64(one.Size),
+		Status:           one.Status,
+		CloudCreatedTime: one.CreatedAt,
+		Extension:        ext,
+	}
+
+	return field, nil
+}
+
+func isDiskSnapshotChange(cloud typesnapshot.HuaWeiSnapshot,
+	db coresnapshot.DiskSnapshot[coresnapshot.HuaWeiExtension]) bool {
+
+	field, err := convHuaWeiDiskSnapshot(cloud)
+	if err != nil {
+		return true
+	}
+
+	// 源云盘在快照之后才同步到db时，需要补充快照的云盘ID
+	if len(db.DiskID) == 0 && len(db.CloudDiskID) != 0 {
+		return true
+	}
+
+	if field.Name != db.Name || field.Size != db.Size || field.Status != db.Status ||
+		field.CloudCreatedTime != db.CloudCreatedTime {
+		return true
+	}
+
+	dbExt, err := core.MarshalStruct(db.Extension)
+	if err != nil {
+		return true
+	}
+
+	return string(dbExt) != string(field.Extension)
+}
@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"testing"
+
+	faketcloud "hcm/pkg/adaptor/fake/tcloud"
+	"hcm/pkg/adaptor/types/disk"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	fakeclient "hcm/pkg/client/fake"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+func TestSyncDiskSnapshot(t *testing.T) {
+	kt := kit.New()
+	cloud, err := faketcloud.New(faketcloud.Option{})
+	if err != nil {
+		t.Fatalf("new fake tcloud failed, err: %v", err)
+	}
+
+	server := fakeclient.NewServer(fakeclient.DataServicePrefix)
+	cli := NewClient(fakeclient.NewDataServiceClient(server), cloud)
+
+	diskResult, err := cloud.CreateDisk(kt, &disk.TCloudDiskCreateOption{Region: testRegion, Zone: testRegion + "-1",
+		DiskType: "CLOUD_PREMIUM", DiskSize: converter.ValToPtr(uint64(20)), DiskChargeType: "POSTPAID_BY_HOUR"})
+	if err != nil || len(diskResult.SuccessCloudIDs) != 1 {
+		t.Fatalf("create disk failed, result: %+v, err: %v", diskResult, err)
+	}
+	cloudDiskID := diskResult.SuccessCloudIDs[0]
+
+	snapshotID, err := cloud.CreateSnapshot(kt, &typesnapshot.TCloudCreateOption{Region: testRegion,
+		CloudDiskID: cloudDiskID, Name: converter.ValToPtr("snapshot")})
+	if err != nil {
+		t.Fatalf("create snapshot failed, err: %v", err)
+	}
+
+	// 源云盘未同步到db时，快照未分配业务，云盘ID为空
+	params := &SyncBaseParams{AccountID: testAccountID, Region: testRegion, CloudIDs: []string{snapshotID}}
+	result, err := cli.DiskSnapshot(kt, params, new(SyncDiskSnapshotOption))
+	if err != nil || len(result.CreatedIds) != 1 {
+		t.Fatalf("sync disk snapshot failed, result: %+v, err: %v", result, err)
+	}
+	snapshot := getSnapshot(t, server)
+	if snapshot.DiskID != "" || snapshot.CloudDiskID != cloudDiskID || snapshot.BkBizID != constant.UnassignedBiz ||
+		snapshot.Status != typesnapshot.TCloudSnapshotNormal {
+		t.Errorf("created snapshot is not as expected, snapshot: %+v", snapshot)
+	}
+
+	// 源云盘同步到db后，快照补充云盘ID，并跟随云盘所属业务
+	diskIDs, err := server.Add("disks", map[string]interface{}{"cloud_id": cloudDiskID, "vendor": enumor.TCloud,
+		"account_id": testAccountID, "region": testRegion, "bk_biz_id": 100})
+	if err != nil {
+		t.Fatalf("add disk failed, err: %v", err)
+	}
+
+	if result, err = cli.DiskSnapshot(kt, params, new(SyncDiskSnapshotOption)); err != nil ||
+		len(result.CreatedIds) != 0 {
+		t.Fatalf("sync disk snapshot after disk synced failed, result: %+v, err: %v", result, err)
+	}
+	if snapshot = getSnapshot(t, server); snapshot.DiskID != diskIDs[0] || snapshot.BkBizID != 100 {
+		t.Errorf("snapshot disk and biz are not updated, snapshot: %+v", snapshot)
+	}
+
+	// 云上删除快照后，db中的快照被删除
+	err = cloud.DeleteSnapshot(kt, &typesnapshot.TCloudDeleteOption{Region: testRegion, CloudIDs: []string{snapshotID}})
+	if err != nil {
+		t.Fatalf("delete snapshot failed, err: %v", err)
+	}
+	if err = cli.RemoveDiskSnapshotDeleteFromCloud(kt, testAccountID, testRegion); err != nil {
+		t.Fatalf("remove disk snapshot deleted from cloud failed, err: %v", err)
+	}
+	assertCount(t, server, map[string]int{"disk_snapshots": 0})
+}
+
+func getSnapshot(t *testing.T, server *fakeclient.Server) coresnapshot.BaseDiskSnapshot {
+	t.Helper()
+
+	snapshots := make([]coresnapshot.BaseDiskSnapshot, 0)
+	if err := server.List("disk_snapshots", &snapshots); err != nil || len(snapshots) != 1 {
+		t.Fatalf("list disk snapshot failed, count: %d, err: %v", len(snapshots), err)
+	}
+
+	return snapshots[0]
+}
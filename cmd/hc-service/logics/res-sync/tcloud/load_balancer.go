@@ -0,0 +1,488 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// tcloudLoadBalancerStatusMap 腾讯云负载均衡状态码与状态名称的映射
+var tcloudLoadBalancerStatusMap = map[uint64]string{
+	typelb.TCloudLoadBalancerCreating: "creating",
+	typelb.TCloudLoadBalancerNormal:   "normal",
+}
+
+// SyncLoadBalancerOption ...
+type SyncLoadBalancerOption struct {
+	// BkBizID 负载均衡创建时，通过同步写入DB，需要传入业务ID
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncLoadBalancerOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// LoadBalancer 同步负载均衡，并同步负载均衡下的监听器和后端服务。
+func (cli *client) LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLoadBalancerOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lbFromCloud, err := cli.listLoadBalancerFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	lbFromDB, err := cli.listLoadBalancerFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lbFromCloud) == 0 && len(lbFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typelb.TCloudLoadBalancer,
+		corelb.LoadBalancer[corelb.TCloudExtension]](lbFromCloud, lbFromDB, isLoadBalancerChange)
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteLoadBalancer(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	createdIDs := make([]string, 0)
+	if len(addSlice) > 0 {
+		createdIDs, err = cli.createLoadBalancer(kt, params.AccountID, params.Region, addSlice, opt.BkBizID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateLoadBalancer(kt, params.AccountID, params.Region, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(lbFromCloud) > 0 {
+		if err = cli.syncLoadBalancerSubRes(kt, params, lbFromCloud); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SyncResult{CreatedIds: createdIDs}, nil
+}
+
+// RemoveLoadBalancerDeleteFromCloud ...
+func (cli *client) RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.TCloud},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.LoadBalancer.List(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list load balancer failed, err: %v, req: %v, rid: %s",
+				enumor.TCloud, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0, len(resultFromDB.Details))
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listLoadBalancerFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.GetCloudID())
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteLoadBalancer(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+// deleteLoadBalancer 删除db中的负载均衡，负载均衡下的监听器、后端服务以及与主机的关联关系由data-service一并删除。
+func (cli *client) deleteLoadBalancer(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete load balancer, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delFromCloud, err := cli.listLoadBalancerFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delFromCloud) > 0 {
+		logs.Errorf("[%s] validate load balancer not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.TCloud, checkParams, len(delFromCloud), kt.Rid)
+		return fmt.Errorf("validate load balancer not exist failed, before delete")
+	}
+
+	deleteReq := &dataservice.BatchDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.TCloud},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: delCloudIDs},
+			},
+		},
+	}
+	if err = cli.dbCli.Global.LoadBalancer.BatchDelete(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete load balancer failed, err: %v, rid: %s",
+			enumor.TCloud, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync load balancer to delete load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.TCloud, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createLoadBalancer(kt *kit.Kit, accountID string, region string,
+	addSlice []typelb.TCloudLoadBalancer, bizID int64) ([]string, error) {
+
+	if len(addSlice) == 0 {
+		return nil, fmt.Errorf("create load balancer, load balancers is required")
+	}
+
+	vpcMap, err := cli.getLoadBalancerVpcMap(kt, accountID, region, addSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dslb.LoadBalancerCreateField, 0, len(addSlice))
+	for _, one := range addSlice {
+		item, err := convTCloudLoadBalancer(one, vpcMap)
+		if err != nil {
+			logs.Errorf("[%s] convert load balancer %s failed, err: %v, rid: %s", enumor.TCloud,
+				one.GetCloudID(), err, kt.Rid)
+			return nil, err
+		}
+		item.AccountID = accountID
+		item.Region = region
+		item.BkBizID = bizID
+		items = append(items, *item)
+	}
+
+	createdIDs := make([]string, 0, len(items))
+	for _, part := range slice.Split(items, constant.BatchOperationMaxLimit) {
+		result, err := cli.dbCli.Global.LoadBalancer.BatchCreate(kt, &dslb.LoadBalancerBatchCreateReq{Items: part})
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to batch create load balancer failed, err: %v, rid: %s",
+				enumor.TCloud, err, kt.Rid)
+			return nil, err
+		}
+		createdIDs = append(createdIDs, result.IDs...)
+	}
+
+	logs.Infof("[%s] sync load balancer to create load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.TCloud, accountID, len(addSlice), kt.Rid)
+
+	return createdIDs, nil
+}
+
+func (cli *client) updateLoadBalancer(kt *kit.Kit, accountID string, region string,
+	updateMap map[string]typelb.TCloudLoadBalancer) error {
+
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update load balancer, load balancers is required")
+	}
+
+	vpcMap, err := cli.getLoadBalancerVpcMap(kt, accountID, region, converter.MapValueToSlice(updateMap))
+	if err != nil {
+		return err
+	}
+
+	items := make([]dslb.LoadBalancerUpdateField, 0, len(updateMap))
+	for id, one := range updateMap {
+		field, err := convTCloudLoadBalancer(one, vpcMap)
+		if err != nil {
+			logs.Errorf("[%s] convert load balancer %s failed, err: %v, rid: %s", enumor.TCloud,
+				one.GetCloudID(), err, kt.Rid)
+			return err
+		}
+
+		items = append(items, dslb.LoadBalancerUpdateField{
+			ID:               id,
+			Name:             field.Name,
+			Zones:            field.Zones,
+			VpcID:            field.VpcID,
+			CloudVpcID:       field.CloudVpcID,
+			NetworkType:      field.NetworkType,
+			IPVersion:        field.IPVersion,
+			Status:           field.Status,
+			Domain:           field.Domain,
+			PublicIPs:        field.PublicIPs,
+			PrivateIPs:       field.PrivateIPs,
+			CloudCreatedTime: field.CloudCreatedTime,
+			Extension:        field.Extension,
+		})
+	}
+
+	for _, part := range slice.Split(items, constant.BatchOperationMaxLimit) {
+		updateReq := &dslb.LoadBalancerBatchUpdateReq{Items: part}
+		if err = cli.dbCli.Global.LoadBalancer.BatchUpdate(kt, updateReq); err != nil {
+			logs.Errorf("[%s] request dataservice to batch update load balancer failed, err: %v, rid: %s",
+				enumor.TCloud, err, kt.Rid)
+			return err
+		}
+	}
+
+	logs.Infof("[%s] sync load balancer to update load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.TCloud, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+// getLoadBalancerVpcMap 获取负载均衡所属vpc在db中的信息，vpc未同步时负载均衡的vpc_id为空，不影响负载均衡同步。
+func (cli *client) getLoadBalancerVpcMap(kt *kit.Kit, accountID string, region string,
+	lbs []typelb.TCloudLoadBalancer) (map[string]*common.VpcDB, error) {
+
+	cloudVpcIDs := make([]string, 0, len(lbs))
+	for _, one := range lbs {
+		if len(converter.PtrToVal(one.VpcId)) != 0 {
+			cloudVpcIDs = append(cloudVpcIDs, converter.PtrToVal(one.VpcId))
+		}
+	}
+
+	if len(cloudVpcIDs) == 0 {
+		return make(map[string]*common.VpcDB), nil
+	}
+
+	return cli.getVpcMap(kt, accountID, region, slice.Unique(cloudVpcIDs))
+}
+
+func (cli *client) listLoadBalancerFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typelb.TCloudLoadBalancer,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typelb.TCloudListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+		Page: &adcore.TCloudPage{
+			Offset: 0,
+			Limit:  adcore.TCloudQueryLimit,
+		},
+	}
+	result, err := cli.cloudCli.ListLoadBalancer(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list load balancer from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.TCloud, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listLoadBalancerFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]corelb.LoadBalancer[corelb.TCloudExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.TCloud},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.TCloud.LoadBalancer.ListExt(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list load balancer from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.TCloud, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// convTCloudLoadBalancer 将云上负载均衡转换为db创建字段，账号、地域和业务由调用方填充。
+func convTCloudLoadBalancer(one typelb.TCloudLoadBalancer, vpcMap map[string]*common.VpcDB) (
+	*dslb.LoadBalancerCreateField, error) {
+
+	networkType := enumor.InternalLoadBalancer
+	vips := converter.PtrToSlice(one.LoadBalancerVips)
+	publicIPs, privateIPs := make([]string, 0), vips
+	if converter.PtrToVal(one.LoadBalancerType) == typelb.TCloudOpenLoadBalancer {
+		networkType = enumor.PublicLoadBalancer
+		publicIPs, privateIPs = vips, make([]string, 0)
+	}
+
+	zones := converter.PtrToSlice(one.Zones)
+	if len(zones) == 0 && one.MasterZone != nil && one.MasterZone.Zone != nil {
+		zones = []string{*one.MasterZone.Zone}
+	}
+
+	backupZones := make([]string, 0, len(one.BackupZoneSet))
+	for _, zone := range one.BackupZoneSet {
+		if zone != nil && zone.Zone != nil {
+			backupZones = append(backupZones, *zone.Zone)
+		}
+	}
+
+	domain := converter.PtrToVal(one.LoadBalancerDomain)
+	if len(domain) == 0 {
+		domain = converter.PtrToVal(one.Domain)
+	}
+
+	extension := &corelb.TCloudExtension{
+		Forward:               one.Forward,
+		CloudSubnetID:         one.SubnetId,
+		CloudSecurityGroupIDs: converter.PtrToSlice(one.SecureGroups),
+		BackupZones:           backupZones,
+		ChargeType:            one.ChargeType,
+		VipIsp:                one.VipIsp,
+		AddressIPv6:           one.AddressIPv6,
+	}
+	if one.NetworkAttributes != nil {
+		extension.InternetChargeType = one.NetworkAttributes.InternetChargeType
+		extension.InternetMaxBandwidthOut = one.NetworkAttributes.InternetMaxBandwidthOut
+	}
+
+	extMsg, err := core.MarshalStruct(extension)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudVpcID := converter.PtrToVal(one.VpcId)
+	vpcID := ""
+	if vpc, exist := vpcMap[cloudVpcID]; exist {
+		vpcID = vpc.VpcID
+	}
+
+	return &dslb.LoadBalancerCreateField{
+		CloudID:          one.GetCloudID(),
+		Name:             converter.PtrToVal(one.LoadBalancerName),
+		Vendor:           enumor.TCloud,
+		Zones:            zones,
+		VpcID:            vpcID,
+		CloudVpcID:       cloudVpcID,
+		NetworkType:      networkType,
+		IPVersion:        converter.PtrToVal(one.AddressIPVersion),
+		Status:           tcloudLoadBalancerStatusMap[converter.PtrToVal(one.Status)],
+		Domain:           domain,
+		PublicIPs:        publicIPs,
+		PrivateIPs:       privateIPs,
+		CloudCreatedTime: converter.PtrToVal(one.CreateTime),
+		Extension:        extMsg,
+	}, nil
+}
+
+func isLoadBalancerChange(cloud typelb.TCloudLoadBalancer, db corelb.LoadBalancer[corelb.TCloudExtension]) bool {
+	field, err := convTCloudLoadBalancer(cloud, nil)
+	if err != nil {
+		return true
+	}
+
+	if field.Name != db.Name || field.Status != db.Status || field.Domain != db.Domain ||
+		field.CloudVpcID != db.CloudVpcID || field.NetworkType != db.NetworkType || field.IPVersion != db.IPVersion {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(field.Zones, db.Zones) || !assert.IsStringSliceEqual(field.PublicIPs,
+		db.PublicIPs) || !assert.IsStringSliceEqual(field.PrivateIPs, db.PrivateIPs) {
+		return true
+	}
+
+	dbExt, err := core.MarshalStruct(db.Extension)
+	if err != nil {
+		return true
+	}
+
+	return string(dbExt) != string(field.Extension)
+}
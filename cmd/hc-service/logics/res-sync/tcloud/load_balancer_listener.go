@@ -0,0 +1,454 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	cvmrelmgr "hcm/cmd/hc-service/logics/res-sync/cvm-rel-manager"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// syncLoadBalancerSubRes 同步负载均衡下的监听器和后端服务，负载均衡需已同步到db。
+func (cli *client) syncLoadBalancerSubRes(kt *kit.Kit, params *SyncBaseParams,
+	lbs []typelb.TCloudLoadBalancer) error {
+
+	cloudIDs := make([]string, 0, len(lbs))
+	for _, one := range lbs {
+		cloudIDs = append(cloudIDs, one.GetCloudID())
+	}
+
+	lbFromDB, err := cli.listLoadBalancerFromDB(kt, &SyncBaseParams{AccountID: params.AccountID,
+		Region: params.Region, CloudIDs: cloudIDs})
+	if err != nil {
+		return err
+	}
+
+	for _, lb := range lbFromDB {
+		listenerIDMap, err := cli.syncListener(kt, params.Region, lb)
+		if err != nil {
+			return err
+		}
+
+		if err = cli.syncTarget(kt, params.Region, lb, listenerIDMap); err != nil {
+			return err
+		}
+	}
+
+	return cli.syncLoadBalancerCvmRel(kt, lbFromDB)
+}
+
+// syncListener 同步负载均衡下的监听器，返回监听器云上ID与db ID的映射。
+func (cli *client) syncListener(kt *kit.Kit, region string, lb corelb.LoadBalancer[corelb.TCloudExtension]) (
+	map[string]string, error) {
+
+	opt := &typelb.TCloudListenerListOption{Region: region, CloudLoadBalancerID: lb.CloudID}
+	listenerFromCloud, err := cli.cloudCli.ListListener(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list listener from cloud failed, err: %v, lb: %s, rid: %s", enumor.TCloud, err,
+			lb.CloudID, kt.Rid)
+		return nil, err
+	}
+
+	listenerFromDB, err := cli.listListenerFromDB(kt, lb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typelb.TCloudListener,
+		corelb.Listener[corelb.TCloudListenerExtension]](listenerFromCloud, listenerFromDB, isListenerChange)
+
+	for _, part := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		deleteReq := &dataservice.BatchDeleteReq{Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "lb_id", Op: filter.Equal.Factory(), Value: lb.ID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: part},
+			},
+		}}
+		if err = cli.dbCli.Global.LoadBalancer.BatchDeleteListener(kt, deleteReq); err != nil {
+			logs.Errorf("[%s] request dataservice to delete listener failed, err: %v, rid: %s", enumor.TCloud,
+				err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	createItems := make([]dslb.ListenerCreateField, 0, len(addSlice))
+	for _, one := range addSlice {
+		extension, err := convTCloudListenerExtension(one)
+		if err != nil {
+			return nil, err
+		}
+
+		createItems = append(createItems, dslb.ListenerCreateField{
+			CloudID:   one.GetCloudID(),
+			Name:      converter.PtrToVal(one.ListenerName),
+			Vendor:    enumor.TCloud,
+			AccountID: lb.AccountID,
+			LbID:      lb.ID,
+			CloudLbID: lb.CloudID,
+			Protocol:  converter.PtrToVal(one.Protocol),
+			Port:      converter.PtrToVal(one.Port),
+			Extension: extension,
+		})
+	}
+
+	for _, part := range slice.Split(createItems, constant.BatchOperationMaxLimit) {
+		if _, err = cli.dbCli.Global.LoadBalancer.BatchCreateListener(kt,
+			&dslb.ListenerBatchCreateReq{Items: part}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to create listener failed, err: %v, rid: %s", enumor.TCloud,
+				err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	updateItems := make([]dslb.ListenerUpdateField, 0, len(updateMap))
+	for id, one := range updateMap {
+		extension, err := convTCloudListenerExtension(one)
+		if err != nil {
+			return nil, err
+		}
+
+		updateItems = append(updateItems, dslb.ListenerUpdateField{
+			ID:        id,
+			Name:      converter.PtrToVal(one.ListenerName),
+			Protocol:  converter.PtrToVal(one.Protocol),
+			Port:      converter.PtrToVal(one.Port),
+			Extension: extension,
+		})
+	}
+
+	for _, part := range slice.Split(updateItems, constant.BatchOperationMaxLimit) {
+		if err = cli.dbCli.Global.LoadBalancer.BatchUpdateListener(kt,
+			&dslb.ListenerBatchUpdateReq{Items: part}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to update listener failed, err: %v, rid: %s", enumor.TCloud,
+				err, kt.Rid)
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if listenerFromDB, err = cli.listListenerFromDB(kt, lb.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	listenerIDMap := make(map[string]string, len(listenerFromDB))
+	for _, one := range listenerFromDB {
+		listenerIDMap[one.CloudID] = one.ID
+	}
+
+	return listenerIDMap, nil
+}
+
+// syncTarget 同步负载均衡下绑定到监听器或转发规则上的后端服务。
+func (cli *client) syncTarget(kt *kit.Kit, region string, lb corelb.LoadBalancer[corelb.TCloudExtension],
+	listenerIDMap map[string]string) error {
+
+	opt := &typelb.TCloudListenerListOption{Region: region, CloudLoadBalancerID: lb.CloudID}
+	targetFromCloud, err := cli.cloudCli.ListTarget(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list target from cloud failed, err: %v, lb: %s, rid: %s", enumor.TCloud, err,
+			lb.CloudID, kt.Rid)
+		return err
+	}
+
+	targetFromDB, err := cli.listTargetFromDB(kt, lb.ID)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typelb.TCloudTarget, corelb.Target](targetFromCloud,
+		targetFromDB, isTargetChange)
+
+	for _, part := range slice.Split(delCloudIDs, constant.BatchOperationMaxLimit) {
+		deleteReq := &dataservice.BatchDeleteReq{Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "lb_id", Op: filter.Equal.Factory(), Value: lb.ID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: part},
+			},
+		}}
+		if err = cli.dbCli.Global.LoadBalancer.BatchDeleteTarget(kt, deleteReq); err != nil {
+			logs.Errorf("[%s] request dataservice to delete target failed, err: %v, rid: %s", enumor.TCloud,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	createItems := make([]dslb.TargetCreateField, 0, len(addSlice))
+	for _, one := range addSlice {
+		createItems = append(createItems, dslb.TargetCreateField{
+			CloudID:            one.GetCloudID(),
+			Vendor:             enumor.TCloud,
+			AccountID:          lb.AccountID,
+			LbID:               lb.ID,
+			CloudLbID:          lb.CloudID,
+			ListenerID:         listenerIDMap[one.CloudListenerID],
+			CloudListenerID:    one.CloudListenerID,
+			CloudRuleID:        one.CloudRuleID,
+			InstType:           enumor.TargetInstType(converter.PtrToVal(one.Type)),
+			CloudInstID:        one.InstanceKey(),
+			PrivateIPAddresses: converter.PtrToSlice(one.PrivateIpAddresses),
+			PublicIPAddresses:  converter.PtrToSlice(one.PublicIpAddresses),
+			Port:               converter.PtrToVal(one.Port),
+			Weight:             one.Weight,
+		})
+	}
+
+	for _, part := range slice.Split(createItems, constant.BatchOperationMaxLimit) {
+		if _, err = cli.dbCli.Global.LoadBalancer.BatchCreateTarget(kt,
+			&dslb.TargetBatchCreateReq{Items: part}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to create target failed, err: %v, rid: %s", enumor.TCloud,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	updateItems := make([]dslb.TargetUpdateField, 0, len(updateMap))
+	for id, one := range updateMap {
+		updateItems = append(updateItems, dslb.TargetUpdateField{
+			ID:                 id,
+			ListenerID:         listenerIDMap[one.CloudListenerID],
+			PrivateIPAddresses: converter.PtrToSlice(one.PrivateIpAddresses),
+			PublicIPAddresses:  converter.PtrToSlice(one.PublicIpAddresses),
+			Weight:             one.Weight,
+		})
+	}
+
+	for _, part := range slice.Split(updateItems, constant.BatchOperationMaxLimit) {
+		if err = cli.dbCli.Global.LoadBalancer.BatchUpdateTarget(kt,
+			&dslb.TargetBatchUpdateReq{Items: part}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to update target failed, err: %v, rid: %s", enumor.TCloud,
+				err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncLoadBalancerCvmRel 根据db中类型为主机的后端服务，同步负载均衡和主机的关联关系。
+func (cli *client) syncLoadBalancerCvmRel(kt *kit.Kit, lbs []corelb.LoadBalancer[corelb.TCloudExtension]) error {
+	if len(lbs) == 0 {
+		return nil
+	}
+
+	lbIDs := make([]string, 0, len(lbs))
+	for _, one := range lbs {
+		lbIDs = append(lbIDs, one.ID)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "lb_id", Op: filter.In.Factory(), Value: lbIDs},
+				&filter.AtomRule{Field: "inst_type", Op: filter.Equal.Factory(), Value: enumor.CvmTargetInstType},
+			},
+		},
+		Fields: []string{"lb_id", "cloud_lb_id", "cloud_inst_id"},
+		Page:   core.NewDefaultBasePage(),
+	}
+	mgr := cvmrelmgr.NewCvmRelManager(cli.dbCli)
+	lbWithCvm := make(map[string]struct{})
+	for {
+		resp, err := cli.dbCli.Global.LoadBalancer.ListTarget(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list cvm target from db failed, err: %v, rid: %s", enumor.TCloud, err, kt.Rid)
+			return err
+		}
+
+		for _, one := range resp.Details {
+			mgr.CvmAppendAssResCloudID(one.CloudInstID, enumor.LoadBalancerCloudResType, one.CloudLbID)
+			lbWithCvm[one.LbID] = struct{}{}
+		}
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	syncRelOpt := &cvmrelmgr.SyncRelOption{
+		Vendor:  enumor.TCloud,
+		ResType: enumor.LoadBalancerCloudResType,
+	}
+	if err := mgr.SyncRel(kt, syncRelOpt); err != nil {
+		logs.Errorf("[%s] sync load balancer cvm rel failed, err: %v, rid: %s", enumor.TCloud, err, kt.Rid)
+		return err
+	}
+
+	// 不再有主机后端服务的负载均衡不会出现在关联关系管理器中，需单独清理其残留的关联关系
+	noCvmLbIDs := make([]string, 0)
+	for _, id := range lbIDs {
+		if _, exist := lbWithCvm[id]; !exist {
+			noCvmLbIDs = append(noCvmLbIDs, id)
+		}
+	}
+
+	for _, part := range slice.Split(noCvmLbIDs, constant.BatchOperationMaxLimit) {
+		deleteReq := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("lb_id", part)}
+		if err := cli.dbCli.Global.LoadBalancer.BatchDeleteCvmRel(kt, deleteReq); err != nil {
+			logs.Errorf("[%s] delete load balancer cvm rel failed, err: %v, rid: %s", enumor.TCloud, err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cli *client) listListenerFromDB(kt *kit.Kit, lbID string) (
+	[]corelb.Listener[corelb.TCloudListenerExtension], error) {
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("lb_id", lbID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result := make([]corelb.Listener[corelb.TCloudListenerExtension], 0)
+	for {
+		resp, err := cli.dbCli.TCloud.LoadBalancer.ListListenerExt(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list listener from db failed, err: %v, lbID: %s, rid: %s", enumor.TCloud, err,
+				lbID, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+func convTCloudListenerExtension(one typelb.TCloudListener) (core.ExtMessage, error) {
+	extension := &corelb.TCloudListenerExtension{
+		EndPort:           one.EndPort,
+		Scheduler:         one.Scheduler,
+		SessionExpireTime: one.SessionExpireTime,
+		SniSwitch:         one.SniSwitch,
+		TargetType:        one.TargetType,
+	}
+
+	if one.Certificate != nil {
+		extension.CloudCertID = one.Certificate.CertId
+	}
+
+	if one.TargetGroup != nil {
+		extension.CloudTargetGroupID = one.TargetGroup.TargetGroupId
+	}
+
+	extension.Rules = make([]corelb.TCloudRule, 0, len(one.Rules))
+	for _, rule := range one.Rules {
+		if rule == nil {
+			continue
+		}
+
+		extension.Rules = append(extension.Rules, corelb.TCloudRule{
+			CloudID:   converter.PtrToVal(rule.LocationId),
+			Domain:    rule.Domain,
+			Url:       rule.Url,
+			Scheduler: rule.Scheduler,
+		})
+	}
+
+	return core.MarshalStruct(extension)
+}
+
+func isListenerChange(cloud typelb.TCloudListener, db corelb.Listener[corelb.TCloudListenerExtension]) bool {
+	if converter.PtrToVal(cloud.ListenerName) != db.Name || converter.PtrToVal(cloud.Protocol) != db.Protocol ||
+		converter.PtrToVal(cloud.Port) != db.Port {
+		return true
+	}
+
+	cloudExt, err := convTCloudListenerExtension(cloud)
+	if err != nil {
+		return true
+	}
+
+	dbExt, err := core.MarshalStruct(db.Extension)
+	if err != nil {
+		return true
+	}
+
+	return string(cloudExt) != string(dbExt)
+}
+
+func isTargetChange(cloud typelb.TCloudTarget, db corelb.Target) bool {
+	if !assert.IsPtrInt64Equal(cloud.Weight, db.Weight) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(converter.PtrToSlice(cloud.PrivateIpAddresses), db.PrivateIPAddresses) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(converter.PtrToSlice(cloud.PublicIpAddresses), db.PublicIPAddresses) {
+		return true
+	}
+
+	return false
+}
+
+func (cli *client) listTargetFromDB(kt *kit.Kit, lbID string) ([]corelb.Target, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("lb_id", lbID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result := make([]corelb.Target, 0)
+	for {
+		resp, err := cli.dbCli.Global.LoadBalancer.ListTarget(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list target from db failed, err: %v, lbID: %s, rid: %s", enumor.TCloud, err,
+				lbID, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, resp.Details...)
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,153 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"testing"
+
+	faketcloud "hcm/pkg/adaptor/fake/tcloud"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	fakeclient "hcm/pkg/client/fake"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+const (
+	testAccountID = "account"
+	testRegion    = "ap-guangzhou"
+)
+
+func TestSyncLoadBalancer(t *testing.T) {
+	kt := kit.New()
+	cloud, err := faketcloud.New(faketcloud.Option{})
+	if err != nil {
+		t.Fatalf("new fake tcloud failed, err: %v", err)
+	}
+
+	server := fakeclient.NewServer(fakeclient.DataServicePrefix)
+	cvm := corecvm.BaseCvm{CloudID: "ins-fake", Vendor: enumor.TCloud, AccountID: testAccountID, Region: testRegion}
+	if _, err = server.Add("cvms", cvm); err != nil {
+		t.Fatalf("add cvm failed, err: %v", err)
+	}
+	cli := NewClient(fakeclient.NewDataServiceClient(server), cloud)
+
+	result, err := cloud.CreateLoadBalancer(kt, &typelb.TCloudCreateOption{Region: testRegion,
+		LoadBalancerType: typelb.TCloudOpenLoadBalancer, LoadBalancerName: converter.ValToPtr("lb")})
+	if err != nil || len(result.SuccessCloudIDs) != 1 {
+		t.Fatalf("create load balancer failed, result: %+v, err: %v", result, err)
+	}
+	lbID := result.SuccessCloudIDs[0]
+
+	listenerIDs, err := cloud.SetListeners(kt, testRegion, lbID, []typelb.TCloudListener{
+		{ListenerName: converter.ValToPtr("tcp"), Protocol: converter.ValToPtr("TCP"),
+			Port: converter.ValToPtr(int64(80))},
+		{ListenerName: converter.ValToPtr("udp"), Protocol: converter.ValToPtr("UDP"),
+			Port: converter.ValToPtr(int64(53))},
+	})
+	if err != nil {
+		t.Fatalf("set listeners failed, err: %v", err)
+	}
+
+	cvmTarget := typelb.TCloudTarget{CloudListenerID: listenerIDs[0], TCloudBackend: &typelb.TCloudBackend{
+		Type: converter.ValToPtr(string(enumor.CvmTargetInstType)), InstanceId: converter.ValToPtr(cvm.CloudID),
+		Port: converter.ValToPtr(int64(8080)), Weight: converter.ValToPtr(int64(10)),
+		PrivateIpAddresses: []*string{converter.ValToPtr("10.0.0.2")}}}
+	eniTarget := typelb.TCloudTarget{CloudListenerID: listenerIDs[1], TCloudBackend: &typelb.TCloudBackend{
+		Type: converter.ValToPtr(string(enumor.EniTargetInstType)), Port: converter.ValToPtr(int64(5353)),
+		Weight: converter.ValToPtr(int64(10)), PrivateIpAddresses: []*string{converter.ValToPtr("10.0.0.3")}}}
+	if err = cloud.SetTargets(kt, testRegion, lbID, []typelb.TCloudTarget{cvmTarget, eniTarget}); err != nil {
+		t.Fatalf("set targets failed, err: %v", err)
+	}
+
+	params := &SyncBaseParams{AccountID: testAccountID, Region: testRegion, CloudIDs: []string{lbID}}
+	syncResult, err := cli.LoadBalancer(kt, params, &SyncLoadBalancerOption{BkBizID: 1})
+	if err != nil || len(syncResult.CreatedIds) != 1 {
+		t.Fatalf("sync load balancer failed, result: %+v, err: %v", syncResult, err)
+	}
+	assertCount(t, server, map[string]int{"load_balancers": 1, "load_balancers/listeners": 2,
+		"load_balancers/targets": 2, "load_balancer_cvm_rels": 1})
+
+	// 云上修改监听器名称、删除监听器、修改后端服务权重后，db中的监听器和后端服务同步修改和删除
+	_, err = cloud.SetListeners(kt, testRegion, lbID, []typelb.TCloudListener{{ListenerId: &listenerIDs[0],
+		ListenerName: converter.ValToPtr("tcp-renamed"), Protocol: converter.ValToPtr("TCP"),
+		Port: converter.ValToPtr(int64(80))}})
+	if err != nil {
+		t.Fatalf("update listeners failed, err: %v", err)
+	}
+	cvmTarget.Weight = converter.ValToPtr(int64(20))
+	if err = cloud.SetTargets(kt, testRegion, lbID, []typelb.TCloudTarget{cvmTarget}); err != nil {
+		t.Fatalf("update targets failed, err: %v", err)
+	}
+
+	if _, err = cli.LoadBalancer(kt, params, new(SyncLoadBalancerOption)); err != nil {
+		t.Fatalf("sync changed load balancer failed, err: %v", err)
+	}
+	assertCount(t, server, map[string]int{"load_balancers": 1, "load_balancers/listeners": 1,
+		"load_balancers/targets": 1, "load_balancer_cvm_rels": 1})
+
+	listeners := make([]corelb.Listener[corelb.TCloudListenerExtension], 0)
+	if err = server.List("load_balancers/listeners", &listeners); err != nil {
+		t.Fatalf("list listeners failed, err: %v", err)
+	}
+	if listeners[0].CloudID != listenerIDs[0] || listeners[0].Name != "tcp-renamed" {
+		t.Errorf("listener is not updated, listener: %+v", listeners[0])
+	}
+
+	targets := make([]corelb.Target, 0)
+	if err = server.List("load_balancers/targets", &targets); err != nil {
+		t.Fatalf("list targets failed, err: %v", err)
+	}
+	if converter.PtrToVal(targets[0].Weight) != 20 || targets[0].ListenerID != listeners[0].ID {
+		t.Errorf("target is not updated, target: %+v", targets[0])
+	}
+
+	// 云上解绑全部后端服务后，负载均衡和主机的关联关系一并删除
+	if err = cloud.SetTargets(kt, testRegion, lbID, nil); err != nil {
+		t.Fatalf("unbind targets failed, err: %v", err)
+	}
+	if _, err = cli.LoadBalancer(kt, params, new(SyncLoadBalancerOption)); err != nil {
+		t.Fatalf("sync unbound load balancer failed, err: %v", err)
+	}
+	assertCount(t, server, map[string]int{"load_balancers": 1, "load_balancers/listeners": 1,
+		"load_balancers/targets": 0, "load_balancer_cvm_rels": 0})
+
+	// 云上删除负载均衡后，db中的负载均衡被删除
+	if err = cloud.DeleteLoadBalancer(kt, &typelb.TCloudDeleteOption{Region: testRegion,
+		CloudIDs: []string{lbID}}); err != nil {
+		t.Fatalf("delete load balancer failed, err: %v", err)
+	}
+	if err = cli.RemoveLoadBalancerDeleteFromCloud(kt, testAccountID, testRegion); err != nil {
+		t.Fatalf("remove load balancer deleted from cloud failed, err: %v", err)
+	}
+	assertCount(t, server, map[string]int{"load_balancers": 0})
+}
+
+func assertCount(t *testing.T, server *fakeclient.Server, expects map[string]int) {
+	t.Helper()
+
+	for table, expect := range expects {
+		if count := server.Count(table); count != expect {
+			t.Errorf("%s count should be %d, but got %d", table, expect, count)
+		}
+	}
+}
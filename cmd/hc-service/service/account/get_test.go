@@ -28,6 +28,7 @@ import (
 	"hcm/cmd/hc-service/logics/cloud-adaptor"
 	"hcm/pkg/api/core/cloud"
 	hsaccount "hcm/pkg/api/hc-service/account"
+	"hcm/pkg/cc"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
 	"hcm/pkg/tools/json"
@@ -54,8 +55,14 @@ func Test_TCloudGetResCountBySecret(t *testing.T) {
 		return
 	}
 
+	ad, err := cloudadaptor.NewCloudAdaptorClient(nil, cc.FakeCloud{})
+	if err != nil {
+		t.Errorf("fail to new cloud adaptor client, err: %v", err)
+		return
+	}
+
 	svc := &service{
-		ad: cloudadaptor.NewCloudAdaptorClient(nil),
+		ad: ad,
 	}
 	got, err := svc.TCloudGetResCountBySecret(&rest.Contexts{Kit: kit.New(), Request: restful.NewRequest(request)})
 	if err != nil {
@@ -97,8 +104,14 @@ func Test_AwsGetResCountBySecret(t *testing.T) {
 		return
 	}
 
+	ad, err := cloudadaptor.NewCloudAdaptorClient(nil, cc.FakeCloud{})
+	if err != nil {
+		t.Errorf("fail to new cloud adaptor client, err: %v", err)
+		return
+	}
+
 	svc := &service{
-		ad: cloudadaptor.NewCloudAdaptorClient(nil),
+		ad: ad,
 	}
 	got, err := svc.AwsGetResCountBySecret(&rest.Contexts{Kit: kit.New(), Request: restful.NewRequest(request)})
 	if err != nil {
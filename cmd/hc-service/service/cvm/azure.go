@@ -89,7 +89,7 @@ func (svc *cvmSvc) CreateAzureCvm(cts *rest.Contexts) (interface{}, error) {
 	return &protocvm.AzureCreateResp{CloudID: cloudID}, nil
 }
 
-func (svc *cvmSvc) createAzureCvm(kt *kit.Kit, azureCli *azure.Azure, req *protocvm.AzureCreateReq) (
+func (svc *cvmSvc) createAzureCvm(kt *kit.Kit, azureCli azure.Azure, req *protocvm.AzureCreateReq) (
 	string, error) {
 
 	listImageReq := &core.ListReq{
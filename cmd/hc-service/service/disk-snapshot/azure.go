@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"fmt"
+
+	syncazure "hcm/cmd/hc-service/logics/res-sync/azure"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// CreateAzureDiskSnapshot create azure disk snapshot from disk, snapshot is created in the disk's resource group.
+func (svc *service) CreateAzureDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeCreateReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	diskData, err := svc.dataCli.Azure.RetrieveDisk(cts.Kit.Ctx, cts.Kit.Header(), req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if diskData.Extension == nil {
+		return nil, errf.Newf(errf.InvalidParameter, "azure disk %s extension is empty", req.DiskID)
+	}
+
+	client, err := svc.ad.Azure(cts.Kit, diskData.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.AzureCreateOption{
+		ResourceGroupName: diskData.Extension.ResourceGroupName,
+		Region:            diskData.Region,
+		Name:              req.Name,
+		CloudDiskID:       diskData.CloudID,
+	}
+	cloudID, err := client.CreateSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create azure disk snapshot failed, err: %v, disk: %s, rid: %s", err, req.DiskID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncazure.NewClient(svc.dataCli, client)
+	params := &syncazure.SyncBaseParams{
+		AccountID:         diskData.AccountID,
+		ResourceGroupName: opt.ResourceGroupName,
+		CloudIDs:          []string{cloudID},
+	}
+	if _, err = syncClient.DiskSnapshot(cts.Kit, params, new(syncazure.SyncDiskSnapshotOption)); err != nil {
+		logs.Errorf("sync azure disk snapshot failed, err: %v, cloud_id: %s, rid: %s", err, cloudID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreate(cts.Kit, enumor.Azure, diskData.AccountID, cloudID, req.Memo)
+}
+
+// BatchDeleteAzureDiskSnapshot batch delete azure disk snapshot, snapshot is deleted by resource group and name.
+func (svc *service) BatchDeleteAzureDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeBatchDeleteReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: req.AccountID},
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.IDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Azure.DiskSnapshot.ListExt(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list azure disk snapshot failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != len(req.IDs) {
+		return nil, errf.New(errf.InvalidParameter, fmt.Sprintf("some disk snapshots not found in %s account %s",
+			enumor.Azure, req.AccountID))
+	}
+
+	client, err := svc.ad.Azure(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, one := range result.Details {
+		if one.Extension == nil {
+			return nil, errf.Newf(errf.InvalidParameter, "azure disk snapshot %s extension is empty", one.ID)
+		}
+
+		opt := &typesnapshot.AzureDeleteOption{ResourceGroupName: one.Extension.ResourceGroupName, Name: one.Name}
+		if err = client.DeleteSnapshot(cts.Kit, opt); err != nil {
+			logs.Errorf("delete azure disk snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if err = svc.deleteDiskSnapshotFromDB(cts.Kit, []string{one.ID}); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
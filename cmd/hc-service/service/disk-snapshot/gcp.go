@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	syncgcp "hcm/cmd/hc-service/logics/res-sync/gcp"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// CreateGcpDiskSnapshot create gcp disk snapshot from disk.
+func (svc *service) CreateGcpDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeCreateReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	diskData, err := svc.dataCli.Gcp.RetrieveDisk(cts.Kit, req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Gcp(cts.Kit, diskData.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.GcpCreateOption{
+		Zone:        diskData.Zone,
+		DiskName:    diskData.Name,
+		Name:        req.Name,
+		Description: converter.PtrToVal(req.Memo),
+	}
+	cloudID, err := client.CreateSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create gcp disk snapshot failed, err: %v, disk: %s, rid: %s", err, req.DiskID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncgcp.NewClient(svc.dataCli, client)
+	params := &syncgcp.SyncBaseParams{
+		AccountID: diskData.AccountID,
+		CloudIDs:  []string{cloudID},
+	}
+	if _, err = syncClient.DiskSnapshot(cts.Kit, params, new(syncgcp.SyncDiskSnapshotOption)); err != nil {
+		logs.Errorf("sync gcp disk snapshot failed, err: %v, cloud_id: %s, rid: %s", err, cloudID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreate(cts.Kit, enumor.Gcp, diskData.AccountID, cloudID, req.Memo)
+}
+
+// BatchDeleteGcpDiskSnapshot batch delete gcp disk snapshot, gcp snapshot is global resource and deleted by name.
+func (svc *service) BatchDeleteGcpDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeBatchDeleteReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	regionMap, err := svc.listDiskSnapshotForDelete(cts.Kit, enumor.Gcp, req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Gcp(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshots := range regionMap {
+		for _, one := range snapshots {
+			opt := &typesnapshot.GcpDeleteOption{Name: one.Name}
+			if err = client.DeleteSnapshot(cts.Kit, opt); err != nil {
+				logs.Errorf("delete gcp disk snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+				return nil, err
+			}
+
+			if err = svc.deleteDiskSnapshotFromDB(cts.Kit, []string{one.ID}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
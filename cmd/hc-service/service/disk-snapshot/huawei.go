@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateHuaWeiDiskSnapshot create huawei disk snapshot from disk.
+func (svc *service) CreateHuaWeiDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeCreateReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	diskData, err := svc.dataCli.HuaWei.RetrieveDisk(cts.Kit.Ctx, cts.Kit.Header(), req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, diskData.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.HuaWeiCreateOption{
+		Region:      diskData.Region,
+		CloudDiskID: diskData.CloudID,
+		Name:        &req.Name,
+	}
+	cloudID, err := client.CreateSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("create huawei disk snapshot failed, err: %v, disk: %s, rid: %s", err, req.DiskID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := synchuawei.NewClient(svc.dataCli, client)
+	params := &synchuawei.SyncBaseParams{
+		AccountID: diskData.AccountID,
+		Region:    diskData.Region,
+		CloudIDs:  []string{cloudID},
+	}
+	if _, err = syncClient.DiskSnapshot(cts.Kit, params, new(synchuawei.SyncDiskSnapshotOption)); err != nil {
+		logs.Errorf("sync huawei disk snapshot failed, err: %v, cloud_id: %s, rid: %s", err, cloudID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreate(cts.Kit, enumor.HuaWei, diskData.AccountID, cloudID, req.Memo)
+}
+
+// BatchDeleteHuaWeiDiskSnapshot batch delete huawei disk snapshot, snapshots are deleted one by one.
+func (svc *service) BatchDeleteHuaWeiDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req, err := decodeBatchDeleteReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	regionMap, err := svc.listDiskSnapshotForDelete(cts.Kit, enumor.HuaWei, req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for region, snapshots := range regionMap {
+		for _, one := range snapshots {
+			opt := &typesnapshot.HuaWeiDeleteOption{Region: region, CloudID: one.CloudID}
+			if err = client.DeleteSnapshot(cts.Kit, opt); err != nil {
+				logs.Errorf("delete huawei disk snapshot failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+				return nil, err
+			}
+
+			if err = svc.deleteDiskSnapshotFromDB(cts.Kit, []string{one.ID}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,190 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot defines disk snapshot service.
+package disksnapshot
+
+import (
+	"fmt"
+	"net/http"
+
+	cloudclient "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dataservice "hcm/pkg/api/data-service"
+	dssnapshot "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	hcsnapshot "hcm/pkg/api/hc-service/disk-snapshot"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitDiskSnapshotService initial the disk snapshot service
+func InitDiskSnapshotService(cap *capability.Capability) {
+	svc := &service{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateTCloudDiskSnapshot", http.MethodPost, "/vendors/tcloud/disk_snapshots/create",
+		svc.CreateTCloudDiskSnapshot)
+	h.Add("CreateAwsDiskSnapshot", http.MethodPost, "/vendors/aws/disk_snapshots/create", svc.CreateAwsDiskSnapshot)
+	h.Add("CreateAzureDiskSnapshot", http.MethodPost, "/vendors/azure/disk_snapshots/create",
+		svc.CreateAzureDiskSnapshot)
+	h.Add("CreateGcpDiskSnapshot", http.MethodPost, "/vendors/gcp/disk_snapshots/create", svc.CreateGcpDiskSnapshot)
+	h.Add("CreateHuaWeiDiskSnapshot", http.MethodPost, "/vendors/huawei/disk_snapshots/create",
+		svc.CreateHuaWeiDiskSnapshot)
+
+	h.Add("BatchDeleteTCloudDiskSnapshot", http.MethodDelete, "/vendors/tcloud/disk_snapshots/batch",
+		svc.BatchDeleteTCloudDiskSnapshot)
+	h.Add("BatchDeleteAwsDiskSnapshot", http.MethodDelete, "/vendors/aws/disk_snapshots/batch",
+		svc.BatchDeleteAwsDiskSnapshot)
+	h.Add("BatchDeleteAzureDiskSnapshot", http.MethodDelete, "/vendors/azure/disk_snapshots/batch",
+		svc.BatchDeleteAzureDiskSnapshot)
+	h.Add("BatchDeleteGcpDiskSnapshot", http.MethodDelete, "/vendors/gcp/disk_snapshots/batch",
+		svc.BatchDeleteGcpDiskSnapshot)
+	h.Add("BatchDeleteHuaWeiDiskSnapshot", http.MethodDelete, "/vendors/huawei/disk_snapshots/batch",
+		svc.BatchDeleteHuaWeiDiskSnapshot)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	ad      *cloudclient.CloudAdaptorClient
+	dataCli *dataclient.Client
+}
+
+// decodeCreateReq 解析并校验快照创建请求
+func decodeCreateReq(cts *rest.Contexts) (*hcsnapshot.DiskSnapshotCreateReq, error) {
+	req := new(hcsnapshot.DiskSnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return req, nil
+}
+
+// decodeBatchDeleteReq 解析并校验快照批量删除请求
+func decodeBatchDeleteReq(cts *rest.Contexts) (*hcsnapshot.DiskSnapshotBatchDeleteReq, error) {
+	req := new(hcsnapshot.DiskSnapshotBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return req, nil
+}
+
+// afterCreate 快照同步到db后，根据云上ID查询快照的hcm ID，并设置快照备注。
+func (svc *service) afterCreate(kt *kit.Kit, vendor enumor.Vendor, accountID, cloudID string,
+	memo *string) (*core.CreateResult, error) {
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.Equal.Factory(), Value: cloudID},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.DiskSnapshot.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, cloud_id: %s, rid: %s", err, cloudID, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "%s disk snapshot %s not found after sync", vendor, cloudID)
+	}
+
+	id := result.Details[0].ID
+	if memo != nil {
+		updateReq := &dssnapshot.DiskSnapshotBatchUpdateReq{
+			Items: []dssnapshot.DiskSnapshotUpdateField{{ID: id, Memo: memo}},
+		}
+		if err = svc.dataCli.Global.DiskSnapshot.BatchUpdate(kt, updateReq); err != nil {
+			logs.Errorf("update disk snapshot memo failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+			return nil, err
+		}
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// listDiskSnapshotForDelete 查询待删除的快照，快照需属于同一账号和指定厂商，返回按地域分组的快照。
+func (svc *service) listDiskSnapshotForDelete(kt *kit.Kit, vendor enumor.Vendor,
+	req *hcsnapshot.DiskSnapshotBatchDeleteReq) (map[string][]coresnapshot.BaseDiskSnapshot, error) {
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: req.AccountID},
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.IDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.DiskSnapshot.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list disk snapshot failed, err: %v, ids: %v, rid: %s", err, req.IDs, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != len(req.IDs) {
+		return nil, errf.New(errf.InvalidParameter, fmt.Sprintf("some disk snapshots not found in %s account %s",
+			vendor, req.AccountID))
+	}
+
+	regionMap := make(map[string][]coresnapshot.BaseDiskSnapshot)
+	for _, one := range result.Details {
+		regionMap[one.Region] = append(regionMap[one.Region], one)
+	}
+
+	return regionMap, nil
+}
+
+// deleteDiskSnapshotFromDB 删除db中的快照
+func (svc *service) deleteDiskSnapshotFromDB(kt *kit.Kit, ids []string) error {
+	req := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("id", ids)}
+	if err := svc.dataCli.Global.DiskSnapshot.BatchDelete(kt, req); err != nil {
+		logs.Errorf("request dataservice to delete disk snapshot failed, err: %v, ids: %v, rid: %s", err, ids,
+			kt.Rid)
+		return err
+	}
+
+	return nil
+}
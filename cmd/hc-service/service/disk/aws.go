@@ -53,6 +53,19 @@ func (svc *service) CreateAwsDisk(cts *rest.Contexts) (interface{}, error) {
 		DiskSize:  diskSize,
 		DiskCount: converter.ValToPtr(uint64(req.DiskCount)),
 	}
+	if req.SnapshotID != nil {
+		snapshot, err := svc.DataCli.Aws.DiskSnapshot.Get(cts.Kit, *req.SnapshotID)
+		if err != nil {
+			logs.Errorf("get aws disk snapshot failed, id: %s, err: %v, rid: %s", *req.SnapshotID, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if err = validateSourceSnapshot(&snapshot.BaseDiskSnapshot, req.AccountID, req.Region); err != nil {
+			return nil, err
+		}
+		opt.SnapshotID = &snapshot.CloudID
+	}
+
 	result, err := client.CreateDisk(cts.Kit, opt)
 	if err != nil {
 		logs.Errorf("create aws cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -55,6 +55,19 @@ func (svc *service) CreateAzureDisk(cts *rest.Contexts) (interface{}, error) {
 		DiskSize:          diskSize,
 		DiskCount:         converter.ValToPtr(uint64(req.DiskCount)),
 	}
+	if req.SnapshotID != nil {
+		snapshot, err := svc.DataCli.Azure.DiskSnapshot.Get(cts.Kit, *req.SnapshotID)
+		if err != nil {
+			logs.Errorf("get azure disk snapshot failed, id: %s, err: %v, rid: %s", *req.SnapshotID, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if err = validateSourceSnapshot(&snapshot.BaseDiskSnapshot, req.AccountID, ""); err != nil {
+			return nil, err
+		}
+		opt.SnapshotID = &snapshot.CloudID
+	}
+
 	cloudIDs, err := client.CreateDisk(cts.Kit, opt)
 	if err != nil {
 		logs.Errorf("create azure cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
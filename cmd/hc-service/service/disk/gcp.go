@@ -54,6 +54,22 @@ func (svc *service) CreateGcpDisk(cts *rest.Contexts) (interface{}, error) {
 		DiskSize:  diskSize,
 		DiskCount: converter.ValToPtr(uint64(req.DiskCount)),
 	}
+	if req.SnapshotID != nil {
+		snapshot, err := svc.DataCli.Gcp.DiskSnapshot.Get(cts.Kit, *req.SnapshotID)
+		if err != nil {
+			logs.Errorf("get gcp disk snapshot failed, id: %s, err: %v, rid: %s", *req.SnapshotID, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if err = validateSourceSnapshot(&snapshot.BaseDiskSnapshot, req.AccountID, ""); err != nil {
+			return nil, err
+		}
+		if snapshot.Extension == nil {
+			return nil, errf.New(errf.InvalidParameter, "snapshot extension is empty")
+		}
+		opt.SourceSnapshot = snapshot.Extension.SelfLink
+	}
+
 	result, err := client.CreateDisk(cts.Kit, opt)
 	if err != nil {
 		logs.Errorf("create gcp cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
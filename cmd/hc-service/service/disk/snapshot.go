@@ -0,0 +1,42 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"fmt"
+
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+)
+
+// validateSourceSnapshot 校验用于恢复云盘的快照，快照需与云盘属于同一账号，region 为空时不校验地域
+func validateSourceSnapshot(snapshot *coresnapshot.BaseDiskSnapshot, accountID, region string) error {
+	if snapshot.AccountID != accountID {
+		return errf.New(errf.InvalidParameter, fmt.Sprintf("snapshot: %s not belongs to account: %s",
+			snapshot.ID, accountID))
+	}
+
+	if len(region) != 0 && snapshot.Region != region {
+		return errf.New(errf.InvalidParameter, fmt.Sprintf("snapshot: %s region: %s not match disk region: %s",
+			snapshot.ID, snapshot.Region, region))
+	}
+
+	return nil
+}
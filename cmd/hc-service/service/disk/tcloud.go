@@ -104,6 +104,19 @@ func (svc *service) CreateTCloudDisk(cts *rest.Contexts) (interface{}, error) {
 		}
 	}
 
+	if req.SnapshotID != nil {
+		snapshot, err := svc.DataCli.TCloud.DiskSnapshot.Get(cts.Kit, *req.SnapshotID)
+		if err != nil {
+			logs.Errorf("get tcloud disk snapshot failed, id: %s, err: %v, rid: %s", *req.SnapshotID, err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if err = validateSourceSnapshot(&snapshot.BaseDiskSnapshot, req.AccountID, req.Region); err != nil {
+			return nil, err
+		}
+		opt.SnapshotID = &snapshot.CloudID
+	}
+
 	result, err := client.CreateDisk(cts.Kit, opt)
 	if err != nil {
 		logs.Errorf("create tcloud cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -0,0 +1,170 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
+	faketcloud "hcm/pkg/adaptor/fake/tcloud"
+	"hcm/pkg/adaptor/types/disk"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/core/cloud"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	proto "hcm/pkg/api/hc-service/disk"
+	"hcm/pkg/cc"
+	fakeclient "hcm/pkg/client/fake"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/json"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+const (
+	testAccountID = "account"
+	testRegion    = "ap-guangzhou"
+	testZone      = "ap-guangzhou-1"
+)
+
+func TestCreateTCloudDiskFromSnapshot(t *testing.T) {
+	kt := kit.New()
+	server := fakeclient.NewServer(fakeclient.DataServicePrefix)
+	account := protocloud.AccountGetResult[cloud.TCloudAccountExtension]{
+		BaseAccount: cloud.BaseAccount{ID: testAccountID, Vendor: enumor.TCloud, Type: enumor.ResourceAccount},
+		Extension:   &cloud.TCloudAccountExtension{CloudSecretID: "id", CloudSecretKey: "key"},
+	}
+	if _, err := server.Add("accounts", account); err != nil {
+		t.Fatalf("add account failed, err: %v", err)
+	}
+
+	dataCli := fakeclient.NewDataServiceClient(server)
+	ad, err := cloudadaptor.NewCloudAdaptorClient(dataCli, cc.FakeCloud{TCloud: cc.FakeTCloud{Enable: true}})
+	if err != nil {
+		t.Fatalf("new cloud adaptor client failed, err: %v", err)
+	}
+	client, err := ad.Adaptor().TCloud(nil)
+	if err != nil {
+		t.Fatalf("get fake tcloud failed, err: %v", err)
+	}
+	fake := client.(*faketcloud.FakeTCloud)
+	svc := &service{DataCli: dataCli, Adaptor: ad}
+
+	// 云上准备20G的云盘及其快照，并将快照同步到db
+	diskResult, err := fake.CreateDisk(kt, &disk.TCloudDiskCreateOption{Region: testRegion, Zone: testZone,
+		DiskType: "CLOUD_PREMIUM", DiskSize: converter.ValToPtr(uint64(20)), DiskChargeType: "POSTPAID_BY_HOUR"})
+	if err != nil {
+		t.Fatalf("create source disk failed, err: %v", err)
+	}
+	cloudSnapshotID, err := fake.CreateSnapshot(kt, &typesnapshot.TCloudCreateOption{Region: testRegion,
+		CloudDiskID: diskResult.SuccessCloudIDs[0]})
+	if err != nil {
+		t.Fatalf("create snapshot failed, err: %v", err)
+	}
+
+	snapshots := []coresnapshot.BaseDiskSnapshot{
+		{ID: "snapshot", CloudID: cloudSnapshotID, Vendor: enumor.TCloud, AccountID: testAccountID, Region: testRegion},
+		{ID: "other-account", CloudID: cloudSnapshotID, Vendor: enumor.TCloud, AccountID: "other", Region: testRegion},
+		{ID: "other-region", CloudID: cloudSnapshotID, Vendor: enumor.TCloud, AccountID: testAccountID,
+			Region: "ap-shanghai"},
+	}
+	for _, one := range snapshots {
+		if _, err = server.Add("disk_snapshots", one); err != nil {
+			t.Fatalf("add disk snapshot failed, err: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		snapshotID string
+		size       uint64
+		success    bool
+	}{
+		{name: "create from snapshot", snapshotID: "snapshot", size: 30, success: true},
+		{name: "size less than snapshot", snapshotID: "snapshot", size: 10},
+		{name: "snapshot of other account", snapshotID: "other-account", size: 30},
+		{name: "snapshot in other region", snapshotID: "other-region", size: 30},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &proto.TCloudDiskCreateReq{
+				DiskBaseCreateReq: &proto.DiskBaseCreateReq{AccountID: testAccountID, Region: testRegion,
+					Zone: testZone, DiskSize: test.size, DiskType: "CLOUD_PREMIUM", DiskCount: 1,
+					SnapshotID: converter.ValToPtr(test.snapshotID)},
+				Extension: &proto.TCloudDiskExtensionCreateReq{DiskChargeType: "POSTPAID_BY_HOUR"},
+			}
+			count := server.Count("disks")
+
+			_, err := svc.CreateTCloudDisk(newContexts(t, req))
+			if !test.success {
+				if err == nil || server.Count("disks") != count {
+					t.Errorf("create disk should fail without disk created, err: %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("create disk from snapshot failed, err: %v", err)
+			}
+			disks := make([]coredisk.BaseDisk, 0)
+			if err = server.List("disks", &disks); err != nil || len(disks) != count+1 {
+				t.Fatalf("created disk is not synced, count: %d, err: %v", len(disks), err)
+			}
+			if disks[count].DiskSize != test.size || disks[count].AccountID != testAccountID {
+				t.Errorf("synced disk is not as expected, disk: %+v", disks[count])
+			}
+		})
+	}
+
+	// 快照不属于该账号时，校验不通过的错误为参数错误
+	req := &proto.TCloudDiskCreateReq{
+		DiskBaseCreateReq: &proto.DiskBaseCreateReq{AccountID: testAccountID, Region: testRegion, Zone: testZone,
+			DiskSize: 30, DiskType: "CLOUD_PREMIUM", DiskCount: 1, SnapshotID: converter.ValToPtr("other-account")},
+		Extension: &proto.TCloudDiskExtensionCreateReq{DiskChargeType: "POSTPAID_BY_HOUR"},
+	}
+	_, err = svc.CreateTCloudDisk(newContexts(t, req))
+	if ef := errf.Error(err); ef.Code != errf.InvalidParameter {
+		t.Errorf("error code should be %d, but got %d", errf.InvalidParameter, ef.Code)
+	}
+}
+
+func newContexts(t *testing.T, req interface{}) *rest.Contexts {
+	t.Helper()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("marshal request failed, err: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "url", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("new http request failed, err: %v", err)
+	}
+
+	return &rest.Contexts{Kit: kit.New(), Request: restful.NewRequest(request)}
+}
@@ -304,7 +304,7 @@ func (svc *EipSvc) CreateEip(cts *rest.Contexts) (interface{}, error) {
 func (svc *EipSvc) makeEipAssociateOption(
 	kt *kit.Kit,
 	req *proto.AzureEipAssociateReq,
-	cli *azure.Azure,
+	cli azure.Azure,
 ) (*eip.AzureEipAssociateOption, error) {
 	dataCli := svc.DataCli.Azure
 
@@ -340,7 +340,7 @@ func (svc *EipSvc) makeEipAssociateOption(
 func (svc *EipSvc) makeEipDisassociateOption(
 	kt *kit.Kit,
 	req *proto.AzureEipDisassociateReq,
-	cli *azure.Azure,
+	cli azure.Azure,
 ) (*eip.AzureEipDisassociateOption, error) {
 	dataCli := svc.DataCli.Azure
 
@@ -0,0 +1,104 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// CreateAwsLoadBalancer create aws load balancer.
+func (svc *service) CreateAwsLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.AwsLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := client.CreateLoadBalancer(cts.Kit, req.AwsCreateOption)
+	if err != nil {
+		logs.Errorf("create aws load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	syncClient := syncaws.NewClient(svc.dataCli, client)
+	params := &syncaws.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  []string{converter.PtrToVal(cloudID)},
+	}
+	_, err = syncClient.LoadBalancer(cts.Kit, params, &syncaws.SyncLoadBalancerOption{BkBizID: req.BkBizID})
+	if err != nil {
+		logs.Errorf("sync aws load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &hclb.BatchCreateResult{SuccessCloudIDs: []string{converter.PtrToVal(cloudID)}}, nil
+}
+
+// BatchDeleteAwsLoadBalancer batch delete aws load balancer.
+func (svc *service) BatchDeleteAwsLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.LoadBalancerBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	regionMap, err := svc.listLoadBalancerForDelete(cts.Kit, enumor.Aws, req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for region, lbs := range regionMap {
+		for _, one := range lbs {
+			opt := &typelb.AwsDeleteOption{Region: region, CloudID: one.CloudID}
+			if err = client.DeleteLoadBalancer(cts.Kit, opt); err != nil {
+				logs.Errorf("delete aws load balancer failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+				return nil, err
+			}
+
+			if err = svc.deleteLoadBalancerFromDB(cts.Kit, []string{one.ID}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer defines load balancer service.
+package loadbalancer
+
+import (
+	"fmt"
+	"net/http"
+
+	cloudclient "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/service/capability"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/api/data-service"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitLoadBalancerService initial the load balancer service
+func InitLoadBalancerService(cap *capability.Capability) {
+	svc := &service{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateTCloudLoadBalancer", http.MethodPost, "/vendors/tcloud/load_balancers/create",
+		svc.CreateTCloudLoadBalancer)
+	h.Add("CreateAwsLoadBalancer", http.MethodPost, "/vendors/aws/load_balancers/create", svc.CreateAwsLoadBalancer)
+
+	h.Add("BatchDeleteTCloudLoadBalancer", http.MethodDelete, "/vendors/tcloud/load_balancers/batch",
+		svc.BatchDeleteTCloudLoadBalancer)
+	h.Add("BatchDeleteAwsLoadBalancer", http.MethodDelete, "/vendors/aws/load_balancers/batch",
+		svc.BatchDeleteAwsLoadBalancer)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	ad      *cloudclient.CloudAdaptorClient
+	dataCli *dataclient.Client
+}
+
+// listLoadBalancerForDelete 查询待删除的负载均衡，负载均衡需属于同一账号和指定厂商，返回按地域分组的负载均衡。
+func (svc *service) listLoadBalancerForDelete(kt *kit.Kit, vendor enumor.Vendor,
+	req *hclb.LoadBalancerBatchDeleteReq) (map[string][]corelb.BaseLoadBalancer, error) {
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: req.AccountID},
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: req.IDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.LoadBalancer.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, ids: %v, rid: %s", err, req.IDs, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != len(req.IDs) {
+		return nil, errf.New(errf.InvalidParameter, fmt.Sprintf("some load balancers not found in %s account %s",
+			vendor, req.AccountID))
+	}
+
+	regionMap := make(map[string][]corelb.BaseLoadBalancer)
+	for _, one := range result.Details {
+		regionMap[one.Region] = append(regionMap[one.Region], one)
+	}
+
+	return regionMap, nil
+}
+
+// deleteLoadBalancerFromDB 删除db中的负载均衡，监听器、后端服务以及与主机的关联关系由data-service一并删除。
+func (svc *service) deleteLoadBalancerFromDB(kt *kit.Kit, ids []string) error {
+	req := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("id", ids)}
+	if err := svc.dataCli.Global.LoadBalancer.BatchDelete(kt, req); err != nil {
+		logs.Errorf("request dataservice to delete load balancer failed, err: %v, ids: %v, rid: %s", err, ids,
+			kt.Rid)
+		return err
+	}
+
+	return nil
+}
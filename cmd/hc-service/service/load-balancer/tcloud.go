@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateTCloudLoadBalancer create tcloud load balancer.
+func (svc *service) CreateTCloudLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.TCloudLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateLoadBalancer(cts.Kit, req.TCloudCreateOption)
+	if err != nil {
+		logs.Errorf("create tcloud load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	respData := &hclb.BatchCreateResult{
+		UnknownCloudIDs: result.UnknownCloudIDs,
+		SuccessCloudIDs: result.SuccessCloudIDs,
+		FailedCloudIDs:  result.FailedCloudIDs,
+		FailedMessage:   result.FailedMessage,
+	}
+
+	if len(result.SuccessCloudIDs) == 0 {
+		return respData, nil
+	}
+
+	syncClient := synctcloud.NewClient(svc.dataCli, client)
+	params := &synctcloud.SyncBaseParams{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		CloudIDs:  result.SuccessCloudIDs,
+	}
+	_, err = syncClient.LoadBalancer(cts.Kit, params, &synctcloud.SyncLoadBalancerOption{BkBizID: req.BkBizID})
+	if err != nil {
+		logs.Errorf("sync tcloud load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return respData, nil
+}
+
+// BatchDeleteTCloudLoadBalancer batch delete tcloud load balancer.
+func (svc *service) BatchDeleteTCloudLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.LoadBalancerBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	regionMap, err := svc.listLoadBalancerForDelete(cts.Kit, enumor.TCloud, req)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for region, lbs := range regionMap {
+		ids := make([]string, 0, len(lbs))
+		cloudIDs := make([]string, 0, len(lbs))
+		for _, one := range lbs {
+			ids = append(ids, one.ID)
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		opt := &typelb.TCloudDeleteOption{Region: region, CloudIDs: cloudIDs}
+		if err = client.DeleteLoadBalancer(cts.Kit, opt); err != nil {
+			logs.Errorf("delete tcloud load balancer failed, err: %v, opt: %v, rid: %s", err, opt, cts.Kit.Rid)
+			return nil, err
+		}
+
+		if err = svc.deleteLoadBalancerFromDB(cts.Kit, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
@@ -35,9 +35,11 @@ import (
 	"hcm/cmd/hc-service/service/capability"
 	"hcm/cmd/hc-service/service/cvm"
 	"hcm/cmd/hc-service/service/disk"
+	disksnapshot "hcm/cmd/hc-service/service/disk-snapshot"
 	"hcm/cmd/hc-service/service/eip"
 	"hcm/cmd/hc-service/service/firewall"
 	instancetype "hcm/cmd/hc-service/service/instance-type"
+	loadbalancer "hcm/cmd/hc-service/service/load-balancer"
 	routetable "hcm/cmd/hc-service/service/route-table"
 	securitygroup "hcm/cmd/hc-service/service/security-group"
 	"hcm/cmd/hc-service/service/subnet"
@@ -73,7 +75,10 @@ func NewService(dis serviced.Discover) (*Service, error) {
 
 	cliSet := client.NewClientSet(cli, dis)
 
-	cloudAdaptor := cloudadaptor.NewCloudAdaptorClient(cliSet.DataService())
+	cloudAdaptor, err := cloudadaptor.NewCloudAdaptorClient(cliSet.DataService(), cc.HCService().FakeCloud)
+	if err != nil {
+		return nil, err
+	}
 
 	svr := &Service{
 		clientSet:    cliSet,
@@ -158,9 +163,11 @@ func (s *Service) apiSet() *restful.Container {
 	vpc.InitVpcService(c)
 	subnet.InitSubnetService(c)
 	disk.InitDiskService(c)
+	disksnapshot.InitDiskSnapshotService(c)
 	cvm.InitCvmService(c)
 	routetable.InitRouteTableService(c)
 	eip.InitEipService(c)
+	loadbalancer.InitLoadBalancerService(c)
 	instancetype.InitInstanceTypeService(c)
 	sync.InitService(c)
 	bill.InitBillService(c)
@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/azure"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncDiskSnapshot ....
+func (svc *service) SyncDiskSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return nil, handler.ResourceSync(cts, &diskSnapshotHandler{cli: svc.syncCli})
+}
+
+// diskSnapshotHandler disk snapshot sync handler.
+type diskSnapshotHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.AzureSyncReq
+	syncCli azure.Interface
+	// finished 快照接口一次返回资源组下的全部快照，第一次查询后即结束
+	finished bool
+}
+
+var _ handler.Handler = new(diskSnapshotHandler)
+
+// Prepare ...
+func (hd *diskSnapshotHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *diskSnapshotHandler) Next(kt *kit.Kit) ([]string, error) {
+	if hd.finished {
+		return nil, nil
+	}
+
+	listOpt := &typesnapshot.AzureListOption{
+		ResourceGroupName: hd.request.ResourceGroupName,
+	}
+	snapshotResult, err := hd.syncCli.CloudCli().ListSnapshot(kt, listOpt)
+	if err != nil {
+		logs.Errorf("request adaptor list azure disk snapshot failed, err: %v, opt: %v, rid: %s", err, listOpt,
+			kt.Rid)
+		return nil, err
+	}
+	hd.finished = true
+
+	cloudIDs := make([]string, 0, len(snapshotResult))
+	for _, one := range snapshotResult {
+		cloudIDs = append(cloudIDs, one.GetCloudID())
+	}
+
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *diskSnapshotHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	for _, partCloudIDs := range slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit) {
+		params := &azure.SyncBaseParams{
+			AccountID:         hd.request.AccountID,
+			ResourceGroupName: hd.request.ResourceGroupName,
+			CloudIDs:          partCloudIDs,
+		}
+		if _, err := hd.syncCli.DiskSnapshot(kt, params, new(azure.SyncDiskSnapshotOption)); err != nil {
+			logs.Errorf("sync azure disk snapshot failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *diskSnapshotHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemoveDiskSnapshotDeleteFromCloud(kt, hd.request.AccountID, hd.request.ResourceGroupName)
+	if err != nil {
+		logs.Errorf("remove disk snapshot delete from cloud failed, err: %v, accountID: %s, resGroupName: %s, "+
+			"rid: %s", err, hd.request.AccountID, hd.request.ResourceGroupName, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *diskSnapshotHandler) Name() enumor.CloudResourceType {
+	return enumor.DiskSnapshotCloudResType
+}
@@ -44,6 +44,7 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncSubnet", "POST", "/subnets/sync", v.SyncSubnet)
 	h.Add("SyncEip", "POST", "/eips/sync", v.SyncEip)
 	h.Add("SyncDisk", "POST", "/disks/sync", v.SyncDisk)
+	h.Add("SyncDiskSnapshot", "POST", "/disk_snapshots/sync", v.SyncDiskSnapshot)
 	h.Add("SyncCvmWithRelRes", "POST", "/cvms/with/relation_resources/sync", v.SyncCvmWithRelRes)
 	h.Add("SyncSecurityGroup", "POST", "/security_groups/sync", v.SyncSecurityGroup)
 	h.Add("SyncNetworkInterface", "POST", "/network_interfaces/sync", v.SyncNetworkInterface)
@@ -31,6 +31,7 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/runtime/ctl"
+	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 )
@@ -60,6 +61,7 @@ func Run(opt *options.Option) error {
 type taskServer struct {
 	svc *service.Service
 	sd  serviced.Service
+	el  serviced.Election
 }
 
 // prepare do prepare jobs before run api discover.
@@ -79,8 +81,9 @@ func (ds *taskServer) prepare(opt *options.Option) error {
 
 	// init service discovery.
 	svcOpt := serviced.NewServiceOption(cc.TaskServerName, cc.TaskServer().Network)
+	svcOpt.Labels = cc.TaskServer().Service.Labels
 	discOpt := serviced.DiscoveryOption{
-		Services: []cc.Name{cc.DataServiceName, cc.HCServiceName},
+		Services: []cc.Name{cc.DataServiceName, cc.HCServiceName, cc.CloudServerName},
 	}
 	sd, err := serviced.NewServiceD(cc.TaskServer().Service, svcOpt, discOpt)
 	if err != nil {
@@ -88,15 +91,25 @@ func (ds *taskServer) prepare(opt *options.Option) error {
 	}
 	ds.sd = sd
 
+	// init leader election, only the leader dispatches async flows.
+	el, err := serviced.NewElection(cc.TaskServer().Service, serviced.ElectionOption{
+		Name: cc.TaskServerName,
+		Uid:  svcOpt.Uid,
+	})
+	if err != nil {
+		return fmt.Errorf("new leader election failed, err: %v", err)
+	}
+	ds.el = el
+
 	// init service.
-	svc, err := service.NewService(sd, shutdownWaitTimeSec)
+	svc, err := service.NewService(sd, el, shutdownWaitTimeSec)
 	if err != nil {
 		return fmt.Errorf("initialize service failed, err: %v", err)
 	}
 	ds.svc = svc
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(ctl.WithBasics(sd)...); err != nil {
+	if err := ctl.LoadCtl(append(ctl.WithBasics(sd), cmd.WithLeaderHandover(el))...); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
@@ -115,6 +128,9 @@ func (ds *taskServer) register() error {
 
 // finalizer ...
 func (ds *taskServer) finalizer() {
+	// give up the leadership first, so that another node can take over as soon as possible.
+	ds.el.Close()
+
 	if err := ds.sd.Deregister(); err != nil {
 		logs.Errorf("process service shutdown, but deregister failed, err: %v", err)
 		return
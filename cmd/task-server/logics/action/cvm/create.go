@@ -32,7 +32,6 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
-	"hcm/pkg/logs"
 	"hcm/pkg/tools/json"
 )
 
@@ -41,6 +40,8 @@ const SaveCreateCvmCloudIDKey = "create_cvm_cloud_ids"
 
 var _ action.Action = new(CreateCvmAction)
 var _ action.ParameterAction = new(CreateCvmAction)
+var _ action.TimeoutAction = new(CreateCvmAction)
+var _ action.RateLimitAction = new(CreateCvmAction)
 
 // CreateCvmAction define create cvm action.
 type CreateCvmAction struct{}
@@ -158,6 +159,24 @@ func (opt CreateOption) Validate() error {
 	return nil
 }
 
+// accountID return the account id of the vendor create request.
+func (opt CreateOption) accountID() string {
+	switch opt.Vendor {
+	case enumor.TCloud:
+		return opt.TCloudBatchCreateReq.AccountID
+	case enumor.Aws:
+		return opt.AwsBatchCreateReq.AccountID
+	case enumor.HuaWei:
+		return opt.HuaWeiBatchCreateReq.AccountID
+	case enumor.Gcp:
+		return opt.GcpBatchCreateReq.AccountID
+	case enumor.Azure:
+		return opt.AzureCreateReq.AccountID
+	default:
+		return ""
+	}
+}
+
 // ParameterNew return request params.
 func (act CreateCvmAction) ParameterNew() (params interface{}) {
 	return new(CreateOption)
@@ -168,6 +187,21 @@ func (act CreateCvmAction) Name() enumor.ActionName {
 	return enumor.ActionCreateCvm
 }
 
+// TimeoutSec 部分云厂商（如Azure）创建主机耗时较长，默认超时时间设置为30分钟。
+func (act CreateCvmAction) TimeoutSec() uint {
+	return 30 * 60
+}
+
+// RateLimitKey return rate limit key by create cvm option.
+func (act CreateCvmAction) RateLimitKey(params interface{}) (action.RateLimitKey, bool) {
+	opt, ok := params.(*CreateOption)
+	if !ok || len(opt.accountID()) == 0 {
+		return action.RateLimitKey{}, false
+	}
+
+	return action.RateLimitKey{Vendor: opt.Vendor, AccountID: opt.accountID(), APIGroup: RateLimitAPIGroup}, true
+}
+
 // Run create cvm.
 func (act CreateCvmAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
 	opt, ok := params.(*CreateOption)
@@ -175,6 +209,8 @@ func (act CreateCvmAction) Run(kt run.ExecuteKit, params interface{}) (interface
 		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
 	}
 
+	kt.Logger().Infof("start batch create cvm, vendor: %s", opt.Vendor)
+
 	var result *hccvm.BatchCreateResult
 	var err error
 	switch opt.Vendor {
@@ -198,16 +234,19 @@ func (act CreateCvmAction) Run(kt run.ExecuteKit, params interface{}) (interface
 		return nil, fmt.Errorf("vendor: %s not support", opt.Vendor)
 	}
 	if err != nil {
-		logs.Errorf("batch create cvm failed, err: %v, result: %+v, rid: %s", err, result, kt.Kit().Rid)
+		kt.Logger().Errorf("batch create cvm failed, err: %v, result: %+v", err, result)
 		return result, err
 	}
 
+	kt.Logger().Infof("batch create cvm finished, success cloud ids: %v, failed cloud ids: %v",
+		result.SuccessCloudIDs, result.FailedCloudIDs)
 	if len(result.FailedMessage) != 0 {
+		kt.Logger().Errorf("batch create cvm partly failed, message: %s", result.FailedMessage)
 		return result, errors.New(result.FailedMessage)
 	}
 
 	if err = kt.ShareData().AppendIDs(kt.Kit(), SaveCreateCvmCloudIDKey, result.SuccessCloudIDs...); err != nil {
-		logs.Errorf("share data appendIDs failed, err: %v, rid: %s", err, kt.Kit().Rid)
+		kt.Logger().Errorf("share data appendIDs failed, err: %v", err)
 		return result, err
 	}
 
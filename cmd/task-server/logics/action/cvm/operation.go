@@ -30,11 +30,14 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/kit"
-	"hcm/pkg/logs"
 )
 
 var _ action.Action = new(CvmOperationAction)
 var _ action.ParameterAction = new(CvmOperationAction)
+var _ action.RateLimitAction = new(CvmOperationAction)
+
+// RateLimitAPIGroup 主机相关任务调用的云厂商接口分组，同一个账号下的主机创建、开关机等任务共享一个令牌桶
+const RateLimitAPIGroup = "cvm"
 
 // CvmOperationAction define cvm operation action.
 type CvmOperationAction struct {
@@ -88,6 +91,16 @@ func (act CvmOperationAction) Name() enumor.ActionName {
 	return act.ActionName
 }
 
+// RateLimitKey return rate limit key by operation cvm option.
+func (act CvmOperationAction) RateLimitKey(params interface{}) (action.RateLimitKey, bool) {
+	opt, ok := params.(*CvmOperationOption)
+	if !ok {
+		return action.RateLimitKey{}, false
+	}
+
+	return action.RateLimitKey{Vendor: opt.Vendor, AccountID: opt.AccountID, APIGroup: RateLimitAPIGroup}, true
+}
+
 // Run operation cvm.
 func (act CvmOperationAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
 	opt, ok := params.(*CvmOperationOption)
@@ -115,7 +128,7 @@ func (act CvmOperationAction) Run(kt run.ExecuteKit, params interface{}) (interf
 		err = act.AzureFunc(kt.Kit(), cli, opt)
 	}
 	if err != nil {
-		logs.Errorf("operate cvm failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Kit().Rid)
+		kt.Logger().Errorf("operate cvm failed, err: %v, opt: %+v", err, opt)
 		return nil, err
 	}
 
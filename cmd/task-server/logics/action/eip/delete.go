@@ -26,7 +26,6 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
-	"hcm/pkg/logs"
 )
 
 // DeleteEIPAction security group delete action
@@ -65,6 +64,8 @@ func (s DeleteEIPAction) Run(kt run.ExecuteKit, params any) (any, error) {
 		return nil, err
 	}
 
+	kt.Logger().Infof("start delete eip, vendor: %s, id: %s", opt.Vendor, opt.ID)
+
 	cli := actcli.GetHCService()
 	var err error
 	deleteReq := &hcproto.EipDeleteReq{EipID: opt.ID}
@@ -83,10 +84,10 @@ func (s DeleteEIPAction) Run(kt run.ExecuteKit, params any) (any, error) {
 		return nil, errf.Newf(errf.Unknown, "id: %s vendor: %s not support", opt.ID, opt.Vendor)
 	}
 	if err != nil {
-		logs.Errorf("delete eip failed, err: %v, vendor: %s, opt: %v, rid: %s",
-			err, opt.Vendor, opt, kt.Kit().Rid)
+		kt.Logger().Errorf("delete eip failed, err: %v, vendor: %s, opt: %v", err, opt.Vendor, opt)
 		return nil, err
 	}
 
+	kt.Logger().Infof("delete eip success, id: %s", opt.ID)
 	return nil, nil
 }
@@ -33,6 +33,7 @@ import (
 
 var _ action.Action = new(DeleteAction)
 var _ action.ParameterAction = new(DeleteAction)
+var _ action.TimeoutAction = new(DeleteAction)
 
 // DeleteAction define delete cvm action.
 type DeleteAction struct{}
@@ -58,6 +59,11 @@ func (act DeleteAction) Name() enumor.ActionName {
 	return enumor.ActionDeleteSubnet
 }
 
+// TimeoutSec 删除子网为同步操作，默认超时时间设置为1分钟。
+func (act DeleteAction) TimeoutSec() uint {
+	return 60
+}
+
 // Run ...
 func (act DeleteAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
 	opt, ok := params.(*DeleteSubnetOption)
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package callback 任务流回调发送器
+package callback
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"hcm/pkg/async/consumer"
+	"hcm/pkg/cc"
+	"hcm/pkg/client/discovery"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/rest/client"
+	"hcm/pkg/serviced"
+)
+
+// NewSender new callback sender, route type callback is sent to hcm service found by service discovery,
+// http type callback is sent to the callback url directly.
+func NewSender(cli client.HTTPClient, sd serviced.Discover) consumer.CallbackSender {
+	return &sender{
+		cli:     cli,
+		sd:      sd,
+		http:    consumer.NewHttpCallbackSender(),
+		clients: make(map[string]rest.ClientInterface),
+	}
+}
+
+type sender struct {
+	cli  client.HTTPClient
+	sd   serviced.Discover
+	http consumer.CallbackSender
+
+	lock    sync.Mutex
+	clients map[string]rest.ClientInterface
+}
+
+// Send callback.
+func (s *sender) Send(kt *kit.Kit, callback *tableasync.Callback, header http.Header, body []byte) error {
+	switch callback.Type {
+	case enumor.CallbackHttp:
+		return s.http.Send(kt, callback, header, body)
+
+	case enumor.CallbackRoute:
+		return s.sendRoute(kt, callback, header, body)
+
+	default:
+		return fmt.Errorf("callback type: %s not support", callback.Type)
+	}
+}
+
+func (s *sender) sendRoute(kt *kit.Kit, callback *tableasync.Callback, header http.Header, body []byte) error {
+	h := kt.Header()
+	for key := range header {
+		h.Set(key, header.Get(key))
+	}
+
+	resp := new(rest.BaseResp)
+	err := s.restClient(callback.Service).Post().
+		WithContext(kt.Ctx).
+		WithHeaders(h).
+		SubResourcef("%s", callback.Path).
+		Body(string(body)).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+func (s *sender) restClient(service string) rest.ClientInterface {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if c, exist := s.clients[service]; exist {
+		return c
+	}
+
+	c := rest.NewClient(&client.Capability{
+		Client:   s.cli,
+		Discover: discovery.NewAPIDiscovery(cc.Name(service), s.sd),
+	}, "/")
+	s.clients[service] = c
+	return c
+}
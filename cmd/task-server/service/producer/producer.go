@@ -41,6 +41,13 @@ func Init(cap *capability.Capability) {
 
 	h.Add("CreateTemplateFlow", "POST", "/template_flows/create", svc.CreateTemplateFlow)
 	h.Add("CreateCustomFlow", "POST", "/custom_flows/create", svc.CreateCustomFlow)
+	h.Add("CancelFlow", "POST", "/flows/{id}/cancel", svc.CancelFlow)
+	h.Add("RetryFlow", "POST", "/flows/{id}/retry", svc.RetryFlow)
+	h.Add("ResumeFlow", "POST", "/flows/{id}/resume", svc.ResumeFlow)
+	h.Add("CreateCronFlow", "POST", "/cron_flows/create", svc.CreateCronFlow)
+	h.Add("DeleteCronFlow", "DELETE", "/cron_flows/{id}", svc.DeleteCronFlow)
+	h.Add("SignalTask", "POST", "/tasks/{id}/signal", svc.SignalTask)
+	h.Add("ReplayCallback", "POST", "/callback_deliveries/{id}/replay", svc.ReplayCallback)
 
 	h.Load(cap.WebService)
 }
@@ -99,3 +106,125 @@ func (p service) CreateCustomFlow(cts *rest.Contexts) (interface{}, error) {
 
 	return &core.CreateResult{ID: id}, nil
 }
+
+// CancelFlow cancel flow.
+func (p service) CancelFlow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := p.pro.CancelFlow(cts.Kit, id); err != nil {
+		logs.Errorf("cancel flow failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// RetryFlow retry failed flow from its failed tasks.
+func (p service) RetryFlow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := p.pro.RetryFlow(cts.Kit, id); err != nil {
+		logs.Errorf("retry flow failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ResumeFlow resume failed or canceled flow after manual fix.
+func (p service) ResumeFlow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := p.pro.ResumeFlow(cts.Kit, id); err != nil {
+		logs.Errorf("resume flow failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// CreateCronFlow add cron flow
+func (p service) CreateCronFlow(cts *rest.Contexts) (interface{}, error) {
+
+	// 1. 解析请求体，原因同 CreateTemplateFlow
+	opt := new(producer.AddCronFlowOption)
+	if err := cts.DecodeInto(opt); err != nil {
+		return nil, err
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 2. 添加周期任务流
+	id, err := p.pro.AddCronFlow(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("add cron flow failed, err: %v, opt: %+v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// DeleteCronFlow delete cron flow.
+func (p service) DeleteCronFlow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := p.pro.DeleteCronFlow(cts.Kit, id); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// SignalTask send approve or reject signal to waiting task.
+func (p service) SignalTask(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	opt := new(producer.SignalTaskOption)
+	if err := cts.DecodeInto(opt); err != nil {
+		return nil, err
+	}
+	opt.TaskID = id
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := p.pro.SignalTask(cts.Kit, opt); err != nil {
+		logs.Errorf("signal task failed, err: %v, opt: %+v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ReplayCallback replay flow callback delivery.
+func (p service) ReplayCallback(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := p.pro.ReplayCallback(cts.Kit, id); err != nil {
+		logs.Errorf("replay callback failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
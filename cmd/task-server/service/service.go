@@ -29,10 +29,12 @@ import (
 	"time"
 
 	logicsaction "hcm/cmd/task-server/logics/action"
+	"hcm/cmd/task-server/logics/callback"
 	"hcm/cmd/task-server/service/capability"
 	"hcm/cmd/task-server/service/producer"
 	"hcm/cmd/task-server/service/viewer"
 	"hcm/pkg/async"
+	"hcm/pkg/async/action"
 	"hcm/pkg/async/backend"
 	"hcm/pkg/async/consumer"
 	"hcm/pkg/async/consumer/leader"
@@ -62,7 +64,7 @@ type Service struct {
 }
 
 // NewService create a service instance.
-func NewService(sd serviced.ServiceDiscover, shutdownWaitTimeSec int) (*Service, error) {
+func NewService(sd serviced.ServiceDiscover, el serviced.Election, shutdownWaitTimeSec int) (*Service, error) {
 	tls := cc.TaskServer().Network.TLS
 
 	var tlsConfig *ssl.TLSConfig
@@ -90,7 +92,7 @@ func NewService(sd serviced.ServiceDiscover, shutdownWaitTimeSec int) (*Service,
 	}
 
 	logicsaction.Init(apiClientSet)
-	async, err := createAndStartAsync(sd, dao, shutdownWaitTimeSec)
+	async, err := createAndStartAsync(sd, el, dao, callback.NewSender(restCli, sd), shutdownWaitTimeSec)
 	if err != nil {
 		return nil, err
 	}
@@ -104,15 +106,30 @@ func NewService(sd serviced.ServiceDiscover, shutdownWaitTimeSec int) (*Service,
 	return svr, nil
 }
 
-func createAndStartAsync(sd serviced.ServiceDiscover, dao dao.Set, shutdownWaitTimeSec int) (async.Async, error) {
+func createAndStartAsync(sd serviced.ServiceDiscover, el serviced.Election, dao dao.Set, sender consumer.CallbackSender,
+	shutdownWaitTimeSec int) (async.Async, error) {
+
 	// 创建async框架使用的backend
 	bd, err := backend.Factory(enumor.BackendMysql, dao)
 	if err != nil {
 		return nil, err
 	}
 
-	leader := leader.NewLeader(sd)
+	leader := leader.NewLeader(sd, el)
 	cfg := cc.TaskServer().Async
+	flowNameMaxRunning := make(map[enumor.FlowName]uint, len(cfg.Dispatcher.FlowNameMaxRunning))
+	for name, max := range cfg.Dispatcher.FlowNameMaxRunning {
+		flowNameMaxRunning[enumor.FlowName(name)] = max
+	}
+	rateLimits := make([]consumer.RateLimitRule, 0, len(cfg.RateLimit.Rules))
+	for _, rule := range cfg.RateLimit.Rules {
+		rateLimits = append(rateLimits, consumer.RateLimitRule{
+			Vendor:   enumor.Vendor(rule.Vendor),
+			APIGroup: rule.APIGroup,
+			Rate:     rule.Rate,
+			Burst:    rule.Burst,
+		})
+	}
 	opt := &async.Option{
 		Register: metrics.Register(),
 		ConsumerOption: &consumer.Option{
@@ -123,17 +140,54 @@ func createAndStartAsync(sd serviced.ServiceDiscover, dao dao.Set, shutdownWaitT
 			Executor: &consumer.ExecutorOption{
 				WorkerNumber:       cfg.Executor.WorkerNumber,
 				TaskExecTimeoutSec: cfg.Executor.TaskExecTimeoutSec,
+				RateLimits:         rateLimits,
 			},
 			Dispatcher: &consumer.DispatcherOption{
-				WatchIntervalSec: cfg.Dispatcher.WatchIntervalSec,
+				WatchIntervalSec:   cfg.Dispatcher.WatchIntervalSec,
+				FlowNameMaxRunning: flowNameMaxRunning,
+				AccountMaxRunning:  cfg.Dispatcher.AccountMaxRunning,
 			},
 			WatchDog: &consumer.WatchDogOption{
 				WatchIntervalSec:    cfg.WatchDog.WatchIntervalSec,
 				TaskRunTimeoutSec:   cfg.WatchDog.TaskTimeoutSec,
 				ShutdownWaitTimeSec: uint(shutdownWaitTimeSec),
 			},
+			Notifier: &consumer.NotifierOption{
+				WatchIntervalSec: cfg.Notifier.WatchIntervalSec,
+				MaxAttempts:      cfg.Notifier.MaxAttempts,
+				TimeoutSec:       cfg.Notifier.TimeoutSec,
+				Secret:           cfg.Notifier.Secret,
+				Sender:           sender,
+			},
 		},
 	}
+	// 配置了保留天数时才开启已结束任务流的清理
+	if cfg.Janitor.RetentionDays != 0 {
+		if cfg.Janitor.PurgeMode != cc.JanitorArchiveMode && cfg.Janitor.PurgeMode != cc.JanitorDeleteMode {
+			return nil, fmt.Errorf("async janitor purge mode: %s not support", cfg.Janitor.PurgeMode)
+		}
+
+		opt.ConsumerOption.Janitor = &consumer.JanitorOption{
+			WatchIntervalSec: cfg.Janitor.WatchIntervalSec,
+			RetentionDays:    cfg.Janitor.RetentionDays,
+			BatchSize:        cfg.Janitor.BatchSize,
+			Archive:          cfg.Janitor.PurgeMode == cc.JanitorArchiveMode,
+		}
+	}
+
+	// 配置了声明式任务流模版目录时，加载模版并周期性重新加载
+	var loader *action.TplLoader
+	if len(cfg.Template.Dir) != 0 {
+		loader = action.NewTplLoader(&action.TplLoaderOption{
+			Dir:               cfg.Template.Dir,
+			ReloadIntervalSec: cfg.Template.ReloadIntervalSec,
+		})
+		if err = loader.Load(); err != nil {
+			return nil, fmt.Errorf("load declarative flow templates failed, err: %v", err)
+		}
+		loader.Start()
+	}
+
 	async, err := async.NewAsync(bd, leader, opt)
 	if err != nil {
 		return nil, err
@@ -146,6 +200,9 @@ func createAndStartAsync(sd serviced.ServiceDiscover, dao dao.Set, shutdownWaitT
 			defer notifier.Done()
 			logs.Infof("start shutdown async consumer gracefully...")
 			async.GetConsumer().Close()
+			if loader != nil {
+				loader.Close()
+			}
 			logs.Infof("shutdown async consumer success...")
 		}
 	}()
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package viewer
+
+import (
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+)
+
+// ListCronFlow list cron flow.
+func (svc *service) ListCronFlow(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.AsyncFlowCron().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list cron flow failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &ts.ListCronFlowResult{Count: result.Count}, nil
+	}
+
+	crons := make([]coreasync.AsyncFlowCron, 0, len(result.Details))
+	for _, one := range result.Details {
+		crons = append(crons, coreasync.AsyncFlowCron{
+			ID:         one.ID,
+			Name:       one.Name,
+			Spec:       one.Spec,
+			ShareData:  one.ShareData,
+			Tasks:      one.Tasks,
+			Memo:       one.Memo,
+			NextRunAt:  times.ConvStdTimeFormat(one.NextRunAt),
+			Priority:   one.Priority,
+			AccountID:  one.AccountID,
+			Compensate: converter.PtrToVal(one.Compensate),
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &ts.ListCronFlowResult{Details: crons}, nil
+}
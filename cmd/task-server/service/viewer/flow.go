@@ -26,14 +26,19 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
+	typesasync "hcm/pkg/dal/dao/types/async"
 	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
 )
 
-// ListFlow list flow.
+// ListFlow list flow, archived flow is queried when archived is true.
 func (svc *service) ListFlow(cts *rest.Contexts) (interface{}, error) {
-	req := new(core.ListReq)
+	req := new(ts.ListAsyncReq)
 	if err := cts.DecodeInto(req); err != nil {
 		return nil, err
 	}
@@ -47,7 +52,13 @@ func (svc *service) ListFlow(cts *rest.Contexts) (interface{}, error) {
 		Filter: req.Filter,
 		Page:   req.Page,
 	}
-	result, err := svc.dao.AsyncFlow().List(cts.Kit, opt)
+	var result *typesasync.ListAsyncFlows
+	var err error
+	if req.Archived {
+		result, err = svc.dao.AsyncArchive().ListFlow(cts.Kit, opt)
+	} else {
+		result, err = svc.dao.AsyncFlow().List(cts.Kit, opt)
+	}
 	if err != nil {
 		logs.Errorf("list flow failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
@@ -67,13 +78,21 @@ func (svc *service) ListFlow(cts *rest.Contexts) (interface{}, error) {
 
 func convCoreFlow(one tableasync.AsyncFlowTable) coreasync.AsyncFlow {
 	return coreasync.AsyncFlow{
-		ID:        one.ID,
-		Name:      one.Name,
-		State:     one.State,
-		Reason:    one.Reason,
-		ShareData: one.ShareData,
-		Memo:      one.Memo,
-		Worker:    one.Worker,
+		ID:             one.ID,
+		Name:           one.Name,
+		State:          one.State,
+		Reason:         one.Reason,
+		ShareData:      one.ShareData,
+		Memo:           one.Memo,
+		Worker:         one.Worker,
+		StartAt:        times.ConvStdTimeFormat(one.StartAt),
+		Priority:       one.Priority,
+		AccountID:      one.AccountID,
+		Compensate:     converter.PtrToVal(one.Compensate),
+		ParentFlowID:   one.ParentFlowID,
+		ParentTaskID:   one.ParentTaskID,
+		Callbacks:      one.Callbacks,
+		IdempotencyKey: converter.PtrToVal(one.IdempotencyKey),
 		Revision: core.Revision{
 			Creator:   one.Creator,
 			Reviser:   one.Reviser,
@@ -103,3 +122,81 @@ func (svc *service) GetFlow(cts *rest.Contexts) (interface{}, error) {
 	flow := convCoreFlow(result.Details[0])
 	return &flow, nil
 }
+
+// maxSubFlowDepth 查询任务流层级结构时，子任务流的最大查询深度
+const maxSubFlowDepth = 10
+
+// GetFlowHierarchy 获取任务流及其子任务流的层级结构。
+func (svc *service) GetFlowHierarchy(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.AsyncFlow().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list flow failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "flow: %s not found", id)
+	}
+
+	root := &ts.FlowHierarchy{AsyncFlow: convCoreFlow(result.Details[0])}
+	level := []*ts.FlowHierarchy{root}
+	for depth := 0; depth < maxSubFlowDepth && len(level) != 0; depth++ {
+		parentMap := make(map[string]*ts.FlowHierarchy, len(level))
+		parentIDs := make([]string, 0, len(level))
+		for _, one := range level {
+			parentMap[one.ID] = one
+			parentIDs = append(parentIDs, one.ID)
+		}
+
+		subFlows, err := svc.listSubFlow(cts.Kit, parentIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		next := make([]*ts.FlowHierarchy, 0, len(subFlows))
+		for _, one := range subFlows {
+			parent := parentMap[one.ParentFlowID]
+			parent.SubFlows = append(parent.SubFlows, ts.FlowHierarchy{AsyncFlow: convCoreFlow(one)})
+		}
+		for _, one := range level {
+			for i := range one.SubFlows {
+				next = append(next, &one.SubFlows[i])
+			}
+		}
+		level = next
+	}
+
+	return root, nil
+}
+
+// listSubFlow 查询父任务流下的全部子任务流
+func (svc *service) listSubFlow(kt *kit.Kit, parentIDs []string) ([]tableasync.AsyncFlowTable, error) {
+	flows := make([]tableasync.AsyncFlowTable, 0)
+	for _, partIDs := range slice.Split(parentIDs, int(core.DefaultMaxPageLimit)) {
+		opt := &types.ListOption{
+			Filter: tools.ContainersExpression("parent_flow_id", partIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			result, err := svc.dao.AsyncFlow().List(kt, opt)
+			if err != nil {
+				logs.Errorf("list sub flow failed, err: %v, parent ids: %v, rid: %s", err, partIDs, kt.Rid)
+				return nil, err
+			}
+
+			flows = append(flows, result.Details...)
+
+			if len(result.Details) < int(core.DefaultMaxPageLimit) {
+				break
+			}
+			opt.Page.Start += uint32(opt.Page.Limit)
+		}
+	}
+
+	return flows, nil
+}
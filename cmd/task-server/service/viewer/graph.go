@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package viewer
+
+import (
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/graph"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/times"
+)
+
+// GetFlowGraph 获取任务流的任务依赖图，节点按照任务状态着色并展示任务执行耗时，format 支持 dot、mermaid，默认为 dot。
+func (svc *service) GetFlowGraph(cts *rest.Contexts) (interface{}, error) {
+	format, err := parseGraphFormat(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	id := cts.PathParameter("id").String()
+	flowOpt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	flowResult, err := svc.dao.AsyncFlow().List(cts.Kit, flowOpt)
+	if err != nil {
+		logs.Errorf("list flow failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(flowResult.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "flow: %s not found", id)
+	}
+	flow := convCoreFlow(flowResult.Details[0])
+
+	taskOpt := &types.ListOption{
+		Filter: tools.EqualExpression("flow_id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	tasks := make([]coreasync.AsyncFlowTask, 0)
+	for {
+		taskResult, err := svc.dao.AsyncFlowTask().List(cts.Kit, taskOpt)
+		if err != nil {
+			logs.Errorf("list flow task failed, err: %v, flow: %s, rid: %s", err, id, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range taskResult.Details {
+			tasks = append(tasks, convCoreTask(one))
+		}
+
+		if len(taskResult.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		taskOpt.Page.Start += uint32(taskOpt.Page.Limit)
+	}
+
+	nodes := graph.FromTasks(flow, tasks, times.ConvStdTimeNow())
+	content, err := graph.Render(format, string(flow.Name), nodes)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return &ts.FlowGraphResult{ID: flow.ID, Name: flow.Name, Format: format, Graph: content}, nil
+}
+
+// GetFlowTemplateGraph 获取已注册任务流模版的任务依赖图，用于评审任务流模版的依赖关系。
+func (svc *service) GetFlowTemplateGraph(cts *rest.Contexts) (interface{}, error) {
+	format, err := parseGraphFormat(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	name := enumor.FlowName(cts.PathParameter("name").String())
+	tpl, exist := action.GetTpl(name)
+	if !exist {
+		return nil, errf.Newf(errf.RecordNotFound, "flow template: %s not found", name)
+	}
+
+	content, err := graph.Render(format, string(name), graph.FromTemplate(&tpl))
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return &ts.FlowGraphResult{Name: name, Format: format, Graph: content}, nil
+}
+
+func parseGraphFormat(cts *rest.Contexts) (enumor.FlowGraphFormat, error) {
+	format := enumor.FlowGraphFormat(cts.Request.QueryParameter("format"))
+	if len(format) == 0 {
+		return enumor.FlowGraphDot, nil
+	}
+
+	if err := format.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return format, nil
+}
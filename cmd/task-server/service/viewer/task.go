@@ -26,14 +26,16 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
+	typesasync "hcm/pkg/dal/dao/types/async"
 	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
 )
 
-// ListTask list task.
+// ListTask list task, archived task is queried when archived is true.
 func (svc *service) ListTask(cts *rest.Contexts) (interface{}, error) {
-	req := new(core.ListReq)
+	req := new(ts.ListAsyncReq)
 	if err := cts.DecodeInto(req); err != nil {
 		return nil, err
 	}
@@ -47,7 +49,13 @@ func (svc *service) ListTask(cts *rest.Contexts) (interface{}, error) {
 		Filter: req.Filter,
 		Page:   req.Page,
 	}
-	result, err := svc.dao.AsyncFlowTask().List(cts.Kit, opt)
+	var result *typesasync.ListAsyncFlowTasks
+	var err error
+	if req.Archived {
+		result, err = svc.dao.AsyncArchive().ListTask(cts.Kit, opt)
+	} else {
+		result, err = svc.dao.AsyncFlowTask().List(cts.Kit, opt)
+	}
 	if err != nil {
 		logs.Errorf("list task failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
@@ -67,17 +75,23 @@ func (svc *service) ListTask(cts *rest.Contexts) (interface{}, error) {
 
 func convCoreTask(one tableasync.AsyncFlowTaskTable) coreasync.AsyncFlowTask {
 	return coreasync.AsyncFlowTask{
-		ID:         one.ID,
-		FlowID:     one.FlowID,
-		FlowName:   one.FlowName,
-		ActionID:   one.ActionID,
-		ActionName: one.ActionName,
-		Params:     one.Params,
-		Result:     one.Result,
-		Retry:      one.Retry,
-		DependOn:   one.DependOn,
-		State:      one.State,
-		Reason:     one.Reason,
+		ID:           one.ID,
+		FlowID:       one.FlowID,
+		FlowName:     one.FlowName,
+		ActionID:     one.ActionID,
+		ActionName:   one.ActionName,
+		Params:       one.Params,
+		Result:       one.Result,
+		Retry:        one.Retry,
+		DependOn:     one.DependOn,
+		TimeoutSec:   one.TimeoutSec,
+		AllowFailure: converter.PtrToVal(one.AllowFailure),
+		TriggerRule:  one.TriggerRule,
+		RunCondition: one.RunCondition,
+		ParentID:     one.ParentID,
+		State:        one.State,
+		Reason:       one.Reason,
+		RetryState:   one.RetryState,
 		Revision: core.Revision{
 			Creator:   one.Creator,
 			Reviser:   one.Reviser,
@@ -107,3 +121,30 @@ func (svc *service) GetTask(cts *rest.Contexts) (interface{}, error) {
 	task := convCoreTask(result.Details[0])
 	return &task, nil
 }
+
+// GetTaskLogs get task execution logs.
+func (svc *service) GetTaskLogs(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	opt := &types.ListOption{
+		Fields: []string{"id", "state", "logs"},
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.AsyncFlowTask().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list task failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "task: %s not found", id)
+	}
+
+	task := result.Details[0]
+	taskLogs := task.Logs
+	if taskLogs == nil {
+		taskLogs = make(tableasync.TaskLogs, 0)
+	}
+
+	return &ts.TaskLogsResult{ID: task.ID, State: task.State, Logs: taskLogs}, nil
+}
@@ -38,8 +38,14 @@ func Init(cap *capability.Capability) {
 
 	h.Add("ListFlow", "POST", "/flows/list", svc.ListFlow)
 	h.Add("GetFlow", "GET", "/flows/{id}", svc.GetFlow)
+	h.Add("GetFlowHierarchy", "GET", "/flows/{id}/hierarchy", svc.GetFlowHierarchy)
+	h.Add("GetFlowGraph", "GET", "/flows/{id}/graph", svc.GetFlowGraph)
+	h.Add("GetFlowTemplateGraph", "GET", "/flow_templates/{name}/graph", svc.GetFlowTemplateGraph)
 	h.Add("ListTask", "POST", "/tasks/list", svc.ListTask)
 	h.Add("GetTask", "GET", "/tasks/{id}", svc.GetTask)
+	h.Add("GetTaskLogs", "GET", "/tasks/{id}/logs", svc.GetTaskLogs)
+	h.Add("ListCronFlow", "POST", "/cron_flows/list", svc.ListCronFlow)
+	h.Add("ListCallbackDelivery", "POST", "/callback_deliveries/list", svc.ListCallbackDelivery)
 
 	h.Load(cap.WebService)
 }
@@ -33,6 +33,8 @@ import (
 
 // Adaptor holds all the supported operations by the adaptor.
 type Adaptor struct {
+	// fakeTCloud 不为空时，所有腾讯云操作都由该模拟实现处理，不再请求真实的云API
+	fakeTCloud tcloud.TCloud
 }
 
 // New an Adaptor pointer
@@ -40,27 +42,36 @@ func New() *Adaptor {
 	return &Adaptor{}
 }
 
+// NewWithFakeTCloud new an Adaptor pointer whose tencent cloud operations are all served by the fake tcloud.
+func NewWithFakeTCloud(fake tcloud.TCloud) *Adaptor {
+	return &Adaptor{fakeTCloud: fake}
+}
+
 // TCloud returns tencent cloud operations.
 func (a *Adaptor) TCloud(s *types.BaseSecret) (tcloud.TCloud, error) {
+	if a.fakeTCloud != nil {
+		return a.fakeTCloud, nil
+	}
+
 	return tcloud.NewTCloud(s)
 }
 
 // Aws returns Aws operations.
-func (a *Adaptor) Aws(s *types.BaseSecret, cloudAccountID string) (*aws.Aws, error) {
+func (a *Adaptor) Aws(s *types.BaseSecret, cloudAccountID string) (aws.Aws, error) {
 	return aws.NewAws(s, cloudAccountID)
 }
 
 // Gcp returns Gcp operations.
-func (a *Adaptor) Gcp(credential *types.GcpCredential) (*gcp.Gcp, error) {
+func (a *Adaptor) Gcp(credential *types.GcpCredential) (gcp.Gcp, error) {
 	return gcp.NewGcp(credential)
 }
 
 // Azure returns Azure operations.
-func (a *Adaptor) Azure(credential *types.AzureCredential) (*azure.Azure, error) {
+func (a *Adaptor) Azure(credential *types.AzureCredential) (azure.Azure, error) {
 	return azure.NewAzure(credential)
 }
 
 // HuaWei returns HuaWei operations.
-func (a *Adaptor) HuaWei(s *types.BaseSecret) (*huawei.HuaWei, error) {
+func (a *Adaptor) HuaWei(s *types.BaseSecret) (huawei.HuaWei, error) {
 	return huawei.NewHuaWei(s)
 }
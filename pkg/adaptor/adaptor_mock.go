@@ -37,6 +37,7 @@ import (
 
 // Adaptor holds all the supported operations by the adaptor.
 type Adaptor struct {
+	fakeTCloud tcloud.TCloud
 }
 
 // New an Adaptor pointer
@@ -46,28 +47,39 @@ func New() *Adaptor {
 	return &Adaptor{}
 }
 
+// NewWithFakeTCloud new an Adaptor pointer whose tencent cloud operations are all served by the fake tcloud.
+func NewWithFakeTCloud(fake tcloud.TCloud) *Adaptor {
+	logs.Infof("Using fake tcloud")
+
+	return &Adaptor{fakeTCloud: fake}
+}
+
 // TCloud returns tencent cloud operations.
 func (a *Adaptor) TCloud(s *types.BaseSecret) (tcloud.TCloud, error) {
+	if a.fakeTCloud != nil {
+		return a.fakeTCloud, nil
+	}
+
 	mockTcloud := mocktcloud.GetMockCloud()
 	return mockTcloud, nil
 }
 
 // Aws returns Aws operations.
-func (a *Adaptor) Aws(s *types.BaseSecret, cloudAccountID string) (*aws.Aws, error) {
+func (a *Adaptor) Aws(s *types.BaseSecret, cloudAccountID string) (aws.Aws, error) {
 	return nil, errors.New("mock of aws not implemented")
 }
 
 // Gcp returns Gcp operations.
-func (a *Adaptor) Gcp(credential *types.GcpCredential) (*gcp.Gcp, error) {
+func (a *Adaptor) Gcp(credential *types.GcpCredential) (gcp.Gcp, error) {
 	return nil, errors.New("mock of gcp not implemented")
 }
 
 // Azure returns Azure operations.
-func (a *Adaptor) Azure(credential *types.AzureCredential) (*azure.Azure, error) {
+func (a *Adaptor) Azure(credential *types.AzureCredential) (azure.Azure, error) {
 	return nil, errors.New("mock of azure not implemented")
 }
 
 // HuaWei returns HuaWei operations.
-func (a *Adaptor) HuaWei(s *types.BaseSecret) (*huawei.HuaWei, error) {
+func (a *Adaptor) HuaWei(s *types.BaseSecret) (huawei.HuaWei, error) {
 	return nil, errors.New("mock of huawei not implemented")
 }
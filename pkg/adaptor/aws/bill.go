@@ -75,7 +75,7 @@ const (
 )
 
 // GetBillList get bill list
-func (a *Aws) GetBillList(kt *kit.Kit, opt *typesBill.AwsBillListOption,
+func (a *AwsImpl) GetBillList(kt *kit.Kit, opt *typesBill.AwsBillListOption,
 	billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (int64, interface{}, error) {
 
 	where, err := parseCondition(opt)
@@ -109,7 +109,8 @@ func (a *Aws) GetBillList(kt *kit.Kit, opt *typesBill.AwsBillListOption,
 }
 
 // GetBillTotal get bill total num
-func (a *Aws) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (
+func (a *AwsImpl) GetBillTotal(kt *kit.Kit, where string,
+	billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (
 	int64, error) {
 
 	sql := fmt.Sprintf(QueryBillTotalSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, where)
@@ -126,7 +127,7 @@ func (a *Aws) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBil
 	return total, nil
 }
 
-func (a *Aws) GetAwsAthenaQuery(kt *kit.Kit, query string,
+func (a *AwsImpl) GetAwsAthenaQuery(kt *kit.Kit, query string,
 	billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) ([]map[string]string, error) {
 
 	client, err := a.clientSet.athenaClient(billInfo.Extension.Region)
@@ -238,7 +239,7 @@ func parseCondition(opt *typesBill.AwsBillListOption) (string, error) {
 
 // CreateBucket create s3 bucket.
 // reference: https://docs.aws.amazon.com/zh_cn/AmazonS3/latest/API/API_CreateBucket.html
-func (a *Aws) CreateBucket(kt *kit.Kit, opt *typesBill.AwsBillBucketCreateReq) (*string, error) {
+func (a *AwsImpl) CreateBucket(kt *kit.Kit, opt *typesBill.AwsBillBucketCreateReq) (*string, error) {
 	client, err := a.clientSet.s3Client(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor s3 bucket client failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
@@ -258,7 +259,7 @@ func (a *Aws) CreateBucket(kt *kit.Kit, opt *typesBill.AwsBillBucketCreateReq) (
 
 // DeleteBucket delete s3 bucket.
 // reference: https://docs.aws.amazon.com/zh_cn/AmazonS3/latest/API/API_DeleteBucket.html
-func (a *Aws) DeleteBucket(kt *kit.Kit, opt *typesBill.AwsBillBucketDeleteReq) error {
+func (a *AwsImpl) DeleteBucket(kt *kit.Kit, opt *typesBill.AwsBillBucketDeleteReq) error {
 	client, err := a.clientSet.s3Client(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor s3 delete bucket client failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
@@ -277,7 +278,7 @@ func (a *Aws) DeleteBucket(kt *kit.Kit, opt *typesBill.AwsBillBucketDeleteReq) e
 
 // ListBucket list bucket.
 // reference: https://docs.aws.amazon.com/zh_cn/AmazonS3/latest/API/API_ListBuckets.html
-func (a *Aws) ListBucket(kt *kit.Kit, region string) ([]*s3.Bucket, error) {
+func (a *AwsImpl) ListBucket(kt *kit.Kit, region string) ([]*s3.Bucket, error) {
 	client, err := a.clientSet.s3Client(region)
 	if err != nil {
 		logs.Errorf("aws adaptor bill bucket list client failed, region: %s, err: %v, rid: %s",
@@ -297,7 +298,7 @@ func (a *Aws) ListBucket(kt *kit.Kit, region string) ([]*s3.Bucket, error) {
 
 // GetObject get object.
 // reference: https://docs.aws.amazon.com/zh_cn/AmazonS3/latest/API/API_GetObject.html
-func (a *Aws) GetObject(kt *kit.Kit, opt *typesBill.AwsBillGetObjectReq) (*s3.GetObjectOutput, error) {
+func (a *AwsImpl) GetObject(kt *kit.Kit, opt *typesBill.AwsBillGetObjectReq) (*s3.GetObjectOutput, error) {
 	client, err := a.clientSet.s3Client(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor bill get object client failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
@@ -318,7 +319,7 @@ func (a *Aws) GetObject(kt *kit.Kit, opt *typesBill.AwsBillGetObjectReq) (*s3.Ge
 
 // GetBucketPolicy get bucket policy.
 // reference: https://docs.aws.amazon.com/zh_cn/AmazonS3/latest/API/API_GetBucketPolicy.html
-func (a *Aws) GetBucketPolicy(kt *kit.Kit, opt *typesBill.AwsBillBucketPolicyReq) (*string, error) {
+func (a *AwsImpl) GetBucketPolicy(kt *kit.Kit, opt *typesBill.AwsBillBucketPolicyReq) (*string, error) {
 	client, err := a.clientSet.s3Client(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor get bucket policy client failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
@@ -339,7 +340,7 @@ func (a *Aws) GetBucketPolicy(kt *kit.Kit, opt *typesBill.AwsBillBucketPolicyReq
 
 // PutBucketPolicy put bucket policy.
 // reference: https://docs.aws.amazon.com/zh_cn/AmazonS3/latest/API/API_PutBucketPolicy.html
-func (a *Aws) PutBucketPolicy(kt *kit.Kit, opt *typesBill.AwsBillBucketPolicyReq) error {
+func (a *AwsImpl) PutBucketPolicy(kt *kit.Kit, opt *typesBill.AwsBillBucketPolicyReq) error {
 	client, err := a.clientSet.s3Client(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor put bucket policy client failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
@@ -367,7 +368,7 @@ func (a *Aws) PutBucketPolicy(kt *kit.Kit, opt *typesBill.AwsBillBucketPolicyReq
 
 // PutReportDefinition put report definition.
 // reference: https://docs.aws.amazon.com/zh_cn/aws-cost-management/latest/APIReference/API_cur_PutReportDefinition.html
-func (a *Aws) PutReportDefinition(kt *kit.Kit, opt *typesBill.AwsBillPutReportDefinitionReq) error {
+func (a *AwsImpl) PutReportDefinition(kt *kit.Kit, opt *typesBill.AwsBillPutReportDefinitionReq) error {
 	client, err := a.clientSet.costAndUsageReportClient(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor cur put report definition client failed, opt: %v, err: %v, rid: %s",
@@ -402,7 +403,7 @@ func (a *Aws) PutReportDefinition(kt *kit.Kit, opt *typesBill.AwsBillPutReportDe
 // DeleteReportDefinition delete report definition.
 // reference: https://docs.aws.amazon.com/zh_cn/aws-cost-management/latest/APIReference/
 // API_cur_DeleteReportDefinition.html
-func (a *Aws) DeleteReportDefinition(kt *kit.Kit, opt *typesBill.AwsBillDeleteReportDefinitionReq) error {
+func (a *AwsImpl) DeleteReportDefinition(kt *kit.Kit, opt *typesBill.AwsBillDeleteReportDefinitionReq) error {
 	client, err := a.clientSet.costAndUsageReportClient(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor delete report definition client failed, opt: %v, err: %v, rid: %s",
@@ -425,7 +426,7 @@ func (a *Aws) DeleteReportDefinition(kt *kit.Kit, opt *typesBill.AwsBillDeleteRe
 
 // CreateStack create stack.
 // reference: https://docs.aws.amazon.com/AWSCloudFormation/latest/APIReference/API_CreateStack.html
-func (a *Aws) CreateStack(kt *kit.Kit, opt *typesBill.AwsCreateStackReq) (string, error) {
+func (a *AwsImpl) CreateStack(kt *kit.Kit, opt *typesBill.AwsCreateStackReq) (string, error) {
 	client, err := a.clientSet.cloudFormationClient(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor formation client failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
@@ -448,7 +449,7 @@ func (a *Aws) CreateStack(kt *kit.Kit, opt *typesBill.AwsCreateStackReq) (string
 
 // DescribeStack describe stack.
 // reference: https://docs.aws.amazon.com/AWSCloudFormation/latest/APIReference/API_DescribeStacks.html
-func (a *Aws) DescribeStack(kt *kit.Kit, opt *typesBill.AwsDeleteStackReq) ([]*cloudformation.Stack, error) {
+func (a *AwsImpl) DescribeStack(kt *kit.Kit, opt *typesBill.AwsDeleteStackReq) ([]*cloudformation.Stack, error) {
 	client, err := a.clientSet.cloudFormationClient(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor formation client failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
@@ -469,7 +470,7 @@ func (a *Aws) DescribeStack(kt *kit.Kit, opt *typesBill.AwsDeleteStackReq) ([]*c
 
 // DeleteStack delete stack.
 // reference: https://docs.aws.amazon.com/AWSCloudFormation/latest/APIReference/API_DeleteStack.html
-func (a *Aws) DeleteStack(kt *kit.Kit, opt *typesBill.AwsDeleteStackReq) error {
+func (a *AwsImpl) DeleteStack(kt *kit.Kit, opt *typesBill.AwsDeleteStackReq) error {
 	client, err := a.clientSet.cloudFormationClient(opt.Region)
 	if err != nil {
 		logs.Errorf("aws adaptor formation client failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
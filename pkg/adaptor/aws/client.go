@@ -20,6 +20,9 @@
 package aws
 
 import (
+	"net/http"
+
+	"hcm/pkg/adaptor/cassette"
 	"hcm/pkg/adaptor/types"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -29,6 +32,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	curservice "github.com/aws/aws-sdk-go/service/costandusagereportservice"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
@@ -44,21 +48,29 @@ const (
 	ErrSubnetNotFound     = "InvalidSubnetID.NotFound"
 	ErrDiskNotFound       = "InvalidVolume.NotFound"
 	ErrCvmNotFound        = "InvalidInstanceID.NotFound"
+
+	ErrLoadBalancerNotFound = "LoadBalancerNotFound"
+	ErrTargetGroupNotFound  = "TargetGroupNotFound"
 )
 
 type clientSet struct {
 	credentials *credentials.Credentials
+	// httpClient 非空时 sdk 使用该 http client 发送请求, 用于录制/回放云厂商 API 调用.
+	httpClient *http.Client
 }
 
 func newClientSet(secret *types.BaseSecret) *clientSet {
-	return &clientSet{credentials.NewStaticCredentials(secret.CloudSecretID, secret.CloudSecretKey, "")}
+	return &clientSet{
+		credentials: credentials.NewStaticCredentials(secret.CloudSecretID, secret.CloudSecretKey, ""),
+		httpClient:  cassette.HTTPClient(),
+	}
 }
 
 func (c *clientSet) ec2Client(region string) (*ec2.EC2, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -78,11 +90,35 @@ func (c *clientSet) ec2Client(region string) (*ec2.EC2, error) {
 	return ec2.New(sess), nil
 }
 
+func (c *clientSet) elbClient(region string) (*elbv2.ELBV2, error) {
+	cfg := &aws.Config{
+		Credentials: c.credentials,
+		DisableSSL:  nil,
+		HTTPClient:  c.httpClient,
+		LogLevel:    nil,
+		Logger:      nil,
+		MaxRetries:  nil,
+		Retryer:     nil,
+		SleepDelay:  nil,
+	}
+
+	if len(region) != 0 {
+		cfg.Region = aws.String(region)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return elbv2.New(sess), nil
+}
+
 func (c *clientSet) stsClient() (*sts.STS, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -102,7 +138,7 @@ func (c *clientSet) athenaClient(region string) (*athena.Athena, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -126,7 +162,7 @@ func (c *clientSet) organizations() (*organizations.Organizations, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -146,7 +182,7 @@ func (c *clientSet) s3Client(region string) (*s3.S3, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -170,7 +206,7 @@ func (c *clientSet) costAndUsageReportClient(region string) (*curservice.Costand
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -194,7 +230,7 @@ func (c *clientSet) cloudFormationClient(region string) (*cloudformation.CloudFo
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -0,0 +1,138 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// ListSnapshot 查询账号所拥有的云盘快照列表
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DescribeSnapshots.html
+func (a *AwsImpl) ListSnapshot(kt *kit.Kit, opt *typesnapshot.AwsListOption) ([]typesnapshot.AwsSnapshot, *string,
+	error) {
+
+	if opt == nil {
+		return nil, nil, errf.New(errf.InvalidParameter, "aws snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 不指定所有者时会返回所有公开快照，因此只查询当前账号所拥有的快照
+	req := &ec2.DescribeSnapshotsInput{OwnerIds: aws.StringSlice([]string{"self"})}
+	if len(opt.CloudIDs) > 0 {
+		// 使用过滤条件而不是SnapshotIds查询，避免部分快照已被删除时整个请求返回InvalidSnapshot.NotFound
+		req.Filters = []*ec2.Filter{{Name: aws.String("snapshot-id"), Values: aws.StringSlice(opt.CloudIDs)}}
+	} else if opt.Page != nil {
+		req.MaxResults = opt.Page.MaxResults
+		req.NextToken = opt.Page.NextToken
+	} else {
+		req.MaxResults = converter.ValToPtr(int64(core.AwsQueryLimit))
+	}
+
+	resp, err := client.DescribeSnapshotsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, nil, err
+	}
+
+	snapshots := make([]typesnapshot.AwsSnapshot, 0, len(resp.Snapshots))
+	for _, one := range resp.Snapshots {
+		snapshots = append(snapshots, typesnapshot.AwsSnapshot{Snapshot: one})
+	}
+
+	return snapshots, resp.NextToken, nil
+}
+
+// CreateSnapshot 创建云盘快照，返回快照的云上ID，快照创建完成前状态为 pending
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_CreateSnapshot.html
+func (a *AwsImpl) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.AwsCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "aws snapshot create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := &ec2.CreateSnapshotInput{
+		VolumeId:    aws.String(opt.CloudDiskID),
+		Description: opt.Description,
+	}
+	if opt.Name != nil {
+		req.TagSpecifications = []*ec2.TagSpecification{{
+			ResourceType: aws.String(ec2.ResourceTypeSnapshot),
+			Tags:         []*ec2.Tag{{Key: aws.String("Name"), Value: opt.Name}},
+		}}
+	}
+
+	resp, err := client.CreateSnapshotWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.SnapshotId), nil
+}
+
+// DeleteSnapshot 删除云盘快照
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DeleteSnapshot.html
+func (a *AwsImpl) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.AwsDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "aws snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DeleteSnapshotWithContext(kt.Ctx, &ec2.DeleteSnapshotInput{
+		SnapshotId: aws.String(opt.CloudID)}); err != nil {
+
+		logs.Errorf("delete aws snapshot failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
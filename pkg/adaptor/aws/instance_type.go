@@ -32,7 +32,7 @@ import (
 
 // ListInstanceType ...
 // reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeInstanceTypes.html
-func (a *Aws) ListInstanceType(kt *kit.Kit, opt *typesinstancetype.AwsInstanceTypeListOption) (
+func (a *AwsImpl) ListInstanceType(kt *kit.Kit, opt *typesinstancetype.AwsInstanceTypeListOption) (
 	*typesinstancetype.AwsInstanceTypeListResult, error,
 ) {
 	client, err := a.clientSet.ec2Client(opt.Region)
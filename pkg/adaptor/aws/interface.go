@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+//go:generate  mockgen -destination ../mock/aws/aws_mock.go  -package=mockaws -typed -source=interface.go
+
+package aws
+
+import (
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/account"
+	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/adaptor/types/image"
+	typesinstancetype "hcm/pkg/adaptor/types/instance-type"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	typesRegion "hcm/pkg/adaptor/types/region"
+	"hcm/pkg/adaptor/types/route-table"
+	"hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/adaptor/types/security-group-rule"
+	"hcm/pkg/adaptor/types/subnet"
+	typeszone "hcm/pkg/adaptor/types/zone"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/kit"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Aws adaptor interface for aws
+type Aws interface {
+	ListAccount(kt *kit.Kit) ([]account.AwsAccount, error)
+	CountAccount(kt *kit.Kit) (int32, error)
+	GetAccountInfoBySecret(kt *kit.Kit) (*cloud.AwsInfoBySecret, error)
+	CloudAccountID() string
+	GetBillList(kt *kit.Kit, opt *typesBill.AwsBillListOption,
+		billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (int64, interface{}, error)
+	GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) (
+		int64, error)
+	GetAwsAthenaQuery(kt *kit.Kit, query string,
+		billInfo *cloud.AccountBillConfig[cloud.AwsBillConfigExtension]) ([]map[string]string, error)
+	CreateBucket(kt *kit.Kit, opt *typesBill.AwsBillBucketCreateReq) (*string, error)
+	DeleteBucket(kt *kit.Kit, opt *typesBill.AwsBillBucketDeleteReq) error
+	ListBucket(kt *kit.Kit, region string) ([]*s3.Bucket, error)
+	GetObject(kt *kit.Kit, opt *typesBill.AwsBillGetObjectReq) (*s3.GetObjectOutput, error)
+	GetBucketPolicy(kt *kit.Kit, opt *typesBill.AwsBillBucketPolicyReq) (*string, error)
+	PutBucketPolicy(kt *kit.Kit, opt *typesBill.AwsBillBucketPolicyReq) error
+	PutReportDefinition(kt *kit.Kit, opt *typesBill.AwsBillPutReportDefinitionReq) error
+	DeleteReportDefinition(kt *kit.Kit, opt *typesBill.AwsBillDeleteReportDefinitionReq) error
+	CreateStack(kt *kit.Kit, opt *typesBill.AwsCreateStackReq) (string, error)
+	DescribeStack(kt *kit.Kit, opt *typesBill.AwsDeleteStackReq) ([]*cloudformation.Stack, error)
+	DeleteStack(kt *kit.Kit, opt *typesBill.AwsDeleteStackReq) error
+	ListCvm(kt *kit.Kit, opt *typecvm.AwsListOption) ([]typecvm.AwsCvm, *ec2.DescribeInstancesOutput, error)
+	CountCvm(kt *kit.Kit, region string) (int32, error)
+	DeleteCvm(kt *kit.Kit, opt *typecvm.AwsDeleteOption) error
+	StartCvm(kt *kit.Kit, opt *typecvm.AwsStartOption) error
+	StopCvm(kt *kit.Kit, opt *typecvm.AwsStopOption) error
+	RebootCvm(kt *kit.Kit, opt *typecvm.AwsRebootOption) error
+	CreateCvm(kt *kit.Kit, opt *typecvm.AwsCreateOption) (*poller.BaseDoneResult, error)
+	CreateDisk(kt *kit.Kit, opt *disk.AwsDiskCreateOption) (*poller.BaseDoneResult, error)
+	ListDisk(kt *kit.Kit, opt *disk.AwsDiskListOption) ([]disk.AwsDisk, *string, error)
+	CountDisk(kt *kit.Kit, region string) (int32, error)
+	DeleteDisk(kt *kit.Kit, opt *disk.AwsDiskDeleteOption) error
+	AttachDisk(kt *kit.Kit, opt *disk.AwsDiskAttachOption) error
+	DetachDisk(kt *kit.Kit, opt *disk.AwsDiskDetachOption) error
+	ListSnapshot(kt *kit.Kit, opt *typesnapshot.AwsListOption) ([]typesnapshot.AwsSnapshot, *string, error)
+	CreateSnapshot(kt *kit.Kit, opt *typesnapshot.AwsCreateOption) (string, error)
+	DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.AwsDeleteOption) error
+	ListEip(kt *kit.Kit, opt *eip.AwsEipListOption) (*eip.AwsEipListResult, error)
+	CountEip(kt *kit.Kit, region string) (int32, error)
+	DeleteEip(kt *kit.Kit, opt *eip.AwsEipDeleteOption) error
+	AssociateEip(kt *kit.Kit, opt *eip.AwsEipAssociateOption) error
+	DisassociateEip(kt *kit.Kit, opt *eip.AwsEipDisassociateOption) error
+	CreateEip(kt *kit.Kit, opt *eip.AwsEipCreateOption) (*string, error)
+	ListImage(kt *kit.Kit, opt *image.AwsImageListOption) (*image.AwsImageListResult, error)
+	ListInstanceType(kt *kit.Kit, opt *typesinstancetype.AwsInstanceTypeListOption) (
+		*typesinstancetype.AwsInstanceTypeListResult, error,
+	)
+	ListRegion(kt *kit.Kit) (*typesRegion.AwsRegionListResult, error)
+	UpdateRouteTable(kt *kit.Kit, opt *routetable.AwsRouteTableUpdateOption) error
+	DeleteRouteTable(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
+	ListRouteTable(kt *kit.Kit, opt *routetable.AwsRouteTableListOption) (
+		*routetable.AwsRouteTableListResult, error)
+	CountRouteTable(kt *kit.Kit, region string) (int32, error)
+	CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsCreateOption) (string, error)
+	ListSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsListOption) ([]securitygroup.AwsSG,
+		*ec2.DescribeSecurityGroupsOutput, error)
+	CountSecurityGroup(kt *kit.Kit, region string) (int32, error)
+	DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsDeleteOption) error
+	SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.AwsAssociateCvmOption) error
+	SecurityGroupCvmDisassociate(kt *kit.Kit, opt *securitygroup.AwsAssociateCvmOption) error
+	CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateOption) (
+		[]*ec2.SecurityGroupRule, error)
+	DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsDeleteOption) error
+	ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsListOption) (
+		[]securitygrouprule.AwsSGRule, error)
+	UpdateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsUpdateOption) error
+	CreateSubnet(kt *kit.Kit, opt *adtysubnet.AwsSubnetCreateOption) (*adtysubnet.AwsSubnet, error)
+	CreateDefaultSubnet(kt *kit.Kit, opt *adtysubnet.AwsDefaultSubnetCreateOption) (*adtysubnet.AwsSubnet,
+		error)
+	UpdateSubnet(_ *kit.Kit, _ *adtysubnet.AwsSubnetUpdateOption) error
+	DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
+	ListSubnet(kt *kit.Kit, opt *core.AwsListOption) (*adtysubnet.AwsSubnetListResult, error)
+	CountSubnet(kt *kit.Kit, region string) (int32, error)
+	CreateVpc(kt *kit.Kit, opt *types.AwsVpcCreateOption) (*types.AwsVpc, error)
+	UpdateVpc(kt *kit.Kit, opt *types.AwsVpcUpdateOption) error
+	DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
+	ListVpc(kt *kit.Kit, opt *core.AwsListOption) (*types.AwsVpcListResult, error)
+	CountVpc(kt *kit.Kit, region string) (int32, error)
+	GetVpcAttribute(kt *kit.Kit, vpcID, region string) (bool, bool, error)
+	ListZone(kit *kit.Kit, opt *typeszone.AwsZoneListOption) ([]typeszone.AwsZone, error)
+	ListLoadBalancer(kt *kit.Kit, opt *typelb.AwsListOption) (*typelb.AwsListResult, error)
+	CreateLoadBalancer(kt *kit.Kit, opt *typelb.AwsCreateOption) (*string, error)
+	DeleteLoadBalancer(kt *kit.Kit, opt *typelb.AwsDeleteOption) error
+	ListListener(kt *kit.Kit, opt *typelb.AwsListenerListOption) ([]typelb.AwsListener, error)
+	ListTargetGroup(kt *kit.Kit, opt *typelb.AwsListOption) (*typelb.AwsTargetGroupListResult, error)
+	ListTarget(kt *kit.Kit, opt *typelb.AwsTargetListOption) ([]typelb.AwsTarget, error)
+}
@@ -0,0 +1,241 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// ListLoadBalancer 查询负载均衡，elbv2 分页使用 Marker，这里复用 AwsPage.NextToken 作为 Marker。
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeLoadBalancers.html
+func (a *AwsImpl) ListLoadBalancer(kt *kit.Kit, opt *typelb.AwsListOption) (*typelb.AwsListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, err: %v", err)
+	}
+
+	req := new(elbv2.DescribeLoadBalancersInput)
+	if len(opt.CloudIDs) != 0 {
+		req.LoadBalancerArns = converter.SliceToPtr(opt.CloudIDs)
+	}
+
+	if opt.Page != nil {
+		req.PageSize = opt.Page.MaxResults
+		req.Marker = opt.Page.NextToken
+	}
+
+	resp, err := client.DescribeLoadBalancersWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]typelb.AwsLoadBalancer, 0, len(resp.LoadBalancers))
+	for _, one := range resp.LoadBalancers {
+		details = append(details, typelb.AwsLoadBalancer{LoadBalancer: one})
+	}
+
+	return &typelb.AwsListResult{NextToken: resp.NextMarker, Details: details}, nil
+}
+
+// CreateLoadBalancer 创建负载均衡，返回负载均衡arn。
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_CreateLoadBalancer.html
+func (a *AwsImpl) CreateLoadBalancer(kt *kit.Kit, opt *typelb.AwsCreateOption) (*string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	req, err := opt.ToCreateLoadBalancerInput()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, err: %v", err)
+	}
+
+	resp, err := client.CreateLoadBalancerWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if len(resp.LoadBalancers) == 0 {
+		return nil, fmt.Errorf("create aws load balancer succeed, but no load balancer returned")
+	}
+
+	return resp.LoadBalancers[0].LoadBalancerArn, nil
+}
+
+// DeleteLoadBalancer 删除负载均衡，关联的监听器会一并删除，目标组需要单独清理。
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DeleteLoadBalancer.html
+func (a *AwsImpl) DeleteLoadBalancer(kt *kit.Kit, opt *typelb.AwsDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new elb client failed, err: %v", err)
+	}
+
+	req := &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: converter.ValToPtr(opt.CloudID)}
+	if _, err = client.DeleteLoadBalancerWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws load balancer failed, err: %v, arn: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListListener 查询负载均衡下的全部监听器。
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeListeners.html
+func (a *AwsImpl) ListListener(kt *kit.Kit, opt *typelb.AwsListenerListOption) ([]typelb.AwsListener, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, err: %v", err)
+	}
+
+	req := &elbv2.DescribeListenersInput{
+		LoadBalancerArn: converter.ValToPtr(opt.CloudLoadBalancerID),
+		PageSize:        converter.ValToPtr(int64(typelb.AwsQueryLimit)),
+	}
+
+	listeners := make([]typelb.AwsListener, 0)
+	for {
+		resp, err := client.DescribeListenersWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("list aws listener failed, err: %v, lb: %s, rid: %s", err, opt.CloudLoadBalancerID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Listeners {
+			listeners = append(listeners, typelb.AwsListener{Listener: one})
+		}
+
+		if resp.NextMarker == nil || len(*resp.NextMarker) == 0 {
+			break
+		}
+		req.Marker = resp.NextMarker
+	}
+
+	return listeners, nil
+}
+
+// ListTargetGroup 查询目标组。
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeTargetGroups.html
+func (a *AwsImpl) ListTargetGroup(kt *kit.Kit, opt *typelb.AwsListOption) (*typelb.AwsTargetGroupListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, err: %v", err)
+	}
+
+	req := new(elbv2.DescribeTargetGroupsInput)
+	if len(opt.CloudIDs) != 0 {
+		req.TargetGroupArns = converter.SliceToPtr(opt.CloudIDs)
+	}
+
+	if opt.Page != nil {
+		req.PageSize = opt.Page.MaxResults
+		req.Marker = opt.Page.NextToken
+	}
+
+	resp, err := client.DescribeTargetGroupsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws target group failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]typelb.AwsTargetGroup, 0, len(resp.TargetGroups))
+	for _, one := range resp.TargetGroups {
+		details = append(details, typelb.AwsTargetGroup{TargetGroup: one})
+	}
+
+	return &typelb.AwsTargetGroupListResult{NextToken: resp.NextMarker, Details: details}, nil
+}
+
+// ListTarget 查询目标组下注册的全部目标及其健康状态。
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeTargetHealth.html
+func (a *AwsImpl) ListTarget(kt *kit.Kit, opt *typelb.AwsTargetListOption) ([]typelb.AwsTarget, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new elb client failed, err: %v", err)
+	}
+
+	req := &elbv2.DescribeTargetHealthInput{TargetGroupArn: converter.ValToPtr(opt.CloudTargetGroupID)}
+	resp, err := client.DescribeTargetHealthWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws target failed, err: %v, tg: %s, rid: %s", err, opt.CloudTargetGroupID, kt.Rid)
+		return nil, err
+	}
+
+	targets := make([]typelb.AwsTarget, 0, len(resp.TargetHealthDescriptions))
+	for _, one := range resp.TargetHealthDescriptions {
+		targets = append(targets, typelb.AwsTarget{CloudTargetGroupID: opt.CloudTargetGroupID,
+			TargetHealthDescription: one})
+	}
+
+	return targets, nil
+}
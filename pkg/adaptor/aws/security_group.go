@@ -38,7 +38,7 @@ import (
 
 // CreateSecurityGroup create security group.
 // reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_CreateSecurityGroup.html
-func (a *Aws) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsCreateOption) (string, error) {
+func (a *AwsImpl) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsCreateOption) (string, error) {
 
 	if opt == nil {
 		return "", errf.New(errf.InvalidParameter, "security group create option is required")
@@ -89,7 +89,7 @@ func (a *Aws) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsCreateOptio
 
 // ListSecurityGroup list security group.
 // reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DescribeSecurityGroups.html
-func (a *Aws) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsListOption) ([]securitygroup.AwsSG,
+func (a *AwsImpl) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsListOption) ([]securitygroup.AwsSG,
 	*ec2.DescribeSecurityGroupsOutput, error) {
 
 	if opt == nil {
@@ -134,7 +134,7 @@ func (a *Aws) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsListOption) (
 
 // CountSecurityGroup 返回给定地域的所有安全组数量，DescribeSecurityGroups 接口遍历
 // reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DescribeSecurityGroups.html
-func (a *Aws) CountSecurityGroup(kt *kit.Kit, region string) (int32, error) {
+func (a *AwsImpl) CountSecurityGroup(kt *kit.Kit, region string) (int32, error) {
 
 	client, err := a.clientSet.ec2Client(region)
 	if err != nil {
@@ -163,7 +163,7 @@ func (a *Aws) CountSecurityGroup(kt *kit.Kit, region string) (int32, error) {
 
 // DeleteSecurityGroup delete security group.
 // reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DeleteSecurityGroup.html
-func (a *Aws) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsDeleteOption) error {
+func (a *AwsImpl) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsDeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "security group delete option is required")
 	}
@@ -190,7 +190,7 @@ func (a *Aws) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.AwsDeleteOptio
 
 // SecurityGroupCvmAssociate reference:
 // https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_ModifyInstanceAttribute.html
-func (a *Aws) SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.AwsAssociateCvmOption) error {
+func (a *AwsImpl) SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.AwsAssociateCvmOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "associate option is required")
 	}
@@ -241,7 +241,7 @@ func (a *Aws) SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.AwsAssoc
 
 // SecurityGroupCvmDisassociate reference:
 // https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_ModifyInstanceAttribute.html
-func (a *Aws) SecurityGroupCvmDisassociate(kt *kit.Kit, opt *securitygroup.AwsAssociateCvmOption) error {
+func (a *AwsImpl) SecurityGroupCvmDisassociate(kt *kit.Kit, opt *securitygroup.AwsAssociateCvmOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "disassociate option is required")
 	}
@@ -30,7 +30,7 @@ import (
 )
 
 // CreateSecurityGroupRule create security group rule.
-func (a *Aws) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateOption) (
+func (a *AwsImpl) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateOption) (
 	[]*ec2.SecurityGroupRule, error) {
 
 	if opt == nil {
@@ -54,7 +54,7 @@ func (a *Aws) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsCre
 
 // createEgressSGRule create egress security group rule.
 // reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_AuthorizeSecurityGroupEgress.html
-func (a *Aws) createEgressSGRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateOption) (
+func (a *AwsImpl) createEgressSGRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateOption) (
 	[]*ec2.SecurityGroupRule, error) {
 
 	client, err := a.clientSet.ec2Client(opt.Region)
@@ -116,7 +116,7 @@ func (a *Aws) createEgressSGRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateOp
 
 // createIngressSGRule create ingress security group rule.
 // reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_AuthorizeSecurityGroupIngress.html
-func (a *Aws) createIngressSGRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateOption) (
+func (a *AwsImpl) createIngressSGRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateOption) (
 	[]*ec2.SecurityGroupRule, error) {
 
 	client, err := a.clientSet.ec2Client(opt.Region)
@@ -179,7 +179,7 @@ func (a *Aws) createIngressSGRule(kt *kit.Kit, opt *securitygrouprule.AwsCreateO
 // DeleteSecurityGroupRule delete security group rule.
 // Egress: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_RevokeSecurityGroupEgress.html
 // Ingress: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_RevokeSecurityGroupIngress.html
-func (a *Aws) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsDeleteOption) error {
+func (a *AwsImpl) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsDeleteOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "security group rule delete option is required")
@@ -229,7 +229,7 @@ func (a *Aws) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsDel
 
 // ListSecurityGroupRule list security group rule.
 // reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_DescribeSecurityGroupRules.html
-func (a *Aws) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsListOption) (
+func (a *AwsImpl) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsListOption) (
 	[]securitygrouprule.AwsSGRule, error) {
 
 	if opt == nil {
@@ -275,7 +275,7 @@ func (a *Aws) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsListO
 
 // UpdateSecurityGroupRule update security group rule.
 // reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_ModifySecurityGroupRules.html
-func (a *Aws) UpdateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsUpdateOption) error {
+func (a *AwsImpl) UpdateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.AwsUpdateOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "security group rule update option is required")
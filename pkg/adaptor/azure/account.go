@@ -35,7 +35,7 @@ import (
 
 // CountAccount count account.
 // reference: https://learn.microsoft.com/en-us/graph/api/user-list?view=graph-rest-1.0&tabs=http
-func (az *Azure) CountAccount(kt *kit.Kit) (int32, error) {
+func (az *AzureImpl) CountAccount(kt *kit.Kit) (int32, error) {
 
 	graphClient, err := az.clientSet.graphServiceClient()
 	if err != nil {
@@ -55,7 +55,7 @@ func (az *Azure) CountAccount(kt *kit.Kit) (int32, error) {
 // ListAccount list account.
 // reference: https://learn.microsoft.com/en-us/graph/api/user-list?view=graph-rest-1.0&tabs=http
 // 接口需要特殊权限，文档：https://learn.microsoft.com/en-us/graph/auth-v2-service?tabs=http
-func (az *Azure) ListAccount(kt *kit.Kit) ([]account.AzureAccount, error) {
+func (az *AzureImpl) ListAccount(kt *kit.Kit) ([]account.AzureAccount, error) {
 
 	graphClient, err := az.clientSet.graphServiceClient()
 	if err != nil {
@@ -87,7 +87,7 @@ func (az *Azure) ListAccount(kt *kit.Kit) ([]account.AzureAccount, error) {
 // GetAccountInfoBySecret 根据秘钥获取账号信息
 // 1. https://learn.microsoft.com/en-us/rest/api/resources/subscriptions/list
 // 2. https://learn.microsoft.com/en-us/graph/api/application-list
-func (az *Azure) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.AzureInfoBySecret, error) {
+func (az *AzureImpl) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.AzureInfoBySecret, error) {
 	graphClient, err := az.clientSet.graphServiceClient()
 	if err != nil {
 		return nil, err
@@ -21,11 +21,15 @@ package azure
 
 import (
 	"fmt"
+	"net/http"
 
+	"hcm/pkg/adaptor/cassette"
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
@@ -37,13 +41,34 @@ import (
 
 type clientSet struct {
 	credential *types.AzureCredential
+	// httpClient 非空时 sdk 使用该 http client 发送请求, 用于录制/回放云厂商 API 调用.
+	httpClient *http.Client
 }
 
 func newClientSet(credential *types.AzureCredential) *clientSet {
-	return &clientSet{credential}
+	return &clientSet{credential: credential, httpClient: cassette.HTTPClient()}
+}
+
+// clientOptions 返回 sdk client 的通用配置, 未设置 http client 时使用 sdk 默认配置.
+func (c *clientSet) clientOptions() azcore.ClientOptions {
+	if c.httpClient == nil {
+		return azcore.ClientOptions{}
+	}
+
+	return azcore.ClientOptions{Transport: c.httpClient}
+}
+
+// armOptions 返回 arm client 配置, 未设置 http client 时返回 nil, 使用 sdk 默认配置.
+func (c *clientSet) armOptions() *arm.ClientOptions {
+	if c.httpClient == nil {
+		return nil
+	}
+
+	return &arm.ClientOptions{ClientOptions: c.clientOptions()}
 }
 
 // graphServiceClient ...
+// Note: graph sdk 使用自带中间件的 http client, 其 API 请求不经过 clientSet 的 http client, 暂不支持录制/回放.
 func (c *clientSet) graphServiceClient() (*msgraphsdk.GraphServiceClient, error) {
 	credential, err := c.newClientSecretCredential()
 	if err != nil {
@@ -65,7 +90,7 @@ func (c *clientSet) subscriptionClient() (*armsubscription.SubscriptionsClient,
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armsubscription.NewSubscriptionsClient(credential, nil)
+	client, err := armsubscription.NewSubscriptionsClient(credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure subscription client failed, err: %v", err)
 	}
@@ -83,7 +108,7 @@ func (c *clientSet) vpcClient() (*armnetwork.VirtualNetworksClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewVirtualNetworksClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewVirtualNetworksClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -100,7 +125,7 @@ func (c *clientSet) usageClient() (*armnetwork.UsagesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewUsagesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewUsagesClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure usage client failed, err: %v", err)
 	}
@@ -117,7 +142,7 @@ func (c *clientSet) subnetClient() (*armnetwork.SubnetsClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewSubnetsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewSubnetsClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -130,7 +155,16 @@ func (c *clientSet) diskClient() (*armcompute.DisksClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
-	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, nil)
+	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
+}
+
+// snapshotClient ...
+func (c *clientSet) snapshotClient() (*armcompute.SnapshotsClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+	return armcompute.NewSnapshotsClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 }
 
 // imageClient ...
@@ -140,7 +174,7 @@ func (c *clientSet) imageClient() (*armcompute.VirtualMachineImagesClient, error
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	return armcompute.NewVirtualMachineImagesClient(c.credential.CloudSubscriptionID, credential, nil)
+	return armcompute.NewVirtualMachineImagesClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 }
 
 // newClientSecretCredential ...
@@ -148,7 +182,7 @@ func (c *clientSet) newClientSecretCredential() (*azidentity.ClientSecretCredent
 	return azidentity.NewClientSecretCredential(
 		c.credential.CloudTenantID,
 		c.credential.CloudApplicationID,
-		c.credential.CloudClientSecretKey, nil)
+		c.credential.CloudClientSecretKey, &azidentity.ClientSecretCredentialOptions{ClientOptions: c.clientOptions()})
 }
 
 // securityGroupClient ...
@@ -158,7 +192,7 @@ func (c *clientSet) securityGroupClient() (*armnetwork.SecurityGroupsClient, err
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewSecurityGroupsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewSecurityGroupsClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure security group client failed, err: %v", err)
 	}
@@ -173,7 +207,7 @@ func (c *clientSet) virtualMachineClient() (*armcompute.VirtualMachinesClient, e
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewVirtualMachinesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewVirtualMachinesClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure virtual machines client failed, err: %v", err)
 	}
@@ -188,7 +222,7 @@ func (c *clientSet) virtualMachineSizeClient() (*armcompute.VirtualMachineSizesC
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewVirtualMachineSizesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewVirtualMachineSizesClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure virtual machine sizes client failed, err: %v", err)
 	}
@@ -203,7 +237,7 @@ func (c *clientSet) clientFactory() (*armcompute.ClientFactory, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewClientFactory(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewClientFactory(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure client factory failed, err: %v", err)
 	}
@@ -218,7 +252,7 @@ func (c *clientSet) resourceGroupsClient() (*armresources.ResourceGroupsClient,
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armresources.NewResourceGroupsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armresources.NewResourceGroupsClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init resourceGroups client failed, err: %v", err)
 	}
@@ -233,7 +267,7 @@ func (c *clientSet) regionClient() (*armsubscriptions.Client, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armsubscriptions.NewClient(credential, nil)
+	client, err := armsubscriptions.NewClient(credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init region client failed, err: %v", err)
 	}
@@ -251,7 +285,7 @@ func (c *clientSet) routeTableClient() (*armnetwork.RouteTablesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewRouteTablesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewRouteTablesClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -268,7 +302,7 @@ func (c *clientSet) routeClient() (*armnetwork.RoutesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewRoutesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewRoutesClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -281,7 +315,7 @@ func (c *clientSet) publicIPAddressesClient() (*armnetwork.PublicIPAddressesClie
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
-	client, err := armnetwork.NewPublicIPAddressesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewPublicIPAddressesClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init azure public ip addresses client failed, err: %v", err)
 	}
@@ -295,7 +329,7 @@ func (c *clientSet) networkInterfaceClient() (*armnetwork.InterfacesClient, erro
 		return nil, fmt.Errorf("init network interface credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewInterfacesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewInterfacesClient(c.credential.CloudSubscriptionID, credential, c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init network interface client failed, err: %v", err)
 	}
@@ -311,7 +345,7 @@ func (c *clientSet) networkInterfaceIPConfigClient() (*armnetwork.InterfaceIPCon
 	}
 
 	client, err := armnetwork.NewInterfaceIPConfigurationsClient(c.credential.CloudSubscriptionID, credential,
-		nil)
+		c.armOptions())
 	if err != nil {
 		return nil, fmt.Errorf("init network interface ipconfig client failed, err: %v", err)
 	}
@@ -0,0 +1,181 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"time"
+
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+)
+
+// ListSnapshot 查询资源组下的云盘快照，指定CloudIDs时只返回匹配的快照
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/list-by-resource-group?tabs=Go
+func (az *AzureImpl) ListSnapshot(kt *kit.Kit, opt *typesnapshot.AzureListOption) ([]typesnapshot.AzureSnapshot,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "azure snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.snapshotClient()
+	if err != nil {
+		return nil, err
+	}
+
+	idMap := make(map[string]struct{}, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		idMap[StrToLowerNoSpaceStr(id)] = struct{}{}
+	}
+
+	snapshots := make([]typesnapshot.AzureSnapshot, 0)
+	pager := client.NewListByResourceGroupPager(opt.ResourceGroupName, nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure snapshot next page failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("failed to advance page: %v", err)
+		}
+
+		for _, one := range nextResult.Value {
+			if len(idMap) > 0 {
+				if _, exist := idMap[SPtrToLowerStr(one.ID)]; !exist {
+					continue
+				}
+			}
+			snapshots = append(snapshots, converterSnapshot(one))
+		}
+	}
+
+	return snapshots, nil
+}
+
+func converterSnapshot(one *armcompute.Snapshot) typesnapshot.AzureSnapshot {
+	snapshot := typesnapshot.AzureSnapshot{
+		ID:       SPtrToLowerSPtr(one.ID),
+		Name:     SPtrToLowerSPtr(one.Name),
+		Location: SPtrToLowerNoSpaceSPtr(one.Location),
+	}
+
+	if one.SKU != nil && one.SKU.Name != nil {
+		snapshot.SKUName = converter.ValToPtr(string(*one.SKU.Name))
+	}
+
+	if one.Properties == nil {
+		return snapshot
+	}
+
+	prop := one.Properties
+	snapshot.DiskSizeGB = prop.DiskSizeGB
+	snapshot.ProvisioningState = prop.ProvisioningState
+	snapshot.Incremental = prop.Incremental
+	if prop.CreationData != nil {
+		snapshot.SourceResourceID = SPtrToLowerSPtr(prop.CreationData.SourceResourceID)
+	}
+	if prop.TimeCreated != nil {
+		snapshot.TimeCreated = converter.ValToPtr(prop.TimeCreated.Format(time.RFC3339))
+	}
+	if prop.OSType != nil {
+		snapshot.OSType = converter.ValToPtr(string(*prop.OSType))
+	}
+
+	return snapshot
+}
+
+// CreateSnapshot 从云盘创建快照，返回快照的资源ID
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/create-or-update?tabs=Go
+func (az *AzureImpl) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.AzureCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "azure snapshot create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.snapshotClient()
+	if err != nil {
+		return "", err
+	}
+
+	req := armcompute.Snapshot{
+		Location: converter.ValToPtr(opt.Region),
+		Properties: &armcompute.SnapshotProperties{
+			CreationData: &armcompute.CreationData{
+				CreateOption:     converter.ValToPtr(armcompute.DiskCreateOptionCopy),
+				SourceResourceID: converter.ValToPtr(opt.CloudDiskID),
+			},
+			Incremental: opt.Incremental,
+		},
+	}
+	poller, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.Name, req, nil)
+	if err != nil {
+		logs.Errorf("create azure snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return "", errorf(err)
+	}
+
+	resp, err := poller.PollUntilDone(kt.Ctx, nil)
+	if err != nil {
+		logs.Errorf("poll azure snapshot create result failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	return SPtrToLowerStr(resp.ID), nil
+}
+
+// DeleteSnapshot 删除云盘快照
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/delete?tabs=Go
+func (az *AzureImpl) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.AzureDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "azure snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.snapshotClient()
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginDelete(kt.Ctx, opt.ResourceGroupName, opt.Name, nil)
+	if err != nil {
+		logs.Errorf("delete azure snapshot failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return errorf(err)
+	}
+
+	if _, err = poller.PollUntilDone(kt.Ctx, nil); err != nil {
+		logs.Errorf("poll azure snapshot delete result failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
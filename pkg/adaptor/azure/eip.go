@@ -36,7 +36,7 @@ import (
 
 // ListEipByID ...
 // reference: https://learn.microsoft.com/zh-cn/rest/api/virtualnetwork/public-ip-addresses/list-all?tabs=HTTP
-func (az *Azure) ListEipByID(kt *kit.Kit, opt *core.AzureListByIDOption) (*eip.AzureEipListResult, error) {
+func (az *AzureImpl) ListEipByID(kt *kit.Kit, opt *core.AzureListByIDOption) (*eip.AzureEipListResult, error) {
 	client, err := az.clientSet.publicIPAddressesClient()
 	if err != nil {
 		return nil, err
@@ -76,7 +76,7 @@ func (az *Azure) ListEipByID(kt *kit.Kit, opt *core.AzureListByIDOption) (*eip.A
 
 // CountEip count eip.
 // reference: https://learn.microsoft.com/zh-cn/rest/api/virtualnetwork/public-ip-addresses/list-all?tabs=HTTP
-func (az *Azure) CountEip(kt *kit.Kit) (int32, error) {
+func (az *AzureImpl) CountEip(kt *kit.Kit) (int32, error) {
 
 	client, err := az.clientSet.publicIPAddressesClient()
 	if err != nil {
@@ -100,7 +100,7 @@ func (az *Azure) CountEip(kt *kit.Kit) (int32, error) {
 
 // ListEipByPage ...
 // reference: https://learn.microsoft.com/zh-cn/rest/api/virtualnetwork/public-ip-addresses/list-all?tabs=HTTP
-func (az *Azure) ListEipByPage(kt *kit.Kit, opt *core.AzureListOption) (
+func (az *AzureImpl) ListEipByPage(kt *kit.Kit, opt *core.AzureListOption) (
 	*Pager[armnetwork.PublicIPAddressesClientListResponse, eip.AzureEip], error) {
 
 	client, err := az.clientSet.publicIPAddressesClient()
@@ -170,7 +170,7 @@ func (handler *eipResultHandler) BuildResult(resp armnetwork.PublicIPAddressesCl
 
 // DeleteEip ...
 // reference: https://learn.microsoft.com/zh-cn/rest/api/virtualnetwork/public-ip-addresses/delete?tabs=HTTP
-func (az *Azure) DeleteEip(kt *kit.Kit, opt *eip.AzureEipDeleteOption) error {
+func (az *AzureImpl) DeleteEip(kt *kit.Kit, opt *eip.AzureEipDeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "azure eip delete option is required")
 	}
@@ -194,7 +194,7 @@ func (az *Azure) DeleteEip(kt *kit.Kit, opt *eip.AzureEipDeleteOption) error {
 
 // AssociateEip ...
 // reference: https://learn.microsoft.com/zh-cn/rest/api/virtualnetwork/network-interfaces/create-or-update?tabs=Go
-func (az *Azure) AssociateEip(kt *kit.Kit, opt *eip.AzureEipAssociateOption) error {
+func (az *AzureImpl) AssociateEip(kt *kit.Kit, opt *eip.AzureEipAssociateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "azure eip associate option is required")
 	}
@@ -227,7 +227,7 @@ func (az *Azure) AssociateEip(kt *kit.Kit, opt *eip.AzureEipAssociateOption) err
 
 // DisassociateEip ...
 // reference: https://learn.microsoft.com/zh-cn/rest/api/virtualnetwork/network-interfaces/create-or-update?tabs=Go
-func (az *Azure) DisassociateEip(kt *kit.Kit, opt *eip.AzureEipDisassociateOption) error {
+func (az *AzureImpl) DisassociateEip(kt *kit.Kit, opt *eip.AzureEipDisassociateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "azure eip associate option is required")
 	}
@@ -263,7 +263,7 @@ func (az *Azure) DisassociateEip(kt *kit.Kit, opt *eip.AzureEipDisassociateOptio
 
 // CreateEip ...
 // reference: https://learn.microsoft.com/zh-cn/rest/api/virtualnetwork/public-ip-addresses/create-or-update?tabs=HTTP
-func (az *Azure) CreateEip(kt *kit.Kit, opt *eip.AzureEipCreateOption) (*string, error) {
+func (az *AzureImpl) CreateEip(kt *kit.Kit, opt *eip.AzureEipCreateOption) (*string, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "azure eip create option is required")
 	}
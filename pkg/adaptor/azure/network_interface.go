@@ -39,7 +39,7 @@ import (
 
 // CountNI count ni.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-interfaces/list-all
-func (az *Azure) CountNI(kt *kit.Kit) (int32, error) {
+func (az *AzureImpl) CountNI(kt *kit.Kit) (int32, error) {
 
 	client, err := az.clientSet.networkInterfaceClient()
 	if err != nil {
@@ -63,7 +63,7 @@ func (az *Azure) CountNI(kt *kit.Kit) (int32, error) {
 
 // ListNetworkInterface list all network interface.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-interfaces/list-all
-func (az *Azure) ListNetworkInterface(kt *kit.Kit) (*typesniproto.AzureInterfaceListResult, error) {
+func (az *AzureImpl) ListNetworkInterface(kt *kit.Kit) (*typesniproto.AzureInterfaceListResult, error) {
 	client, err := az.clientSet.networkInterfaceClient()
 	if err != nil {
 		return nil, fmt.Errorf("new network interface client failed, err: %v", err)
@@ -87,7 +87,7 @@ func (az *Azure) ListNetworkInterface(kt *kit.Kit) (*typesniproto.AzureInterface
 
 // ListNetworkInterfaceByPage list all network interface.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-interfaces/list-all
-func (az *Azure) ListNetworkInterfaceByPage(kt *kit.Kit) (
+func (az *AzureImpl) ListNetworkInterfaceByPage(kt *kit.Kit) (
 	*Pager[armnetwork.InterfacesClientListAllResponse, typesniproto.AzureNI], error) {
 
 	client, err := az.clientSet.networkInterfaceClient()
@@ -110,7 +110,7 @@ func (az *Azure) ListNetworkInterfaceByPage(kt *kit.Kit) (
 
 type niResultHandler struct {
 	kt  *kit.Kit
-	cli *Azure
+	cli *AzureImpl
 }
 
 // BuildResult ...
@@ -126,7 +126,7 @@ func (handler *niResultHandler) BuildResult(resp armnetwork.InterfacesClientList
 
 // ListNetworkInterfaceByID list all network interface by id.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-interfaces/list-all
-func (az *Azure) ListNetworkInterfaceByID(kt *kit.Kit, opt *core.AzureListByIDOption) (
+func (az *AzureImpl) ListNetworkInterfaceByID(kt *kit.Kit, opt *core.AzureListByIDOption) (
 	*typesniproto.AzureInterfaceListResult, error,
 ) {
 	if opt == nil {
@@ -169,7 +169,7 @@ func (az *Azure) ListNetworkInterfaceByID(kt *kit.Kit, opt *core.AzureListByIDOp
 }
 
 // ListRawNetworkInterfaceByIDs ...
-func (az *Azure) ListRawNetworkInterfaceByIDs(kt *kit.Kit, opt *core.AzureListByIDOption) (
+func (az *AzureImpl) ListRawNetworkInterfaceByIDs(kt *kit.Kit, opt *core.AzureListByIDOption) (
 	[]*armnetwork.Interface, error,
 ) {
 	if opt == nil {
@@ -207,7 +207,7 @@ func (az *Azure) ListRawNetworkInterfaceByIDs(kt *kit.Kit, opt *core.AzureListBy
 }
 
 // ConvertCloudNetworkInterface ...
-func (az *Azure) ConvertCloudNetworkInterface(kt *kit.Kit, data *armnetwork.Interface) *typesniproto.AzureNI {
+func (az *AzureImpl) ConvertCloudNetworkInterface(kt *kit.Kit, data *armnetwork.Interface) *typesniproto.AzureNI {
 	if data == nil {
 		return nil
 	}
@@ -249,7 +249,7 @@ func (az *Azure) ConvertCloudNetworkInterface(kt *kit.Kit, data *armnetwork.Inte
 	return v
 }
 
-func (az *Azure) getExtensionData(kt *kit.Kit, data *armnetwork.Interface, v *typesniproto.AzureNI) {
+func (az *AzureImpl) getExtensionData(kt *kit.Kit, data *armnetwork.Interface, v *typesniproto.AzureNI) {
 	if data.Properties.NetworkSecurityGroup != nil {
 		v.Extension.CloudSecurityGroupID = SPtrToLowerSPtr(data.Properties.NetworkSecurityGroup.ID)
 	}
@@ -264,7 +264,7 @@ func (az *Azure) getExtensionData(kt *kit.Kit, data *armnetwork.Interface, v *ty
 }
 
 // getIpConfigExtensionData get ipconfig extension data
-func (az *Azure) getIpConfigExtensionData(kt *kit.Kit, data *armnetwork.Interface, v *typesniproto.AzureNI) {
+func (az *AzureImpl) getIpConfigExtensionData(kt *kit.Kit, data *armnetwork.Interface, v *typesniproto.AzureNI) {
 	if data == nil || data.Properties == nil || data.Properties.IPConfigurations == nil {
 		return
 	}
@@ -350,7 +350,7 @@ func getIpConfigSubnetData(
 
 // GetNetworkInterface get one network interface.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-interfaces/get
-func (az *Azure) GetNetworkInterface(kt *kit.Kit, opt *core.AzureListOption) (*typesniproto.AzureNI, error) {
+func (az *AzureImpl) GetNetworkInterface(kt *kit.Kit, opt *core.AzureListOption) (*typesniproto.AzureNI, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -384,7 +384,7 @@ func (az *Azure) GetNetworkInterface(kt *kit.Kit, opt *core.AzureListOption) (*t
 // ListNetworkSecurityGroup list network security group.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-interfaces/
 // list-effective-network-security-groups
-func (az *Azure) ListNetworkSecurityGroup(kt *kit.Kit, opt *core.AzureListOption) (interface{}, error) {
+func (az *AzureImpl) ListNetworkSecurityGroup(kt *kit.Kit, opt *core.AzureListOption) (interface{}, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -415,7 +415,7 @@ func (az *Azure) ListNetworkSecurityGroup(kt *kit.Kit, opt *core.AzureListOption
 
 // ListIP list all network interface's ip.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-interface-ip-configurations/list
-func (az *Azure) ListIP(kt *kit.Kit, opt *core.AzureListOption) ([]*coreni.InterfaceIPConfiguration, error) {
+func (az *AzureImpl) ListIP(kt *kit.Kit, opt *core.AzureListOption) ([]*coreni.InterfaceIPConfiguration, error) {
 	client, err := az.clientSet.networkInterfaceIPConfigClient()
 	if err != nil {
 		return nil, fmt.Errorf("new network interface ipconfig client failed, err: %v", err)
@@ -472,7 +472,7 @@ func convertCloudNetworkInterfaceIPConfig(data *armnetwork.InterfaceIPConfigurat
 
 // ListNetworkInterfacePage list network interface page.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-interfaces/list-all
-func (az *Azure) ListNetworkInterfacePage() (*runtime.Pager[armnetwork.InterfacesClientListAllResponse], error) {
+func (az *AzureImpl) ListNetworkInterfacePage() (*runtime.Pager[armnetwork.InterfacesClientListAllResponse], error) {
 
 	client, err := az.clientSet.networkInterfaceClient()
 	if err != nil {
@@ -485,7 +485,7 @@ func (az *Azure) ListNetworkInterfacePage() (*runtime.Pager[armnetwork.Interface
 
 // ListNetworkInterfaceByIDPage list network interface by id page.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-interfaces/list-all
-func (az *Azure) ListNetworkInterfaceByIDPage(opt *core.AzureListByIDOption) (
+func (az *AzureImpl) ListNetworkInterfaceByIDPage(opt *core.AzureListByIDOption) (
 	*runtime.Pager[armnetwork.InterfacesClientListResponse], error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "new network interface client list option is required")
@@ -505,7 +505,7 @@ func (az *Azure) ListNetworkInterfaceByIDPage(opt *core.AzureListByIDOption) (
 }
 
 // GetEipByCloudID get eip info by cloudid
-func (az *Azure) GetEipByCloudID(kt *kit.Kit, resourceGroupName, cloudPublicIP string) (*eip.AzureEip, error) {
+func (az *AzureImpl) GetEipByCloudID(kt *kit.Kit, resourceGroupName, cloudPublicIP string) (*eip.AzureEip, error) {
 	opt := &core.AzureListByIDOption{
 		ResourceGroupName: resourceGroupName,
 		CloudIDs:          []string{cloudPublicIP},
@@ -30,7 +30,7 @@ import (
 
 // ListResourceGroup list resource group.
 // reference: https://learn.microsoft.com/en-us/rest/api/resources/resource-groups/list#resourcegroup
-func (az *Azure) ListResourceGroup(kt *kit.Kit) ([]*resourcegroup.AzureResourceGroup, error) {
+func (az *AzureImpl) ListResourceGroup(kt *kit.Kit) ([]*resourcegroup.AzureResourceGroup, error) {
 
 	client, err := az.clientSet.resourceGroupsClient()
 	if err != nil {
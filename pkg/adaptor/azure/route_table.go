@@ -35,13 +35,13 @@ import (
 
 // UpdateRouteTable update route table.
 // TODO right now only memo is supported to update, add other update operations later.
-func (az *Azure) UpdateRouteTable(_ *kit.Kit, _ *routetable.AzureRouteTableUpdateOption) error {
+func (az *AzureImpl) UpdateRouteTable(_ *kit.Kit, _ *routetable.AzureRouteTableUpdateOption) error {
 	return nil
 }
 
 // DeleteRouteTable delete route table.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/route-tables/delete?tabs=HTTP
-func (az *Azure) DeleteRouteTable(kt *kit.Kit, opt *core.AzureDeleteOption) error {
+func (az *AzureImpl) DeleteRouteTable(kt *kit.Kit, opt *core.AzureDeleteOption) error {
 	if err := opt.Validate(); err != nil {
 		return err
 	}
@@ -67,7 +67,7 @@ func (az *Azure) DeleteRouteTable(kt *kit.Kit, opt *core.AzureDeleteOption) erro
 
 // CountRouteTable count route table.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/route-tables/list?tabs=HTTP
-func (az *Azure) CountRouteTable(kt *kit.Kit) (int32, error) {
+func (az *AzureImpl) CountRouteTable(kt *kit.Kit) (int32, error) {
 
 	client, err := az.clientSet.routeTableClient()
 	if err != nil {
@@ -91,7 +91,8 @@ func (az *Azure) CountRouteTable(kt *kit.Kit) (int32, error) {
 
 // ListRouteTable list route table.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/route-tables/list?tabs=HTTP
-func (az *Azure) ListRouteTable(kt *kit.Kit, opt *core.AzureListOption) (*routetable.AzureRouteTableListResult, error) {
+func (az *AzureImpl) ListRouteTable(kt *kit.Kit, opt *core.AzureListOption) (
+	*routetable.AzureRouteTableListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -127,7 +128,7 @@ func (az *Azure) ListRouteTable(kt *kit.Kit, opt *core.AzureListOption) (*routet
 
 type routeTableResultHandler struct {
 	resGroupName string
-	a            *Azure
+	a            *AzureImpl
 }
 
 func (handler *routeTableResultHandler) BuildResult(resp armnetwork.RouteTablesClientListResponse) []routetable.AzureRouteTable {
@@ -142,7 +143,7 @@ func (handler *routeTableResultHandler) BuildResult(resp armnetwork.RouteTablesC
 
 // ListRouteTableByPage ...
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/route-tables/list?tabs=HTTP
-func (az *Azure) ListRouteTableByPage(kt *kit.Kit, opt *core.AzureListOption) (
+func (az *AzureImpl) ListRouteTableByPage(kt *kit.Kit, opt *core.AzureListOption) (
 	*Pager[armnetwork.RouteTablesClientListResponse, routetable.AzureRouteTable], error) {
 
 	client, err := az.clientSet.routeTableClient()
@@ -165,7 +166,7 @@ func (az *Azure) ListRouteTableByPage(kt *kit.Kit, opt *core.AzureListOption) (
 
 // ListRouteTablePage list route table page.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/route-tables/list?tabs=HTTP
-func (az *Azure) ListRouteTablePage(opt *core.AzureListByIDOption) (
+func (az *AzureImpl) ListRouteTablePage(opt *core.AzureListByIDOption) (
 	*runtime.Pager[armnetwork.RouteTablesClientListResponse], string, error) {
 
 	if err := opt.Validate(); err != nil {
@@ -185,7 +186,7 @@ func (az *Azure) ListRouteTablePage(opt *core.AzureListByIDOption) (
 
 // ListRouteTableByID list route table.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/route-tables/list?tabs=HTTP
-func (az *Azure) ListRouteTableByID(kt *kit.Kit, opt *core.AzureListByIDOption) (
+func (az *AzureImpl) ListRouteTableByID(kt *kit.Kit, opt *core.AzureListByIDOption) (
 	*routetable.AzureRouteTableListResult, error) {
 
 	if err := opt.Validate(); err != nil {
@@ -228,7 +229,7 @@ func (az *Azure) ListRouteTableByID(kt *kit.Kit, opt *core.AzureListByIDOption)
 
 // GetRouteTable get route table.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/route-tables/get?tabs=HTTP
-func (az *Azure) GetRouteTable(kt *kit.Kit, opt *routetable.AzureRouteTableGetOption) (*routetable.AzureRouteTable,
+func (az *AzureImpl) GetRouteTable(kt *kit.Kit, opt *routetable.AzureRouteTableGetOption) (*routetable.AzureRouteTable,
 	error) {
 
 	if err := opt.Validate(); err != nil {
@@ -253,7 +254,7 @@ func (az *Azure) GetRouteTable(kt *kit.Kit, opt *routetable.AzureRouteTableGetOp
 }
 
 // ConvertRouteTable ...
-func (az *Azure) ConvertRouteTable(data *armnetwork.RouteTable, resourceGroup,
+func (az *AzureImpl) ConvertRouteTable(data *armnetwork.RouteTable, resourceGroup,
 	subscription string) *routetable.AzureRouteTable {
 
 	if data == nil {
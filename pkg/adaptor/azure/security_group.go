@@ -34,7 +34,8 @@ import (
 
 // CreateSecurityGroup create security group.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/create-or-update
-func (az *Azure) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.AzureOption) (*securitygroup.AzureSecurityGroup,
+func (az *AzureImpl) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.AzureOption) (
+	*securitygroup.AzureSecurityGroup,
 	error) {
 
 	if opt == nil {
@@ -70,7 +71,7 @@ func (az *Azure) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.AzureOption
 
 // DeleteSecurityGroup delete security group.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/delete?tabs=HTTP
-func (az *Azure) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.AzureOption) error {
+func (az *AzureImpl) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.AzureOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "security group delete option is required")
@@ -102,7 +103,7 @@ func (az *Azure) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.AzureOption
 
 type sgResultHandler struct {
 	resGroupName string
-	az           *Azure
+	az           *AzureImpl
 }
 
 // BuildResult ...
@@ -118,7 +119,7 @@ func (handler *sgResultHandler) BuildResult(resp armnetwork.SecurityGroupsClient
 
 // CountSecurityGroup count security group.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/list-all
-func (az *Azure) CountSecurityGroup(kt *kit.Kit) (int32, error) {
+func (az *AzureImpl) CountSecurityGroup(kt *kit.Kit) (int32, error) {
 
 	client, err := az.clientSet.securityGroupClient()
 	if err != nil {
@@ -142,7 +143,7 @@ func (az *Azure) CountSecurityGroup(kt *kit.Kit) (int32, error) {
 
 // ListSecurityGroupByPage ...
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/list-all
-func (az *Azure) ListSecurityGroupByPage(kt *kit.Kit, opt *securitygroup.AzureListOption) (
+func (az *AzureImpl) ListSecurityGroupByPage(kt *kit.Kit, opt *securitygroup.AzureListOption) (
 	*Pager[armnetwork.SecurityGroupsClientListResponse, securitygroup.AzureSecurityGroup], error) {
 
 	client, err := az.clientSet.securityGroupClient()
@@ -164,7 +165,7 @@ func (az *Azure) ListSecurityGroupByPage(kt *kit.Kit, opt *securitygroup.AzureLi
 
 // ListSecurityGroup list security group.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/list-all
-func (az *Azure) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.AzureListOption) (
+func (az *AzureImpl) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.AzureListOption) (
 	[]*securitygroup.AzureSecurityGroup, error) {
 
 	if opt == nil {
@@ -200,7 +201,7 @@ func (az *Azure) ListSecurityGroup(kt *kit.Kit, opt *securitygroup.AzureListOpti
 
 // ListSecurityGroupByID list security group.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/network-security-groups/list-all
-func (az *Azure) ListSecurityGroupByID(kt *kit.Kit, opt *core.AzureListByIDOption) (
+func (az *AzureImpl) ListSecurityGroupByID(kt *kit.Kit, opt *core.AzureListByIDOption) (
 	[]*securitygroup.AzureSecurityGroup, error) {
 
 	if opt == nil {
@@ -254,7 +255,7 @@ func (az *Azure) ListSecurityGroupByID(kt *kit.Kit, opt *core.AzureListByIDOptio
 	return typesSecurityGroups, nil
 }
 
-func (az *Azure) converCloudToSecurityGroup(cloud *armnetwork.SecurityGroup) *securitygroup.AzureSecurityGroup {
+func (az *AzureImpl) converCloudToSecurityGroup(cloud *armnetwork.SecurityGroup) *securitygroup.AzureSecurityGroup {
 	respSecurityGroup := &securitygroup.AzureSecurityGroup{
 		ID:              SPtrToLowerSPtr(cloud.ID),
 		Location:        SPtrToLowerNoSpaceSPtr(cloud.Location),
@@ -271,7 +272,8 @@ func (az *Azure) converCloudToSecurityGroup(cloud *armnetwork.SecurityGroup) *se
 	return respSecurityGroup
 }
 
-func (az *Azure) getSecurityGroupByCloudID(kt *kit.Kit, resGroupName, cloudID string) (*securitygroup.AzureSecurityGroup,
+func (az *AzureImpl) getSecurityGroupByCloudID(kt *kit.Kit, resGroupName, cloudID string) (
+	*securitygroup.AzureSecurityGroup,
 	error) {
 
 	client, err := az.clientSet.securityGroupClient()
@@ -305,7 +307,7 @@ func (az *Azure) getSecurityGroupByCloudID(kt *kit.Kit, resGroupName, cloudID st
 
 // SecurityGroupSubnetAssociate associate subnet.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/subnets/create-or-update?tabs=HTTP
-func (az *Azure) SecurityGroupSubnetAssociate(kt *kit.Kit, opt *securitygroup.AzureAssociateSubnetOption) error {
+func (az *AzureImpl) SecurityGroupSubnetAssociate(kt *kit.Kit, opt *securitygroup.AzureAssociateSubnetOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "associate option is required")
@@ -365,7 +367,7 @@ func (az *Azure) SecurityGroupSubnetAssociate(kt *kit.Kit, opt *securitygroup.Az
 
 // SecurityGroupSubnetDisassociate disassociate subnet.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/subnets/create-or-update?tabs=HTTP
-func (az *Azure) SecurityGroupSubnetDisassociate(kt *kit.Kit, opt *securitygroup.AzureAssociateSubnetOption) error {
+func (az *AzureImpl) SecurityGroupSubnetDisassociate(kt *kit.Kit, opt *securitygroup.AzureAssociateSubnetOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "disassociate option is required")
@@ -428,7 +430,7 @@ func (az *Azure) SecurityGroupSubnetDisassociate(kt *kit.Kit, opt *securitygroup
 
 // SecurityGroupNetworkInterfaceAssociate associate network interface.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/subnets/create-or-update?tabs=Go
-func (az *Azure) SecurityGroupNetworkInterfaceAssociate(kt *kit.Kit,
+func (az *AzureImpl) SecurityGroupNetworkInterfaceAssociate(kt *kit.Kit,
 	opt *securitygroup.AzureAssociateNetworkInterfaceOption) error {
 
 	if opt == nil {
@@ -495,7 +497,7 @@ func (az *Azure) SecurityGroupNetworkInterfaceAssociate(kt *kit.Kit,
 
 // SecurityGroupNetworkInterfaceDisassociate disassociate network interface.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/subnets/create-or-update?tabs=Go
-func (az *Azure) SecurityGroupNetworkInterfaceDisassociate(kt *kit.Kit,
+func (az *AzureImpl) SecurityGroupNetworkInterfaceDisassociate(kt *kit.Kit,
 	opt *securitygroup.AzureAssociateNetworkInterfaceOption) error {
 
 	if opt == nil {
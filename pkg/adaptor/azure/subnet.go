@@ -37,7 +37,8 @@ import (
 
 // CreateSubnet create subnet.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/subnets/create-or-update?tabs=HTTP
-func (az *Azure) CreateSubnet(kt *kit.Kit, opt *adtysubnet.AzureSubnetCreateOption) (*adtysubnet.AzureSubnet, error) {
+func (az *AzureImpl) CreateSubnet(kt *kit.Kit, opt *adtysubnet.AzureSubnetCreateOption) (
+	*adtysubnet.AzureSubnet, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -111,13 +112,13 @@ func convertSubnetCreateReq(opt *adtysubnet.AzureSubnetCreateOption) *armnetwork
 
 // UpdateSubnet update subnet.
 // TODO right now only memo is supported to update, add other update operations later.
-func (az *Azure) UpdateSubnet(_ *kit.Kit, _ *adtysubnet.AzureSubnetUpdateOption) error {
+func (az *AzureImpl) UpdateSubnet(_ *kit.Kit, _ *adtysubnet.AzureSubnetUpdateOption) error {
 	return nil
 }
 
 // DeleteSubnet delete subnet.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/subnets/delete?tabs=HTTP
-func (az *Azure) DeleteSubnet(kt *kit.Kit, opt *adtysubnet.AzureSubnetDeleteOption) error {
+func (az *AzureImpl) DeleteSubnet(kt *kit.Kit, opt *adtysubnet.AzureSubnetDeleteOption) error {
 	if err := opt.Validate(); err != nil {
 		return err
 	}
@@ -144,7 +145,7 @@ func (az *Azure) DeleteSubnet(kt *kit.Kit, opt *adtysubnet.AzureSubnetDeleteOpti
 
 // ListSubnet list subnet.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/subnets/list?tabs=HTTP
-func (az *Azure) ListSubnet(kt *kit.Kit, opt *adtysubnet.AzureSubnetListOption) (*adtysubnet.AzureSubnetListResult,
+func (az *AzureImpl) ListSubnet(kt *kit.Kit, opt *adtysubnet.AzureSubnetListOption) (*adtysubnet.AzureSubnetListResult,
 	error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
@@ -181,7 +182,7 @@ func (az *Azure) ListSubnet(kt *kit.Kit, opt *adtysubnet.AzureSubnetListOption)
 
 // ListSubnetByPage list subnet by page.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/subnets/list?tabs=HTTP
-func (az *Azure) ListSubnetByPage(kt *kit.Kit, opt *adtysubnet.AzureSubnetListOption) (
+func (az *AzureImpl) ListSubnetByPage(kt *kit.Kit, opt *adtysubnet.AzureSubnetListOption) (
 	*Pager[armnetwork.SubnetsClientListResponse, adtysubnet.AzureSubnet], error) {
 
 	if err := opt.Validate(); err != nil {
@@ -225,7 +226,7 @@ func (handler *subnetResultHandler) BuildResult(resp armnetwork.SubnetsClientLis
 
 // ListSubnetByID list subnet.
 // reference: https://learn.microsoft.com/en-us/rest/api/virtualnetwork/subnets/list?tabs=HTTP
-func (az *Azure) ListSubnetByID(kt *kit.Kit, opt *adtysubnet.AzureSubnetListByIDOption) (
+func (az *AzureImpl) ListSubnetByID(kt *kit.Kit, opt *adtysubnet.AzureSubnetListByIDOption) (
 	*adtysubnet.AzureSubnetListResult, error) {
 
 	if err := opt.Validate(); err != nil {
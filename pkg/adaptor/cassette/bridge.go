@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cassette
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+)
+
+// bridge 为通过 Use 设置的 transport 启动的本地 TLS server, 在 Transport 变化或恢复时关闭.
+var bridge *httptest.Server
+
+// DialContext 返回将所有连接转发到本地 TLS server 的 DialContext, 未设置 transport 时返回 nil.
+// 本地 TLS server 将收到的请求还原为原始的 https 请求后通过 Transport 发送, 用于不支持注入 transport、
+// 仅支持设置 DialContext 的云厂商 sdk(如华为云). 本地 TLS server 使用自签名证书, 调用方需忽略证书校验.
+// 回放时 transport 返回错误, 本地 TLS server 会响应 502 及错误信息, 使 sdk 调用失败.
+func DialContext() func(ctx context.Context, network, addr string) (net.Conn, error) {
+	transportLock.Lock()
+	defer transportLock.Unlock()
+
+	if transport == nil {
+		return nil
+	}
+
+	if bridge == nil {
+		bridge = httptest.NewUnstartedServer(newBridgeHandler(transport))
+		bridge.StartTLS()
+	}
+
+	bridgeAddr := bridge.Listener.Addr().String()
+	return func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return new(net.Dialer).DialContext(ctx, network, bridgeAddr)
+	}
+}
+
+// closeBridge 关闭本地 TLS server, 调用方需持有 transportLock.
+func closeBridge() {
+	if bridge == nil {
+		return
+	}
+
+	bridge.Close()
+	bridge = nil
+}
+
+func newBridgeHandler(rt http.RoundTripper) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := r.Clone(r.Context())
+		req.RequestURI = ""
+		req.URL.Scheme = "https"
+		req.URL.Host = r.Host
+
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("cassette round trip %s %s failed, err: %v", req.Method, sanitizeURL(req.URL),
+				err), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+
+		for key, values := range resp.Header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, resp.Body)
+	})
+}
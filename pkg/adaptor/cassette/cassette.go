@@ -0,0 +1,157 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cassette 提供云厂商 API 调用的录制/回放能力, 用于在无网络环境下对 adaptor 进行回归测试.
+// 录制时真实请求会经过 Recorder 发往云厂商, 请求与响应脱敏后保存为 cassette 文件; 回放时按请求内容
+// 确定性地匹配 cassette 中的交互记录并返回, 不会产生任何网络请求.
+package cassette
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"hcm/pkg/tools/json"
+)
+
+// Mode cassette work mode.
+type Mode string
+
+const (
+	// ModeRecord 请求发往云厂商, 并将脱敏后的交互记录保存到 cassette 文件.
+	ModeRecord Mode = "record"
+	// ModeReplay 从 cassette 文件中回放交互记录, 不发起真实请求.
+	ModeReplay Mode = "replay"
+)
+
+// ModeEnv 用于切换测试用例录制/回放模式的环境变量, 未设置时为回放模式.
+const ModeEnv = "HCM_CASSETTE_MODE"
+
+// ModeFromEnv 根据环境变量 HCM_CASSETTE_MODE 获取工作模式, 只有值为 record 时才会录制.
+func ModeFromEnv() Mode {
+	if Mode(os.Getenv(ModeEnv)) == ModeRecord {
+		return ModeRecord
+	}
+
+	return ModeReplay
+}
+
+// Validate mode.
+func (m Mode) Validate() error {
+	switch m {
+	case ModeRecord, ModeReplay:
+	default:
+		return fmt.Errorf("unsupported cassette mode: %s", m)
+	}
+
+	return nil
+}
+
+const base64Encoding = "base64"
+
+// Cassette 录制的交互记录集合, 按请求发生的先后顺序保存.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction 一次请求及其响应.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request 脱敏后的请求.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	// BodyEncoding 为 base64 时 Body 为非 UTF-8 内容经 base64 编码后的值.
+	BodyEncoding string `json:"body_encoding,omitempty"`
+}
+
+// Response 脱敏后的响应.
+type Response struct {
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// Load cassette from file.
+func Load(path string) (*Cassette, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette %s failed, err: %v", path, err)
+	}
+
+	c := new(Cassette)
+	if err = json.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("unmarshal cassette %s failed, err: %v", path, err)
+	}
+
+	return c, nil
+}
+
+// Exist 判断 cassette 文件是否存在, 测试用例可据此在缺少 cassette 时跳过回放.
+func Exist(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Save cassette to file, the parent directory is created if not exists.
+func (c *Cassette) Save(path string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cassette failed, err: %v", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create cassette dir failed, err: %v", err)
+	}
+
+	if err = os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("write cassette %s failed, err: %v", path, err)
+	}
+
+	return nil
+}
+
+// encodeBody 将 body 编码为可保存到 cassette 中的字符串, 非 UTF-8 内容使用 base64 编码.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return base64.StdEncoding.EncodeToString(body), base64Encoding
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case base64Encoding:
+		return base64.StdEncoding.DecodeString(body)
+	default:
+		return nil, fmt.Errorf("unsupported body encoding: %s", encoding)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cassette
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		if r.URL.Path == "/gzip" {
+			w.Header().Set("Content-Encoding", "gzip")
+			gw := gzip.NewWriter(w)
+			_, _ = gw.Write([]byte(`{"name":"gzip"}`))
+			_ = gw.Close()
+			return
+		}
+		_, _ = w.Write([]byte(`{"count":` + strconv.Itoa(count) + `,"access_token":"secret-token"}`))
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("new record recorder failed, err: %v", err)
+	}
+
+	cli := &http.Client{Transport: rec}
+	first := doRequest(t, cli, server.URL+"/list?b=2&a=1&Signature=secret-sign", `{"Password":"secret-pwd","Limit":1}`)
+	second := doRequest(t, cli, server.URL+"/list?a=1&b=2&Signature=other-sign", `{"Limit":1,"Password":"other-pwd"}`)
+	zipped := doRequest(t, cli, server.URL+"/gzip", "")
+	if err = rec.Stop(); err != nil {
+		t.Fatalf("save cassette failed, err: %v", err)
+	}
+	server.Close()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette failed, err: %v", err)
+	}
+	for _, secret := range []string{"secret-sign", "secret-pwd", "secret-token", "secret-cookie", "Authorization"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette should not contain %s", secret)
+		}
+	}
+
+	rec, err = New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("new replay recorder failed, err: %v", err)
+	}
+
+	// 请求参数顺序、签名、密码不同时也能匹配, 相同请求按录制顺序回放.
+	cli = &http.Client{Transport: rec}
+	got := doRequest(t, cli, server.URL+"/list?a=1&b=2&Signature=replay-sign", `{"Limit":1,"Password":"replay"}`)
+	if !strings.Contains(got, `"count":1`) || !strings.Contains(first, `"count":1`) {
+		t.Errorf("first replayed response is unexpected, recorded: %s, replayed: %s", first, got)
+	}
+	got = doRequest(t, cli, server.URL+"/list?Signature=s&b=2&a=1", `{"Password":"p","Limit":1}`)
+	if !strings.Contains(got, `"count":2`) || !strings.Contains(second, `"count":2`) {
+		t.Errorf("second replayed response is unexpected, recorded: %s, replayed: %s", second, got)
+	}
+	if got = doRequest(t, cli, server.URL+"/gzip", ""); got != zipped || got != `{"name":"gzip"}` {
+		t.Errorf("gzip replayed response is unexpected, recorded: %s, replayed: %s", zipped, got)
+	}
+
+	if rec.Unused() != 0 {
+		t.Errorf("all interactions should be replayed, but %d is unused", rec.Unused())
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/list?a=1&b=2", nil)
+	if _, err = rec.RoundTrip(req); err == nil {
+		t.Errorf("replay request without matched interaction should be failed")
+	}
+}
+
+func TestDialContext(t *testing.T) {
+	if DialContext() != nil {
+		t.Fatalf("dial context should be nil without transport")
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	c := &Cassette{Interactions: []Interaction{{
+		Request:  Request{Method: http.MethodPost, URL: "https://api.example.com/list", Body: `{"Limit":1}`},
+		Response: Response{StatusCode: http.StatusOK, Body: `{"count":1}`},
+	}}}
+	if err := c.Save(path); err != nil {
+		t.Fatalf("save cassette failed, err: %v", err)
+	}
+
+	rec, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("new replay recorder failed, err: %v", err)
+	}
+	restore := Use(rec)
+	defer restore()
+
+	// 通过 DialContext 建立的连接转发到本地 TLS server, 由 recorder 回放, 不会发往真实地址.
+	cli := &http.Client{Transport: &http.Transport{
+		DialContext:     DialContext(),
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+	if got := doRequest(t, cli, "https://api.example.com/list", `{"Limit":1}`); got != `{"count":1}` {
+		t.Errorf("replayed response through dial context is unexpected, got: %s", got)
+	}
+
+	resp, err := cli.Post("https://api.example.com/list", "application/json", strings.NewReader(`{"Limit":2}`))
+	if err != nil {
+		t.Fatalf("do request failed, err: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("request without matched interaction should get bad gateway, but got: %d", resp.StatusCode)
+	}
+}
+
+func doRequest(t *testing.T, cli *http.Client, url, body string) string {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("new request failed, err: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret-token")
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		t.Fatalf("do request failed, err: %v", err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response failed, err: %v", err)
+	}
+
+	return string(content)
+}
+
+func TestUseForTest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(ModeEnv, string(ModeRecord))
+		t.Setenv("TEST_SECRET_ID", "secret-id")
+		t.Setenv("TEST_SECRET_KEY", "secret-key")
+
+		rec := UseForTest(t, path)
+		if rec.Mode() != ModeRecord || Transport() != rec {
+			t.Fatalf("recorder should be used in record mode, mode: %s", rec.Mode())
+		}
+
+		id, key := rec.SecretForTest(t, "TEST_SECRET_ID", "TEST_SECRET_KEY")
+		if id != "secret-id" || key != "secret-key" {
+			t.Errorf("secret should be read from env in record mode, id: %s, key: %s", id, key)
+		}
+	})
+
+	// 测试结束后还原 transport 并保存 cassette 文件
+	if Transport() != nil || !Exist(path) {
+		t.Fatalf("transport should be restored and cassette should be saved after test")
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		t.Setenv(ModeEnv, "")
+
+		rec := UseForTest(t, path)
+		id, key := rec.SecretForTest(t, "TEST_SECRET_ID", "TEST_SECRET_KEY")
+		if rec.Mode() != ModeReplay || id != "fake-secret-id" || key != "fake-secret-key" {
+			t.Errorf("fake secret should be used in replay mode, mode: %s, id: %s, key: %s", rec.Mode(), id, key)
+		}
+	})
+}
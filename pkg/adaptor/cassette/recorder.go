@@ -0,0 +1,252 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cassette
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Recorder 实现了 http.RoundTripper, 根据工作模式录制或回放云厂商 API 调用.
+type Recorder struct {
+	mode Mode
+	path string
+	// real 录制模式下实际发送请求的 transport.
+	real http.RoundTripper
+
+	lock     sync.Mutex
+	cassette *Cassette
+	// keys 回放模式下每条交互记录的匹配 key, used 标记交互记录是否已被回放.
+	keys []string
+	used []bool
+}
+
+// New 创建 Recorder. 录制模式下 cassette 文件会在 Stop 时被覆盖写入; 回放模式下 cassette 文件必须存在.
+func New(path string, mode Mode) (*Recorder, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cassette path is required")
+	}
+
+	if err := mode.Validate(); err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		real:     http.DefaultTransport,
+		cassette: new(Cassette),
+	}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cassette = c
+	r.keys = make([]string, len(c.Interactions))
+	r.used = make([]bool, len(c.Interactions))
+	for idx, one := range c.Interactions {
+		key, err := matchKey(one.Request)
+		if err != nil {
+			return nil, fmt.Errorf("cassette %s interaction[%d] is invalid, err: %v", path, idx, err)
+		}
+		r.keys[idx] = key
+	}
+
+	return r, nil
+}
+
+// Mode return recorder work mode.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Stop 结束录制/回放, 录制模式下会将交互记录保存到 cassette 文件.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.cassette.Save(r.path)
+}
+
+// Unused 返回回放模式下尚未被回放的交互记录数量, 用于测试用例校验是否发起了预期的全部请求.
+func (r *Recorder) Unused() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	count := 0
+	for _, used := range r.used {
+		if !used {
+			count++
+		}
+	}
+
+	return count
+}
+
+// RoundTrip implement http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, body)
+	}
+
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, reqBody []byte) (*http.Response, error) {
+	// 在请求发出前脱敏, 避免 real transport 修改请求头影响录制结果.
+	recordReq := sanitizeRequest(req.Method, req.URL, req.Header, reqBody)
+
+	resp, err := r.real.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recordReq,
+		Response: sanitizeResponse(resp.StatusCode, resp.Header, respBody),
+	})
+	r.lock.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, reqBody []byte) (*http.Response, error) {
+	key, err := matchKey(sanitizeRequest(req.Method, req.URL, req.Header, reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// 按录制顺序匹配第一条未被回放的交互记录, 相同请求多次发起时依次返回录制的响应.
+	for idx, one := range r.cassette.Interactions {
+		if r.used[idx] || r.keys[idx] != key {
+			continue
+		}
+
+		r.used[idx] = true
+		return one.Response.toHttp(req)
+	}
+
+	return nil, fmt.Errorf("cassette %s has no unused interaction matched request %s %s", r.path, req.Method,
+		sanitizeURL(req.URL))
+}
+
+func (resp Response) toHttp(req *http.Request) (*http.Response, error) {
+	body, err := decodeBody(resp.Body, resp.BodyEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	// sdk 通过 Header.Get 读取响应头, 需要转换为规范格式的 key.
+	header := make(http.Header, len(resp.Header))
+	for key, values := range resp.Header {
+		header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(resp.StatusCode) + " " + http.StatusText(resp.StatusCode),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody 读取请求 body 后重新设置, 保证后续发送请求时 body 可用.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read request body failed, err: %v", err)
+	}
+	_ = req.Body.Close()
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// readResponseBody 读取响应 body 后重新设置, gzip 压缩的 body 会被解压, 以便 cassette 中保存明文内容.
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed, err: %v", err)
+	}
+
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("new gzip reader failed, err: %v", err)
+		}
+
+		if body, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("decompress response body failed, err: %v", err)
+		}
+
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = int64(len(body))
+		resp.Uncompressed = true
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,236 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cassette
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"hcm/pkg/tools/json"
+)
+
+// redacted 敏感字段脱敏后的值.
+const redacted = "REDACTED"
+
+// sensitiveKeys 需要脱敏的查询参数、表单字段以及 json 字段(忽略大小写), 除密钥、密码、token 和签名外,
+// 还包括每次请求都会变化的时间戳、随机数, 脱敏后的值固定, 以保证回放时能够确定性地匹配.
+var sensitiveKeys = map[string]struct{}{
+	"secretid":             {},
+	"secretkey":            {},
+	"password":             {},
+	"signature":            {},
+	"timestamp":            {},
+	"nonce":                {},
+	"token":                {},
+	"sig":                  {},
+	"access_token":         {},
+	"refresh_token":        {},
+	"id_token":             {},
+	"client_secret":        {},
+	"client_assertion":     {},
+	"assertion":            {},
+	"private_key":          {},
+	"private_key_id":       {},
+	"x-amz-signature":      {},
+	"x-amz-credential":     {},
+	"x-amz-security-token": {},
+	"x-amz-date":           {},
+}
+
+// requestHeaders 保存到 cassette 中的请求头, 其余请求头(如 Authorization、签名、时间戳等)全部丢弃.
+var requestHeaders = []string{"Content-Type", "X-TC-Action", "X-TC-Version", "X-TC-Region", "X-Amz-Target"}
+
+// matchHeaders 回放时参与请求匹配的请求头, 部分云厂商的接口名和地域只体现在请求头中.
+var matchHeaders = []string{"X-TC-Action", "X-TC-Version", "X-TC-Region", "X-Amz-Target"}
+
+// responseHeaders 保存到 cassette 中的响应头, 包括 sdk 解析响应、轮询异步操作所依赖的响应头.
+var responseHeaders = []string{"Content-Type", "Location", "Azure-AsyncOperation", "Retry-After", "X-Ms-Request-Id",
+	"X-Amzn-RequestId", "X-Request-Id"}
+
+func isSensitive(key string) bool {
+	_, exist := sensitiveKeys[strings.ToLower(key)]
+	return exist
+}
+
+// sanitizeRequest 对请求进行脱敏, 结果可直接保存到 cassette 中.
+func sanitizeRequest(method string, u *url.URL, header http.Header, body []byte) Request {
+	req := Request{
+		Method: strings.ToUpper(method),
+		URL:    sanitizeURL(u),
+		Header: filterHeader(header, requestHeaders),
+	}
+	req.Body, req.BodyEncoding = encodeBody(sanitizeBody(contentType(header), body))
+
+	return req
+}
+
+// sanitizeResponse 对响应进行脱敏, 结果可直接保存到 cassette 中.
+func sanitizeResponse(statusCode int, header http.Header, body []byte) Response {
+	resp := Response{
+		StatusCode: statusCode,
+		Header:     filterHeader(header, responseHeaders),
+	}
+	resp.Body, resp.BodyEncoding = encodeBody(sanitizeBody(contentType(header), body))
+
+	return resp
+}
+
+// sanitizeURL 去掉 url 中的用户信息和 fragment, 对敏感查询参数脱敏, 并按参数名排序.
+func sanitizeURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	cp := *u
+	cp.User = nil
+	cp.Fragment = ""
+	cp.RawFragment = ""
+	if len(cp.RawQuery) != 0 {
+		query := cp.Query()
+		redactValues(query)
+		cp.RawQuery = query.Encode()
+	}
+
+	return cp.String()
+}
+
+// filterHeader 按 keys 过滤请求头/响应头, 部分 sdk(如腾讯云)直接设置非规范格式的请求头, 因此忽略大小写匹配.
+func filterHeader(header http.Header, keys []string) http.Header {
+	filtered := make(http.Header)
+	for _, key := range keys {
+		if values := headerValues(header, key); len(values) != 0 {
+			filtered[key] = append([]string(nil), values...)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	return filtered
+}
+
+func headerValues(header http.Header, key string) []string {
+	var values []string
+	for name, one := range header {
+		if strings.EqualFold(name, key) {
+			values = append(values, one...)
+		}
+	}
+
+	return values
+}
+
+func contentType(header http.Header) string {
+	values := headerValues(header, "Content-Type")
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// sanitizeBody 对 json 和表单格式的 body 进行脱敏和规范化(json 字段排序, 表单参数排序), 其他格式原样返回.
+func sanitizeBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+
+		redactValues(values)
+		return []byte(values.Encode())
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return body
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(trimmed, &data); err != nil {
+		return body
+	}
+
+	normalized, err := json.Marshal(redactJson(data))
+	if err != nil {
+		return body
+	}
+
+	return normalized
+}
+
+func redactValues(values url.Values) {
+	for key := range values {
+		if isSensitive(key) {
+			values[key] = []string{redacted}
+		}
+	}
+}
+
+func redactJson(data interface{}) interface{} {
+	switch val := data.(type) {
+	case map[string]interface{}:
+		for key, one := range val {
+			if isSensitive(key) {
+				val[key] = redacted
+				continue
+			}
+			val[key] = redactJson(one)
+		}
+	case []interface{}:
+		for idx := range val {
+			val[idx] = redactJson(val[idx])
+		}
+	}
+
+	return data
+}
+
+// matchKey 生成回放时用于匹配请求的 key, 由请求方法、规范化后的 url、匹配请求头以及规范化后的 body 组成.
+func matchKey(req Request) (string, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := decodeBody(req.Body, req.BodyEncoding)
+	if err != nil {
+		return "", err
+	}
+
+	header := filterHeader(req.Header, matchHeaders)
+	headerKeys := make([]string, 0, len(header))
+	for key, values := range header {
+		headerKeys = append(headerKeys, key+":"+strings.Join(values, ","))
+	}
+	sort.Strings(headerKeys)
+
+	parts := []string{strings.ToUpper(req.Method), sanitizeURL(u), strings.Join(headerKeys, ";"),
+		string(sanitizeBody(contentType(req.Header), body))}
+	return strings.Join(parts, "\n"), nil
+}
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cassette
+
+import (
+	"os"
+	"testing"
+)
+
+// UseForTest 为测试用例创建 path 对应的 Recorder 并替换云厂商 API 调用的传输层, 工作模式由环境变量 HCM_CASSETTE_MODE
+// 决定, 测试结束时还原传输层并保存录制结果.
+func UseForTest(t testing.TB, path string) *Recorder {
+	t.Helper()
+
+	rec, err := New(path, ModeFromEnv())
+	if err != nil {
+		t.Fatalf("new cassette recorder failed, err: %v", err)
+	}
+
+	restore := Use(rec)
+	t.Cleanup(func() {
+		restore()
+		if err := rec.Stop(); err != nil {
+			t.Errorf("stop cassette recorder failed, err: %v", err)
+		}
+	})
+
+	return rec
+}
+
+// SecretForTest 获取测试用例使用的云账号密钥, 录制模式下从环境变量 idEnv、keyEnv 中读取, 未设置时测试失败;
+// 回放模式下交互记录已经脱敏, 返回假密钥即可.
+func (r *Recorder) SecretForTest(t testing.TB, idEnv, keyEnv string) (id string, key string) {
+	t.Helper()
+
+	if r.Mode() != ModeRecord {
+		return "fake-secret-id", "fake-secret-key"
+	}
+
+	return getenv(t, idEnv), getenv(t, keyEnv)
+}
+
+func getenv(t testing.TB, key string) string {
+	t.Helper()
+
+	val, exist := os.LookupEnv(key)
+	if !exist {
+		t.Fatalf("env %s is required in record mode", key)
+	}
+
+	return val
+}
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cassette
+
+import (
+	"net/http"
+	"sync"
+)
+
+var (
+	transportLock sync.RWMutex
+	transport     http.RoundTripper
+)
+
+// Use 设置云厂商 clientSet 发送请求使用的 transport, 仅对设置之后新建的 clientSet 生效, 返回恢复原 transport 的函数.
+// 测试用例中一般通过 UseForTest 创建 Recorder 并设置:
+//
+//	rec := cassette.UseForTest(t, "testdata/list_cvm.json")
+func Use(rt http.RoundTripper) (restore func()) {
+	transportLock.Lock()
+	defer transportLock.Unlock()
+
+	prev := transport
+	transport = rt
+	closeBridge()
+
+	return func() {
+		transportLock.Lock()
+		defer transportLock.Unlock()
+
+		transport = prev
+		closeBridge()
+	}
+}
+
+// Transport 返回通过 Use 设置的 transport, 未设置时返回 nil, 云厂商 sdk 使用各自默认的 http client.
+func Transport() http.RoundTripper {
+	transportLock.RLock()
+	defer transportLock.RUnlock()
+
+	return transport
+}
+
+// HTTPClient 返回使用 Transport 发送请求的 http client, 未设置 transport 时返回 nil.
+func HTTPClient() *http.Client {
+	rt := Transport()
+	if rt == nil {
+		return nil
+	}
+
+	return &http.Client{Transport: rt}
+}
@@ -0,0 +1,436 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package faketcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/poller"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// 模拟的主机状态
+const (
+	cvmStateRunning = "RUNNING"
+	cvmStateStopped = "STOPPED"
+)
+
+const (
+	defaultDiskType   = "CLOUD_PREMIUM"
+	defaultSystemSize = 50
+	defaultDataSize   = 10
+	// cvmHourPrice 模拟的主机每小时价格
+	cvmHourPrice = 0.5
+)
+
+type cvmRecord struct {
+	cvm      cvm.Instance
+	region   string
+	password string
+}
+
+// CreateCvm create cvm with system disk and data disks, the private ip is allocated from the subnet, cvm failed by
+// async fault injection is returned as failed and does not exist.
+func (f *FakeTCloud) CreateCvm(kt *kit.Kit, opt *typecvm.TCloudCreateOption) (*poller.BaseDoneResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "CreateCvm"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	subnet, err := f.checkCvmCreate(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	// 参数预校验不生产资源
+	if opt.DryRun {
+		return new(poller.BaseDoneResult), nil
+	}
+
+	cpu, memory := instanceTypeSpec(opt.InstanceType)
+	result := &poller.BaseDoneResult{
+		SuccessCloudIDs: make([]string, 0, opt.RequiredCount),
+		FailedCloudIDs:  make([]string, 0),
+	}
+	for i := int64(0); i < opt.RequiredCount; i++ {
+		id := f.newID("ins")
+		if f.asyncFailed() {
+			result.FailedCloudIDs = append(result.FailedCloudIDs, id)
+			result.FailedMessage = "[fake] cvm launch failed by fault injection"
+			continue
+		}
+
+		ip, ok := subnet.allocateIP(id)
+		if !ok {
+			result.FailedCloudIDs = append(result.FailedCloudIDs, id)
+			result.FailedMessage = fmt.Sprintf("[fake] subnet %s has no available ip", subnet.subnet.CloudID)
+			continue
+		}
+
+		instance := cvm.Instance{
+			Placement:          &cvm.Placement{Zone: converter.ValToPtr(opt.Zone)},
+			InstanceId:         converter.ValToPtr(id),
+			InstanceType:       converter.ValToPtr(opt.InstanceType),
+			CPU:                converter.ValToPtr(cpu),
+			Memory:             converter.ValToPtr(memory),
+			InstanceName:       converter.ValToPtr(opt.Name),
+			InstanceChargeType: converter.ValToPtr(string(opt.InstanceChargeType)),
+			PrivateIpAddresses: []*string{converter.ValToPtr(ip)},
+			VirtualPrivateCloud: &cvm.VirtualPrivateCloud{
+				VpcId:    converter.ValToPtr(opt.CloudVpcID),
+				SubnetId: converter.ValToPtr(opt.CloudSubnetID),
+			},
+			ImageId:          converter.ValToPtr(opt.CloudImageID),
+			CreatedTime:      now(),
+			OsName:           converter.ValToPtr(imageName(opt.CloudImageID)),
+			SecurityGroupIds: converter.SliceToPtr(opt.CloudSecurityGroupIDs),
+			InstanceState:    converter.ValToPtr(cvmStateRunning),
+			InternetAccessible: &cvm.InternetAccessible{
+				InternetMaxBandwidthOut: converter.ValToPtr(opt.InternetMaxBandwidthOut),
+				PublicIpAssigned:        converter.ValToPtr(opt.PublicIPAssigned),
+			},
+		}
+
+		if opt.PublicIPAssigned {
+			instance.PublicIpAddresses = []*string{converter.ValToPtr(f.newPublicIP())}
+		}
+
+		systemSize := int64(defaultSystemSize)
+		if opt.SystemDisk.DiskSizeGB != nil {
+			systemSize = *opt.SystemDisk.DiskSizeGB
+		}
+		systemType := string(opt.SystemDisk.DiskType)
+		if len(systemType) == 0 {
+			systemType = defaultDiskType
+		}
+		system := f.newDisk(opt.Region, opt.Zone, systemType, uint64(systemSize), string(opt.InstanceChargeType))
+		system.disk.DiskUsage = converter.ValToPtr(diskUsageSystem)
+		system.attach(id, true)
+		instance.SystemDisk = &cvm.SystemDisk{DiskType: system.disk.DiskType, DiskId: system.disk.DiskId,
+			DiskSize: converter.ValToPtr(systemSize)}
+
+		for _, one := range opt.DataDisk {
+			size := int64(defaultDataSize)
+			if one.DiskSizeGB != nil {
+				size = *one.DiskSizeGB
+			}
+			diskType := string(one.DiskType)
+			if len(diskType) == 0 {
+				diskType = defaultDiskType
+			}
+			data := f.newDisk(opt.Region, opt.Zone, diskType, uint64(size), string(opt.InstanceChargeType))
+			data.attach(id, true)
+			instance.DataDisks = append(instance.DataDisks, &cvm.DataDisk{DiskSize: converter.ValToPtr(size),
+				DiskType: data.disk.DiskType, DiskId: data.disk.DiskId, DeleteWithInstance: converter.ValToPtr(true)})
+		}
+
+		f.cvms.add(id, &cvmRecord{cvm: instance, region: opt.Region, password: opt.Password})
+		result.SuccessCloudIDs = append(result.SuccessCloudIDs, id)
+	}
+
+	return result, nil
+}
+
+// checkCvmCreate check the cvm create option, returns the subnet to allocate ip, it should be called with lock held.
+func (f *FakeTCloud) checkCvmCreate(kt *kit.Kit, opt *typecvm.TCloudCreateOption) (*subnetRecord, error) {
+	if err := f.checkZone(kt, opt.Region, opt.Zone); err != nil {
+		return nil, err
+	}
+
+	if err := checkQuota(kt, "cvm", f.opt.Quota.Cvm, f.cvms.len(), int(opt.RequiredCount)); err != nil {
+		return nil, err
+	}
+
+	diskCount := int(opt.RequiredCount) * (1 + len(opt.DataDisk))
+	if err := checkQuota(kt, "disk", f.opt.Quota.Disk, f.disks.len(), diskCount); err != nil {
+		return nil, err
+	}
+
+	vpc, exists := f.vpcs.get(opt.CloudVpcID)
+	if !exists || vpc.vpc.Region != opt.Region {
+		return nil, sdkError(kt, ErrCodeNotFound, "[fake] vpc %s not found in region %s", opt.CloudVpcID, opt.Region)
+	}
+
+	subnet, exists := f.subnets.get(opt.CloudSubnetID)
+	if !exists || subnet.subnet.CloudVpcID != opt.CloudVpcID {
+		return nil, sdkError(kt, ErrCodeNotFound, "[fake] subnet %s not found in vpc %s", opt.CloudSubnetID,
+			opt.CloudVpcID)
+	}
+
+	if subnet.subnet.Extension.Zone != opt.Zone {
+		return nil, sdkError(kt, ErrCodeInvalidParameter, "[fake] subnet %s is in zone %s, not %s",
+			opt.CloudSubnetID, subnet.subnet.Extension.Zone, opt.Zone)
+	}
+
+	for _, id := range opt.CloudSecurityGroupIDs {
+		if _, err := f.getSG(kt, opt.Region, id); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, one := range opt.DataDisk {
+		if one.CloudDiskID != nil {
+			return nil, sdkError(kt, ErrCodeUnsupportedOperation, "[fake] create cvm with existing data disk "+
+				"is not supported")
+		}
+	}
+
+	return subnet, nil
+}
+
+// DeleteCvm delete cvm, the system disk and data disks deleted with instance are deleted, other data disks are
+// detached and the bound eips are unbound.
+func (f *FakeTCloud) DeleteCvm(kt *kit.Kit, opt *typecvm.TCloudDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteCvm"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	cvms, err := f.getCvms(kt, opt.Region, opt.CloudIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, one := range cvms {
+		id := converter.PtrToVal(one.cvm.InstanceId)
+
+		if subnet, exists := f.subnets.get(converter.PtrToVal(one.cvm.VirtualPrivateCloud.SubnetId)); exists {
+			for _, ip := range one.cvm.PrivateIpAddresses {
+				delete(subnet.usedIPs, converter.PtrToVal(ip))
+			}
+		}
+
+		for _, disk := range f.disks.list(nil, func(r *diskRecord) bool {
+			return converter.PtrToVal(r.disk.InstanceId) == id
+		}) {
+			if converter.PtrToVal(disk.disk.DeleteWithInstance) {
+				f.disks.remove(converter.PtrToVal(disk.disk.DiskId))
+				continue
+			}
+			disk.detach()
+		}
+
+		for _, eip := range f.eips.list(nil, func(r *eipRecord) bool {
+			return converter.PtrToVal(r.eip.InstanceId) == id
+		}) {
+			eip.unbind()
+		}
+
+		f.cvms.remove(id)
+	}
+
+	return nil
+}
+
+// StartCvm start stopped cvm.
+func (f *FakeTCloud) StartCvm(kt *kit.Kit, opt *typecvm.TCloudStartOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "StartCvm"); err != nil {
+		return err
+	}
+
+	return f.transitCvm(kt, opt.Region, opt.CloudIDs, cvmStateStopped, cvmStateRunning)
+}
+
+// StopCvm stop running cvm.
+func (f *FakeTCloud) StopCvm(kt *kit.Kit, opt *typecvm.TCloudStopOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "StopCvm"); err != nil {
+		return err
+	}
+
+	return f.transitCvm(kt, opt.Region, opt.CloudIDs, cvmStateRunning, cvmStateStopped)
+}
+
+// RebootCvm reboot running cvm.
+func (f *FakeTCloud) RebootCvm(kt *kit.Kit, opt *typecvm.TCloudRebootOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "RebootCvm"); err != nil {
+		return err
+	}
+
+	return f.transitCvm(kt, opt.Region, opt.CloudIDs, cvmStateRunning, cvmStateRunning)
+}
+
+// ResetCvmPwd reset cvm password, running cvm should be stopped first unless force stop is set.
+func (f *FakeTCloud) ResetCvmPwd(kt *kit.Kit, opt *typecvm.TCloudResetPwdOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "ResetCvmPwd"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	cvms, err := f.getCvms(kt, opt.Region, opt.CloudIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, one := range cvms {
+		if converter.PtrToVal(one.cvm.InstanceState) == cvmStateRunning && !opt.ForceStop {
+			return sdkError(kt, ErrCodeIncorrectState, "[fake] cvm %s should be stopped before reset password",
+				converter.PtrToVal(one.cvm.InstanceId))
+		}
+	}
+
+	for _, one := range cvms {
+		one.password = opt.Password
+	}
+
+	return nil
+}
+
+// ListCvm list cvm.
+func (f *FakeTCloud) ListCvm(kt *kit.Kit, opt *typecvm.TCloudListOption) ([]typecvm.TCloudCvm, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListCvm"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	records := f.cvms.list(opt.CloudIDs, func(r *cvmRecord) bool { return r.region == opt.Region })
+	result := make([]typecvm.TCloudCvm, 0, len(records))
+	for _, one := range paginate(records, opt.Page) {
+		instance := one.cvm
+		instance.DataDisks = append([]*cvm.DataDisk(nil), one.cvm.DataDisks...)
+		instance.SecurityGroupIds = append([]*string(nil), one.cvm.SecurityGroupIds...)
+		instance.PublicIpAddresses = append([]*string(nil), one.cvm.PublicIpAddresses...)
+		result = append(result, typecvm.TCloudCvm{Instance: &instance})
+	}
+
+	return result, nil
+}
+
+// CountCvm count cvm of the region.
+func (f *FakeTCloud) CountCvm(kt *kit.Kit, region string) (int32, error) {
+	if err := f.fault(kt, "CountCvm"); err != nil {
+		return 0, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return int32(len(f.cvms.list(nil, func(r *cvmRecord) bool { return r.region == region }))), nil
+}
+
+// InquiryPriceCvm inquiry cvm price, the price is fixed per cvm.
+func (f *FakeTCloud) InquiryPriceCvm(kt *kit.Kit, opt *typecvm.TCloudCreateOption) (*typecvm.InquiryPriceResult,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "InquiryPriceCvm"); err != nil {
+		return nil, err
+	}
+
+	price := cvmHourPrice * float64(opt.RequiredCount)
+	return &typecvm.InquiryPriceResult{DiscountPrice: price, OriginalPrice: price}, nil
+}
+
+// transitCvm change the state of cvms from the source state to the target state, it returns incorrect state error
+// if any cvm is not in the source state and no cvm is changed.
+func (f *FakeTCloud) transitCvm(kt *kit.Kit, region string, ids []string, from, to string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	cvms, err := f.getCvms(kt, region, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, one := range cvms {
+		if state := converter.PtrToVal(one.cvm.InstanceState); state != from {
+			return sdkError(kt, ErrCodeIncorrectState, "[fake] cvm %s is %s, should be %s",
+				converter.PtrToVal(one.cvm.InstanceId), state, from)
+		}
+	}
+
+	for _, one := range cvms {
+		one.cvm.InstanceState = converter.ValToPtr(to)
+	}
+
+	return nil
+}
+
+// getCvm get cvm record, it should be called with lock held.
+func (f *FakeTCloud) getCvm(kt *kit.Kit, region, id string) (*cvmRecord, error) {
+	cvm, exists := f.cvms.get(id)
+	if !exists || cvm.region != region {
+		return nil, sdkError(kt, ErrCodeNotFound, "[fake] cvm %s not found in region %s", id, region)
+	}
+
+	return cvm, nil
+}
+
+// getCvms get all cvm records of the ids, it should be called with lock held.
+func (f *FakeTCloud) getCvms(kt *kit.Kit, region string, ids []string) ([]*cvmRecord, error) {
+	cvms := make([]*cvmRecord, 0, len(ids))
+	for _, id := range ids {
+		cvm, err := f.getCvm(kt, region, id)
+		if err != nil {
+			return nil, err
+		}
+		cvms = append(cvms, cvm)
+	}
+
+	return cvms, nil
+}
+
+// newPublicIP generate a new public ip in the TEST-NET-3 range, it should be called with lock held.
+func (f *FakeTCloud) newPublicIP() string {
+	f.seq++
+	return fmt.Sprintf("203.0.%d.%d", (f.seq>>8)&0xff, f.seq&0xff)
+}
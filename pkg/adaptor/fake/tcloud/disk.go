@@ -0,0 +1,364 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package faketcloud
+
+import (
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// 模拟的硬盘状态和用途
+const (
+	diskStateAttached   = "ATTACHED"
+	diskStateUnattached = "UNATTACHED"
+	diskUsageSystem     = "SYSTEM_DISK"
+	diskUsageData       = "DATA_DISK"
+	// diskGBHourPrice 模拟的硬盘每GB每小时价格
+	diskGBHourPrice = 0.001
+)
+
+type diskRecord struct {
+	disk   cbs.Disk
+	region string
+}
+
+// attach set the disk attached to the cvm.
+func (r *diskRecord) attach(cvmID string, deleteWithInstance bool) {
+	r.disk.Attached = converter.ValToPtr(true)
+	r.disk.DiskState = converter.ValToPtr(diskStateAttached)
+	r.disk.InstanceId = converter.ValToPtr(cvmID)
+	r.disk.InstanceIdList = []*string{converter.ValToPtr(cvmID)}
+	r.disk.DeleteWithInstance = converter.ValToPtr(deleteWithInstance)
+}
+
+// detach set the disk unattached.
+func (r *diskRecord) detach() {
+	r.disk.LastAttachInsId = r.disk.InstanceId
+	r.disk.Attached = converter.ValToPtr(false)
+	r.disk.DiskState = converter.ValToPtr(diskStateUnattached)
+	r.disk.InstanceId = converter.ValToPtr("")
+	r.disk.InstanceIdList = make([]*string, 0)
+	r.disk.DeleteWithInstance = converter.ValToPtr(false)
+}
+
+// newDisk create an unattached data disk record, it should be called with lock held.
+func (f *FakeTCloud) newDisk(region, zone, diskType string, size uint64, chargeType string) *diskRecord {
+	record := &diskRecord{
+		disk: cbs.Disk{
+			DiskId:             converter.ValToPtr(f.newID("disk")),
+			DiskName:           converter.ValToPtr("未命名"),
+			DiskType:           converter.ValToPtr(diskType),
+			DiskSize:           converter.ValToPtr(size),
+			DiskUsage:          converter.ValToPtr(diskUsageData),
+			DiskChargeType:     converter.ValToPtr(chargeType),
+			Placement:          &cbs.Placement{Zone: converter.ValToPtr(zone)},
+			Portable:           converter.ValToPtr(true),
+			SnapshotAbility:    converter.ValToPtr(true),
+			CreateTime:         now(),
+			DeleteWithInstance: converter.ValToPtr(false),
+		},
+		region: region,
+	}
+	record.detach()
+	record.disk.LastAttachInsId = nil
+	f.disks.add(*record.disk.DiskId, record)
+
+	return record
+}
+
+// CreateDisk create unattached data disks, disk failed by async fault injection is returned as failed and does not
+// exist.
+func (f *FakeTCloud) CreateDisk(kt *kit.Kit, opt *disk.TCloudDiskCreateOption) (*poller.BaseDoneResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "CreateDisk"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := f.checkZone(kt, opt.Region, opt.Zone); err != nil {
+		return nil, err
+	}
+
+	count := converter.PtrToVal(opt.DiskCount)
+	if count == 0 {
+		count = 1
+	}
+
+	if err := checkQuota(kt, "disk", f.opt.Quota.Disk, f.disks.len(), int(count)); err != nil {
+		return nil, err
+	}
+
+	size := converter.PtrToVal(opt.DiskSize)
+	if opt.SnapshotID != nil {
+		snapshots, err := f.getSnapshots(kt, opt.Region, []string{*opt.SnapshotID})
+		if err != nil {
+			return nil, err
+		}
+
+		// 从快照创建时，云盘大小默认为快照大小，且不能小于快照大小
+		snapSize := converter.PtrToVal(snapshots[0].snapshot.DiskSize)
+		if size == 0 {
+			size = snapSize
+		}
+		if size < snapSize {
+			return nil, sdkError(kt, ErrCodeInvalidParameter, "[fake] disk size %d is less than snapshot size %d",
+				size, snapSize)
+		}
+	}
+	if size == 0 {
+		size = defaultDataSize
+	}
+
+	result := &poller.BaseDoneResult{
+		SuccessCloudIDs: make([]string, 0, count),
+		FailedCloudIDs:  make([]string, 0),
+	}
+	for i := uint64(0); i < count; i++ {
+		if f.asyncFailed() {
+			result.FailedCloudIDs = append(result.FailedCloudIDs, f.newID("disk"))
+			result.FailedMessage = "[fake] disk create failed by fault injection"
+			continue
+		}
+
+		record := f.newDisk(opt.Region, opt.Zone, opt.DiskType, size, opt.DiskChargeType)
+		if opt.DiskName != nil {
+			record.disk.DiskName = converter.ValToPtr(*opt.DiskName)
+		}
+		result.SuccessCloudIDs = append(result.SuccessCloudIDs, *record.disk.DiskId)
+	}
+
+	return result, nil
+}
+
+// InquiryPriceDisk inquiry disk price, the price is fixed per GB.
+func (f *FakeTCloud) InquiryPriceDisk(kt *kit.Kit, opt *disk.TCloudDiskCreateOption) (*typecvm.InquiryPriceResult,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "InquiryPriceDisk"); err != nil {
+		return nil, err
+	}
+
+	count := converter.PtrToVal(opt.DiskCount)
+	if count == 0 {
+		count = 1
+	}
+
+	price := diskGBHourPrice * float64(converter.PtrToVal(opt.DiskSize)*count)
+	return &typecvm.InquiryPriceResult{DiscountPrice: price, OriginalPrice: price}, nil
+}
+
+// ListDisk list disk.
+func (f *FakeTCloud) ListDisk(kt *kit.Kit, opt *core.TCloudListOption) ([]disk.TCloudDisk, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListDisk"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	records := f.disks.list(opt.CloudIDs, func(r *diskRecord) bool { return r.region == opt.Region })
+	result := make([]disk.TCloudDisk, 0, len(records))
+	for _, one := range paginate(records, opt.Page) {
+		one := one.disk
+		result = append(result, disk.TCloudDisk{Boot: converter.PtrToVal(one.DiskUsage) == diskUsageSystem,
+			Disk: &one})
+	}
+
+	return result, nil
+}
+
+// CountDisk count disk of the region.
+func (f *FakeTCloud) CountDisk(kt *kit.Kit, region string) (int32, error) {
+	if err := f.fault(kt, "CountDisk"); err != nil {
+		return 0, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return int32(len(f.disks.list(nil, func(r *diskRecord) bool { return r.region == region }))), nil
+}
+
+// DeleteDisk delete disks, only unattached disk can be deleted.
+func (f *FakeTCloud) DeleteDisk(kt *kit.Kit, opt *disk.TCloudDiskDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteDisk"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	disks, err := f.getDisks(kt, opt.Region, opt.CloudIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, one := range disks {
+		if state := converter.PtrToVal(one.disk.DiskState); state != diskStateUnattached {
+			return sdkError(kt, ErrCodeIncorrectState, "[fake] disk %s is %s, should be detached before delete",
+				converter.PtrToVal(one.disk.DiskId), state)
+		}
+	}
+
+	for _, one := range disks {
+		f.disks.remove(converter.PtrToVal(one.disk.DiskId))
+	}
+
+	return nil
+}
+
+// AttachDisk attach unattached data disks to the cvm in the same zone.
+func (f *FakeTCloud) AttachDisk(kt *kit.Kit, opt *disk.TCloudDiskAttachOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "AttachDisk"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	cvmRec, err := f.getCvm(kt, opt.Region, opt.CloudCvmID)
+	if err != nil {
+		return err
+	}
+
+	disks, err := f.getDisks(kt, opt.Region, opt.CloudDiskIDs)
+	if err != nil {
+		return err
+	}
+
+	zone := converter.PtrToVal(cvmRec.cvm.Placement.Zone)
+	for _, one := range disks {
+		id := converter.PtrToVal(one.disk.DiskId)
+		if state := converter.PtrToVal(one.disk.DiskState); state != diskStateUnattached {
+			return sdkError(kt, ErrCodeIncorrectState, "[fake] disk %s is %s, can not be attached", id, state)
+		}
+
+		if diskZone := converter.PtrToVal(one.disk.Placement.Zone); diskZone != zone {
+			return sdkError(kt, ErrCodeInvalidParameter, "[fake] disk %s is in zone %s, cvm %s is in zone %s", id,
+				diskZone, opt.CloudCvmID, zone)
+		}
+	}
+
+	for _, one := range disks {
+		one.attach(opt.CloudCvmID, converter.PtrToVal(opt.DeleteWithInstance))
+		cvmRec.cvm.DataDisks = append(cvmRec.cvm.DataDisks, &cvm.DataDisk{
+			DiskSize:           converter.ValToPtr(int64(converter.PtrToVal(one.disk.DiskSize))),
+			DiskType:           one.disk.DiskType,
+			DiskId:             one.disk.DiskId,
+			DeleteWithInstance: one.disk.DeleteWithInstance,
+		})
+	}
+
+	return nil
+}
+
+// DetachDisk detach data disks from the cvm, system disk can not be detached.
+func (f *FakeTCloud) DetachDisk(kt *kit.Kit, opt *disk.TCloudDiskDetachOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DetachDisk"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	cvmRec, err := f.getCvm(kt, opt.Region, opt.CloudCvmID)
+	if err != nil {
+		return err
+	}
+
+	disks, err := f.getDisks(kt, opt.Region, opt.CloudDiskIDs)
+	if err != nil {
+		return err
+	}
+
+	detached := make(map[string]struct{}, len(disks))
+	for _, one := range disks {
+		id := converter.PtrToVal(one.disk.DiskId)
+		if converter.PtrToVal(one.disk.InstanceId) != opt.CloudCvmID {
+			return sdkError(kt, ErrCodeIncorrectState, "[fake] disk %s is not attached to cvm %s", id,
+				opt.CloudCvmID)
+		}
+
+		if converter.PtrToVal(one.disk.DiskUsage) == diskUsageSystem {
+			return sdkError(kt, ErrCodeUnsupportedOperation, "[fake] system disk %s can not be detached", id)
+		}
+		detached[id] = struct{}{}
+	}
+
+	for _, one := range disks {
+		one.detach()
+	}
+
+	dataDisks := make([]*cvm.DataDisk, 0, len(cvmRec.cvm.DataDisks))
+	for _, one := range cvmRec.cvm.DataDisks {
+		if _, exists := detached[converter.PtrToVal(one.DiskId)]; !exists {
+			dataDisks = append(dataDisks, one)
+		}
+	}
+	cvmRec.cvm.DataDisks = dataDisks
+
+	return nil
+}
+
+// getDisks get all disk records of the ids, it should be called with lock held.
+func (f *FakeTCloud) getDisks(kt *kit.Kit, region string, ids []string) ([]*diskRecord, error) {
+	disks := make([]*diskRecord, 0, len(ids))
+	for _, id := range ids {
+		one, exists := f.disks.get(id)
+		if !exists || one.region != region {
+			return nil, sdkError(kt, ErrCodeNotFound, "[fake] disk %s not found in region %s", id, region)
+		}
+		disks = append(disks, one)
+	}
+
+	return disks, nil
+}
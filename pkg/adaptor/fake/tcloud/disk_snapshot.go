@@ -0,0 +1,140 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package faketcloud
+
+import (
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+)
+
+type snapshotRecord struct {
+	snapshot cbs.Snapshot
+	region   string
+}
+
+// ListSnapshot list disk snapshot.
+func (f *FakeTCloud) ListSnapshot(kt *kit.Kit, opt *typesnapshot.TCloudListOption) ([]typesnapshot.TCloudSnapshot,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListSnapshot"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	records := f.snapshots.list(opt.CloudIDs, func(r *snapshotRecord) bool { return r.region == opt.Region })
+	result := make([]typesnapshot.TCloudSnapshot, 0, len(records))
+	for _, one := range paginate(records, opt.Page) {
+		one := one.snapshot
+		result = append(result, typesnapshot.TCloudSnapshot{Snapshot: &one})
+	}
+
+	return result, nil
+}
+
+// CreateSnapshot create a snapshot of the disk, the snapshot is available immediately.
+func (f *FakeTCloud) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.TCloudCreateOption) (string, error) {
+	if err := opt.Validate(); err != nil {
+		return "", err
+	}
+
+	if err := f.fault(kt, "CreateSnapshot"); err != nil {
+		return "", err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	disks, err := f.getDisks(kt, opt.Region, []string{opt.CloudDiskID})
+	if err != nil {
+		return "", err
+	}
+	one := disks[0].disk
+
+	record := &snapshotRecord{
+		snapshot: cbs.Snapshot{
+			SnapshotId:    converter.ValToPtr(f.newID("snap")),
+			SnapshotName:  converter.ValToPtr("未命名"),
+			SnapshotState: converter.ValToPtr(typesnapshot.TCloudSnapshotNormal),
+			SnapshotType:  converter.ValToPtr("PRIVATE_SNAPSHOT"),
+			DiskId:        one.DiskId,
+			DiskSize:      one.DiskSize,
+			DiskUsage:     one.DiskUsage,
+			Placement:     one.Placement,
+			Encrypt:       converter.ValToPtr(false),
+			IsPermanent:   converter.ValToPtr(true),
+			Percent:       converter.ValToPtr(uint64(100)),
+			CreateTime:    now(),
+		},
+		region: opt.Region,
+	}
+	if opt.Name != nil {
+		record.snapshot.SnapshotName = converter.ValToPtr(*opt.Name)
+	}
+	f.snapshots.add(*record.snapshot.SnapshotId, record)
+
+	return *record.snapshot.SnapshotId, nil
+}
+
+// DeleteSnapshot delete disk snapshots.
+func (f *FakeTCloud) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.TCloudDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteSnapshot"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, err := f.getSnapshots(kt, opt.Region, opt.CloudIDs); err != nil {
+		return err
+	}
+
+	for _, id := range opt.CloudIDs {
+		f.snapshots.remove(id)
+	}
+
+	return nil
+}
+
+// getSnapshots get all snapshot records of the ids, it should be called with lock held.
+func (f *FakeTCloud) getSnapshots(kt *kit.Kit, region string, ids []string) ([]*snapshotRecord, error) {
+	snapshots := make([]*snapshotRecord, 0, len(ids))
+	for _, id := range ids {
+		one, exists := f.snapshots.get(id)
+		if !exists || one.region != region {
+			return nil, sdkError(kt, ErrCodeNotFound, "[fake] snapshot %s not found in region %s", id, region)
+		}
+		snapshots = append(snapshots, one)
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,275 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package faketcloud
+
+import (
+	"errors"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/eip"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// 模拟的弹性IP状态
+const (
+	eipStatusBind   = "BIND"
+	eipStatusUnbind = "UNBIND"
+)
+
+type eipRecord struct {
+	eip eip.TCloudEip
+	// cvm 绑定的主机，未绑定时为空
+	cvm *cvmRecord
+}
+
+// unbind set the eip unbound and remove its public ip from the bound cvm.
+func (r *eipRecord) unbind() {
+	if r.cvm != nil {
+		ips := converter.PtrToSlice(r.cvm.cvm.PublicIpAddresses)
+		r.cvm.cvm.PublicIpAddresses = converter.SliceToPtr(slice.Remove(ips, converter.PtrToVal(r.eip.PublicIp)))
+	}
+
+	r.cvm = nil
+	r.eip.Status = converter.ValToPtr(eipStatusUnbind)
+	r.eip.InstanceId = nil
+	r.eip.PrivateIp = nil
+}
+
+// CreateEip create unbound eips, eip failed by async fault injection is returned as failed and does not exist.
+func (f *FakeTCloud) CreateEip(kt *kit.Kit, opt *eip.TCloudEipCreateOption) (*poller.BaseDoneResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "CreateEip"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := f.checkRegion(kt, opt.Region); err != nil {
+		return nil, err
+	}
+
+	if err := checkQuota(kt, "eip", f.opt.Quota.Eip, f.eips.len(), int(opt.EipCount)); err != nil {
+		return nil, err
+	}
+
+	result := &poller.BaseDoneResult{
+		SuccessCloudIDs: make([]string, 0, opt.EipCount),
+		FailedCloudIDs:  make([]string, 0),
+	}
+	for i := int64(0); i < opt.EipCount; i++ {
+		id := f.newID("eip")
+		if f.asyncFailed() {
+			result.FailedCloudIDs = append(result.FailedCloudIDs, id)
+			result.FailedMessage = "[fake] eip create failed by fault injection"
+			continue
+		}
+
+		record := &eipRecord{eip: eip.TCloudEip{
+			CloudID:                 id,
+			Name:                    opt.EipName,
+			Region:                  opt.Region,
+			PublicIp:                converter.ValToPtr(f.newPublicIP()),
+			Bandwidth:               converter.ValToPtr(uint64(1)),
+			InternetChargeType:      converter.ValToPtr("TRAFFIC_POSTPAID_BY_HOUR"),
+			InternetServiceProvider: converter.ValToPtr(opt.ServiceProvider),
+		}}
+		record.unbind()
+		f.eips.add(id, record)
+		result.SuccessCloudIDs = append(result.SuccessCloudIDs, id)
+	}
+
+	return result, nil
+}
+
+// DeleteEip delete eips, only unbound eip can be deleted.
+func (f *FakeTCloud) DeleteEip(kt *kit.Kit, opt *eip.TCloudEipDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteEip"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	eips := make([]*eipRecord, 0, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		one, err := f.getEip(kt, opt.Region, id)
+		if err != nil {
+			return err
+		}
+
+		if status := converter.PtrToVal(one.eip.Status); status != eipStatusUnbind {
+			return sdkError(kt, ErrCodeIncorrectState, "[fake] eip %s is %s, should be unbound before delete", id,
+				status)
+		}
+		eips = append(eips, one)
+	}
+
+	for _, one := range eips {
+		f.eips.remove(one.eip.CloudID)
+	}
+
+	return nil
+}
+
+// AssociateEip bind the eip to the cvm, one cvm can be bound with only one eip.
+func (f *FakeTCloud) AssociateEip(kt *kit.Kit, opt *eip.TCloudEipAssociateOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "AssociateEip"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	record, err := f.getEip(kt, opt.Region, opt.CloudEipID)
+	if err != nil {
+		return err
+	}
+
+	if status := converter.PtrToVal(record.eip.Status); status != eipStatusUnbind {
+		return sdkError(kt, ErrCodeIncorrectState, "[fake] eip %s is %s, can not be bound", opt.CloudEipID, status)
+	}
+
+	cvm, err := f.getCvm(kt, opt.Region, opt.CloudCvmID)
+	if err != nil {
+		return err
+	}
+
+	bound := f.eips.list(nil, func(r *eipRecord) bool { return r.cvm == cvm })
+	if len(bound) != 0 {
+		return sdkError(kt, ErrCodeIncorrectState, "[fake] cvm %s is already bound with eip %s", opt.CloudCvmID,
+			bound[0].eip.CloudID)
+	}
+
+	record.cvm = cvm
+	record.eip.Status = converter.ValToPtr(eipStatusBind)
+	record.eip.InstanceId = converter.ValToPtr(opt.CloudCvmID)
+	if len(cvm.cvm.PrivateIpAddresses) != 0 {
+		record.eip.PrivateIp = converter.ValToPtr(converter.PtrToVal(cvm.cvm.PrivateIpAddresses[0]))
+	}
+	cvm.cvm.PublicIpAddresses = append(cvm.cvm.PublicIpAddresses, converter.ValToPtr(*record.eip.PublicIp))
+
+	return nil
+}
+
+// DisassociateEip unbind the eip.
+func (f *FakeTCloud) DisassociateEip(kt *kit.Kit, opt *eip.TCloudEipDisassociateOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DisassociateEip"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	record, err := f.getEip(kt, opt.Region, opt.CloudEipID)
+	if err != nil {
+		return err
+	}
+
+	if status := converter.PtrToVal(record.eip.Status); status != eipStatusBind {
+		return sdkError(kt, ErrCodeIncorrectState, "[fake] eip %s is %s, can not be unbound", opt.CloudEipID,
+			status)
+	}
+	record.unbind()
+
+	return nil
+}
+
+// ListEip list eip.
+func (f *FakeTCloud) ListEip(kt *kit.Kit, opt *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListEip"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	records := f.eips.list(opt.CloudIDs, func(r *eipRecord) bool {
+		if r.eip.Region != opt.Region {
+			return false
+		}
+		return len(opt.Ips) == 0 || slice.IsItemInSlice(opt.Ips, converter.PtrToVal(r.eip.PublicIp))
+	})
+	details := make([]*eip.TCloudEip, 0, len(records))
+	for _, one := range paginate(records, opt.Page) {
+		one := one.eip
+		details = append(details, &one)
+	}
+
+	return &eip.TCloudEipListResult{Count: converter.ValToPtr(uint64(len(records))), Details: details}, nil
+}
+
+// CountEip count eip of the region.
+func (f *FakeTCloud) CountEip(kt *kit.Kit, region string) (int32, error) {
+	if err := f.fault(kt, "CountEip"); err != nil {
+		return 0, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return int32(len(f.eips.list(nil, func(r *eipRecord) bool { return r.eip.Region == region }))), nil
+}
+
+// DetermineIPv6Type 判断ipv6地址是否是公网ip，模拟云中的ipv6地址均为内网ip
+func (f *FakeTCloud) DetermineIPv6Type(kt *kit.Kit, region string, ipv6Addresses []*string) ([]*string,
+	[]*string, error,
+) {
+	if len(region) == 0 || len(ipv6Addresses) == 0 {
+		return nil, nil, errors.New("region and ipv6Addresses is required")
+	}
+
+	if err := f.fault(kt, "DetermineIPv6Type"); err != nil {
+		return nil, nil, err
+	}
+
+	return make([]*string, 0), ipv6Addresses, nil
+}
+
+// getEip get eip record, it should be called with lock held.
+func (f *FakeTCloud) getEip(kt *kit.Kit, region, id string) (*eipRecord, error) {
+	one, exists := f.eips.get(id)
+	if !exists || one.eip.Region != region {
+		return nil, sdkError(kt, ErrCodeNotFound, "[fake] eip %s not found in region %s", id, region)
+	}
+
+	return one, nil
+}
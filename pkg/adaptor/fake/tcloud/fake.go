@@ -0,0 +1,349 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package faketcloud 内存中的腾讯云模拟实现，有状态地保存vpc、子网、安全组、主机、硬盘、快照、弹性IP和负载均衡等资源，并模拟云上的
+// 网段包含关系、挂载/卸载状态机、配额限制，以及限频、异步任务失败等故障，用于在没有云账号的情况下进行端到端测试。
+package faketcloud
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"hcm/pkg/adaptor/tcloud"
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+)
+
+// 模拟的腾讯云错误码
+const (
+	// ErrCodeNotFound 资源不存在
+	ErrCodeNotFound = "ResourceNotFound"
+	// ErrCodeInvalidParameter 参数不合法，如网段不包含、网段冲突等
+	ErrCodeInvalidParameter = "InvalidParameterValue"
+	// ErrCodeResourceInUse 资源被占用，如删除仍有子网的vpc
+	ErrCodeResourceInUse = "ResourceInUse"
+	// ErrCodeIncorrectState 资源状态不支持该操作，如挂载已挂载的硬盘
+	ErrCodeIncorrectState = "IncorrectState"
+	// ErrCodeLimitExceeded 超过配额
+	ErrCodeLimitExceeded = "LimitExceeded"
+	// ErrCodeRequestLimitExceeded 请求限频
+	ErrCodeRequestLimitExceeded = "RequestLimitExceeded"
+	// ErrCodeUnsupportedOperation 模拟云不支持的操作
+	ErrCodeUnsupportedOperation = "UnsupportedOperation"
+)
+
+const (
+	defaultRegion    = "ap-guangzhou"
+	defaultAccountID = "100000000001"
+	timeLayout       = "2006-01-02T15:04:05Z"
+	// unlimitedQuota 配额为0时查询配额返回的总配额
+	unlimitedQuota = 10000
+)
+
+var _ tcloud.TCloud = new(FakeTCloud)
+
+// Option defines fake tcloud options.
+type Option struct {
+	// Regions 模拟云支持的地域，为空时默认为ap-guangzhou
+	Regions []string
+	// ZoneCount 每个地域的可用区数量，可用区名称为<region>-<1..n>，为0时默认为3
+	ZoneCount int
+	// MainAccountID 模拟的主账号ID，为空时默认为100000000001
+	MainAccountID string
+	// SubAccountID 模拟的子账号ID，为空时和主账号ID相同
+	SubAccountID string
+	Quota        Quota
+	Fault        Fault
+}
+
+// Quota defines the max resource count of each resource type in fake tcloud, 0 means unlimited.
+type Quota struct {
+	Vpc           uint
+	Subnet        uint
+	SecurityGroup uint
+	Cvm           uint
+	Disk          uint
+	Eip           uint
+	LoadBalancer  uint
+}
+
+// Fault defines the random fault injection options of fake tcloud.
+type Fault struct {
+	// ThrottleRate 接口调用返回限频错误的概率，取值范围[0, 1]
+	ThrottleRate float64
+	// AsyncFailRate 异步创建的资源（主机、硬盘、弹性IP、负载均衡）创建失败的概率，取值范围[0, 1]
+	AsyncFailRate float64
+}
+
+// Validate Fault.
+func (f Fault) Validate() error {
+	if f.ThrottleRate < 0 || f.ThrottleRate > 1 {
+		return fmt.Errorf("throttle rate %v should be in [0, 1]", f.ThrottleRate)
+	}
+
+	if f.AsyncFailRate < 0 || f.AsyncFailRate > 1 {
+		return fmt.Errorf("async fail rate %v should be in [0, 1]", f.AsyncFailRate)
+	}
+
+	return nil
+}
+
+// New fake tcloud.
+func New(opt Option) (*FakeTCloud, error) {
+	if err := opt.Fault.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(opt.Regions) == 0 {
+		opt.Regions = []string{defaultRegion}
+	}
+
+	if opt.ZoneCount <= 0 {
+		opt.ZoneCount = 3
+	}
+
+	if len(opt.MainAccountID) == 0 {
+		opt.MainAccountID = defaultAccountID
+	}
+
+	if len(opt.SubAccountID) == 0 {
+		opt.SubAccountID = opt.MainAccountID
+	}
+
+	return &FakeTCloud{
+		opt:         opt,
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		injected:    make(map[string][]error),
+		vpcs:        newStore[*vpcRecord](),
+		subnets:     newStore[*subnetRecord](),
+		routeTables: newStore[*routeTableRecord](),
+		sgs:         newStore[*sgRecord](),
+		cvms:        newStore[*cvmRecord](),
+		disks:       newStore[*diskRecord](),
+		snapshots:   newStore[*snapshotRecord](),
+		eips:        newStore[*eipRecord](),
+		lbs:         newStore[*lbRecord](),
+	}, nil
+}
+
+// FakeTCloud is an in-memory fake implementation of tencent cloud adaptor.
+type FakeTCloud struct {
+	opt Option
+
+	// lock protects all the fields below.
+	lock     sync.Mutex
+	rand     *rand.Rand
+	seq      uint64
+	injected map[string][]error
+
+	vpcs        *store[*vpcRecord]
+	subnets     *store[*subnetRecord]
+	routeTables *store[*routeTableRecord]
+	sgs         *store[*sgRecord]
+	cvms        *store[*cvmRecord]
+	disks       *store[*diskRecord]
+	snapshots   *store[*snapshotRecord]
+	eips        *store[*eipRecord]
+	lbs         *store[*lbRecord]
+}
+
+// SetFault reset the random fault injection options.
+func (f *FakeTCloud) SetFault(fault Fault) error {
+	if err := fault.Validate(); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.opt.Fault = fault
+	return nil
+}
+
+// InjectError make the next call of the method(e.g. CreateCvm) return the error, multiple injected errors of one
+// method are returned in order by the following calls.
+func (f *FakeTCloud) InjectError(method string, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.injected[method] = append(f.injected[method], err)
+}
+
+// fault returns the injected error of the method or a random throttling error, it should be called before the lock
+// is held.
+func (f *FakeTCloud) fault(kt *kit.Kit, method string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if errs := f.injected[method]; len(errs) != 0 {
+		f.injected[method] = errs[1:]
+		return errs[0]
+	}
+
+	if f.opt.Fault.ThrottleRate > 0 && f.rand.Float64() < f.opt.Fault.ThrottleRate {
+		return sdkError(kt, ErrCodeRequestLimitExceeded, "[fake] %s request limit exceeded", method)
+	}
+
+	return nil
+}
+
+// asyncFailed returns whether an async created resource should be failed, it should be called with lock held.
+func (f *FakeTCloud) asyncFailed() bool {
+	return f.opt.Fault.AsyncFailRate > 0 && f.rand.Float64() < f.opt.Fault.AsyncFailRate
+}
+
+// checkQuota returns limit exceeded error if the resource count reaches the quota after adding n resources.
+func checkQuota(kt *kit.Kit, resource string, quota uint, count int, n int) error {
+	if quota == 0 || count+n <= int(quota) {
+		return nil
+	}
+
+	return sdkError(kt, ErrCodeLimitExceeded, "[fake] %s quota %d exceeded, current: %d, require: %d", resource,
+		quota, count, n)
+}
+
+// newID generate a new unique cloud id with the prefix, it should be called with lock held.
+func (f *FakeTCloud) newID(prefix string) string {
+	f.seq++
+	return fmt.Sprintf("%s-%08x", prefix, f.seq)
+}
+
+// checkRegion returns not found error if the region is not supported.
+func (f *FakeTCloud) checkRegion(kt *kit.Kit, region string) error {
+	for _, one := range f.opt.Regions {
+		if one == region {
+			return nil
+		}
+	}
+
+	return sdkError(kt, ErrCodeNotFound, "[fake] region %s not found", region)
+}
+
+// checkZone returns not found error if the zone does not belong to the region.
+func (f *FakeTCloud) checkZone(kt *kit.Kit, region, zone string) error {
+	if err := f.checkRegion(kt, region); err != nil {
+		return err
+	}
+
+	for _, one := range f.zones(region) {
+		if one == zone {
+			return nil
+		}
+	}
+
+	return sdkError(kt, ErrCodeNotFound, "[fake] zone %s not found in region %s", zone, region)
+}
+
+func (f *FakeTCloud) zones(region string) []string {
+	zones := make([]string, 0, f.opt.ZoneCount)
+	for i := 1; i <= f.opt.ZoneCount; i++ {
+		zones = append(zones, fmt.Sprintf("%s-%d", region, i))
+	}
+	return zones
+}
+
+func sdkError(kt *kit.Kit, code string, format string, args ...interface{}) error {
+	return errors.NewTencentCloudSDKError(code, fmt.Sprintf(format, args...), kt.Rid)
+}
+
+func now() *string {
+	return converter.ValToPtr(time.Now().UTC().Format(timeLayout))
+}
+
+// store is an ordered in-memory resource store, it is not concurrent safe.
+type store[T any] struct {
+	ids   []string
+	items map[string]T
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{items: make(map[string]T)}
+}
+
+func (s *store[T]) add(id string, item T) {
+	if _, exists := s.items[id]; !exists {
+		s.ids = append(s.ids, id)
+	}
+	s.items[id] = item
+}
+
+func (s *store[T]) get(id string) (T, bool) {
+	item, exists := s.items[id]
+	return item, exists
+}
+
+func (s *store[T]) remove(id string) {
+	if _, exists := s.items[id]; !exists {
+		return
+	}
+
+	delete(s.items, id)
+	for i := range s.ids {
+		if s.ids[i] == id {
+			s.ids = append(s.ids[:i], s.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+func (s *store[T]) len() int {
+	return len(s.items)
+}
+
+// list returns items of the ids in order if ids is not empty, otherwise returns all items in creation order, only
+// items that match the filter are returned.
+func (s *store[T]) list(ids []string, match func(T) bool) []T {
+	if len(ids) == 0 {
+		ids = s.ids
+	}
+
+	result := make([]T, 0, len(ids))
+	for _, id := range ids {
+		item, exists := s.items[id]
+		if !exists || (match != nil && !match(item)) {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// paginate returns the page of items.
+func paginate[T any](items []T, page *core.TCloudPage) []T {
+	if page == nil {
+		return items
+	}
+
+	if page.Offset >= uint64(len(items)) {
+		return make([]T, 0)
+	}
+
+	end := uint64(len(items))
+	if page.Limit != 0 && page.Offset+page.Limit < end {
+		end = page.Offset + page.Limit
+	}
+
+	return items[page.Offset:end]
+}
@@ -0,0 +1,241 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package faketcloud
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/adaptor/types/disk"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/adaptor/types/eip"
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+)
+
+const (
+	testRegion = "ap-guangzhou"
+	testZone   = "ap-guangzhou-1"
+)
+
+func TestResourceLifecycle(t *testing.T) {
+	kt := kit.New()
+	fake, err := New(Option{Quota: Quota{Cvm: 2}})
+	if err != nil {
+		t.Fatalf("new fake tcloud failed, err: %v", err)
+	}
+
+	vpc, err := fake.CreateVpc(kt, &types.TCloudVpcCreateOption{AccountID: "test", Name: "vpc",
+		Extension: &types.TCloudVpcCreateExt{Region: testRegion, IPv4Cidr: "10.0.0.0/16"}})
+	if err != nil {
+		t.Fatalf("create vpc failed, err: %v", err)
+	}
+
+	subnetOpt := &adtysubnet.TCloudSubnetCreateOption{Name: "subnet", CloudVpcID: vpc.CloudID,
+		Extension: &adtysubnet.TCloudSubnetCreateExt{Region: testRegion, Zone: testZone, IPv4Cidr: "10.1.0.0/24"}}
+	_, err = fake.CreateSubnet(kt, subnetOpt)
+	assertErrCode(t, err, ErrCodeInvalidParameter)
+
+	subnetOpt.Extension.IPv4Cidr = "10.0.0.0/24"
+	subnet, err := fake.CreateSubnet(kt, subnetOpt)
+	if err != nil {
+		t.Fatalf("create subnet failed, err: %v", err)
+	}
+
+	subnetOpt.Extension.IPv4Cidr = "10.0.0.128/25"
+	_, err = fake.CreateSubnet(kt, subnetOpt)
+	assertErrCode(t, err, ErrCodeInvalidParameter)
+
+	sg, err := fake.CreateSecurityGroup(kt, &securitygroup.TCloudCreateOption{Region: testRegion, Name: "sg"})
+	if err != nil {
+		t.Fatalf("create security group failed, err: %v", err)
+	}
+
+	cvmOpt := &typecvm.TCloudCreateOption{Region: testRegion, Name: "cvm", Zone: testZone,
+		InstanceType: "S5.MEDIUM4", CloudImageID: "img-fakecentos", Password: "fake", RequiredCount: 3,
+		CloudSecurityGroupIDs: []string{*sg.SecurityGroupId}, CloudVpcID: vpc.CloudID, CloudSubnetID: subnet.CloudID,
+		InstanceChargeType: typecvm.PostpaidByHour, SystemDisk: &typecvm.TCloudSystemDisk{}}
+	_, err = fake.CreateCvm(kt, cvmOpt)
+	assertErrCode(t, err, ErrCodeLimitExceeded)
+
+	cvmOpt.RequiredCount = 1
+	result, err := fake.CreateCvm(kt, cvmOpt)
+	if err != nil || len(result.SuccessCloudIDs) != 1 {
+		t.Fatalf("create cvm failed, result: %+v, err: %v", result, err)
+	}
+	cvmID := result.SuccessCloudIDs[0]
+
+	cvms, err := fake.ListCvm(kt, &typecvm.TCloudListOption{Region: testRegion, CloudIDs: []string{cvmID}})
+	if err != nil || len(cvms) != 1 {
+		t.Fatalf("list cvm failed, cvms: %d, err: %v", len(cvms), err)
+	}
+
+	if ip := converter.PtrToVal(cvms[0].PrivateIpAddresses[0]); ip != "10.0.0.2" {
+		t.Errorf("cvm private ip %s is not the first available ip of the subnet", ip)
+	}
+
+	err = fake.DeleteSubnet(kt, &core.BaseRegionalDeleteOption{BaseDeleteOption: core.BaseDeleteOption{
+		ResourceID: subnet.CloudID}, Region: testRegion})
+	assertErrCode(t, err, ErrCodeResourceInUse)
+
+	diskResult, err := fake.CreateDisk(kt, &disk.TCloudDiskCreateOption{Region: testRegion, Zone: testZone,
+		DiskType: "CLOUD_PREMIUM", DiskSize: converter.ValToPtr(uint64(20)), DiskChargeType: "POSTPAID_BY_HOUR"})
+	if err != nil || len(diskResult.SuccessCloudIDs) != 1 {
+		t.Fatalf("create disk failed, result: %+v, err: %v", diskResult, err)
+	}
+	diskID := diskResult.SuccessCloudIDs[0]
+
+	snapshotID, err := fake.CreateSnapshot(kt, &typesnapshot.TCloudCreateOption{Region: testRegion,
+		CloudDiskID: diskID})
+	if err != nil {
+		t.Fatalf("create snapshot failed, err: %v", err)
+	}
+
+	// 从快照创建的云盘不能小于快照大小
+	snapDiskOpt := &disk.TCloudDiskCreateOption{Region: testRegion, Zone: testZone, DiskType: "CLOUD_PREMIUM",
+		DiskSize: converter.ValToPtr(uint64(10)), DiskChargeType: "POSTPAID_BY_HOUR", SnapshotID: &snapshotID}
+	_, err = fake.CreateDisk(kt, snapDiskOpt)
+	assertErrCode(t, err, ErrCodeInvalidParameter)
+
+	snapDiskOpt.DiskSize = nil
+	snapDiskResult, err := fake.CreateDisk(kt, snapDiskOpt)
+	if err != nil || len(snapDiskResult.SuccessCloudIDs) != 1 {
+		t.Fatalf("create disk from snapshot failed, result: %+v, err: %v", snapDiskResult, err)
+	}
+
+	err = fake.DeleteDisk(kt, &disk.TCloudDiskDeleteOption{Region: testRegion,
+		CloudIDs: snapDiskResult.SuccessCloudIDs})
+	if err != nil {
+		t.Fatalf("delete disk created from snapshot failed, err: %v", err)
+	}
+
+	err = fake.DeleteSnapshot(kt, &typesnapshot.TCloudDeleteOption{Region: testRegion, CloudIDs: []string{snapshotID}})
+	if err != nil {
+		t.Fatalf("delete snapshot failed, err: %v", err)
+	}
+
+	attachOpt := &disk.TCloudDiskAttachOption{Region: testRegion, CloudCvmID: cvmID, CloudDiskIDs: []string{diskID}}
+	if err = fake.AttachDisk(kt, attachOpt); err != nil {
+		t.Fatalf("attach disk failed, err: %v", err)
+	}
+	assertErrCode(t, fake.AttachDisk(kt, attachOpt), ErrCodeIncorrectState)
+
+	err = fake.DeleteDisk(kt, &disk.TCloudDiskDeleteOption{Region: testRegion, CloudIDs: []string{diskID}})
+	assertErrCode(t, err, ErrCodeIncorrectState)
+
+	eipResult, err := fake.CreateEip(kt, &eip.TCloudEipCreateOption{Region: testRegion, EipCount: 1,
+		ServiceProvider: "BGP", AddressType: "EIP"})
+	if err != nil || len(eipResult.SuccessCloudIDs) != 1 {
+		t.Fatalf("create eip failed, result: %+v, err: %v", eipResult, err)
+	}
+	eipID := eipResult.SuccessCloudIDs[0]
+
+	err = fake.AssociateEip(kt, &eip.TCloudEipAssociateOption{Region: testRegion, CloudEipID: eipID,
+		CloudCvmID: cvmID})
+	if err != nil {
+		t.Fatalf("associate eip failed, err: %v", err)
+	}
+
+	err = fake.DeleteCvm(kt, &typecvm.TCloudDeleteOption{Region: testRegion, CloudIDs: []string{cvmID}})
+	if err != nil {
+		t.Fatalf("delete cvm failed, err: %v", err)
+	}
+
+	// 主机删除后，系统盘随主机删除，数据盘被卸载，弹性IP被解绑
+	disks, err := fake.ListDisk(kt, &core.TCloudListOption{Region: testRegion,
+		Page: &core.TCloudPage{Limit: core.TCloudQueryLimit}})
+	if err != nil || len(disks) != 1 || converter.PtrToVal(disks[0].DiskState) != diskStateUnattached {
+		t.Fatalf("list disk after cvm deleted not as expected, disks: %d, err: %v", len(disks), err)
+	}
+
+	eips, err := fake.ListEip(kt, &eip.TCloudEipListOption{Region: testRegion})
+	if err != nil || len(eips.Details) != 1 || converter.PtrToVal(eips.Details[0].Status) != eipStatusUnbind {
+		t.Fatalf("list eip after cvm deleted not as expected, err: %v", err)
+	}
+
+	err = fake.DeleteVpc(kt, &core.BaseRegionalDeleteOption{BaseDeleteOption: core.BaseDeleteOption{
+		ResourceID: vpc.CloudID}, Region: testRegion})
+	assertErrCode(t, err, ErrCodeResourceInUse)
+
+	err = fake.DeleteSubnet(kt, &core.BaseRegionalDeleteOption{BaseDeleteOption: core.BaseDeleteOption{
+		ResourceID: subnet.CloudID}, Region: testRegion})
+	if err != nil {
+		t.Fatalf("delete subnet failed, err: %v", err)
+	}
+
+	err = fake.DeleteVpc(kt, &core.BaseRegionalDeleteOption{BaseDeleteOption: core.BaseDeleteOption{
+		ResourceID: vpc.CloudID}, Region: testRegion})
+	if err != nil {
+		t.Fatalf("delete vpc failed, err: %v", err)
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	kt := kit.New()
+	fake, err := New(Option{})
+	if err != nil {
+		t.Fatalf("new fake tcloud failed, err: %v", err)
+	}
+
+	injected := errors.NewTencentCloudSDKError("InternalError", "injected", kt.Rid)
+	fake.InjectError("CountVpc", injected)
+	if _, err = fake.CountVpc(kt, testRegion); err != injected {
+		t.Errorf("count vpc should return injected error, but got: %v", err)
+	}
+
+	if _, err = fake.CountVpc(kt, testRegion); err != nil {
+		t.Errorf("injected error should be returned only once, but got: %v", err)
+	}
+
+	if err = fake.SetFault(Fault{ThrottleRate: 1}); err != nil {
+		t.Fatalf("set fault failed, err: %v", err)
+	}
+	_, err = fake.CountVpc(kt, testRegion)
+	assertErrCode(t, err, ErrCodeRequestLimitExceeded)
+
+	if err = fake.SetFault(Fault{AsyncFailRate: 1}); err != nil {
+		t.Fatalf("set fault failed, err: %v", err)
+	}
+
+	result, err := fake.CreateEip(kt, &eip.TCloudEipCreateOption{Region: testRegion, EipCount: 2,
+		ServiceProvider: "BGP", AddressType: "EIP"})
+	if err != nil || len(result.SuccessCloudIDs) != 0 || len(result.FailedCloudIDs) != 2 {
+		t.Fatalf("create eip should be failed, result: %+v, err: %v", result, err)
+	}
+
+	if err = fake.SetFault(Fault{ThrottleRate: 2}); err == nil {
+		t.Errorf("invalid throttle rate should be rejected")
+	}
+}
+
+func assertErrCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	if err == nil || !strings.Contains(err.Error(), "Code="+code) {
+		t.Errorf("expect error code %s, but got: %v", code, err)
+	}
+}
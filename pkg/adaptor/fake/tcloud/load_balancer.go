@@ -0,0 +1,368 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package faketcloud
+
+import (
+	"errors"
+
+	"hcm/pkg/adaptor/poller"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+type lbRecord struct {
+	lb     typelb.TCloudLoadBalancer
+	region string
+	// subnet 内网负载均衡所在子网，公网负载均衡为空
+	subnet *subnetRecord
+	// listeners 负载均衡下的监听器，由 SetListeners 设置
+	listeners []typelb.TCloudListener
+	// targets 绑定到监听器上的后端服务，由 SetTargets 设置
+	targets []typelb.TCloudTarget
+}
+
+// CreateLoadBalancer create load balancers, internal load balancer allocates a vip from the subnet, open load
+// balancer is assigned a public vip. load balancer failed by async fault injection is returned as failed.
+func (f *FakeTCloud) CreateLoadBalancer(kt *kit.Kit, opt *typelb.TCloudCreateOption) (*poller.BaseDoneResult,
+	error) {
+
+	if opt == nil {
+		return nil, errors.New("create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "CreateLoadBalancer"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := f.checkRegion(kt, opt.Region); err != nil {
+		return nil, err
+	}
+
+	vpcID := converter.PtrToVal(opt.CloudVpcID)
+	if len(vpcID) != 0 {
+		vpc, exists := f.vpcs.get(vpcID)
+		if !exists || vpc.vpc.Region != opt.Region {
+			return nil, sdkError(kt, ErrCodeNotFound, "[fake] vpc %s not found in region %s", vpcID, opt.Region)
+		}
+	}
+
+	var subnet *subnetRecord
+	if opt.LoadBalancerType == typelb.TCloudInternalLoadBalancer {
+		subnetID := converter.PtrToVal(opt.CloudSubnetID)
+		one, exists := f.subnets.get(subnetID)
+		if !exists || one.subnet.Region != opt.Region || (len(vpcID) != 0 && one.subnet.CloudVpcID != vpcID) {
+			return nil, sdkError(kt, ErrCodeNotFound, "[fake] subnet %s not found in vpc %s", subnetID, vpcID)
+		}
+		subnet = one
+		vpcID = one.subnet.CloudVpcID
+	}
+
+	number := int(converter.PtrToVal(opt.Number))
+	if number == 0 {
+		number = 1
+	}
+
+	if err := checkQuota(kt, "load balancer", f.opt.Quota.LoadBalancer, f.lbs.len(), number); err != nil {
+		return nil, err
+	}
+
+	result := &poller.BaseDoneResult{
+		SuccessCloudIDs: make([]string, 0, number),
+		FailedCloudIDs:  make([]string, 0),
+	}
+	for i := 0; i < number; i++ {
+		id := f.newID("lb")
+		if f.asyncFailed() {
+			result.FailedCloudIDs = append(result.FailedCloudIDs, id)
+			result.FailedMessage = "[fake] load balancer create failed by fault injection"
+			continue
+		}
+
+		var vip string
+		if subnet != nil {
+			ip, ok := subnet.allocateIP(id)
+			if !ok {
+				return nil, sdkError(kt, ErrCodeLimitExceeded, "[fake] subnet %s has no available ip",
+					subnet.subnet.CloudID)
+			}
+			vip = ip
+		} else {
+			vip = f.newPublicIP()
+		}
+
+		name := converter.PtrToVal(opt.LoadBalancerName)
+		if len(name) == 0 {
+			name = id
+		}
+
+		record := &lbRecord{
+			lb: typelb.TCloudLoadBalancer{
+				LoadBalancerId:   converter.ValToPtr(id),
+				LoadBalancerName: converter.ValToPtr(name),
+				LoadBalancerType: converter.ValToPtr(opt.LoadBalancerType),
+				Forward:          converter.ValToPtr(uint64(1)),
+				LoadBalancerVips: []*string{converter.ValToPtr(vip)},
+				Status:           converter.ValToPtr(typelb.TCloudLoadBalancerNormal),
+				CreateTime:       now(),
+				VpcId:            converter.ValToPtr(vpcID),
+				SubnetId:         opt.CloudSubnetID,
+				AddressIPVersion: converter.ValToPtr("ipv4"),
+				ChargeType:       converter.ValToPtr("POSTPAID_BY_HOUR"),
+			},
+			region: opt.Region,
+			subnet: subnet,
+		}
+		if opt.MasterZone != nil {
+			record.lb.MasterZone = &typelb.TCloudZoneInfo{Zone: opt.MasterZone, ZoneRegion: &opt.Region}
+		}
+		f.lbs.add(id, record)
+		result.SuccessCloudIDs = append(result.SuccessCloudIDs, id)
+	}
+
+	return result, nil
+}
+
+// DeleteLoadBalancer delete load balancers and release the vips.
+func (f *FakeTCloud) DeleteLoadBalancer(kt *kit.Kit, opt *typelb.TCloudDeleteOption) error {
+	if opt == nil {
+		return errors.New("delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteLoadBalancer"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	lbs := make([]*lbRecord, 0, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		one, err := f.getLoadBalancer(kt, opt.Region, id)
+		if err != nil {
+			return err
+		}
+		lbs = append(lbs, one)
+	}
+
+	for _, one := range lbs {
+		if one.subnet != nil {
+			for _, vip := range one.lb.LoadBalancerVips {
+				delete(one.subnet.usedIPs, converter.PtrToVal(vip))
+			}
+		}
+		f.lbs.remove(one.lb.GetCloudID())
+	}
+
+	return nil
+}
+
+// ListLoadBalancer list load balancer.
+func (f *FakeTCloud) ListLoadBalancer(kt *kit.Kit, opt *typelb.TCloudListOption) ([]typelb.TCloudLoadBalancer,
+	error) {
+
+	if opt == nil {
+		return nil, errors.New("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListLoadBalancer"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	records := f.lbs.list(opt.CloudIDs, func(r *lbRecord) bool { return r.region == opt.Region })
+
+	details := make([]typelb.TCloudLoadBalancer, 0, len(records))
+	for _, one := range paginate(records, opt.Page) {
+		details = append(details, one.lb)
+	}
+
+	return details, nil
+}
+
+// ListListener list listeners of the load balancer.
+func (f *FakeTCloud) ListListener(kt *kit.Kit, opt *typelb.TCloudListenerListOption) ([]typelb.TCloudListener,
+	error) {
+
+	if err := f.checkListOption(kt, "ListListener", opt); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	lb, err := f.getLoadBalancer(kt, opt.Region, opt.CloudLoadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]struct{}, len(opt.CloudListenerIDs))
+	for _, id := range opt.CloudListenerIDs {
+		ids[id] = struct{}{}
+	}
+
+	listeners := make([]typelb.TCloudListener, 0, len(lb.listeners))
+	for _, one := range lb.listeners {
+		if _, exists := ids[one.GetCloudID()]; len(ids) != 0 && !exists {
+			continue
+		}
+		listeners = append(listeners, one)
+	}
+
+	return listeners, nil
+}
+
+// ListTarget list targets bound to the listeners of the load balancer.
+func (f *FakeTCloud) ListTarget(kt *kit.Kit, opt *typelb.TCloudListenerListOption) ([]typelb.TCloudTarget, error) {
+	if err := f.checkListOption(kt, "ListTarget", opt); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	lb, err := f.getLoadBalancer(kt, opt.Region, opt.CloudLoadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(make([]typelb.TCloudTarget, 0, len(lb.targets)), lb.targets...), nil
+}
+
+// SetListeners 设置负载均衡下的监听器，模拟在云上创建、修改和删除监听器，监听器ID为空时自动生成，返回监听器ID。
+// 被删除的监听器上绑定的后端服务一并解绑。
+func (f *FakeTCloud) SetListeners(kt *kit.Kit, region, lbID string, listeners []typelb.TCloudListener) ([]string,
+	error) {
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	lb, err := f.getLoadBalancer(kt, region, lbID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(listeners))
+	listenerIDs := make(map[string]struct{}, len(listeners))
+	for i := range listeners {
+		if len(listeners[i].GetCloudID()) == 0 {
+			listeners[i].ListenerId = converter.ValToPtr(f.newID("lbl"))
+		}
+		listeners[i].CloudLoadBalancerID = lbID
+		ids = append(ids, listeners[i].GetCloudID())
+		listenerIDs[listeners[i].GetCloudID()] = struct{}{}
+	}
+	lb.listeners = listeners
+
+	targets := make([]typelb.TCloudTarget, 0, len(lb.targets))
+	for _, one := range lb.targets {
+		if _, exists := listenerIDs[one.CloudListenerID]; exists {
+			targets = append(targets, one)
+		}
+	}
+	lb.targets = targets
+
+	return ids, nil
+}
+
+// SetTargets 设置负载均衡下绑定的后端服务，模拟在云上绑定、修改和解绑后端服务，后端服务需绑定到负载均衡下已有的监听器。
+func (f *FakeTCloud) SetTargets(kt *kit.Kit, region, lbID string, targets []typelb.TCloudTarget) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	lb, err := f.getLoadBalancer(kt, region, lbID)
+	if err != nil {
+		return err
+	}
+
+	listenerIDs := make(map[string]struct{}, len(lb.listeners))
+	for _, one := range lb.listeners {
+		listenerIDs[one.GetCloudID()] = struct{}{}
+	}
+
+	for i := range targets {
+		if _, exists := listenerIDs[targets[i].CloudListenerID]; !exists {
+			return sdkError(kt, ErrCodeNotFound, "[fake] listener %s not found in load balancer %s",
+				targets[i].CloudListenerID, lbID)
+		}
+		targets[i].CloudLoadBalancerID = lbID
+	}
+	lb.targets = targets
+
+	return nil
+}
+
+// ListTargetGroup 模拟云不支持创建目标组，返回空列表。
+func (f *FakeTCloud) ListTargetGroup(kt *kit.Kit, opt *typelb.TCloudListOption) ([]typelb.TCloudTargetGroup,
+	error) {
+
+	if opt == nil {
+		return nil, errors.New("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListTargetGroup"); err != nil {
+		return nil, err
+	}
+
+	return make([]typelb.TCloudTargetGroup, 0), nil
+}
+
+func (f *FakeTCloud) checkListOption(kt *kit.Kit, method string, opt *typelb.TCloudListenerListOption) error {
+	if opt == nil {
+		return errors.New("list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	return f.fault(kt, method)
+}
+
+// getLoadBalancer get load balancer record, it should be called with lock held.
+func (f *FakeTCloud) getLoadBalancer(kt *kit.Kit, region, id string) (*lbRecord, error) {
+	one, exists := f.lbs.get(id)
+	if !exists || one.region != region {
+		return nil, sdkError(kt, ErrCodeNotFound, "[fake] load balancer %s not found in region %s", id, region)
+	}
+
+	return one, nil
+}
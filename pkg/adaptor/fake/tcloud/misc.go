@@ -0,0 +1,291 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package faketcloud
+
+import (
+	"strconv"
+
+	"hcm/pkg/adaptor/types/account"
+	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/adaptor/types/image"
+	instancetype "hcm/pkg/adaptor/types/instance-type"
+	"hcm/pkg/adaptor/types/region"
+	"hcm/pkg/adaptor/types/zone"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	billing "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/billing/v20180709"
+	cam "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cam/v20190116"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// fakeImages 模拟云支持的公共镜像
+var fakeImages = []image.TCloudImage{
+	{CloudID: "img-fakecentos", Name: "CentOS 7.9 64位", Architecture: "x86_64", Platform: "CentOS",
+		State: "NORMAL", Type: "public", ImageSize: 20, ImageSource: "OFFICIAL", OsType: enumor.LinuxOsType},
+	{CloudID: "img-fakeubuntu", Name: "Ubuntu Server 22.04 LTS 64位", Architecture: "x86_64", Platform: "Ubuntu",
+		State: "NORMAL", Type: "public", ImageSize: 20, ImageSource: "OFFICIAL", OsType: enumor.LinuxOsType},
+	{CloudID: "img-fakewindows", Name: "Windows Server 2019 数据中心版 64位中文版", Architecture: "x86_64",
+		Platform: "Windows", State: "NORMAL", Type: "public", ImageSize: 50, ImageSource: "OFFICIAL",
+		OsType: enumor.WindowsOsType},
+}
+
+// fakeInstanceTypes 模拟云支持的机型
+var fakeInstanceTypes = []instancetype.TCloudInstanceType{
+	{InstanceType: "S5.SMALL1", InstanceFamily: "S5", CPU: 1, Memory: 1, TypeName: "标准型S5"},
+	{InstanceType: "S5.MEDIUM4", InstanceFamily: "S5", CPU: 2, Memory: 4, TypeName: "标准型S5"},
+	{InstanceType: "S5.LARGE8", InstanceFamily: "S5", CPU: 4, Memory: 8, TypeName: "标准型S5"},
+}
+
+// ListAccount list sub account, fake tcloud has only one sub account.
+func (f *FakeTCloud) ListAccount(kt *kit.Kit) ([]account.TCloudAccount, error) {
+	if err := f.fault(kt, "ListAccount"); err != nil {
+		return nil, err
+	}
+
+	uin, err := strconv.ParseUint(f.opt.SubAccountID, 10, 64)
+	if err != nil {
+		return nil, sdkError(kt, ErrCodeInvalidParameter, "[fake] invalid sub account id %s", f.opt.SubAccountID)
+	}
+
+	return []account.TCloudAccount{{
+		Uin:          converter.ValToPtr(uin),
+		Name:         converter.ValToPtr("fake"),
+		Uid:          converter.ValToPtr(uin),
+		ConsoleLogin: converter.ValToPtr(uint64(0)),
+	}}, nil
+}
+
+// CountAccount count sub account.
+func (f *FakeTCloud) CountAccount(kt *kit.Kit) (int32, error) {
+	accounts, err := f.ListAccount(kt)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(len(accounts)), nil
+}
+
+// GetAccountZoneQuota get cvm quota of the zone, the total quota is the cvm quota of the fake tcloud.
+func (f *FakeTCloud) GetAccountZoneQuota(kt *kit.Kit, opt *account.GetTCloudAccountZoneQuotaOption) (
+	*account.TCloudAccountQuota, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "GetAccountZoneQuota"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := f.checkZone(kt, opt.Region, opt.Zone); err != nil {
+		return nil, err
+	}
+
+	total := uint64(f.opt.Quota.Cvm)
+	if total == 0 {
+		total = unlimitedQuota
+	}
+
+	used := uint64(len(f.cvms.list(nil, func(r *cvmRecord) bool {
+		return converter.PtrToVal(r.cvm.Placement.Zone) == opt.Zone
+	})))
+	remaining := uint64(0)
+	if total > uint64(f.cvms.len()) {
+		remaining = total - uint64(f.cvms.len())
+	}
+
+	return &account.TCloudAccountQuota{
+		PostPaidQuotaSet: &account.TCloudPostPaidQuota{
+			UsedQuota:      converter.ValToPtr(used),
+			RemainingQuota: converter.ValToPtr(remaining),
+			TotalQuota:     converter.ValToPtr(total),
+		},
+	}, nil
+}
+
+// GetAccountInfoBySecret get account info of the secret.
+func (f *FakeTCloud) GetAccountInfoBySecret(kt *kit.Kit) (*cloud.TCloudInfoBySecret, error) {
+	if err := f.fault(kt, "GetAccountInfoBySecret"); err != nil {
+		return nil, err
+	}
+
+	return &cloud.TCloudInfoBySecret{
+		CloudMainAccountID: f.opt.MainAccountID,
+		CloudSubAccountID:  f.opt.SubAccountID,
+	}, nil
+}
+
+// ListPoliciesGrantingServiceAccess list policies granting service access, fake tcloud does not check permission,
+// so no policy is returned.
+func (f *FakeTCloud) ListPoliciesGrantingServiceAccess(kt *kit.Kit, opt *account.TCloudListPolicyOption) (
+	[]*cam.ListGrantServiceAccessNode, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListPoliciesGrantingServiceAccess"); err != nil {
+		return nil, err
+	}
+
+	return make([]*cam.ListGrantServiceAccessNode, 0), nil
+}
+
+// GetBillList get bill list, fake tcloud has no bill.
+func (f *FakeTCloud) GetBillList(kt *kit.Kit, opt *typesBill.TCloudBillListOption) (
+	*billing.DescribeBillDetailResponseParams, error) {
+
+	if err := f.fault(kt, "GetBillList"); err != nil {
+		return nil, err
+	}
+
+	return &billing.DescribeBillDetailResponseParams{
+		DetailSet: make([]*billing.BillDetail, 0),
+		Total:     converter.ValToPtr(uint64(0)),
+		RequestId: converter.ValToPtr(kt.Rid),
+	}, nil
+}
+
+// ListRegion list region.
+func (f *FakeTCloud) ListRegion(kt *kit.Kit) (*region.TCloudRegionListResult, error) {
+	if err := f.fault(kt, "ListRegion"); err != nil {
+		return nil, err
+	}
+
+	details := make([]region.TCloudRegion, 0, len(f.opt.Regions))
+	for _, one := range f.opt.Regions {
+		details = append(details, region.TCloudRegion{RegionID: one, RegionName: one, RegionState: "AVAILABLE"})
+	}
+
+	return &region.TCloudRegionListResult{Count: converter.ValToPtr(uint64(len(details))), Details: details}, nil
+}
+
+// ListZone list zone of the region.
+func (f *FakeTCloud) ListZone(kt *kit.Kit, opt *zone.TCloudZoneListOption) ([]zone.TCloudZone, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListZone"); err != nil {
+		return nil, err
+	}
+
+	if err := f.checkRegion(kt, opt.Region); err != nil {
+		return nil, err
+	}
+
+	zones := make([]zone.TCloudZone, 0, f.opt.ZoneCount)
+	for index, one := range f.zones(opt.Region) {
+		zones = append(zones, zone.TCloudZone{ZoneInfo: &cvm.ZoneInfo{
+			Zone:      converter.ValToPtr(one),
+			ZoneName:  converter.ValToPtr(one),
+			ZoneId:    converter.ValToPtr(strconv.Itoa(100000 + index + 1)),
+			ZoneState: converter.ValToPtr("AVAILABLE"),
+		}})
+	}
+
+	return zones, nil
+}
+
+// ListImage list public image.
+func (f *FakeTCloud) ListImage(kt *kit.Kit, opt *image.TCloudImageListOption) (*image.TCloudImageListResult,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListImage"); err != nil {
+		return nil, err
+	}
+
+	if err := f.checkRegion(kt, opt.Region); err != nil {
+		return nil, err
+	}
+
+	images := make([]image.TCloudImage, 0, len(fakeImages))
+	for _, one := range fakeImages {
+		if len(opt.CloudIDs) == 0 || slice.IsItemInSlice(opt.CloudIDs, one.CloudID) {
+			images = append(images, one)
+		}
+	}
+
+	return &image.TCloudImageListResult{Count: converter.ValToPtr(uint64(len(images))),
+		Details: paginate(images, opt.Page)}, nil
+}
+
+// ListInstanceType list instance type of the zone.
+func (f *FakeTCloud) ListInstanceType(kt *kit.Kit, opt *instancetype.TCloudInstanceTypeListOption) (
+	[]instancetype.TCloudInstanceType, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListInstanceType"); err != nil {
+		return nil, err
+	}
+
+	if err := f.checkZone(kt, opt.Region, opt.Zone); err != nil {
+		return nil, err
+	}
+
+	types := make([]instancetype.TCloudInstanceType, 0, len(fakeInstanceTypes))
+	for _, one := range fakeInstanceTypes {
+		one.Status = "SELL"
+		one.Price = cvm.ItemPrice{
+			UnitPrice:     converter.ValToPtr(cvmHourPrice),
+			ChargeUnit:    converter.ValToPtr("HOUR"),
+			OriginalPrice: converter.ValToPtr(cvmHourPrice),
+		}
+		types = append(types, one)
+	}
+
+	return types, nil
+}
+
+// instanceTypeSpec returns cpu and memory of the instance type, unknown instance type is treated as 1C1G.
+func instanceTypeSpec(instanceType string) (int64, int64) {
+	for _, one := range fakeInstanceTypes {
+		if one.InstanceType == instanceType {
+			return one.CPU, one.Memory
+		}
+	}
+
+	return 1, 1
+}
+
+// imageName returns the name of the image, unknown image is treated as custom image.
+func imageName(imageID string) string {
+	for _, one := range fakeImages {
+		if one.CloudID == imageID {
+			return one.Name
+		}
+	}
+
+	return "custom image " + imageID
+}
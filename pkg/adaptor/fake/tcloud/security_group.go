@@ -0,0 +1,495 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package faketcloud
+
+import (
+	"strconv"
+
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+)
+
+type sgRecord struct {
+	sg     vpc.SecurityGroup
+	region string
+	// version 安全组规则版本号，每次规则变更后加一，和腾讯云一样，变更规则时需要传入当前版本号
+	version int
+	egress  []*vpc.SecurityGroupPolicy
+	ingress []*vpc.SecurityGroupPolicy
+}
+
+// CreateSecurityGroup create security group.
+func (f *FakeTCloud) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.TCloudCreateOption) (*vpc.SecurityGroup,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "CreateSecurityGroup"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := f.checkRegion(kt, opt.Region); err != nil {
+		return nil, err
+	}
+
+	if err := checkQuota(kt, "security group", f.opt.Quota.SecurityGroup, f.sgs.len(), 1); err != nil {
+		return nil, err
+	}
+
+	sg := vpc.SecurityGroup{
+		SecurityGroupId:   converter.ValToPtr(f.newID("sg")),
+		SecurityGroupName: converter.ValToPtr(opt.Name),
+		SecurityGroupDesc: converter.ValToPtr(converter.PtrToVal(opt.Description)),
+		ProjectId:         converter.ValToPtr("0"),
+		IsDefault:         converter.ValToPtr(false),
+		CreatedTime:       now(),
+		UpdateTime:        now(),
+	}
+	f.sgs.add(*sg.SecurityGroupId, &sgRecord{sg: sg, region: opt.Region})
+
+	return &sg, nil
+}
+
+// DeleteSecurityGroup delete security group, security group associated with cvm can not be deleted.
+func (f *FakeTCloud) DeleteSecurityGroup(kt *kit.Kit, opt *securitygroup.TCloudDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteSecurityGroup"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, err := f.getSG(kt, opt.Region, opt.CloudID); err != nil {
+		return err
+	}
+
+	cvms := f.cvms.list(nil, func(r *cvmRecord) bool {
+		return slice.IsItemInSlice(converter.PtrToSlice(r.cvm.SecurityGroupIds), opt.CloudID)
+	})
+	if len(cvms) != 0 {
+		return sdkError(kt, ErrCodeResourceInUse, "[fake] security group %s is associated with %d cvms",
+			opt.CloudID, len(cvms))
+	}
+	f.sgs.remove(opt.CloudID)
+
+	return nil
+}
+
+// UpdateSecurityGroup update security group.
+func (f *FakeTCloud) UpdateSecurityGroup(kt *kit.Kit, opt *securitygroup.TCloudUpdateOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "UpdateSecurityGroup"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sg, err := f.getSG(kt, opt.Region, opt.CloudID)
+	if err != nil {
+		return err
+	}
+
+	if len(opt.Name) != 0 {
+		sg.sg.SecurityGroupName = converter.ValToPtr(opt.Name)
+	}
+
+	if opt.Description != nil {
+		sg.sg.SecurityGroupDesc = converter.ValToPtr(*opt.Description)
+	}
+	sg.sg.UpdateTime = now()
+
+	return nil
+}
+
+// ListSecurityGroupNew list security group.
+func (f *FakeTCloud) ListSecurityGroupNew(kt *kit.Kit, opt *securitygroup.TCloudListOption) ([]securitygroup.TCloudSG,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListSecurityGroupNew"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	records := f.sgs.list(opt.CloudIDs, func(r *sgRecord) bool { return r.region == opt.Region })
+	result := make([]securitygroup.TCloudSG, 0, len(records))
+	for _, one := range paginate(records, opt.Page) {
+		sg := one.sg
+		result = append(result, securitygroup.TCloudSG{SecurityGroup: &sg})
+	}
+
+	return result, nil
+}
+
+// CountSecurityGroup count security group of the region.
+func (f *FakeTCloud) CountSecurityGroup(kt *kit.Kit, region string) (int32, error) {
+	if err := f.fault(kt, "CountSecurityGroup"); err != nil {
+		return 0, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return int32(len(f.sgs.list(nil, func(r *sgRecord) bool { return r.region == region }))), nil
+}
+
+// SecurityGroupCvmAssociate associate security group with cvm.
+func (f *FakeTCloud) SecurityGroupCvmAssociate(kt *kit.Kit, opt *securitygroup.TCloudAssociateCvmOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "SecurityGroupCvmAssociate"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, err := f.getSG(kt, opt.Region, opt.CloudSecurityGroupID); err != nil {
+		return err
+	}
+
+	cvm, err := f.getCvm(kt, opt.Region, opt.CloudCvmID)
+	if err != nil {
+		return err
+	}
+
+	sgIDs := converter.PtrToSlice(cvm.cvm.SecurityGroupIds)
+	if slice.IsItemInSlice(sgIDs, opt.CloudSecurityGroupID) {
+		return sdkError(kt, ErrCodeIncorrectState, "[fake] security group %s is already associated with cvm %s",
+			opt.CloudSecurityGroupID, opt.CloudCvmID)
+	}
+	cvm.cvm.SecurityGroupIds = converter.SliceToPtr(append(sgIDs, opt.CloudSecurityGroupID))
+
+	return nil
+}
+
+// SecurityGroupCvmDisassociate disassociate security group with cvm, the last security group of cvm can not be
+// disassociated.
+func (f *FakeTCloud) SecurityGroupCvmDisassociate(kt *kit.Kit, opt *securitygroup.TCloudAssociateCvmOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "SecurityGroupCvmDisassociate"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	cvm, err := f.getCvm(kt, opt.Region, opt.CloudCvmID)
+	if err != nil {
+		return err
+	}
+
+	sgIDs := converter.PtrToSlice(cvm.cvm.SecurityGroupIds)
+	if !slice.IsItemInSlice(sgIDs, opt.CloudSecurityGroupID) {
+		return sdkError(kt, ErrCodeIncorrectState, "[fake] security group %s is not associated with cvm %s",
+			opt.CloudSecurityGroupID, opt.CloudCvmID)
+	}
+
+	if len(sgIDs) == 1 {
+		return sdkError(kt, ErrCodeIncorrectState, "[fake] cvm %s should have at least one security group",
+			opt.CloudCvmID)
+	}
+
+	cvm.cvm.SecurityGroupIds = converter.SliceToPtr(slice.Remove(sgIDs, opt.CloudSecurityGroupID))
+
+	return nil
+}
+
+// CreateSecurityGroupRule append security group rules.
+func (f *FakeTCloud) CreateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudCreateOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "CreateSecurityGroupRule"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sg, err := f.getSG(kt, opt.Region, opt.CloudSecurityGroupID)
+	if err != nil {
+		return err
+	}
+
+	egress, err := f.convSGRules(kt, opt.EgressRuleSet)
+	if err != nil {
+		return err
+	}
+
+	ingress, err := f.convSGRules(kt, opt.IngressRuleSet)
+	if err != nil {
+		return err
+	}
+
+	sg.egress = reindexPolicies(append(sg.egress, egress...))
+	sg.ingress = reindexPolicies(append(sg.ingress, ingress...))
+	sg.version++
+
+	return nil
+}
+
+// DeleteSecurityGroupRule delete security group rules by policy index.
+func (f *FakeTCloud) DeleteSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteSecurityGroupRule"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sg, err := f.getSGWithVersion(kt, opt.Region, opt.CloudSecurityGroupID, opt.Version)
+	if err != nil {
+		return err
+	}
+
+	egress, err := deletePolicies(kt, sg.egress, opt.EgressRuleIndexes)
+	if err != nil {
+		return err
+	}
+
+	ingress, err := deletePolicies(kt, sg.ingress, opt.IngressRuleIndexes)
+	if err != nil {
+		return err
+	}
+
+	sg.egress, sg.ingress = egress, ingress
+	sg.version++
+
+	return nil
+}
+
+// UpdateSecurityGroupRule replace security group rules by policy index.
+func (f *FakeTCloud) UpdateSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudUpdateOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "UpdateSecurityGroupRule"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sg, err := f.getSGWithVersion(kt, opt.Region, opt.CloudSecurityGroupID, opt.Version)
+	if err != nil {
+		return err
+	}
+
+	// 先全部校验，再统一替换，避免部分替换成功
+	specs := []struct {
+		policies []*vpc.SecurityGroupPolicy
+		rules    []securitygrouprule.TCloudUpdateSpec
+	}{{sg.egress, opt.EgressRuleSet}, {sg.ingress, opt.IngressRuleSet}}
+	for _, spec := range specs {
+		for _, rule := range spec.rules {
+			if rule.CloudPolicyIndex < 0 || rule.CloudPolicyIndex >= int64(len(spec.policies)) {
+				return sdkError(kt, ErrCodeNotFound, "[fake] security group policy index %d not found",
+					rule.CloudPolicyIndex)
+			}
+
+			if err := f.checkTargetSG(kt, rule.CloudTargetSecurityGroupID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, spec := range specs {
+		for _, rule := range spec.rules {
+			spec.policies[rule.CloudPolicyIndex] = &vpc.SecurityGroupPolicy{
+				PolicyIndex:       converter.ValToPtr(rule.CloudPolicyIndex),
+				Protocol:          rule.Protocol,
+				Port:              rule.Port,
+				CidrBlock:         rule.IPv4Cidr,
+				Ipv6CidrBlock:     rule.IPv6Cidr,
+				SecurityGroupId:   rule.CloudTargetSecurityGroupID,
+				Action:            converter.ValToPtr(rule.Action),
+				PolicyDescription: rule.Description,
+				ModifyTime:        now(),
+			}
+		}
+	}
+	sg.version++
+
+	return nil
+}
+
+// ListSecurityGroupRule list security group rules.
+func (f *FakeTCloud) ListSecurityGroupRule(kt *kit.Kit, opt *securitygrouprule.TCloudListOption) (
+	*vpc.SecurityGroupPolicySet, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListSecurityGroupRule"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	sg, err := f.getSG(kt, opt.Region, opt.CloudSecurityGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vpc.SecurityGroupPolicySet{
+		Version: converter.ValToPtr(strconv.Itoa(sg.version)),
+		Egress:  copyPolicies(sg.egress),
+		Ingress: copyPolicies(sg.ingress),
+	}, nil
+}
+
+// getSG get security group record, it should be called with lock held.
+func (f *FakeTCloud) getSG(kt *kit.Kit, region, id string) (*sgRecord, error) {
+	sg, exists := f.sgs.get(id)
+	if !exists || sg.region != region {
+		return nil, sdkError(kt, ErrCodeNotFound, "[fake] security group %s not found in region %s", id, region)
+	}
+
+	return sg, nil
+}
+
+// getSGWithVersion get security group record and check the rule version, it should be called with lock held.
+func (f *FakeTCloud) getSGWithVersion(kt *kit.Kit, region, id, version string) (*sgRecord, error) {
+	sg, err := f.getSG(kt, region, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != strconv.Itoa(sg.version) {
+		return nil, sdkError(kt, ErrCodeIncorrectState, "[fake] security group %s rule version %s mismatch, "+
+			"current: %d", id, version, sg.version)
+	}
+
+	return sg, nil
+}
+
+// checkTargetSG check the target security group of the rule exists, it should be called with lock held.
+func (f *FakeTCloud) checkTargetSG(kt *kit.Kit, id *string) error {
+	if id == nil {
+		return nil
+	}
+
+	if _, exists := f.sgs.get(*id); !exists {
+		return sdkError(kt, ErrCodeNotFound, "[fake] target security group %s not found", *id)
+	}
+
+	return nil
+}
+
+// convSGRules convert security group rules to policies, it should be called with lock held.
+func (f *FakeTCloud) convSGRules(kt *kit.Kit, rules []securitygrouprule.TCloud) ([]*vpc.SecurityGroupPolicy,
+	error) {
+
+	policies := make([]*vpc.SecurityGroupPolicy, 0, len(rules))
+	for _, rule := range rules {
+		if err := f.checkTargetSG(kt, rule.CloudTargetSecurityGroupID); err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, &vpc.SecurityGroupPolicy{
+			Protocol:          rule.Protocol,
+			Port:              rule.Port,
+			CidrBlock:         rule.IPv4Cidr,
+			Ipv6CidrBlock:     rule.IPv6Cidr,
+			SecurityGroupId:   rule.CloudTargetSecurityGroupID,
+			Action:            converter.ValToPtr(rule.Action),
+			PolicyDescription: rule.Description,
+			ModifyTime:        now(),
+		})
+	}
+
+	return policies, nil
+}
+
+// deletePolicies delete the policies of the indexes and reindex the left policies.
+func deletePolicies(kt *kit.Kit, policies []*vpc.SecurityGroupPolicy, indexes []int64) ([]*vpc.SecurityGroupPolicy,
+	error) {
+
+	deleted := make(map[int64]struct{}, len(indexes))
+	for _, index := range indexes {
+		if index < 0 || index >= int64(len(policies)) {
+			return nil, sdkError(kt, ErrCodeNotFound, "[fake] security group policy index %d not found", index)
+		}
+		deleted[index] = struct{}{}
+	}
+
+	left := make([]*vpc.SecurityGroupPolicy, 0, len(policies))
+	for index, policy := range policies {
+		if _, exists := deleted[int64(index)]; !exists {
+			left = append(left, policy)
+		}
+	}
+
+	return reindexPolicies(left), nil
+}
+
+// reindexPolicies reset the policy index to its position, just like tencent cloud does.
+func reindexPolicies(policies []*vpc.SecurityGroupPolicy) []*vpc.SecurityGroupPolicy {
+	for index := range policies {
+		policies[index].PolicyIndex = converter.ValToPtr(int64(index))
+	}
+	return policies
+}
+
+func copyPolicies(policies []*vpc.SecurityGroupPolicy) []*vpc.SecurityGroupPolicy {
+	result := make([]*vpc.SecurityGroupPolicy, 0, len(policies))
+	for _, policy := range policies {
+		one := *policy
+		result = append(result, &one)
+	}
+	return result
+}
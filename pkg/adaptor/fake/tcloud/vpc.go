@@ -0,0 +1,504 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package faketcloud
+
+import (
+	"encoding/binary"
+	"net"
+
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	routetable "hcm/pkg/adaptor/types/route-table"
+	adtysubnet "hcm/pkg/adaptor/types/subnet"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/cidr"
+	"hcm/pkg/tools/converter"
+)
+
+type vpcRecord struct {
+	vpc types.TCloudVpc
+}
+
+type routeTableRecord struct {
+	table routetable.TCloudRouteTable
+}
+
+type subnetRecord struct {
+	subnet adtysubnet.TCloudSubnet
+	// usedIPs 子网中已经分配的私有IP，value为使用该IP的主机
+	usedIPs map[string]string
+}
+
+// CreateVpc create vpc with a main route table.
+func (f *FakeTCloud) CreateVpc(kt *kit.Kit, opt *types.TCloudVpcCreateOption) (*types.TCloudVpc, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "CreateVpc"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := f.checkRegion(kt, opt.Extension.Region); err != nil {
+		return nil, err
+	}
+
+	if err := checkQuota(kt, "vpc", f.opt.Quota.Vpc, f.vpcs.len(), 1); err != nil {
+		return nil, err
+	}
+
+	vpc := types.TCloudVpc{
+		CloudID: f.newID("vpc"),
+		Name:    opt.Name,
+		Region:  opt.Extension.Region,
+		Memo:    opt.Memo,
+		Extension: &cloud.TCloudVpcExtension{
+			Cidr: []cloud.TCloudCidr{{Type: enumor.Ipv4, Cidr: opt.Extension.IPv4Cidr,
+				Category: enumor.MasterTCloudCidr}},
+			DnsServerSet: []string{"183.60.83.19", "183.60.82.98"},
+		},
+	}
+	f.vpcs.add(vpc.CloudID, &vpcRecord{vpc: vpc})
+
+	// 和腾讯云一样，创建vpc时同时创建默认路由表
+	table := routetable.TCloudRouteTable{
+		CloudID:    f.newID("rtb"),
+		Name:       "default",
+		CloudVpcID: vpc.CloudID,
+		Region:     vpc.Region,
+		Extension:  &routetable.TCloudRouteTableExtension{Main: true},
+	}
+	f.routeTables.add(table.CloudID, &routeTableRecord{table: table})
+
+	return &vpc, nil
+}
+
+// UpdateVpc update vpc, only memo is supported and it is not synced to cloud, so do nothing.
+func (f *FakeTCloud) UpdateVpc(kt *kit.Kit, opt *types.TCloudVpcUpdateOption) error {
+	return f.fault(kt, "UpdateVpc")
+}
+
+// DeleteVpc delete vpc, vpc with subnets can not be deleted.
+func (f *FakeTCloud) DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteVpc"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, exists := f.vpcs.get(opt.ResourceID); !exists {
+		return sdkError(kt, ErrCodeNotFound, "[fake] vpc %s not found", opt.ResourceID)
+	}
+
+	subnets := f.subnets.list(nil, func(r *subnetRecord) bool { return r.subnet.CloudVpcID == opt.ResourceID })
+	if len(subnets) != 0 {
+		return sdkError(kt, ErrCodeResourceInUse, "[fake] vpc %s still has %d subnets", opt.ResourceID,
+			len(subnets))
+	}
+
+	tables := f.routeTables.list(nil, func(r *routeTableRecord) bool { return r.table.CloudVpcID == opt.ResourceID })
+	for _, one := range tables {
+		f.routeTables.remove(one.table.CloudID)
+	}
+	f.vpcs.remove(opt.ResourceID)
+
+	return nil
+}
+
+// ListVpc list vpc.
+func (f *FakeTCloud) ListVpc(kt *kit.Kit, opt *core.TCloudListOption) (*types.TCloudVpcListResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListVpc"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	records := f.vpcs.list(opt.CloudIDs, func(r *vpcRecord) bool { return r.vpc.Region == opt.Region })
+	details := make([]types.TCloudVpc, 0, len(records))
+	for _, one := range paginate(records, opt.Page) {
+		details = append(details, one.vpc)
+	}
+
+	return &types.TCloudVpcListResult{Count: converter.ValToPtr(uint64(len(records))), Details: details}, nil
+}
+
+// CountVpc count vpc of the region.
+func (f *FakeTCloud) CountVpc(kt *kit.Kit, region string) (int32, error) {
+	if err := f.fault(kt, "CountVpc"); err != nil {
+		return 0, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return int32(len(f.vpcs.list(nil, func(r *vpcRecord) bool { return r.vpc.Region == region }))), nil
+}
+
+// CreateSubnet create subnet.
+func (f *FakeTCloud) CreateSubnet(kt *kit.Kit, opt *adtysubnet.TCloudSubnetCreateOption) (*adtysubnet.TCloudSubnet,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "CreateSubnet"); err != nil {
+		return nil, err
+	}
+
+	subnets, err := f.createSubnets(kt, &adtysubnet.TCloudSubnetsCreateOption{
+		AccountID:  "fake",
+		Region:     opt.Extension.Region,
+		CloudVpcID: opt.CloudVpcID,
+		Subnets: []adtysubnet.TCloudOneSubnetCreateOpt{{
+			IPv4Cidr: opt.Extension.IPv4Cidr,
+			Name:     opt.Name,
+			Zone:     opt.Extension.Zone,
+			Memo:     opt.Memo,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &subnets[0], nil
+}
+
+// CreateSubnets create subnets, the subnet cidr should be contained by vpc cidr and should not overlap with other
+// subnets of the vpc.
+func (f *FakeTCloud) CreateSubnets(kt *kit.Kit, opt *adtysubnet.TCloudSubnetsCreateOption) ([]adtysubnet.TCloudSubnet,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "CreateSubnets"); err != nil {
+		return nil, err
+	}
+
+	return f.createSubnets(kt, opt)
+}
+
+func (f *FakeTCloud) createSubnets(kt *kit.Kit, opt *adtysubnet.TCloudSubnetsCreateOption) ([]adtysubnet.TCloudSubnet,
+	error) {
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	vpc, exists := f.vpcs.get(opt.CloudVpcID)
+	if !exists || vpc.vpc.Region != opt.Region {
+		return nil, sdkError(kt, ErrCodeNotFound, "[fake] vpc %s not found in region %s", opt.CloudVpcID, opt.Region)
+	}
+
+	if err := checkQuota(kt, "subnet", f.opt.Quota.Subnet, f.subnets.len(), len(opt.Subnets)); err != nil {
+		return nil, err
+	}
+
+	mainTable := f.routeTables.list(nil, func(r *routeTableRecord) bool {
+		return r.table.CloudVpcID == opt.CloudVpcID && r.table.Extension.Main
+	})
+
+	// 先校验全部子网，再统一创建，保证批量创建的原子性
+	usedCidrs := make([]string, 0)
+	siblings := f.subnets.list(nil, func(r *subnetRecord) bool { return r.subnet.CloudVpcID == opt.CloudVpcID })
+	for _, one := range siblings {
+		usedCidrs = append(usedCidrs, one.subnet.Ipv4Cidr...)
+	}
+
+	for _, one := range opt.Subnets {
+		if err := f.checkZone(kt, opt.Region, one.Zone); err != nil {
+			return nil, err
+		}
+
+		if err := cidr.IsSubnetContained(vpc.vpc.Extension.Cidr[0].Cidr, one.IPv4Cidr); err != nil {
+			return nil, sdkError(kt, ErrCodeInvalidParameter, "[fake] invalid subnet cidr, err: %v", err)
+		}
+
+		for _, used := range usedCidrs {
+			if cidrOverlapped(used, one.IPv4Cidr) {
+				return nil, sdkError(kt, ErrCodeInvalidParameter, "[fake] subnet cidr %s conflicts with %s",
+					one.IPv4Cidr, used)
+			}
+		}
+		usedCidrs = append(usedCidrs, one.IPv4Cidr)
+
+		if len(one.CloudRouteTableID) != 0 {
+			table, exists := f.routeTables.get(one.CloudRouteTableID)
+			if !exists || table.table.CloudVpcID != opt.CloudVpcID {
+				return nil, sdkError(kt, ErrCodeNotFound, "[fake] route table %s not found in vpc %s",
+					one.CloudRouteTableID, opt.CloudVpcID)
+			}
+		}
+	}
+
+	result := make([]adtysubnet.TCloudSubnet, 0, len(opt.Subnets))
+	for _, one := range opt.Subnets {
+		tableID := one.CloudRouteTableID
+		if len(tableID) == 0 && len(mainTable) != 0 {
+			tableID = mainTable[0].table.CloudID
+		}
+
+		total := subnetIPCount(one.IPv4Cidr)
+		subnet := adtysubnet.TCloudSubnet{
+			CloudVpcID: opt.CloudVpcID,
+			CloudID:    f.newID("subnet"),
+			Name:       one.Name,
+			Region:     opt.Region,
+			Ipv4Cidr:   []string{one.IPv4Cidr},
+			Memo:       one.Memo,
+			Extension: &adtysubnet.TCloudSubnetExtension{
+				Zone:                    one.Zone,
+				CloudRouteTableID:       converter.ValToPtr(tableID),
+				AvailableIPAddressCount: total,
+				TotalIpAddressCount:     total,
+			},
+		}
+		f.subnets.add(subnet.CloudID, &subnetRecord{subnet: subnet, usedIPs: make(map[string]string)})
+
+		if table, exists := f.routeTables.get(tableID); exists {
+			table.table.Extension.Associations = append(table.table.Extension.Associations,
+				routetable.TCloudRouteTableAsst{CloudSubnetID: subnet.CloudID})
+		}
+
+		result = append(result, subnet)
+	}
+
+	return result, nil
+}
+
+// UpdateSubnet update subnet, only memo is supported and it is not synced to cloud, so do nothing.
+func (f *FakeTCloud) UpdateSubnet(kt *kit.Kit, _ *adtysubnet.TCloudSubnetUpdateOption) error {
+	return f.fault(kt, "UpdateSubnet")
+}
+
+// DeleteSubnet delete subnet, subnet with cvm can not be deleted.
+func (f *FakeTCloud) DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteSubnet"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	subnet, exists := f.subnets.get(opt.ResourceID)
+	if !exists {
+		return sdkError(kt, ErrCodeNotFound, "[fake] subnet %s not found", opt.ResourceID)
+	}
+
+	if len(subnet.usedIPs) != 0 {
+		return sdkError(kt, ErrCodeResourceInUse, "[fake] subnet %s still has %d used ips", opt.ResourceID,
+			len(subnet.usedIPs))
+	}
+
+	if table, exists := f.routeTables.get(converter.PtrToVal(subnet.subnet.Extension.CloudRouteTableID)); exists {
+		assts := make([]routetable.TCloudRouteTableAsst, 0, len(table.table.Extension.Associations))
+		for _, one := range table.table.Extension.Associations {
+			if one.CloudSubnetID != opt.ResourceID {
+				assts = append(assts, one)
+			}
+		}
+		table.table.Extension.Associations = assts
+	}
+	f.subnets.remove(opt.ResourceID)
+
+	return nil
+}
+
+// ListSubnet list subnet.
+func (f *FakeTCloud) ListSubnet(kt *kit.Kit, opt *core.TCloudListOption) (*adtysubnet.TCloudSubnetListResult, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListSubnet"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	records := f.subnets.list(opt.CloudIDs, func(r *subnetRecord) bool { return r.subnet.Region == opt.Region })
+	details := make([]adtysubnet.TCloudSubnet, 0, len(records))
+	for _, one := range paginate(records, opt.Page) {
+		subnet := one.subnet
+		ext := *one.subnet.Extension
+		ext.UsedIpAddressCount = uint64(len(one.usedIPs))
+		ext.AvailableIPAddressCount = ext.TotalIpAddressCount - ext.UsedIpAddressCount
+		subnet.Extension = &ext
+		details = append(details, subnet)
+	}
+
+	return &adtysubnet.TCloudSubnetListResult{Count: converter.ValToPtr(uint64(len(records))), Details: details}, nil
+}
+
+// CountSubnet count subnet of the region.
+func (f *FakeTCloud) CountSubnet(kt *kit.Kit, region string) (int32, error) {
+	if err := f.fault(kt, "CountSubnet"); err != nil {
+		return 0, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return int32(len(f.subnets.list(nil, func(r *subnetRecord) bool { return r.subnet.Region == region }))), nil
+}
+
+// UpdateRouteTable update route table, it is not synced to cloud, so do nothing.
+func (f *FakeTCloud) UpdateRouteTable(kt *kit.Kit, _ *routetable.TCloudRouteTableUpdateOption) error {
+	return f.fault(kt, "UpdateRouteTable")
+}
+
+// DeleteRouteTable delete route table, main route table or route table with subnets can not be deleted.
+func (f *FakeTCloud) DeleteRouteTable(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.fault(kt, "DeleteRouteTable"); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	table, exists := f.routeTables.get(opt.ResourceID)
+	if !exists {
+		return sdkError(kt, ErrCodeNotFound, "[fake] route table %s not found", opt.ResourceID)
+	}
+
+	if table.table.Extension.Main || len(table.table.Extension.Associations) != 0 {
+		return sdkError(kt, ErrCodeResourceInUse, "[fake] route table %s is main table or associated with subnets",
+			opt.ResourceID)
+	}
+	f.routeTables.remove(opt.ResourceID)
+
+	return nil
+}
+
+// ListRouteTable list route table.
+func (f *FakeTCloud) ListRouteTable(kt *kit.Kit, opt *core.TCloudListOption) (*routetable.TCloudRouteTableListResult,
+	error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := f.fault(kt, "ListRouteTable"); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	records := f.routeTables.list(opt.CloudIDs, func(r *routeTableRecord) bool { return r.table.Region == opt.Region })
+	details := make([]routetable.TCloudRouteTable, 0, len(records))
+	for _, one := range paginate(records, opt.Page) {
+		table := one.table
+		ext := *one.table.Extension
+		ext.Associations = append([]routetable.TCloudRouteTableAsst(nil), ext.Associations...)
+		table.Extension = &ext
+		details = append(details, table)
+	}
+
+	return &routetable.TCloudRouteTableListResult{Count: converter.ValToPtr(uint64(len(records))),
+		Details: details}, nil
+}
+
+// CountRouteTable count route table of the region.
+func (f *FakeTCloud) CountRouteTable(kt *kit.Kit, region string) (int32, error) {
+	if err := f.fault(kt, "CountRouteTable"); err != nil {
+		return 0, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return int32(len(f.routeTables.list(nil, func(r *routeTableRecord) bool { return r.table.Region == region }))),
+		nil
+}
+
+// allocateIP allocate a private ip from the subnet for the cvm, it should be called with lock held.
+func (r *subnetRecord) allocateIP(cvmID string) (string, bool) {
+	_, ipNet, err := net.ParseCIDR(r.subnet.Ipv4Cidr[0])
+	if err != nil {
+		return "", false
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	start := binary.BigEndian.Uint32(ipNet.IP.To4())
+	size := uint32(1) << uint32(bits-ones)
+	// 和腾讯云一样，子网的网络地址、网关地址和广播地址不可分配
+	for offset := uint32(2); offset+1 < size; offset++ {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, start+offset)
+		if _, used := r.usedIPs[ip.String()]; !used {
+			r.usedIPs[ip.String()] = cvmID
+			return ip.String(), true
+		}
+	}
+
+	return "", false
+}
+
+// subnetIPCount returns the available ip count of the subnet cidr, network, gateway and broadcast address are
+// excluded.
+func subnetIPCount(subnetCidr string) uint64 {
+	count, err := cidr.CidrIPCounts(subnetCidr)
+	if err != nil || count < 3 {
+		return 0
+	}
+
+	return uint64(count - 3)
+}
+
+// cidrOverlapped returns whether the two cidr blocks are overlapped.
+func cidrOverlapped(a, b string) bool {
+	_, aNet, err := net.ParseCIDR(a)
+	if err != nil {
+		return false
+	}
+
+	_, bNet, err := net.ParseCIDR(b)
+	if err != nil {
+		return false
+	}
+
+	return aNet.Contains(bNet.IP) || bNet.Contains(aNet.IP)
+}
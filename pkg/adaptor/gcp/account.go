@@ -35,7 +35,7 @@ import (
 
 // CountAccount count account.
 // reference: https://cloud.google.com/asset-inventory/docs/reference/rest/v1/TopLevel/analyzeIamPolicy
-func (g *Gcp) CountAccount(kt *kit.Kit) (int32, error) {
+func (g *GcpImpl) CountAccount(kt *kit.Kit) (int32, error) {
 
 	client, err := g.clientSet.assetClient(kt)
 	if err != nil {
@@ -76,7 +76,7 @@ func (g *Gcp) CountAccount(kt *kit.Kit) (int32, error) {
 
 // ListAccount list account.
 // reference: https://cloud.google.com/asset-inventory/docs/reference/rest/v1/TopLevel/analyzeIamPolicy
-func (g *Gcp) ListAccount(kt *kit.Kit) ([]typeaccount.GcpAccount, error) {
+func (g *GcpImpl) ListAccount(kt *kit.Kit) ([]typeaccount.GcpAccount, error) {
 
 	client, err := g.clientSet.assetClient(kt)
 	if err != nil {
@@ -124,7 +124,7 @@ func (g *Gcp) ListAccount(kt *kit.Kit) ([]typeaccount.GcpAccount, error) {
 
 // GetProjectRegionQuota 获取项目地域配额
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/regions/get
-func (g *Gcp) GetProjectRegionQuota(kt *kit.Kit, opt *typeaccount.GcpProjectRegionQuotaOption) (
+func (g *GcpImpl) GetProjectRegionQuota(kt *kit.Kit, opt *typeaccount.GcpProjectRegionQuotaOption) (
 	*typeaccount.GcpProjectQuota, error) {
 
 	if opt == nil {
@@ -164,7 +164,8 @@ func (g *Gcp) GetProjectRegionQuota(kt *kit.Kit, opt *typeaccount.GcpProjectRegi
 // reference:
 // 1. https://cloud.google.com/resource-manager/reference/rest/v3/projects/search
 // 2. https://cloud.google.com/iam/docs/reference/rest/v1/projects.serviceAccounts/get
-func (g *Gcp) GetAccountInfoBySecret(kit *kit.Kit, cloudSecretKeyString string) (*cloud.GcpInfoBySecret, error) /**/ {
+func (g *GcpImpl) GetAccountInfoBySecret(kit *kit.Kit, cloudSecretKeyString string) (
+	*cloud.GcpInfoBySecret, error) /**/ {
 	client, err := g.clientSet.resClient(kit)
 	if err != nil {
 		return nil, err
@@ -53,7 +53,7 @@ const (
 )
 
 // GetBillList demonstrates issuing a query and reading results.
-func (g *Gcp) GetBillList(kt *kit.Kit, opt *typesBill.GcpBillListOption,
+func (g *GcpImpl) GetBillList(kt *kit.Kit, opt *typesBill.GcpBillListOption,
 	billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (interface{}, int64, error) {
 
 	where, err := g.parseCondition(opt)
@@ -84,7 +84,8 @@ func (g *Gcp) GetBillList(kt *kit.Kit, opt *typesBill.GcpBillListOption,
 }
 
 // GetBillTotal get bill total num
-func (g *Gcp) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (
+func (g *GcpImpl) GetBillTotal(kt *kit.Kit, where string,
+	billInfo *cloud.AccountBillConfig[cloud.GcpBillConfigExtension]) (
 	int64, error) {
 
 	sql := fmt.Sprintf(QueryBillTotalSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, where)
@@ -95,7 +96,7 @@ func (g *Gcp) GetBillTotal(kt *kit.Kit, where string, billInfo *cloud.AccountBil
 	return total, nil
 }
 
-func (g *Gcp) GetBigQuery(kt *kit.Kit, query string) ([]map[string]bigquery.Value, int64, error) {
+func (g *GcpImpl) GetBigQuery(kt *kit.Kit, query string) ([]map[string]bigquery.Value, int64, error) {
 	client, err := g.clientSet.bigQueryClient(kt)
 	if err != nil {
 		return nil, 0, fmt.Errorf("gcp.billquery.NewClient, err: %+v", err)
@@ -138,7 +139,7 @@ func (g *Gcp) GetBigQuery(kt *kit.Kit, query string) ([]map[string]bigquery.Valu
 	return list, num, nil
 }
 
-func (g *Gcp) parseCondition(opt *typesBill.GcpBillListOption) (string, error) {
+func (g *GcpImpl) parseCondition(opt *typesBill.GcpBillListOption) (string, error) {
 	var condition = []string{fmt.Sprintf("project.id = '%s'", opt.ProjectID)}
 	if opt.Month != "" {
 		condition = append(condition, fmt.Sprintf("invoice.month = '%s'", opt.Month))
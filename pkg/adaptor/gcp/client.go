@@ -20,14 +20,19 @@
 package gcp
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 
+	"hcm/pkg/adaptor/cassette"
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/kit"
 
 	asset "cloud.google.com/go/asset/apiv1"
 	"cloud.google.com/go/bigquery"
 	credentials "cloud.google.com/go/iam/credentials/apiv1"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
 	res "google.golang.org/api/cloudresourcemanager/v3"
 	"google.golang.org/api/compute/v1"
 	iam "google.golang.org/api/iam/v1"
@@ -36,10 +41,28 @@ import (
 
 type clientSet struct {
 	credential *types.GcpCredential
+	// httpClient 非空时 REST sdk 使用该 http client 发送请求, 用于录制/回放云厂商 API 调用,
+	// 基于 gRPC 的 asset、iam credentials client 不支持录制/回放.
+	httpClient *http.Client
 }
 
 func newClientSet(credential *types.GcpCredential) *clientSet {
-	return &clientSet{credential}
+	return &clientSet{credential: credential, httpClient: cassette.HTTPClient()}
+}
+
+// restOptions 返回 REST sdk client 配置, 设置了 http client 时获取 token 和调用 API 都通过该 http client 发送请求.
+func (c *clientSet) restOptions(kt *kit.Kit) ([]option.ClientOption, error) {
+	if c.httpClient == nil {
+		return []option.ClientOption{option.WithCredentialsJSON(c.credential.Json)}, nil
+	}
+
+	ctx := context.WithValue(kt.Ctx, oauth2.HTTPClient, c.httpClient)
+	cred, err := google.CredentialsFromJSON(ctx, c.credential.Json, compute.CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("parse gcp credential failed, err: %v", err)
+	}
+
+	return []option.ClientOption{option.WithHTTPClient(oauth2.NewClient(ctx, cred.TokenSource))}, nil
 }
 
 func (c *clientSet) assetClient(kt *kit.Kit) (*asset.Client, error) {
@@ -63,8 +86,12 @@ func (c *clientSet) iamClient(kt *kit.Kit) (*credentials.IamCredentialsClient, e
 }
 
 func (c *clientSet) computeClient(kt *kit.Kit) (*compute.Service, error) {
-	opt := option.WithCredentialsJSON(c.credential.Json)
-	service, err := compute.NewService(kt.Ctx, opt)
+	opts, err := c.restOptions(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := compute.NewService(kt.Ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -73,8 +100,12 @@ func (c *clientSet) computeClient(kt *kit.Kit) (*compute.Service, error) {
 }
 
 func (c *clientSet) bigQueryClient(kt *kit.Kit) (*bigquery.Client, error) {
-	opt := option.WithCredentialsJSON(c.credential.Json)
-	service, err := bigquery.NewClient(kt.Ctx, c.credential.CloudProjectID, opt)
+	opts, err := c.restOptions(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := bigquery.NewClient(kt.Ctx, c.credential.CloudProjectID, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("gcp.bigquery.NewClient, projectID: %s, err: %+v",
 			c.credential.CloudProjectID, err)
@@ -85,8 +116,12 @@ func (c *clientSet) bigQueryClient(kt *kit.Kit) (*bigquery.Client, error) {
 }
 
 func (c *clientSet) resClient(kt *kit.Kit) (*res.Service, error) {
-	opt := option.WithCredentialsJSON(c.credential.Json)
-	service, err := res.NewService(kt.Ctx, opt)
+	opts, err := c.restOptions(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := res.NewService(kt.Ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -95,8 +130,12 @@ func (c *clientSet) resClient(kt *kit.Kit) (*res.Service, error) {
 }
 
 func (c *clientSet) iamServiceClient(kt *kit.Kit) (*iam.Service, error) {
-	opt := option.WithCredentialsJSON(c.credential.Json)
-	service, err := iam.NewService(kt.Ctx, opt)
+	opts, err := c.restOptions(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := iam.NewService(kt.Ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ import (
 )
 
 // ListCvm reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/list
-func (g *Gcp) ListCvm(kt *kit.Kit, opt *typecvm.GcpListOption) ([]typecvm.GcpCvm, string, error) {
+func (g *GcpImpl) ListCvm(kt *kit.Kit, opt *typecvm.GcpListOption) ([]typecvm.GcpCvm, string, error) {
 	if opt == nil {
 		return nil, "", errf.New(errf.InvalidParameter, "list option is required")
 	}
@@ -82,7 +82,7 @@ func (g *Gcp) ListCvm(kt *kit.Kit, opt *typecvm.GcpListOption) ([]typecvm.GcpCvm
 }
 
 // CountCvmAndNI reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/list
-func (g *Gcp) CountCvmAndNI(kt *kit.Kit) (int32, int32, error) {
+func (g *GcpImpl) CountCvmAndNI(kt *kit.Kit) (int32, int32, error) {
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -152,7 +152,7 @@ func GetGcpIPAddresses(networkInterfaces []*compute.NetworkInterface) ([]string,
 }
 
 // DeleteCvm reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/delete
-func (g *Gcp) DeleteCvm(kt *kit.Kit, opt *typecvm.GcpDeleteOption) error {
+func (g *GcpImpl) DeleteCvm(kt *kit.Kit, opt *typecvm.GcpDeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "delete option is required")
 	}
@@ -176,7 +176,7 @@ func (g *Gcp) DeleteCvm(kt *kit.Kit, opt *typecvm.GcpDeleteOption) error {
 }
 
 // StopCvm reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/stop
-func (g *Gcp) StopCvm(kt *kit.Kit, opt *typecvm.GcpStopOption) error {
+func (g *GcpImpl) StopCvm(kt *kit.Kit, opt *typecvm.GcpStopOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "stop option is required")
 	}
@@ -199,7 +199,7 @@ func (g *Gcp) StopCvm(kt *kit.Kit, opt *typecvm.GcpStopOption) error {
 	handler := &stopCvmPollingHandler{
 		opt.Zone,
 	}
-	respPoller := poller.Poller[*Gcp, []*compute.Instance, poller.BaseDoneResult]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []*compute.Instance, poller.BaseDoneResult]{Handler: handler}
 	_, err = respPoller.PollUntilDone(g, kt, []*string{to.Ptr(opt.Name)},
 		types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
@@ -210,7 +210,7 @@ func (g *Gcp) StopCvm(kt *kit.Kit, opt *typecvm.GcpStopOption) error {
 }
 
 // StartCvm reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/start
-func (g *Gcp) StartCvm(kt *kit.Kit, opt *typecvm.GcpStartOption) error {
+func (g *GcpImpl) StartCvm(kt *kit.Kit, opt *typecvm.GcpStartOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "start option is required")
 	}
@@ -233,7 +233,7 @@ func (g *Gcp) StartCvm(kt *kit.Kit, opt *typecvm.GcpStartOption) error {
 	handler := &startCvmPollingHandler{
 		opt.Zone,
 	}
-	respPoller := poller.Poller[*Gcp, []*compute.Instance, poller.BaseDoneResult]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []*compute.Instance, poller.BaseDoneResult]{Handler: handler}
 	_, err = respPoller.PollUntilDone(g, kt, []*string{to.Ptr(opt.Name)},
 		types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
@@ -244,7 +244,7 @@ func (g *Gcp) StartCvm(kt *kit.Kit, opt *typecvm.GcpStartOption) error {
 }
 
 // ResetCvm reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/reset
-func (g *Gcp) ResetCvm(kt *kit.Kit, opt *typecvm.GcpResetOption) error {
+func (g *GcpImpl) ResetCvm(kt *kit.Kit, opt *typecvm.GcpResetOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "reset option is required")
 	}
@@ -267,7 +267,7 @@ func (g *Gcp) ResetCvm(kt *kit.Kit, opt *typecvm.GcpResetOption) error {
 	handler := &resetCvmPollingHandler{
 		opt.Zone,
 	}
-	respPoller := poller.Poller[*Gcp, []*compute.Instance, poller.BaseDoneResult]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []*compute.Instance, poller.BaseDoneResult]{Handler: handler}
 	_, err = respPoller.PollUntilDone(g, kt, []*string{to.Ptr(opt.Name)},
 		types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
@@ -278,7 +278,7 @@ func (g *Gcp) ResetCvm(kt *kit.Kit, opt *typecvm.GcpResetOption) error {
 }
 
 // CreateCvm reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/bulkInsert
-func (g *Gcp) CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.BaseDoneResult, error) {
+func (g *GcpImpl) CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.BaseDoneResult, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "reset option is required")
 	}
@@ -377,7 +377,7 @@ func (g *Gcp) CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.Base
 	handler := &createCvmPollingHandler{
 		opt.Zone,
 	}
-	respPoller := poller.Poller[*Gcp, []*compute.Operation, poller.BaseDoneResult]{Handler: handler}
+	respPoller := poller.Poller[*GcpImpl, []*compute.Operation, poller.BaseDoneResult]{Handler: handler}
 	result, err := respPoller.PollUntilDone(g, kt, []*string{to.Ptr(resp.OperationGroupId)},
 		types.NewBatchCreateCvmPollerOption())
 	if err != nil {
@@ -389,7 +389,7 @@ func (g *Gcp) CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.Base
 	return result, nil
 }
 
-func (g *Gcp) deleteCvmMetadataStartScript(kt *kit.Kit, client *compute.Service, zone string, ids []string) {
+func (g *GcpImpl) deleteCvmMetadataStartScript(kt *kit.Kit, client *compute.Service, zone string, ids []string) {
 
 	resp, err := client.Instances.List(g.CloudProjectID(), zone).Context(kt.Ctx).
 		Filter(generateResourceIDsFilter(ids)).Do()
@@ -425,7 +425,7 @@ func (h *startCvmPollingHandler) Done(instances []*compute.Instance) (bool, *pol
 }
 
 // Poll ...
-func (h *startCvmPollingHandler) Poll(client *Gcp, kt *kit.Kit, names []*string) ([]*compute.Instance, error) {
+func (h *startCvmPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, names []*string) ([]*compute.Instance, error) {
 	return poll(client, kt, h.zone, names)
 }
 
@@ -439,7 +439,7 @@ func (h *stopCvmPollingHandler) Done(instances []*compute.Instance) (bool, *poll
 }
 
 // Poll ...
-func (h *stopCvmPollingHandler) Poll(client *Gcp, kt *kit.Kit, names []*string) ([]*compute.Instance, error) {
+func (h *stopCvmPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, names []*string) ([]*compute.Instance, error) {
 	return poll(client, kt, h.zone, names)
 }
 
@@ -453,7 +453,7 @@ func (h *resetCvmPollingHandler) Done(instances []*compute.Instance) (bool, *pol
 }
 
 // Poll ...
-func (h *resetCvmPollingHandler) Poll(client *Gcp, kt *kit.Kit, names []*string) ([]*compute.Instance, error) {
+func (h *resetCvmPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, names []*string) ([]*compute.Instance, error) {
 	return poll(client, kt, h.zone, names)
 }
 
@@ -476,7 +476,7 @@ func done(instances []*compute.Instance, succeed []string) (bool, *poller.BaseDo
 	return flag, result
 }
 
-func poll(client *Gcp, kt *kit.Kit, zone string, names []*string) ([]*compute.Instance, error) {
+func poll(client *GcpImpl, kt *kit.Kit, zone string, names []*string) ([]*compute.Instance, error) {
 	cli, err := client.clientSet.computeClient(kt)
 	if err != nil {
 		return nil, err
@@ -528,7 +528,8 @@ func (h *createCvmPollingHandler) Done(items []*compute.Operation) (bool, *polle
 	return flag, result
 }
 
-func (h *createCvmPollingHandler) Poll(client *Gcp, kt *kit.Kit, operGroupIDs []*string) ([]*compute.Operation, error) {
+func (h *createCvmPollingHandler) Poll(client *GcpImpl, kt *kit.Kit, operGroupIDs []*string) (
+	[]*compute.Operation, error) {
 
 	if len(operGroupIDs) == 0 {
 		return nil, errors.New("operation group id is required")
@@ -553,4 +554,4 @@ func (h *createCvmPollingHandler) Poll(client *Gcp, kt *kit.Kit, operGroupIDs []
 	return operResp.Items, nil
 }
 
-var _ poller.PollingHandler[*Gcp, []*compute.Operation, poller.BaseDoneResult] = new(createCvmPollingHandler)
+var _ poller.PollingHandler[*GcpImpl, []*compute.Operation, poller.BaseDoneResult] = new(createCvmPollingHandler)
@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// ListSnapshot 查询云盘快照
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/list
+func (g *GcpImpl) ListSnapshot(kt *kit.Kit, opt *typesnapshot.GcpListOption) ([]typesnapshot.GcpSnapshot, string,
+	error) {
+
+	if opt == nil {
+		return nil, "", errf.New(errf.InvalidParameter, "gcp snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	request := client.Snapshots.List(g.CloudProjectID()).Context(kt.Ctx)
+
+	if len(opt.CloudIDs) > 0 {
+		request.Filter(generateResourceIDsFilter(opt.CloudIDs))
+	}
+
+	if len(opt.SelfLinks) > 0 {
+		request.Filter(generateResourceFilter("selfLink", opt.SelfLinks))
+	}
+
+	if opt.Page != nil {
+		request.MaxResults(opt.Page.PageSize).PageToken(opt.Page.PageToken)
+	}
+
+	resp, err := request.Do()
+	if err != nil {
+		logs.Errorf("list gcp snapshot failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+		return nil, "", err
+	}
+
+	snapshots := make([]typesnapshot.GcpSnapshot, 0, len(resp.Items))
+	for _, one := range resp.Items {
+		snapshots = append(snapshots, typesnapshot.GcpSnapshot{Snapshot: one})
+	}
+
+	return snapshots, resp.NextPageToken, nil
+}
+
+// CreateSnapshot 从云盘创建快照，等待创建操作结束后返回快照的云上ID
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/createSnapshot
+func (g *GcpImpl) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.GcpCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "gcp snapshot create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return "", err
+	}
+
+	cloudProjectID := g.CloudProjectID()
+	req := &compute.Snapshot{
+		Name:        opt.Name,
+		Description: opt.Description,
+	}
+	op, err := client.Disks.CreateSnapshot(cloudProjectID, opt.Zone, opt.DiskName, req).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("create gcp snapshot failed, err: %v, disk: %s, rid: %s", err, opt.DiskName, kt.Rid)
+		return "", err
+	}
+
+	op, err = client.ZoneOperations.Wait(cloudProjectID, opt.Zone, op.Name).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("wait gcp snapshot create operation failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return "", fmt.Errorf("create gcp snapshot failed, reason: %s", op.Error.Errors[0].Message)
+	}
+
+	snapshot, err := client.Snapshots.Get(cloudProjectID, opt.Name).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("get gcp snapshot failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return "", err
+	}
+
+	return fmt.Sprint(snapshot.Id), nil
+}
+
+// DeleteSnapshot 删除云盘快照
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/delete
+func (g *GcpImpl) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.GcpDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "gcp snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Snapshots.Delete(g.CloudProjectID(), opt.Name).Context(kt.Ctx).Do(); err != nil {
+		logs.Errorf("delete gcp snapshot failed, err: %v, name: %s, rid: %s", err, opt.Name, kt.Rid)
+		return err
+	}
+
+	return nil
+}
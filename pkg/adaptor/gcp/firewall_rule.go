@@ -31,7 +31,8 @@ import (
 
 // ListFirewallRule list firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/list
-func (g *Gcp) ListFirewallRule(kt *kit.Kit, opt *firewallrule.ListOption) ([]firewallrule.GcpFirewall, string, error) {
+func (g *GcpImpl) ListFirewallRule(kt *kit.Kit, opt *firewallrule.ListOption) (
+	[]firewallrule.GcpFirewall, string, error) {
 	if opt == nil {
 		return nil, "", errf.New(errf.InvalidParameter, "list option is required")
 	}
@@ -71,7 +72,7 @@ func (g *Gcp) ListFirewallRule(kt *kit.Kit, opt *firewallrule.ListOption) ([]fir
 
 // CountFirewall count firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/list
-func (g *Gcp) CountFirewall(kt *kit.Kit) (int32, error) {
+func (g *GcpImpl) CountFirewall(kt *kit.Kit) (int32, error) {
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -100,7 +101,7 @@ func (g *Gcp) CountFirewall(kt *kit.Kit) (int32, error) {
 
 // UpdateFirewallRule update firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/patch
-func (g *Gcp) UpdateFirewallRule(kt *kit.Kit, opt *firewallrule.UpdateOption) error {
+func (g *GcpImpl) UpdateFirewallRule(kt *kit.Kit, opt *firewallrule.UpdateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "update option is required")
 	}
@@ -157,7 +158,7 @@ func (g *Gcp) UpdateFirewallRule(kt *kit.Kit, opt *firewallrule.UpdateOption) er
 
 // DeleteFirewallRule delete firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/delete
-func (g *Gcp) DeleteFirewallRule(kt *kit.Kit, opt *firewallrule.DeleteOption) error {
+func (g *GcpImpl) DeleteFirewallRule(kt *kit.Kit, opt *firewallrule.DeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "delete option is required")
 	}
@@ -181,7 +182,7 @@ func (g *Gcp) DeleteFirewallRule(kt *kit.Kit, opt *firewallrule.DeleteOption) er
 
 // CreateFirewallRule create firewall rule.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/patch
-func (g *Gcp) CreateFirewallRule(kt *kit.Kit, opt *firewallrule.CreateOption) (uint64, error) {
+func (g *GcpImpl) CreateFirewallRule(kt *kit.Kit, opt *firewallrule.CreateOption) (uint64, error) {
 	if opt == nil {
 		return 0, errf.New(errf.InvalidParameter, "create option is required")
 	}
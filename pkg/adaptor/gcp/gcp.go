@@ -27,20 +27,20 @@ import (
 )
 
 // NewGcp new gcp.
-func NewGcp(credential *types.GcpCredential) (*Gcp, error) {
+func NewGcp(credential *types.GcpCredential) (Gcp, error) {
 	if err := credential.Validate(); err != nil {
 		return nil, err
 	}
-	return &Gcp{clientSet: newClientSet(credential)}, nil
+	return &GcpImpl{clientSet: newClientSet(credential)}, nil
 }
 
-// Gcp is gcp operator.
-type Gcp struct {
+// GcpImpl is gcp operator.
+type GcpImpl struct {
 	clientSet *clientSet
 }
 
 // CloudProjectID return cloud project id.
-func (g *Gcp) CloudProjectID() string {
+func (g *GcpImpl) CloudProjectID() string {
 	return g.clientSet.credential.CloudProjectID
 }
 
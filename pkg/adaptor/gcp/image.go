@@ -50,7 +50,7 @@ func GetSystemPlatformFromImagePlatforms(platform string) (typecvm.GcpImageProje
 
 // ListImage ...
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/images/list
-func (g *Gcp) ListImage(kt *kit.Kit,
+func (g *GcpImpl) ListImage(kt *kit.Kit,
 	opt *image.GcpImageListOption) (*image.GcpImageListResult, string, error) {
 
 	client, err := g.clientSet.computeClient(kt)
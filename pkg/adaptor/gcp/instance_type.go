@@ -31,7 +31,7 @@ import (
 
 // ListInstanceType ...
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/machineTypes/list
-func (g *Gcp) ListInstanceType(
+func (g *GcpImpl) ListInstanceType(
 	kt *kit.Kit, opt *typesinstancetype.GcpInstanceTypeListOption,
 ) (*typesinstancetype.GcpInstanceTypeListResult, error) {
 	client, err := g.clientSet.computeClient(kt)
@@ -38,7 +38,8 @@ import (
 // ListNetworkInterface list network interface.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/list
 // Note：该接口分页是针对于主机，而不是网络接口，有可能出现查询出来的网络接口数量超过分页数量的情况，使用注意！！！
-func (g *Gcp) ListNetworkInterface(kt *kit.Kit, opt *core.GcpListOption) (*typesniproto.GcpInterfaceListResult, error) {
+func (g *GcpImpl) ListNetworkInterface(kt *kit.Kit, opt *core.GcpListOption) (
+	*typesniproto.GcpInterfaceListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -81,7 +82,7 @@ func (g *Gcp) ListNetworkInterface(kt *kit.Kit, opt *core.GcpListOption) (*types
 
 // ListNetworkInterfacePage list network interface page.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/list
-func (g *Gcp) ListNetworkInterfacePage(kt *kit.Kit, opt *core.GcpListOption) (*compute.InstancesListCall, error) {
+func (g *GcpImpl) ListNetworkInterfacePage(kt *kit.Kit, opt *core.GcpListOption) (*compute.InstancesListCall, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -104,7 +105,7 @@ func (g *Gcp) ListNetworkInterfacePage(kt *kit.Kit, opt *core.GcpListOption) (*c
 
 // ListNetworkInterfaceByCvmID list network interface by cvm id.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/list
-func (g *Gcp) ListNetworkInterfaceByCvmID(kt *kit.Kit, opt *typesniproto.GcpListByCvmIDOption) (
+func (g *GcpImpl) ListNetworkInterfaceByCvmID(kt *kit.Kit, opt *typesniproto.GcpListByCvmIDOption) (
 	map[string] /*CloudCvmID*/ []typesniproto.GcpNI, error) {
 
 	if err := opt.Validate(); err != nil {
@@ -136,7 +137,8 @@ func (g *Gcp) ListNetworkInterfaceByCvmID(kt *kit.Kit, opt *typesniproto.GcpList
 	return result, nil
 }
 
-func (g *Gcp) ConvertNetworkInterface(data *compute.Instance, niItem *compute.NetworkInterface) *typesniproto.GcpNI {
+func (g *GcpImpl) ConvertNetworkInterface(data *compute.Instance,
+	niItem *compute.NetworkInterface) *typesniproto.GcpNI {
 	// @see https://www.googleapis.com/compute/v1/projects/xxxx/zones/us-central1-a
 	zone := data.Zone[(strings.LastIndex(data.Zone, "/") + 1):]
 	region := zone[:strings.LastIndex(zone, "-")]
@@ -32,7 +32,7 @@ import (
 
 // CountRoute count route.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/routes/list
-func (g *Gcp) CountRoute(kt *kit.Kit) (int32, error) {
+func (g *GcpImpl) CountRoute(kt *kit.Kit) (int32, error) {
 
 	client, err := g.clientSet.computeClient(kt)
 	if err != nil {
@@ -61,7 +61,7 @@ func (g *Gcp) CountRoute(kt *kit.Kit) (int32, error) {
 
 // ListRoute list route.
 // reference: https://cloud.google.com/compute/docs/reference/rest/v1/routes/list
-func (g *Gcp) ListRoute(kt *kit.Kit, opt *routetable.GcpListOption) (*routetable.GcpRouteListResult, error) {
+func (g *GcpImpl) ListRoute(kt *kit.Kit, opt *routetable.GcpListOption) (*routetable.GcpRouteListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -32,7 +32,7 @@ import (
 
 // GetBillList get bill list.
 // reference: https://support.huaweicloud.com/api-oce/mbc_00003.html
-func (h *HuaWei) GetBillList(_ *kit.Kit, opt *typesBill.HuaWeiBillListOption) (
+func (h *HuaWeiImpl) GetBillList(_ *kit.Kit, opt *typesBill.HuaWeiBillListOption) (
 	*model.ListCustomerselfResourceRecordDetailsResponse, error) {
 
 	if err := opt.Validate(); err != nil {
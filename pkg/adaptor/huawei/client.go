@@ -22,6 +22,7 @@ package huawei
 import (
 	"fmt"
 
+	"hcm/pkg/adaptor/cassette"
 	"hcm/pkg/adaptor/types"
 
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/auth/basic"
@@ -61,9 +62,13 @@ type NewCredentialsFunc func() *basic.Credentials
 // NewGlobalCredentialsFunc ...
 type NewGlobalCredentialsFunc func() *global.Credentials
 
+// clientSet huawei sdk client set.
 type clientSet struct {
 	credentials       NewCredentialsFunc
 	globalCredentials NewGlobalCredentialsFunc
+	// dialContext 非空时 sdk 通过该 DialContext 建立连接, 用于录制/回放云厂商 API 调用. 当前版本 sdk 内部固定使用
+	// 自行创建的 http.Transport, 无法直接注入 cassette 的 transport, 因此将连接转发到 cassette 的本地 TLS server.
+	dialContext config.DialContext
 }
 
 func newClientSet(secret *types.BaseSecret) *clientSet {
@@ -80,9 +85,21 @@ func newClientSet(secret *types.BaseSecret) *clientSet {
 				WithSk(secret.CloudSecretKey).
 				Build()
 		},
+		dialContext: cassette.DialContext(),
 	}
 }
 
+// httpConfig 返回 sdk 使用的 http 配置.
+func (c *clientSet) httpConfig() *config.HttpConfig {
+	cfg := config.DefaultHttpConfig()
+	if c.dialContext != nil {
+		// cassette 的本地 TLS server 使用自签名证书
+		cfg.WithDialContext(c.dialContext).WithIgnoreSSLVerification(true)
+	}
+
+	return cfg
+}
+
 func (c *clientSet) iamGlobalClient(region *region.Region) (client *iam.IamClient, err error) {
 	defer func() {
 		if p := recover(); p != nil {
@@ -94,7 +111,7 @@ func (c *clientSet) iamGlobalClient(region *region.Region) (client *iam.IamClien
 		iam.IamClientBuilder().
 			WithRegion(region).
 			WithCredential(c.globalCredentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -111,7 +128,7 @@ func (c *clientSet) iamClient(region *region.Region) (client *iam.IamClient, err
 		iam.IamClientBuilder().
 			WithRegion(region).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -127,7 +144,7 @@ func (c *clientSet) iamRegionClient(region string) (client *iam.IamClient, err e
 		iam.IamClientBuilder().
 			WithRegion(iamregion.ValueOf(region)).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -143,7 +160,7 @@ func (c *clientSet) evsClient(region string) (client *evs.EvsClient, err error)
 		evs.EvsClientBuilder().
 			WithRegion(evsregion.ValueOf(region)).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -160,7 +177,7 @@ func (c *clientSet) vpcClient(regionID string) (cli *vpc.VpcClient, err error) {
 		vpc.VpcClientBuilder().
 			WithRegion(vpcregion.ValueOf(regionID)).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -177,7 +194,7 @@ func (c *clientSet) vpcClientV2(regionID string) (cli *vpcv2.VpcClient, err erro
 		vpcv2.VpcClientBuilder().
 			WithRegion(vpcregion.ValueOf(regionID)).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -194,7 +211,7 @@ func (c *clientSet) imsClientV2(region *region.Region) (cli *ims.ImsClient, err
 		ims.ImsClientBuilder().
 			WithRegion(region).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return cli, nil
@@ -211,7 +228,7 @@ func (c *clientSet) ecsClient(regionID string) (cli *ecs.EcsClient, err error) {
 		ecs.EcsClientBuilder().
 			WithRegion(ecsregion.ValueOf(regionID)).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -228,7 +245,7 @@ func (c *clientSet) dcsClient(regionID string) (cli *dcs.DcsClient, err error) {
 		dcs.DcsClientBuilder().
 			WithRegion(dcsregion.ValueOf(regionID)).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
@@ -245,7 +262,7 @@ func (c *clientSet) eipClient(regionID string) (cli *eip.EipClient, err error) {
 		eip.EipClientBuilder().
 			WithRegion(eipregion.ValueOf(regionID)).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return cli, nil
@@ -262,7 +279,7 @@ func (c *clientSet) eipV3Client(regionID string) (cli *eipv3.EipClient, err erro
 		eipv3.EipClientBuilder().
 			WithRegion(eipv3region.ValueOf(regionID)).
 			WithCredential(c.credentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return cli, nil
@@ -311,7 +328,7 @@ func (c *clientSet) newRmsClient() (cli *rms.RmsClient, err error) {
 		rms.RmsClientBuilder().
 			WithRegion(rmsregion.ValueOf("cn-north-4")).
 			WithCredential(c.globalCredentials()).
-			WithHttpConfig(config.DefaultHttpConfig()).
+			WithHttpConfig(c.httpConfig()).
 			Build())
 
 	return client, nil
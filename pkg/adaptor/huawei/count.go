@@ -43,7 +43,7 @@ const (
 
 // CountAllResources count resources for cvm disk vpc sg eip.
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-rms/rms_04_0107.html
-func (h *HuaWei) CountAllResources(kt *kit.Kit,
+func (h *HuaWeiImpl) CountAllResources(kt *kit.Kit,
 	typ enumor.HuaWeiProviderType) (*model.CountAllResourcesResponse, error) {
 
 	client, err := h.clientSet.newRmsClient()
@@ -70,7 +70,7 @@ func (h *HuaWei) CountAllResources(kt *kit.Kit,
 
 // CountSubAccountResources count subaccount.
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-iam/iam_08_0001.html
-func (h *HuaWei) CountSubAccountResources(kt *kit.Kit) (int32, error) {
+func (h *HuaWeiImpl) CountSubAccountResources(kt *kit.Kit) (int32, error) {
 	accounts, err := h.ListAccount(kt)
 	if err != nil {
 		logs.Errorf("[%s] count list account failed, err: %v, rid: %s", enumor.HuaWei,
@@ -83,7 +83,7 @@ func (h *HuaWei) CountSubAccountResources(kt *kit.Kit) (int32, error) {
 
 // CountSubnetRouteTableRes count subnet and routeTable.
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-vpc/vpc_apiv3_0003.html
-func (h *HuaWei) CountSubnetRouteTableRes(kt *kit.Kit) (int32, int32, error) {
+func (h *HuaWeiImpl) CountSubnetRouteTableRes(kt *kit.Kit) (int32, int32, error) {
 	regions, err := h.getAvailableRegions(kt, Vpc)
 	if err != nil {
 		logs.Errorf("[%s] count get region failed, err: %v, rid: %s", enumor.HuaWei,
@@ -150,7 +150,7 @@ func (h *HuaWei) CountSubnetRouteTableRes(kt *kit.Kit) (int32, int32, error) {
 // CountNIResources count network interface.
 // reference: https://support.huaweicloud.com/api-ecs/zh-cn_topic_0094148850.html
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-ecs/ecs_02_0505.html
-func (h *HuaWei) CountNIResources(kt *kit.Kit) (int32, error) {
+func (h *HuaWeiImpl) CountNIResources(kt *kit.Kit) (int32, error) {
 	regions, err := h.getAvailableRegions(kt, Ecs)
 	if err != nil {
 		logs.Errorf("[%s] count get region failed, err: %v, rid: %s", enumor.HuaWei,
@@ -211,7 +211,7 @@ func (h *HuaWei) CountNIResources(kt *kit.Kit) (int32, error) {
 	return atomic.LoadInt32(&niCount), nil
 }
 
-func (h *HuaWei) getAvailableRegions(kt *kit.Kit, typ string) ([]string, error) {
+func (h *HuaWeiImpl) getAvailableRegions(kt *kit.Kit, typ string) ([]string, error) {
 	ret := make([]string, 0)
 
 	regions, err := h.ListRegion(kt)
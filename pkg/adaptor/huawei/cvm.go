@@ -41,7 +41,7 @@ import (
 
 // ListCvm list cvm.
 // reference: https://support.huaweicloud.com/api-ecs/zh-cn_topic_0094148850.html
-func (h *HuaWei) ListCvm(kt *kit.Kit, opt *typecvm.HuaWeiListOption) ([]typecvm.HuaWeiCvm, error) {
+func (h *HuaWeiImpl) ListCvm(kt *kit.Kit, opt *typecvm.HuaWeiListOption) ([]typecvm.HuaWeiCvm, error) {
 
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "list option is required")
@@ -81,7 +81,7 @@ func (h *HuaWei) ListCvm(kt *kit.Kit, opt *typecvm.HuaWeiListOption) ([]typecvm.
 }
 
 // DeleteCvm reference: https://support.huaweicloud.com/api-ecs/ecs_02_0103.html
-func (h *HuaWei) DeleteCvm(kt *kit.Kit, opt *typecvm.HuaWeiDeleteOption) error {
+func (h *HuaWeiImpl) DeleteCvm(kt *kit.Kit, opt *typecvm.HuaWeiDeleteOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "delete option is required")
@@ -121,7 +121,7 @@ func (h *HuaWei) DeleteCvm(kt *kit.Kit, opt *typecvm.HuaWeiDeleteOption) error {
 }
 
 // StartCvm reference: https://support.huaweicloud.com/api-ecs/ecs_02_0301.html
-func (h *HuaWei) StartCvm(kt *kit.Kit, opt *typecvm.HuaWeiStartOption) error {
+func (h *HuaWeiImpl) StartCvm(kt *kit.Kit, opt *typecvm.HuaWeiStartOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "start option is required")
@@ -161,7 +161,7 @@ func (h *HuaWei) StartCvm(kt *kit.Kit, opt *typecvm.HuaWeiStartOption) error {
 	handler := &jobPollingHandler{
 		opt.Region,
 	}
-	respPoller := poller.Poller[*HuaWei, []model.SubJob, poller.BaseDoneResult]{Handler: handler}
+	respPoller := poller.Poller[*HuaWeiImpl, []model.SubJob, poller.BaseDoneResult]{Handler: handler}
 	_, err = respPoller.PollUntilDone(h, kt, []*string{resp.JobId}, types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
 		return err
@@ -171,7 +171,7 @@ func (h *HuaWei) StartCvm(kt *kit.Kit, opt *typecvm.HuaWeiStartOption) error {
 	startHandler := &startCvmPollingHandler{
 		opt.Region,
 	}
-	startPoller := poller.Poller[*HuaWei, []model.ServerDetail, poller.BaseDoneResult]{Handler: startHandler}
+	startPoller := poller.Poller[*HuaWeiImpl, []model.ServerDetail, poller.BaseDoneResult]{Handler: startHandler}
 	_, err = startPoller.PollUntilDone(h, kt, converter.SliceToPtr(opt.CloudIDs),
 		types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
@@ -182,7 +182,7 @@ func (h *HuaWei) StartCvm(kt *kit.Kit, opt *typecvm.HuaWeiStartOption) error {
 }
 
 // StopCvm reference: https://support.huaweicloud.com/api-ecs/ecs_02_0303.html
-func (h *HuaWei) StopCvm(kt *kit.Kit, opt *typecvm.HuaWeiStopOption) error {
+func (h *HuaWeiImpl) StopCvm(kt *kit.Kit, opt *typecvm.HuaWeiStopOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "stop option is required")
@@ -230,7 +230,7 @@ func (h *HuaWei) StopCvm(kt *kit.Kit, opt *typecvm.HuaWeiStopOption) error {
 	handler := &jobPollingHandler{
 		opt.Region,
 	}
-	respPoller := poller.Poller[*HuaWei, []model.SubJob, poller.BaseDoneResult]{Handler: handler}
+	respPoller := poller.Poller[*HuaWeiImpl, []model.SubJob, poller.BaseDoneResult]{Handler: handler}
 	_, err = respPoller.PollUntilDone(h, kt, []*string{resp.JobId}, types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
 		return err
@@ -240,7 +240,7 @@ func (h *HuaWei) StopCvm(kt *kit.Kit, opt *typecvm.HuaWeiStopOption) error {
 	stopHandler := &stopCvmPollingHandler{
 		opt.Region,
 	}
-	stopPoller := poller.Poller[*HuaWei, []model.ServerDetail, poller.BaseDoneResult]{Handler: stopHandler}
+	stopPoller := poller.Poller[*HuaWeiImpl, []model.ServerDetail, poller.BaseDoneResult]{Handler: stopHandler}
 	_, err = stopPoller.PollUntilDone(h, kt, converter.SliceToPtr(opt.CloudIDs), types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
 		return err
@@ -250,7 +250,7 @@ func (h *HuaWei) StopCvm(kt *kit.Kit, opt *typecvm.HuaWeiStopOption) error {
 }
 
 // RebootCvm reference: https://support.huaweicloud.com/api-ecs/ecs_02_0302.html
-func (h *HuaWei) RebootCvm(kt *kit.Kit, opt *typecvm.HuaWeiRebootOption) error {
+func (h *HuaWeiImpl) RebootCvm(kt *kit.Kit, opt *typecvm.HuaWeiRebootOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "reboot option is required")
@@ -298,7 +298,7 @@ func (h *HuaWei) RebootCvm(kt *kit.Kit, opt *typecvm.HuaWeiRebootOption) error {
 	handler := &jobPollingHandler{
 		opt.Region,
 	}
-	respPoller := poller.Poller[*HuaWei, []model.SubJob, poller.BaseDoneResult]{Handler: handler}
+	respPoller := poller.Poller[*HuaWeiImpl, []model.SubJob, poller.BaseDoneResult]{Handler: handler}
 	_, err = respPoller.PollUntilDone(h, kt, []*string{resp.JobId}, types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
 		return err
@@ -308,7 +308,7 @@ func (h *HuaWei) RebootCvm(kt *kit.Kit, opt *typecvm.HuaWeiRebootOption) error {
 	rebootHandler := &rebootCvmPollingHandler{
 		opt.Region,
 	}
-	rebootPoller := poller.Poller[*HuaWei, []model.ServerDetail, poller.BaseDoneResult]{Handler: rebootHandler}
+	rebootPoller := poller.Poller[*HuaWeiImpl, []model.ServerDetail, poller.BaseDoneResult]{Handler: rebootHandler}
 	_, err = rebootPoller.PollUntilDone(h, kt, converter.SliceToPtr(opt.CloudIDs), types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
 		return err
@@ -318,7 +318,7 @@ func (h *HuaWei) RebootCvm(kt *kit.Kit, opt *typecvm.HuaWeiRebootOption) error {
 }
 
 // ResetCvmPwd reference: https://support.huaweicloud.com/api-ecs/ecs_02_0306.html
-func (h *HuaWei) ResetCvmPwd(kt *kit.Kit, opt *typecvm.HuaWeiResetPwdOption) error {
+func (h *HuaWeiImpl) ResetCvmPwd(kt *kit.Kit, opt *typecvm.HuaWeiResetPwdOption) error {
 
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "reset pwd option is required")
@@ -356,7 +356,7 @@ func (h *HuaWei) ResetCvmPwd(kt *kit.Kit, opt *typecvm.HuaWeiResetPwdOption) err
 	handler := &resetpwdCvmPollingHandler{
 		opt.Region,
 	}
-	respPoller := poller.Poller[*HuaWei, []model.ServerDetail, poller.BaseDoneResult]{Handler: handler}
+	respPoller := poller.Poller[*HuaWeiImpl, []model.ServerDetail, poller.BaseDoneResult]{Handler: handler}
 	_, err = respPoller.PollUntilDone(h, kt, converter.SliceToPtr(opt.CloudIDs),
 		types.NewBatchOperateCvmPollerOpt())
 	if err != nil {
@@ -369,7 +369,7 @@ func (h *HuaWei) ResetCvmPwd(kt *kit.Kit, opt *typecvm.HuaWeiResetPwdOption) err
 // InquiryPriceCvm 创建云主机询价
 // reference: https://console-intl.huaweicloud.com/apiexplorer/#/openapi/BSSINTL/debug?api=ListRateOnPeriodDetail
 // reference: https://console-intl.huaweicloud.com/apiexplorer/#/openapi/BSSINTL/debug?api=ListOnDemandResourceRatings
-func (h *HuaWei) InquiryPriceCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (
+func (h *HuaWeiImpl) InquiryPriceCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (
 	*typecvm.InquiryPriceResult, error) {
 
 	if opt == nil {
@@ -392,7 +392,7 @@ func (h *HuaWei) InquiryPriceCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (
 	}
 }
 
-func (h *HuaWei) inquiryPricePrepaidCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption, projectID string) (
+func (h *HuaWeiImpl) inquiryPricePrepaidCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption, projectID string) (
 	*typecvm.InquiryPriceResult, error) {
 
 	client, err := h.clientSet.bssintlGlobalClient()
@@ -473,7 +473,7 @@ func (h *HuaWei) inquiryPricePrepaidCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOp
 	return result, nil
 }
 
-func (h *HuaWei) inquiryPricePostPaidCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption, projectID string) (
+func (h *HuaWeiImpl) inquiryPricePostPaidCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption, projectID string) (
 	*typecvm.InquiryPriceResult, error) {
 
 	client, err := h.clientSet.bssintlGlobalClient()
@@ -548,7 +548,7 @@ func (h *HuaWei) inquiryPricePostPaidCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateO
 }
 
 // CreateCvm reference: https://support.huaweicloud.com/api-ecs/ecs_02_0101.html
-func (h *HuaWei) CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*poller.BaseDoneResult, error) {
+func (h *HuaWeiImpl) CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*poller.BaseDoneResult, error) {
 
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "reset pwd option is required")
@@ -678,7 +678,7 @@ func (h *HuaWei) CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*polle
 	handler := &createCvmPollingHandler{
 		opt.Region,
 	}
-	respPoller := poller.Poller[*HuaWei, []model.ServerDetail, poller.BaseDoneResult]{Handler: handler}
+	respPoller := poller.Poller[*HuaWeiImpl, []model.ServerDetail, poller.BaseDoneResult]{Handler: handler}
 	result, err := respPoller.PollUntilDone(h, kt, converter.SliceToPtr(converter.PtrToVal(resp.ServerIds)),
 		types.NewBatchCreateCvmPollerOption())
 	if err != nil {
@@ -720,7 +720,7 @@ func (h *jobPollingHandler) Done(jobs []model.SubJob) (bool, *poller.BaseDoneRes
 }
 
 // Poll ...
-func (h *jobPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]model.SubJob, error) {
+func (h *jobPollingHandler) Poll(client *HuaWeiImpl, kt *kit.Kit, cloudIDs []*string) ([]model.SubJob, error) {
 	if len(cloudIDs) == 0 {
 		return nil, errors.New("job id is required")
 	}
@@ -753,7 +753,8 @@ func (h *startCvmPollingHandler) Done(cvms []model.ServerDetail) (bool, *poller.
 }
 
 // Poll ...
-func (h *startCvmPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]model.ServerDetail, error) {
+func (h *startCvmPollingHandler) Poll(client *HuaWeiImpl, kt *kit.Kit, cloudIDs []*string) (
+	[]model.ServerDetail, error) {
 	return poll(client, kt, h.region, cloudIDs)
 }
 
@@ -767,7 +768,8 @@ func (h *stopCvmPollingHandler) Done(cvms []model.ServerDetail) (bool, *poller.B
 }
 
 // Poll ...
-func (h *stopCvmPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]model.ServerDetail, error) {
+func (h *stopCvmPollingHandler) Poll(client *HuaWeiImpl, kt *kit.Kit, cloudIDs []*string) (
+	[]model.ServerDetail, error) {
 	return poll(client, kt, h.region, cloudIDs)
 }
 
@@ -781,7 +783,8 @@ func (h *resetpwdCvmPollingHandler) Done(cvms []model.ServerDetail) (bool, *poll
 }
 
 // Poll ...
-func (h *resetpwdCvmPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]model.ServerDetail, error) {
+func (h *resetpwdCvmPollingHandler) Poll(client *HuaWeiImpl, kt *kit.Kit, cloudIDs []*string) (
+	[]model.ServerDetail, error) {
 	return poll(client, kt, h.region, cloudIDs)
 }
 
@@ -795,7 +798,8 @@ func (h *rebootCvmPollingHandler) Done(cvms []model.ServerDetail) (bool, *poller
 }
 
 // Poll ...
-func (h *rebootCvmPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]model.ServerDetail, error) {
+func (h *rebootCvmPollingHandler) Poll(client *HuaWeiImpl, kt *kit.Kit, cloudIDs []*string) (
+	[]model.ServerDetail, error) {
 	return poll(client, kt, h.region, cloudIDs)
 }
 
@@ -816,7 +820,7 @@ func done(cvms []model.ServerDetail, succeed string) (bool, *poller.BaseDoneResu
 	return flag, result
 }
 
-func poll(client *HuaWei, kt *kit.Kit, region string, cloudIDs []*string) ([]model.ServerDetail, error) {
+func poll(client *HuaWeiImpl, kt *kit.Kit, region string, cloudIDs []*string) ([]model.ServerDetail, error) {
 	cloudIDSplit := slice.Split(cloudIDs, core.HuaWeiQueryLimit)
 
 	cvms := make([]model.ServerDetail, 0, len(cloudIDs))
@@ -876,7 +880,8 @@ func (h *createCvmPollingHandler) Done(cvms []model.ServerDetail) (bool, *poller
 }
 
 // Poll ...
-func (h *createCvmPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]model.ServerDetail, error) {
+func (h *createCvmPollingHandler) Poll(client *HuaWeiImpl, kt *kit.Kit, cloudIDs []*string) (
+	[]model.ServerDetail, error) {
 
 	cloudIDSplit := slice.Split(cloudIDs, core.HuaWeiQueryLimit)
 
@@ -906,4 +911,4 @@ func (h *createCvmPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*
 	return cvms, nil
 }
 
-var _ poller.PollingHandler[*HuaWei, []model.ServerDetail, poller.BaseDoneResult] = new(createCvmPollingHandler)
+var _ poller.PollingHandler[*HuaWeiImpl, []model.ServerDetail, poller.BaseDoneResult] = new(createCvmPollingHandler)
@@ -0,0 +1,156 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+)
+
+// ListSnapshot 查询云盘快照，接口只支持按单个快照ID过滤，所以指定CloudIDs时逐个查询
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2009.html
+func (h *HuaWeiImpl) ListSnapshot(kt *kit.Kit, opt *typesnapshot.HuaWeiListOption) ([]typesnapshot.HuaWeiSnapshot,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "huawei snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opt.CloudIDs) == 0 {
+		req := &model.ListSnapshotsRequest{Limit: converter.ValToPtr(int32(core.HuaWeiQueryLimit))}
+		if opt.Page != nil {
+			req.Limit = converter.ValToPtr(opt.Page.Limit)
+			req.Offset = converter.ValToPtr(opt.Page.Offset)
+		}
+
+		resp, err := client.ListSnapshots(req)
+		if err != nil {
+			logs.Errorf("list huawei snapshot failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		return convSnapshots(resp.Snapshots), nil
+	}
+
+	snapshots := make([]typesnapshot.HuaWeiSnapshot, 0, len(opt.CloudIDs))
+	for _, id := range opt.CloudIDs {
+		resp, err := client.ListSnapshots(&model.ListSnapshotsRequest{Id: converter.ValToPtr(id)})
+		if err != nil {
+			logs.Errorf("list huawei snapshot failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+			return nil, err
+		}
+
+		snapshots = append(snapshots, convSnapshots(resp.Snapshots)...)
+	}
+
+	return snapshots, nil
+}
+
+func convSnapshots(list *[]model.SnapshotList) []typesnapshot.HuaWeiSnapshot {
+	if list == nil {
+		return make([]typesnapshot.HuaWeiSnapshot, 0)
+	}
+
+	snapshots := make([]typesnapshot.HuaWeiSnapshot, 0, len(*list))
+	for _, one := range *list {
+		snapshots = append(snapshots, typesnapshot.HuaWeiSnapshot{SnapshotList: one})
+	}
+
+	return snapshots
+}
+
+// CreateSnapshot 从云盘创建快照，返回快照的云上ID
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2005.html
+func (h *HuaWeiImpl) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.HuaWeiCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "huawei snapshot create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := &model.CreateSnapshotRequest{
+		Body: &model.CreateSnapshotRequestBody{
+			Snapshot: &model.CreateSnapshotOption{
+				VolumeId:    opt.CloudDiskID,
+				Name:        opt.Name,
+				Description: opt.Description,
+				// 允许对已挂载的云盘创建快照
+				Force: converter.ValToPtr(true),
+			},
+		},
+	}
+	resp, err := client.CreateSnapshot(req)
+	if err != nil {
+		logs.Errorf("create huawei snapshot failed, err: %v, disk: %s, rid: %s", err, opt.CloudDiskID, kt.Rid)
+		return "", err
+	}
+
+	if resp.Snapshot == nil {
+		return "", errf.New(errf.Unknown, "huawei create snapshot return empty snapshot")
+	}
+
+	return converter.PtrToVal(resp.Snapshot.Id), nil
+}
+
+// DeleteSnapshot 删除云盘快照
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2008.html
+func (h *HuaWeiImpl) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.HuaWeiDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "huawei snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DeleteSnapshot(&model.DeleteSnapshotRequest{SnapshotId: opt.CloudID}); err != nil {
+		logs.Errorf("delete huawei snapshot failed, err: %v, id: %s, rid: %s", err, opt.CloudID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
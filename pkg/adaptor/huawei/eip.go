@@ -35,7 +35,7 @@ import (
 
 // ListEip ...
 // reference: https://support.huaweicloud.com/api-eip/eip_api_0003.html
-func (h *HuaWei) ListEip(kt *kit.Kit, opt *eip.HuaWeiEipListOption) (*eip.HuaWeiEipListResult, error) {
+func (h *HuaWeiImpl) ListEip(kt *kit.Kit, opt *eip.HuaWeiEipListOption) (*eip.HuaWeiEipListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -111,7 +111,7 @@ func (h *HuaWei) ListEip(kt *kit.Kit, opt *eip.HuaWeiEipListOption) (*eip.HuaWei
 
 // DeleteEip ...
 // reference: https://support.huaweicloud.com/api-eip/eip_api_0005.html
-func (h *HuaWei) DeleteEip(kt *kit.Kit, opt *eip.HuaWeiEipDeleteOption) error {
+func (h *HuaWeiImpl) DeleteEip(kt *kit.Kit, opt *eip.HuaWeiEipDeleteOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "huawei eip delete option is required")
 	}
@@ -137,7 +137,7 @@ func (h *HuaWei) DeleteEip(kt *kit.Kit, opt *eip.HuaWeiEipDeleteOption) error {
 
 // AssociateEip ...
 // reference: https://support.huaweicloud.com/api-eip/eip_api_0004.html
-func (h *HuaWei) AssociateEip(kt *kit.Kit, opt *eip.HuaWeiEipAssociateOption) error {
+func (h *HuaWeiImpl) AssociateEip(kt *kit.Kit, opt *eip.HuaWeiEipAssociateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "huawei eip associate option is required")
 	}
@@ -158,7 +158,7 @@ func (h *HuaWei) AssociateEip(kt *kit.Kit, opt *eip.HuaWeiEipAssociateOption) er
 		return err
 	}
 
-	respPoller := poller.Poller[*HuaWei, []*eip.HuaWeiEip,
+	respPoller := poller.Poller[*HuaWeiImpl, []*eip.HuaWeiEip,
 		poller.BaseDoneResult]{Handler: &associateEipPollingHandler{region: opt.Region}}
 	_, err = respPoller.PollUntilDone(h, kt, []*string{&opt.CloudEipID}, nil)
 	if err != nil {
@@ -170,7 +170,7 @@ func (h *HuaWei) AssociateEip(kt *kit.Kit, opt *eip.HuaWeiEipAssociateOption) er
 
 // DisassociateEip ...
 // reference: https://support.huaweicloud.com/api-eip/eip_api_0004.html
-func (h *HuaWei) DisassociateEip(kt *kit.Kit, opt *eip.HuaWeiEipDisassociateOption) error {
+func (h *HuaWeiImpl) DisassociateEip(kt *kit.Kit, opt *eip.HuaWeiEipDisassociateOption) error {
 	if opt == nil {
 		return errf.New(errf.InvalidParameter, "huawei eip disassociate option is required")
 	}
@@ -191,7 +191,7 @@ func (h *HuaWei) DisassociateEip(kt *kit.Kit, opt *eip.HuaWeiEipDisassociateOpti
 		return err
 	}
 
-	respPoller := poller.Poller[*HuaWei, []*eip.HuaWeiEip,
+	respPoller := poller.Poller[*HuaWeiImpl, []*eip.HuaWeiEip,
 		poller.BaseDoneResult]{Handler: &disassociateEipPollingHandler{region: opt.Region}}
 	_, err = respPoller.PollUntilDone(h, kt, []*string{&opt.CloudEipID}, nil)
 	if err != nil {
@@ -204,7 +204,7 @@ func (h *HuaWei) DisassociateEip(kt *kit.Kit, opt *eip.HuaWeiEipDisassociateOpti
 // CreateEip ...
 // reference: https://support.huaweicloud.com/api-eip/eip_api_0001.html
 // https://support.huaweicloud.com/api-eip/eip_api_0006.html
-func (h *HuaWei) CreateEip(kt *kit.Kit, opt *eip.HuaWeiEipCreateOption) (*poller.BaseDoneResult, error) {
+func (h *HuaWeiImpl) CreateEip(kt *kit.Kit, opt *eip.HuaWeiEipCreateOption) (*poller.BaseDoneResult, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "huawei eip create option is required")
 	}
@@ -230,7 +230,7 @@ func (h *HuaWei) CreateEip(kt *kit.Kit, opt *eip.HuaWeiEipCreateOption) (*poller
 			return nil, err
 		}
 
-		respPoller := poller.Poller[*HuaWei, []*eip.HuaWeiEip,
+		respPoller := poller.Poller[*HuaWeiImpl, []*eip.HuaWeiEip,
 			poller.BaseDoneResult]{Handler: &createEipPollingHandler{region: opt.Region}}
 		return respPoller.PollUntilDone(h, kt, []*string{resp.PublicipId}, nil)
 	}
@@ -244,7 +244,7 @@ func (h *HuaWei) CreateEip(kt *kit.Kit, opt *eip.HuaWeiEipCreateOption) (*poller
 	if err != nil {
 		return nil, err
 	}
-	respPoller := poller.Poller[*HuaWei, []*eip.HuaWeiEip,
+	respPoller := poller.Poller[*HuaWeiImpl, []*eip.HuaWeiEip,
 		poller.BaseDoneResult]{Handler: &createEipPollingHandler{region: opt.Region}}
 	return respPoller.PollUntilDone(h, kt, []*string{resp.Publicip.Id}, nil)
 }
@@ -283,7 +283,7 @@ func (h *createEipPollingHandler) Done(pollResult []*eip.HuaWeiEip) (bool, *poll
 }
 
 // Poll ...
-func (h *createEipPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]*eip.HuaWeiEip, error) {
+func (h *createEipPollingHandler) Poll(client *HuaWeiImpl, kt *kit.Kit, cloudIDs []*string) ([]*eip.HuaWeiEip, error) {
 	cIDs := converter.PtrToSlice(cloudIDs)
 	result, err := client.ListEip(kt, &eip.HuaWeiEipListOption{Region: h.region, CloudIDs: cIDs})
 	if err != nil {
@@ -293,7 +293,7 @@ func (h *createEipPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*
 	return result.Details, nil
 }
 
-var _ poller.PollingHandler[*HuaWei, []*eip.HuaWeiEip, poller.BaseDoneResult] = new(createEipPollingHandler)
+var _ poller.PollingHandler[*HuaWeiImpl, []*eip.HuaWeiEip, poller.BaseDoneResult] = new(createEipPollingHandler)
 
 type associateEipPollingHandler struct {
 	region string
@@ -310,7 +310,8 @@ func (h *associateEipPollingHandler) Done(pollResult []*eip.HuaWeiEip) (bool, *p
 }
 
 // Poll ...
-func (h *associateEipPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]*eip.HuaWeiEip, error) {
+func (h *associateEipPollingHandler) Poll(client *HuaWeiImpl, kt *kit.Kit, cloudIDs []*string) (
+	[]*eip.HuaWeiEip, error) {
 	if len(cloudIDs) != 1 {
 		return nil, fmt.Errorf("poll only support one id param, but get %v. rid: %s", cloudIDs, kt.Rid)
 	}
@@ -340,7 +341,7 @@ func (h *disassociateEipPollingHandler) Done(pollResult []*eip.HuaWeiEip) (bool,
 
 // Poll ...
 func (h *disassociateEipPollingHandler) Poll(
-	client *HuaWei,
+	client *HuaWeiImpl,
 	kt *kit.Kit,
 	cloudIDs []*string,
 ) ([]*eip.HuaWeiEip, error) {
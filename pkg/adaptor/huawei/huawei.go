@@ -38,15 +38,15 @@ const (
 )
 
 // NewHuaWei new huawei.
-func NewHuaWei(s *types.BaseSecret) (*HuaWei, error) {
+func NewHuaWei(s *types.BaseSecret) (HuaWei, error) {
 	if err := validateSecret(s); err != nil {
 		return nil, err
 	}
-	return &HuaWei{clientSet: newClientSet(s)}, nil
+	return &HuaWeiImpl{clientSet: newClientSet(s)}, nil
 }
 
-// HuaWei is huawei operator.
-type HuaWei struct {
+// HuaWeiImpl is huawei operator.
+type HuaWeiImpl struct {
 	clientSet *clientSet
 }
 
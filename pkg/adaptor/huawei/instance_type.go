@@ -38,7 +38,7 @@ const (
 
 // ListInstanceType ...
 // reference: https://support.huaweicloud.com/api-ecs/zh-cn_topic_0020212656.html
-func (h *HuaWei) ListInstanceType(kt *kit.Kit, opt *typesinstancetype.HuaWeiInstanceTypeListOption) (
+func (h *HuaWeiImpl) ListInstanceType(kt *kit.Kit, opt *typesinstancetype.HuaWeiInstanceTypeListOption) (
 	[]*typesinstancetype.HuaWeiInstanceType, error,
 ) {
 
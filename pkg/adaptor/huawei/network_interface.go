@@ -35,7 +35,7 @@ import (
 
 // ListNetworkInterface 查看网卡列表
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-ecs/ecs_02_0505.html
-func (h *HuaWei) ListNetworkInterface(kt *kit.Kit, opt *typesniproto.HuaWeiNIListOption) (
+func (h *HuaWeiImpl) ListNetworkInterface(kt *kit.Kit, opt *typesniproto.HuaWeiNIListOption) (
 	*typesniproto.HuaWeiInterfaceListResult, error) {
 
 	client, err := h.clientSet.ecsClient(opt.Region)
@@ -75,7 +75,7 @@ func (h *HuaWei) ListNetworkInterface(kt *kit.Kit, opt *typesniproto.HuaWeiNILis
 }
 
 // replenishEipInfo replenish eip info
-func (h *HuaWei) replenishEipInfo(kt *kit.Kit, opt *typesniproto.HuaWeiNIListOption, vnicPortIDs []string,
+func (h *HuaWeiImpl) replenishEipInfo(kt *kit.Kit, opt *typesniproto.HuaWeiNIListOption, vnicPortIDs []string,
 	details []typesniproto.HuaWeiNI) []typesniproto.HuaWeiNI {
 
 	// 获取弹性IP信息
@@ -111,7 +111,7 @@ func (h *HuaWei) replenishEipInfo(kt *kit.Kit, opt *typesniproto.HuaWeiNIListOpt
 	return list
 }
 
-func (h *HuaWei) convertCloudNetworkInterface(kt *kit.Kit, opt *typesniproto.HuaWeiNIListOption,
+func (h *HuaWeiImpl) convertCloudNetworkInterface(kt *kit.Kit, opt *typesniproto.HuaWeiNIListOption,
 	data *ecsmodel.InterfaceAttachment) (*typesniproto.HuaWeiNI, error) {
 
 	if data == nil {
@@ -185,7 +185,7 @@ func (h *HuaWei) convertCloudNetworkInterface(kt *kit.Kit, opt *typesniproto.Hua
 }
 
 // GetPublicIpsMapByPortIDs get public ips map
-func (h *HuaWei) GetPublicIpsMapByPortIDs(kt *kit.Kit, opt *typesniproto.HuaWeiEipListOption) (
+func (h *HuaWeiImpl) GetPublicIpsMapByPortIDs(kt *kit.Kit, opt *typesniproto.HuaWeiEipListOption) (
 	map[string]*coreni.EipNetwork, error) {
 
 	client, err := h.clientSet.eipV3Client(opt.Region)
@@ -229,7 +229,7 @@ func (h *HuaWei) GetPublicIpsMapByPortIDs(kt *kit.Kit, opt *typesniproto.HuaWeiE
 }
 
 // GetSecurityGroupsByPortID get security groups by port id
-func (h *HuaWei) GetSecurityGroupsByPortID(kt *kit.Kit, opt *typesniproto.HuaWeiPortInfoOption) (
+func (h *HuaWeiImpl) GetSecurityGroupsByPortID(kt *kit.Kit, opt *typesniproto.HuaWeiPortInfoOption) (
 	map[string][]string, error) {
 
 	client, err := h.clientSet.vpcClientV2(opt.Region)
@@ -261,7 +261,7 @@ func (h *HuaWei) GetSecurityGroupsByPortID(kt *kit.Kit, opt *typesniproto.HuaWei
 }
 
 // GetSecurityGroupsByNetID get security groups by net id
-func (h *HuaWei) GetSecurityGroupsByNetID(kt *kit.Kit, opt *typesniproto.HuaWeiPortInfoOption) (
+func (h *HuaWeiImpl) GetSecurityGroupsByNetID(kt *kit.Kit, opt *typesniproto.HuaWeiPortInfoOption) (
 	map[string][]string, []coreni.NetVirtualIP, error) {
 
 	client, err := h.clientSet.vpcClientV2(opt.Region)
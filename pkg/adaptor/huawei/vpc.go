@@ -40,7 +40,7 @@ import (
 // CreateVpc create vpc.
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-vpc/vpc_api01_0001.html
 // TODO returns created vpc after sdk supports (API docs returns created vpc info)
-func (h *HuaWei) CreateVpc(kt *kit.Kit, opt *types.HuaWeiVpcCreateOption) error {
+func (h *HuaWeiImpl) CreateVpc(kt *kit.Kit, opt *types.HuaWeiVpcCreateOption) error {
 	if err := opt.Validate(); err != nil {
 		return err
 	}
@@ -70,7 +70,7 @@ func (h *HuaWei) CreateVpc(kt *kit.Kit, opt *types.HuaWeiVpcCreateOption) error
 	handler := &createVpcPollingHandler{
 		opt.Extension.Region,
 	}
-	respPoller := poller.Poller[*HuaWei, []model.Vpc, []model.Vpc]{Handler: handler}
+	respPoller := poller.Poller[*HuaWeiImpl, []model.Vpc, []model.Vpc]{Handler: handler}
 	_, err = respPoller.PollUntilDone(h, kt, []*string{converter.ValToPtr(resp.Vpc.Id)},
 		types.NewBatchCreateVpcPollerOption())
 	if err != nil {
@@ -82,7 +82,7 @@ func (h *HuaWei) CreateVpc(kt *kit.Kit, opt *types.HuaWeiVpcCreateOption) error
 
 // UpdateVpc update vpc.
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-vpc/vpc_api01_0004.html
-func (h *HuaWei) UpdateVpc(kt *kit.Kit, opt *types.HuaWeiVpcUpdateOption) error {
+func (h *HuaWeiImpl) UpdateVpc(kt *kit.Kit, opt *types.HuaWeiVpcUpdateOption) error {
 	if err := opt.Validate(); err != nil {
 		return err
 	}
@@ -112,7 +112,7 @@ func (h *HuaWei) UpdateVpc(kt *kit.Kit, opt *types.HuaWeiVpcUpdateOption) error
 
 // DeleteVpc delete vpc.
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-vpc/vpc_api01_0005.html
-func (h *HuaWei) DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+func (h *HuaWeiImpl) DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
 	if err := opt.Validate(); err != nil {
 		return err
 	}
@@ -137,7 +137,7 @@ func (h *HuaWei) DeleteVpc(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) erro
 
 // ListVpcRaw list vpc raw.
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-vpc/vpc_apiv3_0003.html
-func (h *HuaWei) ListVpcRaw(kt *kit.Kit, opt *types.HuaWeiVpcListOption) (*model.ListVpcsResponse, error) {
+func (h *HuaWeiImpl) ListVpcRaw(kt *kit.Kit, opt *types.HuaWeiVpcListOption) (*model.ListVpcsResponse, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -176,7 +176,7 @@ func (h *HuaWei) ListVpcRaw(kt *kit.Kit, opt *types.HuaWeiVpcListOption) (*model
 
 // ListVpc list vpc.
 // reference: https://support.huaweicloud.com/intl/zh-cn/api-vpc/vpc_apiv3_0003.html
-func (h *HuaWei) ListVpc(kt *kit.Kit, opt *types.HuaWeiVpcListOption) (*types.HuaWeiVpcListResult, error) {
+func (h *HuaWeiImpl) ListVpc(kt *kit.Kit, opt *types.HuaWeiVpcListOption) (*types.HuaWeiVpcListResult, error) {
 	if err := opt.Validate(); err != nil {
 		return nil, err
 	}
@@ -276,7 +276,7 @@ func (h *createVpcPollingHandler) Done(vpcs []model.Vpc) (bool, *[]model.Vpc) {
 }
 
 // Poll ...
-func (h *createVpcPollingHandler) Poll(client *HuaWei, kt *kit.Kit, cloudIDs []*string) ([]model.Vpc, error) {
+func (h *createVpcPollingHandler) Poll(client *HuaWeiImpl, kt *kit.Kit, cloudIDs []*string) ([]model.Vpc, error) {
 	cloudIDSplit := slice.Split(cloudIDs, core.HuaWeiQueryLimit)
 
 	vpcs := make([]model.Vpc, 0, len(cloudIDs))
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/adaptor/cassette"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+// newReplayHuaWei 创建通过 cassette 录制/回放 API 调用的 huawei, 默认回放 testdata 下的 cassette 文件,
+// 设置环境变量 HCM_CASSETTE_MODE=record 以及 HUAWEI_SECRET_ID、HUAWEI_SECRET_KEY 时会请求真实云厂商并重新录制.
+func newReplayHuaWei(t *testing.T, path string) (HuaWei, *cassette.Recorder) {
+	rec := cassette.UseForTest(t, path)
+
+	id, key := rec.SecretForTest(t, "HUAWEI_SECRET_ID", "HUAWEI_SECRET_KEY")
+	cli, err := NewHuaWei(&types.BaseSecret{CloudSecretID: id, CloudSecretKey: key})
+	if err != nil {
+		t.Fatalf("new huawei failed, err: %v", err)
+	}
+
+	return cli, rec
+}
+
+func TestListVpc(t *testing.T) {
+	cli, rec := newReplayHuaWei(t, "testdata/list_vpc.json")
+	kt := kit.New()
+
+	ids := make([]string, 0)
+	page := &core.HuaWeiPage{Limit: converter.ValToPtr(int32(2))}
+	for {
+		result, err := cli.ListVpc(kt, &types.HuaWeiVpcListOption{
+			HuaWeiListOption: core.HuaWeiListOption{Region: "cn-south-1", Page: page},
+		})
+		if err != nil {
+			t.Fatalf("list vpc failed, err: %v", err)
+		}
+
+		for _, one := range result.Details {
+			ids = append(ids, one.CloudID)
+		}
+
+		if result.NextMarker == nil {
+			break
+		}
+		page.Marker = result.NextMarker
+	}
+
+	if strings.Join(ids, ",") != "vpc-00000001,vpc-00000002,vpc-00000003" {
+		t.Errorf("list vpc by page got unexpected vpcs: %v", ids)
+	}
+
+	if rec.Mode() != cassette.ModeReplay {
+		return
+	}
+
+	if rec.Unused() != 0 {
+		t.Errorf("%d interactions in cassette are not replayed", rec.Unused())
+	}
+
+	// 回放模式下没有匹配的交互记录时, 请求失败而不会发往云厂商
+	_, err := cli.ListVpc(kt, &types.HuaWeiVpcListOption{
+		HuaWeiListOption: core.HuaWeiListOption{Region: "cn-south-1", CloudIDs: []string{"vpc-not-recorded"},
+			Page: &core.HuaWeiPage{Limit: converter.ValToPtr(int32(2))}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "no unused interaction matched") {
+		t.Errorf("list vpc without recorded interaction should be failed, but got: %v", err)
+	}
+}
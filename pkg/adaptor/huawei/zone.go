@@ -33,7 +33,7 @@ import (
 
 // ListZone list zone.
 // reference: https://support.huaweicloud.com/api-dcs/ListAvailableZones.html
-func (h *HuaWei) ListZone(kt *kit.Kit, opt *typeszone.HuaWeiZoneListOption) ([]typeszone.HuaWeiZone, error) {
+func (h *HuaWeiImpl) ListZone(kt *kit.Kit, opt *typeszone.HuaWeiZoneListOption) ([]typeszone.HuaWeiZone, error) {
 
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "huawei zone list option is required")
@@ -12,9 +12,11 @@ import (
 	core "hcm/pkg/adaptor/types/core"
 	cvm "hcm/pkg/adaptor/types/cvm"
 	disk "hcm/pkg/adaptor/types/disk"
+	disksnapshot "hcm/pkg/adaptor/types/disk-snapshot"
 	eip "hcm/pkg/adaptor/types/eip"
 	image "hcm/pkg/adaptor/types/image"
 	instancetype "hcm/pkg/adaptor/types/instance-type"
+	loadbalancer "hcm/pkg/adaptor/types/load-balancer"
 	region "hcm/pkg/adaptor/types/region"
 	routetable "hcm/pkg/adaptor/types/route-table"
 	securitygroup "hcm/pkg/adaptor/types/security-group"
@@ -559,6 +561,45 @@ func (c *TCloudCreateEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipCreateO
 	return c
 }
 
+// CreateLoadBalancer mocks base method.
+func (m *MockTCloud) CreateLoadBalancer(kt *kit.Kit, opt *loadbalancer.TCloudCreateOption) (*poller.BaseDoneResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoadBalancer", kt, opt)
+	ret0, _ := ret[0].(*poller.BaseDoneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoadBalancer indicates an expected call of CreateLoadBalancer.
+func (mr *MockTCloudMockRecorder) CreateLoadBalancer(kt, opt interface{}) *TCloudCreateLoadBalancerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockTCloud)(nil).CreateLoadBalancer), kt, opt)
+	return &TCloudCreateLoadBalancerCall{Call: call}
+}
+
+// TCloudCreateLoadBalancerCall wrap *gomock.Call
+type TCloudCreateLoadBalancerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudCreateLoadBalancerCall) Return(arg0 *poller.BaseDoneResult, arg1 error) *TCloudCreateLoadBalancerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudCreateLoadBalancerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateLoadBalancerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudCreateLoadBalancerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudCreateOption) (*poller.BaseDoneResult, error)) *TCloudCreateLoadBalancerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // CreateSecurityGroup mocks base method.
 func (m *MockTCloud) CreateSecurityGroup(kt *kit.Kit, opt *securitygroup.TCloudCreateOption) (*v20170312.SecurityGroup, error) {
 	m.ctrl.T.Helper()
@@ -636,6 +677,45 @@ func (c *TCloudCreateSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securi
 	return c
 }
 
+// CreateSnapshot mocks base method.
+func (m *MockTCloud) CreateSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudCreateOption) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSnapshot", kt, opt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSnapshot indicates an expected call of CreateSnapshot.
+func (mr *MockTCloudMockRecorder) CreateSnapshot(kt, opt interface{}) *TCloudCreateSnapshotCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSnapshot", reflect.TypeOf((*MockTCloud)(nil).CreateSnapshot), kt, opt)
+	return &TCloudCreateSnapshotCall{Call: call}
+}
+
+// TCloudCreateSnapshotCall wrap *gomock.Call
+type TCloudCreateSnapshotCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudCreateSnapshotCall) Return(arg0 string, arg1 error) *TCloudCreateSnapshotCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudCreateSnapshotCall) Do(f func(*kit.Kit, *disksnapshot.TCloudCreateOption) (string, error)) *TCloudCreateSnapshotCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudCreateSnapshotCall) DoAndReturn(f func(*kit.Kit, *disksnapshot.TCloudCreateOption) (string, error)) *TCloudCreateSnapshotCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // CreateSubnet mocks base method.
 func (m *MockTCloud) CreateSubnet(kt *kit.Kit, opt *adtysubnet.TCloudSubnetCreateOption) (*adtysubnet.TCloudSubnet, error) {
 	m.ctrl.T.Helper()
@@ -867,6 +947,44 @@ func (c *TCloudDeleteEipCall) DoAndReturn(f func(*kit.Kit, *eip.TCloudEipDeleteO
 	return c
 }
 
+// DeleteLoadBalancer mocks base method.
+func (m *MockTCloud) DeleteLoadBalancer(kt *kit.Kit, opt *loadbalancer.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoadBalancer", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoadBalancer indicates an expected call of DeleteLoadBalancer.
+func (mr *MockTCloudMockRecorder) DeleteLoadBalancer(kt, opt interface{}) *TCloudDeleteLoadBalancerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancer", reflect.TypeOf((*MockTCloud)(nil).DeleteLoadBalancer), kt, opt)
+	return &TCloudDeleteLoadBalancerCall{Call: call}
+}
+
+// TCloudDeleteLoadBalancerCall wrap *gomock.Call
+type TCloudDeleteLoadBalancerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteLoadBalancerCall) Return(arg0 error) *TCloudDeleteLoadBalancerCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteLoadBalancerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudDeleteOption) error) *TCloudDeleteLoadBalancerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteLoadBalancerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudDeleteOption) error) *TCloudDeleteLoadBalancerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // DeleteRouteTable mocks base method.
 func (m *MockTCloud) DeleteRouteTable(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
 	m.ctrl.T.Helper()
@@ -981,6 +1099,44 @@ func (c *TCloudDeleteSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *securi
 	return c
 }
 
+// DeleteSnapshot mocks base method.
+func (m *MockTCloud) DeleteSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudDeleteOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSnapshot", kt, opt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSnapshot indicates an expected call of DeleteSnapshot.
+func (mr *MockTCloudMockRecorder) DeleteSnapshot(kt, opt interface{}) *TCloudDeleteSnapshotCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSnapshot", reflect.TypeOf((*MockTCloud)(nil).DeleteSnapshot), kt, opt)
+	return &TCloudDeleteSnapshotCall{Call: call}
+}
+
+// TCloudDeleteSnapshotCall wrap *gomock.Call
+type TCloudDeleteSnapshotCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudDeleteSnapshotCall) Return(arg0 error) *TCloudDeleteSnapshotCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudDeleteSnapshotCall) Do(f func(*kit.Kit, *disksnapshot.TCloudDeleteOption) error) *TCloudDeleteSnapshotCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudDeleteSnapshotCall) DoAndReturn(f func(*kit.Kit, *disksnapshot.TCloudDeleteOption) error) *TCloudDeleteSnapshotCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // DeleteSubnet mocks base method.
 func (m *MockTCloud) DeleteSubnet(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
 	m.ctrl.T.Helper()
@@ -1602,6 +1758,84 @@ func (c *TCloudListInstanceTypeCall) DoAndReturn(f func(*kit.Kit, *instancetype.
 	return c
 }
 
+// ListListener mocks base method.
+func (m *MockTCloud) ListListener(kt *kit.Kit, opt *loadbalancer.TCloudListenerListOption) ([]loadbalancer.TCloudListener, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListListener", kt, opt)
+	ret0, _ := ret[0].([]loadbalancer.TCloudListener)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListListener indicates an expected call of ListListener.
+func (mr *MockTCloudMockRecorder) ListListener(kt, opt interface{}) *TCloudListListenerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListListener", reflect.TypeOf((*MockTCloud)(nil).ListListener), kt, opt)
+	return &TCloudListListenerCall{Call: call}
+}
+
+// TCloudListListenerCall wrap *gomock.Call
+type TCloudListListenerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListListenerCall) Return(arg0 []loadbalancer.TCloudListener, arg1 error) *TCloudListListenerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListListenerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudListenerListOption) ([]loadbalancer.TCloudListener, error)) *TCloudListListenerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListListenerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudListenerListOption) ([]loadbalancer.TCloudListener, error)) *TCloudListListenerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListLoadBalancer mocks base method.
+func (m *MockTCloud) ListLoadBalancer(kt *kit.Kit, opt *loadbalancer.TCloudListOption) ([]loadbalancer.TCloudLoadBalancer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoadBalancer", kt, opt)
+	ret0, _ := ret[0].([]loadbalancer.TCloudLoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoadBalancer indicates an expected call of ListLoadBalancer.
+func (mr *MockTCloudMockRecorder) ListLoadBalancer(kt, opt interface{}) *TCloudListLoadBalancerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancer", reflect.TypeOf((*MockTCloud)(nil).ListLoadBalancer), kt, opt)
+	return &TCloudListLoadBalancerCall{Call: call}
+}
+
+// TCloudListLoadBalancerCall wrap *gomock.Call
+type TCloudListLoadBalancerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListLoadBalancerCall) Return(arg0 []loadbalancer.TCloudLoadBalancer, arg1 error) *TCloudListLoadBalancerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListLoadBalancerCall) Do(f func(*kit.Kit, *loadbalancer.TCloudListOption) ([]loadbalancer.TCloudLoadBalancer, error)) *TCloudListLoadBalancerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListLoadBalancerCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudListOption) ([]loadbalancer.TCloudLoadBalancer, error)) *TCloudListLoadBalancerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListPoliciesGrantingServiceAccess mocks base method.
 func (m *MockTCloud) ListPoliciesGrantingServiceAccess(kt *kit.Kit, opt *account.TCloudListPolicyOption) ([]*v20190116.ListGrantServiceAccessNode, error) {
 	m.ctrl.T.Helper()
@@ -1797,6 +2031,45 @@ func (c *TCloudListSecurityGroupRuleCall) DoAndReturn(f func(*kit.Kit, *security
 	return c
 }
 
+// ListSnapshot mocks base method.
+func (m *MockTCloud) ListSnapshot(kt *kit.Kit, opt *disksnapshot.TCloudListOption) ([]disksnapshot.TCloudSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSnapshot", kt, opt)
+	ret0, _ := ret[0].([]disksnapshot.TCloudSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSnapshot indicates an expected call of ListSnapshot.
+func (mr *MockTCloudMockRecorder) ListSnapshot(kt, opt interface{}) *TCloudListSnapshotCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSnapshot", reflect.TypeOf((*MockTCloud)(nil).ListSnapshot), kt, opt)
+	return &TCloudListSnapshotCall{Call: call}
+}
+
+// TCloudListSnapshotCall wrap *gomock.Call
+type TCloudListSnapshotCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListSnapshotCall) Return(arg0 []disksnapshot.TCloudSnapshot, arg1 error) *TCloudListSnapshotCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListSnapshotCall) Do(f func(*kit.Kit, *disksnapshot.TCloudListOption) ([]disksnapshot.TCloudSnapshot, error)) *TCloudListSnapshotCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListSnapshotCall) DoAndReturn(f func(*kit.Kit, *disksnapshot.TCloudListOption) ([]disksnapshot.TCloudSnapshot, error)) *TCloudListSnapshotCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListSubnet mocks base method.
 func (m *MockTCloud) ListSubnet(kt *kit.Kit, opt *core.TCloudListOption) (*adtysubnet.TCloudSubnetListResult, error) {
 	m.ctrl.T.Helper()
@@ -1836,6 +2109,84 @@ func (c *TCloudListSubnetCall) DoAndReturn(f func(*kit.Kit, *core.TCloudListOpti
 	return c
 }
 
+// ListTarget mocks base method.
+func (m *MockTCloud) ListTarget(kt *kit.Kit, opt *loadbalancer.TCloudListenerListOption) ([]loadbalancer.TCloudTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTarget", kt, opt)
+	ret0, _ := ret[0].([]loadbalancer.TCloudTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTarget indicates an expected call of ListTarget.
+func (mr *MockTCloudMockRecorder) ListTarget(kt, opt interface{}) *TCloudListTargetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTarget", reflect.TypeOf((*MockTCloud)(nil).ListTarget), kt, opt)
+	return &TCloudListTargetCall{Call: call}
+}
+
+// TCloudListTargetCall wrap *gomock.Call
+type TCloudListTargetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListTargetCall) Return(arg0 []loadbalancer.TCloudTarget, arg1 error) *TCloudListTargetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListTargetCall) Do(f func(*kit.Kit, *loadbalancer.TCloudListenerListOption) ([]loadbalancer.TCloudTarget, error)) *TCloudListTargetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListTargetCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudListenerListOption) ([]loadbalancer.TCloudTarget, error)) *TCloudListTargetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListTargetGroup mocks base method.
+func (m *MockTCloud) ListTargetGroup(kt *kit.Kit, opt *loadbalancer.TCloudListOption) ([]loadbalancer.TCloudTargetGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTargetGroup", kt, opt)
+	ret0, _ := ret[0].([]loadbalancer.TCloudTargetGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTargetGroup indicates an expected call of ListTargetGroup.
+func (mr *MockTCloudMockRecorder) ListTargetGroup(kt, opt interface{}) *TCloudListTargetGroupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTargetGroup", reflect.TypeOf((*MockTCloud)(nil).ListTargetGroup), kt, opt)
+	return &TCloudListTargetGroupCall{Call: call}
+}
+
+// TCloudListTargetGroupCall wrap *gomock.Call
+type TCloudListTargetGroupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *TCloudListTargetGroupCall) Return(arg0 []loadbalancer.TCloudTargetGroup, arg1 error) *TCloudListTargetGroupCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *TCloudListTargetGroupCall) Do(f func(*kit.Kit, *loadbalancer.TCloudListOption) ([]loadbalancer.TCloudTargetGroup, error)) *TCloudListTargetGroupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *TCloudListTargetGroupCall) DoAndReturn(f func(*kit.Kit, *loadbalancer.TCloudListOption) ([]loadbalancer.TCloudTargetGroup, error)) *TCloudListTargetGroupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ListVpc mocks base method.
 func (m *MockTCloud) ListVpc(kt *kit.Kit, opt *core.TCloudListOption) (*types.TCloudVpcListResult, error) {
 	m.ctrl.T.Helper()
@@ -20,6 +20,9 @@
 package tcloud
 
 import (
+	"net/http"
+
+	"hcm/pkg/adaptor/cassette"
 	"hcm/pkg/adaptor/types"
 
 	billing "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/billing/v20180709"
@@ -38,12 +41,22 @@ const (
 type clientSet struct {
 	credential *common.Credential
 	profile    *profile.ClientProfile
+	// transport 非空时 sdk 使用该 transport 发送请求, 用于录制/回放云厂商 API 调用.
+	transport http.RoundTripper
 }
 
 func newClientSet(s *types.BaseSecret, profile *profile.ClientProfile) *clientSet {
 	return &clientSet{
 		credential: common.NewCredential(s.CloudSecretID, s.CloudSecretKey),
 		profile:    profile,
+		transport:  cassette.Transport(),
+	}
+}
+
+// setTransport 设置 sdk client 发送请求使用的 transport, 未设置时使用 sdk 默认 transport.
+func (c *clientSet) setTransport(client *common.Client) {
+	if c.transport != nil {
+		client.WithHttpTransport(c.transport)
 	}
 }
 
@@ -53,6 +66,8 @@ func (c *clientSet) camServiceClient(region string) (*cam.Client, error) {
 		return nil, err
 	}
 
+	c.setTransport(&client.Client)
+
 	return client, nil
 }
 
@@ -62,6 +77,8 @@ func (c *clientSet) cvmClient(region string) (*cvm.Client, error) {
 		return nil, err
 	}
 
+	c.setTransport(&client.Client)
+
 	return client, nil
 }
 
@@ -71,6 +88,8 @@ func (c *clientSet) cbsClient(region string) (*cbs.Client, error) {
 		return nil, err
 	}
 
+	c.setTransport(&client.Client)
+
 	return client, nil
 }
 
@@ -80,6 +99,17 @@ func (c *clientSet) vpcClient(region string) (*vpc.Client, error) {
 		return nil, err
 	}
 
+	c.setTransport(&client.Client)
+
+	return client, nil
+}
+
+// clbClient 负载均衡 sdk 未引入, 使用通用 client 调用负载均衡接口.
+func (c *clientSet) clbClient(region string) (*common.Client, error) {
+	client := common.NewCommonClient(c.credential, region, c.profile)
+
+	c.setTransport(client)
+
 	return client, nil
 }
 
@@ -89,5 +119,7 @@ func (c *clientSet) billClient() (*billing.Client, error) {
 		return nil, err
 	}
 
+	c.setTransport(&client.Client)
+
 	return client, nil
 }
@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/adaptor/cassette"
+	"hcm/pkg/adaptor/types"
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/kit"
+)
+
+// newReplayTCloud 创建通过 cassette 录制/回放 API 调用的 tcloud, 默认回放 testdata 下的 cassette 文件,
+// 设置环境变量 HCM_CASSETTE_MODE=record 以及 TCLOUD_SECRET_ID、TCLOUD_SECRET_KEY 时会请求真实云厂商并重新录制.
+func newReplayTCloud(t *testing.T, path string) (TCloud, *cassette.Recorder) {
+	rec := cassette.UseForTest(t, path)
+
+	id, key := rec.SecretForTest(t, "TCLOUD_SECRET_ID", "TCLOUD_SECRET_KEY")
+	cli, err := NewTCloud(&types.BaseSecret{CloudSecretID: id, CloudSecretKey: key})
+	if err != nil {
+		t.Fatalf("new tcloud failed, err: %v", err)
+	}
+
+	return cli, rec
+}
+
+func TestListCvm(t *testing.T) {
+	cli, rec := newReplayTCloud(t, "testdata/list_cvm.json")
+	kt := kit.New()
+
+	ids := make([]string, 0)
+	page := &core.TCloudPage{Offset: 0, Limit: 2}
+	for {
+		cvms, err := cli.ListCvm(kt, &typecvm.TCloudListOption{Region: "ap-guangzhou", Page: page})
+		if err != nil {
+			t.Fatalf("list cvm failed, err: %v", err)
+		}
+
+		for _, one := range cvms {
+			ids = append(ids, *one.InstanceId)
+		}
+
+		if uint64(len(cvms)) < page.Limit {
+			break
+		}
+		page.Offset += page.Limit
+	}
+
+	if strings.Join(ids, ",") != "ins-00000001,ins-00000002,ins-00000003" {
+		t.Errorf("list cvm by page got unexpected cvms: %v", ids)
+	}
+
+	_, err := cli.ListCvm(kt, &typecvm.TCloudListOption{Region: "ap-guangzhou", CloudIDs: []string{"ins-invalid"}})
+	if err == nil || !strings.Contains(err.Error(), "Code=InvalidInstanceId.Malformed") {
+		t.Errorf("list cvm by invalid id should return malformed error, but got: %v", err)
+	}
+
+	if rec.Mode() == cassette.ModeReplay && rec.Unused() != 0 {
+		t.Errorf("%d interactions in cassette are not replayed", rec.Unused())
+	}
+}
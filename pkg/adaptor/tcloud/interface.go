@@ -29,9 +29,11 @@ import (
 	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/cvm"
 	"hcm/pkg/adaptor/types/disk"
+	typesnapshot "hcm/pkg/adaptor/types/disk-snapshot"
 	"hcm/pkg/adaptor/types/eip"
 	"hcm/pkg/adaptor/types/image"
 	"hcm/pkg/adaptor/types/instance-type"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
 	"hcm/pkg/adaptor/types/region"
 	"hcm/pkg/adaptor/types/route-table"
 	"hcm/pkg/adaptor/types/security-group"
@@ -81,6 +83,9 @@ type TCloud interface {
 	DeleteDisk(kt *kit.Kit, opt *disk.TCloudDiskDeleteOption) error
 	AttachDisk(kt *kit.Kit, opt *disk.TCloudDiskAttachOption) error
 	DetachDisk(kt *kit.Kit, opt *disk.TCloudDiskDetachOption) error
+	ListSnapshot(kt *kit.Kit, opt *typesnapshot.TCloudListOption) ([]typesnapshot.TCloudSnapshot, error)
+	CreateSnapshot(kt *kit.Kit, opt *typesnapshot.TCloudCreateOption) (string, error)
+	DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.TCloudDeleteOption) error
 	ListEip(kt *kit.Kit, opt *eip.TCloudEipListOption) (*eip.TCloudEipListResult, error)
 	CountEip(kt *kit.Kit, region string) (int32, error)
 	DeleteEip(kt *kit.Kit, opt *eip.TCloudEipDeleteOption) error
@@ -122,4 +127,10 @@ type TCloud interface {
 		*cvm.InquiryPriceResult, error)
 	ListPoliciesGrantingServiceAccess(kt *kit.Kit, opt *account.TCloudListPolicyOption) (
 		[]*v20190116.ListGrantServiceAccessNode, error)
+	ListLoadBalancer(kt *kit.Kit, opt *typelb.TCloudListOption) ([]typelb.TCloudLoadBalancer, error)
+	CreateLoadBalancer(kt *kit.Kit, opt *typelb.TCloudCreateOption) (*poller.BaseDoneResult, error)
+	DeleteLoadBalancer(kt *kit.Kit, opt *typelb.TCloudDeleteOption) error
+	ListListener(kt *kit.Kit, opt *typelb.TCloudListenerListOption) ([]typelb.TCloudListener, error)
+	ListTarget(kt *kit.Kit, opt *typelb.TCloudListenerListOption) ([]typelb.TCloudTarget, error)
+	ListTargetGroup(kt *kit.Kit, opt *typelb.TCloudListOption) ([]typelb.TCloudTargetGroup, error)
 }
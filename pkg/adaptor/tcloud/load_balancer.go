@@ -0,0 +1,399 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/poller"
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+/*
+	clb 的 sdk 未引入, 负载均衡相关接口的请求、返回结构参照 clb/v20180317 sdk 定义, 通过通用的 common.Client 调用.
+*/
+
+const (
+	clbService = "clb"
+	clbVersion = "2018-03-17"
+)
+
+func newClbRequest(kt *kit.Kit, action string) *tchttp.BaseRequest {
+	req := new(tchttp.BaseRequest)
+	req.Init().WithApiInfo(clbService, clbVersion, action)
+	req.SetContext(kt.Ctx)
+	return req
+}
+
+type describeLoadBalancersRequest struct {
+	*tchttp.BaseRequest
+	LoadBalancerIds []*string `json:"LoadBalancerIds,omitempty" name:"LoadBalancerIds"`
+	Offset          *int64    `json:"Offset,omitempty" name:"Offset"`
+	Limit           *int64    `json:"Limit,omitempty" name:"Limit"`
+}
+
+type describeLoadBalancersResponse struct {
+	*tchttp.BaseResponse
+	Response *struct {
+		TotalCount      *uint64                      `json:"TotalCount,omitempty"`
+		LoadBalancerSet []*typelb.TCloudLoadBalancer `json:"LoadBalancerSet,omitempty"`
+		RequestId       *string                      `json:"RequestId,omitempty"`
+	} `json:"Response"`
+}
+
+// ListLoadBalancer list load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30685
+func (t *TCloudImpl) ListLoadBalancer(kt *kit.Kit, opt *typelb.TCloudListOption) ([]typelb.TCloudLoadBalancer,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.clbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud clb client failed, err: %v", err)
+	}
+
+	req := &describeLoadBalancersRequest{BaseRequest: newClbRequest(kt, "DescribeLoadBalancers")}
+	if len(opt.CloudIDs) != 0 {
+		req.LoadBalancerIds = common.StringPtrs(opt.CloudIDs)
+		req.Limit = common.Int64Ptr(int64(core.TCloudQueryLimit))
+	}
+
+	if opt.Page != nil {
+		req.Offset = common.Int64Ptr(int64(opt.Page.Offset))
+		req.Limit = common.Int64Ptr(int64(opt.Page.Limit))
+	}
+
+	resp := &describeLoadBalancersResponse{BaseResponse: new(tchttp.BaseResponse)}
+	if err = client.Send(req, resp); err != nil {
+		logs.Errorf("list tcloud load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	lbs := make([]typelb.TCloudLoadBalancer, 0, len(resp.Response.LoadBalancerSet))
+	for _, one := range resp.Response.LoadBalancerSet {
+		lbs = append(lbs, converter.PtrToVal(one))
+	}
+
+	return lbs, nil
+}
+
+type createLoadBalancerRequest struct {
+	*tchttp.BaseRequest
+	LoadBalancerType *string `json:"LoadBalancerType,omitempty" name:"LoadBalancerType"`
+	Forward          *int64  `json:"Forward,omitempty" name:"Forward"`
+	LoadBalancerName *string `json:"LoadBalancerName,omitempty" name:"LoadBalancerName"`
+	VpcId            *string `json:"VpcId,omitempty" name:"VpcId"`
+	SubnetId         *string `json:"SubnetId,omitempty" name:"SubnetId"`
+	AddressIPVersion *string `json:"AddressIPVersion,omitempty" name:"AddressIPVersion"`
+	Number           *uint64 `json:"Number,omitempty" name:"Number"`
+	MasterZoneId     *string `json:"MasterZoneId,omitempty" name:"MasterZoneId"`
+}
+
+type createLoadBalancerResponse struct {
+	*tchttp.BaseResponse
+	Response *struct {
+		LoadBalancerIds []*string `json:"LoadBalancerIds,omitempty"`
+		DealName        *string   `json:"DealName,omitempty"`
+		RequestId       *string   `json:"RequestId,omitempty"`
+	} `json:"Response"`
+}
+
+// CreateLoadBalancer create load balancer, only load balancer(not classic load balancer) can be created.
+// reference: https://cloud.tencent.com/document/api/214/30692
+func (t *TCloudImpl) CreateLoadBalancer(kt *kit.Kit, opt *typelb.TCloudCreateOption) (*poller.BaseDoneResult,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.clbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud clb client failed, err: %v", err)
+	}
+
+	req := &createLoadBalancerRequest{
+		BaseRequest:      newClbRequest(kt, "CreateLoadBalancer"),
+		LoadBalancerType: common.StringPtr(opt.LoadBalancerType),
+		Forward:          common.Int64Ptr(1),
+		LoadBalancerName: opt.LoadBalancerName,
+		VpcId:            opt.CloudVpcID,
+		SubnetId:         opt.CloudSubnetID,
+		AddressIPVersion: opt.AddressIPVersion,
+		Number:           opt.Number,
+		MasterZoneId:     opt.MasterZone,
+	}
+
+	resp := &createLoadBalancerResponse{BaseResponse: new(tchttp.BaseResponse)}
+	if err = client.Send(req, resp); err != nil {
+		logs.Errorf("create tcloud load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return &poller.BaseDoneResult{SuccessCloudIDs: converter.PtrToSlice(resp.Response.LoadBalancerIds)}, nil
+}
+
+type deleteLoadBalancerRequest struct {
+	*tchttp.BaseRequest
+	LoadBalancerIds []*string `json:"LoadBalancerIds,omitempty" name:"LoadBalancerIds"`
+}
+
+type clbBaseResponse struct {
+	*tchttp.BaseResponse
+	Response *struct {
+		RequestId *string `json:"RequestId,omitempty"`
+	} `json:"Response"`
+}
+
+// DeleteLoadBalancer delete load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30689
+func (t *TCloudImpl) DeleteLoadBalancer(kt *kit.Kit, opt *typelb.TCloudDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.clbClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new tcloud clb client failed, err: %v", err)
+	}
+
+	req := &deleteLoadBalancerRequest{
+		BaseRequest:     newClbRequest(kt, "DeleteLoadBalancer"),
+		LoadBalancerIds: common.StringPtrs(opt.CloudIDs),
+	}
+
+	resp := &clbBaseResponse{BaseResponse: new(tchttp.BaseResponse)}
+	if err = client.Send(req, resp); err != nil {
+		logs.Errorf("delete tcloud load balancer failed, err: %v, ids: %v, rid: %s", err, opt.CloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+type describeListenersRequest struct {
+	*tchttp.BaseRequest
+	LoadBalancerId *string   `json:"LoadBalancerId,omitempty" name:"LoadBalancerId"`
+	ListenerIds    []*string `json:"ListenerIds,omitempty" name:"ListenerIds"`
+}
+
+type describeListenersResponse struct {
+	*tchttp.BaseResponse
+	Response *struct {
+		Listeners  []*typelb.TCloudListener `json:"Listeners,omitempty"`
+		TotalCount *uint64                  `json:"TotalCount,omitempty"`
+		RequestId  *string                  `json:"RequestId,omitempty"`
+	} `json:"Response"`
+}
+
+// ListListener list listeners of the load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30686
+func (t *TCloudImpl) ListListener(kt *kit.Kit, opt *typelb.TCloudListenerListOption) ([]typelb.TCloudListener,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.clbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud clb client failed, err: %v", err)
+	}
+
+	req := &describeListenersRequest{
+		BaseRequest:    newClbRequest(kt, "DescribeListeners"),
+		LoadBalancerId: common.StringPtr(opt.CloudLoadBalancerID),
+	}
+	if len(opt.CloudListenerIDs) != 0 {
+		req.ListenerIds = common.StringPtrs(opt.CloudListenerIDs)
+	}
+
+	resp := &describeListenersResponse{BaseResponse: new(tchttp.BaseResponse)}
+	if err = client.Send(req, resp); err != nil {
+		logs.Errorf("list tcloud listener failed, err: %v, lb: %s, rid: %s", err, opt.CloudLoadBalancerID, kt.Rid)
+		return nil, err
+	}
+
+	listeners := make([]typelb.TCloudListener, 0, len(resp.Response.Listeners))
+	for _, one := range resp.Response.Listeners {
+		listener := converter.PtrToVal(one)
+		listener.CloudLoadBalancerID = opt.CloudLoadBalancerID
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+type describeTargetsRequest struct {
+	*tchttp.BaseRequest
+	LoadBalancerId *string   `json:"LoadBalancerId,omitempty" name:"LoadBalancerId"`
+	ListenerIds    []*string `json:"ListenerIds,omitempty" name:"ListenerIds"`
+}
+
+type describeTargetsResponse struct {
+	*tchttp.BaseResponse
+	Response *struct {
+		Listeners []*struct {
+			ListenerId *string `json:"ListenerId,omitempty"`
+			Rules      []*struct {
+				LocationId *string                 `json:"LocationId,omitempty"`
+				Targets    []*typelb.TCloudBackend `json:"Targets,omitempty"`
+			} `json:"Rules,omitempty"`
+			Targets []*typelb.TCloudBackend `json:"Targets,omitempty"`
+		} `json:"Listeners,omitempty"`
+		RequestId *string `json:"RequestId,omitempty"`
+	} `json:"Response"`
+}
+
+// ListTarget list backend targets bound to the listeners and layer-7 rules of the load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30684
+func (t *TCloudImpl) ListTarget(kt *kit.Kit, opt *typelb.TCloudListenerListOption) ([]typelb.TCloudTarget, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.clbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud clb client failed, err: %v", err)
+	}
+
+	req := &describeTargetsRequest{
+		BaseRequest:    newClbRequest(kt, "DescribeTargets"),
+		LoadBalancerId: common.StringPtr(opt.CloudLoadBalancerID),
+	}
+	if len(opt.CloudListenerIDs) != 0 {
+		req.ListenerIds = common.StringPtrs(opt.CloudListenerIDs)
+	}
+
+	resp := &describeTargetsResponse{BaseResponse: new(tchttp.BaseResponse)}
+	if err = client.Send(req, resp); err != nil {
+		logs.Errorf("list tcloud target failed, err: %v, lb: %s, rid: %s", err, opt.CloudLoadBalancerID, kt.Rid)
+		return nil, err
+	}
+
+	targets := make([]typelb.TCloudTarget, 0)
+	for _, listener := range resp.Response.Listeners {
+		listenerID := converter.PtrToVal(listener.ListenerId)
+		for _, backend := range listener.Targets {
+			targets = append(targets, typelb.TCloudTarget{CloudLoadBalancerID: opt.CloudLoadBalancerID,
+				CloudListenerID: listenerID, TCloudBackend: backend})
+		}
+
+		for _, rule := range listener.Rules {
+			for _, backend := range rule.Targets {
+				targets = append(targets, typelb.TCloudTarget{CloudLoadBalancerID: opt.CloudLoadBalancerID,
+					CloudListenerID: listenerID, CloudRuleID: converter.PtrToVal(rule.LocationId),
+					TCloudBackend: backend})
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+type describeTargetGroupsRequest struct {
+	*tchttp.BaseRequest
+	TargetGroupIds []*string `json:"TargetGroupIds,omitempty" name:"TargetGroupIds"`
+	Offset         *uint64   `json:"Offset,omitempty" name:"Offset"`
+	Limit          *uint64   `json:"Limit,omitempty" name:"Limit"`
+}
+
+type describeTargetGroupsResponse struct {
+	*tchttp.BaseResponse
+	Response *struct {
+		TotalCount     *uint64                     `json:"TotalCount,omitempty"`
+		TargetGroupSet []*typelb.TCloudTargetGroup `json:"TargetGroupSet,omitempty"`
+		RequestId      *string                     `json:"RequestId,omitempty"`
+	} `json:"Response"`
+}
+
+// ListTargetGroup list target group.
+// reference: https://cloud.tencent.com/document/api/214/40554
+func (t *TCloudImpl) ListTargetGroup(kt *kit.Kit, opt *typelb.TCloudListOption) ([]typelb.TCloudTargetGroup,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.clbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud clb client failed, err: %v", err)
+	}
+
+	req := &describeTargetGroupsRequest{BaseRequest: newClbRequest(kt, "DescribeTargetGroups")}
+	if len(opt.CloudIDs) != 0 {
+		req.TargetGroupIds = common.StringPtrs(opt.CloudIDs)
+		req.Limit = common.Uint64Ptr(core.TCloudQueryLimit)
+	}
+
+	if opt.Page != nil {
+		req.Offset = common.Uint64Ptr(opt.Page.Offset)
+		req.Limit = common.Uint64Ptr(opt.Page.Limit)
+	}
+
+	resp := &describeTargetGroupsResponse{BaseResponse: new(tchttp.BaseResponse)}
+	if err = client.Send(req, resp); err != nil {
+		logs.Errorf("list tcloud target group failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	tgs := make([]typelb.TCloudTargetGroup, 0, len(resp.Response.TargetGroupSet))
+	for _, one := range resp.Response.TargetGroupSet {
+		tgs = append(tgs, converter.PtrToVal(one))
+	}
+
+	return tgs, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/adaptor/cassette"
+	"hcm/pkg/adaptor/types/core"
+	securitygroup "hcm/pkg/adaptor/types/security-group"
+	"hcm/pkg/kit"
+)
+
+func TestListSecurityGroupNew(t *testing.T) {
+	cli, rec := newReplayTCloud(t, "testdata/list_security_group.json")
+	kt := kit.New()
+
+	ids := make([]string, 0)
+	page := &core.TCloudPage{Offset: 0, Limit: 2}
+	for {
+		sgs, err := cli.ListSecurityGroupNew(kt, &securitygroup.TCloudListOption{Region: "ap-guangzhou", Page: page})
+		if err != nil {
+			t.Fatalf("list security group failed, err: %v", err)
+		}
+
+		for _, one := range sgs {
+			ids = append(ids, *one.SecurityGroupId)
+		}
+
+		if uint64(len(sgs)) < page.Limit {
+			break
+		}
+		page.Offset += page.Limit
+	}
+
+	if strings.Join(ids, ",") != "sg-00000001,sg-00000002,sg-00000003" {
+		t.Errorf("list security group by page got unexpected security groups: %v", ids)
+	}
+
+	_, err := cli.ListSecurityGroupNew(kt, &securitygroup.TCloudListOption{Region: "ap-guangzhou",
+		CloudIDs: []string{"sg-notexist"}})
+	if err == nil || !strings.Contains(err.Error(), ErrNotFound) {
+		t.Errorf("list not exist security group should return not found error, but got: %v", err)
+	}
+
+	if rec.Mode() == cassette.ModeReplay && rec.Unused() != 0 {
+		t.Errorf("%d interactions in cassette are not replayed", rec.Unused())
+	}
+}
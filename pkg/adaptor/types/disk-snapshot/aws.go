@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"errors"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// AwsSnapshotCompleted aws快照可用状态
+const AwsSnapshotCompleted = "completed"
+
+// -------------------------- List --------------------------
+
+// AwsListOption defines options to list aws disk snapshots, only snapshots owned by the account are listed.
+type AwsListOption struct {
+	Region   string        `json:"region" validate:"required"`
+	CloudIDs []string      `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.AwsPage `json:"page" validate:"omitempty"`
+}
+
+// Validate aws disk snapshot list option.
+func (opt AwsListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) != 0 && opt.Page != nil {
+		return errors.New("list by cloud_ids not support page")
+	}
+
+	if len(opt.CloudIDs) > core.AwsQueryLimit {
+		return errors.New("cloud_ids should <= 1000")
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- Create --------------------------
+
+// AwsCreateOption defines options to create aws disk snapshot.
+// reference: https://docs.amazonaws.cn/AWSEC2/latest/APIReference/API_CreateSnapshot.html
+type AwsCreateOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	// Name 快照名称，aws快照无名称字段，以 Name 标签的形式设置
+	Name        *string `json:"name" validate:"omitempty,max=255"`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+}
+
+// Validate aws disk snapshot create option.
+func (opt AwsCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Delete --------------------------
+
+// AwsDeleteOption defines options to delete aws disk snapshot.
+type AwsDeleteOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate aws disk snapshot delete option.
+func (opt AwsDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsSnapshot for ec2 Snapshot
+type AwsSnapshot struct {
+	*ec2.Snapshot
+}
+
+// GetCloudID ...
+func (s AwsSnapshot) GetCloudID() string {
+	return converter.PtrToVal(s.SnapshotId)
+}
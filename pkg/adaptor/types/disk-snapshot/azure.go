@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+)
+
+// AzureSnapshotSucceeded azure快照可用状态
+const AzureSnapshotSucceeded = "Succeeded"
+
+// -------------------------- List --------------------------
+
+// AzureListOption defines options to list azure disk snapshots.
+type AzureListOption struct {
+	ResourceGroupName string   `json:"resource_group_name" validate:"required"`
+	CloudIDs          []string `json:"cloud_ids" validate:"omitempty"`
+}
+
+// Validate azure disk snapshot list option.
+func (opt AzureListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Create --------------------------
+
+// AzureCreateOption defines options to create azure disk snapshot.
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/create-or-update?tabs=Go
+type AzureCreateOption struct {
+	ResourceGroupName string `json:"resource_group_name" validate:"required"`
+	Region            string `json:"region" validate:"required"`
+	Name              string `json:"name" validate:"required,max=80"`
+	// CloudDiskID 源云盘的资源ID
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+	Incremental *bool  `json:"incremental" validate:"omitempty"`
+}
+
+// Validate azure disk snapshot create option.
+func (opt AzureCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Delete --------------------------
+
+// AzureDeleteOption defines options to delete azure disk snapshot.
+type AzureDeleteOption struct {
+	ResourceGroupName string `json:"resource_group_name" validate:"required"`
+	Name              string `json:"name" validate:"required"`
+}
+
+// Validate azure disk snapshot delete option.
+func (opt AzureDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AzureSnapshot define azure disk snapshot.
+type AzureSnapshot struct {
+	ID                *string `json:"id"`
+	Name              *string `json:"name"`
+	Location          *string `json:"location"`
+	SourceResourceID  *string `json:"source_resource_id"`
+	DiskSizeGB        *int32  `json:"disk_size_gb"`
+	ProvisioningState *string `json:"provisioning_state"`
+	TimeCreated       *string `json:"time_created"`
+	SKUName           *string `json:"sku_name"`
+	Incremental       *bool   `json:"incremental"`
+	OSType            *string `json:"os_type"`
+}
+
+// GetCloudID ...
+func (s AzureSnapshot) GetCloudID() string {
+	return converter.PtrToVal(s.ID)
+}
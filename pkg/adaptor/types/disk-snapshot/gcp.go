@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// GcpSnapshotReady gcp快照可用状态
+const GcpSnapshotReady = "READY"
+
+// -------------------------- List --------------------------
+
+// GcpListOption defines options to list gcp disk snapshots.
+type GcpListOption struct {
+	CloudIDs  []string      `json:"cloud_ids" validate:"omitempty"`
+	SelfLinks []string      `json:"self_links" validate:"omitempty"`
+	Page      *core.GcpPage `json:"page" validate:"omitempty"`
+}
+
+// Validate gcp disk snapshot list option.
+func (opt GcpListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) != 0 && opt.Page != nil {
+		return errors.New("list by cloud_ids not support page")
+	}
+
+	if len(opt.CloudIDs) > core.GcpQueryLimit {
+		return fmt.Errorf("cloud_ids should <= %d", core.GcpQueryLimit)
+	}
+
+	if len(opt.SelfLinks) > core.GcpSelfLinkMaxQueryLimit {
+		return fmt.Errorf("self_links should <= %d", core.GcpSelfLinkMaxQueryLimit)
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- Create --------------------------
+
+// GcpCreateOption defines options to create gcp disk snapshot.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/createSnapshot
+type GcpCreateOption struct {
+	Zone        string `json:"zone" validate:"required"`
+	DiskName    string `json:"disk_name" validate:"required"`
+	Name        string `json:"name" validate:"required,max=63"`
+	Description string `json:"description" validate:"omitempty"`
+}
+
+// Validate gcp disk snapshot create option.
+func (opt GcpCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Delete --------------------------
+
+// GcpDeleteOption defines options to delete gcp disk snapshot.
+type GcpDeleteOption struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// Validate gcp disk snapshot delete option.
+func (opt GcpDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// GcpSnapshot for compute Snapshot
+type GcpSnapshot struct {
+	*compute.Snapshot
+}
+
+// GetCloudID ...
+func (s GcpSnapshot) GetCloudID() string {
+	return fmt.Sprint(s.Id)
+}
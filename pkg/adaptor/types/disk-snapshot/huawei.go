@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disksnapshot
+
+import (
+	"errors"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+)
+
+// HuaWeiSnapshotAvailable 华为云快照可用状态
+const HuaWeiSnapshotAvailable = "available"
+
+// -------------------------- List --------------------------
+
+// HuaWeiListOption defines options to list huawei disk snapshots.
+type HuaWeiListOption struct {
+	Region   string                    `json:"region" validate:"required"`
+	CloudIDs []string                  `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.HuaWeiCvmOffsetPage `json:"page" validate:"omitempty"`
+}
+
+// Validate huawei disk snapshot list option.
+func (opt HuaWeiListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) != 0 && opt.Page != nil {
+		return errors.New("list by cloud_ids not support page")
+	}
+
+	if len(opt.CloudIDs) > core.HuaWeiQueryLimit {
+		return errors.New("cloud_ids should <= 2000")
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- Create --------------------------
+
+// HuaWeiCreateOption defines options to create huawei disk snapshot.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2005.html
+type HuaWeiCreateOption struct {
+	Region      string  `json:"region" validate:"required"`
+	CloudDiskID string  `json:"cloud_disk_id" validate:"required"`
+	Name        *string `json:"name" validate:"omitempty,max=64"`
+	Description *string `json:"description" validate:"omitempty,max=85"`
+}
+
+// Validate huawei disk snapshot create option.
+func (opt HuaWeiCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Delete --------------------------
+
+// HuaWeiDeleteOption defines options to delete huawei disk snapshot.
+type HuaWeiDeleteOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate huawei disk snapshot delete option.
+func (opt HuaWeiDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiSnapshot for evs SnapshotList
+type HuaWeiSnapshot struct {
+	model.SnapshotList
+}
+
+// GetCloudID ...
+func (s HuaWeiSnapshot) GetCloudID() string {
+	return s.Id
+}
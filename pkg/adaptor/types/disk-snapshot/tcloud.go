@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot defines disk snapshot types of cloud adaptor.
+package disksnapshot
+
+import (
+	"errors"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+)
+
+// TCloudSnapshotNormal 腾讯云快照可用状态
+const TCloudSnapshotNormal = "NORMAL"
+
+// -------------------------- List --------------------------
+
+// TCloudListOption defines options to list tcloud disk snapshots.
+type TCloudListOption struct {
+	Region   string           `json:"region" validate:"required"`
+	CloudIDs []string         `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.TCloudPage `json:"page" validate:"omitempty"`
+}
+
+// Validate tcloud disk snapshot list option.
+func (opt TCloudListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > core.TCloudQueryLimit {
+		return errors.New("cloud_ids should <= 100")
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// -------------------------- Create --------------------------
+
+// TCloudCreateOption defines options to create tcloud disk snapshot.
+// reference: https://cloud.tencent.com/document/api/362/15648
+type TCloudCreateOption struct {
+	Region      string  `json:"region" validate:"required"`
+	CloudDiskID string  `json:"cloud_disk_id" validate:"required"`
+	Name        *string `json:"name" validate:"omitempty,max=60"`
+}
+
+// Validate tcloud disk snapshot create option.
+func (opt TCloudCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Delete --------------------------
+
+// TCloudDeleteOption defines options to delete tcloud disk snapshots.
+type TCloudDeleteOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"required,min=1,max=100"`
+}
+
+// Validate tcloud disk snapshot delete option.
+func (opt TCloudDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudSnapshot for cbs Snapshot
+type TCloudSnapshot struct {
+	*cbs.Snapshot
+}
+
+// GetCloudID ...
+func (s TCloudSnapshot) GetCloudID() string {
+	return converter.PtrToVal(s.SnapshotId)
+}
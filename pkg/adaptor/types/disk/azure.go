@@ -43,6 +43,8 @@ type AzureDiskCreateOption struct {
 	DiskType          string  `json:"disk_type" validate:"required"`
 	DiskSize          int32   `json:"disk_size" validate:"required"`
 	DiskCount         *uint64 `json:"disk_count" validate:"required"`
+	// SnapshotID 用于创建云盘的快照资源ID，指定后从快照复制创建云盘
+	SnapshotID *string `json:"snapshot_id"`
 }
 
 // Validate ...
@@ -64,6 +66,10 @@ func (opt *AzureDiskCreateOption) ToCreateDiskRequest() (*armcompute.Disk, error
 			CreateOption: converter.ValToPtr(armcompute.DiskCreateOptionEmpty),
 		},
 	}
+	if opt.SnapshotID != nil {
+		prop.CreationData.CreateOption = converter.ValToPtr(armcompute.DiskCreateOptionCopy)
+		prop.CreationData.SourceResourceID = opt.SnapshotID
+	}
 
 	return &armcompute.Disk{
 		Zones:      to.SliceOfPtrs[string](opt.Zone),
@@ -68,6 +68,8 @@ type HuaWeiDiskCreateOption struct {
 	DiskCount         *int32                   `json:"disk_count"`
 	DiskChargeType    *string                  `json:"disk_charge_type"`
 	DiskChargePrepaid *HuaWeiDiskChargePrepaid `json:"disk_charge_prepaid"`
+	// SnapshotID 用于创建云盘的快照ID
+	SnapshotID *string `json:"snapshot_id"`
 }
 
 // Validate ...
@@ -95,6 +97,7 @@ func (opt *HuaWeiDiskCreateOption) ToCreateVolumeRequest() (*model.CreateVolumeR
 		VolumeType:       *volumeType,
 		Size:             opt.DiskSize,
 		Count:            opt.DiskCount,
+		SnapshotId:       opt.SnapshotID,
 	}
 
 	chargingMode, err := GetCreateVolumeChargingMode(*opt.DiskChargeType)
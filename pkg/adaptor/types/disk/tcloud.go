@@ -45,6 +45,8 @@ type TCloudDiskCreateOption struct {
 	DiskCount         *uint64 `json:"disk_count"`
 	DiskChargeType    string  `json:"disk_charge_type" validate:"required"`
 	DiskChargePrepaid *TCloudDiskChargePrepaid
+	// SnapshotID 用于创建云盘的快照ID，指定后云盘大小不能小于快照大小
+	SnapshotID *string `json:"snapshot_id"`
 }
 
 // Validate ...
@@ -65,6 +67,7 @@ func (opt *TCloudDiskCreateOption) ToCreateDisksRequest() (*cbs.CreateDisksReque
 	req.DiskCount = opt.DiskCount
 	req.DiskSize = opt.DiskSize
 	req.DiskChargeType = common.StringPtr(opt.DiskChargeType)
+	req.SnapshotId = opt.SnapshotID
 	// 预付费模式需要设定 ChargePrepaid
 	if *req.DiskChargeType == TCloudDiskChargeTypeEnum.PREPAID {
 		req.DiskChargePrepaid = &cbs.DiskChargePrepaid{
@@ -0,0 +1,229 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"errors"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+const (
+	// AwsQueryLimit aws elbv2 describe api maximum page size.
+	AwsQueryLimit = 400
+	// AwsLoadBalancerQueryLimit aws elbv2 maximum load balancer arns of one describe request.
+	AwsLoadBalancerQueryLimit = 20
+)
+
+// -------------------------- List --------------------------
+
+// AwsListOption defines options to list aws load balancers or target groups.
+type AwsListOption struct {
+	Region   string        `json:"region" validate:"required"`
+	CloudIDs []string      `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.AwsPage `json:"page" validate:"omitempty"`
+}
+
+// Validate aws load balancer list option.
+func (opt AwsListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > AwsLoadBalancerQueryLimit {
+		return errors.New("cloud_ids should <= 20")
+	}
+
+	if len(opt.CloudIDs) != 0 && opt.Page != nil {
+		return errors.New("only one of cloud_ids and page can be set")
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+
+		if converter.PtrToVal(opt.Page.MaxResults) > AwsQueryLimit {
+			return errors.New("page.max_results should <= 400")
+		}
+	}
+
+	return nil
+}
+
+// AwsListResult defines aws list load balancer result.
+type AwsListResult struct {
+	NextToken *string           `json:"next_token,omitempty"`
+	Details   []AwsLoadBalancer `json:"details"`
+}
+
+// AwsTargetGroupListResult defines aws list target group result.
+type AwsTargetGroupListResult struct {
+	NextToken *string          `json:"next_token,omitempty"`
+	Details   []AwsTargetGroup `json:"details"`
+}
+
+// AwsListenerListOption defines options to list aws listeners of a load balancer.
+type AwsListenerListOption struct {
+	Region              string `json:"region" validate:"required"`
+	CloudLoadBalancerID string `json:"cloud_load_balancer_id" validate:"required"`
+}
+
+// Validate aws listener list option.
+func (opt AwsListenerListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsTargetListOption defines options to list aws targets of a target group.
+type AwsTargetListOption struct {
+	Region             string `json:"region" validate:"required"`
+	CloudTargetGroupID string `json:"cloud_target_group_id" validate:"required"`
+}
+
+// Validate aws target list option.
+func (opt AwsTargetListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Create --------------------------
+
+// AwsCreateOption defines options to create aws load balancer.
+type AwsCreateOption struct {
+	Region                string   `json:"region" validate:"required"`
+	Name                  string   `json:"name" validate:"required,max=32"`
+	Type                  string   `json:"type" validate:"required,eq=application|eq=network|eq=gateway"`
+	Scheme                *string  `json:"scheme" validate:"omitempty,eq=internet-facing|eq=internal"`
+	IpAddressType         *string  `json:"ip_address_type" validate:"omitempty,eq=ipv4|eq=dualstack"`
+	CloudSubnetIDs        []string `json:"cloud_subnet_ids" validate:"required,min=1"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids" validate:"omitempty"`
+}
+
+// Validate aws load balancer create option.
+func (opt AwsCreateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	// 应用型负载均衡至少需要两个可用区的子网
+	if opt.Type == elbv2.LoadBalancerTypeEnumApplication && len(opt.CloudSubnetIDs) < 2 {
+		return errors.New("application load balancer requires at least two subnets")
+	}
+
+	return nil
+}
+
+// ToCreateLoadBalancerInput convert to aws create load balancer input.
+func (opt AwsCreateOption) ToCreateLoadBalancerInput() (*elbv2.CreateLoadBalancerInput, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	input := &elbv2.CreateLoadBalancerInput{
+		Name:          converter.ValToPtr(opt.Name),
+		Type:          converter.ValToPtr(opt.Type),
+		Scheme:        opt.Scheme,
+		IpAddressType: opt.IpAddressType,
+		Subnets:       converter.SliceToPtr(opt.CloudSubnetIDs),
+	}
+
+	if len(opt.CloudSecurityGroupIDs) != 0 {
+		input.SecurityGroups = converter.SliceToPtr(opt.CloudSecurityGroupIDs)
+	}
+
+	return input, nil
+}
+
+// -------------------------- Delete --------------------------
+
+// AwsDeleteOption defines options to delete aws load balancer.
+type AwsDeleteOption struct {
+	Region  string `json:"region" validate:"required"`
+	CloudID string `json:"cloud_id" validate:"required"`
+}
+
+// Validate aws load balancer delete option.
+func (opt AwsDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Resource --------------------------
+
+// AwsLoadBalancer define aws elbv2 load balancer.
+type AwsLoadBalancer struct {
+	*elbv2.LoadBalancer
+}
+
+// GetCloudID ...
+func (lb AwsLoadBalancer) GetCloudID() string {
+	return converter.PtrToVal(lb.LoadBalancerArn)
+}
+
+// AwsListener define aws elbv2 listener.
+type AwsListener struct {
+	*elbv2.Listener
+}
+
+// GetCloudID ...
+func (listener AwsListener) GetCloudID() string {
+	return converter.PtrToVal(listener.ListenerArn)
+}
+
+// AwsTargetGroup define aws elbv2 target group.
+type AwsTargetGroup struct {
+	*elbv2.TargetGroup
+}
+
+// GetCloudID ...
+func (tg AwsTargetGroup) GetCloudID() string {
+	return converter.PtrToVal(tg.TargetGroupArn)
+}
+
+// AwsTarget define aws elbv2 target registered to target group.
+type AwsTarget struct {
+	CloudTargetGroupID string `json:"cloud_target_group_id"`
+	*elbv2.TargetHealthDescription
+}
+
+// GetCloudID aws target has no cloud id, use target group arn, target id and port as unique key.
+func (target AwsTarget) GetCloudID() string {
+	return TargetKey(target.CloudTargetGroupID, "", target.InstanceKey(), target.TargetPort())
+}
+
+// InstanceKey return the target id, which is instance id, ip address, lambda function arn or alb arn.
+func (target AwsTarget) InstanceKey() string {
+	if target.TargetHealthDescription == nil || target.Target == nil {
+		return ""
+	}
+
+	return converter.PtrToVal(target.Target.Id)
+}
+
+// TargetPort return the port on which the target is listening.
+func (target AwsTarget) TargetPort() int64 {
+	if target.TargetHealthDescription == nil || target.Target == nil {
+		return 0
+	}
+
+	return converter.PtrToVal(target.Target.Port)
+}
@@ -0,0 +1,29 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer defines load balancer, listener, target group and backend target types of cloud adaptor.
+package loadbalancer
+
+import "fmt"
+
+// TargetKey generate the unique key of backend target. backend target has no cloud id, so the key is joined by the
+// cloud id of listener(or target group), rule, the backend instance(or ip) and port.
+func TargetKey(ownerCloudID, ruleCloudID, instance string, port int64) string {
+	return fmt.Sprintf("%s/%s/%s:%d", ownerCloudID, ruleCloudID, instance, port)
+}
@@ -0,0 +1,369 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"errors"
+
+	"hcm/pkg/adaptor/types/core"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/converter"
+)
+
+// TCloudLoadBalancerType 腾讯云负载均衡网络类型
+const (
+	// TCloudOpenLoadBalancer 公网负载均衡
+	TCloudOpenLoadBalancer = "OPEN"
+	// TCloudInternalLoadBalancer 内网负载均衡
+	TCloudInternalLoadBalancer = "INTERNAL"
+)
+
+// TCloudLoadBalancerStatus 腾讯云负载均衡状态
+const (
+	// TCloudLoadBalancerCreating 创建中
+	TCloudLoadBalancerCreating uint64 = 0
+	// TCloudLoadBalancerNormal 正常运行
+	TCloudLoadBalancerNormal uint64 = 1
+)
+
+// TCloudDeleteLimit 腾讯云单次删除负载均衡的最大数量
+const TCloudDeleteLimit = 20
+
+// -------------------------- List --------------------------
+
+// TCloudListOption defines options to list tcloud load balancers or target groups.
+type TCloudListOption struct {
+	Region   string           `json:"region" validate:"required"`
+	CloudIDs []string         `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.TCloudPage `json:"page" validate:"omitempty"`
+}
+
+// Validate tcloud load balancer list option.
+func (opt TCloudListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > core.TCloudQueryLimit {
+		return errors.New("cloud_ids should <= 100")
+	}
+
+	if opt.Page != nil {
+		if err := opt.Page.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TCloudListenerListOption defines options to list tcloud listeners or targets of a load balancer.
+type TCloudListenerListOption struct {
+	Region              string   `json:"region" validate:"required"`
+	CloudLoadBalancerID string   `json:"cloud_load_balancer_id" validate:"required"`
+	CloudListenerIDs    []string `json:"cloud_listener_ids" validate:"omitempty"`
+}
+
+// Validate tcloud listener list option.
+func (opt TCloudListenerListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Create --------------------------
+
+// TCloudCreateOption defines options to create tcloud load balancers.
+type TCloudCreateOption struct {
+	Region           string  `json:"region" validate:"required"`
+	LoadBalancerType string  `json:"load_balancer_type" validate:"required,eq=OPEN|eq=INTERNAL"`
+	LoadBalancerName *string `json:"load_balancer_name" validate:"omitempty,max=60"`
+	CloudVpcID       *string `json:"cloud_vpc_id" validate:"omitempty"`
+	CloudSubnetID    *string `json:"cloud_subnet_id" validate:"omitempty"`
+	AddressIPVersion *string `json:"address_ip_version" validate:"omitempty,eq=IPV4|eq=IPV6|eq=IPv6FullChain"`
+	MasterZone       *string `json:"master_zone" validate:"omitempty"`
+	Number           *uint64 `json:"number" validate:"omitempty,min=1,max=20"`
+}
+
+// Validate tcloud load balancer create option.
+func (opt TCloudCreateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	// 内网负载均衡必须指定子网
+	if opt.LoadBalancerType == TCloudInternalLoadBalancer && len(converter.PtrToVal(opt.CloudSubnetID)) == 0 {
+		return errors.New("cloud_subnet_id is required when load_balancer_type is INTERNAL")
+	}
+
+	return nil
+}
+
+// -------------------------- Delete --------------------------
+
+// TCloudDeleteOption defines options to delete tcloud load balancers.
+type TCloudDeleteOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"required,min=1,max=20"`
+}
+
+// Validate tcloud load balancer delete option.
+func (opt TCloudDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Resource --------------------------
+
+// TCloudLoadBalancer define tcloud clb load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30694#LoadBalancer
+type TCloudLoadBalancer struct {
+	// 负载均衡实例 ID
+	LoadBalancerId *string `json:"LoadBalancerId,omitempty"`
+	// 负载均衡实例的名称
+	LoadBalancerName *string `json:"LoadBalancerName,omitempty"`
+	// 负载均衡实例的网络类型，OPEN：公网属性，INTERNAL：内网属性
+	LoadBalancerType *string `json:"LoadBalancerType,omitempty"`
+	// 负载均衡类型标识，1：负载均衡，0：传统型负载均衡
+	Forward *uint64 `json:"Forward,omitempty"`
+	// 负载均衡实例的域名，仅公网传统型负载均衡实例才提供该字段
+	Domain *string `json:"Domain,omitempty"`
+	// 负载均衡实例的 VIP 列表
+	LoadBalancerVips []*string `json:"LoadBalancerVips,omitempty"`
+	// 负载均衡实例的状态，0：创建中，1：正常运行
+	Status *uint64 `json:"Status,omitempty"`
+	// 负载均衡实例的创建时间
+	CreateTime *string `json:"CreateTime,omitempty"`
+	// 私有网络的 ID
+	VpcId *string `json:"VpcId,omitempty"`
+	// 负载均衡实例所在的子网，仅对内网VPC型LB有意义
+	SubnetId *string `json:"SubnetId,omitempty"`
+	// 负载均衡实例的安全组
+	SecureGroups []*string `json:"SecureGroups,omitempty"`
+	// IP版本，ipv4 | ipv6
+	AddressIPVersion *string `json:"AddressIPVersion,omitempty"`
+	// 负载均衡实例的IPv6地址
+	AddressIPv6 *string `json:"AddressIPv6,omitempty"`
+	// 主可用区
+	MasterZone *TCloudZoneInfo `json:"MasterZone,omitempty"`
+	// 备可用区
+	BackupZoneSet []*TCloudZoneInfo `json:"BackupZoneSet,omitempty"`
+	// 负载均衡实例计费类型，PREPAID：包年包月，POSTPAID_BY_HOUR：按量计费
+	ChargeType *string `json:"ChargeType,omitempty"`
+	// 负载均衡实例的网络属性
+	NetworkAttributes *TCloudInternetAccessible `json:"NetworkAttributes,omitempty"`
+	// 内网独占集群，可用区列表
+	Zones []*string `json:"Zones,omitempty"`
+	// 负载均衡实例的域名
+	LoadBalancerDomain *string `json:"LoadBalancerDomain,omitempty"`
+	// 负载均衡实例的isp
+	VipIsp *string `json:"VipIsp,omitempty"`
+	// 负载均衡实例的标签信息
+	Tags []*TCloudTagInfo `json:"Tags,omitempty"`
+}
+
+// GetCloudID ...
+func (lb TCloudLoadBalancer) GetCloudID() string {
+	return converter.PtrToVal(lb.LoadBalancerId)
+}
+
+// TCloudZoneInfo define tcloud clb zone info.
+type TCloudZoneInfo struct {
+	ZoneId     *uint64 `json:"ZoneId,omitempty"`
+	Zone       *string `json:"Zone,omitempty"`
+	ZoneName   *string `json:"ZoneName,omitempty"`
+	ZoneRegion *string `json:"ZoneRegion,omitempty"`
+}
+
+// TCloudInternetAccessible define tcloud clb internet accessible.
+type TCloudInternetAccessible struct {
+	InternetChargeType      *string `json:"InternetChargeType,omitempty"`
+	InternetMaxBandwidthOut *int64  `json:"InternetMaxBandwidthOut,omitempty"`
+	BandwidthpkgSubType     *string `json:"BandwidthpkgSubType,omitempty"`
+}
+
+// TCloudTagInfo define tcloud clb tag info.
+type TCloudTagInfo struct {
+	TagKey   *string `json:"TagKey,omitempty"`
+	TagValue *string `json:"TagValue,omitempty"`
+}
+
+// TCloudListener define tcloud clb listener.
+// reference: https://cloud.tencent.com/document/api/214/30694#Listener
+type TCloudListener struct {
+	// CloudLoadBalancerID 监听器所属负载均衡实例ID，接口不返回，由适配层填充
+	CloudLoadBalancerID string `json:"-"`
+	// 负载均衡监听器 ID
+	ListenerId *string `json:"ListenerId,omitempty"`
+	// 监听器名称
+	ListenerName *string `json:"ListenerName,omitempty"`
+	// 监听器协议，TCP | UDP | HTTP | HTTPS | TCP_SSL | QUIC
+	Protocol *string `json:"Protocol,omitempty"`
+	// 监听器端口
+	Port *int64 `json:"Port,omitempty"`
+	// 端口段结束端口
+	EndPort *int64 `json:"EndPort,omitempty"`
+	// 监听器绑定的证书信息
+	Certificate *TCloudCertificate `json:"Certificate,omitempty"`
+	// 监听器的健康检查信息
+	HealthCheck *TCloudHealthCheck `json:"HealthCheck,omitempty"`
+	// 请求调度方式，WRR | LEAST_CONN | IP_HASH
+	Scheduler *string `json:"Scheduler,omitempty"`
+	// 会话保持时间
+	SessionExpireTime *int64 `json:"SessionExpireTime,omitempty"`
+	// 是否开启SNI特性，1：表示开启，0：表示不开启
+	SniSwitch *int64 `json:"SniSwitch,omitempty"`
+	// 监听器下的全部转发规则，仅七层监听器有
+	Rules []*TCloudRule `json:"Rules,omitempty"`
+	// 后端服务器类型，NODE | TARGETGROUP
+	TargetType *string `json:"TargetType,omitempty"`
+	// 绑定的目标组基本信息，当监听器绑定目标组时，会返回该字段
+	TargetGroup *TCloudBasicTargetGroup `json:"TargetGroup,omitempty"`
+	// 监听器的创建时间
+	CreateTime *string `json:"CreateTime,omitempty"`
+}
+
+// GetCloudID ...
+func (listener TCloudListener) GetCloudID() string {
+	return converter.PtrToVal(listener.ListenerId)
+}
+
+// TCloudCertificate define tcloud clb listener certificate.
+type TCloudCertificate struct {
+	SSLMode  *string `json:"SSLMode,omitempty"`
+	CertId   *string `json:"CertId,omitempty"`
+	CertCaId *string `json:"CertCaId,omitempty"`
+}
+
+// TCloudHealthCheck define tcloud clb health check.
+type TCloudHealthCheck struct {
+	HealthSwitch    *int64  `json:"HealthSwitch,omitempty"`
+	TimeOut         *int64  `json:"TimeOut,omitempty"`
+	IntervalTime    *int64  `json:"IntervalTime,omitempty"`
+	HealthNum       *int64  `json:"HealthNum,omitempty"`
+	UnHealthNum     *int64  `json:"UnHealthNum,omitempty"`
+	HttpCode        *int64  `json:"HttpCode,omitempty"`
+	HttpCheckPath   *string `json:"HttpCheckPath,omitempty"`
+	HttpCheckDomain *string `json:"HttpCheckDomain,omitempty"`
+	HttpCheckMethod *string `json:"HttpCheckMethod,omitempty"`
+	CheckPort       *int64  `json:"CheckPort,omitempty"`
+	CheckType       *string `json:"CheckType,omitempty"`
+}
+
+// TCloudRule define tcloud clb layer-7 listener forwarding rule.
+type TCloudRule struct {
+	LocationId *string `json:"LocationId,omitempty"`
+	Domain     *string `json:"Domain,omitempty"`
+	Url        *string `json:"Url,omitempty"`
+	Scheduler  *string `json:"Scheduler,omitempty"`
+}
+
+// TCloudBasicTargetGroup define tcloud clb basic target group info.
+type TCloudBasicTargetGroup struct {
+	TargetGroupId   *string `json:"TargetGroupId,omitempty"`
+	TargetGroupName *string `json:"TargetGroupName,omitempty"`
+}
+
+// TCloudTargetGroup define tcloud clb target group.
+// reference: https://cloud.tencent.com/document/api/214/30694#TargetGroupInfo
+type TCloudTargetGroup struct {
+	// 目标组ID
+	TargetGroupId *string `json:"TargetGroupId,omitempty"`
+	// 目标组的vpcid
+	VpcId *string `json:"VpcId,omitempty"`
+	// 目标组的名字
+	TargetGroupName *string `json:"TargetGroupName,omitempty"`
+	// 目标组的默认端口
+	Port *uint64 `json:"Port,omitempty"`
+	// 目标组的创建时间
+	CreatedTime *string `json:"CreatedTime,omitempty"`
+	// 目标组的修改时间
+	UpdatedTime *string `json:"UpdatedTime,omitempty"`
+	// 关联到的规则数组
+	AssociatedRule []*TCloudAssociationItem `json:"AssociatedRule,omitempty"`
+}
+
+// GetCloudID ...
+func (tg TCloudTargetGroup) GetCloudID() string {
+	return converter.PtrToVal(tg.TargetGroupId)
+}
+
+// TCloudAssociationItem define tcloud clb target group associated rule.
+type TCloudAssociationItem struct {
+	LoadBalancerId *string `json:"LoadBalancerId,omitempty"`
+	ListenerId     *string `json:"ListenerId,omitempty"`
+	LocationId     *string `json:"LocationId,omitempty"`
+	Protocol       *string `json:"Protocol,omitempty"`
+	Port           *uint64 `json:"Port,omitempty"`
+	Domain         *string `json:"Domain,omitempty"`
+	Url            *string `json:"Url,omitempty"`
+}
+
+// TCloudBackend define tcloud clb backend service bound to listener or rule.
+// reference: https://cloud.tencent.com/document/api/214/30694#Backend
+type TCloudBackend struct {
+	// 后端服务的类型，CVM | ENI
+	Type *string `json:"Type,omitempty"`
+	// 后端服务的唯一 ID，如 ins-abcd1234
+	InstanceId *string `json:"InstanceId,omitempty"`
+	// 后端服务的监听端口
+	Port *int64 `json:"Port,omitempty"`
+	// 后端服务的转发权重，取值范围：[0, 100]
+	Weight *int64 `json:"Weight,omitempty"`
+	// 后端服务的外网 IP
+	PublicIpAddresses []*string `json:"PublicIpAddresses,omitempty"`
+	// 后端服务的内网 IP
+	PrivateIpAddresses []*string `json:"PrivateIpAddresses,omitempty"`
+	// 后端服务的实例名称
+	InstanceName *string `json:"InstanceName,omitempty"`
+	// 后端服务被绑定的时间
+	RegisteredTime *string `json:"RegisteredTime,omitempty"`
+	// 弹性网卡唯一ID，如 eni-1234abcd
+	EniId *string `json:"EniId,omitempty"`
+}
+
+// TCloudTarget define tcloud clb backend binding, which is backend bound to listener or layer-7 rule.
+type TCloudTarget struct {
+	CloudLoadBalancerID string `json:"cloud_load_balancer_id"`
+	CloudListenerID     string `json:"cloud_listener_id"`
+	// CloudRuleID 七层监听器转发规则ID，四层监听器为空
+	CloudRuleID string `json:"cloud_rule_id"`
+	*TCloudBackend
+}
+
+// GetCloudID tcloud target has no cloud id, use listener id, rule id, instance id(or ip) and port as unique key.
+func (target TCloudTarget) GetCloudID() string {
+	return TargetKey(target.CloudListenerID, target.CloudRuleID, target.InstanceKey(),
+		converter.PtrToVal(target.Port))
+}
+
+// InstanceKey return the backend instance id, or private ip when backend is bound by ip.
+func (target TCloudTarget) InstanceKey() string {
+	if target.TCloudBackend == nil {
+		return ""
+	}
+
+	if len(converter.PtrToVal(target.InstanceId)) != 0 {
+		return converter.PtrToVal(target.InstanceId)
+	}
+
+	if len(target.PrivateIpAddresses) != 0 {
+		return converter.PtrToVal(target.PrivateIpAddresses[0])
+	}
+
+	return ""
+}
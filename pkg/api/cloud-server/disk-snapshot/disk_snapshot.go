@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot defines cloud server disk snapshot api types.
+package disksnapshot
+
+import (
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	"hcm/pkg/criteria/validator"
+)
+
+// DiskSnapshotCreateReq define disk snapshot create request, snapshot is created from the disk.
+type DiskSnapshotCreateReq struct {
+	DiskID string  `json:"disk_id" validate:"required"`
+	Name   string  `json:"name" validate:"required,max=60"`
+	Memo   *string `json:"memo" validate:"omitempty"`
+}
+
+// Validate DiskSnapshotCreateReq.
+func (req *DiskSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AssignDiskSnapshotToBizReq define assign disk snapshot to biz request.
+type AssignDiskSnapshotToBizReq struct {
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+	IDs     []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate AssignDiskSnapshotToBizReq.
+func (req *AssignDiskSnapshotToBizReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotListResult define disk snapshot list result with cost.
+type DiskSnapshotListResult struct {
+	Count   uint64               `json:"count"`
+	Details []DiskSnapshotResult `json:"details"`
+}
+
+// DiskSnapshotResult define disk snapshot with cost.
+type DiskSnapshotResult struct {
+	coresnapshot.BaseDiskSnapshot `json:",inline"`
+	// Cost 快照费用，为快照大小(GB)与所属云厂商快照单价的乘积
+	Cost float64 `json:"cost"`
+}
+
+// DiskSnapshotExtResult define disk snapshot with extension and cost.
+type DiskSnapshotExtResult[Ext coresnapshot.Extension] struct {
+	coresnapshot.DiskSnapshot[Ext] `json:",inline"`
+	// Cost 快照费用，为快照大小(GB)与所属云厂商快照单价的乘积
+	Cost float64 `json:"cost"`
+}
@@ -48,6 +48,8 @@ type GcpDiskCreateReq struct {
 	DiskSize  int32   `json:"disk_size" validate:"required"`
 	DiskCount int32   `json:"disk_count" validate:"required"`
 	Memo      *string `json:"memo" validate:"omitempty"`
+	// SnapshotID 用于创建云盘的快照ID，指定时从快照恢复云盘
+	SnapshotID *string `json:"snapshot_id" validate:"omitempty"`
 }
 
 // Validate ...
@@ -51,6 +51,8 @@ type HuaWeiDiskCreateReq struct {
 	DiskChargeType    *string                          `json:"disk_charge_type" validate:"required"`
 	DiskChargePrepaid *hcproto.HuaWeiDiskChargePrepaid `json:"disk_charge_prepaid" validate:"omitempty"`
 	Memo              *string                          `json:"memo" validate:"omitempty"`
+	// SnapshotID 用于创建云盘的快照ID，指定时从快照恢复云盘
+	SnapshotID *string `json:"snapshot_id" validate:"omitempty"`
 }
 
 // Validate ...
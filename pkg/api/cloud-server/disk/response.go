@@ -20,6 +20,7 @@
 package csdisk
 
 import (
+	"hcm/pkg/api/core"
 	coredisk "hcm/pkg/api/core/cloud/disk"
 )
 
@@ -75,3 +76,10 @@ type GcpDiskExtResult struct {
 	InstanceID                            string `json:"instance_id,omitempty"`
 	InstanceName                          string `json:"instance_name,omitempty"`
 }
+
+// DiskDeleteRecycleResult delete recycle disk result.
+type DiskDeleteRecycleResult struct {
+	core.BatchOperateResult `json:",inline"`
+	// Waiting 等待保留的快照可用的回收记录ID，快照可用后由定时回收任务销毁云盘
+	Waiting []string `json:"waiting,omitempty"`
+}
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudLoadBalancerCreateReq define tcloud load balancer create request.
+type TCloudLoadBalancerCreateReq struct {
+	AccountID                  string `json:"account_id" validate:"required"`
+	*typelb.TCloudCreateOption `json:",inline" validate:"required"`
+}
+
+// Validate TCloudLoadBalancerCreateReq.
+func (req *TCloudLoadBalancerCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.TCloudCreateOption.Validate()
+}
+
+// AwsLoadBalancerCreateReq define aws load balancer create request.
+type AwsLoadBalancerCreateReq struct {
+	AccountID               string `json:"account_id" validate:"required"`
+	*typelb.AwsCreateOption `json:",inline" validate:"required"`
+}
+
+// Validate AwsLoadBalancerCreateReq.
+func (req *AwsLoadBalancerCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.AwsCreateOption.Validate()
+}
+
+// AssignLoadBalancerToBizReq define assign load balancer to biz request.
+type AssignLoadBalancerToBizReq struct {
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+	IDs     []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate AssignLoadBalancerToBizReq.
+func (req *AssignLoadBalancerToBizReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
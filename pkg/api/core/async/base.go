@@ -28,28 +28,71 @@ import (
 
 // AsyncFlow ...
 type AsyncFlow struct {
-	ID            string                `json:"id"`
-	Name          enumor.FlowName       `json:"name"`
-	State         enumor.FlowState      `json:"state"`
-	Reason        *tableasync.Reason    `json:"reason"`
-	ShareData     *tableasync.ShareData `json:"share_data"`
-	Memo          string                `json:"memo"`
-	Worker        *string               `json:"worker"`
-	core.Revision `json:",inline"`
+	ID             string                `json:"id"`
+	Name           enumor.FlowName       `json:"name"`
+	State          enumor.FlowState      `json:"state"`
+	Reason         *tableasync.Reason    `json:"reason"`
+	ShareData      *tableasync.ShareData `json:"share_data"`
+	Memo           string                `json:"memo"`
+	Worker         *string               `json:"worker"`
+	StartAt        string                `json:"start_at"`
+	Priority       int                   `json:"priority"`
+	AccountID      string                `json:"account_id"`
+	Compensate     bool                  `json:"compensate"`
+	ParentFlowID   string                `json:"parent_flow_id"`
+	ParentTaskID   string                `json:"parent_task_id"`
+	Callbacks      tableasync.Callbacks  `json:"callbacks"`
+	IdempotencyKey string                `json:"idempotency_key"`
+	core.Revision  `json:",inline"`
 }
 
 // AsyncFlowTask ...
 type AsyncFlowTask struct {
-	ID            string             `json:"id"`
-	FlowID        string             `json:"flow_id"`
-	FlowName      enumor.FlowName    `json:"flow_name"`
-	ActionID      string             `json:"action_id"`
-	ActionName    enumor.ActionName  `json:"action_name"`
-	Params        types.JsonField    `json:"params"`
-	Result        types.JsonField    `json:"result"`
-	Retry         *tableasync.Retry  `json:"retry"`
-	DependOn      types.StringArray  `json:"depend_on"`
-	State         enumor.TaskState   `json:"state"`
-	Reason        *tableasync.Reason `json:"reason"`
+	ID            string                   `json:"id"`
+	FlowID        string                   `json:"flow_id"`
+	FlowName      enumor.FlowName          `json:"flow_name"`
+	ActionID      string                   `json:"action_id"`
+	ActionName    enumor.ActionName        `json:"action_name"`
+	Params        types.JsonField          `json:"params"`
+	Result        types.JsonField          `json:"result"`
+	Retry         *tableasync.Retry        `json:"retry"`
+	DependOn      types.StringArray        `json:"depend_on"`
+	TimeoutSec    uint                     `json:"timeout_sec"`
+	AllowFailure  bool                     `json:"allow_failure"`
+	TriggerRule   enumor.TaskTriggerRule   `json:"trigger_rule"`
+	RunCondition  *tableasync.RunCondition `json:"run_condition"`
+	ParentID      string                   `json:"parent_id"`
+	State         enumor.TaskState         `json:"state"`
+	Reason        *tableasync.Reason       `json:"reason"`
+	RetryState    *tableasync.RetryState   `json:"retry_state"`
+	core.Revision `json:",inline"`
+}
+
+// AsyncFlowCron ...
+type AsyncFlowCron struct {
+	ID            string                   `json:"id"`
+	Name          enumor.FlowName          `json:"name"`
+	Spec          string                   `json:"spec"`
+	ShareData     *tableasync.ShareData    `json:"share_data"`
+	Tasks         tableasync.CronFlowTasks `json:"tasks"`
+	Memo          string                   `json:"memo"`
+	NextRunAt     string                   `json:"next_run_at"`
+	Priority      int                      `json:"priority"`
+	AccountID     string                   `json:"account_id"`
+	Compensate    bool                     `json:"compensate"`
+	core.Revision `json:",inline"`
+}
+
+// AsyncFlowCallback 任务流回调的投递记录
+type AsyncFlowCallback struct {
+	ID            string               `json:"id"`
+	FlowID        string               `json:"flow_id"`
+	FlowState     enumor.FlowState     `json:"flow_state"`
+	Callback      *tableasync.Callback `json:"callback"`
+	Payload       types.JsonField      `json:"payload"`
+	State         enumor.CallbackState `json:"state"`
+	Attempts      uint                 `json:"attempts"`
+	NextAt        string               `json:"next_at"`
+	Reason        *tableasync.Reason   `json:"reason"`
 	core.Revision `json:",inline"`
 }
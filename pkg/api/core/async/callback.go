@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package coreasync
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
+)
+
+const (
+	// CallbackDeliveryHeader 回调请求头，回调投递记录ID，重新投递时保持不变，订阅方可以用来去重
+	CallbackDeliveryHeader = "X-Hcm-Callback-Delivery"
+	// CallbackTimestampHeader 回调请求头，发送回调的Unix时间戳（秒）
+	CallbackTimestampHeader = "X-Hcm-Callback-Timestamp"
+	// CallbackSignatureHeader 回调请求头，回调签名，计算方式见 SignCallback
+	CallbackSignatureHeader = "X-Hcm-Callback-Signature"
+)
+
+// FlowCallbackEvent 任务流回调事件，任务流进入最终状态后发送给回调订阅方。
+type FlowCallbackEvent struct {
+	FlowID   string             `json:"flow_id"`
+	FlowName enumor.FlowName    `json:"flow_name"`
+	State    enumor.FlowState   `json:"state"`
+	Reason   *tableasync.Reason `json:"reason"`
+	Memo     string             `json:"memo"`
+	// FinishedAt 任务流进入最终状态的时间
+	FinishedAt string                  `json:"finished_at"`
+	Tasks      []FlowCallbackEventTask `json:"tasks"`
+}
+
+// FlowCallbackEventTask 任务流回调事件中任务的执行结果
+type FlowCallbackEventTask struct {
+	ID         string             `json:"id"`
+	ActionID   string             `json:"action_id"`
+	ActionName enumor.ActionName  `json:"action_name"`
+	State      enumor.TaskState   `json:"state"`
+	Reason     *tableasync.Reason `json:"reason"`
+	Result     types.JsonField    `json:"result"`
+}
+
+// SignCallback 计算回调签名，签名为 hex(HMAC-SHA256(secret, timestamp + "." + body))。
+func SignCallback(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCallback 校验回调签名，订阅方收到回调后使用与任务服务相同的密钥校验请求是否合法。
+func VerifyCallback(secret, timestamp, signature string, body []byte) bool {
+	return hmac.Equal([]byte(SignCallback(secret, timestamp, body)), []byte(signature))
+}
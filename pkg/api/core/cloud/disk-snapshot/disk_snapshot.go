@@ -0,0 +1,134 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package coresnapshot defines disk snapshot core types.
+package coresnapshot
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// DiskSnapshot define disk snapshot.
+type DiskSnapshot[Ext Extension] struct {
+	BaseDiskSnapshot `json:",inline"`
+	Extension        *Ext `json:"extension"`
+}
+
+// GetID ...
+func (s DiskSnapshot[Ext]) GetID() string {
+	return s.ID
+}
+
+// GetCloudID ...
+func (s DiskSnapshot[Ext]) GetCloudID() string {
+	return s.CloudID
+}
+
+// Extension disk snapshot extension.
+type Extension interface {
+	TCloudExtension | AwsExtension | AzureExtension | GcpExtension | HuaWeiExtension
+}
+
+// BaseDiskSnapshot 云盘快照
+type BaseDiskSnapshot struct {
+	ID               string        `json:"id"`
+	CloudID          string        `json:"cloud_id"`
+	Name             string        `json:"name"`
+	Vendor           enumor.Vendor `json:"vendor"`
+	AccountID        string        `json:"account_id"`
+	BkBizID          int64         `json:"bk_biz_id"`
+	Region           string        `json:"region"`
+	Zone             string        `json:"zone"`
+	DiskID           string        `json:"disk_id"`
+	CloudDiskID      string        `json:"cloud_disk_id"`
+	Size             uint64        `json:"size"`
+	Status           string        `json:"status"`
+	CloudCreatedTime string        `json:"cloud_created_time"`
+	Memo             *string       `json:"memo"`
+	core.Revision    `json:",inline"`
+}
+
+// TCloudExtension define tcloud disk snapshot extension.
+type TCloudExtension struct {
+	// SnapshotType 快照类型，PRIVATE_SNAPSHOT：私有快照，SHARED_SNAPSHOT：共享快照
+	SnapshotType *string `json:"snapshot_type"`
+	// DiskUsage 源云盘类型，SYSTEM_DISK：系统盘，DATA_DISK：数据盘
+	DiskUsage *string `json:"disk_usage"`
+	// Encrypt 是否为加密盘创建的快照
+	Encrypt *bool `json:"encrypt"`
+	// IsPermanent 是否为永久快照
+	IsPermanent *bool `json:"is_permanent"`
+	// DeadlineTime 快照到期时间
+	DeadlineTime *string `json:"deadline_time"`
+	// Percent 快照创建进度百分比
+	Percent *uint64 `json:"percent"`
+}
+
+// AwsExtension define aws disk snapshot extension.
+type AwsExtension struct {
+	// Description 快照描述
+	Description *string `json:"description"`
+	// Encrypted 是否加密
+	Encrypted *bool `json:"encrypted"`
+	// Progress 快照创建进度
+	Progress *string `json:"progress"`
+	// StorageTier 存储层级，archive | standard
+	StorageTier *string `json:"storage_tier"`
+	// OwnerID 快照所有者账号ID
+	OwnerID *string `json:"owner_id"`
+}
+
+// AzureExtension define azure disk snapshot extension.
+type AzureExtension struct {
+	// ResourceGroupName 资源组名称
+	ResourceGroupName string `json:"resource_group_name"`
+	// SKUName 快照存储类型
+	SKUName *string `json:"sku_name"`
+	// Incremental 是否为增量快照
+	Incremental *bool `json:"incremental"`
+	// OSType 源云盘操作系统类型
+	OSType *string `json:"os_type"`
+}
+
+// GcpExtension define gcp disk snapshot extension.
+type GcpExtension struct {
+	// SelfLink 快照资源链接，通过快照创建云盘时使用
+	SelfLink string `json:"self_link"`
+	// SourceDiskSelfLink 源云盘资源链接
+	SourceDiskSelfLink string `json:"source_disk_self_link"`
+	// StorageBytes 快照实际占用的存储大小，单位字节
+	StorageBytes int64 `json:"storage_bytes"`
+	// StorageLocations 快照存储位置
+	StorageLocations []string `json:"storage_locations"`
+	// SnapshotType 快照类型，ARCHIVE | STANDARD
+	SnapshotType string `json:"snapshot_type"`
+}
+
+// HuaWeiExtension define huawei disk snapshot extension.
+type HuaWeiExtension struct {
+	// Description 快照描述
+	Description *string `json:"description"`
+	// Progress 快照创建进度
+	Progress string `json:"progress"`
+	// ServiceType 云服务类型
+	ServiceType string `json:"service_type"`
+	// UpdatedAt 云上更新时间
+	UpdatedAt *string `json:"updated_at"`
+}
@@ -0,0 +1,285 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package corelb defines load balancer, listener, target group and target core types.
+package corelb
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// LoadBalancer define load balancer.
+type LoadBalancer[Ext Extension] struct {
+	BaseLoadBalancer `json:",inline"`
+	Extension        *Ext `json:"extension"`
+}
+
+// GetID ...
+func (lb LoadBalancer[Ext]) GetID() string {
+	return lb.ID
+}
+
+// GetCloudID ...
+func (lb LoadBalancer[Ext]) GetCloudID() string {
+	return lb.CloudID
+}
+
+// Extension load balancer extension.
+type Extension interface {
+	TCloudExtension | AwsExtension
+}
+
+// BaseLoadBalancer 负载均衡
+type BaseLoadBalancer struct {
+	ID               string                         `json:"id"`
+	CloudID          string                         `json:"cloud_id"`
+	Name             string                         `json:"name"`
+	Vendor           enumor.Vendor                  `json:"vendor"`
+	AccountID        string                         `json:"account_id"`
+	BkBizID          int64                          `json:"bk_biz_id"`
+	Region           string                         `json:"region"`
+	Zones            []string                       `json:"zones"`
+	VpcID            string                         `json:"vpc_id"`
+	CloudVpcID       string                         `json:"cloud_vpc_id"`
+	NetworkType      enumor.LoadBalancerNetworkType `json:"network_type"`
+	IPVersion        string                         `json:"ip_version"`
+	Status           string                         `json:"status"`
+	Domain           string                         `json:"domain"`
+	PublicIPs        []string                       `json:"public_ips"`
+	PrivateIPs       []string                       `json:"private_ips"`
+	CloudCreatedTime string                         `json:"cloud_created_time"`
+	Memo             *string                        `json:"memo"`
+	core.Revision    `json:",inline"`
+}
+
+// TCloudExtension define tcloud load balancer extension.
+type TCloudExtension struct {
+	// Forward 负载均衡类型标识，1：负载均衡，0：传统型负载均衡
+	Forward *uint64 `json:"forward"`
+	// CloudSubnetID 内网负载均衡所在子网
+	CloudSubnetID *string `json:"cloud_subnet_id"`
+	// CloudSecurityGroupIDs 负载均衡绑定的安全组
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids"`
+	// BackupZones 备可用区
+	BackupZones []string `json:"backup_zones"`
+	// ChargeType 计费类型，PREPAID：包年包月，POSTPAID_BY_HOUR：按量计费
+	ChargeType *string `json:"charge_type"`
+	// InternetChargeType 网络计费模式
+	InternetChargeType *string `json:"internet_charge_type"`
+	// InternetMaxBandwidthOut 最大出带宽，单位Mbps
+	InternetMaxBandwidthOut *int64 `json:"internet_max_bandwidth_out"`
+	// VipIsp 运营商
+	VipIsp *string `json:"vip_isp"`
+	// AddressIPv6 IPv6地址
+	AddressIPv6 *string `json:"address_ipv6"`
+}
+
+// AwsExtension define aws load balancer extension.
+type AwsExtension struct {
+	// Type 负载均衡类型，application | network | gateway
+	Type *string `json:"type"`
+	// Scheme internet-facing | internal
+	Scheme *string `json:"scheme"`
+	// CanonicalHostedZoneID 负载均衡 Route 53 托管区域ID
+	CanonicalHostedZoneID *string `json:"canonical_hosted_zone_id"`
+	// CloudSubnetIDs 负载均衡所在子网
+	CloudSubnetIDs []string `json:"cloud_subnet_ids"`
+	// CloudSecurityGroupIDs 负载均衡绑定的安全组
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids"`
+	// StateReason 状态说明
+	StateReason *string `json:"state_reason"`
+}
+
+// Listener define load balancer listener.
+type Listener[Ext ListenerExtension] struct {
+	BaseListener `json:",inline"`
+	Extension    *Ext `json:"extension"`
+}
+
+// GetID ...
+func (l Listener[Ext]) GetID() string {
+	return l.ID
+}
+
+// GetCloudID ...
+func (l Listener[Ext]) GetCloudID() string {
+	return l.CloudID
+}
+
+// ListenerExtension listener extension.
+type ListenerExtension interface {
+	TCloudListenerExtension | AwsListenerExtension
+}
+
+// BaseListener 负载均衡监听器
+type BaseListener struct {
+	ID            string        `json:"id"`
+	CloudID       string        `json:"cloud_id"`
+	Name          string        `json:"name"`
+	Vendor        enumor.Vendor `json:"vendor"`
+	AccountID     string        `json:"account_id"`
+	LbID          string        `json:"lb_id"`
+	CloudLbID     string        `json:"cloud_lb_id"`
+	Protocol      string        `json:"protocol"`
+	Port          int64         `json:"port"`
+	core.Revision `json:",inline"`
+}
+
+// TCloudListenerExtension define tcloud listener extension.
+type TCloudListenerExtension struct {
+	// EndPort 端口段结束端口
+	EndPort *int64 `json:"end_port"`
+	// Scheduler 请求调度方式，WRR | LEAST_CONN | IP_HASH
+	Scheduler *string `json:"scheduler"`
+	// SessionExpireTime 会话保持时间
+	SessionExpireTime *int64 `json:"session_expire_time"`
+	// SniSwitch 是否开启SNI特性
+	SniSwitch *int64 `json:"sni_switch"`
+	// CloudCertID 服务端证书ID
+	CloudCertID *string `json:"cloud_cert_id"`
+	// TargetType 后端服务器类型，NODE | TARGETGROUP
+	TargetType *string `json:"target_type"`
+	// CloudTargetGroupID 绑定的目标组ID
+	CloudTargetGroupID *string `json:"cloud_target_group_id"`
+	// Rules 七层监听器的转发规则
+	Rules []TCloudRule `json:"rules"`
+}
+
+// TCloudRule define tcloud layer-7 listener forwarding rule.
+type TCloudRule struct {
+	CloudID   string  `json:"cloud_id"`
+	Domain    *string `json:"domain"`
+	Url       *string `json:"url"`
+	Scheduler *string `json:"scheduler"`
+}
+
+// AwsListenerExtension define aws listener extension.
+type AwsListenerExtension struct {
+	// SslPolicy 安全策略
+	SslPolicy *string `json:"ssl_policy"`
+	// CloudCertIDs 证书arn
+	CloudCertIDs []string `json:"cloud_cert_ids"`
+	// AlpnPolicy ALPN策略
+	AlpnPolicy []string `json:"alpn_policy"`
+	// CloudTargetGroupIDs 默认动作转发的目标组arn
+	CloudTargetGroupIDs []string `json:"cloud_target_group_ids"`
+}
+
+// TargetGroup define load balancer target group.
+type TargetGroup[Ext TargetGroupExtension] struct {
+	BaseTargetGroup `json:",inline"`
+	Extension       *Ext `json:"extension"`
+}
+
+// GetID ...
+func (tg TargetGroup[Ext]) GetID() string {
+	return tg.ID
+}
+
+// GetCloudID ...
+func (tg TargetGroup[Ext]) GetCloudID() string {
+	return tg.CloudID
+}
+
+// TargetGroupExtension target group extension.
+type TargetGroupExtension interface {
+	TCloudTargetGroupExtension | AwsTargetGroupExtension
+}
+
+// BaseTargetGroup 负载均衡目标组
+type BaseTargetGroup struct {
+	ID            string        `json:"id"`
+	CloudID       string        `json:"cloud_id"`
+	Name          string        `json:"name"`
+	Vendor        enumor.Vendor `json:"vendor"`
+	AccountID     string        `json:"account_id"`
+	Region        string        `json:"region"`
+	VpcID         string        `json:"vpc_id"`
+	CloudVpcID    string        `json:"cloud_vpc_id"`
+	Protocol      string        `json:"protocol"`
+	Port          int64         `json:"port"`
+	TargetType    string        `json:"target_type"`
+	core.Revision `json:",inline"`
+}
+
+// TCloudTargetGroupExtension define tcloud target group extension.
+type TCloudTargetGroupExtension struct {
+	// CloudLbIDs 目标组关联的负载均衡ID
+	CloudLbIDs []string `json:"cloud_lb_ids"`
+	// CloudListenerIDs 目标组关联的监听器ID
+	CloudListenerIDs []string `json:"cloud_listener_ids"`
+	// CloudCreatedTime 云上创建时间
+	CloudCreatedTime *string `json:"cloud_created_time"`
+}
+
+// AwsTargetGroupExtension define aws target group extension.
+type AwsTargetGroupExtension struct {
+	// CloudLbIDs 目标组关联的负载均衡arn
+	CloudLbIDs []string `json:"cloud_lb_ids"`
+	// HealthCheckEnabled 是否开启健康检查
+	HealthCheckEnabled *bool `json:"health_check_enabled"`
+	// HealthCheckProtocol 健康检查协议
+	HealthCheckProtocol *string `json:"health_check_protocol"`
+	// HealthCheckPath 健康检查路径
+	HealthCheckPath *string `json:"health_check_path"`
+	// IpAddressType ipv4 | ipv6
+	IpAddressType *string `json:"ip_address_type"`
+}
+
+// Target 负载均衡后端服务
+type Target struct {
+	ID                 string                `json:"id"`
+	CloudID            string                `json:"cloud_id"`
+	Vendor             enumor.Vendor         `json:"vendor"`
+	AccountID          string                `json:"account_id"`
+	LbID               string                `json:"lb_id"`
+	CloudLbID          string                `json:"cloud_lb_id"`
+	ListenerID         string                `json:"listener_id"`
+	CloudListenerID    string                `json:"cloud_listener_id"`
+	CloudRuleID        string                `json:"cloud_rule_id"`
+	TargetGroupID      string                `json:"target_group_id"`
+	CloudTargetGroupID string                `json:"cloud_target_group_id"`
+	InstType           enumor.TargetInstType `json:"inst_type"`
+	CloudInstID        string                `json:"cloud_inst_id"`
+	PrivateIPAddresses []string              `json:"private_ip_addresses"`
+	PublicIPAddresses  []string              `json:"public_ip_addresses"`
+	Port               int64                 `json:"port"`
+	Weight             *int64                `json:"weight"`
+	core.Revision      `json:",inline"`
+}
+
+// GetID ...
+func (t Target) GetID() string {
+	return t.ID
+}
+
+// GetCloudID ...
+func (t Target) GetCloudID() string {
+	return t.CloudID
+}
+
+// CvmRel 负载均衡和主机关联关系
+type CvmRel struct {
+	ID        uint64 `json:"id"`
+	LbID      string `json:"lb_id"`
+	CvmID     string `json:"cvm_id"`
+	Creator   string `json:"creator"`
+	CreatedAt string `json:"created_at"`
+}
@@ -94,7 +94,31 @@ type CvmRecycleOptions struct {
 }
 
 // DiskRecycleOptions disk recycle record options.
-type DiskRecycleOptions struct{}
+type DiskRecycleOptions struct {
+	// KeepSnapshot 销毁云盘前是否先为云盘创建快照
+	KeepSnapshot bool `json:"keep_snapshot,omitempty"`
+	// SnapshotID 销毁云盘前为云盘创建的快照ID，回收重试时复用该快照
+	SnapshotID string `json:"snapshot_id,omitempty"`
+}
+
+// ParseDiskRecycleOptions 从回收记录详情中解析云盘回收选项
+func ParseDiskRecycleOptions(detail any) (*DiskRecycleOptions, error) {
+	opt := new(DiskRecycleOptions)
+	if detail == nil {
+		return opt, nil
+	}
+
+	raw, err := json.Marshal(detail)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(raw, opt); err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
 
 // DiskRelatedRecycleOpt 磁盘作为关联资源回收时的回收选项，记录关联的cvm_id
 type DiskRelatedRecycleOpt struct {
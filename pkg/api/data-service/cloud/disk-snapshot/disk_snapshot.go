@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dssnapshot defines data service disk snapshot api types.
+package dssnapshot
+
+import (
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Create --------------------------
+
+// DiskSnapshotBatchCreateReq define batch create disk snapshot request.
+type DiskSnapshotBatchCreateReq struct {
+	Items []DiskSnapshotCreateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate DiskSnapshotBatchCreateReq.
+func (req DiskSnapshotBatchCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotCreateField define disk snapshot create field.
+type DiskSnapshotCreateField struct {
+	CloudID          string          `json:"cloud_id" validate:"required"`
+	Name             string          `json:"name" validate:"omitempty"`
+	Vendor           enumor.Vendor   `json:"vendor" validate:"required"`
+	AccountID        string          `json:"account_id" validate:"required"`
+	BkBizID          int64           `json:"bk_biz_id" validate:"omitempty"`
+	Region           string          `json:"region" validate:"omitempty"`
+	Zone             string          `json:"zone" validate:"omitempty"`
+	DiskID           string          `json:"disk_id" validate:"omitempty"`
+	CloudDiskID      string          `json:"cloud_disk_id" validate:"omitempty"`
+	Size             uint64          `json:"size" validate:"omitempty"`
+	Status           string          `json:"status" validate:"omitempty"`
+	CloudCreatedTime string          `json:"cloud_created_time" validate:"omitempty"`
+	Extension        core.ExtMessage `json:"extension" validate:"required"`
+	Memo             *string         `json:"memo" validate:"omitempty"`
+}
+
+// -------------------------- Update --------------------------
+
+// DiskSnapshotBatchUpdateReq define batch update disk snapshot request.
+type DiskSnapshotBatchUpdateReq struct {
+	Items []DiskSnapshotUpdateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate DiskSnapshotBatchUpdateReq.
+func (req DiskSnapshotBatchUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotUpdateField define disk snapshot update field.
+type DiskSnapshotUpdateField struct {
+	ID string `json:"id" validate:"required"`
+
+	Name             string          `json:"name" validate:"omitempty"`
+	BkBizID          int64           `json:"bk_biz_id" validate:"omitempty"`
+	DiskID           string          `json:"disk_id" validate:"omitempty"`
+	Size             uint64          `json:"size" validate:"omitempty"`
+	Status           string          `json:"status" validate:"omitempty"`
+	CloudCreatedTime string          `json:"cloud_created_time" validate:"omitempty"`
+	Extension        core.ExtMessage `json:"extension" validate:"omitempty"`
+	Memo             *string         `json:"memo" validate:"omitempty"`
+}
+
+// -------------------------- List --------------------------
+
+// DiskSnapshotListResult defines list disk snapshot result.
+type DiskSnapshotListResult struct {
+	Count   uint64                          `json:"count"`
+	Details []coresnapshot.BaseDiskSnapshot `json:"details"`
+}
+
+// DiskSnapshotListExtResult define list disk snapshot with extension result.
+type DiskSnapshotListExtResult[T coresnapshot.Extension] struct {
+	Count   uint64                         `json:"count,omitempty"`
+	Details []coresnapshot.DiskSnapshot[T] `json:"details,omitempty"`
+}
@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dslb defines data service load balancer, listener, target group, target and cvm relation api types.
+package dslb
+
+import (
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+)
+
+// -------------------------- Create --------------------------
+
+// LoadBalancerBatchCreateReq define batch create load balancer request.
+type LoadBalancerBatchCreateReq struct {
+	Items []LoadBalancerCreateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate LoadBalancerBatchCreateReq.
+func (req LoadBalancerBatchCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// LoadBalancerCreateField define load balancer create field.
+type LoadBalancerCreateField struct {
+	CloudID          string                         `json:"cloud_id" validate:"required"`
+	Name             string                         `json:"name" validate:"omitempty"`
+	Vendor           enumor.Vendor                  `json:"vendor" validate:"required"`
+	AccountID        string                         `json:"account_id" validate:"required"`
+	BkBizID          int64                          `json:"bk_biz_id" validate:"omitempty"`
+	Region           string                         `json:"region" validate:"required"`
+	Zones            types.StringArray              `json:"zones" validate:"omitempty"`
+	VpcID            string                         `json:"vpc_id" validate:"omitempty"`
+	CloudVpcID       string                         `json:"cloud_vpc_id" validate:"omitempty"`
+	NetworkType      enumor.LoadBalancerNetworkType `json:"network_type" validate:"omitempty"`
+	IPVersion        string                         `json:"ip_version" validate:"omitempty"`
+	Status           string                         `json:"status" validate:"omitempty"`
+	Domain           string                         `json:"domain" validate:"omitempty"`
+	PublicIPs        types.StringArray              `json:"public_ips" validate:"omitempty"`
+	PrivateIPs       types.StringArray              `json:"private_ips" validate:"omitempty"`
+	CloudCreatedTime string                         `json:"cloud_created_time" validate:"omitempty"`
+	Extension        core.ExtMessage                `json:"extension" validate:"required"`
+	Memo             *string                        `json:"memo" validate:"omitempty"`
+}
+
+// -------------------------- Update --------------------------
+
+// LoadBalancerBatchUpdateReq define batch update load balancer request.
+type LoadBalancerBatchUpdateReq struct {
+	Items []LoadBalancerUpdateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate LoadBalancerBatchUpdateReq.
+func (req LoadBalancerBatchUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// LoadBalancerUpdateField define load balancer update field.
+type LoadBalancerUpdateField struct {
+	ID string `json:"id" validate:"required"`
+
+	Name             string                         `json:"name" validate:"omitempty"`
+	BkBizID          int64                          `json:"bk_biz_id" validate:"omitempty"`
+	Zones            types.StringArray              `json:"zones" validate:"omitempty"`
+	VpcID            string                         `json:"vpc_id" validate:"omitempty"`
+	CloudVpcID       string                         `json:"cloud_vpc_id" validate:"omitempty"`
+	NetworkType      enumor.LoadBalancerNetworkType `json:"network_type" validate:"omitempty"`
+	IPVersion        string                         `json:"ip_version" validate:"omitempty"`
+	Status           string                         `json:"status" validate:"omitempty"`
+	Domain           string                         `json:"domain" validate:"omitempty"`
+	PublicIPs        types.StringArray              `json:"public_ips" validate:"omitempty"`
+	PrivateIPs       types.StringArray              `json:"private_ips" validate:"omitempty"`
+	CloudCreatedTime string                         `json:"cloud_created_time" validate:"omitempty"`
+	Extension        core.ExtMessage                `json:"extension" validate:"omitempty"`
+	Memo             *string                        `json:"memo" validate:"omitempty"`
+}
+
+// -------------------------- List --------------------------
+
+// LoadBalancerListResult defines list load balancer result.
+type LoadBalancerListResult struct {
+	Count   uint64                    `json:"count"`
+	Details []corelb.BaseLoadBalancer `json:"details"`
+}
+
+// LoadBalancerListExtResult define list load balancer with extension result.
+type LoadBalancerListExtResult[T corelb.Extension] struct {
+	Count   uint64                   `json:"count,omitempty"`
+	Details []corelb.LoadBalancer[T] `json:"details,omitempty"`
+}
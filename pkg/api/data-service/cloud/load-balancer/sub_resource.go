@@ -0,0 +1,233 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package dslb
+
+import (
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+)
+
+// -------------------------- Listener --------------------------
+
+// ListenerBatchCreateReq define batch create listener request.
+type ListenerBatchCreateReq struct {
+	Items []ListenerCreateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate ListenerBatchCreateReq.
+func (req ListenerBatchCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ListenerCreateField define listener create field.
+type ListenerCreateField struct {
+	CloudID   string          `json:"cloud_id" validate:"required"`
+	Name      string          `json:"name" validate:"omitempty"`
+	Vendor    enumor.Vendor   `json:"vendor" validate:"required"`
+	AccountID string          `json:"account_id" validate:"required"`
+	LbID      string          `json:"lb_id" validate:"required"`
+	CloudLbID string          `json:"cloud_lb_id" validate:"required"`
+	Protocol  string          `json:"protocol" validate:"omitempty"`
+	Port      int64           `json:"port" validate:"omitempty"`
+	Extension core.ExtMessage `json:"extension" validate:"required"`
+}
+
+// ListenerBatchUpdateReq define batch update listener request.
+type ListenerBatchUpdateReq struct {
+	Items []ListenerUpdateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate ListenerBatchUpdateReq.
+func (req ListenerBatchUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ListenerUpdateField define listener update field.
+type ListenerUpdateField struct {
+	ID string `json:"id" validate:"required"`
+
+	Name      string          `json:"name" validate:"omitempty"`
+	Protocol  string          `json:"protocol" validate:"omitempty"`
+	Port      int64           `json:"port" validate:"omitempty"`
+	Extension core.ExtMessage `json:"extension" validate:"omitempty"`
+}
+
+// ListenerListResult defines list listener result.
+type ListenerListResult struct {
+	Count   uint64                `json:"count"`
+	Details []corelb.BaseListener `json:"details"`
+}
+
+// ListenerListExtResult define list listener with extension result.
+type ListenerListExtResult[T corelb.ListenerExtension] struct {
+	Count   uint64               `json:"count,omitempty"`
+	Details []corelb.Listener[T] `json:"details,omitempty"`
+}
+
+// -------------------------- Target Group --------------------------
+
+// TargetGroupBatchCreateReq define batch create target group request.
+type TargetGroupBatchCreateReq struct {
+	Items []TargetGroupCreateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate TargetGroupBatchCreateReq.
+func (req TargetGroupBatchCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TargetGroupCreateField define target group create field.
+type TargetGroupCreateField struct {
+	CloudID    string          `json:"cloud_id" validate:"required"`
+	Name       string          `json:"name" validate:"omitempty"`
+	Vendor     enumor.Vendor   `json:"vendor" validate:"required"`
+	AccountID  string          `json:"account_id" validate:"required"`
+	Region     string          `json:"region" validate:"required"`
+	VpcID      string          `json:"vpc_id" validate:"omitempty"`
+	CloudVpcID string          `json:"cloud_vpc_id" validate:"omitempty"`
+	Protocol   string          `json:"protocol" validate:"omitempty"`
+	Port       int64           `json:"port" validate:"omitempty"`
+	TargetType string          `json:"target_type" validate:"omitempty"`
+	Extension  core.ExtMessage `json:"extension" validate:"required"`
+}
+
+// TargetGroupBatchUpdateReq define batch update target group request.
+type TargetGroupBatchUpdateReq struct {
+	Items []TargetGroupUpdateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate TargetGroupBatchUpdateReq.
+func (req TargetGroupBatchUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TargetGroupUpdateField define target group update field.
+type TargetGroupUpdateField struct {
+	ID string `json:"id" validate:"required"`
+
+	Name       string          `json:"name" validate:"omitempty"`
+	VpcID      string          `json:"vpc_id" validate:"omitempty"`
+	CloudVpcID string          `json:"cloud_vpc_id" validate:"omitempty"`
+	Protocol   string          `json:"protocol" validate:"omitempty"`
+	Port       int64           `json:"port" validate:"omitempty"`
+	TargetType string          `json:"target_type" validate:"omitempty"`
+	Extension  core.ExtMessage `json:"extension" validate:"omitempty"`
+}
+
+// TargetGroupListResult defines list target group result.
+type TargetGroupListResult struct {
+	Count   uint64                   `json:"count"`
+	Details []corelb.BaseTargetGroup `json:"details"`
+}
+
+// TargetGroupListExtResult define list target group with extension result.
+type TargetGroupListExtResult[T corelb.TargetGroupExtension] struct {
+	Count   uint64                  `json:"count,omitempty"`
+	Details []corelb.TargetGroup[T] `json:"details,omitempty"`
+}
+
+// -------------------------- Target --------------------------
+
+// TargetBatchCreateReq define batch create target request.
+type TargetBatchCreateReq struct {
+	Items []TargetCreateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate TargetBatchCreateReq.
+func (req TargetBatchCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TargetCreateField define target create field.
+type TargetCreateField struct {
+	CloudID            string                `json:"cloud_id" validate:"required"`
+	Vendor             enumor.Vendor         `json:"vendor" validate:"required"`
+	AccountID          string                `json:"account_id" validate:"required"`
+	LbID               string                `json:"lb_id" validate:"omitempty"`
+	CloudLbID          string                `json:"cloud_lb_id" validate:"omitempty"`
+	ListenerID         string                `json:"listener_id" validate:"omitempty"`
+	CloudListenerID    string                `json:"cloud_listener_id" validate:"omitempty"`
+	CloudRuleID        string                `json:"cloud_rule_id" validate:"omitempty"`
+	TargetGroupID      string                `json:"target_group_id" validate:"omitempty"`
+	CloudTargetGroupID string                `json:"cloud_target_group_id" validate:"omitempty"`
+	InstType           enumor.TargetInstType `json:"inst_type" validate:"omitempty"`
+	CloudInstID        string                `json:"cloud_inst_id" validate:"required"`
+	PrivateIPAddresses types.StringArray     `json:"private_ip_addresses" validate:"omitempty"`
+	PublicIPAddresses  types.StringArray     `json:"public_ip_addresses" validate:"omitempty"`
+	Port               int64                 `json:"port" validate:"omitempty"`
+	Weight             *int64                `json:"weight" validate:"omitempty"`
+}
+
+// TargetBatchUpdateReq define batch update target request.
+type TargetBatchUpdateReq struct {
+	Items []TargetUpdateField `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate TargetBatchUpdateReq.
+func (req TargetBatchUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TargetUpdateField define target update field.
+type TargetUpdateField struct {
+	ID string `json:"id" validate:"required"`
+
+	LbID               string                `json:"lb_id" validate:"omitempty"`
+	CloudLbID          string                `json:"cloud_lb_id" validate:"omitempty"`
+	ListenerID         string                `json:"listener_id" validate:"omitempty"`
+	TargetGroupID      string                `json:"target_group_id" validate:"omitempty"`
+	InstType           enumor.TargetInstType `json:"inst_type" validate:"omitempty"`
+	PrivateIPAddresses types.StringArray     `json:"private_ip_addresses" validate:"omitempty"`
+	PublicIPAddresses  types.StringArray     `json:"public_ip_addresses" validate:"omitempty"`
+	Weight             *int64                `json:"weight" validate:"omitempty"`
+}
+
+// TargetListResult defines list target result.
+type TargetListResult struct {
+	Count   uint64          `json:"count"`
+	Details []corelb.Target `json:"details"`
+}
+
+// -------------------------- Cvm Rel --------------------------
+
+// CvmRelBatchCreateReq define batch create load balancer and cvm relation request.
+type CvmRelBatchCreateReq struct {
+	Rels []CvmRelCreateField `json:"rels" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate CvmRelBatchCreateReq.
+func (req CvmRelBatchCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// CvmRelCreateField define load balancer and cvm relation create field.
+type CvmRelCreateField struct {
+	LbID  string `json:"lb_id" validate:"required"`
+	CvmID string `json:"cvm_id" validate:"required"`
+}
+
+// CvmRelListResult defines list load balancer and cvm relation result.
+type CvmRelListResult struct {
+	Count   uint64          `json:"count"`
+	Details []corelb.CvmRel `json:"details"`
+}
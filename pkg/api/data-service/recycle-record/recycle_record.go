@@ -92,6 +92,8 @@ type UpdateReq struct {
 	ID     string                     `json:"id" validate:"required"`
 	Status enumor.RecycleRecordStatus `json:"status" validate:"omitempty"`
 	Detail interface{}                `json:"detail" validate:"omitempty"`
+	// RecycledAt 回收时间，标准格式：2006-01-02T15:04:05Z07:00
+	RecycledAt string `json:"recycled_at" validate:"omitempty"`
 }
 
 // Validate BatchUpdateReq.
@@ -0,0 +1,49 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package disksnapshot defines hc service disk snapshot api types.
+package disksnapshot
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// DiskSnapshotCreateReq define disk snapshot create request, snapshot is created from the disk.
+type DiskSnapshotCreateReq struct {
+	DiskID string `json:"disk_id" validate:"required"`
+	// Name 快照名称，需同时满足各云厂商的命名规范，建议只使用小写字母、数字和中划线
+	Name string  `json:"name" validate:"required,max=60"`
+	Memo *string `json:"memo" validate:"omitempty"`
+}
+
+// Validate DiskSnapshotCreateReq.
+func (req *DiskSnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DiskSnapshotBatchDeleteReq define disk snapshot batch delete request, snapshots should belong to one account.
+type DiskSnapshotBatchDeleteReq struct {
+	AccountID string   `json:"account_id" validate:"required"`
+	IDs       []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate DiskSnapshotBatchDeleteReq.
+func (req *DiskSnapshotBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
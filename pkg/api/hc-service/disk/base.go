@@ -37,6 +37,8 @@ type DiskBaseCreateReq struct {
 	DiskType  string  `json:"disk_type" validate:"required"`
 	DiskCount uint32  `json:"disk_count" validate:"required"`
 	Memo      *string `json:"memo"`
+	// SnapshotID 用于创建云盘的快照ID(hcm中的ID)，指定时从快照恢复云盘
+	SnapshotID *string `json:"snapshot_id"`
 }
 
 // DiskSyncReq disk sync request
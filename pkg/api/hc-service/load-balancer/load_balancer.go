@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"errors"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// TCloudLoadBalancerCreateReq define tcloud load balancer create request.
+type TCloudLoadBalancerCreateReq struct {
+	AccountID                  string `json:"account_id" validate:"required"`
+	BkBizID                    int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typelb.TCloudCreateOption `json:",inline" validate:"required"`
+}
+
+// Validate TCloudLoadBalancerCreateReq.
+func (req *TCloudLoadBalancerCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.TCloudCreateOption.Validate()
+}
+
+// AwsLoadBalancerCreateReq define aws load balancer create request.
+type AwsLoadBalancerCreateReq struct {
+	AccountID               string `json:"account_id" validate:"required"`
+	BkBizID                 int64  `json:"bk_biz_id" validate:"omitempty"`
+	*typelb.AwsCreateOption `json:",inline" validate:"required"`
+}
+
+// Validate AwsLoadBalancerCreateReq.
+func (req *AwsLoadBalancerCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.AwsCreateOption.Validate()
+}
+
+// BatchCreateResult define load balancer batch create result.
+type BatchCreateResult struct {
+	UnknownCloudIDs []string `json:"unknown_cloud_ids"`
+	SuccessCloudIDs []string `json:"success_cloud_ids"`
+	FailedCloudIDs  []string `json:"failed_cloud_ids"`
+	FailedMessage   string   `json:"failed_message"`
+}
+
+// BatchCreateResp define load balancer batch create response.
+type BatchCreateResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BatchCreateResult `json:"data"`
+}
+
+// LoadBalancerBatchDeleteReq define load balancer batch delete request, load balancers should belong to one account.
+type LoadBalancerBatchDeleteReq struct {
+	AccountID string   `json:"account_id" validate:"required"`
+	IDs       []string `json:"ids" validate:"required,min=1"`
+}
+
+// Validate LoadBalancerBatchDeleteReq.
+func (req *LoadBalancerBatchDeleteReq) Validate() error {
+	if len(req.IDs) > typelb.TCloudDeleteLimit {
+		return errors.New("ids should <= 20")
+	}
+
+	return validator.Validate.Struct(req)
+}
@@ -20,8 +20,13 @@
 package taskserver
 
 import (
+	"errors"
+
+	"hcm/pkg/api/core"
 	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/waitsignal"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 	tableasync "hcm/pkg/dal/table/async"
 )
@@ -34,12 +39,24 @@ type AddTemplateFlowReq struct {
 	Memo string `json:"memo" validate:"omitempty"`
 	// Tasks 任务私有化参数设置
 	Tasks []TemplateFlowTask `json:"tasks" validate:"required, min=1"`
+	// StartAt 任务流开始执行时间，标准格式：2006-01-02T15:04:05Z07:00，不设置则立即执行
+	StartAt string `json:"start_at" validate:"omitempty"`
+	// Priority 任务流优先级，值越大越优先派发和调度，默认为0
+	Priority int `json:"priority" validate:"omitempty"`
+	// AccountID 任务流操作的云账号，用于按账号限制同时执行的任务流数量
+	AccountID string `json:"account_id" validate:"omitempty,max=64"`
+	// Compensate 任务流执行失败后，是否按照任务依赖的逆序对执行成功的任务进行补偿，清理已经创建的资源
+	Compensate bool `json:"compensate" validate:"omitempty"`
+	// Callbacks 任务流执行结束后的回调订阅，任务流进入success、failed、cancel等最终状态后发送通知
+	Callbacks tableasync.Callbacks `json:"callbacks" validate:"omitempty"`
+	// IdempotencyKey 任务流提交的幂等键，相同幂等键且请求内容一致的重复提交返回已经创建的任务流ID，请求内容不一致时返回冲突错误
+	IdempotencyKey string `json:"idempotency_key" validate:"omitempty,max=64"`
 }
 
 // Validate AddTemplateFlowReq
 func (req *AddTemplateFlowReq) Validate() error {
 
-	if err := req.Name.Validate(); err != nil {
+	if err := action.ValidateTplName(req.Name); err != nil {
 		return err
 	}
 
@@ -49,6 +66,10 @@ func (req *AddTemplateFlowReq) Validate() error {
 		}
 	}
 
+	if err := req.Callbacks.Validate(); err != nil {
+		return err
+	}
+
 	return validator.Validate.Struct(req)
 }
 
@@ -58,6 +79,8 @@ type TemplateFlowTask struct {
 	ActionID action.ActIDType `json:"action_id" validate:"required"`
 	// Params 任务执行请求参数
 	Params interface{} `json:"params" validate:"required"`
+	// TimeoutSec 任务执行超时时间，单位：秒，不设置则使用Action定义的默认超时时间或全局配置的超时时间
+	TimeoutSec uint `json:"timeout_sec" validate:"omitempty"`
 }
 
 // Validate TemplateFlowTask
@@ -75,6 +98,18 @@ type AddCustomFlowReq struct {
 	ShareData *tableasync.ShareData `json:"share_data" validate:"omitempty"`
 	// Tasks 任务私有化参数设置
 	Tasks []CustomFlowTask `json:"tasks" validate:"omitempty"`
+	// StartAt 任务流开始执行时间，标准格式：2006-01-02T15:04:05Z07:00，不设置则立即执行
+	StartAt string `json:"start_at" validate:"omitempty"`
+	// Priority 任务流优先级，值越大越优先派发和调度，默认为0
+	Priority int `json:"priority" validate:"omitempty"`
+	// AccountID 任务流操作的云账号，用于按账号限制同时执行的任务流数量
+	AccountID string `json:"account_id" validate:"omitempty,max=64"`
+	// Compensate 任务流执行失败后，是否按照任务依赖的逆序对执行成功的任务进行补偿，清理已经创建的资源
+	Compensate bool `json:"compensate" validate:"omitempty"`
+	// Callbacks 任务流执行结束后的回调订阅，任务流进入success、failed、cancel等最终状态后发送通知
+	Callbacks tableasync.Callbacks `json:"callbacks" validate:"omitempty"`
+	// IdempotencyKey 任务流提交的幂等键，相同幂等键且请求内容一致的重复提交返回已经创建的任务流ID，请求内容不一致时返回冲突错误
+	IdempotencyKey string `json:"idempotency_key" validate:"omitempty,max=64"`
 }
 
 // Validate AddCustomFlowReq
@@ -90,6 +125,10 @@ func (opt *AddCustomFlowReq) Validate() error {
 		}
 	}
 
+	if err := opt.Callbacks.Validate(); err != nil {
+		return err
+	}
+
 	return validator.Validate.Struct(opt)
 }
 
@@ -106,9 +145,95 @@ type CustomFlowTask struct {
 
 	// Retry 任务运行重试相关配置参数，如果不设置，默认不允许进行重试。
 	Retry *tableasync.Retry `json:"retry" validate:"omitempty"`
+	// TimeoutSec 任务执行超时时间，单位：秒，不设置则使用Action定义的默认超时时间或全局配置的超时时间
+	TimeoutSec uint `json:"timeout_sec" validate:"omitempty"`
+	// AllowFailure 是否允许任务执行失败，允许失败的任务执行失败后，不影响后续任务执行和任务流状态
+	AllowFailure bool `json:"allow_failure" validate:"omitempty"`
+	// TriggerRule 任务触发规则（all_success、on_failure、always），不设置默认为all_success
+	TriggerRule enumor.TaskTriggerRule `json:"trigger_rule" validate:"omitempty"`
+	// RunCondition 任务执行条件，根据任务流共享数据判断任务是否执行，不满足条件时任务会被跳过
+	RunCondition *tableasync.RunCondition `json:"run_condition" validate:"omitempty"`
 }
 
 // Validate CustomFlowTask
 func (task *CustomFlowTask) Validate() error {
-	return validator.Validate.Struct(task)
+	if err := validator.Validate.Struct(task); err != nil {
+		return err
+	}
+
+	if err := task.TriggerRule.Validate(); err != nil {
+		return err
+	}
+
+	if task.RunCondition != nil {
+		if err := task.RunCondition.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddCronFlowReq define add cron flow option. Template 和 Custom 有且只能设置一个。
+type AddCronFlowReq struct {
+	// Spec 五段式cron表达式（分 时 日 月 星期）
+	Spec string `json:"spec" validate:"required"`
+	// Template 按照任务流模版生成任务流
+	Template *AddTemplateFlowReq `json:"template" validate:"omitempty"`
+	// Custom 按照自定义任务流生成任务流
+	Custom *AddCustomFlowReq `json:"custom" validate:"omitempty"`
+}
+
+// Validate AddCronFlowReq
+func (req *AddCronFlowReq) Validate() error {
+	if (req.Template == nil) == (req.Custom == nil) {
+		return errors.New("one of template and custom must be set")
+	}
+
+	if req.Template != nil {
+		if err := req.Template.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if req.Custom != nil {
+		if err := req.Custom.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// SignalTaskReq define signal waiting task request.
+type SignalTaskReq struct {
+	// Decision 审批结果（approve、reject）
+	Decision waitsignal.Decision `json:"decision" validate:"required"`
+	// Memo 审批意见
+	Memo string `json:"memo" validate:"omitempty"`
+}
+
+// Validate SignalTaskReq
+func (req *SignalTaskReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.Decision.Validate()
+}
+
+// ListAsyncReq define list flow or task request.
+type ListAsyncReq struct {
+	*core.ListReq `json:",inline"`
+	// Archived 为true时查询已经归档的任务流或任务
+	Archived bool `json:"archived"`
+}
+
+// Validate ListAsyncReq
+func (req *ListAsyncReq) Validate() error {
+	if req.ListReq == nil {
+		return errf.New(errf.InvalidParameter, "list request is required")
+	}
+
+	return req.ListReq.Validate()
 }
@@ -19,7 +19,11 @@
 
 package taskserver
 
-import "hcm/pkg/api/core/async"
+import (
+	"hcm/pkg/api/core/async"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+)
 
 // ListFlowResult ...
 type ListFlowResult struct {
@@ -27,8 +31,42 @@ type ListFlowResult struct {
 	Details []coreasync.AsyncFlow `json:"details"`
 }
 
+// FlowHierarchy 任务流层级结构，包含由子任务流Action创建的子任务流。
+type FlowHierarchy struct {
+	coreasync.AsyncFlow `json:",inline"`
+	SubFlows            []FlowHierarchy `json:"sub_flows"`
+}
+
 // ListTaskResult ...
 type ListTaskResult struct {
 	Count   uint64                    `json:"count"`
 	Details []coreasync.AsyncFlowTask `json:"details"`
 }
+
+// ListCronFlowResult ...
+type ListCronFlowResult struct {
+	Count   uint64                    `json:"count"`
+	Details []coreasync.AsyncFlowCron `json:"details"`
+}
+
+// ListCallbackDeliveryResult ...
+type ListCallbackDeliveryResult struct {
+	Count   uint64                        `json:"count"`
+	Details []coreasync.AsyncFlowCallback `json:"details"`
+}
+
+// TaskLogsResult task execution logs.
+type TaskLogsResult struct {
+	ID    string              `json:"id"`
+	State enumor.TaskState    `json:"state"`
+	Logs  tableasync.TaskLogs `json:"logs"`
+}
+
+// FlowGraphResult 任务流或任务流模版的任务依赖图。
+type FlowGraphResult struct {
+	// ID 任务流ID，查询任务流模版时为空
+	ID     string                 `json:"id,omitempty"`
+	Name   enumor.FlowName        `json:"name"`
+	Format enumor.FlowGraphFormat `json:"format"`
+	Graph  string                 `json:"graph"`
+}
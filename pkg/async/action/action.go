@@ -23,6 +23,7 @@ package action
 import (
 	"hcm/pkg/async/action/run"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
 )
 
 // Action 异步任务必须实现的运行接口。
@@ -39,8 +40,56 @@ type RollbackAction interface {
 	Rollback(kt run.ExecuteKit, params interface{}) error
 }
 
+// CompensateAction Action如果支持补偿操作，实现该接口。开启补偿的任务流执行失败后，会按照任务依赖的逆序，
+// 对执行成功的任务进行调用，清理任务已经创建的资源。
+// State: success -> compensated
+type CompensateAction interface {
+	// Compensate 补偿操作，result 为任务执行成功时返回的结果。
+	Compensate(kt run.ExecuteKit, params interface{}, result types.JsonField) error
+}
+
+// WaitingAction Action如果需要等待外部事件完成（如子任务流执行结束），实现该接口。Run 返回 *Waiting 时，
+// 任务进入 waiting 状态并释放执行器，调度器定期调用 Poll 检查外部事件是否完成。
+// State: running -> waiting -> success/failed
+type WaitingAction interface {
+	// Poll 检查外部事件是否完成，result 为任务进入等待状态时记录的结果。done 为 true 时，err 为空表示任务执行成功，
+	// 否则表示任务执行失败，pollResult 为任务最终的执行结果。
+	Poll(kt run.ExecuteKit, params interface{}, result types.JsonField) (done bool, pollResult interface{}, err error)
+}
+
+// Waiting 实现了 WaitingAction 的 Action 执行返回 *Waiting 时，任务进入等待状态，Result 记录为任务的执行结果。
+type Waiting struct {
+	Result interface{}
+}
+
 // ParameterAction 如果任务运行需要依赖请求参数，需要通过该接口返回参数结构，会将任务实例中的参数内容解析到这个返回参数上。
 type ParameterAction interface {
 	// ParameterNew 返回新的参数结构。返回参数可以实现 Decoder 接口，自定义解码方式。
 	ParameterNew() (params interface{})
 }
+
+// TimeoutAction Action如果需要区别于全局配置的执行超时时间，实现该接口。创建任务时可以通过任务参数覆盖该默认超时时间。
+type TimeoutAction interface {
+	// TimeoutSec 返回任务默认执行超时时间，单位：秒，返回0表示使用全局配置的超时时间。
+	TimeoutSec() uint
+}
+
+// RateLimitAction Action如果需要调用云厂商接口，且需要按照账号限制调用速率，实现该接口。执行器在执行任务前，
+// 从集群共享的令牌桶中获取令牌，令牌不足时任务进入重试等待状态，等待期间不计入任务的失败次数。
+type RateLimitAction interface {
+	// RateLimitKey 根据任务参数返回限流维度，ok 为 false 表示该任务不需要限流。
+	RateLimitKey(params interface{}) (key RateLimitKey, ok bool)
+}
+
+// RateLimitKey 限流维度，同一个账号下相同云厂商接口分组的任务共享一个令牌桶。
+type RateLimitKey struct {
+	Vendor    enumor.Vendor
+	AccountID string
+	// APIGroup 云厂商接口分组，如 cvm
+	APIGroup string
+}
+
+// String return rate limit key string.
+func (k RateLimitKey) String() string {
+	return string(k.Vendor) + "/" + k.AccountID + "/" + k.APIGroup
+}
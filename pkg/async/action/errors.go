@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package action
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// PermanentError 不可重试的错误，Action 返回该错误时，即使任务开启了重试也会直接失败，如参数错误、资源不存在等。
+type PermanentError struct {
+	Err error
+}
+
+// Error return error message.
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap return wrapped error.
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// NewPermanentError 将错误标记为不可重试的错误。
+func NewPermanentError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &PermanentError{Err: err}
+}
+
+// RetryableError 可重试的错误，如云上接口限频。RetryAfter 不为0时，下次重试的等待时间不小于 RetryAfter。
+type RetryableError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+// Error return error message.
+func (e *RetryableError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap return wrapped error.
+func (e *RetryableError) Unwrap() error {
+	return e.Err
+}
+
+// NewRetryableError 将错误标记为可重试的错误，retryAfter 为建议的最小重试等待时间。
+func NewRetryableError(err error, retryAfter time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return &RetryableError{Err: err, RetryAfter: retryAfter}
+}
+
+// throttlingErrCodes 云上接口限频的错误码，返回这些错误时视为可重试的错误
+var throttlingErrCodes = []string{"RequestLimitExceeded", "Throttling", "TooManyRequests", "ThrottlingException"}
+
+// IsPermanentError 判断错误是否为不可重试的错误。
+func IsPermanentError(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// IsRetryableError 判断错误是否为明确可重试的错误，包括标记为可重试的错误和云上接口限频错误。
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var retryable *RetryableError
+	if errors.As(err, &retryable) {
+		return true
+	}
+
+	msg := err.Error()
+	for _, code := range throttlingErrCodes {
+		if strings.Contains(msg, code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RetryAfter 返回可重试的错误建议的最小重试等待时间，未设置时返回0。
+func RetryAfter(err error) time.Duration {
+	var retryable *RetryableError
+	if errors.As(err, &retryable) {
+		return retryable.RetryAfter
+	}
+
+	return 0
+}
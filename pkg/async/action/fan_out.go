@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package action
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
+)
+
+// FanOut 动态扇出定义，Action 执行成功返回 *FanOut 时，执行器会在运行中的任务流里动态创建子任务。
+// 子任务依赖当前任务执行，原本依赖当前任务的后续任务会等待全部子任务（设置了汇聚任务时为汇聚任务）执行完成后再执行，
+// 全部子任务执行结束后，子任务的执行结果会汇总为 FanOutResult 回写到当前任务的执行结果中。
+type FanOut struct {
+	// Tasks 子任务声明
+	Tasks []FanOutTask `json:"tasks" validate:"required,min=1,max=100,dive"`
+	// Join 汇聚任务声明，汇聚任务在全部子任务执行成功后执行，不设置则不创建汇聚任务
+	Join *FanOutTask `json:"join" validate:"omitempty"`
+}
+
+// Validate FanOut.
+func (f *FanOut) Validate() error {
+	if err := validator.Validate.Struct(f); err != nil {
+		return err
+	}
+
+	for i := range f.Tasks {
+		if err := f.Tasks[i].Validate(); err != nil {
+			return fmt.Errorf("tasks[%d] is invalid, err: %v", i, err)
+		}
+	}
+
+	if f.Join != nil {
+		if err := f.Join.Validate(); err != nil {
+			return fmt.Errorf("join is invalid, err: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// FanOutTask 动态扇出子任务声明
+type FanOutTask struct {
+	ActionName enumor.ActionName `json:"action_name" validate:"required"`
+	// Params 子任务执行参数，会被序列化后存储
+	Params interface{} `json:"params" validate:"omitempty"`
+	// Retry 子任务运行重试相关配置参数，如果不设置，默认不允许进行重试。
+	Retry *tableasync.Retry `json:"retry" validate:"omitempty"`
+	// TimeoutSec 子任务执行超时时间，单位：秒，不设置则使用Action定义的默认超时时间或全局配置的超时时间
+	TimeoutSec uint `json:"timeout_sec" validate:"omitempty"`
+	// AllowFailure 是否允许子任务执行失败
+	AllowFailure bool `json:"allow_failure" validate:"omitempty"`
+}
+
+// Validate FanOutTask.
+func (task *FanOutTask) Validate() error {
+	if err := validator.Validate.Struct(task); err != nil {
+		return err
+	}
+
+	if err := task.ActionName.Validate(); err != nil {
+		return err
+	}
+
+	if _, exist := GetAction(task.ActionName); !exist {
+		return fmt.Errorf("action: %s not found", task.ActionName)
+	}
+
+	if task.Retry != nil {
+		if err := task.Retry.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FanOutResult 动态扇出任务的执行结果，由全部子任务的执行结果汇总而来。
+type FanOutResult struct {
+	Tasks []FanOutTaskResult `json:"tasks"`
+}
+
+// FanOutTaskResult 动态扇出子任务的执行结果
+type FanOutTaskResult struct {
+	TaskID   string           `json:"task_id"`
+	ActionID ActIDType        `json:"action_id"`
+	State    enumor.TaskState `json:"state"`
+	Result   types.JsonField  `json:"result"`
+}
+
+// FanOutActionID 返回动态扇出第index个子任务的ActionID
+func FanOutActionID(parent ActIDType, index int) ActIDType {
+	return ActIDType(fmt.Sprintf("%s-%d", parent, index))
+}
+
+// FanOutJoinActionID 返回动态扇出汇聚任务的ActionID
+func FanOutJoinActionID(parent ActIDType) ActIDType {
+	return parent + "-join"
+}
@@ -37,15 +37,18 @@ func init() {
 type Manager struct {
 	actionMap  map[enumor.ActionName]Action
 	flowTplMap map[enumor.FlowName]FlowTemplate
-	rwLock     *sync.RWMutex
+	// declarativeTpl 从声明式文档加载的任务流模版名称
+	declarativeTpl map[enumor.FlowName]struct{}
+	rwLock         *sync.RWMutex
 }
 
 // NewManager 创建action管理器
 func NewManager() *Manager {
 	return &Manager{
-		actionMap:  make(map[enumor.ActionName]Action),
-		flowTplMap: make(map[enumor.FlowName]FlowTemplate),
-		rwLock:     &sync.RWMutex{},
+		actionMap:      make(map[enumor.ActionName]Action),
+		flowTplMap:     make(map[enumor.FlowName]FlowTemplate),
+		declarativeTpl: make(map[enumor.FlowName]struct{}),
+		rwLock:         &sync.RWMutex{},
 	}
 }
 
@@ -80,31 +83,77 @@ func (am *Manager) RegisterFlowTpl(templates ...FlowTemplate) error {
 	defer am.rwLock.Unlock()
 
 	for _, tpl := range templates {
-		// actionID 唯一性校验
-		taskMap := make(map[ActIDType]bool)
-		for _, one := range tpl.Tasks {
-			if taskMap[one.ActionID] {
-				return fmt.Errorf("actionID: %s repeat", one.ActionID)
-			}
+		if err := am.validateTplTasks(tpl); err != nil {
+			return err
+		}
+
+		am.flowTplMap[tpl.Name] = tpl
+	}
+
+	return nil
+}
+
+// validateTplTasks 校验任务流模版中任务的ActionID唯一、Action名称合法以及依赖的ActionID存在
+func (am *Manager) validateTplTasks(tpl FlowTemplate) error {
+	// actionID 唯一性校验
+	taskMap := make(map[ActIDType]bool)
+	for _, one := range tpl.Tasks {
+		if taskMap[one.ActionID] {
+			return fmt.Errorf("actionID: %s repeat", one.ActionID)
+		}
 
-			if err := one.ActionName.Validate(); err != nil {
-				return err
+		if err := one.ActionName.Validate(); err != nil {
+			return err
+		}
+
+		taskMap[one.ActionID] = true
+	}
+
+	// dependOn 依赖ActionID存在校验
+	for _, task := range tpl.Tasks {
+		for _, one := range task.DependOn {
+			if !taskMap[one] {
+				return fmt.Errorf("dependOn's actionID: %s not exist", one)
 			}
+		}
+	}
+
+	return nil
+}
+
+// ReplaceDeclarativeTpl 使用新加载的声明式任务流模版整体替换之前加载的声明式任务流模版，不在新模版中的声明式模版会被移除。
+// 声明式任务流模版不允许覆盖代码中注册的任务流模版，任意模版校验失败时不做任何替换。
+func (am *Manager) ReplaceDeclarativeTpl(templates []FlowTemplate) error {
+	am.rwLock.Lock()
+	defer am.rwLock.Unlock()
 
-			taskMap[one.ActionID] = true
+	names := make(map[enumor.FlowName]struct{}, len(templates))
+	for _, tpl := range templates {
+		if _, exist := names[tpl.Name]; exist {
+			return fmt.Errorf("declarative flow template: %s repeat", tpl.Name)
 		}
 
-		// dependOn 依赖ActionID存在校验
-		for _, task := range tpl.Tasks {
-			for _, one := range task.DependOn {
-				if !taskMap[one] {
-					return fmt.Errorf("dependOn's actionID: %s not exist", one)
-				}
+		if _, exist := am.flowTplMap[tpl.Name]; exist {
+			if _, declarative := am.declarativeTpl[tpl.Name]; !declarative {
+				return fmt.Errorf("declarative flow template: %s conflicts with registered template", tpl.Name)
 			}
 		}
 
+		if err := am.validateTplTasks(tpl); err != nil {
+			return fmt.Errorf("declarative flow template: %s is invalid, err: %v", tpl.Name, err)
+		}
+
+		names[tpl.Name] = struct{}{}
+	}
+
+	for name := range am.declarativeTpl {
+		delete(am.flowTplMap, name)
+	}
+
+	for _, tpl := range templates {
 		am.flowTplMap[tpl.Name] = tpl
 	}
+	am.declarativeTpl = names
 
 	return nil
 }
@@ -130,6 +179,26 @@ func GetAction(name enumor.ActionName) (Action, bool) {
 	return manager.GetAction(name)
 }
 
+// GetTimeoutSec 获取任务执行超时时间，优先使用创建任务时指定的超时时间，其次使用Action定义的默认超时时间，
+// 都未设置返回0，表示使用全局配置的超时时间。
+func GetTimeoutSec(name enumor.ActionName, timeoutSec uint) uint {
+	if timeoutSec != 0 {
+		return timeoutSec
+	}
+
+	act, exist := GetAction(name)
+	if !exist {
+		return 0
+	}
+
+	timeoutAct, ok := act.(TimeoutAction)
+	if !ok {
+		return 0
+	}
+
+	return timeoutAct.TimeoutSec()
+}
+
 // RegisterTpl register flow template.
 func RegisterTpl(templates ...FlowTemplate) {
 	if err := manager.RegisterFlowTpl(templates...); err != nil {
@@ -141,3 +210,12 @@ func RegisterTpl(templates ...FlowTemplate) {
 func GetTpl(name enumor.FlowName) (FlowTemplate, bool) {
 	return manager.GetFlowTpl(name)
 }
+
+// ValidateTplName 校验任务流模版名称，已注册的任务流模版（包括声明式任务流模版）或者内置的任务流名称为合法名称。
+func ValidateTplName(name enumor.FlowName) error {
+	if _, exist := GetTpl(name); exist {
+		return nil
+	}
+
+	return name.Validate()
+}
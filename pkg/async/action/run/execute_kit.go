@@ -25,6 +25,12 @@ import "hcm/pkg/kit"
 type ExecuteKit interface {
 	Kit() *kit.Kit
 	ShareData() ShareDataOperator
+	// FlowID 返回当前任务所属的任务流ID
+	FlowID() string
+	// TaskID 返回当前执行的任务ID
+	TaskID() string
+	// Logger 返回当前任务的日志记录器，记录的日志会随任务保存，可以通过任务日志接口查看
+	Logger() *TaskLogger
 }
 
 // ShareDataOperator used to operate share data
@@ -35,10 +41,13 @@ type ShareDataOperator interface {
 }
 
 // NewExecuteContext new execute context for task exec.
-func NewExecuteContext(kt *kit.Kit, shareData ShareDataOperator) ExecuteKit {
+func NewExecuteContext(kt *kit.Kit, shareData ShareDataOperator, flowID, taskID string) ExecuteKit {
 	return &DefExecuteContext{
 		kit:       kt,
 		shareData: shareData,
+		flowID:    flowID,
+		taskID:    taskID,
+		logger:    NewTaskLogger(kt, taskID),
 	}
 }
 
@@ -46,6 +55,9 @@ func NewExecuteContext(kt *kit.Kit, shareData ShareDataOperator) ExecuteKit {
 type DefExecuteContext struct {
 	kit       *kit.Kit
 	shareData ShareDataOperator
+	flowID    string
+	taskID    string
+	logger    *TaskLogger
 }
 
 // Kit return kit.
@@ -57,3 +69,18 @@ func (ctx *DefExecuteContext) Kit() *kit.Kit {
 func (ctx *DefExecuteContext) ShareData() ShareDataOperator {
 	return ctx.shareData
 }
+
+// FlowID return flow id.
+func (ctx *DefExecuteContext) FlowID() string {
+	return ctx.flowID
+}
+
+// TaskID return task id.
+func (ctx *DefExecuteContext) TaskID() string {
+	return ctx.taskID
+}
+
+// Logger return task logger.
+func (ctx *DefExecuteContext) Logger() *TaskLogger {
+	return ctx.logger
+}
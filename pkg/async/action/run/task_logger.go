@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package run
+
+import (
+	"fmt"
+	"sync"
+
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/times"
+)
+
+// NewTaskLogger new task logger.
+func NewTaskLogger(kt *kit.Kit, taskID string) *TaskLogger {
+	return &TaskLogger{
+		kt:      kt,
+		taskID:  taskID,
+		entries: make(tableasync.TaskLogs, 0),
+	}
+}
+
+// TaskLogger 任务日志记录器，日志会同时输出到当前节点的服务日志中，并在任务状态更新时随任务一起保存，
+// 每个任务保存的日志条数和单条日志长度有上限，超过后丢弃最早的日志。
+type TaskLogger struct {
+	kt     *kit.Kit
+	taskID string
+
+	lock    sync.Mutex
+	entries tableasync.TaskLogs
+}
+
+// Infof record info level task log.
+func (l *TaskLogger) Infof(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logs.InfoDepthf(1, "task: %s, %s, rid: %s", l.taskID, msg, l.rid())
+	l.record(enumor.TaskLogInfo, msg)
+}
+
+// Warnf record warn level task log.
+func (l *TaskLogger) Warnf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logs.WarnDepthf(1, "task: %s, %s, rid: %s", l.taskID, msg, l.rid())
+	l.record(enumor.TaskLogWarn, msg)
+}
+
+// Errorf record error level task log.
+func (l *TaskLogger) Errorf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logs.ErrorDepthf(1, "task: %s, %s, rid: %s", l.taskID, msg, l.rid())
+	l.record(enumor.TaskLogError, msg)
+}
+
+// Drain 取出当前还未保存的任务日志，取出后记录器中不再保留这些日志。
+func (l *TaskLogger) Drain() tableasync.TaskLogs {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	entries := l.entries
+	l.entries = make(tableasync.TaskLogs, 0)
+	return entries
+}
+
+func (l *TaskLogger) record(level enumor.TaskLogLevel, msg string) {
+	entry := tableasync.TaskLogEntry{
+		Level:   level,
+		Message: msg,
+		Time:    times.ConvStdTimeFormat(times.ConvStdTimeNow()),
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// 未保存的日志同样限制条数，避免长时间运行的任务占用过多内存
+	l.entries = l.entries.Append(entry)
+}
+
+func (l *TaskLogger) rid() string {
+	if l.kt == nil {
+		return ""
+	}
+
+	return l.kt.Rid
+}
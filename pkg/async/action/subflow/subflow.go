@@ -0,0 +1,242 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package subflow 子任务流Action，将已有的任务流模版作为任务流中的一个任务执行。
+package subflow
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/async/producer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/json"
+)
+
+var _ action.Action = new(SubFlowAction)
+var _ action.ParameterAction = new(SubFlowAction)
+var _ action.WaitingAction = new(SubFlowAction)
+
+// SubFlowAction 子任务流Action，执行时通过生产者创建子任务流后进入等待状态，不占用执行器，
+// 子任务流执行结束后，子任务流的最终状态和任务执行结果作为当前任务的执行结果。
+type SubFlowAction struct {
+	producer producer.Producer
+	backend  backend.Backend
+}
+
+// NewSubFlowAction new sub flow action.
+func NewSubFlowAction(pdr producer.Producer, bd backend.Backend) SubFlowAction {
+	return SubFlowAction{
+		producer: pdr,
+		backend:  bd,
+	}
+}
+
+// Params 子任务流参数
+type Params struct {
+	// FlowName 子任务流使用的任务流模版名称
+	FlowName enumor.FlowName `json:"flow_name" validate:"required"`
+	// Memo 子任务流备注
+	Memo string `json:"memo" validate:"omitempty"`
+	// Tasks 子任务流中任务私有化参数设置
+	Tasks []producer.TemplateFlowTask `json:"tasks" validate:"omitempty"`
+	// Compensate 子任务流执行失败后，是否对子任务流中执行成功的任务进行补偿
+	Compensate bool `json:"compensate" validate:"omitempty"`
+}
+
+// Validate Params.
+func (p *Params) Validate() error {
+	return validator.Validate.Struct(p)
+}
+
+// Result 子任务流执行结果
+type Result struct {
+	FlowID string           `json:"flow_id"`
+	State  enumor.FlowState `json:"state,omitempty"`
+	Tasks  []TaskResult     `json:"tasks,omitempty"`
+}
+
+// TaskResult 子任务流中任务的执行结果
+type TaskResult struct {
+	ID         string            `json:"id"`
+	ActionID   action.ActIDType  `json:"action_id"`
+	ActionName enumor.ActionName `json:"action_name"`
+	State      enumor.TaskState  `json:"state"`
+	Result     types.JsonField   `json:"result"`
+}
+
+// ParameterNew return sub flow params.
+func (act SubFlowAction) ParameterNew() (params interface{}) {
+	return new(Params)
+}
+
+// Name return action name
+func (act SubFlowAction) Name() enumor.ActionName {
+	return enumor.ActionSubFlow
+}
+
+// Run 创建子任务流，并进入等待状态等待子任务流执行结束。
+func (act SubFlowAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	p, ok := params.(*Params)
+	if !ok {
+		return nil, errors.New("params type mismatch")
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 任务执行中断后重新执行时，复用已经创建且没有执行失败的子任务流，避免重复创建
+	flow, err := act.getSubFlow(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	if flow != nil && !isFailedState(flow.State) {
+		return &action.Waiting{Result: &Result{FlowID: flow.ID}}, nil
+	}
+
+	opt := &producer.AddTemplateFlowOption{
+		Name:         p.FlowName,
+		Memo:         p.Memo,
+		Tasks:        p.Tasks,
+		Compensate:   p.Compensate,
+		ParentFlowID: kt.FlowID(),
+		ParentTaskID: kt.TaskID(),
+	}
+	id, err := act.producer.AddTemplateFlow(kt.Kit(), opt)
+	if err != nil {
+		logs.Errorf("add sub flow failed, err: %v, flow: %s, rid: %s", err, p.FlowName, kt.Kit().Rid)
+		return nil, err
+	}
+
+	return &action.Waiting{Result: &Result{FlowID: id}}, nil
+}
+
+// Poll 检查子任务流是否执行结束，执行结束后返回子任务流的最终状态和任务执行结果。
+func (act SubFlowAction) Poll(kt run.ExecuteKit, _ interface{}, result types.JsonField) (bool, interface{}, error) {
+	res := new(Result)
+	if err := json.UnmarshalFromString(string(result), res); err != nil {
+		return false, nil, fmt.Errorf("unmarshal sub flow result failed, err: %v", err)
+	}
+
+	flows, err := act.backend.ListFlow(kt.Kit(), &backend.ListInput{
+		Filter: tools.EqualExpression("id", res.FlowID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	if len(flows) == 0 {
+		return true, res, fmt.Errorf("sub flow: %s not found", res.FlowID)
+	}
+
+	flow := flows[0]
+	if flow.State != enumor.FlowSuccess && !isFailedState(flow.State) {
+		return false, nil, nil
+	}
+
+	res.State = flow.State
+	if res.Tasks, err = act.listSubFlowTaskResult(kt, flow.ID); err != nil {
+		return false, nil, err
+	}
+
+	if flow.State != enumor.FlowSuccess {
+		return true, res, fmt.Errorf("sub flow: %s finished with state: %s", flow.ID, flow.State)
+	}
+
+	return true, res, nil
+}
+
+// getSubFlow 获取当前任务最近一次创建的子任务流，不存在返回nil
+func (act SubFlowAction) getSubFlow(kt run.ExecuteKit) (*model.Flow, error) {
+	flows, err := act.backend.ListFlow(kt.Kit(), &backend.ListInput{
+		Filter: tools.EqualExpression("parent_task_id", kt.TaskID()),
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: 1,
+			Sort:  "created_at",
+			Order: core.Descending,
+		},
+	})
+	if err != nil {
+		logs.Errorf("list sub flow failed, err: %v, task: %s, rid: %s", err, kt.TaskID(), kt.Kit().Rid)
+		return nil, err
+	}
+
+	if len(flows) == 0 {
+		return nil, nil
+	}
+
+	return &flows[0], nil
+}
+
+func (act SubFlowAction) listSubFlowTaskResult(kt run.ExecuteKit, flowID string) ([]TaskResult, error) {
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("flow_id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	results := make([]TaskResult, 0)
+	for {
+		tasks, err := act.backend.ListTask(kt.Kit(), input)
+		if err != nil {
+			logs.Errorf("list sub flow task failed, err: %v, flow: %s, rid: %s", err, flowID, kt.Kit().Rid)
+			return nil, err
+		}
+
+		for _, one := range tasks {
+			results = append(results, TaskResult{
+				ID:         one.ID,
+				ActionID:   one.ActionID,
+				ActionName: one.ActionName,
+				State:      one.State,
+				Result:     one.Result,
+			})
+		}
+
+		if len(tasks) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		input.Page.Start += uint32(input.Page.Limit)
+	}
+
+	return results, nil
+}
+
+// isFailedState 任务流是否处于执行失败的最终状态，开启补偿的任务流需要等待补偿结束
+func isFailedState(state enumor.FlowState) bool {
+	switch state {
+	case enumor.FlowFailed, enumor.FlowCancel, enumor.FlowCompensated, enumor.FlowCompensateFailed:
+		return true
+	default:
+		return false
+	}
+}
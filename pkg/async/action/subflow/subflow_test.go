@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package subflow
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	_ "hcm/pkg/async/action/test"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/async/producer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/json"
+)
+
+func TestSubFlowAction(t *testing.T) {
+	kt := kit.New()
+	bd := backend.NewMemory()
+	pdr, err := producer.NewProducer(bd, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("new producer failed, err: %v", err)
+	}
+
+	act := NewSubFlowAction(pdr, bd)
+	ekt := run.NewExecuteContext(kt, nil, "parent_flow", "parent_task")
+	params := &Params{
+		FlowName: enumor.FlowNormalTest,
+		Tasks: []producer.TemplateFlowTask{
+			{ActionID: "1", Params: types.JsonField(`{"name":"test","age":1}`)},
+		},
+	}
+
+	result, err := act.Run(ekt, params)
+	if err != nil {
+		t.Fatalf("run sub flow action failed, err: %v", err)
+	}
+
+	waiting, ok := result.(*action.Waiting)
+	if !ok {
+		t.Fatalf("sub flow action should return waiting, but got %T", result)
+	}
+	flowID := waiting.Result.(*Result).FlowID
+
+	flow, err := act.getSubFlow(ekt)
+	if err != nil || flow == nil || flow.ID != flowID || flow.ParentFlowID != "parent_flow" {
+		t.Fatalf("sub flow should be created with parent, flow: %+v, err: %v", flow, err)
+	}
+
+	field, err := types.NewJsonField(waiting.Result)
+	if err != nil {
+		t.Fatalf("marshal waiting result failed, err: %v", err)
+	}
+
+	// 重复执行时复用已经创建的子任务流
+	if result, err = act.Run(ekt, params); err != nil || result.(*action.Waiting).Result.(*Result).FlowID != flowID {
+		t.Errorf("rerun sub flow action should reuse sub flow: %s, err: %v", flowID, err)
+	}
+
+	done, _, err := act.Poll(ekt, params, field)
+	if err != nil || done {
+		t.Fatalf("sub flow should not be done, done: %v, err: %v", done, err)
+	}
+
+	if err = bd.BatchUpdateFlow(kt, []model.Flow{{ID: flowID, State: enumor.FlowFailed}}); err != nil {
+		t.Fatalf("update flow failed, err: %v", err)
+	}
+
+	done, pollResult, err := act.Poll(ekt, params, field)
+	if !done || err == nil {
+		t.Fatalf("sub flow should be done with error, done: %v, err: %v", done, err)
+	}
+
+	res := new(Result)
+	if err = json.UnmarshalFromString(string(mustMarshal(t, pollResult)), res); err != nil {
+		t.Fatalf("unmarshal poll result failed, err: %v", err)
+	}
+
+	if res.FlowID != flowID || res.State != enumor.FlowFailed || len(res.Tasks) != 4 {
+		t.Errorf("unexpected sub flow result: %+v", res)
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) types.JsonField {
+	field, err := types.NewJsonField(v)
+	if err != nil {
+		t.Fatalf("marshal failed, err: %v", err)
+	}
+	return field
+}
@@ -20,6 +20,8 @@
 package action
 
 import (
+	"fmt"
+
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	tableasync "hcm/pkg/dal/table/async"
@@ -30,6 +32,10 @@ type FlowTemplate struct {
 	Name      enumor.FlowName       `json:"name" validate:"required"`
 	ShareData *tableasync.ShareData `json:"share_data"`
 	Tasks     []TaskTemplate        `json:"tasks" validate:"required,min=1"`
+	// Compensate 任务流执行失败后，是否按照任务依赖的逆序对执行成功的任务进行补偿
+	Compensate bool `json:"compensate"`
+	// NodeSelector 任务流要求的节点标签，任务流只会派发到包含全部标签的节点执行，如只有部分节点可以访问云厂商接口或代理
+	NodeSelector map[string]string `json:"node_selector"`
 }
 
 // Validate FlowTemplate.
@@ -44,6 +50,12 @@ func (tpl *FlowTemplate) Validate() error {
 		}
 	}
 
+	for key := range tpl.NodeSelector {
+		if len(key) == 0 {
+			return fmt.Errorf("flow template: %s node selector key can not be empty", tpl.Name)
+		}
+	}
+
 	return nil
 }
 
@@ -61,6 +73,16 @@ type TaskTemplate struct {
 
 	// Retry 任务运行重试相关配置参数，如果不设置，默认不允许进行重试。
 	Retry *tableasync.Retry `json:"retry" validate:"omitempty"`
+
+	// AllowFailure 是否允许任务执行失败，允许失败的任务执行失败后，不影响后续任务执行和任务流状态
+	AllowFailure bool `json:"allow_failure" validate:"omitempty"`
+	// TriggerRule 任务触发规则（all_success、on_failure、always），不设置默认为all_success
+	TriggerRule enumor.TaskTriggerRule `json:"trigger_rule" validate:"omitempty"`
+	// RunCondition 任务执行条件，根据任务流共享数据判断任务是否执行，不满足条件时任务会被跳过
+	RunCondition *tableasync.RunCondition `json:"run_condition" validate:"omitempty"`
+	// ParamsTemplate 声明式任务流模版中定义的任务参数模版，参数中的字符串值支持使用 {{ .key }} 引用任务流共享数据，
+	// 创建任务流时未指定任务参数则使用该模版，任务执行时使用当前的任务流共享数据渲染
+	ParamsTemplate string `json:"params_template" validate:"omitempty"`
 }
 
 // Validate TaskTemplate.
@@ -83,6 +105,16 @@ func (tpl *TaskTemplate) Validate() error {
 		}
 	}
 
+	if err := tpl.TriggerRule.Validate(); err != nil {
+		return err
+	}
+
+	if tpl.RunCondition != nil {
+		if err := tpl.RunCondition.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,414 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package action
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TplDocument 声明式任务流模版文档，支持 YAML 或 JSON 格式。
+type TplDocument struct {
+	Name      enumor.FlowName       `json:"name"`
+	ShareData *tableasync.ShareData `json:"share_data"`
+	Tasks     []TaskDocument        `json:"tasks"`
+	// Compensate 任务流执行失败后，是否按照任务依赖的逆序对执行成功的任务进行补偿
+	Compensate bool `json:"compensate"`
+	// NodeSelector 任务流要求的节点标签
+	NodeSelector map[string]string `json:"node_selector"`
+}
+
+// TaskDocument 声明式任务流模版文档中的任务定义。
+type TaskDocument struct {
+	ActionID     ActIDType                `json:"action_id"`
+	ActionName   enumor.ActionName        `json:"action_name"`
+	DependOn     []ActIDType              `json:"depend_on"`
+	Retry        *tableasync.Retry        `json:"retry"`
+	AllowFailure bool                     `json:"allow_failure"`
+	TriggerRule  enumor.TaskTriggerRule   `json:"trigger_rule"`
+	RunCondition *tableasync.RunCondition `json:"run_condition"`
+	// Params 任务参数模版，参数中的字符串值支持使用 {{ .key }} 引用任务流共享数据
+	Params json.RawMessage `json:"params"`
+}
+
+// ParseTplDocument 解析声明式任务流模版文档，并根据已注册的Action校验任务定义。
+func ParseTplDocument(data []byte) (FlowTemplate, error) {
+	// JSON 是 YAML 的子集，统一按照 YAML 解析后转为 JSON，复用 JSON 标签定义的字段名
+	raw := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return FlowTemplate{}, fmt.Errorf("unmarshal template document failed, err: %v", err)
+	}
+
+	js, err := json.Marshal(raw)
+	if err != nil {
+		return FlowTemplate{}, fmt.Errorf("marshal template document failed, err: %v", err)
+	}
+
+	doc := new(TplDocument)
+	decoder := json.NewDecoder(bytes.NewReader(js))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(doc); err != nil {
+		return FlowTemplate{}, fmt.Errorf("decode template document failed, err: %v", err)
+	}
+
+	tpl := FlowTemplate{
+		Name:         doc.Name,
+		ShareData:    doc.ShareData,
+		Tasks:        make([]TaskTemplate, 0, len(doc.Tasks)),
+		Compensate:   doc.Compensate,
+		NodeSelector: doc.NodeSelector,
+	}
+	for _, one := range doc.Tasks {
+		task, err := one.toTaskTemplate()
+		if err != nil {
+			return FlowTemplate{}, fmt.Errorf("template: %s action: %s is invalid, err: %v", doc.Name, one.ActionID,
+				err)
+		}
+		tpl.Tasks = append(tpl.Tasks, task)
+	}
+
+	if err = tpl.Validate(); err != nil {
+		return FlowTemplate{}, fmt.Errorf("template: %s is invalid, err: %v", doc.Name, err)
+	}
+
+	if err = validateTplAcyclic(tpl); err != nil {
+		return FlowTemplate{}, fmt.Errorf("template: %s is invalid, err: %v", doc.Name, err)
+	}
+
+	return tpl, nil
+}
+
+// toTaskTemplate 根据已注册的Action校验任务定义，并转为任务模版
+func (doc TaskDocument) toTaskTemplate() (TaskTemplate, error) {
+	act, exist := GetAction(doc.ActionName)
+	if !exist {
+		return TaskTemplate{}, fmt.Errorf("action: %s not registered", doc.ActionName)
+	}
+
+	task := TaskTemplate{
+		ActionID:     doc.ActionID,
+		ActionName:   doc.ActionName,
+		DependOn:     doc.DependOn,
+		Retry:        doc.Retry,
+		AllowFailure: doc.AllowFailure,
+		TriggerRule:  doc.TriggerRule,
+		RunCondition: doc.RunCondition,
+	}
+
+	if task.Retry != nil && task.Retry.IsEnable() {
+		if _, ok := act.(RollbackAction); !ok {
+			return TaskTemplate{}, fmt.Errorf("action: %s can retry, but not impl RollbackAction", doc.ActionName)
+		}
+	}
+
+	paramAct, ok := act.(ParameterAction)
+	if ok && paramAct.ParameterNew() != nil {
+		task.Params = &Params{Type: paramAct.ParameterNew()}
+	}
+
+	if len(doc.Params) == 0 || string(doc.Params) == "null" {
+		return task, nil
+	}
+
+	if task.Params == nil {
+		return TaskTemplate{}, fmt.Errorf("action: %s has params, but not impl ParameterAction", doc.ActionName)
+	}
+
+	params := make(map[string]interface{})
+	if err := json.Unmarshal(doc.Params, &params); err != nil {
+		return TaskTemplate{}, fmt.Errorf("params should be an object, err: %v", err)
+	}
+
+	// 校验参数模版语法，并使用空的共享数据渲染，检查参数结构能被Action解析
+	rendered, err := renderParamValue(params, map[string]string{}, true)
+	if err != nil {
+		return TaskTemplate{}, err
+	}
+
+	js, err := json.Marshal(rendered)
+	if err != nil {
+		return TaskTemplate{}, err
+	}
+
+	if err = Decode(types.JsonField(js), paramAct.ParameterNew()); err != nil {
+		return TaskTemplate{}, fmt.Errorf("action: %s can not decode params, err: %v", doc.ActionName, err)
+	}
+
+	task.ParamsTemplate = string(doc.Params)
+	return task, nil
+}
+
+// validateTplAcyclic 校验任务流模版中的任务依赖不存在环
+func validateTplAcyclic(tpl FlowTemplate) error {
+	inDegree := make(map[ActIDType]int, len(tpl.Tasks))
+	children := make(map[ActIDType][]ActIDType, len(tpl.Tasks))
+	for _, one := range tpl.Tasks {
+		inDegree[one.ActionID] += len(one.DependOn)
+		for _, dep := range one.DependOn {
+			children[dep] = append(children[dep], one.ActionID)
+		}
+	}
+
+	queue := make([]ActIDType, 0, len(tpl.Tasks))
+	for _, one := range tpl.Tasks {
+		if inDegree[one.ActionID] == 0 {
+			queue = append(queue, one.ActionID)
+		}
+	}
+
+	visited := 0
+	for len(queue) != 0 {
+		id := queue[0]
+		queue = queue[1:]
+		visited++
+
+		for _, child := range children[id] {
+			inDegree[child]--
+			if inDegree[child] == 0 {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	if visited != len(tpl.Tasks) {
+		return errors.New("task depend_on has cycle")
+	}
+
+	return nil
+}
+
+// RenderParams 使用任务流共享数据渲染声明式任务流模版中定义的任务参数模版。参数模版在创建任务时已记录到任务中，
+// 调用方只能对创建任务时标记为参数模版的任务参数进行渲染，不能渲染用户传入的参数。
+func RenderParams(params types.JsonField, shareData map[string]string) (types.JsonField, error) {
+	if len(params) == 0 {
+		return params, nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(params), &value); err != nil {
+		return "", fmt.Errorf("unmarshal params failed, err: %v", err)
+	}
+
+	rendered, err := renderParamValue(value, shareData, false)
+	if err != nil {
+		return "", err
+	}
+
+	js, err := json.Marshal(rendered)
+	if err != nil {
+		return "", err
+	}
+
+	return types.JsonField(js), nil
+}
+
+// renderParamValue 递归渲染参数中包含模版表达式的字符串值，lenient 为 true 时引用不存在的共享数据渲染为空字符串
+func renderParamValue(value interface{}, shareData map[string]string, lenient bool) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+
+		t := template.New("params")
+		if !lenient {
+			t = t.Option("missingkey=error")
+		}
+		t, err := t.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("parse params template: %s failed, err: %v", v, err)
+		}
+
+		buf := new(bytes.Buffer)
+		if err = t.Execute(buf, shareData); err != nil {
+			return nil, fmt.Errorf("render params template: %s failed, err: %v", v, err)
+		}
+
+		// lenient 渲染时缺失的数据会输出 <no value>，统一替换为空
+		return strings.ReplaceAll(buf.String(), "<no value>", ""), nil
+
+	case map[string]interface{}:
+		for key, one := range v {
+			rendered, err := renderParamValue(one, shareData, lenient)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = rendered
+		}
+		return v, nil
+
+	case []interface{}:
+		for index, one := range v {
+			rendered, err := renderParamValue(one, shareData, lenient)
+			if err != nil {
+				return nil, err
+			}
+			v[index] = rendered
+		}
+		return v, nil
+
+	default:
+		return v, nil
+	}
+}
+
+// TplLoaderOption 声明式任务流模版加载器配置
+type TplLoaderOption struct {
+	// Dir 声明式任务流模版文档所在目录，加载目录下的 .yaml、.yml、.json 文件
+	Dir string `json:"dir" validate:"required"`
+	// ReloadIntervalSec 重新加载目录的周期
+	ReloadIntervalSec uint `json:"reload_interval_sec" validate:"required"`
+}
+
+// NewTplLoader new declarative flow template loader.
+func NewTplLoader(opt *TplLoaderOption) *TplLoader {
+	return &TplLoader{
+		dir:            opt.Dir,
+		reloadInterval: time.Duration(opt.ReloadIntervalSec) * time.Second,
+		closeCh:        make(chan struct{}),
+		wg:             new(sync.WaitGroup),
+	}
+}
+
+// TplLoader 声明式任务流模版加载器，从目录加载 YAML/JSON 格式的任务流模版，并周期性重新加载，新增或修改模版不需要重新
+// 构建和部署服务。目录中任意文档校验失败时，保留上一次成功加载的模版。
+type TplLoader struct {
+	dir            string
+	reloadInterval time.Duration
+	// digest 上一次成功加载的目录内容摘要，目录内容未变化时不重新加载
+	digest string
+
+	wg      *sync.WaitGroup
+	closeCh chan struct{}
+}
+
+// Load 加载目录下全部声明式任务流模版，并整体替换之前加载的声明式任务流模版。
+func (l *TplLoader) Load() error {
+	files, err := l.listFiles()
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	contents := make(map[string][]byte, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read template file: %s failed, err: %v", file, err)
+		}
+		contents[file] = data
+		hash.Write([]byte(file))
+		hash.Write(data)
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if digest == l.digest {
+		return nil
+	}
+
+	templates := make([]FlowTemplate, 0, len(files))
+	names := make([]enumor.FlowName, 0, len(files))
+	for _, file := range files {
+		tpl, err := ParseTplDocument(contents[file])
+		if err != nil {
+			return fmt.Errorf("parse template file: %s failed, err: %v", file, err)
+		}
+		templates = append(templates, tpl)
+		names = append(names, tpl.Name)
+	}
+
+	if err = manager.ReplaceDeclarativeTpl(templates); err != nil {
+		return err
+	}
+	l.digest = digest
+
+	logs.Infof("load declarative flow templates success, dir: %s, templates: %v", l.dir, names)
+
+	return nil
+}
+
+func (l *TplLoader) listFiles() ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read template dir: %s failed, err: %v", l.dir, err)
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, one := range entries {
+		if one.IsDir() {
+			continue
+		}
+
+		switch strings.ToLower(filepath.Ext(one.Name())) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, filepath.Join(l.dir, one.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// Start 周期性重新加载声明式任务流模版。
+func (l *TplLoader) Start() {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+
+		ticker := time.NewTicker(l.reloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-l.closeCh:
+				return
+			case <-ticker.C:
+			}
+
+			if err := l.Load(); err != nil {
+				logs.Errorf("reload declarative flow templates failed, keep last loaded templates, err: %v", err)
+			}
+		}
+	}()
+}
+
+// Close template loader.
+func (l *TplLoader) Close() {
+	close(l.closeCh)
+	l.wg.Wait()
+}
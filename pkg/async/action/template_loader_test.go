@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package action
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+)
+
+type tplLoaderTestParams struct {
+	VpcID string `json:"vpc_id"`
+	Count int    `json:"count"`
+}
+
+type tplLoaderTestAction struct{}
+
+func (act tplLoaderTestAction) Name() enumor.ActionName { return enumor.ActionProduceTest }
+
+func (act tplLoaderTestAction) ParameterNew() interface{} { return new(tplLoaderTestParams) }
+
+func (act tplLoaderTestAction) Run(run.ExecuteKit, interface{}) (interface{}, error) { return nil, nil }
+
+const tplLoaderTestDoc = `
+name: yaml_test_flow
+share_data:
+  vendor: tcloud
+node_selector:
+  zone: proxy
+tasks:
+  - action_id: "1"
+    action_name: produce
+    params:
+      vpc_id: "{{ .vpc_id }}"
+      count: 2
+  - action_id: "2"
+    action_name: produce
+    depend_on: ["1"]
+`
+
+func TestTplLoader(t *testing.T) {
+	RegisterAction(tplLoaderTestAction{})
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "yaml_test_flow.yaml")
+	if err := os.WriteFile(file, []byte(tplLoaderTestDoc), 0644); err != nil {
+		t.Fatalf("write template file failed, err: %v", err)
+	}
+
+	loader := NewTplLoader(&TplLoaderOption{Dir: dir, ReloadIntervalSec: 1})
+	if err := loader.Load(); err != nil {
+		t.Fatalf("load templates failed, err: %v", err)
+	}
+
+	tpl, exist := GetTpl("yaml_test_flow")
+	if !exist {
+		t.Fatalf("declarative template should be registered")
+	}
+
+	if len(tpl.Tasks) != 2 || tpl.Tasks[0].Params == nil || tpl.NodeSelector["zone"] != "proxy" {
+		t.Errorf("declarative template is not parsed as expected, tpl: %+v", tpl)
+	}
+
+	if err := ValidateTplName("yaml_test_flow"); err != nil {
+		t.Errorf("declarative template name should be valid, err: %v", err)
+	}
+
+	params, err := RenderParams(types.JsonField(tpl.Tasks[0].ParamsTemplate),
+		map[string]string{"vpc_id": "vpc-1"})
+	if err != nil {
+		t.Fatalf("render params failed, err: %v", err)
+	}
+
+	decoded := new(tplLoaderTestParams)
+	if err = Decode(params, decoded); err != nil {
+		t.Fatalf("decode params failed, err: %v", err)
+	}
+
+	if decoded.VpcID != "vpc-1" || decoded.Count != 2 {
+		t.Errorf("rendered params is not expected, params: %s", params)
+	}
+
+	if _, err = RenderParams(types.JsonField(tpl.Tasks[0].ParamsTemplate),
+		map[string]string{}); err == nil {
+		t.Errorf("render params with missing share data should return error")
+	}
+
+	// 依赖存在环的模版校验失败，保留上一次加载成功的模版
+	cycle := `
+name: yaml_test_flow
+tasks:
+  - {action_id: "1", action_name: produce, depend_on: ["2"]}
+  - {action_id: "2", action_name: produce, depend_on: ["1"]}
+`
+	if err = os.WriteFile(file, []byte(cycle), 0644); err != nil {
+		t.Fatalf("write template file failed, err: %v", err)
+	}
+
+	if err = loader.Load(); err == nil {
+		t.Errorf("load template with cycle dependency should return error")
+	}
+
+	if tpl, _ = GetTpl("yaml_test_flow"); len(tpl.Tasks) != 2 || len(tpl.Tasks[1].DependOn) != 1 ||
+		tpl.Tasks[1].DependOn[0] != "1" {
+		t.Errorf("last loaded template should be kept, tpl: %+v", tpl)
+	}
+
+	// 删除模版文件后重新加载，模版被移除
+	if err = os.Remove(file); err != nil {
+		t.Fatalf("remove template file failed, err: %v", err)
+	}
+
+	if err = loader.Load(); err != nil {
+		t.Fatalf("reload templates failed, err: %v", err)
+	}
+
+	if _, exist = GetTpl("yaml_test_flow"); exist {
+		t.Errorf("removed declarative template should be unregistered")
+	}
+}
+
+func TestParseTplDocument(t *testing.T) {
+	RegisterAction(tplLoaderTestAction{})
+
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"unknown field", `{"name": "yaml_test_flow", "tasks": [{"action_id": "1", "action_name": "produce", ` +
+			`"unknown": 1}]}`},
+		{"action not registered", `{"name": "yaml_test_flow", "tasks": [{"action_id": "1", ` +
+			`"action_name": "create_cvm"}]}`},
+		{"params can not decode", `{"name": "yaml_test_flow", "tasks": [{"action_id": "1", ` +
+			`"action_name": "produce", "params": {"count": "{{ .count }}"}}]}`},
+		{"invalid template", `{"name": "yaml_test_flow", "tasks": [{"action_id": "1", ` +
+			`"action_name": "produce", "params": {"vpc_id": "{{ .vpc_id "}}]}`},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseTplDocument([]byte(tt.doc)); err == nil {
+			t.Errorf("case %s: parse template document should return error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package waitsignal 等待信号Action，任务流执行到该任务时暂停，直到收到人工审批通过或拒绝的信号。
+package waitsignal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/tools/json"
+)
+
+// DefaultTimeoutSec 未设置等待超时时间时，默认等待一天
+const DefaultTimeoutSec = 24 * 60 * 60
+
+var _ action.Action = new(WaitSignalAction)
+var _ action.ParameterAction = new(WaitSignalAction)
+var _ action.WaitingAction = new(WaitSignalAction)
+
+// WaitSignalAction 等待信号Action，执行时进入等待状态，不占用执行器。收到通过信号后任务执行成功，
+// 收到拒绝信号或者等待超时后任务执行失败。
+type WaitSignalAction struct{}
+
+// Params 等待信号参数
+type Params struct {
+	// TimeoutSec 等待信号的超时时间，单位秒，为0时使用 DefaultTimeoutSec
+	TimeoutSec uint `json:"timeout_sec" validate:"omitempty"`
+	// Memo 等待信号的说明，如需要审批的内容
+	Memo string `json:"memo" validate:"omitempty"`
+}
+
+// Validate Params.
+func (p *Params) Validate() error {
+	return validator.Validate.Struct(p)
+}
+
+// Result 等待信号结果
+type Result struct {
+	// Deadline 等待信号的截止时间，超过截止时间未收到信号，任务执行失败
+	Deadline string `json:"deadline"`
+	// Signal 收到的信号，未收到信号时为空
+	Signal *Signal `json:"signal,omitempty"`
+}
+
+// Decision 信号的审批结果
+type Decision string
+
+const (
+	// Approve 审批通过
+	Approve Decision = "approve"
+	// Reject 审批拒绝
+	Reject Decision = "reject"
+)
+
+// Validate Decision.
+func (d Decision) Validate() error {
+	switch d {
+	case Approve, Reject:
+	default:
+		return fmt.Errorf("unsupported decision: %s", d)
+	}
+
+	return nil
+}
+
+// Signal 人工审批信号
+type Signal struct {
+	Decision Decision `json:"decision" validate:"required"`
+	Operator string   `json:"operator"`
+	Memo     string   `json:"memo"`
+	SignalAt string   `json:"signal_at"`
+}
+
+// Validate Signal.
+func (s *Signal) Validate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	return s.Decision.Validate()
+}
+
+// ParameterNew return wait signal params.
+func (act WaitSignalAction) ParameterNew() (params interface{}) {
+	return new(Params)
+}
+
+// Name return action name
+func (act WaitSignalAction) Name() enumor.ActionName {
+	return enumor.ActionWaitSignal
+}
+
+// Run 计算等待截止时间，并进入等待状态。
+func (act WaitSignalAction) Run(_ run.ExecuteKit, params interface{}) (interface{}, error) {
+	timeoutSec := uint(DefaultTimeoutSec)
+	if params != nil {
+		p, ok := params.(*Params)
+		if !ok {
+			return nil, errors.New("params type mismatch")
+		}
+
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+
+		if p.TimeoutSec != 0 {
+			timeoutSec = p.TimeoutSec
+		}
+	}
+
+	deadline := time.Now().Add(time.Duration(timeoutSec) * time.Second)
+	return &action.Waiting{Result: &Result{Deadline: deadline.Format(constant.TimeStdFormat)}}, nil
+}
+
+// Poll 检查是否收到信号或者等待超时。
+func (act WaitSignalAction) Poll(_ run.ExecuteKit, _ interface{}, result types.JsonField) (bool, interface{}, error) {
+	res, err := ParseResult(result)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if res.Signal != nil {
+		if res.Signal.Decision == Reject {
+			return true, res, fmt.Errorf("rejected by %s, memo: %s", res.Signal.Operator, res.Signal.Memo)
+		}
+
+		return true, res, nil
+	}
+
+	deadline, err := time.Parse(constant.TimeStdFormat, res.Deadline)
+	if err != nil {
+		return true, res, fmt.Errorf("parse deadline: %s failed, err: %v", res.Deadline, err)
+	}
+
+	if time.Now().After(deadline) {
+		return true, res, fmt.Errorf("wait signal timeout, deadline: %s", res.Deadline)
+	}
+
+	return false, nil, nil
+}
+
+// ParseResult 解析等待信号任务的执行结果
+func ParseResult(result types.JsonField) (*Result, error) {
+	res := new(Result)
+	if err := json.UnmarshalFromString(string(result), res); err != nil {
+		return nil, fmt.Errorf("unmarshal wait signal result failed, err: %v", err)
+	}
+
+	return res, nil
+}
@@ -21,6 +21,9 @@
 package async
 
 import (
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/subflow"
+	"hcm/pkg/async/action/waitsignal"
 	// 注册测试用例
 	_ "hcm/pkg/async/action/test"
 	"hcm/pkg/async/backend"
@@ -56,6 +59,10 @@ func NewAsync(bd backend.Backend, ld leader.Leader, opt *Option) (Async, error)
 		return nil, err
 	}
 
+	// 注册框架内置的子任务流Action、等待信号Action
+	action.RegisterAction(subflow.NewSubFlowAction(pdr, bd))
+	action.RegisterAction(waitsignal.WaitSignalAction{})
+
 	csm, err := consumer.NewConsumer(bd, ld, opt.Register, opt.ConsumerOption)
 	if err != nil {
 		logs.Errorf("new consumer failed, err: %v", err)
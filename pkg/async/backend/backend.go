@@ -21,11 +21,14 @@
 package backend
 
 import (
+	"time"
+
 	"hcm/pkg/api/core"
 	"hcm/pkg/async/backend/model"
 	"hcm/pkg/criteria/validator"
 	typesasync "hcm/pkg/dal/dao/types/async"
 	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
 )
 
 // Backend - a common interface for all backends
@@ -41,6 +44,8 @@ type Backend interface {
 	ListFlow(kt *kit.Kit, input *ListInput) ([]model.Flow, error)
 	// BatchUpdateFlowStateByCAS CAS批量更新Flow状态
 	BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo) error
+	// PurgeFlows 清理任务流及其任务、回调投递记录，返回实际被清理的任务流ID，只能由主节点组件携带任期的fencing token调用
+	PurgeFlows(kt *kit.Kit, input *PurgeFlowsInput) ([]string, error)
 
 	/*
 		Task 相关接口
@@ -51,13 +56,69 @@ type Backend interface {
 	UpdateTask(kt *kit.Kit, task *model.Task) error
 	// UpdateTaskStateByCAS CAS更新任务状态
 	UpdateTaskStateByCAS(kt *kit.Kit, info *UpdateTaskInfo) error
+	// UpdateTaskResultByCAS CAS更新任务执行结果，任务状态或执行结果已变化时返回RecordNotUpdate错误
+	UpdateTaskResultByCAS(kt *kit.Kit, info *UpdateTaskResultInfo) error
 	// ListTask 查询任务
 	ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error)
+
+	/*
+		CronFlow 相关接口
+	*/
+	// CreateCronFlow 创建周期任务流
+	CreateCronFlow(kt *kit.Kit, cron *model.CronFlow) (string, error)
+	// ListCronFlow 查询周期任务流
+	ListCronFlow(kt *kit.Kit, input *ListInput) ([]model.CronFlow, error)
+	// UpdateCronNextRunByCAS CAS更新周期任务流下次执行时间
+	UpdateCronNextRunByCAS(kt *kit.Kit, info *UpdateCronNextRunInfo) error
+	// DeleteCronFlow 删除周期任务流
+	DeleteCronFlow(kt *kit.Kit, id string) error
+
+	/*
+		CallbackDelivery 相关接口
+	*/
+	// BatchCreateCallbackDelivery 批量创建回调投递记录
+	BatchCreateCallbackDelivery(kt *kit.Kit, deliveries []model.CallbackDelivery) ([]string, error)
+	// ListCallbackDelivery 查询回调投递记录
+	ListCallbackDelivery(kt *kit.Kit, input *ListInput) ([]model.CallbackDelivery, error)
+	// UpdateCallbackDelivery 更新回调投递记录
+	UpdateCallbackDelivery(kt *kit.Kit, delivery *model.CallbackDelivery) error
+
+	/*
+		RateLimit 相关接口
+	*/
+	// AcquireRateLimitToken 从所有节点共享的令牌桶中获取一个令牌，获取成功时返回0，令牌不足时返回需要等待的时间
+	AcquireRateLimitToken(kt *kit.Kit, opt *AcquireTokenOption) (time.Duration, error)
+
+	/*
+		Leader 相关接口
+	*/
+	// UpdateLeaderFence 登记主节点当前任期的fencing token，已经存在更大的token时返回错误
+	UpdateLeaderFence(kt *kit.Kit, opt *UpdateLeaderFenceOption) error
+	// FencedBatchUpdateFlowStateByCAS 主节点组件携带任期的fencing token CAS批量更新Flow状态
+	FencedBatchUpdateFlowStateByCAS(kt *kit.Kit, token int64, infos []UpdateFlowInfo) error
+	// FencedBatchUpdateFlow 主节点组件携带任期的fencing token批量更新任务流
+	FencedBatchUpdateFlow(kt *kit.Kit, token int64, flows []model.Flow) error
+	// FencedUpdateTask 主节点组件携带任期的fencing token更新任务
+	FencedUpdateTask(kt *kit.Kit, token int64, task *model.Task) error
+	// FencedUpdateCallbackDelivery 主节点组件携带任期的fencing token更新回调投递记录
+	FencedUpdateCallbackDelivery(kt *kit.Kit, token int64, delivery *model.CallbackDelivery) error
 }
 
 // ListInput 查询输入参数
 type ListInput core.ListReq
 
+// PurgeFlowsInput 清理任务流输入参数
+type PurgeFlowsInput struct {
+	// FlowIDs 待清理的任务流ID
+	FlowIDs []string
+	// Filter 任务流可被清理的条件，清理时会重新校验，避免查询后被重试或恢复的任务流被清理
+	Filter *filter.Expression
+	// Archive 为true时先归档到归档表
+	Archive bool
+	// FencingToken 清理任务流的主节点组件所在任期的fencing token
+	FencingToken int64
+}
+
 // UpdateFlowInfo define update flow info.
 type UpdateFlowInfo typesasync.UpdateFlowInfo
 
@@ -73,3 +134,35 @@ type UpdateTaskInfo typesasync.UpdateTaskInfo
 func (info *UpdateTaskInfo) Validate() error {
 	return validator.Validate.Struct(info)
 }
+
+// UpdateTaskResultInfo define update task result info.
+type UpdateTaskResultInfo typesasync.UpdateTaskResultInfo
+
+// Validate UpdateTaskResultInfo
+func (info *UpdateTaskResultInfo) Validate() error {
+	return validator.Validate.Struct(info)
+}
+
+// UpdateCronNextRunInfo define update cron flow next run time info.
+type UpdateCronNextRunInfo typesasync.UpdateCronNextRunInfo
+
+// Validate UpdateCronNextRunInfo
+func (info *UpdateCronNextRunInfo) Validate() error {
+	return (*typesasync.UpdateCronNextRunInfo)(info).Validate()
+}
+
+// AcquireTokenOption define acquire rate limit token option.
+type AcquireTokenOption typesasync.AcquireTokenOption
+
+// Validate AcquireTokenOption
+func (opt *AcquireTokenOption) Validate() error {
+	return (*typesasync.AcquireTokenOption)(opt).Validate()
+}
+
+// UpdateLeaderFenceOption define update leader fencing token option.
+type UpdateLeaderFenceOption typesasync.UpdateLeaderFenceOption
+
+// Validate UpdateLeaderFenceOption
+func (opt *UpdateLeaderFenceOption) Validate() error {
+	return (*typesasync.UpdateLeaderFenceOption)(opt).Validate()
+}
@@ -36,6 +36,8 @@ func Factory(typ enumor.BackendType, client interface{}) (Backend, error) {
 			return nil, errors.New("client is not mysql dao set")
 		}
 		return NewMysql(cli), nil
+	case enumor.BackendMemory:
+		return NewMemory(), nil
 	default:
 		return nil, fmt.Errorf("unsupported mysql type: %s", typ)
 	}
@@ -0,0 +1,737 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	tableasync "hcm/pkg/dal/table/async"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/times"
+)
+
+// NewMemory create memory instance, all flows and tasks are stored in process memory, it is used by
+// unit tests and single-node deployments which do not need to persist async flows.
+func NewMemory() Backend {
+	return &memory{
+		flows: make(map[string]*model.Flow),
+		tasks: make(map[string]*model.Task),
+		crons: make(map[string]*model.CronFlow),
+
+		deliveries: make(map[string]*model.CallbackDelivery),
+
+		archivedFlows: make(map[string]*model.Flow),
+		archivedTasks: make(map[string]*model.Task),
+
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// memory 基于内存实现的后端存储，CAS 语义与 mysql 保持一致。
+type memory struct {
+	lock sync.RWMutex
+
+	flowSeq uint64
+	taskSeq uint64
+	cronSeq uint64
+
+	deliverySeq uint64
+
+	flows map[string]*model.Flow
+	tasks map[string]*model.Task
+	crons map[string]*model.CronFlow
+
+	deliveries map[string]*model.CallbackDelivery
+
+	archivedFlows map[string]*model.Flow
+	archivedTasks map[string]*model.Task
+
+	buckets map[string]*tokenBucket
+
+	// fencingToken 主节点当前任期的fencing token
+	fencingToken int64
+}
+
+var _ Backend = new(memory)
+
+// CreateFlow 创建任务流
+func (m *memory) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+	startAt, err := parseStartAt(flow.StartAt)
+	if err != nil {
+		return "", err
+	}
+
+	// 与mysql幂等键的唯一索引保持一致
+	if len(flow.IdempotencyKey) != 0 {
+		for _, one := range m.flows {
+			if one.IdempotencyKey == flow.IdempotencyKey {
+				return "", fmt.Errorf("flow idempotency_key: %s already exists", flow.IdempotencyKey)
+			}
+		}
+	}
+
+	m.flowSeq++
+	flowID := genMemoryID(m.flowSeq)
+	md := &model.Flow{
+		ID:           flowID,
+		Name:         flow.Name,
+		State:        enumor.FlowPending,
+		Reason:       new(tableasync.Reason),
+		ShareData:    copyShareData(flow.ShareData),
+		Memo:         flow.Memo,
+		Worker:       converter.ValToPtr(""),
+		StartAt:      times.ConvStdTimeFormat(startAt),
+		Priority:     flow.Priority,
+		AccountID:    flow.AccountID,
+		Compensate:   flow.Compensate,
+		ParentFlowID: flow.ParentFlowID,
+		ParentTaskID: flow.ParentTaskID,
+		Callbacks:    copyCallbacks(flow.Callbacks),
+		// 订阅了回调的任务流，执行结束后需要生成回调投递记录
+		CallbackPending: converter.ValToPtr(len(flow.Callbacks) != 0),
+		IdempotencyKey:  flow.IdempotencyKey,
+		PayloadDigest:   flow.PayloadDigest,
+		Creator:         kt.User,
+		Reviser:         kt.User,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if len(md.Name) == 0 {
+		return "", errf.New(errf.InvalidParameter, "name is required")
+	}
+
+	tasks := make([]*model.Task, 0, len(flow.Tasks))
+	for _, one := range flow.Tasks {
+		if len(one.FlowName) == 0 {
+			return "", errf.New(errf.InvalidParameter, "flow_name is required")
+		}
+
+		if len(one.ActionName) == 0 {
+			return "", errf.New(errf.InvalidParameter, "action_name is required")
+		}
+
+		m.taskSeq++
+		tasks = append(tasks, &model.Task{
+			ID:           genMemoryID(m.taskSeq),
+			FlowID:       flowID,
+			FlowName:     one.FlowName,
+			ActionID:     one.ActionID,
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			ParamsRender: one.ParamsRender,
+			Retry:        copyRetry(one.Retry),
+			DependOn:     copyDependOn(one.DependOn),
+			TimeoutSec:   one.TimeoutSec,
+			AllowFailure: one.AllowFailure,
+			TriggerRule:  one.TriggerRule,
+			RunCondition: copyRunCondition(one.RunCondition),
+			State:        enumor.TaskPending,
+			Reason:       new(tableasync.Reason),
+			Creator:      kt.User,
+			Reviser:      kt.User,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+	}
+
+	m.flows[flowID] = md
+	for _, one := range tasks {
+		m.tasks[one.ID] = one
+	}
+
+	return flowID, nil
+}
+
+// BatchUpdateFlow 批量更新任务流
+func (m *memory) BatchUpdateFlow(kt *kit.Kit, flows []model.Flow) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.batchUpdateFlow(flows)
+}
+
+// batchUpdateFlow 批量更新任务流，需要在持有锁时调用
+func (m *memory) batchUpdateFlow(flows []model.Flow) error {
+	// 与 mysql 的事务语义保持一致，任意一个任务流更新失败，全部不更新。
+	for _, one := range flows {
+		if _, exist := m.flows[one.ID]; !exist {
+			return errf.New(errf.RecordNotUpdate, "record not update")
+		}
+	}
+
+	now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+	for _, one := range flows {
+		md := m.flows[one.ID]
+		if len(one.State) != 0 {
+			md.State = one.State
+		}
+		if one.Reason != nil {
+			md.Reason = &tableasync.Reason{Message: one.Reason.Message}
+		}
+		if one.ShareData != nil {
+			md.ShareData = copyShareData(one.ShareData)
+		}
+		if len(one.Memo) != 0 {
+			md.Memo = one.Memo
+		}
+		if one.Worker != nil {
+			md.Worker = converter.ValToPtr(*one.Worker)
+		}
+		if one.CallbackPending != nil {
+			md.CallbackPending = converter.ValToPtr(*one.CallbackPending)
+		}
+		if one.FencingToken != 0 {
+			md.FencingToken = one.FencingToken
+		}
+		if len(one.Reviser) != 0 {
+			md.Reviser = one.Reviser
+		}
+		md.UpdatedAt = now
+	}
+
+	return nil
+}
+
+// PurgeFlows 清理任务流及其任务、回调投递记录，archive为true时先归档到归档表
+func (m *memory) PurgeFlows(kt *kit.Kit, input *PurgeFlowsInput) ([]string, error) {
+	if input == nil || len(input.FlowIDs) == 0 {
+		return nil, nil
+	}
+
+	if input.Filter == nil {
+		return nil, errf.New(errf.InvalidParameter, "purge flows filter is required")
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkFence(input.FencingToken); err != nil {
+		return nil, err
+	}
+
+	flowIDs := make([]string, 0, len(input.FlowIDs))
+	idMap := make(map[string]struct{}, len(input.FlowIDs))
+	for _, id := range input.FlowIDs {
+		flow, exists := m.flows[id]
+		if !exists {
+			continue
+		}
+
+		// 重新校验清理条件，避免查询后被重试或恢复的任务流被清理
+		matched, err := matchExpression(input.Filter, flowToRecord(flow))
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		flowIDs = append(flowIDs, id)
+		idMap[id] = struct{}{}
+		if input.Archive {
+			m.archivedFlows[id] = flow
+		}
+		delete(m.flows, id)
+	}
+
+	for id, task := range m.tasks {
+		if _, exists := idMap[task.FlowID]; !exists {
+			continue
+		}
+		if input.Archive {
+			m.archivedTasks[id] = task
+		}
+		delete(m.tasks, id)
+	}
+
+	for id, delivery := range m.deliveries {
+		if _, exists := idMap[delivery.FlowID]; exists {
+			delete(m.deliveries, id)
+		}
+	}
+
+	return flowIDs, nil
+}
+
+// ListFlow 查询任务流
+func (m *memory) ListFlow(kt *kit.Kit, input *ListInput) ([]model.Flow, error) {
+	if err := validateMemoryListInput(input, tableasync.AsyncFlowColumns.ColumnTypes()); err != nil {
+		return nil, err
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if input.Page.Count {
+		return make([]model.Flow, 0), nil
+	}
+
+	records := make([]memoryRecord, 0)
+	for _, one := range m.flows {
+		record := flowToRecord(one)
+		matched, err := matchExpression(input.Filter, record)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			records = append(records, record)
+		}
+	}
+
+	records = pageRecords(records, input.Page)
+
+	flows := make([]model.Flow, 0, len(records))
+	for _, one := range records {
+		flows = append(flows, copyFlow(m.flows[one["id"].(string)]))
+	}
+
+	return flows, nil
+}
+
+// BatchUpdateFlowStateByCAS CAS批量更新流状态
+func (m *memory) BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo) error {
+	for _, one := range infos {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.batchUpdateFlowStateByCAS(infos)
+}
+
+// batchUpdateFlowStateByCAS CAS批量更新流状态，需要在持有锁时调用
+func (m *memory) batchUpdateFlowStateByCAS(infos []UpdateFlowInfo) error {
+	// 先校验全部任务流的源状态，保证和 mysql 的事务一样，要么全部成功，要么全部失败。
+	for _, one := range infos {
+		md, exist := m.flows[one.ID]
+		if !exist || md.State != one.Source {
+			return errf.Newf(errf.RecordNotUpdate, "flow[%s: %s] update state: %s, worker: %s failed", one.ID,
+				one.Source, one.Target, one.Worker)
+		}
+	}
+
+	now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+	for _, one := range infos {
+		md := m.flows[one.ID]
+		md.State = one.Target
+		if len(one.Worker) != 0 {
+			md.Worker = converter.ValToPtr(one.Worker)
+		}
+		if one.Reason != nil {
+			md.Reason = &tableasync.Reason{Message: one.Reason.Message}
+		}
+		if one.FencingToken != 0 {
+			md.FencingToken = one.FencingToken
+		}
+		md.UpdatedAt = now
+	}
+
+	return nil
+}
+
+// BatchCreateTask 批量创建任务
+func (m *memory) BatchCreateTask(kt *kit.Kit, tasks []model.Task) ([]string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, one := range tasks {
+		if len(one.FlowID) == 0 {
+			return nil, errf.New(errf.InvalidParameter, "flow_id is required")
+		}
+
+		if len(one.FlowName) == 0 {
+			return nil, errf.New(errf.InvalidParameter, "flow_name is required")
+		}
+
+		if len(one.ActionName) == 0 {
+			return nil, errf.New(errf.InvalidParameter, "action_name is required")
+		}
+	}
+
+	now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+	ids := make([]string, 0, len(tasks))
+	for _, one := range tasks {
+		m.taskSeq++
+		md := &model.Task{
+			ID:           genMemoryID(m.taskSeq),
+			FlowID:       one.FlowID,
+			FlowName:     one.FlowName,
+			ActionID:     one.ActionID,
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			ParamsRender: one.ParamsRender,
+			Retry:        copyRetry(one.Retry),
+			DependOn:     copyDependOn(one.DependOn),
+			TimeoutSec:   one.TimeoutSec,
+			AllowFailure: one.AllowFailure,
+			TriggerRule:  one.TriggerRule,
+			RunCondition: copyRunCondition(one.RunCondition),
+			ParentID:     one.ParentID,
+			State:        enumor.TaskPending,
+			Reason:       copyReason(one.Reason),
+			Creator:      one.Creator,
+			Reviser:      one.Reviser,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		m.tasks[md.ID] = md
+		ids = append(ids, md.ID)
+	}
+
+	return ids, nil
+}
+
+// UpdateTask 更新任务
+func (m *memory) UpdateTask(kt *kit.Kit, task *model.Task) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.updateTask(kt, task)
+}
+
+// updateTask 更新任务，需要在持有锁时调用
+func (m *memory) updateTask(kt *kit.Kit, task *model.Task) error {
+	md, exist := m.tasks[task.ID]
+	if !exist {
+		// mysql 按照ID更新任务时，不校验影响行数，这里保持一致。
+		return nil
+	}
+
+	if task.Retry != nil {
+		md.Retry = copyRetry(task.Retry)
+	}
+	if len(task.DependOn) != 0 {
+		md.DependOn = copyDependOn(task.DependOn)
+	}
+	if len(task.State) != 0 {
+		md.State = task.State
+	}
+	if len(task.Result) != 0 {
+		md.Result = task.Result
+	}
+	if task.Reason != nil {
+		md.Reason = copyReason(task.Reason)
+	}
+	if len(task.Logs) != 0 {
+		md.Logs = append(tableasync.TaskLogs{}, task.Logs...)
+	}
+	if task.RetryState != nil {
+		md.RetryState = copyRetryState(task.RetryState)
+	}
+	if len(kt.User) != 0 {
+		md.Reviser = kt.User
+	}
+	md.UpdatedAt = times.ConvStdTimeFormat(times.ConvStdTimeNow())
+
+	return nil
+}
+
+// UpdateTaskStateByCAS CAS更新任务状态
+func (m *memory) UpdateTaskStateByCAS(kt *kit.Kit, info *UpdateTaskInfo) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	md, exist := m.tasks[info.ID]
+	if !exist || md.State != info.Source {
+		return errf.Newf(errf.RecordNotUpdate, "task[%s: %s] update state to %s failed", info.ID, info.Source,
+			info.Target)
+	}
+
+	md.State = info.Target
+	if info.Reason != nil {
+		md.Reason = copyReason(info.Reason)
+	}
+	if info.RetryState != nil {
+		md.RetryState = copyRetryState(info.RetryState)
+	}
+	md.UpdatedAt = times.ConvStdTimeFormat(times.ConvStdTimeNow())
+
+	return nil
+}
+
+// UpdateTaskResultByCAS CAS更新任务执行结果
+func (m *memory) UpdateTaskResultByCAS(kt *kit.Kit, info *UpdateTaskResultInfo) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	md, exist := m.tasks[info.ID]
+	if !exist || md.State != info.State {
+		return errf.Newf(errf.RecordNotUpdate, "task[%s: %s] result has been changed", info.ID, info.State)
+	}
+
+	equal, err := jsonFieldEqual(md.Result, info.Source)
+	if err != nil {
+		return err
+	}
+	if !equal {
+		return errf.Newf(errf.RecordNotUpdate, "task[%s: %s] result has been changed", info.ID, info.State)
+	}
+
+	md.Result = info.Target
+	md.UpdatedAt = times.ConvStdTimeFormat(times.ConvStdTimeNow())
+
+	return nil
+}
+
+// jsonFieldEqual 按照json语义比较两个json字段是否相等
+func jsonFieldEqual(a, b tabletypes.JsonField) (bool, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b), nil
+	}
+
+	var av, bv interface{}
+	if err := json.UnmarshalFromString(string(a), &av); err != nil {
+		return false, err
+	}
+	if err := json.UnmarshalFromString(string(b), &bv); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(av, bv), nil
+}
+
+// ListTask 查询任务
+func (m *memory) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
+	if err := validateMemoryListInput(input, tableasync.AsyncFlowTaskColumns.ColumnTypes()); err != nil {
+		return nil, err
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if input.Page.Count {
+		return make([]model.Task, 0), nil
+	}
+
+	records := make([]memoryRecord, 0)
+	for _, one := range m.tasks {
+		record := taskToRecord(one)
+		matched, err := matchExpression(input.Filter, record)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			records = append(records, record)
+		}
+	}
+
+	records = pageRecords(records, input.Page)
+
+	tasks := make([]model.Task, 0, len(records))
+	for _, one := range records {
+		tasks = append(tasks, copyTask(m.tasks[one["id"].(string)]))
+	}
+
+	return tasks, nil
+}
+
+// validateMemoryListInput 和 mysql 的 dao 层使用相同的校验规则，保证两种后端对查询条件的约束一致。
+func validateMemoryListInput(input *ListInput, columnTypes map[string]enumor.ColumnType) error {
+	if input == nil {
+		return errf.New(errf.InvalidParameter, "list input is nil")
+	}
+
+	opt := &types.ListOption{
+		Fields: input.Fields,
+		Filter: input.Filter,
+		Page:   input.Page,
+	}
+	return opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)), core.NewDefaultPageOption())
+}
+
+// pageRecords 按照分页参数对记录进行排序和截取，未指定排序字段时，和 mysql 一样默认按照 id 排序。
+func pageRecords(records []memoryRecord, page *core.BasePage) []memoryRecord {
+	sortField := "id"
+	if len(page.Sort) != 0 {
+		sortField = page.Sort
+	}
+	desc := page.Order == core.Descending
+
+	sort.SliceStable(records, func(i, j int) bool {
+		cmp, err := compareValue(records[i][sortField], records[j][sortField])
+		if err != nil {
+			return false
+		}
+
+		// 排序字段相同时按照 id 升序，和 mysql 按主键顺序返回的结果保持一致
+		if cmp == 0 && sortField != "id" {
+			cmp, _ = compareValue(records[i]["id"], records[j]["id"])
+			return cmp < 0
+		}
+
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+
+	if page.Start == 0 && page.Limit == 0 {
+		return records
+	}
+
+	start := int(page.Start)
+	if start >= len(records) {
+		return make([]memoryRecord, 0)
+	}
+
+	end := len(records)
+	if page.Limit != 0 && start+int(page.Limit) < end {
+		end = start + int(page.Limit)
+	}
+
+	return records[start:end]
+}
+
+// genMemoryID 生成和 id_generator 相同格式的ID，保证按照ID排序的结果与创建顺序一致。
+func genMemoryID(seq uint64) string {
+	return fmt.Sprintf("%08s", strconv.FormatUint(seq, 36))
+}
+
+func copyFlow(flow *model.Flow) model.Flow {
+	result := *flow
+	result.Reason = copyReason(flow.Reason)
+	result.ShareData = copyShareData(flow.ShareData)
+	if flow.Worker != nil {
+		result.Worker = converter.ValToPtr(*flow.Worker)
+	}
+	result.Callbacks = copyCallbacks(flow.Callbacks)
+	if flow.CallbackPending != nil {
+		result.CallbackPending = converter.ValToPtr(*flow.CallbackPending)
+	}
+
+	return result
+}
+
+func copyTask(task *model.Task) model.Task {
+	result := *task
+	result.Reason = copyReason(task.Reason)
+	result.Retry = copyRetry(task.Retry)
+	result.DependOn = copyDependOn(task.DependOn)
+	result.RunCondition = copyRunCondition(task.RunCondition)
+	result.RetryState = copyRetryState(task.RetryState)
+	if task.Logs != nil {
+		result.Logs = append(tableasync.TaskLogs{}, task.Logs...)
+	}
+
+	return result
+}
+
+func copyReason(reason *tableasync.Reason) *tableasync.Reason {
+	if reason == nil {
+		return nil
+	}
+
+	return &tableasync.Reason{Message: reason.Message}
+}
+
+func copyRetry(retry *tableasync.Retry) *tableasync.Retry {
+	if retry == nil {
+		return nil
+	}
+
+	result := &tableasync.Retry{Enable: retry.Enable}
+	if retry.Policy != nil {
+		policy := *retry.Policy
+		if retry.Policy.Backoff != nil {
+			backoff := *retry.Policy.Backoff
+			policy.Backoff = &backoff
+		}
+		result.Policy = &policy
+	}
+
+	return result
+}
+
+func copyRetryState(state *tableasync.RetryState) *tableasync.RetryState {
+	if state == nil {
+		return nil
+	}
+
+	result := *state
+	return &result
+}
+
+func copyShareData(data *tableasync.ShareData) *tableasync.ShareData {
+	result := tableasync.NewShareData()
+	if data == nil {
+		return result
+	}
+
+	for key, val := range data.Dict {
+		result.Dict[key] = val
+	}
+
+	return result
+}
+
+func copyDependOn(dependOn []action.ActIDType) []action.ActIDType {
+	result := make([]action.ActIDType, 0, len(dependOn))
+	return append(result, dependOn...)
+}
+
+func copyRunCondition(cond *tableasync.RunCondition) *tableasync.RunCondition {
+	if cond == nil {
+		return nil
+	}
+
+	result := *cond
+	return &result
+}
+
+func copyCallbacks(callbacks tableasync.Callbacks) tableasync.Callbacks {
+	if callbacks == nil {
+		return nil
+	}
+
+	result := make(tableasync.Callbacks, len(callbacks))
+	copy(result, callbacks)
+	return result
+}
@@ -0,0 +1,166 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+)
+
+// BatchCreateCallbackDelivery 批量创建回调投递记录
+func (m *memory) BatchCreateCallbackDelivery(kt *kit.Kit, deliveries []model.CallbackDelivery) ([]string, error) {
+	nextAts := make([]time.Time, 0, len(deliveries))
+	for _, one := range deliveries {
+		if err := one.CreateValidate(); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		nextAt, err := time.Parse(constant.TimeStdFormat, one.NextAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse next_at: %s failed, err: %v", one.NextAt, err)
+		}
+		nextAts = append(nextAts, nextAt)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+	ids := make([]string, 0, len(deliveries))
+	for index, one := range deliveries {
+		m.deliverySeq++
+		md := copyDelivery(&one)
+		md.ID = genMemoryID(m.deliverySeq)
+		md.State = enumor.CallbackPending
+		md.Attempts = converter.ValToPtr(uint(0))
+		md.NextAt = times.ConvStdTimeFormat(nextAts[index])
+		md.Reason = new(tableasync.Reason)
+		md.Creator = kt.User
+		md.Reviser = kt.User
+		md.CreatedAt = now
+		md.UpdatedAt = now
+		m.deliveries[md.ID] = &md
+		ids = append(ids, md.ID)
+	}
+
+	return ids, nil
+}
+
+// ListCallbackDelivery 查询回调投递记录
+func (m *memory) ListCallbackDelivery(kt *kit.Kit, input *ListInput) ([]model.CallbackDelivery, error) {
+	if err := validateMemoryListInput(input, tableasync.AsyncFlowCallbackColumns.ColumnTypes()); err != nil {
+		return nil, err
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if input.Page.Count {
+		return make([]model.CallbackDelivery, 0), nil
+	}
+
+	records := make([]memoryRecord, 0)
+	for _, one := range m.deliveries {
+		record := deliveryToRecord(one)
+		matched, err := matchExpression(input.Filter, record)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			records = append(records, record)
+		}
+	}
+
+	records = pageRecords(records, input.Page)
+
+	deliveries := make([]model.CallbackDelivery, 0, len(records))
+	for _, one := range records {
+		deliveries = append(deliveries, copyDelivery(m.deliveries[one["id"].(string)]))
+	}
+
+	return deliveries, nil
+}
+
+// UpdateCallbackDelivery 更新回调投递记录
+func (m *memory) UpdateCallbackDelivery(kt *kit.Kit, delivery *model.CallbackDelivery) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.updateCallbackDelivery(kt, delivery)
+}
+
+// updateCallbackDelivery 更新回调投递记录，需要在持有锁时调用
+func (m *memory) updateCallbackDelivery(kt *kit.Kit, delivery *model.CallbackDelivery) error {
+	var nextAt string
+	if len(delivery.NextAt) != 0 {
+		t, err := time.Parse(constant.TimeStdFormat, delivery.NextAt)
+		if err != nil {
+			return fmt.Errorf("parse next_at: %s failed, err: %v", delivery.NextAt, err)
+		}
+		nextAt = times.ConvStdTimeFormat(t)
+	}
+
+	md, exist := m.deliveries[delivery.ID]
+	if !exist {
+		// mysql 按照ID更新时，不校验影响行数，这里保持一致。
+		return nil
+	}
+
+	if len(delivery.State) != 0 {
+		md.State = delivery.State
+	}
+	if delivery.Attempts != nil {
+		md.Attempts = converter.ValToPtr(*delivery.Attempts)
+	}
+	if len(nextAt) != 0 {
+		md.NextAt = nextAt
+	}
+	if delivery.Reason != nil {
+		md.Reason = copyReason(delivery.Reason)
+	}
+	md.Reviser = kt.User
+	md.UpdatedAt = times.ConvStdTimeFormat(times.ConvStdTimeNow())
+
+	return nil
+}
+
+func copyDelivery(delivery *model.CallbackDelivery) model.CallbackDelivery {
+	result := *delivery
+	result.Reason = copyReason(delivery.Reason)
+	if delivery.Callback != nil {
+		callback := *delivery.Callback
+		result.Callback = &callback
+	}
+	if delivery.Attempts != nil {
+		result.Attempts = converter.ValToPtr(*delivery.Attempts)
+	}
+
+	return result
+}
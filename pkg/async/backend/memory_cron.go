@@ -0,0 +1,140 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/times"
+)
+
+// CreateCronFlow 创建周期任务流
+func (m *memory) CreateCronFlow(kt *kit.Kit, cron *model.CronFlow) (string, error) {
+	if err := cron.CreateValidate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	nextRunAt, err := time.Parse(constant.TimeStdFormat, cron.NextRunAt)
+	if err != nil {
+		return "", fmt.Errorf("parse next_run_at: %s failed, err: %v", cron.NextRunAt, err)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+
+	m.cronSeq++
+	id := genMemoryID(m.cronSeq)
+	md := copyCronFlow(cron)
+	md.ID = id
+	md.NextRunAt = times.ConvStdTimeFormat(nextRunAt)
+	md.Creator = kt.User
+	md.Reviser = kt.User
+	md.CreatedAt = now
+	md.UpdatedAt = now
+	m.crons[id] = &md
+
+	return id, nil
+}
+
+// ListCronFlow 查询周期任务流
+func (m *memory) ListCronFlow(kt *kit.Kit, input *ListInput) ([]model.CronFlow, error) {
+	if err := validateMemoryListInput(input, tableasync.AsyncFlowCronColumns.ColumnTypes()); err != nil {
+		return nil, err
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if input.Page.Count {
+		return make([]model.CronFlow, 0), nil
+	}
+
+	records := make([]memoryRecord, 0)
+	for _, one := range m.crons {
+		record := cronToRecord(one)
+		matched, err := matchExpression(input.Filter, record)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			records = append(records, record)
+		}
+	}
+
+	records = pageRecords(records, input.Page)
+
+	crons := make([]model.CronFlow, 0, len(records))
+	for _, one := range records {
+		crons = append(crons, copyCronFlow(m.crons[one["id"].(string)]))
+	}
+
+	return crons, nil
+}
+
+// UpdateCronNextRunByCAS CAS更新周期任务流下次执行时间
+func (m *memory) UpdateCronNextRunByCAS(kt *kit.Kit, info *UpdateCronNextRunInfo) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	md, exist := m.crons[info.ID]
+	if !exist || md.NextRunAt != times.ConvStdTimeFormat(info.Source) {
+		return errf.Newf(errf.RecordNotUpdate, "flow cron[%s: %s] update next run time: %s failed",
+			info.ID, info.Source, info.Target)
+	}
+
+	md.NextRunAt = times.ConvStdTimeFormat(info.Target)
+	md.UpdatedAt = times.ConvStdTimeFormat(times.ConvStdTimeNow())
+
+	return nil
+}
+
+// DeleteCronFlow 删除周期任务流
+func (m *memory) DeleteCronFlow(kt *kit.Kit, id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.crons, id)
+
+	return nil
+}
+
+func copyCronFlow(cron *model.CronFlow) model.CronFlow {
+	result := *cron
+	result.ShareData = copyShareData(cron.ShareData)
+	result.Tasks = make([]model.Task, 0, len(cron.Tasks))
+	for index := range cron.Tasks {
+		result.Tasks = append(result.Tasks, copyTask(&cron.Tasks[index]))
+	}
+
+	return result
+}
@@ -0,0 +1,305 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+// memoryRecord 内存后端用于过滤和排序的记录，key 为表字段名，value 为对应字段值。
+type memoryRecord map[string]interface{}
+
+func flowToRecord(flow *model.Flow) memoryRecord {
+	return memoryRecord{
+		"id":               flow.ID,
+		"name":             string(flow.Name),
+		"state":            string(flow.State),
+		"memo":             flow.Memo,
+		"worker":           converter.PtrToVal(flow.Worker),
+		"start_at":         flow.StartAt,
+		"priority":         flow.Priority,
+		"account_id":       flow.AccountID,
+		"compensate":       flow.Compensate,
+		"parent_flow_id":   flow.ParentFlowID,
+		"parent_task_id":   flow.ParentTaskID,
+		"callback_pending": converter.PtrToVal(flow.CallbackPending),
+		"idempotency_key":  flow.IdempotencyKey,
+		"fencing_token":    flow.FencingToken,
+		"creator":          flow.Creator,
+		"reviser":          flow.Reviser,
+		"created_at":       flow.CreatedAt,
+		"updated_at":       flow.UpdatedAt,
+	}
+}
+
+func cronToRecord(cron *model.CronFlow) memoryRecord {
+	return memoryRecord{
+		"id":          cron.ID,
+		"name":        string(cron.Name),
+		"spec":        cron.Spec,
+		"memo":        cron.Memo,
+		"next_run_at": cron.NextRunAt,
+		"priority":    cron.Priority,
+		"account_id":  cron.AccountID,
+		"compensate":  cron.Compensate,
+		"creator":     cron.Creator,
+		"reviser":     cron.Reviser,
+		"created_at":  cron.CreatedAt,
+		"updated_at":  cron.UpdatedAt,
+	}
+}
+
+func deliveryToRecord(delivery *model.CallbackDelivery) memoryRecord {
+	return memoryRecord{
+		"id":         delivery.ID,
+		"flow_id":    delivery.FlowID,
+		"flow_state": string(delivery.FlowState),
+		"state":      string(delivery.State),
+		"attempts":   converter.PtrToVal(delivery.Attempts),
+		"next_at":    delivery.NextAt,
+		"creator":    delivery.Creator,
+		"reviser":    delivery.Reviser,
+		"created_at": delivery.CreatedAt,
+		"updated_at": delivery.UpdatedAt,
+	}
+}
+
+func taskToRecord(task *model.Task) memoryRecord {
+	dependOn := make([]interface{}, 0, len(task.DependOn))
+	for _, one := range task.DependOn {
+		dependOn = append(dependOn, string(one))
+	}
+
+	return memoryRecord{
+		"id":            task.ID,
+		"flow_id":       task.FlowID,
+		"flow_name":     string(task.FlowName),
+		"action_id":     string(task.ActionID),
+		"action_name":   string(task.ActionName),
+		"depend_on":     dependOn,
+		"timeout_sec":   task.TimeoutSec,
+		"allow_failure": task.AllowFailure,
+		"trigger_rule":  string(task.TriggerRule),
+		"parent_id":     task.ParentID,
+		"state":         string(task.State),
+		"creator":       task.Creator,
+		"reviser":       task.Reviser,
+		"created_at":    task.CreatedAt,
+		"updated_at":    task.UpdatedAt,
+	}
+}
+
+// matchExpression 判断记录是否满足过滤条件。
+func matchExpression(expr *filter.Expression, record memoryRecord) (bool, error) {
+	if expr == nil || len(expr.Rules) == 0 {
+		return true, nil
+	}
+
+	for _, rule := range expr.Rules {
+		matched, err := matchRule(rule, record)
+		if err != nil {
+			return false, err
+		}
+
+		switch expr.Op {
+		case filter.And:
+			if !matched {
+				return false, nil
+			}
+		case filter.Or:
+			if matched {
+				return true, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported logic operator: %s", expr.Op)
+		}
+	}
+
+	return expr.Op == filter.And, nil
+}
+
+func matchRule(rule filter.RuleFactory, record memoryRecord) (bool, error) {
+	switch r := rule.(type) {
+	case *filter.Expression:
+		return matchExpression(r, record)
+	case *filter.AtomRule:
+		return matchAtomRule(r, record)
+	case filter.AtomRule:
+		return matchAtomRule(&r, record)
+	default:
+		return false, fmt.Errorf("unsupported rule type: %T", rule)
+	}
+}
+
+func matchAtomRule(rule *filter.AtomRule, record memoryRecord) (bool, error) {
+	fieldVal, exist := record[rule.Field]
+	if !exist {
+		return false, fmt.Errorf("memory backend not support filter by field: %s", rule.Field)
+	}
+
+	switch filter.OpType(rule.Op) {
+	case filter.Equal:
+		cmp, err := compareValue(fieldVal, rule.Value)
+		return err == nil && cmp == 0, nil
+
+	case filter.NotEqual:
+		cmp, err := compareValue(fieldVal, rule.Value)
+		return err != nil || cmp != 0, nil
+
+	case filter.GreaterThan, filter.GreaterThanEqual, filter.LessThan, filter.LessThanEqual:
+		cmp, err := compareValue(fieldVal, rule.Value)
+		if err != nil {
+			return false, err
+		}
+
+		switch filter.OpType(rule.Op) {
+		case filter.GreaterThan:
+			return cmp > 0, nil
+		case filter.GreaterThanEqual:
+			return cmp >= 0, nil
+		case filter.LessThan:
+			return cmp < 0, nil
+		default:
+			return cmp <= 0, nil
+		}
+
+	case filter.In, filter.NotIn:
+		hit, err := inValues(fieldVal, rule.Value)
+		if err != nil {
+			return false, err
+		}
+
+		if filter.OpType(rule.Op) == filter.In {
+			return hit, nil
+		}
+		return !hit, nil
+
+	case filter.ContainsSensitive:
+		return strings.Contains(fmt.Sprint(fieldVal), fmt.Sprint(rule.Value)), nil
+
+	case filter.ContainsInsensitive:
+		return strings.Contains(strings.ToLower(fmt.Sprint(fieldVal)), strings.ToLower(fmt.Sprint(rule.Value))), nil
+
+	case filter.JSONContains:
+		elements, ok := fieldVal.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("field: %s is not json array", rule.Field)
+		}
+		return inValues(rule.Value, elements)
+
+	case filter.JSONLength:
+		elements, ok := fieldVal.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("field: %s is not json array", rule.Field)
+		}
+		cmp, err := compareValue(len(elements), rule.Value)
+		return err == nil && cmp == 0, nil
+
+	default:
+		return false, fmt.Errorf("memory backend not support operator: %s", rule.Op)
+	}
+}
+
+// inValues 判断 val 是否在 values 数组中。
+func inValues(val interface{}, values interface{}) (bool, error) {
+	rv := reflect.ValueOf(values)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false, errors.New("in operator's value should be an array")
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		cmp, err := compareValue(val, rv.Index(i).Interface())
+		if err == nil && cmp == 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// compareValue 比较两个基础类型的值，a < b 返回 -1，a == b 返回 0，a > b 返回 1。
+// 数值类型按照数值比较，时间字符串按照时间比较，其余按照字符串比较。
+func compareValue(a, b interface{}) (int, error) {
+	af, aNum := toFloat(a)
+	bf, bNum := toFloat(b)
+	if aNum && bNum {
+		return compareOrdered(af, bf), nil
+	}
+
+	as, aStr := toString(a)
+	bs, bStr := toString(b)
+	if !aStr || !bStr {
+		return 0, fmt.Errorf("can not compare %v(%T) with %v(%T)", a, a, b, b)
+	}
+
+	at, aErr := time.Parse(constant.TimeStdFormat, as)
+	bt, bErr := time.Parse(constant.TimeStdFormat, bs)
+	if aErr == nil && bErr == nil {
+		return compareOrdered(at.UnixNano(), bt.UnixNano()), nil
+	}
+
+	return strings.Compare(as, bs), nil
+}
+
+func compareOrdered[T float64 | int64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func toString(v interface{}) (string, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), true
+	case reflect.Bool:
+		return fmt.Sprint(rv.Bool()), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// UpdateLeaderFence 登记主节点当前任期的fencing token，已经存在更大的token时返回错误
+func (m *memory) UpdateLeaderFence(kt *kit.Kit, opt *UpdateLeaderFenceOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if opt.Token < m.fencingToken {
+		return errf.Newf(errf.RecordNotUpdate, "leader fencing token: %d is stale", opt.Token)
+	}
+	m.fencingToken = opt.Token
+
+	return nil
+}
+
+// checkFence 校验主节点组件携带的fencing token与当前登记的token一致，token为0时直接拒绝，需要在持有锁时调用
+func (m *memory) checkFence(token int64) error {
+	if token <= 0 {
+		return errf.Newf(errf.InvalidParameter, "leader fencing token: %d is invalid", token)
+	}
+
+	if token != m.fencingToken {
+		return errf.Newf(errf.RecordNotUpdate, "leader fencing token: %d is stale", token)
+	}
+
+	return nil
+}
+
+// FencedBatchUpdateFlowStateByCAS 主节点组件携带任期的fencing token CAS批量更新Flow状态
+func (m *memory) FencedBatchUpdateFlowStateByCAS(kt *kit.Kit, token int64, infos []UpdateFlowInfo) error {
+	for _, one := range infos {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkFence(token); err != nil {
+		return err
+	}
+
+	fenced := make([]UpdateFlowInfo, 0, len(infos))
+	for _, one := range infos {
+		one.FencingToken = token
+		fenced = append(fenced, one)
+	}
+
+	return m.batchUpdateFlowStateByCAS(fenced)
+}
+
+// FencedBatchUpdateFlow 主节点组件携带任期的fencing token批量更新任务流
+func (m *memory) FencedBatchUpdateFlow(kt *kit.Kit, token int64, flows []model.Flow) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkFence(token); err != nil {
+		return err
+	}
+
+	fenced := make([]model.Flow, 0, len(flows))
+	for _, one := range flows {
+		one.FencingToken = token
+		fenced = append(fenced, one)
+	}
+
+	return m.batchUpdateFlow(fenced)
+}
+
+// FencedUpdateTask 主节点组件携带任期的fencing token更新任务
+func (m *memory) FencedUpdateTask(kt *kit.Kit, token int64, task *model.Task) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkFence(token); err != nil {
+		return err
+	}
+
+	return m.updateTask(kt, task)
+}
+
+// FencedUpdateCallbackDelivery 主节点组件携带任期的fencing token更新回调投递记录
+func (m *memory) FencedUpdateCallbackDelivery(kt *kit.Kit, token int64, delivery *model.CallbackDelivery) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkFence(token); err != nil {
+		return err
+	}
+
+	return m.updateCallbackDelivery(kt, delivery)
+}
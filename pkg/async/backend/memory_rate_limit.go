@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"math"
+	"time"
+
+	typesasync "hcm/pkg/dal/dao/types/async"
+	"hcm/pkg/kit"
+)
+
+// tokenBucket 令牌桶，记录上次补充令牌时的令牌数以及补充时间
+type tokenBucket struct {
+	tokens      float64
+	refreshedAt time.Time
+}
+
+// AcquireRateLimitToken 从令牌桶中获取一个令牌，获取成功时返回0，令牌不足时返回需要等待的时间
+func (m *memory) AcquireRateLimitToken(kt *kit.Kit, opt *AcquireTokenOption) (time.Duration, error) {
+	if err := opt.Validate(); err != nil {
+		return 0, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	bucket, exist := m.buckets[opt.Key]
+	if !exist {
+		// 首次获取令牌时初始化令牌桶，令牌桶初始为满
+		bucket = &tokenBucket{tokens: float64(opt.Burst), refreshedAt: now}
+		m.buckets[opt.Key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.refreshedAt).Seconds()
+	tokens := math.Min(float64(opt.Burst), bucket.tokens+elapsed*opt.Rate)
+	if tokens < 1 {
+		return (*typesasync.AcquireTokenOption)(opt).TokenWait(tokens), nil
+	}
+
+	bucket.tokens = tokens - 1
+	bucket.refreshedAt = now
+
+	return 0, nil
+}
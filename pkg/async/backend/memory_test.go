@@ -0,0 +1,239 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+)
+
+func newTestKit() *kit.Kit {
+	kt := kit.New()
+	kt.User = "test"
+	return kt
+}
+
+func createTestFlow(t *testing.T, bd Backend) string {
+	flow := &model.Flow{
+		Name:      "test_flow",
+		ShareData: tableasync.NewShareData(),
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: "test_action"},
+			{FlowName: "test_flow", ActionID: "2", ActionName: "test_action", DependOn: []action.ActIDType{"1"}},
+		},
+	}
+	id, err := bd.CreateFlow(newTestKit(), flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	return id
+}
+
+func TestMemoryFlowCAS(t *testing.T) {
+	bd := NewMemory()
+	kt := newTestKit()
+	id := createTestFlow(t, bd)
+
+	infos := []UpdateFlowInfo{{ID: id, Source: enumor.FlowPending, Target: enumor.FlowScheduled, Worker: "node1"}}
+	if err := bd.BatchUpdateFlowStateByCAS(kt, infos); err != nil {
+		t.Fatalf("update flow state by cas failed, err: %v", err)
+	}
+
+	// 源状态已经不是 pending，CAS 更新必须失败。
+	if err := bd.BatchUpdateFlowStateByCAS(kt, infos); err == nil {
+		t.Fatalf("update flow state by cas with stale source state should failed")
+	}
+
+	input := &ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "state", Op: filter.Equal.Factory(), Value: enumor.FlowScheduled},
+				&filter.AtomRule{Field: "worker", Op: filter.Equal.Factory(), Value: "node1"},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	flows, err := bd.ListFlow(kt, input)
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	if len(flows) != 1 || flows[0].ID != id {
+		t.Fatalf("list flow result not expected, flows: %+v", flows)
+	}
+}
+
+func TestMemoryTaskCAS(t *testing.T) {
+	bd := NewMemory()
+	kt := newTestKit()
+	flowID := createTestFlow(t, bd)
+
+	input := &ListInput{
+		Filter: tools.EqualExpression("flow_id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	tasks, err := bd.ListTask(kt, input)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("task count should be 2, but got %d", len(tasks))
+	}
+
+	info := &UpdateTaskInfo{ID: tasks[0].ID, Source: enumor.TaskPending, Target: enumor.TaskRunning}
+	if err = bd.UpdateTaskStateByCAS(kt, info); err != nil {
+		t.Fatalf("update task state by cas failed, err: %v", err)
+	}
+
+	if err = bd.UpdateTaskStateByCAS(kt, info); err == nil {
+		t.Fatalf("update task state by cas with stale source state should failed")
+	}
+
+	input.Filter = &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "flow_id", Op: filter.Equal.Factory(), Value: flowID},
+			&filter.AtomRule{Field: "state", Op: filter.In.Factory(),
+				Value: []enumor.TaskState{enumor.TaskRunning, enumor.TaskRollback}},
+		},
+	}
+	tasks, err = bd.ListTask(kt, input)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].ID != info.ID {
+		t.Fatalf("list running task result not expected, tasks: %+v", tasks)
+	}
+}
+
+func TestMemoryListPage(t *testing.T) {
+	bd := NewMemory()
+	kt := newTestKit()
+	for i := 0; i < 5; i++ {
+		createTestFlow(t, bd)
+	}
+
+	input := &ListInput{
+		Filter: tools.EqualExpression("state", enumor.FlowPending),
+		Page:   &core.BasePage{Start: 1, Limit: 2, Sort: "id", Order: core.Descending},
+	}
+	flows, err := bd.ListFlow(kt, input)
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	if len(flows) != 2 || flows[0].ID <= flows[1].ID {
+		t.Fatalf("list flow page result not expected, flows: %+v", flows)
+	}
+}
+
+func TestMemoryRateLimit(t *testing.T) {
+	bd := NewMemory()
+	kt := newTestKit()
+	opt := &AcquireTokenOption{Key: "tcloud/account1/cvm", Rate: 1, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		wait, err := bd.AcquireRateLimitToken(kt, opt)
+		if err != nil {
+			t.Fatalf("acquire token failed, err: %v", err)
+		}
+
+		if wait != 0 {
+			t.Fatalf("acquire token within burst should not wait, wait: %s", wait)
+		}
+	}
+
+	// 令牌桶已经耗尽，需要等待令牌补充。
+	wait, err := bd.AcquireRateLimitToken(kt, opt)
+	if err != nil {
+		t.Fatalf("acquire token failed, err: %v", err)
+	}
+
+	if wait <= 0 || wait > time.Second {
+		t.Fatalf("acquire token from empty bucket should wait (0, 1s], wait: %s", wait)
+	}
+
+	// 不同的令牌桶之间互不影响。
+	other := &AcquireTokenOption{Key: "tcloud/account2/cvm", Rate: 1, Burst: 2}
+	if wait, err = bd.AcquireRateLimitToken(kt, other); err != nil || wait != 0 {
+		t.Fatalf("acquire token from other bucket should success, wait: %s, err: %v", wait, err)
+	}
+}
+
+func TestMemoryLeaderFence(t *testing.T) {
+	bd := NewMemory()
+	kt := newTestKit()
+	id := createTestFlow(t, bd)
+
+	if err := bd.UpdateLeaderFence(kt, &UpdateLeaderFenceOption{Worker: "node1", Token: 10}); err != nil {
+		t.Fatalf("update leader fence failed, err: %v", err)
+	}
+
+	// 新主节点登记了更大的token后，旧主节点不能再登记，也不能再派发任务流。
+	if err := bd.UpdateLeaderFence(kt, &UpdateLeaderFenceOption{Worker: "node2", Token: 20}); err != nil {
+		t.Fatalf("update leader fence failed, err: %v", err)
+	}
+	if err := bd.UpdateLeaderFence(kt, &UpdateLeaderFenceOption{Worker: "node1", Token: 10}); err == nil {
+		t.Fatalf("update leader fence with stale token should failed")
+	}
+
+	stale := []UpdateFlowInfo{{ID: id, Source: enumor.FlowPending, Target: enumor.FlowScheduled, Worker: "node1"}}
+	if err := bd.FencedBatchUpdateFlowStateByCAS(kt, 10, stale); err == nil {
+		t.Fatalf("update flow state by cas with stale fencing token should failed")
+	}
+
+	// 主节点组件的更新必须携带fencing token
+	if err := bd.FencedBatchUpdateFlowStateByCAS(kt, 0, stale); err == nil {
+		t.Fatalf("update flow state by cas without fencing token should failed")
+	}
+	if err := bd.FencedBatchUpdateFlow(kt, 0, []model.Flow{{ID: id, Memo: "stale"}}); err == nil {
+		t.Fatalf("update flow without fencing token should failed")
+	}
+
+	current := []UpdateFlowInfo{{ID: id, Source: enumor.FlowPending, Target: enumor.FlowScheduled, Worker: "node2"}}
+	if err := bd.FencedBatchUpdateFlowStateByCAS(kt, 20, current); err != nil {
+		t.Fatalf("update flow state by cas with current fencing token failed, err: %v", err)
+	}
+
+	flows, err := bd.ListFlow(kt, &ListInput{Filter: tools.EqualExpression("id", id), Page: core.NewDefaultBasePage()})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 1 || flows[0].FencingToken != 20 {
+		t.Errorf("fencing token should be recorded on flow, flows: %+v", flows)
+	}
+
+	if err = bd.FencedUpdateTask(kt, 10, &model.Task{ID: "1", State: enumor.TaskFailed}); err == nil {
+		t.Errorf("update task with stale fencing token should failed")
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package model
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
+)
+
+// CallbackDelivery 任务流回调的投递记录，记录回调的内容和投递结果，投递失败后按照退避时间重试。
+type CallbackDelivery struct {
+	ID        string               `json:"id"`
+	FlowID    string               `json:"flow_id"`
+	FlowState enumor.FlowState     `json:"flow_state"`
+	Callback  *tableasync.Callback `json:"callback"`
+	// Payload 回调发送的事件内容，重新投递时发送相同的内容
+	Payload types.JsonField      `json:"payload"`
+	State   enumor.CallbackState `json:"state"`
+	// Attempts 已经投递的次数
+	Attempts *uint `json:"attempts"`
+	// NextAt 下一次投递的时间
+	NextAt    string             `json:"next_at"`
+	Reason    *tableasync.Reason `json:"reason"`
+	Creator   string             `json:"creator"`
+	Reviser   string             `json:"reviser"`
+	CreatedAt string             `json:"created_at"`
+	UpdatedAt string             `json:"updated_at"`
+}
+
+// CreateValidate CallbackDelivery.
+func (c CallbackDelivery) CreateValidate() error {
+
+	if len(c.ID) != 0 {
+		return errors.New("id can not set")
+	}
+
+	if len(c.FlowID) == 0 {
+		return errors.New("flow_id is required")
+	}
+
+	if len(c.FlowState) == 0 {
+		return errors.New("flow_state is required")
+	}
+
+	if c.Callback == nil {
+		return errors.New("callback is required")
+	}
+
+	if len(c.Payload) == 0 {
+		return errors.New("payload is required")
+	}
+
+	if len(c.NextAt) == 0 {
+		return errors.New("next_at is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package model
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+)
+
+// CronFlow 周期任务流定义，主节点到达NextRunAt时间后，按照定义生成普通任务流。
+type CronFlow struct {
+	Name       enumor.FlowName       `json:"name"`
+	Spec       string                `json:"spec"`
+	ShareData  *tableasync.ShareData `json:"share_data"`
+	Memo       string                `json:"memo"`
+	Priority   int                   `json:"priority"`
+	AccountID  string                `json:"account_id"`
+	Compensate bool                  `json:"compensate"`
+	// Tasks 生成任务流所需的任务定义，只使用ActionID、ActionName、Params、Retry、DependOn字段
+	Tasks []Task `json:"tasks"`
+
+	ID        string `json:"id"`
+	NextRunAt string `json:"next_run_at"`
+	Creator   string `json:"creator"`
+	Reviser   string `json:"reviser"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// CreateValidate CronFlow.
+func (c CronFlow) CreateValidate() error {
+
+	if len(c.ID) != 0 {
+		return errors.New("id can not set")
+	}
+
+	if len(c.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(c.Spec) == 0 {
+		return errors.New("spec is required")
+	}
+
+	if len(c.Tasks) == 0 {
+		return errors.New("tasks is required")
+	}
+
+	if len(c.NextRunAt) == 0 {
+		return errors.New("next_run_at is required")
+	}
+
+	return nil
+}
+
+// BuildFlow 按照周期任务流定义生成在startAt时间开始执行的任务流。
+func (c CronFlow) BuildFlow(startAt string) *Flow {
+	flow := &Flow{
+		Name:       c.Name,
+		ShareData:  c.ShareData,
+		Memo:       c.Memo,
+		StartAt:    startAt,
+		Priority:   c.Priority,
+		AccountID:  c.AccountID,
+		Compensate: c.Compensate,
+		Tasks:      make([]Task, 0, len(c.Tasks)),
+	}
+
+	for _, one := range c.Tasks {
+		flow.Tasks = append(flow.Tasks, Task{
+			FlowName:     c.Name,
+			ActionID:     one.ActionID,
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			ParamsRender: one.ParamsRender,
+			Retry:        one.Retry,
+			DependOn:     one.DependOn,
+			TimeoutSec:   one.TimeoutSec,
+			AllowFailure: one.AllowFailure,
+			TriggerRule:  one.TriggerRule,
+			RunCondition: one.RunCondition,
+		})
+	}
+
+	return flow
+}
@@ -31,6 +31,28 @@ type Flow struct {
 	Name      enumor.FlowName       `json:"name"`
 	ShareData *tableasync.ShareData `json:"share_data"`
 	Memo      string                `json:"memo"`
+	// StartAt 任务流开始执行时间，不设置则立即执行
+	StartAt string `json:"start_at"`
+	// Priority 任务流优先级，值越大越优先派发和调度
+	Priority int `json:"priority"`
+	// AccountID 任务流操作的云账号，用于按账号限制同时执行的任务流数量
+	AccountID string `json:"account_id"`
+	// Compensate 任务流执行失败后，是否按照任务依赖的逆序对执行成功的任务进行补偿
+	Compensate bool `json:"compensate"`
+	// ParentFlowID 子任务流所属的父任务流ID
+	ParentFlowID string `json:"parent_flow_id"`
+	// ParentTaskID 创建子任务流的父任务流中的任务ID
+	ParentTaskID string `json:"parent_task_id"`
+	// Callbacks 任务流执行结束后的回调订阅
+	Callbacks tableasync.Callbacks `json:"callbacks"`
+	// CallbackPending 任务流结束后是否还需要生成回调投递记录
+	CallbackPending *bool `json:"callback_pending"`
+	// IdempotencyKey 任务流提交的幂等键
+	IdempotencyKey string `json:"idempotency_key"`
+	// PayloadDigest 提交任务流时请求内容的摘要
+	PayloadDigest string `json:"payload_digest"`
+	// FencingToken 主节点组件最近一次更新任务流时所在任期的fencing token
+	FencingToken int64 `json:"fencing_token"`
 
 	ID        string             `json:"id"`
 	State     enumor.FlowState   `json:"state"`
@@ -30,21 +30,29 @@ import (
 
 // Task define task struct.
 type Task struct {
-	ID         string             `json:"id"`
-	FlowID     string             `json:"flow_id"`
-	FlowName   enumor.FlowName    `json:"flow_name"`
-	ActionID   action.ActIDType   `json:"action_id"`
-	ActionName enumor.ActionName  `json:"action_name"`
-	Params     types.JsonField    `json:"params"`
-	Retry      *tableasync.Retry  `json:"can_retry"`
-	DependOn   []action.ActIDType `json:"depend_on"`
-	State      enumor.TaskState   `json:"state"`
-	Reason     *tableasync.Reason `json:"reason"`
-	Result     types.JsonField    `json:"result"`
-	Creator    string             `json:"creator"`
-	Reviser    string             `json:"reviser"`
-	CreatedAt  string             `json:"created_at"`
-	UpdatedAt  string             `json:"updated_at"`
+	ID           string                   `json:"id"`
+	FlowID       string                   `json:"flow_id"`
+	FlowName     enumor.FlowName          `json:"flow_name"`
+	ActionID     action.ActIDType         `json:"action_id"`
+	ActionName   enumor.ActionName        `json:"action_name"`
+	Params       types.JsonField          `json:"params"`
+	ParamsRender bool                     `json:"params_render"`
+	Retry        *tableasync.Retry        `json:"can_retry"`
+	DependOn     []action.ActIDType       `json:"depend_on"`
+	TimeoutSec   uint                     `json:"timeout_sec"`
+	AllowFailure bool                     `json:"allow_failure"`
+	TriggerRule  enumor.TaskTriggerRule   `json:"trigger_rule"`
+	RunCondition *tableasync.RunCondition `json:"run_condition"`
+	ParentID     string                   `json:"parent_id"`
+	State        enumor.TaskState         `json:"state"`
+	Reason       *tableasync.Reason       `json:"reason"`
+	Result       types.JsonField          `json:"result"`
+	Logs         tableasync.TaskLogs      `json:"logs"`
+	RetryState   *tableasync.RetryState   `json:"retry_state"`
+	Creator      string                   `json:"creator"`
+	Reviser      string                   `json:"reviser"`
+	CreatedAt    string                   `json:"created_at"`
+	UpdatedAt    string                   `json:"updated_at"`
 }
 
 // CreateValidate Task create validate.
@@ -22,20 +22,25 @@ package backend
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
 	"hcm/pkg/async/action"
 	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/dao/types/async"
 	tableasync "hcm/pkg/dal/table/async"
 	tabletypes "hcm/pkg/dal/table/types"
 	"hcm/pkg/kit"
 	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
 )
 
 // NewMysql create mysql instance
@@ -60,20 +65,7 @@ func (db *mysql) BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo)
 	}
 
 	_, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
-		for _, one := range infos {
-			info := &typesasync.UpdateFlowInfo{
-				ID:     one.ID,
-				Source: one.Source,
-				Target: one.Target,
-				Reason: one.Reason,
-				Worker: one.Worker,
-			}
-			if err := db.dao.AsyncFlow().UpdateStateByCAS(kt, txn, info); err != nil {
-				return nil, err
-			}
-		}
-
-		return nil, nil
+		return nil, db.batchUpdateFlowStateByCASWithTx(kt, txn, infos)
 	})
 	if err != nil {
 		return err
@@ -82,6 +74,24 @@ func (db *mysql) BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo)
 	return nil
 }
 
+func (db *mysql) batchUpdateFlowStateByCASWithTx(kt *kit.Kit, txn *sqlx.Tx, infos []UpdateFlowInfo) error {
+	for _, one := range infos {
+		info := &typesasync.UpdateFlowInfo{
+			ID:           one.ID,
+			Source:       one.Source,
+			Target:       one.Target,
+			Reason:       one.Reason,
+			Worker:       one.Worker,
+			FencingToken: one.FencingToken,
+		}
+		if err := db.dao.AsyncFlow().UpdateStateByCAS(kt, txn, info); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // UpdateTaskStateByCAS CAS更新任务状态
 func (db *mysql) UpdateTaskStateByCAS(kt *kit.Kit, info *UpdateTaskInfo) error {
 	update := &typesasync.UpdateTaskInfo{
@@ -93,22 +103,47 @@ func (db *mysql) UpdateTaskStateByCAS(kt *kit.Kit, info *UpdateTaskInfo) error {
 	return db.dao.AsyncFlowTask().UpdateStateByCAS(kt, update)
 }
 
+// UpdateTaskResultByCAS CAS更新任务执行结果
+func (db *mysql) UpdateTaskResultByCAS(kt *kit.Kit, info *UpdateTaskResultInfo) error {
+	return db.dao.AsyncFlowTask().UpdateResultByCAS(kt, (*typesasync.UpdateTaskResultInfo)(info))
+}
+
 var _ Backend = new(mysql)
 
 // CreateFlow 创建任务流
 func (db *mysql) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
 
+	startAt, err := parseStartAt(flow.StartAt)
+	if err != nil {
+		return "", err
+	}
+
 	result, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		// 创建任务流
 		md := &tableasync.AsyncFlowTable{
-			Name:      flow.Name,
-			State:     enumor.FlowPending,
-			Reason:    new(tableasync.Reason),
-			ShareData: flow.ShareData,
-			Memo:      flow.Memo,
-			Worker:    converter.ValToPtr(""),
-			Creator:   kt.User,
-			Reviser:   kt.User,
+			Name:         flow.Name,
+			State:        enumor.FlowPending,
+			Reason:       new(tableasync.Reason),
+			ShareData:    flow.ShareData,
+			Memo:         flow.Memo,
+			Worker:       converter.ValToPtr(""),
+			StartAt:      startAt,
+			Priority:     flow.Priority,
+			AccountID:    flow.AccountID,
+			Compensate:   converter.ValToPtr(flow.Compensate),
+			ParentFlowID: flow.ParentFlowID,
+			ParentTaskID: flow.ParentTaskID,
+			Callbacks:    make(tableasync.Callbacks, 0),
+			// 订阅了回调的任务流，执行结束后需要生成回调投递记录
+			CallbackPending: converter.ValToPtr(len(flow.Callbacks) != 0),
+			PayloadDigest:   flow.PayloadDigest,
+			Creator:         kt.User,
+			Reviser:         kt.User,
+		}
+		md.Callbacks = append(md.Callbacks, flow.Callbacks...)
+		// 幂等键存在唯一索引，未设置时保存为NULL
+		if len(flow.IdempotencyKey) != 0 {
+			md.IdempotencyKey = converter.ValToPtr(flow.IdempotencyKey)
 		}
 		flowID, err := db.dao.AsyncFlow().Create(kt, txn, md)
 		if err != nil {
@@ -120,17 +155,22 @@ func (db *mysql) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
 		mds := make([]tableasync.AsyncFlowTaskTable, 0, len(tasks))
 		for _, one := range tasks {
 			mds = append(mds, tableasync.AsyncFlowTaskTable{
-				FlowID:     flowID,
-				FlowName:   one.FlowName,
-				ActionID:   string(one.ActionID),
-				ActionName: one.ActionName,
-				Params:     one.Params,
-				Retry:      one.Retry,
-				DependOn:   dependOnToStringArray(one.DependOn),
-				State:      enumor.TaskPending,
-				Reason:     new(tableasync.Reason),
-				Creator:    kt.User,
-				Reviser:    kt.User,
+				FlowID:       flowID,
+				FlowName:     one.FlowName,
+				ActionID:     string(one.ActionID),
+				ActionName:   one.ActionName,
+				Params:       one.Params,
+				ParamsRender: converter.ValToPtr(one.ParamsRender),
+				Retry:        one.Retry,
+				DependOn:     dependOnToStringArray(one.DependOn),
+				TimeoutSec:   one.TimeoutSec,
+				AllowFailure: converter.ValToPtr(one.AllowFailure),
+				TriggerRule:  one.TriggerRule,
+				RunCondition: one.RunCondition,
+				State:        enumor.TaskPending,
+				Reason:       new(tableasync.Reason),
+				Creator:      kt.User,
+				Reviser:      kt.User,
 			})
 		}
 		if _, err = db.dao.AsyncFlowTask().BatchCreateWithTx(kt, txn, mds); err != nil {
@@ -155,28 +195,107 @@ func (db *mysql) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
 func (db *mysql) BatchUpdateFlow(kt *kit.Kit, flows []model.Flow) error {
 
 	_, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
-		for _, one := range flows {
-			md := &tableasync.AsyncFlowTable{
-				State:     one.State,
-				Reason:    one.Reason,
-				ShareData: one.ShareData,
-				Memo:      one.Memo,
-				Worker:    one.Worker,
-				Reviser:   one.Reviser,
+		return nil, db.batchUpdateFlowWithTx(kt, txn, flows)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *mysql) batchUpdateFlowWithTx(kt *kit.Kit, txn *sqlx.Tx, flows []model.Flow) error {
+	for _, one := range flows {
+		md := &tableasync.AsyncFlowTable{
+			State:           one.State,
+			Reason:          one.Reason,
+			ShareData:       one.ShareData,
+			Memo:            one.Memo,
+			Worker:          one.Worker,
+			CallbackPending: one.CallbackPending,
+			FencingToken:    one.FencingToken,
+			Reviser:         one.Reviser,
+		}
+
+		if err := db.dao.AsyncFlow().UpdateByIDWithTx(kt, txn, one.ID, md); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PurgeFlows 清理任务流及其任务、回调投递记录，archive为true时先归档到归档表。事务中先锁定仍满足清理条件的任务流，
+// 归档和删除都基于锁定的任务流进行，避免查询后被重试或恢复的任务流被清理。
+func (db *mysql) PurgeFlows(kt *kit.Kit, input *PurgeFlowsInput) ([]string, error) {
+	if input == nil || len(input.FlowIDs) == 0 {
+		return nil, nil
+	}
+
+	if input.Filter == nil {
+		return nil, errf.New(errf.InvalidParameter, "purge flows filter is required")
+	}
+
+	expr, err := tools.And(tools.ContainersExpression("id", input.FlowIDs), input.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := db.dao.AsyncLeaderFence().CheckWithTx(kt, txn, input.FencingToken); err != nil {
+			return nil, err
+		}
+
+		flowIDs, err := db.dao.AsyncFlow().ListIDForUpdateWithTx(kt, txn, expr)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(flowIDs) == 0 {
+			return flowIDs, nil
+		}
+
+		lockedExpr, err := tools.And(tools.ContainersExpression("id", flowIDs), input.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if input.Archive {
+			if err = db.dao.AsyncArchive().ArchiveFlowWithTx(kt, txn, lockedExpr); err != nil {
+				return nil, err
 			}
 
-			if err := db.dao.AsyncFlow().UpdateByIDWithTx(kt, txn, one.ID, md); err != nil {
+			if err = db.dao.AsyncArchive().ArchiveTaskWithTx(kt, txn, flowIDs); err != nil {
 				return nil, err
 			}
 		}
 
-		return nil, nil
+		if err = db.dao.AsyncFlowTask().DeleteWithTx(kt, txn,
+			tools.ContainersExpression("flow_id", flowIDs)); err != nil {
+			return nil, err
+		}
+
+		if err = db.dao.AsyncFlowCallback().DeleteWithTx(kt, txn,
+			tools.ContainersExpression("flow_id", flowIDs)); err != nil {
+			return nil, err
+		}
+
+		if err = db.dao.AsyncFlow().DeleteWithTx(kt, txn, lockedExpr); err != nil {
+			return nil, err
+		}
+
+		return flowIDs, nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	flowIDs, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("purge flows result type: %T not []string", result)
+	}
+
+	return flowIDs, nil
 }
 
 // ListFlow 查询任务流
@@ -195,17 +314,27 @@ func (db *mysql) ListFlow(kt *kit.Kit, input *ListInput) ([]model.Flow, error) {
 	flows := make([]model.Flow, 0, len(list.Details))
 	for _, one := range list.Details {
 		flows = append(flows, model.Flow{
-			ID:        one.ID,
-			Name:      one.Name,
-			State:     one.State,
-			Reason:    one.Reason,
-			ShareData: one.ShareData,
-			Memo:      one.Memo,
-			Worker:    one.Worker,
-			Creator:   one.Creator,
-			Reviser:   one.Reviser,
-			CreatedAt: one.CreatedAt.String(),
-			UpdatedAt: one.UpdatedAt.String(),
+			ID:              one.ID,
+			Name:            one.Name,
+			State:           one.State,
+			Reason:          one.Reason,
+			ShareData:       one.ShareData,
+			Memo:            one.Memo,
+			Worker:          one.Worker,
+			StartAt:         times.ConvStdTimeFormat(one.StartAt),
+			Priority:        one.Priority,
+			AccountID:       one.AccountID,
+			Compensate:      converter.PtrToVal(one.Compensate),
+			ParentFlowID:    one.ParentFlowID,
+			ParentTaskID:    one.ParentTaskID,
+			Callbacks:       one.Callbacks,
+			CallbackPending: one.CallbackPending,
+			IdempotencyKey:  converter.PtrToVal(one.IdempotencyKey),
+			PayloadDigest:   one.PayloadDigest,
+			Creator:         one.Creator,
+			Reviser:         one.Reviser,
+			CreatedAt:       one.CreatedAt.String(),
+			UpdatedAt:       one.UpdatedAt.String(),
 		})
 	}
 
@@ -218,17 +347,23 @@ func (db *mysql) BatchCreateTask(kt *kit.Kit, tasks []model.Task) ([]string, err
 	mds := make([]tableasync.AsyncFlowTaskTable, 0, len(tasks))
 	for _, one := range tasks {
 		mds = append(mds, tableasync.AsyncFlowTaskTable{
-			FlowID:     one.FlowID,
-			FlowName:   one.FlowName,
-			ActionID:   string(one.ActionID),
-			ActionName: one.ActionName,
-			Params:     one.Params,
-			Retry:      one.Retry,
-			DependOn:   dependOnToStringArray(one.DependOn),
-			State:      enumor.TaskPending,
-			Reason:     one.Reason,
-			Creator:    one.Creator,
-			Reviser:    one.Reviser,
+			FlowID:       one.FlowID,
+			FlowName:     one.FlowName,
+			ActionID:     string(one.ActionID),
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			ParamsRender: converter.ValToPtr(one.ParamsRender),
+			Retry:        one.Retry,
+			DependOn:     dependOnToStringArray(one.DependOn),
+			TimeoutSec:   one.TimeoutSec,
+			AllowFailure: converter.ValToPtr(one.AllowFailure),
+			TriggerRule:  one.TriggerRule,
+			RunCondition: one.RunCondition,
+			ParentID:     one.ParentID,
+			State:        enumor.TaskPending,
+			Reason:       one.Reason,
+			Creator:      one.Creator,
+			Reviser:      one.Reviser,
 		})
 	}
 
@@ -237,17 +372,20 @@ func (db *mysql) BatchCreateTask(kt *kit.Kit, tasks []model.Task) ([]string, err
 
 // UpdateTask 更新任务
 func (db *mysql) UpdateTask(kt *kit.Kit, task *model.Task) error {
+	return db.dao.AsyncFlowTask().UpdateByID(kt, task.ID, convTaskToUpdate(kt, task))
+}
 
-	md := &tableasync.AsyncFlowTaskTable{
-		Retry:    task.Retry,
-		DependOn: dependOnToStringArray(task.DependOn),
-		State:    task.State,
-		Result:   task.Result,
-		Reason:   task.Reason,
-		Reviser:  kt.User,
+func convTaskToUpdate(kt *kit.Kit, task *model.Task) *tableasync.AsyncFlowTaskTable {
+	return &tableasync.AsyncFlowTaskTable{
+		Retry:      task.Retry,
+		DependOn:   dependOnToStringArray(task.DependOn),
+		State:      task.State,
+		Result:     task.Result,
+		Reason:     task.Reason,
+		Logs:       task.Logs,
+		RetryState: task.RetryState,
+		Reviser:    kt.User,
 	}
-
-	return db.dao.AsyncFlowTask().UpdateByID(kt, task.ID, md)
 }
 
 // ListTask 查询任务
@@ -266,17 +404,119 @@ func (db *mysql) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
 	tasks := make([]model.Task, 0, len(list.Details))
 	for _, one := range list.Details {
 		tasks = append(tasks, model.Task{
+			ID:           one.ID,
+			FlowID:       one.FlowID,
+			FlowName:     one.FlowName,
+			ActionID:     action.ActIDType(one.ActionID),
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			ParamsRender: converter.PtrToVal(one.ParamsRender),
+			Retry:        one.Retry,
+			DependOn:     dependOnToActIDArray(one.DependOn),
+			TimeoutSec:   one.TimeoutSec,
+			AllowFailure: converter.PtrToVal(one.AllowFailure),
+			TriggerRule:  one.TriggerRule,
+			RunCondition: one.RunCondition,
+			ParentID:     one.ParentID,
+			State:        one.State,
+			Reason:       one.Reason,
+			Result:       one.Result,
+			Logs:         one.Logs,
+			RetryState:   one.RetryState,
+			Creator:      one.Creator,
+			Reviser:      one.Reviser,
+			CreatedAt:    one.CreatedAt.String(),
+			UpdatedAt:    one.UpdatedAt.String(),
+		})
+	}
+
+	return tasks, nil
+}
+
+// CreateCronFlow 创建周期任务流
+func (db *mysql) CreateCronFlow(kt *kit.Kit, cron *model.CronFlow) (string, error) {
+
+	nextRunAt, err := time.Parse(constant.TimeStdFormat, cron.NextRunAt)
+	if err != nil {
+		return "", fmt.Errorf("parse next_run_at: %s failed, err: %v", cron.NextRunAt, err)
+	}
+
+	tasks := make(tableasync.CronFlowTasks, 0, len(cron.Tasks))
+	for _, one := range cron.Tasks {
+		tasks = append(tasks, tableasync.CronFlowTask{
+			ActionID:     string(one.ActionID),
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			ParamsRender: one.ParamsRender,
+			Retry:        one.Retry,
+			DependOn:     dependOnToStringArray(one.DependOn),
+			TimeoutSec:   one.TimeoutSec,
+			AllowFailure: one.AllowFailure,
+			TriggerRule:  one.TriggerRule,
+			RunCondition: one.RunCondition,
+		})
+	}
+
+	md := &tableasync.AsyncFlowCronTable{
+		Name:       cron.Name,
+		Spec:       cron.Spec,
+		ShareData:  cron.ShareData,
+		Tasks:      tasks,
+		Memo:       cron.Memo,
+		NextRunAt:  nextRunAt,
+		Priority:   cron.Priority,
+		AccountID:  cron.AccountID,
+		Compensate: converter.ValToPtr(cron.Compensate),
+		Creator:    kt.User,
+		Reviser:    kt.User,
+	}
+
+	return db.dao.AsyncFlowCron().Create(kt, md)
+}
+
+// ListCronFlow 查询周期任务流
+func (db *mysql) ListCronFlow(kt *kit.Kit, input *ListInput) ([]model.CronFlow, error) {
+
+	opt := &types.ListOption{
+		Fields: input.Fields,
+		Filter: input.Filter,
+		Page:   input.Page,
+	}
+	list, err := db.dao.AsyncFlowCron().List(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	crons := make([]model.CronFlow, 0, len(list.Details))
+	for _, one := range list.Details {
+		tasks := make([]model.Task, 0, len(one.Tasks))
+		for _, task := range one.Tasks {
+			tasks = append(tasks, model.Task{
+				FlowName:     one.Name,
+				ActionID:     action.ActIDType(task.ActionID),
+				ActionName:   task.ActionName,
+				Params:       task.Params,
+				ParamsRender: task.ParamsRender,
+				Retry:        task.Retry,
+				DependOn:     dependOnToActIDArray(task.DependOn),
+				TimeoutSec:   task.TimeoutSec,
+				AllowFailure: task.AllowFailure,
+				TriggerRule:  task.TriggerRule,
+				RunCondition: task.RunCondition,
+			})
+		}
+
+		crons = append(crons, model.CronFlow{
 			ID:         one.ID,
-			FlowID:     one.FlowID,
-			FlowName:   one.FlowName,
-			ActionID:   action.ActIDType(one.ActionID),
-			ActionName: one.ActionName,
-			Params:     one.Params,
-			Retry:      one.Retry,
-			DependOn:   dependOnToActIDArray(one.DependOn),
-			State:      one.State,
-			Reason:     one.Reason,
-			Result:     one.Result,
+			Name:       one.Name,
+			Spec:       one.Spec,
+			ShareData:  one.ShareData,
+			Tasks:      tasks,
+			Memo:       one.Memo,
+			NextRunAt:  times.ConvStdTimeFormat(one.NextRunAt),
+			Priority:   one.Priority,
+			AccountID:  one.AccountID,
+			Compensate: converter.PtrToVal(one.Compensate),
 			Creator:    one.Creator,
 			Reviser:    one.Reviser,
 			CreatedAt:  one.CreatedAt.String(),
@@ -284,7 +524,202 @@ func (db *mysql) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
 		})
 	}
 
-	return tasks, nil
+	return crons, nil
+}
+
+// UpdateCronNextRunByCAS CAS更新周期任务流下次执行时间
+func (db *mysql) UpdateCronNextRunByCAS(kt *kit.Kit, info *UpdateCronNextRunInfo) error {
+	return db.dao.AsyncFlowCron().UpdateNextRunByCAS(kt, (*typesasync.UpdateCronNextRunInfo)(info))
+}
+
+// DeleteCronFlow 删除周期任务流
+func (db *mysql) DeleteCronFlow(kt *kit.Kit, id string) error {
+	return db.dao.AsyncFlowCron().Delete(kt, tools.EqualExpression("id", id))
+}
+
+// BatchCreateCallbackDelivery 批量创建回调投递记录
+func (db *mysql) BatchCreateCallbackDelivery(kt *kit.Kit, deliveries []model.CallbackDelivery) ([]string, error) {
+
+	mds := make([]tableasync.AsyncFlowCallbackTable, 0, len(deliveries))
+	for _, one := range deliveries {
+		if err := one.CreateValidate(); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		nextAt, err := time.Parse(constant.TimeStdFormat, one.NextAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse next_at: %s failed, err: %v", one.NextAt, err)
+		}
+
+		mds = append(mds, tableasync.AsyncFlowCallbackTable{
+			FlowID:    one.FlowID,
+			FlowState: one.FlowState,
+			Callback:  one.Callback,
+			Payload:   one.Payload,
+			State:     enumor.CallbackPending,
+			Attempts:  converter.ValToPtr(uint(0)),
+			NextAt:    nextAt,
+			Reason:    new(tableasync.Reason),
+			Creator:   kt.User,
+			Reviser:   kt.User,
+		})
+	}
+
+	return db.dao.AsyncFlowCallback().BatchCreate(kt, mds)
+}
+
+// ListCallbackDelivery 查询回调投递记录
+func (db *mysql) ListCallbackDelivery(kt *kit.Kit, input *ListInput) ([]model.CallbackDelivery, error) {
+
+	opt := &types.ListOption{
+		Fields: input.Fields,
+		Filter: input.Filter,
+		Page:   input.Page,
+	}
+	list, err := db.dao.AsyncFlowCallback().List(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]model.CallbackDelivery, 0, len(list.Details))
+	for _, one := range list.Details {
+		deliveries = append(deliveries, model.CallbackDelivery{
+			ID:        one.ID,
+			FlowID:    one.FlowID,
+			FlowState: one.FlowState,
+			Callback:  one.Callback,
+			Payload:   one.Payload,
+			State:     one.State,
+			Attempts:  one.Attempts,
+			NextAt:    times.ConvStdTimeFormat(one.NextAt),
+			Reason:    one.Reason,
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		})
+	}
+
+	return deliveries, nil
+}
+
+// UpdateCallbackDelivery 更新回调投递记录
+func (db *mysql) UpdateCallbackDelivery(kt *kit.Kit, delivery *model.CallbackDelivery) error {
+	md, err := convDeliveryToUpdate(kt, delivery)
+	if err != nil {
+		return err
+	}
+
+	return db.dao.AsyncFlowCallback().UpdateByID(kt, delivery.ID, md)
+}
+
+func convDeliveryToUpdate(kt *kit.Kit, delivery *model.CallbackDelivery) (*tableasync.AsyncFlowCallbackTable, error) {
+	md := &tableasync.AsyncFlowCallbackTable{
+		State:    delivery.State,
+		Attempts: delivery.Attempts,
+		Reason:   delivery.Reason,
+		Reviser:  kt.User,
+	}
+
+	if len(delivery.NextAt) != 0 {
+		nextAt, err := time.Parse(constant.TimeStdFormat, delivery.NextAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse next_at: %s failed, err: %v", delivery.NextAt, err)
+		}
+		md.NextAt = nextAt
+	}
+
+	return md, nil
+}
+
+// AcquireRateLimitToken 从所有节点共享的令牌桶中获取一个令牌，获取成功时返回0，令牌不足时返回需要等待的时间
+func (db *mysql) AcquireRateLimitToken(kt *kit.Kit, opt *AcquireTokenOption) (time.Duration, error) {
+	return db.dao.AsyncRateLimit().Acquire(kt, (*typesasync.AcquireTokenOption)(opt))
+}
+
+// UpdateLeaderFence 登记主节点当前任期的fencing token，已经存在更大的token时返回错误
+func (db *mysql) UpdateLeaderFence(kt *kit.Kit, opt *UpdateLeaderFenceOption) error {
+	return db.dao.AsyncLeaderFence().Update(kt, (*typesasync.UpdateLeaderFenceOption)(opt))
+}
+
+// fencedTxn 在同一事务中先校验主节点组件携带的fencing token，再执行更新。校验时对登记记录加共享锁，
+// 新主节点登记token需要等待事务结束，登记后旧主节点的更新全部失败。
+func (db *mysql) fencedTxn(kt *kit.Kit, token int64, do func(txn *sqlx.Tx) error) error {
+	_, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := db.dao.AsyncLeaderFence().CheckWithTx(kt, txn, token); err != nil {
+			return nil, err
+		}
+
+		return nil, do(txn)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FencedBatchUpdateFlowStateByCAS 主节点组件携带任期的fencing token CAS批量更新Flow状态
+func (db *mysql) FencedBatchUpdateFlowStateByCAS(kt *kit.Kit, token int64, infos []UpdateFlowInfo) error {
+	fenced := make([]UpdateFlowInfo, 0, len(infos))
+	for _, one := range infos {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+
+		one.FencingToken = token
+		fenced = append(fenced, one)
+	}
+
+	return db.fencedTxn(kt, token, func(txn *sqlx.Tx) error {
+		return db.batchUpdateFlowStateByCASWithTx(kt, txn, fenced)
+	})
+}
+
+// FencedBatchUpdateFlow 主节点组件携带任期的fencing token批量更新任务流
+func (db *mysql) FencedBatchUpdateFlow(kt *kit.Kit, token int64, flows []model.Flow) error {
+	fenced := make([]model.Flow, 0, len(flows))
+	for _, one := range flows {
+		one.FencingToken = token
+		fenced = append(fenced, one)
+	}
+
+	return db.fencedTxn(kt, token, func(txn *sqlx.Tx) error {
+		return db.batchUpdateFlowWithTx(kt, txn, fenced)
+	})
+}
+
+// FencedUpdateTask 主节点组件携带任期的fencing token更新任务
+func (db *mysql) FencedUpdateTask(kt *kit.Kit, token int64, task *model.Task) error {
+	return db.fencedTxn(kt, token, func(txn *sqlx.Tx) error {
+		return db.dao.AsyncFlowTask().UpdateByIDWithTx(kt, txn, task.ID, convTaskToUpdate(kt, task))
+	})
+}
+
+// FencedUpdateCallbackDelivery 主节点组件携带任期的fencing token更新回调投递记录
+func (db *mysql) FencedUpdateCallbackDelivery(kt *kit.Kit, token int64, delivery *model.CallbackDelivery) error {
+	md, err := convDeliveryToUpdate(kt, delivery)
+	if err != nil {
+		return err
+	}
+
+	return db.fencedTxn(kt, token, func(txn *sqlx.Tx) error {
+		return db.dao.AsyncFlowCallback().UpdateByIDWithTx(kt, txn, delivery.ID, md)
+	})
+}
+
+// parseStartAt 解析任务流开始执行时间，未设置时立即执行
+func parseStartAt(startAt string) (time.Time, error) {
+	if len(startAt) == 0 {
+		return times.ConvStdTimeNow(), nil
+	}
+
+	t, err := time.Parse(constant.TimeStdFormat, startAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse start_at: %s failed, err: %v", startAt, err)
+	}
+
+	return t, nil
 }
 
 func dependOnToStringArray(d []action.ActIDType) tabletypes.StringArray {
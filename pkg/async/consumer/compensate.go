@@ -0,0 +1,159 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+/*
+任务流补偿（Saga）:
+	1. 开启补偿的任务流执行失败后，状态由 running 更新为 compensating，而不是 failed。
+	2. 执行任务流的节点的调度器获取 compensating 状态的任务流，按照任务依赖的逆拓扑序，对执行成功的任务调用补偿操作。
+	3. 全部任务补偿成功后，任务流状态更新为 compensated，否则更新为 compensate_failed，可以通过重试接口重新补偿。
+*/
+
+// getFlowFailedState 获取任务流执行失败后的目标状态，开启补偿的任务流进入补偿中状态。
+func getFlowFailedState(flow model.Flow) enumor.FlowState {
+	if flow.Compensate {
+		return enumor.FlowCompensating
+	}
+
+	return enumor.FlowFailed
+}
+
+// watchCompensatingFlow 查询分配给当前节点处于补偿中状态的任务流，并进行补偿。
+func (sch *scheduler) watchCompensatingFlow(kt *kit.Kit) error {
+	input := &backend.ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "state",
+					Op:    filter.Equal.Factory(),
+					Value: enumor.FlowCompensating,
+				},
+				&filter.AtomRule{
+					Field: "worker",
+					Op:    filter.Equal.Factory(),
+					Value: sch.leader.CurrNode(),
+				},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: listScheduledFlowLimit,
+		},
+	}
+	flows, err := sch.backend.ListFlow(kt, input)
+	if err != nil {
+		logs.Errorf("list compensating flows failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	for _, flow := range flows {
+		subKit := kt.NewSubKit()
+		if err = compensateFlow(subKit, sch.backend, flow); err != nil {
+			logs.Errorf("compensate flow failed, err: %v, flow: %s, rid: %s", err, flow.ID, subKit.Rid)
+		}
+	}
+
+	return nil
+}
+
+// compensateFlow 按照任务依赖的逆拓扑序，对执行成功的任务进行补偿，已经补偿过的任务不会重复补偿。
+func compensateFlow(kt *kit.Kit, bd backend.Backend, flow model.Flow) error {
+	tasks, err := listTaskByFlowID(kt, bd, flow.ID)
+	if err != nil {
+		return err
+	}
+
+	root, err := BuildTaskRoot(tasks)
+	if err != nil {
+		if stateErr := updateFlowStateAndReason(kt, bd, flow.ID, enumor.FlowCompensating,
+			enumor.FlowCompensateFailed, err.Error()); stateErr != nil {
+
+			logs.Errorf("update flow state and reason failed, after build task root failed, err: %v, rid: %s",
+				stateErr, kt.Rid)
+		}
+
+		return err
+	}
+
+	taskIDMap := make(map[string]*Task, len(tasks))
+	for _, one := range tasks {
+		taskIDMap[one.ID] = one
+	}
+
+	// 设置共享数据更新函数
+	if flow.ShareData != nil {
+		flow.ShareData.Save = func(kt *kit.Kit, data *tableasync.ShareData) error {
+			return bd.BatchUpdateFlow(kt, []model.Flow{{ID: flow.ID, ShareData: data}})
+		}
+	}
+
+	for _, id := range root.GetCompensateTasks() {
+		if err = compensateTask(kt, bd, flow, taskIDMap[id]); err != nil {
+			logs.Errorf("compensate task failed, err: %v, task: %s, rid: %s", err, id, kt.Rid)
+
+			reason := fmt.Sprintf("compensate task: %s failed, err: %v", id, err)
+			if stateErr := updateFlowStateAndReason(kt, bd, flow.ID, enumor.FlowCompensating,
+				enumor.FlowCompensateFailed, reason); stateErr != nil {
+
+				logs.Errorf("update flow state to %s failed, err: %v, rid: %s", enumor.FlowCompensateFailed,
+					stateErr, kt.Rid)
+			}
+
+			return err
+		}
+	}
+
+	if err = updateFlowState(kt, bd, flow.ID, enumor.FlowCompensating, enumor.FlowCompensated); err != nil {
+		logs.Errorf("update flow state to %s failed, err: %v, rid: %s", enumor.FlowCompensated, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// compensateTask 补偿单个任务，任务设置了执行超时时间时，补偿操作同样受该超时时间控制。
+func compensateTask(kt *kit.Kit, bd backend.Backend, flow model.Flow, task *Task) error {
+	if task.TimeoutSec != 0 {
+		cancel := task.Kit.CtxWithTimeoutMS(int(task.TimeoutSec) * 1000)
+		defer cancel()
+	}
+
+	ekt := run.NewExecuteContext(task.Kit, flow.ShareData, flow.ID, task.ID)
+	task.InitDep(ekt, func(kt *kit.Kit, task *model.Task) error {
+		return bd.UpdateTask(kt, task)
+	}, &Flow{Flow: flow, Kit: kt})
+
+	return task.Compensate()
+}
@@ -0,0 +1,168 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+)
+
+// compensateTestAction 记录补偿顺序的测试Action，使用任务执行结果标识任务。
+type compensateTestAction struct {
+	compensated *[]string
+}
+
+// Name ...
+func (act compensateTestAction) Name() enumor.ActionName {
+	return enumor.ActionProduceTest
+}
+
+// Run ...
+func (act compensateTestAction) Run(_ run.ExecuteKit, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+// Compensate ...
+func (act compensateTestAction) Compensate(_ run.ExecuteKit, _ interface{}, result types.JsonField) error {
+	*act.compensated = append(*act.compensated, string(result))
+	return nil
+}
+
+func TestGetCompensateTasks(t *testing.T) {
+	// a -> b -> d, a -> c -> d
+	tasks := []*Task{
+		{Task: model.Task{ID: "a", ActionID: "a", State: enumor.TaskSuccess}},
+		{Task: model.Task{ID: "b", ActionID: "b", State: enumor.TaskSuccess, DependOn: []action.ActIDType{"a"}}},
+		{Task: model.Task{ID: "c", ActionID: "c", State: enumor.TaskSuccess, DependOn: []action.ActIDType{"a"}}},
+		{Task: model.Task{ID: "d", ActionID: "d", State: enumor.TaskFailed,
+			DependOn: []action.ActIDType{"b", "c"}}},
+	}
+	root, err := BuildTaskRoot(tasks)
+	if err != nil {
+		t.Fatalf("build task root failed, err: %v", err)
+	}
+
+	ids := root.GetCompensateTasks()
+	if len(ids) != 3 || ids[2] != "a" {
+		t.Errorf("compensate tasks should be [c b a] or [b c a], but got %v", ids)
+	}
+}
+
+func TestCompensateFlow(t *testing.T) {
+	compensated := make([]string, 0)
+	action.RegisterAction(compensateTestAction{compensated: &compensated})
+
+	kt := NewKit()
+	bd := backend.NewMemory()
+	flowID, err := bd.CreateFlow(kt, &model.Flow{
+		Name:       "test_flow",
+		Compensate: true,
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: enumor.ActionProduceTest},
+			{FlowName: "test_flow", ActionID: "2", ActionName: enumor.ActionProduceTest,
+				DependOn: []action.ActIDType{"1"}},
+			{FlowName: "test_flow", ActionID: "3", ActionName: enumor.ActionProduceTest,
+				DependOn: []action.ActIDType{"2"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	states := map[action.ActIDType]enumor.TaskState{"1": enumor.TaskSuccess, "2": enumor.TaskSuccess,
+		"3": enumor.TaskFailed}
+	for _, one := range tasks {
+		md := &model.Task{ID: one.ID, State: states[one.ActionID], Result: types.JsonField(one.ActionID)}
+		if err = bd.UpdateTask(kt, md); err != nil {
+			t.Fatalf("update task failed, err: %v", err)
+		}
+	}
+
+	if err = bd.BatchUpdateFlow(kt, []model.Flow{{ID: flowID, State: enumor.FlowCompensating}}); err != nil {
+		t.Fatalf("update flow failed, err: %v", err)
+	}
+
+	flow, err := getTestFlow(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("get flow failed, err: %v", err)
+	}
+
+	if getFlowFailedState(flow) != enumor.FlowCompensating {
+		t.Errorf("compensate flow failed state should be compensating")
+	}
+
+	if err = compensateFlow(kt, bd, flow); err != nil {
+		t.Fatalf("compensate flow failed, err: %v", err)
+	}
+
+	if !reflect.DeepEqual(compensated, []string{"2", "1"}) {
+		t.Errorf("tasks should be compensated in reverse order [2 1], but got %v", compensated)
+	}
+
+	if flow, err = getTestFlow(kt, bd, flowID); err != nil {
+		t.Fatalf("get flow failed, err: %v", err)
+	}
+
+	if flow.State != enumor.FlowCompensated {
+		t.Errorf("flow state should be compensated, but got %s", flow.State)
+	}
+
+	if tasks, err = listTaskByFlowID(kt, bd, flowID); err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	for _, one := range tasks {
+		expect := enumor.TaskCompensated
+		if one.ActionID == "3" {
+			expect = enumor.TaskFailed
+		}
+
+		if one.State != expect {
+			t.Errorf("task %s state should be %s, but got %s", one.ActionID, expect, one.State)
+		}
+	}
+}
+
+func getTestFlow(kt *kit.Kit, bd backend.Backend, id string) (model.Flow, error) {
+	flows, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		return model.Flow{}, err
+	}
+
+	return flows[0], nil
+}
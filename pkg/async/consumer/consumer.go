@@ -109,7 +109,7 @@ func (csm *consumer) Start() error {
 // initLeaderComponent 初始化主节点私有组件并启动，同时设置关闭函数
 func (csm *consumer) initLeaderComponent(opt *Option) {
 
-	handler := NewLeaderChangeHandler(csm.backend, csm.leader, opt)
+	handler := NewLeaderChangeHandler(csm.backend, csm.leader, csm.mc, opt)
 	handler.Start()
 	csm.closers = append(csm.closers, handler)
 
@@ -21,36 +21,51 @@ package consumer
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
 	"hcm/pkg/async/consumer/leader"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/cron"
+	"hcm/pkg/tools/times"
 )
 
-// NewDispatcher new dispatcher.
-func NewDispatcher(bd backend.Backend, ld leader.Leader, opt *DispatcherOption) *Dispatcher {
+// NewDispatcher new dispatcher. term 为主节点组件启动时所在任期的fencing token。
+func NewDispatcher(bd backend.Backend, ld leader.Leader, term int64, opt *DispatcherOption) *Dispatcher {
 	return &Dispatcher{
-		watchIntervalSec: time.Duration(opt.WatchIntervalSec) * time.Second,
-		bd:               bd,
-		ld:               ld,
-		closeCh:          make(chan struct{}),
-		wg:               new(sync.WaitGroup),
+		watchIntervalSec:   time.Duration(opt.WatchIntervalSec) * time.Second,
+		flowNameMaxRunning: opt.FlowNameMaxRunning,
+		accountMaxRunning:  opt.AccountMaxRunning,
+		bd:                 bd,
+		ld:                 ld,
+		term:               term,
+		closeCh:            make(chan struct{}),
+		wg:                 new(sync.WaitGroup),
 	}
 }
 
-// Dispatcher 派发器，负责将Pending状态的任务流，派发到指定节点去执行，并将Flow状态改为Scheduled。。
+// Dispatcher 派发器，负责将Pending状态且到达开始执行时间的任务流，派发到指定节点去执行，并将Flow状态改为Scheduled。
+// 同时负责将到达执行时间的周期任务流，生成为Pending状态的普通任务流。
 type Dispatcher struct {
 	watchIntervalSec time.Duration
+	// flowNameMaxRunning 每种任务流同时处于调度中和执行中的最大数量
+	flowNameMaxRunning map[enumor.FlowName]uint
+	// accountMaxRunning 每个云账号同时处于调度中和执行中的最大任务流数量，0表示不限制
+	accountMaxRunning uint
 
 	bd backend.Backend
 	ld leader.Leader
+	// term 主节点组件启动时所在任期的fencing token，派发任务流时携带，上一任期的主节点派发任务流时状态更新会失败
+	term int64
 
 	wg      *sync.WaitGroup
 	closeCh chan struct{}
@@ -64,68 +79,257 @@ func (d *Dispatcher) Start() {
 
 // WatchPendingFlow 监听处于Pending状态的流，并派发到指定节点。
 func (d *Dispatcher) WatchPendingFlow() {
+	defer d.wg.Done()
+
 	for {
-		select {
-		case <-d.closeCh:
-			break
-		default:
+		kt := NewKit()
+		if err := d.MaterializeCronFlow(kt); err != nil {
+			logs.Errorf("%s: dispatcher materialize cron flow failed, err: %v, rid: %s", constant.AsyncTaskWarnSign,
+				err, kt.Rid)
 		}
 
-		kt := NewKit()
 		if err := d.Do(kt); err != nil {
 			logs.Errorf("%s: dispatcher do failed, err: %v, rid: %s", constant.AsyncTaskWarnSign, err, kt.Rid)
 		}
 
-		time.Sleep(d.watchIntervalSec)
+		select {
+		case <-d.closeCh:
+			return
+		case <-time.After(d.watchIntervalSec):
+		}
 	}
-
-	d.wg.Done()
 }
 
-// Do 监听处于Pending状态的流，并派发到指定节点。
+// Do 监听处于Pending状态且到达开始执行时间的流，按照优先级从高到低，在不超过并发限制的前提下派发到满足任务流模版
+// 节点标签要求的存活节点。
 func (d *Dispatcher) Do(kt *kit.Kit) error {
-	input := &backend.ListInput{
-		Filter: tools.EqualExpression("state", enumor.FlowPending),
-		Page:   core.NewDefaultBasePage(),
+	limiter, err := newRunningLimiter(kt, d.bd, d.flowNameMaxRunning, d.accountMaxRunning)
+	if err != nil {
+		return err
 	}
-	flows, err := d.bd.ListFlow(kt, input)
+
+	labels, err := d.ld.AliveNodeLabels()
 	if err != nil {
-		logs.Errorf("list flow failed, err: %v, rid: %s", err, kt.Rid)
 		return err
 	}
 
-	if len(flows) == 0 {
-		logs.V(3).Infof("currently no task flows to assign, skip handleRunningFlow, rid: %s", kt.Rid)
+	if len(labels) == 0 {
+		return errors.New("alive nodes not found")
+	}
+
+	infos, err := d.pickDispatchFlows(kt, limiter, newNodeAffinity(labels))
+	if err != nil {
+		return err
+	}
+
+	if len(infos) == 0 {
+		logs.V(3).Infof("currently no task flows to assign, skip dispatch, rid: %s", kt.Rid)
 		return nil
 	}
 
-	nodes, err := d.ld.AliveNodes()
+	// 逐个派发任务流，单个任务流被并发取消或者状态变化导致CAS失败时，不影响其他任务流的派发
+	var lastErr error
+	failed := 0
+	for _, one := range infos {
+		if err = d.bd.FencedBatchUpdateFlowStateByCAS(kt, d.term, []backend.UpdateFlowInfo{one}); err != nil {
+			logs.Errorf("dispatch flow failed, err: %v, id: %s, worker: %s, rid: %s", err, one.ID, one.Worker, kt.Rid)
+			lastErr = err
+			failed++
+			continue
+		}
+	}
+
+	// 全部派发失败时（如主节点任期已经失效）返回错误
+	if failed == len(infos) {
+		return lastErr
+	}
+
+	return nil
+}
+
+// pickDispatchFlows 按照优先级从高到低分页查询处于Pending状态且到达开始执行时间的流，跳过达到并发限制或者没有满足
+// 节点标签要求的存活节点的任务流，直到选出一页数量的任务流或者查询完全部任务流，避免低优先级的任务流一直得不到派发。
+func (d *Dispatcher) pickDispatchFlows(kt *kit.Kit, limiter *runningLimiter, affinity *nodeAffinity) (
+	[]backend.UpdateFlowInfo, error) {
+
+	rules := []filter.RuleFactory{
+		filter.AtomRule{Field: "state", Op: filter.Equal.Factory(), Value: enumor.FlowPending},
+		filter.AtomRule{Field: "start_at", Op: filter.LessThanEqual.Factory(),
+			Value: times.ConvStdTimeFormat(time.Now())},
+	}
+	input := &backend.ListInput{
+		Filter: &filter.Expression{
+			Op:    filter.And,
+			Rules: append(rules, limiter.ExcludeRules()...),
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+			Sort:  "priority",
+			Order: core.Descending,
+		},
+	}
+
+	infos := make([]backend.UpdateFlowInfo, 0)
+	for {
+		flows, err := d.bd.ListFlow(kt, input)
+		if err != nil {
+			logs.Errorf("list flow failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range flows {
+			// 没有满足任务流模版节点标签的存活节点时，任务流保持Pending状态，等待满足条件的节点上线
+			if len(affinity.Candidates(one.Name)) == 0 {
+				logs.V(3).Infof("no alive node matches flow: %s node selector, skip dispatch, rid: %s", one.ID,
+					kt.Rid)
+				continue
+			}
+
+			if !limiter.Acquire(one) {
+				continue
+			}
+
+			infos = append(infos, backend.UpdateFlowInfo{
+				ID:     one.ID,
+				Source: enumor.FlowPending,
+				Target: enumor.FlowScheduled,
+				Worker: affinity.Next(one.Name),
+			})
+
+			if len(infos) >= int(core.DefaultMaxPageLimit) {
+				return infos, nil
+			}
+		}
+
+		if len(flows) < int(core.DefaultMaxPageLimit) {
+			return infos, nil
+		}
+
+		input.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+// MaterializeCronFlow 将到达执行时间的周期任务流生成为普通任务流，并推进其下次执行时间。
+// 先以周期任务流ID和执行时间点作为幂等键创建任务流，创建成功后再通过CAS推进下次执行时间：创建失败时下次执行时间不变，
+// 下次物化时重试，不会丢失执行；推进失败时重试创建命中幂等键，同一个执行时间点只会生成一次任务流。
+func (d *Dispatcher) MaterializeCronFlow(kt *kit.Kit) error {
+	now := times.ConvStdTimeNow()
+	input := &backend.ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "next_run_at", Op: filter.LessThanEqual.Factory(),
+					Value: times.ConvStdTimeFormat(now)},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	crons, err := d.bd.ListCronFlow(kt, input)
 	if err != nil {
+		logs.Errorf("list cron flow failed, err: %v, rid: %s", err, kt.Rid)
 		return err
 	}
 
-	if len(nodes) == 0 {
-		return errors.New("alive nodes not found")
+	for _, one := range crons {
+		if err = d.materializeOneCronFlow(kt, one, now); err != nil {
+			logs.Errorf("materialize cron flow failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) materializeOneCronFlow(kt *kit.Kit, one model.CronFlow, now time.Time) error {
+	sch, err := cron.Parse(one.Spec)
+	if err != nil {
+		return err
 	}
 
-	infos := make([]backend.UpdateFlowInfo, 0, len(flows))
-	for index, one := range flows {
-		infos = append(infos, backend.UpdateFlowInfo{
-			ID:     one.ID,
-			Source: enumor.FlowPending,
-			Target: enumor.FlowScheduled,
-			Worker: nodes[index%len(nodes)], // 任务分发算法，后续看是否优化
-		})
+	current, err := time.Parse(constant.TimeStdFormat, one.NextRunAt)
+	if err != nil {
+		return err
+	}
+
+	// 主节点停止期间错过的执行时间点不再补偿，直接计算当前时间之后的下次执行时间
+	next := sch.Next(now)
+	if next.IsZero() {
+		return fmt.Errorf("cron spec: %s will never be triggered", one.Spec)
+	}
+
+	flow := one.BuildFlow(one.NextRunAt)
+	flow.IdempotencyKey = fmt.Sprintf("cron-%s-%d", one.ID, current.Unix())
+	flowID, err := d.createCronRunFlow(kt, flow)
+	if err != nil {
+		return err
 	}
 
-	if err = d.bd.BatchUpdateFlowStateByCAS(kt, infos); err != nil {
-		logs.Errorf("batch update flow failed, err: %v, rid: %s", err, kt.Rid)
+	info := &backend.UpdateCronNextRunInfo{
+		ID:     one.ID,
+		Source: current,
+		Target: next,
+	}
+	if err = d.bd.UpdateCronNextRunByCAS(kt, info); err != nil {
 		return err
 	}
 
+	logs.Infof("cron flow: %s materialize flow: %s, next run at: %s, rid: %s", one.ID, flowID,
+		times.ConvStdTimeFormat(next), kt.Rid)
+
 	return nil
 }
 
+// createCronRunFlow 创建周期任务流某个执行时间点的任务流，该执行时间点的任务流已经创建时直接返回已创建的任务流ID。
+func (d *Dispatcher) createCronRunFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
+	id, err := d.getFlowByIdempotencyKey(kt, flow.IdempotencyKey)
+	if err != nil {
+		return "", err
+	}
+
+	if len(id) != 0 {
+		logs.Infof("cron run flow with idempotency_key: %s already exists, flow: %s, rid: %s", flow.IdempotencyKey,
+			id, kt.Rid)
+		return id, nil
+	}
+
+	id, err = d.bd.CreateFlow(kt, flow)
+	if err == nil {
+		return id, nil
+	}
+
+	// 多个节点并发物化同一个执行时间点时，后创建的因唯一索引冲突创建失败，此时返回先创建成功的任务流
+	existID, getErr := d.getFlowByIdempotencyKey(kt, flow.IdempotencyKey)
+	if getErr != nil {
+		return "", getErr
+	}
+
+	if len(existID) != 0 {
+		return existID, nil
+	}
+
+	return "", err
+}
+
+// getFlowByIdempotencyKey 查询幂等键对应的任务流ID，不存在时返回空字符串。
+func (d *Dispatcher) getFlowByIdempotencyKey(kt *kit.Kit, key string) (string, error) {
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("idempotency_key", key),
+		Page:   core.NewDefaultBasePage(),
+	}
+	flows, err := d.bd.ListFlow(kt, input)
+	if err != nil {
+		logs.Errorf("list flow by idempotency_key failed, err: %v, key: %s, rid: %s", err, key, kt.Rid)
+		return "", err
+	}
+
+	if len(flows) == 0 {
+		return "", nil
+	}
+
+	return flows[0].ID, nil
+}
+
 // Close dispatcher
 func (d *Dispatcher) Close() {
 
@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"sort"
+	"strings"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+)
+
+// nodeAffinity 根据任务流模版声明的节点标签，筛选可以执行任务流的存活节点。
+type nodeAffinity struct {
+	// nodes 全部存活节点，按照节点唯一标识排序，保证派发顺序稳定
+	nodes []string
+	// labels 存活节点的标签
+	labels map[string]map[string]string
+	// candidates 每种任务流可以派发的节点缓存
+	candidates map[enumor.FlowName][]string
+	// cursors 每组候选节点的轮询游标，key为候选节点拼接后的字符串，使相同候选节点的任务流在这组节点间均匀派发
+	cursors map[string]int
+}
+
+func newNodeAffinity(labels map[string]map[string]string) *nodeAffinity {
+	nodes := make([]string, 0, len(labels))
+	for node := range labels {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	return &nodeAffinity{
+		nodes:      nodes,
+		labels:     labels,
+		candidates: make(map[enumor.FlowName][]string),
+		cursors:    make(map[string]int),
+	}
+}
+
+// Candidates 返回可以执行该任务流的节点，任务流模版未声明节点标签或者不是模版创建的任务流，可以派发到全部存活节点。
+func (na *nodeAffinity) Candidates(name enumor.FlowName) []string {
+	if nodes, exist := na.candidates[name]; exist {
+		return nodes
+	}
+
+	tpl, exist := action.GetTpl(name)
+	if !exist || len(tpl.NodeSelector) == 0 {
+		na.candidates[name] = na.nodes
+		return na.nodes
+	}
+
+	nodes := make([]string, 0)
+	for _, node := range na.nodes {
+		if matchLabels(na.labels[node], tpl.NodeSelector) {
+			nodes = append(nodes, node)
+		}
+	}
+	na.candidates[name] = nodes
+
+	return nodes
+}
+
+// Next 在可以执行该任务流的节点中轮询选择一个节点，没有可以执行的节点时返回空。
+func (na *nodeAffinity) Next(name enumor.FlowName) string {
+	nodes := na.Candidates(name)
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	key := strings.Join(nodes, ",")
+	node := nodes[na.cursors[key]%len(nodes)]
+	na.cursors[key]++
+
+	return node
+}
+
+// matchLabels 节点标签包含全部要求的标签
+func matchLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
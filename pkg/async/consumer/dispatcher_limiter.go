@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// runningLimiter 限制每种任务流、每个云账号同时处于调度中和执行中的任务流数量。
+// 只有主节点的派发器会将任务流派发到各个节点，所以基于DB中的任务流状态进行统计，限制是整个集群维度的。
+type runningLimiter struct {
+	flowNameMax map[enumor.FlowName]uint
+	accountMax  uint
+
+	flowNameCount map[enumor.FlowName]uint
+	accountCount  map[string]uint
+}
+
+// newRunningLimiter 统计当前处于调度中和执行中的任务流数量，构建并发限制器。
+func newRunningLimiter(kt *kit.Kit, bd backend.Backend, flowNameMax map[enumor.FlowName]uint,
+	accountMax uint) (*runningLimiter, error) {
+
+	limiter := &runningLimiter{
+		flowNameMax:   flowNameMax,
+		accountMax:    accountMax,
+		flowNameCount: make(map[enumor.FlowName]uint),
+		accountCount:  make(map[string]uint),
+	}
+
+	if len(flowNameMax) == 0 && accountMax == 0 {
+		return limiter, nil
+	}
+
+	rules := []filter.RuleFactory{
+		filter.AtomRule{Field: "state", Op: filter.In.Factory(),
+			Value: []enumor.FlowState{enumor.FlowScheduled, enumor.FlowRunning}},
+	}
+	// 未设置账号维度限制时，只需要统计设置了限制的任务流
+	if accountMax == 0 {
+		names := make([]enumor.FlowName, 0, len(flowNameMax))
+		for name := range flowNameMax {
+			names = append(names, name)
+		}
+		rules = append(rules, filter.AtomRule{Field: "name", Op: filter.In.Factory(), Value: names})
+	}
+
+	input := &backend.ListInput{
+		Filter: &filter.Expression{Op: filter.And, Rules: rules},
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "name", "account_id"},
+	}
+	for {
+		flows, err := bd.ListFlow(kt, input)
+		if err != nil {
+			logs.Errorf("list running flow failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range flows {
+			limiter.flowNameCount[one.Name]++
+			if len(one.AccountID) != 0 {
+				limiter.accountCount[one.AccountID]++
+			}
+		}
+
+		if len(flows) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		input.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return limiter, nil
+}
+
+// ExcludeRules 返回排除已经达到并发上限的任务流名称、云账号的过滤规则，避免这部分任务流占用每次派发的查询数量。
+func (l *runningLimiter) ExcludeRules() []filter.RuleFactory {
+	names := make([]enumor.FlowName, 0)
+	for name, max := range l.flowNameMax {
+		if l.flowNameCount[name] >= max {
+			names = append(names, name)
+		}
+	}
+
+	accounts := make([]string, 0)
+	if l.accountMax != 0 {
+		for account, count := range l.accountCount {
+			if count >= l.accountMax {
+				accounts = append(accounts, account)
+			}
+		}
+	}
+
+	rules := make([]filter.RuleFactory, 0)
+	if len(names) != 0 && len(names) <= int(filter.DefaultMaxNotInLimit) {
+		rules = append(rules, filter.AtomRule{Field: "name", Op: filter.NotIn.Factory(), Value: names})
+	}
+
+	if len(accounts) != 0 && len(accounts) <= int(filter.DefaultMaxNotInLimit) {
+		rules = append(rules, filter.AtomRule{Field: "account_id", Op: filter.NotIn.Factory(), Value: accounts})
+	}
+
+	return rules
+}
+
+// Acquire 判断任务流是否可以派发，可以派发时占用对应的并发数量。
+func (l *runningLimiter) Acquire(flow model.Flow) bool {
+	if max, exist := l.flowNameMax[flow.Name]; exist && l.flowNameCount[flow.Name] >= max {
+		return false
+	}
+
+	if l.accountMax != 0 && len(flow.AccountID) != 0 && l.accountCount[flow.AccountID] >= l.accountMax {
+		return false
+	}
+
+	l.flowNameCount[flow.Name]++
+	if len(flow.AccountID) != 0 {
+		l.accountCount[flow.AccountID]++
+	}
+
+	return true
+}
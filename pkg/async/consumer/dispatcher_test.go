@@ -0,0 +1,521 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/times"
+)
+
+func TestMaterializeCronFlow(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+	dis := NewDispatcher(bd, nil, registerTestFence(t, bd), &DispatcherOption{WatchIntervalSec: 1})
+
+	runAt := times.ConvStdTimeFormat(time.Now().Add(-time.Minute))
+	cron := &model.CronFlow{
+		Name:      "test_flow",
+		Spec:      "* * * * *",
+		Tasks:     []model.Task{{ActionID: "1", ActionName: "test_action"}},
+		NextRunAt: runAt,
+	}
+	cronID, err := bd.CreateCronFlow(kt, cron)
+	if err != nil {
+		t.Fatalf("create cron flow failed, err: %v", err)
+	}
+
+	// 多次执行，同一个执行时间点只会生成一个任务流
+	for i := 0; i < 2; i++ {
+		if err = dis.MaterializeCronFlow(kt); err != nil {
+			t.Fatalf("materialize cron flow failed, err: %v", err)
+		}
+	}
+
+	flows, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("name", "test_flow"),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	if len(flows) != 1 {
+		t.Fatalf("materialized flow count should be 1, but got %d", len(flows))
+	}
+
+	if flows[0].StartAt != runAt {
+		t.Errorf("flow start_at should be %s, but got %s", runAt, flows[0].StartAt)
+	}
+
+	crons, err := bd.ListCronFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", cronID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list cron flow failed, err: %v", err)
+	}
+
+	if len(crons) != 1 || crons[0].NextRunAt <= runAt {
+		t.Errorf("cron flow next_run_at should be advanced, cron: %+v", crons)
+	}
+}
+
+// cronFailBackend 模拟创建任务流或推进周期任务流下次执行时间失败的存储
+type cronFailBackend struct {
+	backend.Backend
+	createErr  error
+	advanceErr error
+}
+
+// CreateFlow 创建任务流，设置了createErr时返回失败
+func (b *cronFailBackend) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
+	if b.createErr != nil {
+		return "", b.createErr
+	}
+	return b.Backend.CreateFlow(kt, flow)
+}
+
+// UpdateCronNextRunByCAS 推进下次执行时间，设置了advanceErr时返回失败
+func (b *cronFailBackend) UpdateCronNextRunByCAS(kt *kit.Kit, info *backend.UpdateCronNextRunInfo) error {
+	if b.advanceErr != nil {
+		return b.advanceErr
+	}
+	return b.Backend.UpdateCronNextRunByCAS(kt, info)
+}
+
+func TestMaterializeCronFlowFailure(t *testing.T) {
+	kt := NewKit()
+	mem := backend.NewMemory()
+	bd := &cronFailBackend{Backend: mem}
+	dis := NewDispatcher(bd, nil, registerTestFence(t, mem), &DispatcherOption{WatchIntervalSec: 1})
+
+	runAt := times.ConvStdTimeFormat(time.Now().Add(-time.Minute))
+	cronID, err := mem.CreateCronFlow(kt, &model.CronFlow{
+		Name:      "test_flow",
+		Spec:      "* * * * *",
+		Tasks:     []model.Task{{ActionID: "1", ActionName: "test_action"}},
+		NextRunAt: runAt,
+	})
+	if err != nil {
+		t.Fatalf("create cron flow failed, err: %v", err)
+	}
+
+	getNextRunAt := func() string {
+		crons, err := mem.ListCronFlow(kt, &backend.ListInput{
+			Filter: tools.EqualExpression("id", cronID),
+			Page:   core.NewDefaultBasePage(),
+		})
+		if err != nil || len(crons) != 1 {
+			t.Fatalf("list cron flow failed, err: %v, crons: %+v", err, crons)
+		}
+		return crons[0].NextRunAt
+	}
+	countFlow := func() int {
+		flows, err := mem.ListFlow(kt, &backend.ListInput{
+			Filter: tools.EqualExpression("name", "test_flow"),
+			Page:   core.NewDefaultBasePage(),
+		})
+		if err != nil {
+			t.Fatalf("list flow failed, err: %v", err)
+		}
+		return len(flows)
+	}
+
+	// 创建任务流失败时不推进下次执行时间，下次物化时重新生成该执行时间点的任务流
+	bd.createErr = errors.New("create flow failed")
+	if err = dis.MaterializeCronFlow(kt); err != nil {
+		t.Fatalf("materialize cron flow failed, err: %v", err)
+	}
+	if countFlow() != 0 || getNextRunAt() != runAt {
+		t.Errorf("next_run_at should not be advanced when create flow failed, flow count: %d, next_run_at: %s",
+			countFlow(), getNextRunAt())
+	}
+
+	// 推进下次执行时间失败时，重试不会重复生成该执行时间点的任务流
+	bd.createErr = nil
+	bd.advanceErr = errors.New("advance next run time failed")
+	for i := 0; i < 2; i++ {
+		if err = dis.MaterializeCronFlow(kt); err != nil {
+			t.Fatalf("materialize cron flow failed, err: %v", err)
+		}
+	}
+	if countFlow() != 1 || getNextRunAt() != runAt {
+		t.Errorf("flow should be created once, flow count: %d, next_run_at: %s", countFlow(), getNextRunAt())
+	}
+
+	bd.advanceErr = nil
+	if err = dis.MaterializeCronFlow(kt); err != nil {
+		t.Fatalf("materialize cron flow failed, err: %v", err)
+	}
+	if countFlow() != 1 || getNextRunAt() <= runAt {
+		t.Errorf("next_run_at should be advanced without creating flow again, flow count: %d, next_run_at: %s",
+			countFlow(), getNextRunAt())
+	}
+}
+
+func TestRunningLimiter(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+
+	// 已经有一个create_cvm任务流在执行中
+	runningID, err := bd.CreateFlow(kt, &model.Flow{Name: enumor.FlowCreateCvm, AccountID: "account1"})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+	err = bd.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{
+		{ID: runningID, Source: enumor.FlowPending, Target: enumor.FlowRunning},
+	})
+	if err != nil {
+		t.Fatalf("update flow state failed, err: %v", err)
+	}
+
+	limiter, err := newRunningLimiter(kt, bd, map[enumor.FlowName]uint{enumor.FlowCreateCvm: 1}, 2)
+	if err != nil {
+		t.Fatalf("new running limiter failed, err: %v", err)
+	}
+
+	if len(limiter.ExcludeRules()) != 1 {
+		t.Errorf("create_cvm reach max running, should be excluded")
+	}
+
+	tests := []struct {
+		flow model.Flow
+		want bool
+	}{
+		{model.Flow{Name: enumor.FlowCreateCvm, AccountID: "account2"}, false},
+		{model.Flow{Name: enumor.FlowStopCvm, AccountID: "account1"}, true},
+		// account1 已经有两个任务流在执行
+		{model.Flow{Name: enumor.FlowStopCvm, AccountID: "account1"}, false},
+		{model.Flow{Name: enumor.FlowStopCvm}, true},
+	}
+	for index, tt := range tests {
+		if got := limiter.Acquire(tt.flow); got != tt.want {
+			t.Errorf("case %d: acquire %s of %s got %v, want %v", index, tt.flow.Name, tt.flow.AccountID, got,
+				tt.want)
+		}
+	}
+}
+
+// registerTestFence 登记测试主节点任期的fencing token，返回登记的token
+func registerTestFence(t *testing.T, bd backend.Backend) int64 {
+	var token int64 = 1
+	if err := bd.UpdateLeaderFence(NewKit(), &backend.UpdateLeaderFenceOption{Worker: "node1",
+		Token: token}); err != nil {
+		t.Fatalf("update leader fence failed, err: %v", err)
+	}
+
+	return token
+}
+
+func TestDispatchStaleTerm(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+	term := registerTestFence(t, bd)
+
+	startAt := times.ConvStdTimeFormat(time.Now().Add(-time.Minute))
+	id, err := bd.CreateFlow(kt, &model.Flow{Name: "test_flow", StartAt: startAt})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	// 新主节点登记了更大的token后，使用启动时任期的旧派发器派发失败，任务流保持Pending状态
+	ld := fakeLeader{labels: map[string]map[string]string{"node1": nil}}
+	dis := NewDispatcher(bd, ld, term, &DispatcherOption{WatchIntervalSec: 1})
+	if err = bd.UpdateLeaderFence(kt, &backend.UpdateLeaderFenceOption{Worker: "node2",
+		Token: term + 1}); err != nil {
+		t.Fatalf("update leader fence failed, err: %v", err)
+	}
+
+	if err = dis.Do(kt); err == nil {
+		t.Fatalf("dispatch with stale term should failed")
+	}
+
+	flows, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	if len(flows) != 1 || flows[0].State != enumor.FlowPending {
+		t.Errorf("flow dispatched with stale term should keep pending, flows: %+v", flows)
+	}
+
+	// 当前任期的派发器派发成功，并在任务流上记录任期的fencing token
+	dis = NewDispatcher(bd, ld, term+1, &DispatcherOption{WatchIntervalSec: 1})
+	if err = dis.Do(kt); err != nil {
+		t.Fatalf("dispatch failed, err: %v", err)
+	}
+
+	flows, err = bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	if len(flows) != 1 || flows[0].State != enumor.FlowScheduled || flows[0].FencingToken != term+1 {
+		t.Errorf("flow should be dispatched with current term, flows: %+v", flows)
+	}
+}
+
+// fakeLeader 固定存活节点及其标签的主节点控制器
+type fakeLeader struct {
+	labels map[string]map[string]string
+}
+
+func (f fakeLeader) IsLeader() bool { return true }
+
+func (f fakeLeader) FencingToken() int64 { return 0 }
+
+func (f fakeLeader) Changed() <-chan struct{} { return nil }
+
+func (f fakeLeader) Resign() error { return nil }
+
+func (f fakeLeader) AliveNodes() ([]string, error) {
+	nodes := make([]string, 0, len(f.labels))
+	for node := range f.labels {
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func (f fakeLeader) AliveNodeLabels() (map[string]map[string]string, error) { return f.labels, nil }
+
+func (f fakeLeader) CurrNode() string { return "node1" }
+
+func TestDispatchNodeAffinity(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+
+	action.RegisterTpl(action.FlowTemplate{
+		Name:         "test_gcp_proxy_flow",
+		Tasks:        []action.TaskTemplate{{ActionID: "1", ActionName: enumor.ActionProduceTest}},
+		NodeSelector: map[string]string{"zone": "proxy"},
+	})
+
+	ld := fakeLeader{labels: map[string]map[string]string{
+		"node1": nil,
+		"node2": {"zone": "proxy"},
+		"node3": {"zone": "proxy"},
+	}}
+	term := registerTestFence(t, bd)
+	dis := NewDispatcher(bd, ld, term, &DispatcherOption{WatchIntervalSec: 1})
+
+	startAt := times.ConvStdTimeFormat(time.Now().Add(-time.Minute))
+	for i := 0; i < 4; i++ {
+		for _, name := range []enumor.FlowName{"test_gcp_proxy_flow", "test_flow"} {
+			_, err := bd.CreateFlow(kt, &model.Flow{Name: name, StartAt: startAt})
+			if err != nil {
+				t.Fatalf("create flow failed, err: %v", err)
+			}
+		}
+	}
+
+	if err := dis.Do(kt); err != nil {
+		t.Fatalf("dispatch failed, err: %v", err)
+	}
+
+	flows, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("state", enumor.FlowScheduled),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	if len(flows) != 8 {
+		t.Fatalf("scheduled flow count should be 8, but got %d", len(flows))
+	}
+
+	for _, one := range flows {
+		if one.Name == "test_gcp_proxy_flow" && *one.Worker == "node1" {
+			t.Errorf("flow: %s should not be dispatched to node without required labels", one.ID)
+		}
+	}
+
+	// 没有满足标签要求的节点时，任务流保持Pending状态
+	id, err := bd.CreateFlow(kt, &model.Flow{Name: "test_gcp_proxy_flow", StartAt: startAt})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	dis = NewDispatcher(bd, fakeLeader{labels: map[string]map[string]string{"node1": nil}}, term,
+		&DispatcherOption{WatchIntervalSec: 1})
+	if err = dis.Do(kt); err != nil {
+		t.Fatalf("dispatch failed, err: %v", err)
+	}
+
+	flows, err = bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	if len(flows) != 1 || flows[0].State != enumor.FlowPending {
+		t.Errorf("flow without matched node should keep pending, flows: %+v", flows)
+	}
+}
+
+// cancelOnListBackend 查询待派发任务流后立即取消指定任务流，模拟派发时任务流被并发取消
+type cancelOnListBackend struct {
+	backend.Backend
+	cancelID string
+}
+
+func (b *cancelOnListBackend) ListFlow(kt *kit.Kit, input *backend.ListInput) ([]model.Flow, error) {
+	flows, err := b.Backend.ListFlow(kt, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Backend.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{{ID: b.cancelID,
+		Source: enumor.FlowPending, Target: enumor.FlowCancel}})
+	return flows, err
+}
+
+func TestDispatchTolerateCASMiss(t *testing.T) {
+	kt := NewKit()
+	mem := backend.NewMemory()
+	term := registerTestFence(t, mem)
+
+	startAt := times.ConvStdTimeFormat(time.Now().Add(-time.Minute))
+	ids := make([]string, 0)
+	for i := 0; i < 3; i++ {
+		id, err := mem.CreateFlow(kt, &model.Flow{Name: "test_flow", StartAt: startAt})
+		if err != nil {
+			t.Fatalf("create flow failed, err: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	// 其中一个任务流在派发时被取消，其他任务流正常派发
+	bd := &cancelOnListBackend{Backend: mem, cancelID: ids[1]}
+	ld := fakeLeader{labels: map[string]map[string]string{"node1": nil}}
+	dis := NewDispatcher(bd, ld, term, &DispatcherOption{WatchIntervalSec: 1})
+	if err := dis.Do(kt); err != nil {
+		t.Fatalf("dispatch failed, err: %v", err)
+	}
+
+	flows, err := mem.ListFlow(kt, &backend.ListInput{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	if len(flows) != 3 {
+		t.Fatalf("flow count should be 3, but got %d", len(flows))
+	}
+
+	for _, one := range flows {
+		want := enumor.FlowScheduled
+		if one.ID == ids[1] {
+			want = enumor.FlowCancel
+		}
+		if one.State != want {
+			t.Errorf("flow: %s state should be %s, but got %s", one.ID, want, one.State)
+		}
+	}
+}
+
+func TestDispatchSkippedFlowNotStarveLowPriority(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+	term := registerTestFence(t, bd)
+
+	action.RegisterTpl(action.FlowTemplate{
+		Name:         "test_no_node_flow",
+		Tasks:        []action.TaskTemplate{{ActionID: "1", ActionName: enumor.ActionProduceTest}},
+		NodeSelector: map[string]string{"zone": "none"},
+	})
+
+	// 高优先级的任务流占满一页且都没有满足标签要求的节点，低优先级的任务流仍然可以派发
+	startAt := times.ConvStdTimeFormat(time.Now().Add(-time.Minute))
+	for i := 0; i < int(core.DefaultMaxPageLimit); i++ {
+		if _, err := bd.CreateFlow(kt, &model.Flow{Name: "test_no_node_flow", StartAt: startAt,
+			Priority: 10}); err != nil {
+			t.Fatalf("create flow failed, err: %v", err)
+		}
+	}
+	id, err := bd.CreateFlow(kt, &model.Flow{Name: "test_flow", StartAt: startAt})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	ld := fakeLeader{labels: map[string]map[string]string{"node1": nil}}
+	dis := NewDispatcher(bd, ld, term, &DispatcherOption{WatchIntervalSec: 1})
+	if err = dis.Do(kt); err != nil {
+		t.Fatalf("dispatch failed, err: %v", err)
+	}
+
+	flows, err := bd.ListFlow(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+
+	if len(flows) != 1 || flows[0].State != enumor.FlowScheduled {
+		t.Errorf("low priority flow should be dispatched, flows: %+v", flows)
+	}
+}
+
+func TestNodeAffinityNext(t *testing.T) {
+	action.RegisterTpl(action.FlowTemplate{
+		Name:         "test_gcp_proxy_flow",
+		Tasks:        []action.TaskTemplate{{ActionID: "1", ActionName: enumor.ActionProduceTest}},
+		NodeSelector: map[string]string{"zone": "proxy"},
+	})
+
+	affinity := newNodeAffinity(map[string]map[string]string{
+		"node1": nil,
+		"node2": {"zone": "proxy"},
+		"node3": {"zone": "proxy"},
+	})
+
+	// 每组候选节点独立轮询，不受其他任务流派发的影响
+	got := make([]string, 0)
+	for i := 0; i < 3; i++ {
+		got = append(got, affinity.Next("test_gcp_proxy_flow"), affinity.Next("test_flow"))
+	}
+
+	want := "node2,node1,node3,node2,node2,node3"
+	if strings.Join(got, ",") != want {
+		t.Errorf("round robin nodes should be %s, but got %v", want, got)
+	}
+}
@@ -61,6 +61,7 @@ type executor struct {
 	workerQueue chan *Task
 	initQueue   chan *initPayload
 	backend     backend.Backend
+	limiter     *rateLimiter
 
 	closeCh chan struct{}
 
@@ -76,6 +77,7 @@ func (exec *executor) SetGetSchedulerFunc(f func() Scheduler) {
 func NewExecutor(bd backend.Backend, opt *ExecutorOption) Executor {
 	return &executor{
 		backend:            bd,
+		limiter:            newRateLimiter(bd, opt.RateLimits),
 		workerWg:           sync.WaitGroup{},
 		initWg:             sync.WaitGroup{},
 		workerQueue:        make(chan *Task, 10),
@@ -119,18 +121,25 @@ func (exec *executor) initWorkerTask(flow *Flow, task *Task) {
 		return
 	}
 
-	// 设置超时控制
-	cancel := task.Kit.CtxWithTimeoutMS(int(exec.taskExecTimeoutSec) * 1000)
+	// 设置超时控制，任务设置了执行超时时间时，优先使用任务的超时时间
+	timeoutSec := exec.taskExecTimeoutSec
+	if task.TimeoutSec != 0 {
+		timeoutSec = task.TimeoutSec
+	}
+	cancel := task.Kit.CtxWithTimeoutMS(int(timeoutSec) * 1000)
 
 	// 设置共享数据更新函数
 	flow.ShareData.Save = func(kt *kit.Kit, data *tableasync.ShareData) error {
 		return exec.backend.BatchUpdateFlow(kt, []model.Flow{{ID: flow.ID, ShareData: data}})
 	}
 
-	// 设置task执行所需要的 kit，更新Task函数，所属流
-	task.InitDep(run.NewExecuteContext(task.Kit, flow.ShareData), func(kt *kit.Kit, task *model.Task) error {
+	// 设置task执行所需要的 kit，更新Task函数，所属流，以及创建动态扇出子任务函数
+	ekt := run.NewExecuteContext(task.Kit, flow.ShareData, flow.ID, task.ID)
+	task.InitDep(ekt, func(kt *kit.Kit, task *model.Task) error {
 		return exec.backend.UpdateTask(kt, task)
 	}, flow)
+	task.CreateTasks = exec.backend.BatchCreateTask
+	task.ListTasks = exec.backend.ListTask
 
 	// cancel存储到cancelMap中
 	exec.cancelMap.Store(task.ID, cancel)
@@ -157,6 +166,16 @@ func (exec *executor) workerDo(task *Task) (err error) {
 	// cancelMap清理执行成功/失败的任务
 	defer exec.cancelMap.Delete(task.ID)
 
+	// 任务调用云厂商接口超过限流速率时，进入重试等待状态释放执行器，等待结束后再执行。进入重试等待状态失败时直接执行任务，
+	// 避免任务停留在待执行状态
+	if wait := exec.limiter.Wait(task); wait > 0 {
+		if err = task.throttle(wait); err == nil {
+			exec.GetSchedulerFunc().EntryTask(task)
+			return nil
+		}
+		logs.Errorf("task throttle failed, err: %v, task: %+v, rid: %s", err, task, task.Kit.Rid)
+	}
+
 	// 执行任务
 	if err = task.Run(); err != nil {
 		logs.Errorf("task run failed, err: %v, task: %+v, rid: %s", err, task, task.Kit.Rid)
@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"time"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+)
+
+// rateLimiter 按照账号、云厂商接口分组限制任务调用云厂商接口的速率。令牌桶保存在后端存储中，由所有节点共享，
+// 所以限制是整个集群维度的。
+type rateLimiter struct {
+	backend backend.Backend
+	// rules key为云厂商与接口分组的组合
+	rules map[string]RateLimitRule
+}
+
+// newRateLimiter 根据限流规则构建限流器。
+func newRateLimiter(bd backend.Backend, rules []RateLimitRule) *rateLimiter {
+	limiter := &rateLimiter{
+		backend: bd,
+		rules:   make(map[string]RateLimitRule, len(rules)),
+	}
+
+	for _, rule := range rules {
+		limiter.rules[rateLimitRuleKey(rule.Vendor, rule.APIGroup)] = rule
+	}
+
+	return limiter
+}
+
+func rateLimitRuleKey(vendor enumor.Vendor, apiGroup string) string {
+	return string(vendor) + "/" + apiGroup
+}
+
+// Wait 获取任务执行所需要的令牌，返回需要等待的时间，0表示可以立即执行。
+// 获取令牌失败时不阻塞任务执行，避免后端存储异常导致所有任务无法执行。
+func (l *rateLimiter) Wait(task *Task) time.Duration {
+	if len(l.rules) == 0 {
+		return 0
+	}
+
+	act, exist := action.GetAction(task.ActionName)
+	if !exist {
+		return 0
+	}
+
+	rateLimitAct, ok := act.(action.RateLimitAction)
+	if !ok {
+		return 0
+	}
+
+	var params interface{}
+	if paramAct, ok := act.(action.ParameterAction); ok && len(task.Params) != 0 {
+		params = paramAct.ParameterNew()
+		if params != nil {
+			if err := task.decodeParams(params); err != nil {
+				// 参数解析失败由任务执行时处理
+				return 0
+			}
+		}
+	}
+
+	key, ok := rateLimitAct.RateLimitKey(params)
+	if !ok {
+		return 0
+	}
+
+	rule, exist := l.rules[rateLimitRuleKey(key.Vendor, key.APIGroup)]
+	if !exist {
+		return 0
+	}
+
+	opt := &backend.AcquireTokenOption{
+		Key:   key.String(),
+		Rate:  rule.Rate,
+		Burst: rule.Burst,
+	}
+	wait, err := l.backend.AcquireRateLimitToken(task.Kit, opt)
+	if err != nil {
+		logs.Errorf("acquire rate limit token failed, err: %v, key: %s, task: %s, rid: %s", err, opt.Key, task.ID,
+			task.Kit.Rid)
+		return 0
+	}
+
+	return wait
+}
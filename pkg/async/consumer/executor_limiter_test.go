@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"testing"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/times"
+)
+
+// rateLimitTestAction 需要限流的测试Action，所有任务共享同一个限流维度。
+type rateLimitTestAction struct {
+	runs *int
+}
+
+// Name ...
+func (act rateLimitTestAction) Name() enumor.ActionName {
+	return enumor.ActionCreateFactoryTest
+}
+
+// Run ...
+func (act rateLimitTestAction) Run(_ run.ExecuteKit, _ interface{}) (interface{}, error) {
+	*act.runs++
+	return nil, nil
+}
+
+// RateLimitKey ...
+func (act rateLimitTestAction) RateLimitKey(_ interface{}) (action.RateLimitKey, bool) {
+	return action.RateLimitKey{Vendor: enumor.TCloud, AccountID: "account", APIGroup: "cvm"}, true
+}
+
+func newRateLimitTestTask(t *testing.T, kt *kit.Kit, bd backend.Backend) *Task {
+	task := model.Task{FlowName: "test_flow", ActionID: "1", ActionName: enumor.ActionCreateFactoryTest,
+		Retry: new(tableasync.Retry)}
+	flowID, err := bd.CreateFlow(kt, &model.Flow{Name: "test_flow", Tasks: []model.Task{task}})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	return getRetryTestTask(t, kt, bd, flowID)
+}
+
+func TestTaskThrottle(t *testing.T) {
+	runs := 0
+	action.RegisterAction(rateLimitTestAction{runs: &runs})
+
+	kt := NewKit()
+	bd := backend.NewMemory()
+	limiter := newRateLimiter(bd, []RateLimitRule{{Vendor: enumor.TCloud, APIGroup: "cvm", Rate: 0.01, Burst: 1}})
+
+	// 令牌桶容量为1，第一个任务可以立即执行
+	first := newRateLimitTestTask(t, kt, bd)
+	if wait := limiter.Wait(first); wait != 0 {
+		t.Fatalf("first task should not be rate limited, but wait: %s", wait)
+	}
+
+	// 第二个任务需要等待令牌补充，进入重试等待状态，且不计入失败次数
+	second := newRateLimitTestTask(t, kt, bd)
+	wait := limiter.Wait(second)
+	if wait <= 0 {
+		t.Fatalf("second task should be rate limited")
+	}
+
+	if err := second.throttle(wait); err != nil {
+		t.Fatalf("task throttle failed, err: %v", err)
+	}
+
+	second = getRetryTestTask(t, kt, bd, second.FlowID)
+	if second.State != enumor.TaskRetrying || second.RetryState == nil || second.RetryState.Attempts != 0 {
+		t.Fatalf("task should be retrying without attempt, but got %s, %+v", second.State, second.RetryState)
+	}
+	if isRetryDue(second, times.ConvStdTimeNow()) {
+		t.Fatalf("task should not run before next_at: %s", second.RetryState.NextAt)
+	}
+
+	// 限流等待结束后恢复为待执行状态直接执行，不需要回滚
+	if state := resumeState(second); state != enumor.TaskPending {
+		t.Fatalf("task should resume to pending, but got %s", state)
+	}
+
+	info := &backend.UpdateTaskInfo{ID: second.ID, Source: enumor.TaskRetrying, Target: resumeState(second)}
+	if err := bd.UpdateTaskStateByCAS(kt, info); err != nil {
+		t.Fatalf("update task state failed, err: %v", err)
+	}
+
+	second = getRetryTestTask(t, kt, bd, second.FlowID)
+	if err := second.Run(); err != nil {
+		t.Fatalf("task run failed, err: %v", err)
+	}
+
+	second = getRetryTestTask(t, kt, bd, second.FlowID)
+	if second.State != enumor.TaskSuccess || runs != 1 {
+		t.Fatalf("task should be success after resume, but got %s, runs: %d", second.State, runs)
+	}
+}
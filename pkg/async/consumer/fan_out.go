@@ -0,0 +1,334 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// fanOut 根据动态扇出声明创建子任务，子任务依赖当前任务，汇聚任务依赖全部子任务。任务崩溃恢复或重试后重新执行时，
+// 已创建的子任务会被复用，不会重复创建。
+func (task *Task) fanOut(fanOut *action.FanOut) error {
+	kt := task.ExecuteKit.Kit()
+
+	if err := fanOut.Validate(); err != nil {
+		return fmt.Errorf("fan out is invalid, err: %v", err)
+	}
+
+	if task.CreateTasks == nil || task.ListTasks == nil {
+		return errors.New("task not support fan out, create or list tasks func is nil")
+	}
+
+	mds := make([]model.Task, 0, len(fanOut.Tasks)+1)
+	childIDs := make([]action.ActIDType, 0, len(fanOut.Tasks))
+	for i := range fanOut.Tasks {
+		actionID := action.FanOutActionID(task.ActionID, i)
+		md, err := task.newFanOutTask(kt, actionID, &fanOut.Tasks[i], []action.ActIDType{task.ActionID})
+		if err != nil {
+			return err
+		}
+
+		mds = append(mds, md)
+		childIDs = append(childIDs, actionID)
+	}
+
+	if fanOut.Join != nil {
+		md, err := task.newFanOutTask(kt, action.FanOutJoinActionID(task.ActionID), fanOut.Join, childIDs)
+		if err != nil {
+			return err
+		}
+		mds = append(mds, md)
+	}
+
+	if err := task.createFanOutTasks(kt, mds); err != nil {
+		return err
+	}
+
+	task.FanOutTasks = make([]*Task, 0, len(mds))
+	for i := range mds {
+		task.FanOutTasks = append(task.FanOutTasks, &Task{
+			Task: mds[i],
+			Kit:  task.Kit.NewSubKit(),
+		})
+	}
+
+	return nil
+}
+
+// createFanOutTasks 创建尚未创建的扇出子任务，已创建的子任务使用已有的任务替换，创建后回填任务ID。
+func (task *Task) createFanOutTasks(kt *kit.Kit, mds []model.Task) error {
+	input := &backend.ListInput{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
+			"flow_id":   task.FlowID,
+			"parent_id": task.ID,
+		}),
+		Page: core.NewDefaultBasePage(),
+	}
+	exists, err := task.ListTasks(kt, input)
+	if err != nil {
+		logs.Errorf("list fan out tasks failed, err: %v, parent: %s, rid: %s", err, task.ID, kt.Rid)
+		return fmt.Errorf("list fan out tasks failed, err: %v", err)
+	}
+
+	existMap := make(map[action.ActIDType]model.Task, len(exists))
+	for _, one := range exists {
+		existMap[one.ActionID] = one
+	}
+
+	creates := make([]model.Task, 0, len(mds))
+	createIdx := make([]int, 0, len(mds))
+	for i := range mds {
+		if one, exist := existMap[mds[i].ActionID]; exist {
+			mds[i] = one
+			continue
+		}
+		creates = append(creates, mds[i])
+		createIdx = append(createIdx, i)
+	}
+
+	if len(creates) == 0 {
+		return nil
+	}
+
+	ids, err := task.CreateTasks(kt, creates)
+	if err != nil {
+		logs.Errorf("create fan out tasks failed, err: %v, parent: %s, rid: %s", err, task.ID, kt.Rid)
+		return fmt.Errorf("create fan out tasks failed, err: %v", err)
+	}
+
+	if len(ids) != len(creates) {
+		return fmt.Errorf("create fan out tasks count mismatch, expect: %d, actual: %d", len(creates), len(ids))
+	}
+
+	for i, idx := range createIdx {
+		mds[idx].ID = ids[i]
+	}
+
+	return nil
+}
+
+func (task *Task) newFanOutTask(kt *kit.Kit, actionID action.ActIDType, spec *action.FanOutTask,
+	dependOn []action.ActIDType) (model.Task, error) {
+
+	params, err := types.NewJsonField(spec.Params)
+	if err != nil {
+		return model.Task{}, fmt.Errorf("marshal fan out task: %s params failed, err: %v", actionID, err)
+	}
+
+	retry := spec.Retry
+	if retry == nil {
+		retry = new(tableasync.Retry)
+	}
+
+	return model.Task{
+		FlowID:       task.FlowID,
+		FlowName:     task.FlowName,
+		ActionID:     actionID,
+		ActionName:   spec.ActionName,
+		Params:       params,
+		Retry:        retry,
+		DependOn:     dependOn,
+		TimeoutSec:   action.GetTimeoutSec(spec.ActionName, spec.TimeoutSec),
+		AllowFailure: spec.AllowFailure,
+		ParentID:     task.ID,
+		Reason:       new(tableasync.Reason),
+		Creator:      kt.User,
+		Reviser:      kt.User,
+	}, nil
+}
+
+// AddFanOutNodes 将动态扇出的子任务插入到任务流执行树中，并将依赖扇出任务的后续任务链接到子任务的末端节点上。
+// 已经在执行树中的子任务（任务重新执行时复用的子任务）不会重复插入。
+func (t *TaskNode) AddFanOutNodes(parent *Task, tasks []*Task) error {
+	var parentNode *TaskNode
+	treeNodes := make(map[string]*TaskNode)
+	walkNode(t, func(node *TaskNode) bool {
+		if node.TaskID == parent.ID {
+			parentNode = node
+		}
+		treeNodes[node.TaskID] = node
+		return true
+	})
+	if parentNode == nil {
+		return fmt.Errorf("fan out parent task: %s not found in task tree", parent.ID)
+	}
+
+	m := map[action.ActIDType]*TaskNode{parent.ActionID: parentNode}
+	adds := make([]*Task, 0, len(tasks))
+	for _, task := range tasks {
+		if _, exist := m[task.ActionID]; exist {
+			return fmt.Errorf("task actionID is repeat, actionID: %s", task.ActionID)
+		}
+
+		if node, exist := treeNodes[task.ID]; exist {
+			m[task.ActionID] = node
+			continue
+		}
+
+		m[task.ActionID] = NewTaskNode(task)
+		adds = append(adds, task)
+	}
+
+	if len(adds) == 0 {
+		return nil
+	}
+
+	for _, task := range adds {
+		for _, dependID := range task.DependOn {
+			dependNode, exist := m[dependID]
+			if !exist {
+				return fmt.Errorf("does not find task[%s] depend: %s", task.ID, dependID)
+			}
+			dependNode.AppendChild(m[task.ActionID])
+			m[task.ActionID].AppendParent(dependNode)
+		}
+	}
+
+	linkFanOutNodes(parentNode)
+
+	return nil
+}
+
+// linkFanOutNodes 将依赖扇出任务的后续任务链接到扇出子任务的末端节点上，保证后续任务在全部扇出子任务执行完成后执行。
+func linkFanOutNodes(parent *TaskNode) {
+	fanOut, others := make([]*TaskNode, 0), make([]*TaskNode, 0)
+	for _, child := range parent.children {
+		if child.ParentID == parent.TaskID {
+			fanOut = append(fanOut, child)
+		} else {
+			others = append(others, child)
+		}
+	}
+
+	if len(fanOut) == 0 || len(others) == 0 {
+		return
+	}
+
+	for _, tail := range getFanOutTails(parent.TaskID, fanOut) {
+		for _, other := range others {
+			if tail.hasChild(other) {
+				continue
+			}
+			tail.AppendChild(other)
+			other.AppendParent(tail)
+		}
+	}
+}
+
+// getFanOutTails 获取扇出子任务中的末端节点，即没有依赖它的扇出子任务的节点。
+func getFanOutTails(parentID string, fanOut []*TaskNode) []*TaskNode {
+	tails := make([]*TaskNode, 0)
+	visited := make(map[string]struct{})
+	queue := append([]*TaskNode{}, fanOut...)
+	for len(queue) != 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if _, exist := visited[cur.TaskID]; exist {
+			continue
+		}
+		visited[cur.TaskID] = struct{}{}
+
+		isTail := true
+		for _, child := range cur.children {
+			if child.ParentID == parentID {
+				isTail = false
+				queue = append(queue, child)
+			}
+		}
+
+		if isTail {
+			tails = append(tails, cur)
+		}
+	}
+
+	return tails
+}
+
+// isFanOutFinished 判断扇出任务的全部子任务是否都已经结束
+func (t *TaskNode) isFanOutFinished(parentID string) (finished bool) {
+	finished = true
+	walkNode(t, func(node *TaskNode) bool {
+		if node.ParentID == parentID && !node.IsFinished() {
+			finished = false
+			return false
+		}
+		return true
+	})
+
+	return
+}
+
+// rollUpFanOutResult 扇出任务的全部子任务执行结束后，将子任务的执行结果汇总到扇出任务的执行结果中。
+func rollUpFanOutResult(kt *kit.Kit, bd backend.Backend, root *TaskNode, parentID string) error {
+	if !root.isFanOutFinished(parentID) {
+		return nil
+	}
+
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("parent_id", parentID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result := action.FanOutResult{Tasks: make([]action.FanOutTaskResult, 0)}
+	for {
+		tasks, err := bd.ListTask(kt, input)
+		if err != nil {
+			logs.Errorf("list fan out tasks failed, err: %v, parent: %s, rid: %s", err, parentID, kt.Rid)
+			return err
+		}
+
+		for _, one := range tasks {
+			result.Tasks = append(result.Tasks, action.FanOutTaskResult{
+				TaskID:   one.ID,
+				ActionID: one.ActionID,
+				State:    one.State,
+				Result:   one.Result,
+			})
+		}
+
+		if len(tasks) < int(input.Page.Limit) {
+			break
+		}
+		input.Page.Start += uint32(input.Page.Limit)
+	}
+
+	field, err := types.NewJsonField(result)
+	if err != nil {
+		return fmt.Errorf("marshal fan out result failed, err: %v", err)
+	}
+
+	if err = bd.UpdateTask(kt, &model.Task{ID: parentID, Result: field}); err != nil {
+		logs.Errorf("update fan out task result failed, err: %v, parent: %s, rid: %s", err, parentID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+)
+
+func TestAddFanOutNodes(t *testing.T) {
+	// a -> b, a 动态扇出 a-0、a-1，汇聚任务 a-join
+	tasks := []*Task{
+		{Task: model.Task{ID: "a", ActionID: "a", State: enumor.TaskRunning}},
+		{Task: model.Task{ID: "b", ActionID: "b", State: enumor.TaskPending, DependOn: []action.ActIDType{"a"}}},
+	}
+	root, err := BuildTaskRoot(tasks)
+	if err != nil {
+		t.Fatalf("build task root failed, err: %v", err)
+	}
+
+	parent := &Task{Task: model.Task{ID: "a", ActionID: "a", State: enumor.TaskSuccess}}
+	parent.FanOutTasks = []*Task{
+		{Task: model.Task{ID: "c0", ActionID: "a-0", State: enumor.TaskPending, ParentID: "a",
+			DependOn: []action.ActIDType{"a"}}},
+		{Task: model.Task{ID: "c1", ActionID: "a-1", State: enumor.TaskPending, ParentID: "a",
+			DependOn: []action.ActIDType{"a"}}},
+		{Task: model.Task{ID: "join", ActionID: "a-join", State: enumor.TaskPending, ParentID: "a",
+			DependOn: []action.ActIDType{"a-0", "a-1"}}},
+	}
+	if err = root.AddFanOutNodes(parent, parent.FanOutTasks); err != nil {
+		t.Fatalf("add fan out nodes failed, err: %v", err)
+	}
+
+	next := root.GetNextTaskNodes(parent)
+	sort.Strings(next)
+	if !reflect.DeepEqual(next, []string{"c0", "c1"}) {
+		t.Errorf("next tasks should be [c0 c1], but got %v", next)
+	}
+
+	if next = root.GetNextTaskNodes(&Task{Task: model.Task{ID: "c0", State: enumor.TaskSuccess}}); len(next) != 0 {
+		t.Errorf("next tasks should be empty before all children finished, but got %v", next)
+	}
+
+	if root.isFanOutFinished("a") {
+		t.Errorf("fan out should not be finished")
+	}
+
+	next = root.GetNextTaskNodes(&Task{Task: model.Task{ID: "c1", State: enumor.TaskSuccess}})
+	if !reflect.DeepEqual(next, []string{"join"}) {
+		t.Errorf("next tasks should be [join], but got %v", next)
+	}
+
+	next = root.GetNextTaskNodes(&Task{Task: model.Task{ID: "join", State: enumor.TaskSuccess}})
+	if !reflect.DeepEqual(next, []string{"b"}) {
+		t.Errorf("next tasks should be [b], but got %v", next)
+	}
+
+	if !root.isFanOutFinished("a") {
+		t.Errorf("fan out should be finished")
+	}
+}
+
+func TestBuildTaskRootWithFanOut(t *testing.T) {
+	// 重新构建执行树时，依赖扇出任务的后续任务需要等待全部扇出子任务执行完成
+	tasks := []*Task{
+		{Task: model.Task{ID: "a", ActionID: "a", State: enumor.TaskSuccess}},
+		{Task: model.Task{ID: "b", ActionID: "b", State: enumor.TaskPending, DependOn: []action.ActIDType{"a"}}},
+		{Task: model.Task{ID: "c0", ActionID: "a-0", State: enumor.TaskSuccess, ParentID: "a",
+			DependOn: []action.ActIDType{"a"}}},
+		{Task: model.Task{ID: "c1", ActionID: "a-1", State: enumor.TaskRunning, ParentID: "a",
+			DependOn: []action.ActIDType{"a"}}},
+	}
+	root, err := BuildTaskRoot(tasks)
+	if err != nil {
+		t.Fatalf("build task root failed, err: %v", err)
+	}
+
+	if executable := root.GetExecutableTasks(); len(executable) != 0 {
+		t.Errorf("executable tasks should be empty, but got %v", executable)
+	}
+
+	next := root.GetNextTaskNodes(&Task{Task: model.Task{ID: "c1", State: enumor.TaskSuccess}})
+	if !reflect.DeepEqual(next, []string{"b"}) {
+		t.Errorf("next tasks should be [b], but got %v", next)
+	}
+}
+
+func TestRollUpFanOutResult(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+	flowID, err := bd.CreateFlow(kt, &model.Flow{
+		Name: "test_flow",
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "a", ActionName: enumor.ActionProduceTest},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("list task failed, err: %v, count: %d", err, len(tasks))
+	}
+	parent := tasks[0]
+	parent.State = enumor.TaskSuccess
+
+	ids, err := bd.BatchCreateTask(kt, []model.Task{
+		{FlowID: flowID, FlowName: "test_flow", ActionID: "a-0", ActionName: enumor.ActionProduceTest,
+			DependOn: []action.ActIDType{"a"}, ParentID: parent.ID},
+	})
+	if err != nil {
+		t.Fatalf("create fan out task failed, err: %v", err)
+	}
+
+	child := &Task{Task: model.Task{ID: ids[0], ActionID: "a-0", State: enumor.TaskPending, ParentID: parent.ID,
+		DependOn: []action.ActIDType{"a"}}}
+	root, err := BuildTaskRoot([]*Task{parent, child})
+	if err != nil {
+		t.Fatalf("build task root failed, err: %v", err)
+	}
+
+	if err = bd.UpdateTask(kt, &model.Task{ID: child.ID, State: enumor.TaskSuccess,
+		Result: types.JsonField(`{"id":"1"}`)}); err != nil {
+		t.Fatalf("update task failed, err: %v", err)
+	}
+	child.State = enumor.TaskSuccess
+	root.GetNextTaskNodes(child)
+
+	if err = rollUpFanOutResult(kt, bd, root, parent.ID); err != nil {
+		t.Fatalf("roll up fan out result failed, err: %v", err)
+	}
+
+	if tasks, err = listTaskByIDs(kt, bd, []string{parent.ID}); err != nil || len(tasks) != 1 {
+		t.Fatalf("list task failed, err: %v, count: %d", err, len(tasks))
+	}
+
+	result := new(action.FanOutResult)
+	if err = json.Unmarshal([]byte(tasks[0].Result), result); err != nil {
+		t.Fatalf("unmarshal fan out result failed, err: %v", err)
+	}
+
+	if len(result.Tasks) != 1 || result.Tasks[0].TaskID != child.ID || result.Tasks[0].State != enumor.TaskSuccess ||
+		string(result.Tasks[0].Result) != `{"id":"1"}` {
+		t.Errorf("unexpected fan out result: %+v", result)
+	}
+}
+
+type fanOutTestAction struct{}
+
+func (act fanOutTestAction) Name() enumor.ActionName {
+	return enumor.ActionSleep
+}
+
+func (act fanOutTestAction) Run(_ run.ExecuteKit, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func TestFanOutRerun(t *testing.T) {
+	action.RegisterAction(fanOutTestAction{})
+
+	kt := NewKit()
+	bd := backend.NewMemory()
+	flowID, err := bd.CreateFlow(kt, &model.Flow{
+		Name: "test_flow",
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "a", ActionName: enumor.ActionProduceTest},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	fanOut := &action.FanOut{
+		Tasks: []action.FanOutTask{{ActionName: enumor.ActionSleep}, {ActionName: enumor.ActionSleep}},
+		Join:  &action.FanOutTask{ActionName: enumor.ActionSleep},
+	}
+	runParent := func() *Task {
+		tasks, err := listTaskByFlowID(kt, bd, flowID)
+		if err != nil {
+			t.Fatalf("list task failed, err: %v", err)
+		}
+
+		var parent *Task
+		for _, one := range tasks {
+			if one.ActionID == "a" {
+				parent = one
+			}
+		}
+		parent.InitDep(run.NewExecuteContext(kt, nil, flowID, parent.ID), func(kt *kit.Kit, task *model.Task) error {
+			return bd.UpdateTask(kt, task)
+		}, nil)
+		parent.CreateTasks = bd.BatchCreateTask
+		parent.ListTasks = bd.ListTask
+
+		if err = parent.fanOut(fanOut); err != nil {
+			t.Fatalf("fan out failed, err: %v", err)
+		}
+		return parent
+	}
+
+	first := runParent()
+	// 扇出任务在更新为成功状态前崩溃，重新执行时复用已创建的子任务
+	second := runParent()
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if len(tasks) != 4 {
+		t.Fatalf("fan out tasks should not be created repeatedly, task count: %d", len(tasks))
+	}
+
+	for i := range first.FanOutTasks {
+		if first.FanOutTasks[i].ID != second.FanOutTasks[i].ID {
+			t.Errorf("rerun fan out task should reuse task: %s, but got %s", first.FanOutTasks[i].ID,
+				second.FanOutTasks[i].ID)
+		}
+	}
+
+	root, err := BuildTaskRoot(tasks)
+	if err != nil {
+		t.Fatalf("build task root failed, err: %v", err)
+	}
+
+	// 执行树中已存在的子任务不会被重复插入
+	second.State = enumor.TaskSuccess
+	if err = root.AddFanOutNodes(second, second.FanOutTasks); err != nil {
+		t.Fatalf("add fan out nodes failed, err: %v", err)
+	}
+
+	next := root.GetNextTaskNodes(second)
+	sort.Strings(next)
+	expect := []string{second.FanOutTasks[0].ID, second.FanOutTasks[1].ID}
+	sort.Strings(expect)
+	if !reflect.DeepEqual(next, expect) {
+		t.Errorf("next tasks should be %v, but got %v", expect, next)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/times"
+)
+
+// NewJanitor new janitor. term 为主节点组件启动时所在任期的fencing token。
+func NewJanitor(bd backend.Backend, term int64, opt *JanitorOption) *Janitor {
+	return &Janitor{
+		watchIntervalSec: time.Duration(opt.WatchIntervalSec) * time.Second,
+		retention:        time.Duration(opt.RetentionDays) * 24 * time.Hour,
+		batchSize:        opt.BatchSize,
+		archive:          opt.Archive,
+		bd:               bd,
+		term:             term,
+		closeCh:          make(chan struct{}),
+		wg:               new(sync.WaitGroup),
+	}
+}
+
+// Janitor 清理器，负责将已结束且超过保留时间的任务流及其任务分批移动到归档表或直接删除，
+// 回调还未投递完成的任务流会跳过，等待回调投递结束后再清理。
+type Janitor struct {
+	watchIntervalSec time.Duration
+	retention        time.Duration
+	batchSize        uint
+	archive          bool
+
+	bd backend.Backend
+	// term 主节点组件启动时所在任期的fencing token，清理任务流时携带，旧主节点的清理会失败
+	term int64
+
+	wg      *sync.WaitGroup
+	closeCh chan struct{}
+}
+
+// Start janitor.
+func (j *Janitor) Start() {
+	j.wg.Add(1)
+	go j.watch()
+}
+
+func (j *Janitor) watch() {
+	defer j.wg.Done()
+
+	for {
+		kt := NewKit()
+		if err := j.Purge(kt); err != nil {
+			logs.Errorf("%s: janitor purge expired flows failed, err: %v, rid: %s", constant.AsyncTaskWarnSign,
+				err, kt.Rid)
+		}
+
+		select {
+		case <-j.closeCh:
+			return
+		case <-time.After(j.watchIntervalSec):
+		}
+	}
+}
+
+// Purge 分批清理已结束且超过保留时间的任务流。
+func (j *Janitor) Purge(kt *kit.Kit) error {
+	expiredAt := times.ConvStdTimeFormat(times.ConvStdTimeNow().Add(-j.retention))
+	input := &backend.ListInput{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "state", Op: filter.In.Factory(), Value: finalFlowStates},
+				&filter.AtomRule{Field: "callback_pending", Op: filter.Equal.Factory(), Value: false},
+				&filter.AtomRule{Field: "updated_at", Op: filter.LessThan.Factory(), Value: expiredAt},
+			},
+		},
+		Page: &core.BasePage{Start: 0, Limit: j.batchSize},
+	}
+
+	for {
+		select {
+		case <-j.closeCh:
+			return nil
+		default:
+		}
+
+		flows, err := j.bd.ListFlow(kt, input)
+		if err != nil {
+			logs.Errorf("list expired flow failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		if len(flows) == 0 {
+			return nil
+		}
+
+		flowIDs := make([]string, 0, len(flows))
+		for _, one := range flows {
+			flowIDs = append(flowIDs, one.ID)
+		}
+
+		purgeIDs, err := j.excludeDeliveringFlows(kt, flowIDs)
+		if err != nil {
+			return err
+		}
+
+		purgeInput := &backend.PurgeFlowsInput{FlowIDs: purgeIDs, Filter: input.Filter, Archive: j.archive,
+			FencingToken: j.term}
+		if purgeIDs, err = j.bd.PurgeFlows(kt, purgeInput); err != nil {
+			logs.Errorf("purge flows failed, err: %v, ids: %v, archive: %v, rid: %s", err, purgeInput.FlowIDs,
+				j.archive, kt.Rid)
+			return err
+		}
+
+		if len(purgeIDs) != 0 {
+			logs.Infof("janitor purge flows success, ids: %v, archive: %v, rid: %s", purgeIDs, j.archive, kt.Rid)
+		}
+
+		if uint(len(flows)) < j.batchSize {
+			return nil
+		}
+
+		// 因回调待投递被跳过的任务流仍然会被查询到，需要跳过这部分数据
+		input.Page.Start += uint32(len(flowIDs) - len(purgeInput.FlowIDs))
+	}
+}
+
+// excludeDeliveringFlows 排除还有待投递回调的任务流
+func (j *Janitor) excludeDeliveringFlows(kt *kit.Kit, flowIDs []string) ([]string, error) {
+	input := &backend.ListInput{
+		Fields: []string{"flow_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "flow_id", Op: filter.In.Factory(), Value: flowIDs},
+				&filter.AtomRule{Field: "state", Op: filter.Equal.Factory(), Value: enumor.CallbackPending},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	deliveries, err := j.bd.ListCallbackDelivery(kt, input)
+	if err != nil {
+		logs.Errorf("list pending callback delivery failed, err: %v, flow ids: %v, rid: %s", err, flowIDs,
+			kt.Rid)
+		return nil, err
+	}
+
+	delivering := make(map[string]struct{}, len(deliveries))
+	for _, one := range deliveries {
+		delivering[one.FlowID] = struct{}{}
+	}
+
+	result := make([]string, 0, len(flowIDs))
+	for _, id := range flowIDs {
+		if _, exists := delivering[id]; !exists {
+			result = append(result, id)
+		}
+	}
+
+	return result, nil
+}
+
+// Close janitor.
+func (j *Janitor) Close() {
+
+	logs.Infof("janitor receive close cmd, start to close")
+
+	close(j.closeCh)
+	j.wg.Wait()
+
+	logs.Infof("janitor close success")
+
+}
@@ -0,0 +1,143 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+)
+
+func TestJanitorPurge(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+	term := registerTestFence(t, bd)
+	nf := NewNotifier(bd, term, &NotifierOption{WatchIntervalSec: 5, MaxAttempts: 2, TimeoutSec: 1,
+		Sender: new(fakeCallbackSender)})
+	jt := NewJanitor(bd, term, &JanitorOption{WatchIntervalSec: 5, RetentionDays: 1, BatchSize: 1, Archive: true})
+	// 测试中任务流刚刚结束，将保留时间设置为负数使其立即过期
+	jt.retention = -time.Minute
+
+	createFlow := func(callbacks tableasync.Callbacks, state enumor.FlowState) string {
+		flow := &model.Flow{
+			Name:      "test_flow",
+			Callbacks: callbacks,
+			Tasks: []model.Task{
+				{FlowName: "test_flow", ActionID: "1", ActionName: "test_action"},
+			},
+		}
+		flowID, err := bd.CreateFlow(kt, flow)
+		if err != nil {
+			t.Fatalf("create flow failed, err: %v", err)
+		}
+
+		if err = bd.BatchUpdateFlow(kt, []model.Flow{{ID: flowID, State: state}}); err != nil {
+			t.Fatalf("update flow failed, err: %v", err)
+		}
+		return flowID
+	}
+
+	finishedID := createFlow(nil, enumor.FlowSuccess)
+	runningID := createFlow(nil, enumor.FlowRunning)
+	callbacks := tableasync.Callbacks{{Type: enumor.CallbackHttp, URL: "http://127.0.0.1/callback"}}
+	deliveringID := createFlow(callbacks, enumor.FlowFailed)
+	if err := nf.GenerateDeliveries(kt); err != nil {
+		t.Fatalf("generate deliveries failed, err: %v", err)
+	}
+
+	if err := jt.Purge(kt); err != nil {
+		t.Fatalf("purge failed, err: %v", err)
+	}
+
+	// 已结束的任务流及其任务被清理，执行中和回调待投递的任务流保留
+	flows, err := bd.ListFlow(kt, &backend.ListInput{Filter: tools.AllExpression(),
+		Page: core.NewDefaultBasePage()})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	remains := make(map[string]struct{}, len(flows))
+	for _, one := range flows {
+		remains[one.ID] = struct{}{}
+	}
+	if _, exists := remains[finishedID]; exists || len(remains) != 2 {
+		t.Fatalf("finished flow should be purged, but remains: %v", remains)
+	}
+	for _, id := range []string{runningID, deliveringID} {
+		if _, exists := remains[id]; !exists {
+			t.Fatalf("flow: %s should not be purged", id)
+		}
+	}
+
+	tasks, err := bd.ListTask(kt, &backend.ListInput{Filter: tools.EqualExpression("flow_id", finishedID),
+		Page: core.NewDefaultBasePage()})
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Fatalf("tasks of purged flow should be purged, but got %d", len(tasks))
+	}
+}
+
+func TestPurgeFlowsRecheckState(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+
+	flow := &model.Flow{
+		Name:  "test_flow",
+		Tasks: []model.Task{{FlowName: "test_flow", ActionID: "1", ActionName: "test_action"}},
+	}
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	// 任务流在被查询为已结束后，又被重试恢复为执行中
+	if err = bd.BatchUpdateFlow(kt, []model.Flow{{ID: flowID, State: enumor.FlowRunning}}); err != nil {
+		t.Fatalf("update flow failed, err: %v", err)
+	}
+
+	input := &backend.PurgeFlowsInput{
+		FlowIDs:      []string{flowID},
+		Filter:       tools.ContainersExpression("state", finalFlowStates),
+		Archive:      true,
+		FencingToken: registerTestFence(t, bd),
+	}
+	purgedIDs, err := bd.PurgeFlows(kt, input)
+	if err != nil {
+		t.Fatalf("purge flows failed, err: %v", err)
+	}
+	if len(purgedIDs) != 0 {
+		t.Fatalf("running flow should not be purged, but purged: %v", purgedIDs)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("tasks of running flow should not be purged, but got %d", len(tasks))
+	}
+}
@@ -28,25 +28,35 @@ import (
 	"hcm/pkg/serviced"
 )
 
-// Leader 选主管理
+// Leader 选主管理，主节点通过etcd租约选举产生，每个任期有单调递增的fencing token。
 type Leader interface {
 	IsLeader() bool
+	// FencingToken 返回主节点当前任期的fencing token，非主节点返回0
+	FencingToken() int64
+	// Changed 返回主从状态变化的通知通道
+	Changed() <-chan struct{}
+	// Resign 主节点主动放弃主节点身份，由其他节点接管，用于主节点平滑交接
+	Resign() error
 	AliveNodes() ([]string, error)
+	// AliveNodeLabels 获取全部存活节点的标签，key为节点的唯一标识，未注册标签的节点标签为空
+	AliveNodeLabels() (map[string]map[string]string, error)
 	CurrNode() string
 }
 
 var _ Leader = new(leader)
 
 // NewLeader 创建一个主节点控制器
-func NewLeader(sd serviced.ServiceDiscover) Leader {
+func NewLeader(sd serviced.ServiceDiscover, el serviced.Election) Leader {
 	return &leader{
 		sd: sd,
+		el: el,
 	}
 }
 
 // leader ...
 type leader struct {
 	sd serviced.ServiceDiscover
+	el serviced.Election
 }
 
 // CurrNode return current node key.
@@ -74,7 +84,44 @@ func (al *leader) AliveNodes() ([]string, error) {
 	return keyUUIDs, nil
 }
 
+// AliveNodeLabels return all alive nodes' labels.
+func (al *leader) AliveNodeLabels() (map[string]map[string]string, error) {
+	nodes, err := al.AliveNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := al.sd.GetServiceAllNodeLabels(cc.TaskServerName)
+	if err != nil {
+		logs.Errorf("get task server all node labels failed, err: %v", err)
+		return nil, err
+	}
+
+	// 标签和节点注册使用同一个租约，但不是原子写入的，所以以存活节点为准
+	nodeLabels := make(map[string]map[string]string, len(nodes))
+	for _, one := range nodes {
+		nodeLabels[one] = labels[one]
+	}
+
+	return nodeLabels, nil
+}
+
 // IsLeader 判断是否是主节点
 func (al *leader) IsLeader() bool {
-	return al.sd.IsMaster()
+	return al.el.IsLeader()
+}
+
+// FencingToken 返回主节点当前任期的fencing token，非主节点返回0
+func (al *leader) FencingToken() int64 {
+	return al.el.FencingToken()
+}
+
+// Changed 返回主从状态变化的通知通道
+func (al *leader) Changed() <-chan struct{} {
+	return al.el.Changed()
+}
+
+// Resign 主节点主动放弃主节点身份，由其他节点接管
+func (al *leader) Resign() error {
+	return al.el.Resign()
 }
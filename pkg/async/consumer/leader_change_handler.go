@@ -30,11 +30,12 @@ import (
 )
 
 // NewLeaderChangeHandler new leader change handler.
-func NewLeaderChangeHandler(bd backend.Backend, ld leader.Leader, opt *Option) *LeaderChangeHandler {
+func NewLeaderChangeHandler(bd backend.Backend, ld leader.Leader, mc *metric, opt *Option) *LeaderChangeHandler {
 	return &LeaderChangeHandler{
 		opt:     opt,
 		ld:      ld,
 		bd:      bd,
+		mc:      mc,
 		closeCh: make(chan struct{}),
 		closers: make([]compctrl.Closer, 0),
 		wg:      sync.WaitGroup{},
@@ -47,12 +48,15 @@ type LeaderChangeHandler struct {
 
 	ld leader.Leader
 	bd backend.Backend
+	mc *metric
 
 	dispatcher *Dispatcher
 	watchDog   WatchDog
 
 	closeCh chan struct{}
 
+	// term 主节点组件启动时所在任期的fencing token，主节点组件关闭时为0
+	term    int64
 	closers []compctrl.Closer
 	wg      sync.WaitGroup
 }
@@ -63,56 +67,89 @@ func (handler *LeaderChangeHandler) Start() {
 	go handler.Do()
 }
 
-// Do 负责主节点组件的开启和关闭，在切主/切从的时候。
+// Do 负责主节点组件的开启和关闭，在切主/切从的时候。主从状态变化时立即处理，同时每秒兜底检查一次。
 func (handler *LeaderChangeHandler) Do() {
-	for {
-		time.Sleep(time.Second)
+	defer handler.wg.Done()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
 
-		// 如果被关闭，退出循环
+	for {
 		select {
 		case <-handler.closeCh:
-			handler.closeLeaderComponent()
-			break
-		default:
+			return
+		case <-handler.ld.Changed():
+		case <-ticker.C:
 		}
 
-		// 如果是从节点，且主节点组件处于关闭状态，直接跳过即可
-		if !handler.ld.IsLeader() && len(handler.closers) == 0 {
-			continue
-		}
+		handler.handle()
+	}
+}
 
-		// 如果是主切从（从节点，但主节点组件处于开启状态），需要关闭主节点组件
-		if !handler.ld.IsLeader() && len(handler.closers) != 0 {
-			logs.Infof("the current node changes from the master node to the slave node, " +
-				"and start to stop handleRunningFlow async tasks")
+// handle 根据当前节点主从状态及任期开启或关闭主节点组件
+func (handler *LeaderChangeHandler) handle() {
+	isLeader := handler.ld.IsLeader()
+	token := handler.ld.FencingToken()
 
-			handler.closeLeaderComponent()
-			continue
-		}
+	// 如果是主切从，或者主节点任期发生了变化（失去主节点后又重新当选），需要关闭上一任期的主节点组件
+	if len(handler.closers) != 0 && (!isLeader || token != handler.term) {
+		logs.Infof("the current node changes from the master node(term: %d) to the slave node, "+
+			"and start to stop handleRunningFlow async tasks", handler.term)
 
-		// 如果是从切主，需要开启主节点组件
-		if handler.ld.IsLeader() && len(handler.closers) == 0 {
-			logs.Infof("the current node is master, start leader component...")
-			handler.startLeaderComponent()
-			logs.Infof("the current node is master, start leader success")
-			continue
-		}
+		handler.closeLeaderComponent()
+		handler.mc.becomeFollower()
+	}
+
+	// 如果是从节点，且主节点组件处于关闭状态，直接跳过即可
+	if !isLeader || len(handler.closers) != 0 {
+		return
 	}
 
-	handler.wg.Done()
+	// 如果是从切主，需要先登记当前任期的fencing token，使上一任期主节点的任务流状态更新全部失效，再开启主节点组件
+	kt := NewKit()
+	opt := &backend.UpdateLeaderFenceOption{
+		Worker: handler.ld.CurrNode(),
+		Token:  token,
+	}
+	if err := handler.bd.UpdateLeaderFence(kt, opt); err != nil {
+		logs.Errorf("update leader fencing token failed, skip start leader component, err: %v, token: %d, rid: %s",
+			err, token, kt.Rid)
+		return
+	}
+
+	logs.Infof("the current node is master(term: %d), start leader component...", token)
+	handler.startLeaderComponent(token)
+	handler.term = token
+	handler.mc.becomeLeader(token)
+	logs.Infof("the current node is master(term: %d), start leader success", token)
 }
 
-func (handler *LeaderChangeHandler) startLeaderComponent() {
-	dis := NewDispatcher(handler.bd, handler.ld, handler.opt.Dispatcher)
+// startLeaderComponent 启动主节点组件，组件使用启动时所在任期的fencing token更新，不会读取之后变化的token
+func (handler *LeaderChangeHandler) startLeaderComponent(term int64) {
+	dis := NewDispatcher(handler.bd, handler.ld, term, handler.opt.Dispatcher)
 	dis.Start()
 	handler.closers = append(handler.closers, dis)
 	handler.dispatcher = dis
 
 	// 初始化watchdog并启动同时设置关闭函数
-	wd := NewWatchDog(handler.bd, handler.ld, handler.opt.WatchDog)
+	wd := NewWatchDog(handler.bd, handler.ld, term, handler.opt.WatchDog)
 	wd.Start()
 	handler.closers = append(handler.closers, wd)
 	handler.watchDog = wd
+
+	// 初始化通知器并启动同时设置关闭函数
+	if handler.opt.Notifier != nil {
+		nf := NewNotifier(handler.bd, term, handler.opt.Notifier)
+		nf.Start()
+		handler.closers = append(handler.closers, nf)
+	}
+
+	// 初始化清理器并启动同时设置关闭函数
+	if handler.opt.Janitor != nil {
+		jt := NewJanitor(handler.bd, term, handler.opt.Janitor)
+		jt.Start()
+		handler.closers = append(handler.closers, jt)
+	}
 }
 
 // Close 主从切换处理器
@@ -121,8 +158,8 @@ func (handler *LeaderChangeHandler) Close() {
 	logs.Infof("LeaderChangeHandler receive close cmd, start to close")
 
 	close(handler.closeCh)
-	handler.closeLeaderComponent()
 	handler.wg.Wait()
+	handler.closeLeaderComponent()
 
 	logs.Infof("LeaderChangeHandler close success")
 
@@ -135,5 +172,6 @@ func (handler *LeaderChangeHandler) closeLeaderComponent() {
 		handler.closers[i].Close()
 	}
 	handler.closers = make([]compctrl.Closer, 0)
+	handler.term = 0
 
 }
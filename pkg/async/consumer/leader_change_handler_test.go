@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hcm/pkg/async/backend"
+)
+
+// termLeader 任期可变的主节点控制器
+type termLeader struct {
+	fakeLeader
+	token int64
+}
+
+func (l *termLeader) FencingToken() int64 { return atomic.LoadInt64(&l.token) }
+
+func TestLeaderChangeHandlerTermChange(t *testing.T) {
+	bd := backend.NewMemory()
+	ld := &termLeader{fakeLeader: fakeLeader{labels: map[string]map[string]string{"node1": nil}}, token: 1}
+	opt := &Option{
+		Dispatcher: &DispatcherOption{WatchIntervalSec: 1},
+		WatchDog:   &WatchDogOption{WatchIntervalSec: 1, TaskRunTimeoutSec: 60, ShutdownWaitTimeSec: 1},
+	}
+	handler := NewLeaderChangeHandler(bd, ld, nil, opt)
+
+	handleWithin := func(timeout time.Duration) {
+		done := make(chan struct{})
+		go func() {
+			handler.handle()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(timeout):
+			t.Fatalf("leader change handle not finished in %s", timeout)
+		}
+	}
+
+	handleWithin(5 * time.Second)
+	if handler.term != 1 || handler.dispatcher == nil || handler.watchDog == nil {
+		t.Fatalf("leader component of term 1 should be started, term: %d", handler.term)
+	}
+	oldDispatcher, oldWatchDog := handler.dispatcher, handler.watchDog
+
+	// 任期变化后，上一任期的组件需要在超时时间内全部停止，并启动当前任期的组件
+	atomic.StoreInt64(&ld.token, 2)
+	handleWithin(5 * time.Second)
+
+	select {
+	case <-oldDispatcher.closeCh:
+	default:
+		t.Errorf("dispatcher of term 1 should be closed")
+	}
+	select {
+	case <-oldWatchDog.(*watchDog).closeCh:
+	default:
+		t.Errorf("watch dog of term 1 should be closed")
+	}
+
+	if handler.term != 2 || handler.dispatcher == oldDispatcher || handler.dispatcher.term != 2 {
+		t.Errorf("leader component of term 2 should be started, term: %d", handler.term)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		handler.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("leader change handler close not finished")
+	}
+}
@@ -20,6 +20,8 @@
 package consumer
 
 import (
+	"hcm/pkg/metrics"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -27,9 +29,71 @@ import (
 
 func initMetric(register prometheus.Registerer) *metric {
 	m := new(metric)
+	labels := prometheus.Labels{}
+
+	m.leaderChangeCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   metrics.Namespace,
+			Subsystem:   metrics.AsyncSubSys,
+			Name:        "leader_change_total",
+			Help:        "the total count of leadership changes of current node",
+			ConstLabels: labels,
+		}, []string{"role"})
+	register.MustRegister(m.leaderChangeCounter)
+
+	m.isLeader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   metrics.Namespace,
+		Subsystem:   metrics.AsyncSubSys,
+		Name:        "is_leader",
+		Help:        "whether current node is the leader, 1 means leader, 0 means follower",
+		ConstLabels: labels,
+	})
+	register.MustRegister(m.isLeader)
+
+	m.fencingToken = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace:   metrics.Namespace,
+		Subsystem:   metrics.AsyncSubSys,
+		Name:        "leader_fencing_token",
+		Help:        "the fencing token of current leader term, 0 means current node is not leader",
+		ConstLabels: labels,
+	})
+	register.MustRegister(m.fencingToken)
 
 	return m
 }
 
 type metric struct {
+	// leaderChangeCounter record the total count of leadership changes, role is the role after change.
+	leaderChangeCounter *prometheus.CounterVec
+
+	// isLeader record whether current node is the leader.
+	isLeader prometheus.Gauge
+
+	// fencingToken record the fencing token of current leader term.
+	fencingToken prometheus.Gauge
+}
+
+const (
+	roleLeader   = "leader"
+	roleFollower = "follower"
+)
+
+// becomeLeader 记录当前节点切主
+func (m *metric) becomeLeader(token int64) {
+	if m == nil {
+		return
+	}
+	m.leaderChangeCounter.WithLabelValues(roleLeader).Inc()
+	m.isLeader.Set(1)
+	m.fencingToken.Set(float64(token))
+}
+
+// becomeFollower 记录当前节点切从
+func (m *metric) becomeFollower() {
+	if m == nil {
+		return
+	}
+	m.leaderChangeCounter.WithLabelValues(roleFollower).Inc()
+	m.isLeader.Set(0)
+	m.fencingToken.Set(0)
 }
@@ -0,0 +1,358 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+)
+
+const (
+	// maxCallbackBackoff 回调投递失败后重试的最大退避时间
+	maxCallbackBackoff = time.Hour
+	// maxCallbackRespBody 回调投递失败时，记录到失败原因中的响应体最大长度
+	maxCallbackRespBody = 512
+)
+
+// finalFlowStates 任务流的最终状态，任务流进入这些状态后发送回调通知
+var finalFlowStates = []enumor.FlowState{enumor.FlowSuccess, enumor.FlowFailed, enumor.FlowCancel,
+	enumor.FlowCompensated, enumor.FlowCompensateFailed}
+
+// CallbackSender 回调发送器，负责将签名后的回调事件发送给订阅方。
+type CallbackSender interface {
+	Send(kt *kit.Kit, callback *tableasync.Callback, header http.Header, body []byte) error
+}
+
+// NewHttpCallbackSender new http callback sender, only http type callback is supported.
+func NewHttpCallbackSender() CallbackSender {
+	return &httpCallbackSender{client: new(http.Client)}
+}
+
+type httpCallbackSender struct {
+	client *http.Client
+}
+
+// Send 通过POST请求将回调事件发送到回调地址，响应状态码为2xx时认为发送成功。
+func (s *httpCallbackSender) Send(kt *kit.Kit, callback *tableasync.Callback, header http.Header,
+	body []byte) error {
+
+	if callback.Type != enumor.CallbackHttp {
+		return fmt.Errorf("http callback sender not support callback type: %s", callback.Type)
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, callback.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header.Clone()
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxCallbackRespBody))
+		return fmt.Errorf("callback response status: %d, body: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// NewNotifier new notifier. term 为主节点组件启动时所在任期的fencing token。
+func NewNotifier(bd backend.Backend, term int64, opt *NotifierOption) *Notifier {
+	sender := opt.Sender
+	if sender == nil {
+		sender = NewHttpCallbackSender()
+	}
+
+	return &Notifier{
+		watchIntervalSec: time.Duration(opt.WatchIntervalSec) * time.Second,
+		maxAttempts:      opt.MaxAttempts,
+		timeoutSec:       time.Duration(opt.TimeoutSec) * time.Second,
+		secret:           opt.Secret,
+		sender:           sender,
+		bd:               bd,
+		term:             term,
+		closeCh:          make(chan struct{}),
+		wg:               new(sync.WaitGroup),
+	}
+}
+
+// Notifier 通知器，负责为进入最终状态且订阅了回调的任务流生成回调投递记录，并按照投递记录发送签名后的回调通知，
+// 投递失败后按照指数退避重试，超过最大投递次数后投递记录置为失败，可以通过接口重新投递。
+type Notifier struct {
+	watchIntervalSec time.Duration
+	maxAttempts      uint
+	timeoutSec       time.Duration
+	secret           string
+	sender           CallbackSender
+
+	bd backend.Backend
+	// term 主节点组件启动时所在任期的fencing token，更新任务流回调标记和投递记录时携带，旧主节点的更新会失败
+	term int64
+
+	wg      *sync.WaitGroup
+	closeCh chan struct{}
+}
+
+// Start notifier.
+func (n *Notifier) Start() {
+	n.wg.Add(1)
+	go n.watch()
+}
+
+func (n *Notifier) watch() {
+	defer n.wg.Done()
+
+	for {
+		kt := NewKit()
+		if err := n.GenerateDeliveries(kt); err != nil {
+			logs.Errorf("%s: notifier generate callback deliveries failed, err: %v, rid: %s",
+				constant.AsyncTaskWarnSign, err, kt.Rid)
+		}
+
+		if err := n.Deliver(kt); err != nil {
+			logs.Errorf("%s: notifier deliver callbacks failed, err: %v, rid: %s", constant.AsyncTaskWarnSign,
+				err, kt.Rid)
+		}
+
+		select {
+		case <-n.closeCh:
+			return
+		case <-time.After(n.watchIntervalSec):
+		}
+	}
+}
+
+// GenerateDeliveries 为进入最终状态且还未生成回调的任务流，按照回调订阅生成投递记录。
+func (n *Notifier) GenerateDeliveries(kt *kit.Kit) error {
+	input := &backend.ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "callback_pending", Op: filter.Equal.Factory(), Value: true},
+				&filter.AtomRule{Field: "state", Op: filter.In.Factory(), Value: finalFlowStates},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	flows, err := n.bd.ListFlow(kt, input)
+	if err != nil {
+		logs.Errorf("list callback pending flow failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	for _, flow := range flows {
+		if err = n.generateFlowDeliveries(kt, flow); err != nil {
+			logs.Errorf("generate flow callback deliveries failed, err: %v, flow: %s, rid: %s", err, flow.ID,
+				kt.Rid)
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (n *Notifier) generateFlowDeliveries(kt *kit.Kit, flow model.Flow) error {
+	if len(flow.Callbacks) != 0 {
+		payload, err := n.buildEventPayload(kt, flow)
+		if err != nil {
+			return err
+		}
+
+		now := times.ConvStdTimeFormat(times.ConvStdTimeNow())
+		deliveries := make([]model.CallbackDelivery, 0, len(flow.Callbacks))
+		for index := range flow.Callbacks {
+			deliveries = append(deliveries, model.CallbackDelivery{
+				FlowID:    flow.ID,
+				FlowState: flow.State,
+				Callback:  &flow.Callbacks[index],
+				Payload:   payload,
+				NextAt:    now,
+			})
+		}
+
+		if _, err = n.bd.BatchCreateCallbackDelivery(kt, deliveries); err != nil {
+			logs.Errorf("create callback deliveries failed, err: %v, flow: %s, rid: %s", err, flow.ID, kt.Rid)
+			return err
+		}
+	}
+
+	// 投递记录生成后再更新标记，异常中断时可能重复生成投递记录，订阅方需要按照任务流ID和状态去重
+	md := model.Flow{ID: flow.ID, CallbackPending: converter.ValToPtr(false)}
+	if err := n.bd.FencedBatchUpdateFlow(kt, n.term, []model.Flow{md}); err != nil {
+		logs.Errorf("update flow callback pending failed, err: %v, flow: %s, rid: %s", err, flow.ID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// buildEventPayload 构建任务流回调事件，事件中包含全部任务的执行结果。
+func (n *Notifier) buildEventPayload(kt *kit.Kit, flow model.Flow) (types.JsonField, error) {
+	tasks, err := listTaskByFlowID(kt, n.bd, flow.ID)
+	if err != nil {
+		return "", err
+	}
+
+	event := &coreasync.FlowCallbackEvent{
+		FlowID:     flow.ID,
+		FlowName:   flow.Name,
+		State:      flow.State,
+		Reason:     flow.Reason,
+		Memo:       flow.Memo,
+		FinishedAt: flow.UpdatedAt,
+		Tasks:      make([]coreasync.FlowCallbackEventTask, 0, len(tasks)),
+	}
+	for _, one := range tasks {
+		event.Tasks = append(event.Tasks, coreasync.FlowCallbackEventTask{
+			ID:         one.ID,
+			ActionID:   string(one.ActionID),
+			ActionName: one.ActionName,
+			State:      one.State,
+			Reason:     one.Reason,
+			Result:     one.Result,
+		})
+	}
+
+	return types.NewJsonField(event)
+}
+
+// Deliver 发送到达投递时间的回调。
+func (n *Notifier) Deliver(kt *kit.Kit) error {
+	input := &backend.ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "state", Op: filter.Equal.Factory(), Value: enumor.CallbackPending},
+				&filter.AtomRule{Field: "next_at", Op: filter.LessThanEqual.Factory(),
+					Value: times.ConvStdTimeFormat(time.Now())},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+			Sort:  "next_at",
+			Order: core.Ascending,
+		},
+	}
+	deliveries, err := n.bd.ListCallbackDelivery(kt, input)
+	if err != nil {
+		logs.Errorf("list pending callback delivery failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	for _, one := range deliveries {
+		md := n.deliverOne(kt, one)
+		if err = n.bd.FencedUpdateCallbackDelivery(kt, n.term, md); err != nil {
+			logs.Errorf("update callback delivery failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+			continue
+		}
+	}
+
+	return nil
+}
+
+// deliverOne 发送一次回调，返回需要更新的投递记录内容。
+func (n *Notifier) deliverOne(kt *kit.Kit, delivery model.CallbackDelivery) *model.CallbackDelivery {
+	attempts := converter.PtrToVal(delivery.Attempts) + 1
+	md := &model.CallbackDelivery{
+		ID:       delivery.ID,
+		Attempts: converter.ValToPtr(attempts),
+	}
+
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	header := http.Header{}
+	header.Set(coreasync.CallbackDeliveryHeader, delivery.ID)
+	header.Set(coreasync.CallbackTimestampHeader, timestamp)
+	header.Set(coreasync.CallbackSignatureHeader, coreasync.SignCallback(n.secret, timestamp, body))
+
+	sendKt := kt.NewSubKit()
+	cancel := sendKt.CtxWithTimeoutMS(int(n.timeoutSec.Milliseconds()))
+	err := n.sender.Send(sendKt, delivery.Callback, header, body)
+	cancel()
+
+	if err == nil {
+		md.State = enumor.CallbackSuccess
+		md.Reason = new(tableasync.Reason)
+		return md
+	}
+
+	logs.Warnf("deliver flow callback failed, err: %v, id: %s, flow: %s, attempts: %d, rid: %s", err, delivery.ID,
+		delivery.FlowID, attempts, kt.Rid)
+
+	md.Reason = &tableasync.Reason{Message: err.Error()}
+	if attempts >= n.maxAttempts {
+		md.State = enumor.CallbackFailed
+		return md
+	}
+
+	md.NextAt = times.ConvStdTimeFormat(time.Now().Add(n.backoff(attempts)))
+	return md
+}
+
+// backoff 回调投递失败后的重试间隔，按照投递次数指数退避。
+func (n *Notifier) backoff(attempts uint) time.Duration {
+	backoff := n.watchIntervalSec
+	for i := uint(1); i < attempts && backoff < maxCallbackBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxCallbackBackoff {
+		return maxCallbackBackoff
+	}
+
+	return backoff
+}
+
+// Close 等待当前执行体执行完成后再关闭
+func (n *Notifier) Close() {
+
+	logs.Infof("notifier receive close cmd, start to close")
+
+	close(n.closeCh)
+	n.wg.Wait()
+
+	logs.Infof("notifier close success")
+
+}
@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+type fakeCallbackSender struct {
+	err     error
+	headers []http.Header
+	bodies  [][]byte
+}
+
+func (s *fakeCallbackSender) Send(kt *kit.Kit, callback *tableasync.Callback, header http.Header,
+	body []byte) error {
+
+	s.headers = append(s.headers, header)
+	s.bodies = append(s.bodies, body)
+	return s.err
+}
+
+func TestNotifierDeliver(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+	sender := new(fakeCallbackSender)
+	nf := NewNotifier(bd, registerTestFence(t, bd), &NotifierOption{WatchIntervalSec: 5, MaxAttempts: 2,
+		TimeoutSec: 1, Secret: "secret", Sender: sender})
+
+	flow := &model.Flow{
+		Name:      "test_flow",
+		Callbacks: tableasync.Callbacks{{Type: enumor.CallbackHttp, URL: "http://127.0.0.1/callback"}},
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: "test_action"},
+		},
+	}
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	// 未结束的任务流不生成投递记录
+	if err = nf.GenerateDeliveries(kt); err != nil {
+		t.Fatalf("generate deliveries failed, err: %v", err)
+	}
+	if deliveries := listDeliveries(t, bd); len(deliveries) != 0 {
+		t.Fatalf("running flow should not generate delivery, but got %d", len(deliveries))
+	}
+
+	md := model.Flow{ID: flowID, State: enumor.FlowSuccess}
+	if err = bd.BatchUpdateFlow(kt, []model.Flow{md}); err != nil {
+		t.Fatalf("update flow failed, err: %v", err)
+	}
+
+	// 重复执行只生成一次投递记录
+	for i := 0; i < 2; i++ {
+		if err = nf.GenerateDeliveries(kt); err != nil {
+			t.Fatalf("generate deliveries failed, err: %v", err)
+		}
+	}
+	deliveries := listDeliveries(t, bd)
+	if len(deliveries) != 1 {
+		t.Fatalf("finished flow should generate 1 delivery, but got %d", len(deliveries))
+	}
+
+	// 第一次投递失败，等待重试
+	sender.err = errors.New("connection refused")
+	if err = nf.Deliver(kt); err != nil {
+		t.Fatalf("deliver failed, err: %v", err)
+	}
+	deliveries = listDeliveries(t, bd)
+	if deliveries[0].State != enumor.CallbackPending || converter.PtrToVal(deliveries[0].Attempts) != 1 {
+		t.Fatalf("delivery should be pending with 1 attempt, but got %s, %d", deliveries[0].State,
+			converter.PtrToVal(deliveries[0].Attempts))
+	}
+
+	// 未到重试时间不投递
+	if err = nf.Deliver(kt); err != nil {
+		t.Fatalf("deliver failed, err: %v", err)
+	}
+	if len(sender.bodies) != 1 {
+		t.Fatalf("delivery should not retry before next_at, but sent %d times", len(sender.bodies))
+	}
+
+	// 到达最大投递次数后置为失败
+	update := &model.CallbackDelivery{ID: deliveries[0].ID, NextAt: time.Now().Add(-time.Second).Format(
+		time.RFC3339)}
+	if err = bd.UpdateCallbackDelivery(kt, update); err != nil {
+		t.Fatalf("update delivery failed, err: %v", err)
+	}
+	if err = nf.Deliver(kt); err != nil {
+		t.Fatalf("deliver failed, err: %v", err)
+	}
+	deliveries = listDeliveries(t, bd)
+	if deliveries[0].State != enumor.CallbackFailed {
+		t.Fatalf("delivery should be failed after max attempts, but got %s", deliveries[0].State)
+	}
+
+	header := sender.headers[1]
+	body := sender.bodies[1]
+	if !coreasync.VerifyCallback("secret", header.Get(coreasync.CallbackTimestampHeader),
+		header.Get(coreasync.CallbackSignatureHeader), body) {
+		t.Errorf("callback signature verify failed")
+	}
+}
+
+func TestNotifierBackoff(t *testing.T) {
+	nf := &Notifier{watchIntervalSec: 5 * time.Second}
+
+	if backoff := nf.backoff(1); backoff != 5*time.Second {
+		t.Errorf("first backoff should be 5s, but got %s", backoff)
+	}
+
+	if backoff := nf.backoff(3); backoff != 20*time.Second {
+		t.Errorf("third backoff should be 20s, but got %s", backoff)
+	}
+
+	if backoff := nf.backoff(100); backoff != maxCallbackBackoff {
+		t.Errorf("backoff should be capped at %s, but got %s", maxCallbackBackoff, backoff)
+	}
+}
+
+func listDeliveries(t *testing.T, bd backend.Backend) []model.CallbackDelivery {
+	input := &backend.ListInput{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+	deliveries, err := bd.ListCallbackDelivery(NewKit(), input)
+	if err != nil {
+		t.Fatalf("list delivery failed, err: %v", err)
+	}
+
+	return deliveries
+}
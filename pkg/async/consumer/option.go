@@ -19,7 +19,12 @@
 
 package consumer
 
-import "hcm/pkg/criteria/validator"
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
 
 // Option defines consumer run option.
 type Option struct {
@@ -27,6 +32,10 @@ type Option struct {
 	Executor   *ExecutorOption   `json:"executor" validate:"required"`
 	Dispatcher *DispatcherOption `json:"dispatcher" validate:"required"`
 	WatchDog   *WatchDogOption   `json:"watch_dog" validate:"required"`
+	// Notifier 不设置时不发送任务流回调
+	Notifier *NotifierOption `json:"notifier" validate:"omitempty"`
+	// Janitor 不设置时不清理已结束的任务流
+	Janitor *JanitorOption `json:"janitor" validate:"omitempty"`
 }
 
 // Validate Option
@@ -49,6 +58,8 @@ func (opt SchedulerOption) Validate() error {
 type ExecutorOption struct {
 	WorkerNumber       uint `json:"worker_number" validate:"required"`
 	TaskExecTimeoutSec uint `json:"task_exec_timeout_sec" validate:"required"`
+	// RateLimits 按照账号、云厂商接口分组限制任务调用云厂商接口的速率，令牌桶由所有节点共享，未设置的接口分组不限制
+	RateLimits []RateLimitRule `json:"rate_limits" validate:"omitempty,dive"`
 }
 
 // Validate ExecutorOption
@@ -56,9 +67,24 @@ func (opt ExecutorOption) Validate() error {
 	return validator.Validate.Struct(opt)
 }
 
+// RateLimitRule 限流规则，同一个账号下的每个云厂商接口分组使用一个令牌桶
+type RateLimitRule struct {
+	Vendor enumor.Vendor `json:"vendor" validate:"required"`
+	// APIGroup 云厂商接口分组，如 cvm
+	APIGroup string `json:"api_group" validate:"required"`
+	// Rate 令牌桶每秒生成的令牌数
+	Rate float64 `json:"rate" validate:"gt=0"`
+	// Burst 令牌桶容量，即允许的最大突发请求数
+	Burst uint `json:"burst" validate:"min=1"`
+}
+
 // DispatcherOption 主节点组件，负责派发任务
 type DispatcherOption struct {
 	WatchIntervalSec uint `json:"watch_interval_sec" validate:"required"`
+	// FlowNameMaxRunning 每种任务流同时处于调度中和执行中的最大数量，未设置的任务流不限制
+	FlowNameMaxRunning map[enumor.FlowName]uint `json:"flow_name_max_running" validate:"omitempty"`
+	// AccountMaxRunning 每个云账号同时处于调度中和执行中的最大任务流数量，0表示不限制
+	AccountMaxRunning uint `json:"account_max_running" validate:"omitempty"`
 }
 
 // Validate DispatcherOption
@@ -77,3 +103,45 @@ type WatchDogOption struct {
 func (opt WatchDogOption) Validate() error {
 	return validator.Validate.Struct(opt)
 }
+
+// NotifierOption 主节点组件，负责发送任务流结束后的回调通知
+type NotifierOption struct {
+	WatchIntervalSec uint `json:"watch_interval_sec" validate:"required"`
+	// MaxAttempts 回调最大投递次数，超过后投递记录置为失败，只能通过接口重新投递
+	MaxAttempts uint `json:"max_attempts" validate:"required"`
+	// TimeoutSec 单次回调请求的超时时间
+	TimeoutSec uint `json:"timeout_sec" validate:"required"`
+	// Secret 回调请求的签名密钥
+	Secret string `json:"-" validate:"omitempty"`
+	// Sender 回调发送器，不设置时只支持http类型的回调
+	Sender CallbackSender `json:"-" validate:"-"`
+}
+
+// Validate NotifierOption
+func (opt NotifierOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.MaxAttempts == 0 {
+		return errors.New("max_attempts is required")
+	}
+
+	return nil
+}
+
+// JanitorOption 主节点组件，负责归档或清理超过保留时间的已结束任务流
+type JanitorOption struct {
+	WatchIntervalSec uint `json:"watch_interval_sec" validate:"required"`
+	// RetentionDays 已结束任务流的保留天数
+	RetentionDays uint `json:"retention_days" validate:"required"`
+	// BatchSize 每批次清理的任务流数量
+	BatchSize uint `json:"batch_size" validate:"required,max=500"`
+	// Archive 为true时将任务流移动到归档表，否则直接删除
+	Archive bool `json:"archive" validate:"omitempty"`
+}
+
+// Validate JanitorOption
+func (opt JanitorOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
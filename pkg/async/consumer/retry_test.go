@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/times"
+)
+
+// retryTestAction 按照顺序返回指定错误的测试Action，记录回滚次数。
+type retryTestAction struct {
+	errs      []error
+	runs      *int
+	rollbacks *int
+}
+
+// Name ...
+func (act retryTestAction) Name() enumor.ActionName {
+	return enumor.ActionAssembleTest
+}
+
+// Run ...
+func (act retryTestAction) Run(_ run.ExecuteKit, _ interface{}) (interface{}, error) {
+	err := act.errs[*act.runs]
+	*act.runs++
+	return nil, err
+}
+
+// Rollback ...
+func (act retryTestAction) Rollback(_ run.ExecuteKit, _ interface{}) error {
+	*act.rollbacks++
+	return nil
+}
+
+func newRetryTestTask(t *testing.T, kt *kit.Kit, bd backend.Backend) *Task {
+	retry := &tableasync.Retry{
+		Enable: true,
+		Policy: &tableasync.RetryPolicy{
+			Count:   3,
+			Backoff: &tableasync.BackoffPolicy{InitialMS: 1000, MaxMS: 60000},
+		},
+	}
+	flowID, err := bd.CreateFlow(kt, &model.Flow{
+		Name: "test_flow",
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: enumor.ActionAssembleTest, Retry: retry},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	return getRetryTestTask(t, kt, bd, flowID)
+}
+
+func getRetryTestTask(t *testing.T, kt *kit.Kit, bd backend.Backend, flowID string) *Task {
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	task := tasks[0]
+	task.InitDep(run.NewExecuteContext(kt, nil, flowID, task.ID), func(kt *kit.Kit, task *model.Task) error {
+		return bd.UpdateTask(kt, task)
+	}, nil)
+	return task
+}
+
+func TestTaskRetryLater(t *testing.T) {
+	runs, rollbacks := 0, 0
+	errs := []error{errors.New("connection reset"), action.NewRetryableError(errors.New("RequestLimitExceeded"),
+		10*time.Minute), errors.New("connection reset")}
+	action.RegisterAction(retryTestAction{errs: errs, runs: &runs, rollbacks: &rollbacks})
+
+	kt := NewKit()
+	bd := backend.NewMemory()
+	task := newRetryTestTask(t, kt, bd)
+
+	// 第一次执行失败后进入重试等待状态，释放执行器
+	if err := task.Run(); err != nil {
+		t.Fatalf("task run failed, err: %v", err)
+	}
+	task = getRetryTestTask(t, kt, bd, task.FlowID)
+	if task.State != enumor.TaskRetrying || task.RetryState == nil || task.RetryState.Attempts != 1 {
+		t.Fatalf("task should be retrying with 1 attempt, but got %s, %+v", task.State, task.RetryState)
+	}
+	if isRetryDue(task, times.ConvStdTimeNow()) {
+		t.Fatalf("task should not retry before next_at: %s", task.RetryState.NextAt)
+	}
+
+	// 到达重试时间后先回滚再执行，限频错误按照建议的时间等待重试
+	retryTask := func() *Task {
+		info := &backend.UpdateTaskInfo{ID: task.ID, Source: enumor.TaskRetrying, Target: enumor.TaskRollback}
+		if err := bd.UpdateTaskStateByCAS(kt, info); err != nil {
+			t.Fatalf("update task state failed, err: %v", err)
+		}
+
+		one := getRetryTestTask(t, kt, bd, task.FlowID)
+		if err := one.Run(); err != nil && one.State != enumor.TaskFailed {
+			t.Fatalf("task run failed, err: %v", err)
+		}
+		return getRetryTestTask(t, kt, bd, task.FlowID)
+	}
+	task = retryTask()
+	if task.State != enumor.TaskRetrying || task.RetryState.Attempts != 2 || rollbacks != 1 {
+		t.Fatalf("task should be rollbacks and retrying with 2 attempts, but got %s, %+v, rollback: %d",
+			task.State, task.RetryState, rollbacks)
+	}
+	if isRetryDue(task, times.ConvStdTimeNow().Add(5*time.Minute)) {
+		t.Fatalf("task should wait retry after of retryable error, but next_at: %s", task.RetryState.NextAt)
+	}
+
+	// 超过最大执行次数后任务失败
+	task = retryTask()
+	if task.State != enumor.TaskFailed || runs != 3 {
+		t.Fatalf("task should be failed after 3 runs, but got %s, runs: %d", task.State, runs)
+	}
+}
+
+func TestTaskPermanentErrorNotRetry(t *testing.T) {
+	runs, rollbacks := 0, 0
+	errs := []error{action.NewPermanentError(errors.New("invalid param"))}
+	action.RegisterAction(retryTestAction{errs: errs, runs: &runs, rollbacks: &rollbacks})
+
+	kt := NewKit()
+	bd := backend.NewMemory()
+	task := newRetryTestTask(t, kt, bd)
+
+	if err := task.Run(); err == nil {
+		t.Fatalf("task run with permanent error should return error")
+	}
+
+	task = getRetryTestTask(t, kt, bd, task.FlowID)
+	if task.State != enumor.TaskFailed || runs != 1 {
+		t.Fatalf("task should be failed without retry, but got %s, runs: %d", task.State, runs)
+	}
+}
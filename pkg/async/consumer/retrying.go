@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+)
+
+// watchRetryingTask 检查当前节点正在执行的任务流中处于重试等待状态的任务，到达下次重试时间后，
+// 将任务更新为回滚状态并重新推送到执行器，由执行器先回滚再执行。因为限流而等待的任务恢复为等待前的状态。
+func (sch *scheduler) watchRetryingTask(kt *kit.Kit) error {
+	trees := make(map[string]*TaskTree)
+	sch.taskTrees.Range(func(key, value interface{}) bool {
+		trees[key.(string)] = value.(*TaskTree)
+		return true
+	})
+
+	if len(trees) == 0 {
+		return nil
+	}
+
+	flowIDs := make([]string, 0, len(trees))
+	for id := range trees {
+		flowIDs = append(flowIDs, id)
+	}
+
+	now := times.ConvStdTimeNow()
+	for _, partIDs := range slice.Split(flowIDs, int(core.DefaultMaxPageLimit)) {
+		tasks, err := listTaskByState(kt, sch.backend, partIDs, enumor.TaskRetrying)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			tree, exist := trees[task.FlowID]
+			if !exist || !isRetryDue(task, now) {
+				continue
+			}
+
+			info := &backend.UpdateTaskInfo{
+				ID:     task.ID,
+				Source: enumor.TaskRetrying,
+				Target: resumeState(task),
+			}
+			if err = sch.backend.UpdateTaskStateByCAS(task.Kit, info); err != nil {
+				// 任务可能刚好被取消，此时不需要再重试
+				logs.Warnf("update task state to rollback failed, err: %v, id: %s, rid: %s", err, task.ID,
+					task.Kit.Rid)
+				continue
+			}
+
+			task.State = info.Target
+			sch.executor.Push(tree.Flow, task)
+		}
+	}
+
+	return nil
+}
+
+// resumeState 返回重试等待中的任务到达下次重试时间后恢复的状态
+func resumeState(task *Task) enumor.TaskState {
+	if task.RetryState == nil || len(task.RetryState.ResumeState) == 0 {
+		return enumor.TaskRollback
+	}
+
+	return task.RetryState.ResumeState
+}
+
+// isRetryDue 判断重试等待中的任务是否已经到达下次重试时间
+func isRetryDue(task *Task, now time.Time) bool {
+	if task.RetryState == nil || len(task.RetryState.NextAt) == 0 {
+		return true
+	}
+
+	nextAt, err := time.Parse(constant.TimeStdFormat, task.RetryState.NextAt)
+	if err != nil {
+		logs.Errorf("parse task retry next_at failed, err: %v, next_at: %s, id: %s, rid: %s", err,
+			task.RetryState.NextAt, task.ID, task.Kit.Rid)
+		return true
+	}
+
+	return !nextAt.After(now)
+}
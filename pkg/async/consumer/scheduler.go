@@ -94,6 +94,22 @@ func (sch *scheduler) Start() {
 	sch.workerWg.Add(1)
 	go sch.startWatcher(sch.watchScheduledFlow)
 
+	// 定期中断当前节点上已经被取消的任务流
+	sch.workerWg.Add(1)
+	go sch.startWatcher(sch.watchCanceledFlow)
+
+	// 定期补偿当前节点上执行失败且开启了补偿的任务流
+	sch.workerWg.Add(1)
+	go sch.startWatcher(sch.watchCompensatingFlow)
+
+	// 定期检查当前节点上处于等待状态的任务等待的外部事件是否完成
+	sch.workerWg.Add(1)
+	go sch.startWatcher(sch.watchWaitingTask)
+
+	// 定期将当前节点上到达重试时间的任务重新推送到执行器执行
+	sch.workerWg.Add(1)
+	go sch.startWatcher(sch.watchRetryingTask)
+
 	// 启动workerNumber个协程进行任务流解析
 	for i := 0; i < int(sch.workerNumber); i++ {
 		sch.workerWg.Add(1)
@@ -103,22 +119,20 @@ func (sch *scheduler) Start() {
 
 // startWatcher 定期执行do函数体
 func (sch *scheduler) startWatcher(do func(kt *kit.Kit) error) {
-	for {
-		select {
-		case <-sch.closeCh:
-			break
-		default:
-		}
+	defer sch.workerWg.Done()
 
+	for {
 		kt := NewKit()
 		if err := do(kt); err != nil {
 			logs.Errorf("%s: scheduler watcher do failed, err: %v, rid: %s", constant.AsyncTaskWarnSign, err, kt.Rid)
 		}
 
-		time.Sleep(sch.watchIntervalSec)
+		select {
+		case <-sch.closeCh:
+			return
+		case <-time.After(sch.watchIntervalSec):
+		}
 	}
-
-	sch.workerWg.Done()
 }
 
 // queryCurrNodeFlow 查询主节点分配给当前节点处于 Scheduled 状态的任务流。
@@ -144,9 +158,12 @@ func (sch *scheduler) queryCurrNodeFlow(kt *kit.Kit, limit int32) ([]*Flow, erro
 				},
 			},
 		},
+		// 优先级高的任务流优先调度
 		Page: &core.BasePage{
 			Start: 0,
 			Limit: uint(limit),
+			Sort:  "priority",
+			Order: core.Descending,
 		},
 	}
 	result, err := sch.backend.ListFlow(kt, input)
@@ -269,6 +286,12 @@ func (sch *scheduler) parseFlowAndPushTask(kt *kit.Kit, flow *Flow) error {
 	if len(executableTaskNodes) == 0 {
 		state := taskTree.Root.ComputeState()
 
+		// 只存在等待中或重试等待中的节点时，存储任务流执行树，由调度器在等待结束后继续调度
+		if state == enumor.FlowRunning {
+			sch.taskTrees.Store(flow.ID, taskTree)
+			return nil
+		}
+
 		if state == enumor.FlowSuccess {
 			if err = updateFlowState(kt, sch.backend, flow.ID, enumor.FlowRunning, state); err != nil {
 				logs.Errorf("update flow state to %s failed, err: %v, rid: %s", state, err, kt.Rid)
@@ -277,6 +300,8 @@ func (sch *scheduler) parseFlowAndPushTask(kt *kit.Kit, flow *Flow) error {
 		}
 
 		if state == enumor.FlowFailed {
+			// 开启补偿的任务流进入补偿中状态，由补偿协程进行补偿
+			state = getFlowFailedState(flow.Flow)
 			if err = updateFlowStateAndReason(kt, sch.backend, flow.ID, enumor.FlowRunning, state,
 				ErrSomeTaskExecFailed); err != nil {
 
@@ -385,12 +410,33 @@ func (sch *scheduler) goWorker() {
 func (sch *scheduler) executeNext(kt *kit.Kit, task *Task) error {
 	tree, ok := sch.getTaskTree(task.FlowID)
 	if !ok {
+		// 任务流被取消后，任务流执行树会被清理，此时执行完的任务不需要再进行解析。
+		if canceled, err := isFlowCanceled(kt, sch.backend, task.FlowID); err == nil && canceled {
+			logs.Infof("flow: %s is canceled, skip execute next, task: %s, rid: %s", task.FlowID, task.ID, kt.Rid)
+			return nil
+		}
+
 		logs.Errorf("execute next get task tree failed, flowID: %s, rid: %s", task.FlowID, kt.Rid)
 		return fmt.Errorf("flow: %s not found", task.FlowID)
 	}
 
+	// 动态扇出的子任务插入到任务流执行树中
+	if len(task.FanOutTasks) != 0 {
+		if err := tree.Root.AddFanOutNodes(task, task.FanOutTasks); err != nil {
+			logs.Errorf("add fan out nodes failed, err: %v, task: %s, rid: %s", err, task.ID, kt.Rid)
+			return err
+		}
+	}
+
 	// 获取下次执行的任务
 	ids := tree.Root.GetNextTaskNodes(task)
+
+	// 扇出子任务全部执行结束后，汇总子任务执行结果到扇出任务
+	if len(task.ParentID) != 0 {
+		if err := rollUpFanOutResult(kt, sch.backend, tree.Root, task.ParentID); err != nil {
+			logs.Errorf("roll up fan out result failed, err: %v, parent: %s, rid: %s", err, task.ParentID, kt.Rid)
+		}
+	}
 	if len(ids) == 0 {
 		state := tree.Root.ComputeState()
 
@@ -404,6 +450,8 @@ func (sch *scheduler) executeNext(kt *kit.Kit, task *Task) error {
 		}
 
 		if state == enumor.FlowFailed {
+			// 开启补偿的任务流进入补偿中状态，由补偿协程进行补偿
+			state = getFlowFailedState(tree.Flow.Flow)
 			if err := updateFlowStateAndReason(kt, sch.backend, task.FlowID, enumor.FlowRunning, state,
 				ErrSomeTaskExecFailed); err != nil {
 
@@ -436,6 +484,125 @@ func (sch *scheduler) pushTasks(kt *kit.Kit, flow *Flow, ids []string) error {
 	return nil
 }
 
+// watchCanceledFlow 查询当前节点正在执行的任务流中已经被取消的任务流，中断正在执行的任务并清理任务流执行树。
+func (sch *scheduler) watchCanceledFlow(kt *kit.Kit) error {
+	flowIDs := make([]string, 0)
+	sch.taskTrees.Range(func(key, _ interface{}) bool {
+		flowIDs = append(flowIDs, key.(string))
+		return true
+	})
+
+	if len(flowIDs) == 0 {
+		return nil
+	}
+
+	for _, partIDs := range slice.Split(flowIDs, int(core.DefaultMaxPageLimit)) {
+		input := &backend.ListInput{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{
+						Field: "id",
+						Op:    filter.In.Factory(),
+						Value: partIDs,
+					},
+					&filter.AtomRule{
+						Field: "state",
+						Op:    filter.Equal.Factory(),
+						Value: enumor.FlowCancel,
+					},
+				},
+			},
+			Page: core.NewDefaultBasePage(),
+		}
+		flows, err := sch.backend.ListFlow(kt, input)
+		if err != nil {
+			logs.Errorf("list canceled flows failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		for _, flow := range flows {
+			if err = sch.cancelFlowTasks(kt, flow.ID); err != nil {
+				logs.Errorf("cancel flow tasks failed, err: %v, flow: %s, rid: %s", err, flow.ID, kt.Rid)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// cancelFlowTasks 中断任务流中正在执行的任务，并将任务状态更新为 cancel。
+func (sch *scheduler) cancelFlowTasks(kt *kit.Kit, flowID string) error {
+	tasks, err := listTaskByFlowID(kt, sch.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0)
+	sources := make(map[string]enumor.TaskState)
+	for _, one := range tasks {
+		if one.State == enumor.TaskRunning || one.State == enumor.TaskRollback {
+			ids = append(ids, one.ID)
+			sources[one.ID] = one.State
+		}
+
+		// 重试等待中的任务不占用执行器，只需要更新状态
+		if one.State == enumor.TaskRetrying {
+			sources[one.ID] = one.State
+		}
+	}
+
+	sch.taskTrees.Delete(flowID)
+
+	if len(sources) == 0 {
+		return nil
+	}
+
+	if len(ids) != 0 {
+		if err = sch.executor.CancelTasks(ids); err != nil {
+			logs.Errorf("executor cancel tasks failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return err
+		}
+	}
+
+	for id := range sources {
+		info := &backend.UpdateTaskInfo{
+			ID:     id,
+			Source: sources[id],
+			Target: enumor.TaskCancel,
+			Reason: &tableasync.Reason{Message: ErrFlowCanceled},
+		}
+		if err = sch.backend.UpdateTaskStateByCAS(kt, info); err != nil {
+			// 任务可能已经执行结束，此时不需要再更新状态
+			logs.Warnf("update task state to cancel failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		}
+	}
+
+	logs.Infof("cancel flow tasks success, flow: %s, tasks: %v, rid: %s", flowID, ids, kt.Rid)
+
+	return nil
+}
+
+// isFlowCanceled 判断任务流是否已经被取消
+func isFlowCanceled(kt *kit.Kit, bd backend.Backend, flowID string) (bool, error) {
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	flows, err := bd.ListFlow(kt, input)
+	if err != nil {
+		logs.Errorf("list flow failed, err: %v, id: %s, rid: %s", err, flowID, kt.Rid)
+		return false, err
+	}
+
+	if len(flows) == 0 {
+		return false, fmt.Errorf("flow: %s not found", flowID)
+	}
+
+	return flows[0].State == enumor.FlowCancel, nil
+}
+
 // 获取存储的任务流树
 func (sch *scheduler) getTaskTree(flowID string) (*TaskTree, bool) {
 	tasks, ok := sch.taskTrees.Load(flowID)
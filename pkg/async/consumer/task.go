@@ -20,19 +20,24 @@
 package consumer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"hcm/pkg/async/action"
 	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
 	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/dal/table/types"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/retry"
+	"hcm/pkg/tools/times"
 )
 
 // Task 异步任务执行体，包含了任务运行流程、回滚流程。
@@ -44,12 +49,19 @@ type Task struct {
 	ExecuteKit run.ExecuteKit `json:"-"`
 	Patch      func(kt *kit.Kit, task *model.Task) error
 	Flow       *Flow
+
+	// CreateTasks 创建动态扇出子任务
+	CreateTasks func(kt *kit.Kit, tasks []model.Task) ([]string, error) `json:"-"`
+	// ListTasks 查询已创建的动态扇出子任务，任务重新执行时复用已创建的子任务
+	ListTasks func(kt *kit.Kit, input *backend.ListInput) ([]model.Task, error) `json:"-"`
+	// FanOutTasks 任务执行过程中动态扇出的子任务，由调度器插入到任务流执行树中
+	FanOutTasks []*Task `json:"-"`
 }
 
-// ValidateBeforeExec task validate before execute.
+// ValidateBeforeExec task validate before execute. 到达重试时间的任务处于 rollback 状态，需要先回滚再执行。
 func (task *Task) ValidateBeforeExec(act action.Action) error {
 	switch task.State {
-	case enumor.TaskPending:
+	case enumor.TaskPending, enumor.TaskRollback:
 	default:
 		return fmt.Errorf("task can not run，state: %s", task.State)
 	}
@@ -111,35 +123,40 @@ func (task *Task) Run() error {
 		return err
 	}
 
-	var runErr error
-	var failedResult interface{}
-	if !task.Retry.IsEnable() {
-		_, failedResult, runErr = task.runOnce(act)
-	} else {
-		failedResult, runErr = task.Retry.Run(func() (interface{}, error) {
-			needRetry, failed, err := task.runOnce(act)
-			if err == nil {
-				return nil, nil
-			}
+	// 执行条件不满足时跳过任务，跳过的任务视为执行成功，不影响后续任务执行
+	if task.RunCondition != nil && task.State == enumor.TaskPending {
+		matched, err := task.RunCondition.Match(task.ExecuteKit.ShareData().Get)
+		if err != nil {
+			return err
+		}
 
-			if !needRetry {
-				return failed, err
-			}
+		if !matched {
+			return task.UpdateTask(enumor.TaskSkipped, "run condition not match", nil)
+		}
+	}
 
-			// 允许重试，将Task状态由 running -> rollback，进行回滚
-			if patchErr := task.UpdateTask(enumor.TaskRollback, err.Error(), failed); patchErr != nil {
-				return failed, fmt.Errorf("task set rollback state failed, after runAction failed, err: %v, "+
-					"patchErr: %v", err, patchErr)
-			}
+	needRetry, failedResult, runErr := task.runOnce(act)
+	if runErr != nil && needRetry && task.Retry.IsEnable() {
+		retrying, err := task.retryLater(runErr, failedResult)
+		if err != nil {
+			runErr = err
+		}
 
-			return nil, nil
-		})
+		if retrying {
+			return nil
+		}
 	}
 	if runErr != nil {
 		logs.Errorf("task run failed, err: %v, task: %+v, result: %+v, rid: %s", runErr, task, failedResult,
 			task.ExecuteKit.Kit().Rid)
 
-		if patchErr := task.UpdateTask(enumor.TaskFailed, runErr.Error(), failedResult); patchErr != nil {
+		// 任务被指挥者强制中断，任务状态设置为取消，而不是失败
+		state := enumor.TaskFailed
+		if errors.Is(task.ExecuteKit.Kit().Ctx.Err(), context.Canceled) {
+			state = enumor.TaskCancel
+		}
+
+		if patchErr := task.UpdateTask(state, runErr.Error(), failedResult); patchErr != nil {
 			logs.Errorf("task set failed state failed, after run failed, err: %v, patchErr: %v, rid: %s",
 				runErr, patchErr, task.ExecuteKit.Kit().Rid)
 			return fmt.Errorf("task set failed state failed, after run failed, err: %v, patchErr: %v",
@@ -178,7 +195,7 @@ func (task *Task) Rollback() error {
 		return task.rollback(nil, act)
 	}
 
-	if err := action.Decode(task.Params, p); err != nil {
+	if err := task.decodeParams(p); err != nil {
 		logs.Errorf("task decode params failed, params: %s, type: %s, rid: %s", task.Params,
 			reflect.TypeOf(p).String(), task.ExecuteKit.Kit().Rid)
 		return fmt.Errorf("task decode params failed, err: %v", err)
@@ -187,6 +204,87 @@ func (task *Task) Rollback() error {
 	return task.rollback(p, act)
 }
 
+// Compensate 任务补偿，清理执行成功的任务已经创建的资源，从Success状态到Compensated状态。
+// 未实现 CompensateAction 的任务不需要补偿，直接跳过。
+func (task *Task) Compensate() error {
+
+	act, exist := action.GetAction(task.ActionName)
+	if !exist {
+		return fmt.Errorf("action: %s not found", task.ActionName)
+	}
+
+	if task.State != enumor.TaskSuccess {
+		return fmt.Errorf("task can not compensate, state: %s", task.State)
+	}
+
+	compensateAct, ok := act.(action.CompensateAction)
+	if !ok {
+		return nil
+	}
+
+	var params interface{}
+	if paramAct, ok := act.(action.ParameterAction); ok && len(task.Params) != 0 {
+		params = paramAct.ParameterNew()
+		if params != nil {
+			if err := task.decodeParams(params); err != nil {
+				logs.Errorf("task decode params failed, params: %s, type: %s, rid: %s", task.Params,
+					reflect.TypeOf(params).String(), task.ExecuteKit.Kit().Rid)
+				return fmt.Errorf("task decode params failed, err: %v", err)
+			}
+		}
+	}
+
+	if err := compensateAct.Compensate(task.ExecuteKit, params, task.Result); err != nil {
+		return fmt.Errorf("compensate failed, err: %v", err)
+	}
+
+	return task.UpdateState(enumor.TaskCompensated)
+}
+
+// Poll 检查等待状态的任务等待的外部事件是否完成，完成后将任务从Waiting状态更新到Success或Failed状态。
+func (task *Task) Poll() (done bool, err error) {
+
+	act, exist := action.GetAction(task.ActionName)
+	if !exist {
+		return false, fmt.Errorf("action: %s not found", task.ActionName)
+	}
+
+	if task.State != enumor.TaskWaiting {
+		return false, fmt.Errorf("task can not poll, state: %s", task.State)
+	}
+
+	waitingAct, ok := act.(action.WaitingAction)
+	if !ok {
+		return false, fmt.Errorf("action: %s not has WaitingAction", act.Name())
+	}
+
+	var params interface{}
+	if paramAct, ok := act.(action.ParameterAction); ok && len(task.Params) != 0 {
+		params = paramAct.ParameterNew()
+		if params != nil {
+			if err = task.decodeParams(params); err != nil {
+				logs.Errorf("task decode params failed, params: %s, type: %s, rid: %s", task.Params,
+					reflect.TypeOf(params).String(), task.ExecuteKit.Kit().Rid)
+				return false, fmt.Errorf("task decode params failed, err: %v", err)
+			}
+		}
+	}
+
+	done, result, pollErr := waitingAct.Poll(task.ExecuteKit, params, task.Result)
+	if !done {
+		if err = task.FlushLogs(); err != nil {
+			return false, err
+		}
+		return false, pollErr
+	}
+
+	if pollErr != nil {
+		return true, task.UpdateTask(enumor.TaskFailed, pollErr.Error(), result)
+	}
+
+	return true, task.UpdateStateResult(enumor.TaskSuccess, result)
+}
+
 func (task *Task) runOnce(act action.Action) (needRetry bool, failedResult interface{}, err error) {
 	if len(task.Params) == 0 {
 		return task.runAction(nil, act)
@@ -202,7 +300,7 @@ func (task *Task) runOnce(act action.Action) (needRetry bool, failedResult inter
 		return task.runAction(nil, act)
 	}
 
-	if err = action.Decode(task.Params, p); err != nil {
+	if err = task.decodeParams(p); err != nil {
 		logs.Errorf("task decode params failed, params: %s, type: %s, rid: %s", task.Params,
 			reflect.TypeOf(p).String(), task.ExecuteKit.Kit().Rid)
 		return false, nil, fmt.Errorf("task decode params failed, err: %v", err)
@@ -211,6 +309,25 @@ func (task *Task) runOnce(act action.Action) (needRetry bool, failedResult inter
 	return task.runAction(p, act)
 }
 
+// decodeParams 解析任务参数，创建时使用声明式任务流模版中参数模版的任务，先使用当前的任务流共享数据渲染参数。
+func (task *Task) decodeParams(params interface{}) error {
+	if !task.ParamsRender {
+		return action.Decode(task.Params, params)
+	}
+
+	shareData := make(map[string]string)
+	if copier, ok := task.ExecuteKit.ShareData().(interface{ Copy() map[string]string }); ok {
+		shareData = copier.Copy()
+	}
+
+	rendered, err := action.RenderParams(task.Params, shareData)
+	if err != nil {
+		return err
+	}
+
+	return action.Decode(rendered, params)
+}
+
 // rollback 任务强制回滚，从Running或者Rollback状态
 func (task *Task) rollback(params interface{}, act action.Action) error {
 	rollbackAct, ok := act.(action.RollbackAction)
@@ -254,7 +371,27 @@ func (task *Task) runAction(params interface{}, act action.Action) (retry bool,
 
 		result, err := act.Run(task.ExecuteKit, params)
 		if err != nil {
-			return true, result, fmt.Errorf("run failed, err: %v", err)
+			return true, result, fmt.Errorf("run failed, err: %w", err)
+		}
+
+		// 动态扇出任务先创建子任务，子任务的执行结果会在全部子任务执行结束后汇总到当前任务的执行结果中
+		if fanOut, ok := result.(*action.FanOut); ok {
+			if err = task.fanOut(fanOut); err != nil {
+				return false, nil, err
+			}
+			result = nil
+		}
+
+		// 需要等待外部事件完成的任务进入等待状态并释放执行器，由调度器定期检查外部事件是否完成
+		if waiting, ok := result.(*action.Waiting); ok {
+			if _, ok = act.(action.WaitingAction); !ok {
+				return false, nil, fmt.Errorf("action: %s return waiting, but not have WaitingAction", act.Name())
+			}
+
+			if err = task.UpdateStateResult(enumor.TaskWaiting, waiting.Result); err != nil {
+				return false, waiting.Result, err
+			}
+			return false, nil, nil
 		}
 
 		// 如果执行成功，返回 result 属于成功结果，设置成功状态时，同时设置成功结果。如果执行失败，
@@ -267,6 +404,97 @@ func (task *Task) runAction(params interface{}, act action.Action) (retry bool,
 	return false, nil, nil
 }
 
+// retryLater 任务执行失败后，按照重试策略计算下次重试时间，并将任务置于重试等待状态，释放执行器，
+// 由调度器在到达下次重试时间后重新执行。不可重试的错误、超过最大执行次数或者重试总时长时返回false。
+func (task *Task) retryLater(runErr error, failedResult interface{}) (bool, error) {
+	// 任务被指挥者强制中断，不再进行重试
+	if errors.Is(task.ExecuteKit.Kit().Ctx.Err(), context.Canceled) {
+		return false, nil
+	}
+
+	policy := task.Retry.Policy
+	if action.IsPermanentError(runErr) || (policy.RetryableOnly && !action.IsRetryableError(runErr)) {
+		return false, nil
+	}
+
+	now := times.ConvStdTimeNow()
+	state := tableasync.RetryState{FirstFailedAt: times.ConvStdTimeFormat(now)}
+	if task.RetryState != nil && len(task.RetryState.FirstFailedAt) != 0 {
+		state = *task.RetryState
+		state.ResumeState = ""
+	}
+	state.Attempts++
+
+	if state.Attempts >= policy.Count {
+		return false, fmt.Errorf("retry exceed the max number of retryable times: %d, lastErr: %v", policy.Count,
+			runErr)
+	}
+
+	firstFailedAt, err := time.Parse(constant.TimeStdFormat, state.FirstFailedAt)
+	if err != nil {
+		return false, fmt.Errorf("parse first_failed_at: %s failed, err: %v", state.FirstFailedAt, err)
+	}
+
+	if policy.DeadlineExceeded(firstFailedAt, now) {
+		return false, fmt.Errorf("retry exceed the deadline: %ds, lastErr: %v", policy.DeadlineSec, runErr)
+	}
+
+	delay := policy.NextDelay(state.Attempts)
+	if retryAfter := action.RetryAfter(runErr); retryAfter > delay {
+		delay = retryAfter
+	}
+	state.NextAt = times.ConvStdTimeFormat(now.Add(delay))
+
+	md := &model.Task{
+		ID:         task.ID,
+		State:      enumor.TaskRetrying,
+		Reason:     &tableasync.Reason{Message: runErr.Error()},
+		RetryState: &state,
+	}
+	if err = task.patchTask(md, failedResult); err != nil {
+		return false, fmt.Errorf("task set retrying state failed, after run failed, err: %v, patchErr: %v", runErr,
+			err)
+	}
+
+	logs.Infof("task run failed, retry later, attempts: %d, next_at: %s, err: %v, id: %s, rid: %s",
+		state.Attempts, state.NextAt, runErr, task.ID, task.ExecuteKit.Kit().Rid)
+
+	return true, nil
+}
+
+// throttle 任务调用云厂商接口超过限流速率时，将任务置于重试等待状态并释放执行器，到达下次重试时间后恢复为等待前的状态
+// 重新执行。任务还没有执行，所以不计入任务的失败次数。
+func (task *Task) throttle(wait time.Duration) error {
+	switch task.State {
+	case enumor.TaskPending, enumor.TaskRollback:
+	default:
+		return fmt.Errorf("task can not throttle, state: %s", task.State)
+	}
+
+	state := tableasync.RetryState{}
+	if task.RetryState != nil {
+		state = *task.RetryState
+	}
+	// 下次重试时间精确到秒，向上取整避免提前执行
+	state.NextAt = times.ConvStdTimeFormat(times.ConvStdTimeNow().Add(wait).Truncate(time.Second).Add(time.Second))
+	state.ResumeState = task.State
+
+	md := &model.Task{
+		ID:         task.ID,
+		State:      enumor.TaskRetrying,
+		Reason:     &tableasync.Reason{Message: fmt.Sprintf("rate limited, wait %s", wait)},
+		RetryState: &state,
+	}
+	if err := task.patchTask(md, nil); err != nil {
+		return fmt.Errorf("task set retrying state failed, after rate limited, err: %v", err)
+	}
+
+	logs.V(3).Infof("task rate limited, retry later, next_at: %s, id: %s, rid: %s", state.NextAt, task.ID,
+		task.ExecuteKit.Kit().Rid)
+
+	return nil
+}
+
 // UpdateState update task state.
 func (task *Task) UpdateState(state enumor.TaskState) error {
 	return task.UpdateTask(state, "", nil)
@@ -287,6 +515,11 @@ func (task *Task) UpdateTask(state enumor.TaskState, reason string, result inter
 		},
 	}
 
+	return task.patchTask(md, result)
+}
+
+// patchTask 更新任务，任务执行过程中记录的日志随任务一起保存。
+func (task *Task) patchTask(md *model.Task, result interface{}) error {
 	if result != nil {
 		field, err := types.NewJsonField(result)
 		if err != nil {
@@ -297,17 +530,48 @@ func (task *Task) UpdateTask(state enumor.TaskState, reason string, result inter
 		md.Result = field
 	}
 
+	// 任务执行过程中记录的日志随任务状态一起保存
+	if entries := task.ExecuteKit.Logger().Drain(); len(entries) != 0 {
+		md.Logs = task.Logs.Append(entries...)
+	}
+
 	rty := retry.NewRetryPolicy(defRetryCount, defRetryRangeMS)
 	err := rty.BaseExec(task.ExecuteKit.Kit(), func() error {
 		return task.Patch(task.ExecuteKit.Kit(), md)
 	})
 	if err != nil {
-		logs.Errorf("task update state failed, err: %v, retryCount: %d, id: %s, state: %s, reason: %s, rid: %s",
-			err, defRetryCount, task.ID, state, reason, task.ExecuteKit.Kit().Rid)
+		logs.Errorf("task update state failed, err: %v, retryCount: %d, id: %s, state: %s, reason: %+v, rid: %s",
+			err, defRetryCount, task.ID, md.State, md.Reason, task.ExecuteKit.Kit().Rid)
 		return err
 	}
 
-	task.State = state
+	task.State = md.State
+	if md.Logs != nil {
+		task.Logs = md.Logs
+	}
+	if md.RetryState != nil {
+		task.RetryState = md.RetryState
+	}
+
+	return nil
+}
+
+// FlushLogs 保存任务执行过程中记录的日志，用于不更新任务状态的场景，如等待中的任务检查外部事件。
+func (task *Task) FlushLogs() error {
+	entries := task.ExecuteKit.Logger().Drain()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	md := &model.Task{
+		ID:   task.ID,
+		Logs: task.Logs.Append(entries...),
+	}
+	if err := task.Patch(task.ExecuteKit.Kit(), md); err != nil {
+		logs.Errorf("task flush logs failed, err: %v, id: %s, rid: %s", err, task.ID, task.ExecuteKit.Kit().Rid)
+		return err
+	}
+	task.Logs = md.Logs
 
 	return nil
 }
@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+)
+
+func TestTaskLogsSavedWithState(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+
+	flow := &model.Flow{
+		Name: "test_flow",
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: "test_action"},
+		},
+	}
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	task := tasks[0]
+	task.InitDep(run.NewExecuteContext(kt, nil, flowID, task.ID), func(kt *kit.Kit, task *model.Task) error {
+		return bd.UpdateTask(kt, task)
+	}, nil)
+
+	// 未更新任务状态前，日志不保存
+	task.ExecuteKit.Logger().Infof("start run, id: %s", task.ID)
+	task.ExecuteKit.Logger().Errorf("run failed")
+	saved, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if len(saved[0].Logs) != 0 {
+		t.Fatalf("logs should not saved before update task, but got %d", len(saved[0].Logs))
+	}
+
+	if err = task.UpdateState(enumor.TaskRunning); err != nil {
+		t.Fatalf("update task failed, err: %v", err)
+	}
+
+	// 超过最大条数后只保留最近的日志
+	for i := 0; i < tableasync.MaxTaskLogEntries; i++ {
+		task.ExecuteKit.Logger().Warnf("retry %d", i)
+	}
+	if err = task.UpdateState(enumor.TaskSuccess); err != nil {
+		t.Fatalf("update task failed, err: %v", err)
+	}
+
+	saved, err = listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	taskLogs := saved[0].Logs
+	if len(taskLogs) != tableasync.MaxTaskLogEntries {
+		t.Fatalf("logs should be capped at %d, but got %d", tableasync.MaxTaskLogEntries, len(taskLogs))
+	}
+
+	if taskLogs[0].Message != "retry 0" || taskLogs[0].Level != enumor.TaskLogWarn {
+		t.Errorf("oldest logs should be dropped, but first log is %+v", taskLogs[0])
+	}
+}
+
+func TestTaskLogsAppendTruncate(t *testing.T) {
+	long := make([]byte, tableasync.MaxTaskLogMessageLen+10)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	taskLogs := tableasync.TaskLogs{}.Append(tableasync.TaskLogEntry{Level: enumor.TaskLogInfo,
+		Message: string(long)})
+	if len(taskLogs[0].Message) != tableasync.MaxTaskLogMessageLen+len("...(truncated)") {
+		t.Errorf("long log message should be truncated, but got length %d", len(taskLogs[0].Message))
+	}
+
+	// 多字节字符在字符边界处截断，不产生非法的UTF-8内容
+	taskLogs = tableasync.TaskLogs{}.Append(tableasync.TaskLogEntry{Level: enumor.TaskLogInfo,
+		Message: strings.Repeat("中", tableasync.MaxTaskLogMessageLen)})
+	if msg := taskLogs[0].Message; !utf8.ValidString(msg) ||
+		len(msg) > tableasync.MaxTaskLogMessageLen+len("...(truncated)") {
+		t.Errorf("multi-byte log message should be truncated at rune boundary, but got: %q", msg)
+	}
+}
@@ -39,8 +39,12 @@ type TaskTree struct {
 
 // TaskNode task node
 type TaskNode struct {
-	TaskID string
-	State  enumor.TaskState
+	TaskID       string
+	State        enumor.TaskState
+	AllowFailure bool
+	TriggerRule  enumor.TaskTriggerRule
+	// ParentID 动态扇出子任务所属的扇出任务ID
+	ParentID string
 
 	children []*TaskNode
 	parents  []*TaskNode
@@ -49,8 +53,11 @@ type TaskNode struct {
 // NewTaskNode new task node
 func NewTaskNode(task *Task) *TaskNode {
 	return &TaskNode{
-		TaskID: task.ID,
-		State:  task.State,
+		TaskID:       task.ID,
+		State:        task.State,
+		AllowFailure: task.AllowFailure,
+		TriggerRule:  task.TriggerRule,
+		ParentID:     task.ParentID,
 	}
 }
 
@@ -59,6 +66,16 @@ func (t *TaskNode) AppendChild(task *TaskNode) {
 	t.children = append(t.children, task)
 }
 
+// hasChild 判断节点是否已经是当前节点的子节点
+func (t *TaskNode) hasChild(task *TaskNode) bool {
+	for _, child := range t.children {
+		if child.TaskID == task.TaskID {
+			return true
+		}
+	}
+	return false
+}
+
 // AppendParent append parent
 func (t *TaskNode) AppendParent(task *TaskNode) {
 	t.parents = append(t.parents, task)
@@ -74,64 +91,116 @@ func (t *TaskNode) GetParents() []*TaskNode {
 	return t.parents
 }
 
-// CanExecuteChild can execute child
+// CanExecuteChild 当前节点是否满足子节点 all_success 触发规则，执行成功、被跳过、允许失败的节点执行失败都视为成功。
 func (t *TaskNode) CanExecuteChild() bool {
-	return t.State == enumor.TaskSuccess
+	switch t.State {
+	case enumor.TaskSuccess, enumor.TaskSkipped:
+		return true
+	case enumor.TaskFailed:
+		return t.AllowFailure
+	default:
+		return false
+	}
 }
 
-// CanBeExecuted check whether task could be executed
+// IsFinished 当前节点是否已经结束，执行成功、执行失败、被跳过，以及永远不会被执行的节点都视为已经结束。
+func (t *TaskNode) IsFinished() bool {
+	switch t.State {
+	case enumor.TaskSuccess, enumor.TaskFailed, enumor.TaskSkipped:
+		return true
+	default:
+		return t.IsUnreachable()
+	}
+}
+
+// IsUnreachable 当前节点是否永远不会被执行，即前置节点都已经结束，但不满足当前节点的触发规则。
+func (t *TaskNode) IsUnreachable() bool {
+	if t.State != enumor.TaskPending || len(t.parents) == 0 {
+		return false
+	}
+
+	return t.parentsFinished() && !t.triggered()
+}
+
+// CanBeExecuted check whether task could be executed，前置节点都已经结束，且满足当前节点的触发规则。
 func (t *TaskNode) CanBeExecuted() bool {
 	if len(t.parents) == 0 {
 		return true
 	}
 
+	return t.parentsFinished() && t.triggered()
+}
+
+// parentsFinished 前置节点是否都已经结束
+func (t *TaskNode) parentsFinished() bool {
 	for _, p := range t.parents {
-		if !p.CanExecuteChild() {
+		if !p.IsFinished() {
 			return false
 		}
 	}
+
 	return true
 }
 
-// Executable check can executable
-func (t *TaskNode) Executable() bool {
-	if t.State != enumor.TaskPending {
+// triggered 前置节点都已经结束时，判断是否满足当前节点的触发规则
+func (t *TaskNode) triggered() bool {
+	switch t.TriggerRule {
+	case enumor.TriggerAlways:
+		return true
+
+	case enumor.TriggerOnFailure:
+		for _, p := range t.parents {
+			if p.State == enumor.TaskFailed {
+				return true
+			}
+		}
 		return false
-	}
 
-	if len(t.parents) == 0 {
+	default:
+		for _, p := range t.parents {
+			if !p.CanExecuteChild() {
+				return false
+			}
+		}
 		return true
 	}
+}
 
-	for i := range t.parents {
-		if !t.parents[i].CanExecuteChild() {
-			return false
-		}
+// Executable check can executable
+func (t *TaskNode) Executable() bool {
+	if t.State != enumor.TaskPending {
+		return false
 	}
 
-	return true
+	return t.CanBeExecuted()
 }
 
-// ComputeState compute state
+// ComputeState 计算任务流状态。存在执行中、等待中、重试等待中或者可以执行的节点时，任务流处于执行中，否则存在不允许失败的节点执行失败时，
+// 任务流执行失败，其余情况任务流执行成功。
 func (t *TaskNode) ComputeState() (state enumor.FlowState) {
+	running, failed := false, false
 	walkNode(t, func(node *TaskNode) bool {
-		switch node.State {
-		// 如果Task存在失败节点，无法继续遍历当前节点子节点。
-		case enumor.TaskFailed:
-			state = enumor.FlowFailed
-			return false
-		// 如果当前节点运行成功，继续遍历当前节点子节点。
-		case enumor.TaskSuccess:
-			state = enumor.FlowSuccess
-			return true
-		// 如果当前节点处于其他运行中间状态，无法继续遍历当前节点子节点。
-		default:
-			state = enumor.FlowRunning
-			return false
+		switch {
+		case node.State == enumor.TaskRunning || node.State == enumor.TaskRollback || node.State == enumor.TaskWaiting,
+			node.State == enumor.TaskRetrying:
+			running = true
+		case node.Executable():
+			running = true
+		case node.State == enumor.TaskFailed && !node.AllowFailure:
+			failed = true
 		}
+		return true
 	})
 
-	return
+	if running {
+		return enumor.FlowRunning
+	}
+
+	if failed {
+		return enumor.FlowFailed
+	}
+
+	return enumor.FlowSuccess
 }
 
 // GetExecutableTasks get executable task nodes
@@ -158,7 +227,40 @@ func (t *TaskNode) GetExecStateTasks() (ids []string) {
 	return
 }
 
-// GetNextTaskNodes get next task nodes
+// GetCompensateTasks 获取需要补偿的任务，按照任务依赖的逆拓扑序返回执行成功的任务，保证子任务先于父任务补偿。
+func (t *TaskNode) GetCompensateTasks() (ids []string) {
+	inDegree := make(map[string]int)
+	walkNode(t, func(node *TaskNode) bool {
+		inDegree[node.TaskID] = len(node.parents)
+		return true
+	})
+
+	order := make([]*TaskNode, 0, len(inDegree))
+	queue := []*TaskNode{t}
+	for len(queue) != 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		order = append(order, cur)
+
+		for _, child := range cur.children {
+			inDegree[child.TaskID]--
+			if inDegree[child.TaskID] == 0 {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		if order[i].TaskID != VirtualTaskRootID && order[i].State == enumor.TaskSuccess {
+			ids = append(ids, order[i].TaskID)
+		}
+	}
+
+	return
+}
+
+// GetNextTaskNodes get next task nodes. 子节点永远不会被执行时，继续判断该子节点的子节点是否可以执行，
+// 如 always、on_failure 触发规则的节点。
 func (t *TaskNode) GetNextTaskNodes(completedOrRetryTask *Task) (executable []string) {
 	walkNode(t, func(node *TaskNode) bool {
 		if node.TaskID == completedOrRetryTask.ID {
@@ -169,13 +271,23 @@ func (t *TaskNode) GetNextTaskNodes(completedOrRetryTask *Task) (executable []st
 				return false
 			}
 
-			if !node.CanExecuteChild() {
-				return false
-			}
+			visited := make(map[string]struct{})
+			queue := append([]*TaskNode{}, node.children...)
+			for len(queue) != 0 {
+				cur := queue[0]
+				queue = queue[1:]
+				if _, exist := visited[cur.TaskID]; exist {
+					continue
+				}
+				visited[cur.TaskID] = struct{}{}
 
-			for i := range node.children {
-				if node.children[i].Executable() {
-					executable = append(executable, node.children[i].TaskID)
+				if cur.Executable() {
+					executable = append(executable, cur.TaskID)
+					continue
+				}
+
+				if cur.IsUnreachable() {
+					queue = append(queue, cur.children...)
 				}
 			}
 
@@ -272,6 +384,16 @@ func BuildTaskRoot(tasks []*Task) (*TaskNode, error) {
 		return nil, errors.New("here is no start nodes")
 	}
 
+	// 动态扇出任务的后续任务需要等待全部扇出子任务执行完成，按照先序遍历的顺序链接，保证嵌套扇出时外层先链接
+	nodes := make([]*TaskNode, 0, len(tasks))
+	walkNode(root, func(node *TaskNode) bool {
+		nodes = append(nodes, node)
+		return true
+	})
+	for _, node := range nodes {
+		linkFanOutNodes(node)
+	}
+
 	if cycleStart := root.HasCycle(); cycleStart != nil {
 		return nil, fmt.Errorf("has cycle at: %s", cycleStart.TaskID)
 	}
@@ -293,30 +415,25 @@ func buildTaskNodeMap(tasks []*Task) (map[action.ActIDType]*TaskNode, error) {
 }
 
 func walkNode(root *TaskNode, walkFunc func(node *TaskNode) bool) {
-	dfsWalk(root, walkFunc)
+	dfsWalk(root, walkFunc, make(map[string]struct{}))
 }
 
-// dfsWalk 从某个节点进行深度优先遍历并依次遍历它的子节点
-// Note: walkFunc
-func dfsWalk(root *TaskNode, walkFunc func(node *TaskNode) (isGoing bool)) (stop bool) {
+// dfsWalk 从某个节点进行深度优先遍历并依次遍历它的子节点，每个节点只会被遍历一次。
+// Note: walkFunc 返回false时，结束整个遍历
+func dfsWalk(root *TaskNode, walkFunc func(node *TaskNode) (isGoing bool), visited map[string]struct{}) (stop bool) {
+	if _, exist := visited[root.TaskID]; exist {
+		return true
+	}
+	visited[root.TaskID] = struct{}{}
+
 	if root.TaskID != VirtualTaskRootID {
 		if !walkFunc(root) {
 			return false
 		}
 	}
 
-	// 当前节点是否可以遍历子节点，如果不能遍历子节点，达到当前路线叶子节点
-	if !root.CanExecuteChild() {
-		return true
-	}
-
 	for _, c := range root.children {
-		// if children's parent is not just root, we must check it
-		if len(c.parents) > 1 && !c.CanBeExecuted() {
-			continue
-		}
-
-		if !dfsWalk(c, walkFunc) {
+		if !dfsWalk(c, walkFunc, visited) {
 			return false
 		}
 	}
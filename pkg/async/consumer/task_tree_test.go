@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestTaskTreeTriggerRule(t *testing.T) {
+	// a -> b(on_failure), a -> c, b&c -> d(always)
+	newTasks := func(aState enumor.TaskState, allowFailure bool) []*Task {
+		return []*Task{
+			{Task: model.Task{ID: "a", ActionID: "a", State: aState, AllowFailure: allowFailure}},
+			{Task: model.Task{ID: "b", ActionID: "b", State: enumor.TaskPending, TriggerRule: enumor.TriggerOnFailure,
+				DependOn: []action.ActIDType{"a"}}},
+			{Task: model.Task{ID: "c", ActionID: "c", State: enumor.TaskPending, DependOn: []action.ActIDType{"a"}}},
+			{Task: model.Task{ID: "d", ActionID: "d", State: enumor.TaskPending, TriggerRule: enumor.TriggerAlways,
+				DependOn: []action.ActIDType{"b", "c"}}},
+		}
+	}
+
+	cases := []struct {
+		name         string
+		aState       enumor.TaskState
+		allowFailure bool
+		next         []string
+	}{
+		{name: "success", aState: enumor.TaskSuccess, next: []string{"c"}},
+		{name: "failed", aState: enumor.TaskFailed, next: []string{"b"}},
+		{name: "allow failure", aState: enumor.TaskFailed, allowFailure: true, next: []string{"b", "c"}},
+	}
+
+	for _, c := range cases {
+		tasks := newTasks(enumor.TaskRunning, c.allowFailure)
+		root, err := BuildTaskRoot(tasks)
+		if err != nil {
+			t.Fatalf("%s: build task root failed, err: %v", c.name, err)
+		}
+
+		completed := &Task{Task: model.Task{ID: "a", State: c.aState}}
+		next := root.GetNextTaskNodes(completed)
+		sort.Strings(next)
+		if !reflect.DeepEqual(next, c.next) {
+			t.Errorf("%s: next tasks should be %v, but got %v", c.name, c.next, next)
+		}
+
+		if state := root.ComputeState(); state != enumor.FlowRunning {
+			t.Errorf("%s: flow state should be running, but got %s", c.name, state)
+		}
+	}
+}
+
+func TestTaskTreeUnreachable(t *testing.T) {
+	// a -> b(on_failure) -> c, a -> d(always)
+	tasks := []*Task{
+		{Task: model.Task{ID: "a", ActionID: "a", State: enumor.TaskRunning}},
+		{Task: model.Task{ID: "b", ActionID: "b", State: enumor.TaskPending, TriggerRule: enumor.TriggerOnFailure,
+			DependOn: []action.ActIDType{"a"}}},
+		{Task: model.Task{ID: "c", ActionID: "c", State: enumor.TaskPending, TriggerRule: enumor.TriggerAlways,
+			DependOn: []action.ActIDType{"b"}}},
+		{Task: model.Task{ID: "d", ActionID: "d", State: enumor.TaskPending, DependOn: []action.ActIDType{"a"}}},
+	}
+	root, err := BuildTaskRoot(tasks)
+	if err != nil {
+		t.Fatalf("build task root failed, err: %v", err)
+	}
+
+	// a 执行成功后 b 永远不会被执行，b 的子节点 c 使用 always 触发规则，可以执行
+	next := root.GetNextTaskNodes(&Task{Task: model.Task{ID: "a", State: enumor.TaskSuccess}})
+	sort.Strings(next)
+	if !reflect.DeepEqual(next, []string{"c", "d"}) {
+		t.Errorf("next tasks should be [c d], but got %v", next)
+	}
+
+	root.GetNextTaskNodes(&Task{Task: model.Task{ID: "c", State: enumor.TaskSuccess}})
+	root.GetNextTaskNodes(&Task{Task: model.Task{ID: "d", State: enumor.TaskSkipped}})
+	if state := root.ComputeState(); state != enumor.FlowSuccess {
+		t.Errorf("flow state should be success, but got %s", state)
+	}
+}
+
+func TestTaskTreeComputeState(t *testing.T) {
+	cases := []struct {
+		name         string
+		state        enumor.TaskState
+		allowFailure bool
+		expect       enumor.FlowState
+	}{
+		{name: "failed", state: enumor.TaskFailed, expect: enumor.FlowFailed},
+		{name: "allow failure", state: enumor.TaskFailed, allowFailure: true, expect: enumor.FlowSuccess},
+		{name: "skipped", state: enumor.TaskSkipped, expect: enumor.FlowSuccess},
+	}
+
+	for _, c := range cases {
+		tasks := []*Task{
+			{Task: model.Task{ID: "a", ActionID: "a", State: enumor.TaskSuccess}},
+			{Task: model.Task{ID: "b", ActionID: "b", State: c.state, AllowFailure: c.allowFailure,
+				DependOn: []action.ActIDType{"a"}}},
+		}
+		root, err := BuildTaskRoot(tasks)
+		if err != nil {
+			t.Fatalf("%s: build task root failed, err: %v", c.name, err)
+		}
+
+		if state := root.ComputeState(); state != c.expect {
+			t.Errorf("%s: flow state should be %s, but got %s", c.name, c.expect, state)
+		}
+	}
+}
@@ -20,6 +20,8 @@
 package consumer
 
 import (
+	"time"
+
 	"hcm/pkg/api/core"
 	"hcm/pkg/async/backend/model"
 	"hcm/pkg/kit"
@@ -44,12 +46,18 @@ const (
 	ErrTaskNodeShutdown = "task node shutdown"
 	// ErrSomeTaskExecFailed 部分任务执行失败
 	ErrSomeTaskExecFailed = "some tasks failed to be executed"
+	// ErrFlowCanceled 任务流被取消
+	ErrFlowCanceled = "flow canceled"
 
 	//  listScheduledFlowLimit 每次调度器查询分配给当前节点的任务流数量
 	listScheduledFlowLimit = 10
 
 	// listExpiredTasksLimit 每次WatchDog查询超时任务的数量
 	listExpiredTasksLimit = 100
+
+	// minTaskTimeout WatchDog查询超时任务时使用的最小超时时间，任务自定义的超时时间小于该值时，由执行器负责超时控制，
+	// WatchDog在任务超过该时间未更新后才会将其判定为超时。
+	minTaskTimeout = 30 * time.Second
 )
 
 // Flow 消费所需的异步任务流。
@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// watchWaitingTask 检查当前节点正在执行的任务流中处于等待状态的任务，等待的外部事件完成后，继续调度任务流。
+func (sch *scheduler) watchWaitingTask(kt *kit.Kit) error {
+	trees := make(map[string]*TaskTree)
+	sch.taskTrees.Range(func(key, value interface{}) bool {
+		trees[key.(string)] = value.(*TaskTree)
+		return true
+	})
+
+	if len(trees) == 0 {
+		return nil
+	}
+
+	flowIDs := make([]string, 0, len(trees))
+	for id := range trees {
+		flowIDs = append(flowIDs, id)
+	}
+
+	for _, partIDs := range slice.Split(flowIDs, int(core.DefaultMaxPageLimit)) {
+		tasks, err := listTaskByState(kt, sch.backend, partIDs, enumor.TaskWaiting)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			tree, exist := trees[task.FlowID]
+			if !exist {
+				continue
+			}
+
+			flow := tree.Flow
+			ekt := run.NewExecuteContext(task.Kit, flow.ShareData, flow.ID, task.ID)
+			task.InitDep(ekt, func(kt *kit.Kit, task *model.Task) error {
+				return sch.backend.UpdateTask(kt, task)
+			}, flow)
+
+			done, err := task.Poll()
+			if err != nil {
+				logs.Errorf("poll waiting task failed, err: %v, task: %s, rid: %s", err, task.ID, task.Kit.Rid)
+			}
+
+			// 外部事件完成后，交给调度器分析任务流的状态
+			if done {
+				sch.EntryTask(task)
+			}
+		}
+	}
+
+	return nil
+}
+
+// listTaskByState 查询指定任务流中处于指定状态的任务
+func listTaskByState(kt *kit.Kit, bd backend.Backend, flowIDs []string, state enumor.TaskState) ([]*Task, error) {
+	input := &backend.ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "flow_id",
+					Op:    filter.In.Factory(),
+					Value: flowIDs,
+				},
+				&filter.AtomRule{
+					Field: "state",
+					Op:    filter.Equal.Factory(),
+					Value: state,
+				},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+
+	tasks := make([]*Task, 0)
+	for {
+		result, err := bd.ListTask(kt, input)
+		if err != nil {
+			logs.Errorf("list %s task failed, err: %v, rid: %s", state, err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result {
+			tasks = append(tasks, &Task{
+				Task: one,
+				Kit:  kt.NewSubKit(),
+			})
+		}
+
+		if len(result) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		input.Page.Start += uint32(input.Page.Limit)
+	}
+
+	return tasks, nil
+}
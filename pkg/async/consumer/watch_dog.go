@@ -32,11 +32,14 @@ import (
 	"hcm/pkg/async/consumer/leader"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
 	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/retry"
+	"hcm/pkg/tools/slice"
 	"hcm/pkg/tools/times"
 )
 
@@ -56,6 +59,8 @@ type WatchDog interface {
 type watchDog struct {
 	bd backend.Backend
 	ld leader.Leader
+	// term 主节点组件启动时所在任期的fencing token，修复任务流和任务时携带，旧主节点的更新会失败
+	term int64
 
 	taskTimeoutSec      time.Duration
 	shutdownWaitTimeSec time.Duration
@@ -64,21 +69,24 @@ type watchDog struct {
 	wg      sync.WaitGroup
 	closeCh chan struct{}
 
-	runningFlowMap map[string]time.Time
+	runningFlowMap      map[string]time.Time
+	compensatingFlowMap map[string]time.Time
 }
 
-// NewWatchDog 创建一个watchdog
-func NewWatchDog(bd backend.Backend, ld leader.Leader, opt *WatchDogOption) WatchDog {
+// NewWatchDog 创建一个watchdog，term 为主节点组件启动时所在任期的fencing token。
+func NewWatchDog(bd backend.Backend, ld leader.Leader, term int64, opt *WatchDogOption) WatchDog {
 
 	return &watchDog{
 		bd:                  bd,
 		ld:                  ld,
+		term:                term,
 		taskTimeoutSec:      time.Duration(opt.TaskRunTimeoutSec) * time.Second,
 		shutdownWaitTimeSec: time.Duration(opt.ShutdownWaitTimeSec) * time.Second,
 		watchIntervalSec:    time.Duration(opt.WatchIntervalSec) * time.Second,
 		wg:                  sync.WaitGroup{},
 		closeCh:             make(chan struct{}),
 		runningFlowMap:      make(map[string]time.Time),
+		compensatingFlowMap: make(map[string]time.Time),
 	}
 }
 
@@ -90,26 +98,27 @@ func (wd *watchDog) Start() {
 	go wd.watchWrapper(wd.handleScheduledNotExistWorkerFlow)
 	wd.wg.Add(1)
 	go wd.watchWrapper(wd.handleRunningNotExistWorkerFlow)
+	wd.wg.Add(1)
+	go wd.watchWrapper(wd.handleCompensatingNotExistWorkerFlow)
 }
 
 // 定期处理异常任务流或任务
 func (wd *watchDog) watchWrapper(do func(kt *kit.Kit) error) {
-	for {
-		select {
-		case <-wd.closeCh:
-			break
-		default:
-		}
+	defer wd.wg.Done()
 
+	for {
 		kt := NewKit()
 		if err := do(kt); err != nil {
 			logs.Errorf("%s: watch dog do watch func failed, err: %v, rid: %s", constant.AsyncTaskWarnSign,
 				err, kt.Rid)
 		}
-		time.Sleep(wd.watchIntervalSec)
-	}
 
-	wd.wg.Done()
+		select {
+		case <-wd.closeCh:
+			return
+		case <-time.After(wd.watchIntervalSec):
+		}
+	}
 }
 
 // Close 等待当前执行体执行完成后再关闭
@@ -121,30 +130,8 @@ func (wd *watchDog) Close() {
 // handleExpiredTasks 将超时任务和所属的任务流，设置为失败状态，失败原因：ErrTaskExecTimeout
 func (wd *watchDog) handleExpiredTasks(kt *kit.Kit) error {
 
-	input := &backend.ListInput{
-		Filter: &filter.Expression{
-			Op: filter.And,
-			Rules: []filter.RuleFactory{
-				&filter.AtomRule{
-					Field: "state",
-					Op:    filter.In.Factory(),
-					Value: []enumor.TaskState{enumor.TaskRunning, enumor.TaskRollback},
-				},
-				&filter.AtomRule{
-					Field: "updated_at",
-					Op:    filter.LessThan.Factory(),
-					Value: times.ConvStdTimeFormat(times.ConvStdTimeNow().Add(-wd.taskTimeoutSec)),
-				},
-			},
-		},
-		Page: &core.BasePage{
-			Start: 0,
-			Limit: listExpiredTasksLimit,
-		},
-	}
-	tasks, err := wd.bd.ListTask(kt, input)
+	tasks, err := wd.listExpiredTasks(kt)
 	if err != nil {
-		logs.Errorf("list expired tasks failed, err: %v, rid: %s", err, kt.Rid)
 		return err
 	}
 
@@ -153,6 +140,15 @@ func (wd *watchDog) handleExpiredTasks(kt *kit.Kit) error {
 		return nil
 	}
 
+	flowIDs := make([]string, 0, len(tasks))
+	for _, one := range tasks {
+		flowIDs = append(flowIDs, one.FlowID)
+	}
+	failedStates, err := wd.getFlowFailedStates(kt, flowIDs)
+	if err != nil {
+		return err
+	}
+
 	ids := make([]string, 0, len(tasks))
 	for _, one := range tasks {
 		ids = append(ids, one.ID)
@@ -160,16 +156,21 @@ func (wd *watchDog) handleExpiredTasks(kt *kit.Kit) error {
 			return err
 		}
 
+		state, exist := failedStates[one.FlowID]
+		if !exist {
+			state = enumor.FlowFailed
+		}
+
 		flows := []model.Flow{
 			{
 				ID:    one.FlowID,
-				State: enumor.FlowFailed,
+				State: state,
 				Reason: &tableasync.Reason{
 					Message: ErrTaskExecTimeout,
 				},
 			},
 		}
-		if err = wd.bd.BatchUpdateFlow(kt, flows); err != nil {
+		if err = wd.bd.FencedBatchUpdateFlow(kt, wd.term, flows); err != nil {
 			logs.Errorf("update flow to failed state failed, err: %v, rid: %s", err, kt.Rid)
 			return err
 		}
@@ -180,6 +181,112 @@ func (wd *watchDog) handleExpiredTasks(kt *kit.Kit) error {
 	return nil
 }
 
+// listExpiredTasks 查询执行超时的任务。未设置超时时间的任务直接按全局超时时间在查询条件中过滤；设置了超时时间的任务，
+// 先按最小超时时间过滤出长时间未更新的任务，再按照任务各自的超时时间进行判断，避免每次扫描所有执行中的任务。
+// 等待状态的任务不占用执行器，由调度器轮询等待的事件是否完成或超时，不作为执行超时的任务处理。
+func (wd *watchDog) listExpiredTasks(kt *kit.Kit) ([]model.Task, error) {
+	now := times.ConvStdTimeNow()
+	minTimeout := wd.taskTimeoutSec
+	if minTimeout > minTaskTimeout {
+		minTimeout = minTaskTimeout
+	}
+
+	input := &backend.ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{
+					Field: "state",
+					Op:    filter.In.Factory(),
+					Value: []enumor.TaskState{enumor.TaskRunning, enumor.TaskRollback},
+				},
+				&filter.Expression{
+					Op: filter.Or,
+					Rules: []filter.RuleFactory{
+						&filter.Expression{
+							Op: filter.And,
+							Rules: []filter.RuleFactory{
+								&filter.AtomRule{Field: "timeout_sec", Op: filter.Equal.Factory(), Value: 0},
+								&filter.AtomRule{Field: "updated_at", Op: filter.LessThan.Factory(),
+									Value: times.ConvStdTimeFormat(now.Add(-wd.taskTimeoutSec))},
+							},
+						},
+						&filter.Expression{
+							Op: filter.And,
+							Rules: []filter.RuleFactory{
+								&filter.AtomRule{Field: "timeout_sec", Op: filter.NotEqual.Factory(), Value: 0},
+								&filter.AtomRule{Field: "updated_at", Op: filter.LessThan.Factory(),
+									Value: times.ConvStdTimeFormat(now.Add(-minTimeout))},
+							},
+						},
+					},
+				},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: listExpiredTasksLimit,
+		},
+		Fields: []string{"id", "flow_id", "timeout_sec", "updated_at"},
+	}
+
+	expired := make([]model.Task, 0)
+	for {
+		tasks, err := wd.bd.ListTask(kt, input)
+		if err != nil {
+			logs.Errorf("list running tasks failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range tasks {
+			if wd.isTaskExpired(one, now) {
+				expired = append(expired, one)
+			}
+		}
+
+		if len(tasks) < int(input.Page.Limit) {
+			break
+		}
+		input.Page.Start += uint32(input.Page.Limit)
+	}
+
+	return expired, nil
+}
+
+// getFlowFailedStates 获取任务流执行失败后的目标状态，开启补偿的任务流进入补偿中状态。
+func (wd *watchDog) getFlowFailedStates(kt *kit.Kit, flowIDs []string) (map[string]enumor.FlowState, error) {
+	input := &backend.ListInput{
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "compensate"},
+	}
+
+	states := make(map[string]enumor.FlowState, len(flowIDs))
+	for _, partIDs := range slice.Split(slice.Unique(flowIDs), int(core.DefaultMaxPageLimit)) {
+		input.Filter = tools.ContainersExpression("id", partIDs)
+		flows, err := wd.bd.ListFlow(kt, input)
+		if err != nil {
+			logs.Errorf("list flow failed, err: %v, ids: %v, rid: %s", err, partIDs, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range flows {
+			states[one.ID] = getFlowFailedState(one)
+		}
+	}
+
+	return states, nil
+}
+
+// isTaskExpired 判断任务是否执行超时，任务设置了执行超时时间时，优先使用任务的超时时间，否则使用全局配置的超时时间。
+func (wd *watchDog) isTaskExpired(task model.Task, now time.Time) bool {
+	timeout := wd.taskTimeoutSec
+	if task.TimeoutSec != 0 {
+		timeout = time.Duration(task.TimeoutSec) * time.Second
+	}
+
+	return task.UpdatedAt < times.ConvStdTimeFormat(now.Add(-timeout))
+}
+
 func (wd *watchDog) updateTimeoutTask(kt *kit.Kit, id string) error {
 	task := &model.Task{
 		ID:    id,
@@ -188,7 +295,7 @@ func (wd *watchDog) updateTimeoutTask(kt *kit.Kit, id string) error {
 			Message: ErrTaskExecTimeout,
 		},
 	}
-	if err := wd.bd.UpdateTask(kt, task); err != nil {
+	if err := wd.bd.FencedUpdateTask(kt, wd.term, task); err != nil {
 		logs.Errorf("update task to failed state failed, err: %v, rid: %s", err, kt.Rid)
 		return err
 	}
@@ -217,7 +324,7 @@ func (wd *watchDog) handleScheduledNotExistWorkerFlow(kt *kit.Kit) error {
 			Worker: converter.ValToPtr(""),
 		})
 	}
-	if err = wd.bd.BatchUpdateFlow(kt, flows); err != nil {
+	if err = wd.bd.FencedBatchUpdateFlow(kt, wd.term, mds); err != nil {
 		logs.Errorf("update flows failed, err: %v, rid: %s", err, kt.Rid)
 		return err
 	}
@@ -320,7 +427,11 @@ func (wd *watchDog) handleRunningFlow(kt *kit.Kit, flow model.Flow) error {
 	// 如果树已经处于结束状态，则直接更新
 	state := root.ComputeState()
 	if state == enumor.FlowSuccess || state == enumor.FlowFailed {
-		if err = updateFlowState(kt, wd.bd, flow.ID, enumor.FlowRunning, state); err != nil {
+		if state == enumor.FlowFailed {
+			state = getFlowFailedState(flow)
+		}
+
+		if err = wd.updateFlowState(kt, flow.ID, enumor.FlowRunning, state); err != nil {
 			logs.Errorf("update flow state to %s failed, err: %v, rid: %s", state, err, kt.Rid)
 			return err
 		}
@@ -338,7 +449,7 @@ func (wd *watchDog) handleRunningFlow(kt *kit.Kit, flow model.Flow) error {
 				Worker: converter.ValToPtr(""),
 			},
 		}
-		if err = wd.bd.BatchUpdateFlow(kt, mds); err != nil {
+		if err = wd.bd.FencedBatchUpdateFlow(kt, wd.term, mds); err != nil {
 			logs.Errorf("update flows failed, err: %v, rid: %s", err, kt.Rid)
 			return err
 		}
@@ -354,6 +465,20 @@ func (wd *watchDog) handleRunningFlow(kt *kit.Kit, flow model.Flow) error {
 	return nil
 }
 
+// updateFlowState 携带主节点任期的fencing token更新Flow状态，采用CAS加三次重试。source原状态，dest目标状态。
+func (wd *watchDog) updateFlowState(kt *kit.Kit, flowID string, source, dest enumor.FlowState) error {
+	info := backend.UpdateFlowInfo{
+		ID:     flowID,
+		Source: source,
+		Target: dest,
+	}
+
+	rty := retry.NewRetryPolicy(defRetryCount, defRetryRangeMS)
+	return rty.BaseExec(kt, func() error {
+		return wd.bd.FencedBatchUpdateFlowStateByCAS(kt, wd.term, []backend.UpdateFlowInfo{info})
+	})
+}
+
 // handleRunningTasks 找出所有处于执行状态的节点，判断它的执行节点是否已经退出，如果退出将Task回滚或者置于失败状态。
 func (wd *watchDog) handleRunningTasks(kt *kit.Kit, flow model.Flow, ids []string) error {
 	tasks, err := listTaskByIDs(kt, wd.bd, ids)
@@ -364,7 +489,7 @@ func (wd *watchDog) handleRunningTasks(kt *kit.Kit, flow model.Flow, ids []strin
 
 	for _, task := range tasks {
 		// 如果任务已经超时，更新为失败状态，失败原因超时
-		if task.UpdatedAt < times.ConvStdTimeFormat(times.ConvStdTimeNow().Add(-wd.taskTimeoutSec)) {
+		if wd.isTaskExpired(task.Task, times.ConvStdTimeNow()) {
 			if err = wd.updateTimeoutTask(kt, task.ID); err != nil {
 				return err
 			}
@@ -380,14 +505,15 @@ func (wd *watchDog) handleRunningTasks(kt *kit.Kit, flow model.Flow, ids []strin
 					Message: ErrTaskNodeShutdown,
 				},
 			}
-			if err = wd.bd.UpdateTask(kt, md); err != nil {
+			if err = wd.bd.FencedUpdateTask(kt, wd.term, md); err != nil {
 				logs.Errorf("update task to failed state failed, err: %v, rid: %s", err, kt.Rid)
 				return err
 			}
 		}
 
-		task.InitDep(run.NewExecuteContext(task.Kit, flow.ShareData), func(kt *kit.Kit, task *model.Task) error {
-			return wd.bd.UpdateTask(kt, task)
+		ekt := run.NewExecuteContext(task.Kit, flow.ShareData, flow.ID, task.ID)
+		task.InitDep(ekt, func(kt *kit.Kit, task *model.Task) error {
+			return wd.bd.FencedUpdateTask(kt, wd.term, task)
 		}, &Flow{Flow: flow})
 
 		// 如果任务可以重试，将任务回滚
@@ -401,7 +527,7 @@ func (wd *watchDog) handleRunningTasks(kt *kit.Kit, flow model.Flow, ids []strin
 					Message: fmt.Sprintf("rollback not exist node task failed, err: %v", err),
 				},
 			}
-			if patchErr := wd.bd.UpdateTask(kt, md); patchErr != nil {
+			if patchErr := wd.bd.FencedUpdateTask(kt, wd.term, md); patchErr != nil {
 				logs.Errorf("update task to failed state failed, err: %v, rid: %s", patchErr, kt.Rid)
 				return err
 			}
@@ -409,3 +535,51 @@ func (wd *watchDog) handleRunningTasks(kt *kit.Kit, flow model.Flow, ids []strin
 	}
 	return nil
 }
+
+// handleCompensatingNotExistWorkerFlow 将处于补偿中状态且执行节点已经下线的任务流，重新分配给当前节点进行补偿。
+func (wd *watchDog) handleCompensatingNotExistWorkerFlow(kt *kit.Kit) error {
+
+	flows, err := wd.queryNotExistNodesFlowByState(kt, enumor.FlowCompensating)
+	if err != nil {
+		return err
+	}
+
+	if len(flows) == 0 {
+		return nil
+	}
+
+	mds := make([]model.Flow, 0, len(flows))
+	ids := make([]string, 0, len(flows))
+	for _, flow := range flows {
+		// 需要等待上一个节点Shutdown结束后再处理，否则会有两个节点补偿同一个Flow。
+		firstWatchTime, exist := wd.compensatingFlowMap[flow.ID]
+		if !exist {
+			wd.compensatingFlowMap[flow.ID] = times.ConvStdTimeNow()
+			continue
+		}
+
+		if !firstWatchTime.Before(times.ConvStdTimeNow().Add(-wd.shutdownWaitTimeSec)) {
+			continue
+		}
+
+		ids = append(ids, flow.ID)
+		mds = append(mds, model.Flow{
+			ID:     flow.ID,
+			Worker: converter.ValToPtr(wd.ld.CurrNode()),
+		})
+		delete(wd.compensatingFlowMap, flow.ID)
+	}
+
+	if len(mds) == 0 {
+		return nil
+	}
+
+	if err = wd.bd.FencedBatchUpdateFlow(kt, wd.term, mds); err != nil {
+		logs.Errorf("update flows failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("handleCompensatingNotExistWorkerFlow success, count: %d, ids: %v, rid: %s", len(ids), ids, kt.Rid)
+
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/times"
+)
+
+func TestIsTaskExpired(t *testing.T) {
+	wd := &watchDog{taskTimeoutSec: 300 * time.Second}
+
+	now := times.ConvStdTimeNow()
+	updatedAt := times.ConvStdTimeFormat(now.Add(-2 * time.Minute))
+
+	// 未设置任务超时时间，使用全局超时时间
+	if wd.isTaskExpired(model.Task{UpdatedAt: updatedAt}, now) {
+		t.Errorf("task without timeout_sec should not expired before global timeout")
+	}
+
+	// 任务超时时间优先于全局超时时间
+	if !wd.isTaskExpired(model.Task{UpdatedAt: updatedAt, TimeoutSec: 60}, now) {
+		t.Errorf("task with timeout_sec 60 should expired after 2 minutes")
+	}
+
+	if wd.isTaskExpired(model.Task{UpdatedAt: updatedAt, TimeoutSec: 1200}, now) {
+		t.Errorf("task with timeout_sec 1200 should not expired after 2 minutes")
+	}
+}
+
+func TestListExpiredTasks(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+	wd := &watchDog{bd: bd, taskTimeoutSec: 300 * time.Second}
+
+	flow := &model.Flow{
+		Name: "test_flow",
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: "test_action", TimeoutSec: 60},
+		},
+	}
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	if err = bd.UpdateTask(kt, &model.Task{ID: tasks[0].ID, State: enumor.TaskRunning}); err != nil {
+		t.Fatalf("update task failed, err: %v", err)
+	}
+
+	expired, err := wd.listExpiredTasks(kt)
+	if err != nil {
+		t.Fatalf("list expired tasks failed, err: %v", err)
+	}
+
+	if len(expired) != 0 {
+		t.Errorf("running task just updated should not expired, but got %d", len(expired))
+	}
+
+	running, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	if running[0].TimeoutSec != 60 {
+		t.Errorf("task timeout_sec should be 60, but got %d", running[0].TimeoutSec)
+	}
+}
+
+func TestListExpiredTasksByTimeout(t *testing.T) {
+	kt := NewKit()
+	bd := backend.NewMemory()
+	// 测试中任务刚刚更新，将全局超时时间设置为负数使未设置超时时间的任务立即超时
+	wd := &watchDog{bd: bd, taskTimeoutSec: -time.Minute}
+
+	flow := &model.Flow{
+		Name: "test_flow",
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: "test_action"},
+			{FlowName: "test_flow", ActionID: "2", ActionName: "test_action", TimeoutSec: 60},
+		},
+	}
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	actionIDs := make(map[string]string, len(tasks))
+	for _, one := range tasks {
+		actionIDs[one.ID] = string(one.ActionID)
+		if err = bd.UpdateTask(kt, &model.Task{ID: one.ID, State: enumor.TaskRunning}); err != nil {
+			t.Fatalf("update task failed, err: %v", err)
+		}
+	}
+
+	expired, err := wd.listExpiredTasks(kt)
+	if err != nil {
+		t.Fatalf("list expired tasks failed, err: %v", err)
+	}
+
+	// 只有未设置超时时间的任务超时，设置了60秒超时时间的任务按自身超时时间判断未超时
+	if len(expired) != 1 || actionIDs[expired[0].ID] != "1" {
+		t.Errorf("only task without timeout_sec should expired, but got %+v", expired)
+	}
+}
@@ -0,0 +1,240 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package graph 将任务流的任务依赖关系渲染为 Graphviz DOT 或 Mermaid 文本，用于排查任务流执行情况和评审任务流模版。
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Node 任务流图中的任务节点
+type Node struct {
+	// ID 节点ID，即任务的ActionID
+	ID string
+	// ActionName 任务执行的Action
+	ActionName enumor.ActionName
+	// State 任务状态，任务流模版中的节点为空
+	State enumor.TaskState
+	// Duration 任务执行耗时，未开始执行的任务为0
+	Duration time.Duration
+	// DependOn 依赖的节点ID
+	DependOn []string
+}
+
+// label 节点展示内容：ActionID、Action名称、状态和耗时
+func (n Node) label() []string {
+	lines := []string{fmt.Sprintf("%s: %s", n.ID, n.ActionName)}
+
+	status := string(n.State)
+	if n.Duration > 0 {
+		status = strings.TrimSpace(fmt.Sprintf("%s %s", status, n.Duration.Round(time.Second)))
+	}
+	if len(status) != 0 {
+		lines = append(lines, status)
+	}
+
+	return lines
+}
+
+// stateColors 不同任务状态的节点颜色
+var stateColors = map[enumor.TaskState]string{
+	enumor.TaskPending:     "#DCDEE5",
+	enumor.TaskRunning:     "#3A84FF",
+	enumor.TaskRollback:    "#FF9C01",
+	enumor.TaskRetrying:    "#FFB848",
+	enumor.TaskWaiting:     "#A3C5FD",
+	enumor.TaskSuccess:     "#2DCB56",
+	enumor.TaskFailed:      "#EA3636",
+	enumor.TaskCancel:      "#979BA5",
+	enumor.TaskCompensated: "#C4C6CC",
+	enumor.TaskSkipped:     "#F0F1F5",
+}
+
+// defaultColor 任务流模版中没有状态的节点颜色
+const defaultColor = "#FFFFFF"
+
+func nodeColor(state enumor.TaskState) string {
+	if color, exist := stateColors[state]; exist {
+		return color
+	}
+
+	return defaultColor
+}
+
+// FromTasks 根据任务流的任务构造图节点。任务没有记录开始执行时间，任务的开始时间取任务流开始时间（未设置时取
+// 创建时间）和依赖任务中最晚的结束时间（任务最后一次更新时间），结束时间取任务最后一次更新时间，执行中的任务取当前时间。
+func FromTasks(flow coreasync.AsyncFlow, tasks []coreasync.AsyncFlowTask, now time.Time) []Node {
+	endAts := make(map[string]time.Time, len(tasks))
+	for _, one := range tasks {
+		endAts[one.ActionID] = parseTime(one.UpdatedAt)
+	}
+	flowStartAt := parseTime(flow.StartAt)
+	if createdAt := parseTime(flow.CreatedAt); flowStartAt.Before(createdAt) {
+		flowStartAt = createdAt
+	}
+
+	nodes := make([]Node, 0, len(tasks))
+	for _, one := range tasks {
+		node := Node{
+			ID:         one.ActionID,
+			ActionName: one.ActionName,
+			State:      one.State,
+			DependOn:   make([]string, 0, len(one.DependOn)),
+		}
+
+		startAt := flowStartAt
+		for _, dep := range one.DependOn {
+			node.DependOn = append(node.DependOn, dep)
+			if endAts[dep].After(startAt) {
+				startAt = endAts[dep]
+			}
+		}
+
+		switch one.State {
+		case enumor.TaskPending, enumor.TaskCancel, enumor.TaskSkipped:
+		case enumor.TaskRunning, enumor.TaskRollback, enumor.TaskRetrying, enumor.TaskWaiting:
+			node.Duration = positive(now.Sub(startAt))
+		default:
+			node.Duration = positive(endAts[one.ActionID].Sub(startAt))
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+// FromTemplate 根据任务流模版构造图节点，用于注册任务流模版前评审任务依赖关系。
+func FromTemplate(tpl *action.FlowTemplate) []Node {
+	nodes := make([]Node, 0, len(tpl.Tasks))
+	for _, one := range tpl.Tasks {
+		node := Node{
+			ID:         string(one.ActionID),
+			ActionName: one.ActionName,
+			DependOn:   make([]string, 0, len(one.DependOn)),
+		}
+		for _, dep := range one.DependOn {
+			node.DependOn = append(node.DependOn, string(dep))
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+// Render 按照指定格式渲染任务流图
+func Render(format enumor.FlowGraphFormat, name string, nodes []Node) (string, error) {
+	switch format {
+	case enumor.FlowGraphDot:
+		return RenderDOT(name, nodes), nil
+	case enumor.FlowGraphMermaid:
+		return RenderMermaid(nodes), nil
+	default:
+		return "", fmt.Errorf("unsupported flow graph format: %s", format)
+	}
+}
+
+// RenderDOT 渲染为 Graphviz DOT 文本
+func RenderDOT(name string, nodes []Node) string {
+	b := new(strings.Builder)
+	fmt.Fprintf(b, "digraph %s {\n", quoteDOT(name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	for _, one := range nodes {
+		fmt.Fprintf(b, "  %s [label=%s, fillcolor=%s];\n", quoteDOT(one.ID),
+			quoteDOT(strings.Join(one.label(), "\n")), quoteDOT(nodeColor(one.State)))
+	}
+
+	for _, one := range nodes {
+		for _, dep := range one.DependOn {
+			fmt.Fprintf(b, "  %s -> %s;\n", quoteDOT(dep), quoteDOT(one.ID))
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func quoteDOT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// RenderMermaid 渲染为 Mermaid flowchart 文本，节点ID使用序号，避免ActionID中的特殊字符影响解析。
+func RenderMermaid(nodes []Node) string {
+	ids := make(map[string]string, len(nodes))
+	for index, one := range nodes {
+		ids[one.ID] = fmt.Sprintf("n%d", index)
+	}
+
+	b := new(strings.Builder)
+	b.WriteString("flowchart LR\n")
+	for _, one := range nodes {
+		fmt.Fprintf(b, "  %s[\"%s\"]\n", ids[one.ID], escapeMermaid(strings.Join(one.label(), "<br/>")))
+	}
+
+	for _, one := range nodes {
+		for _, dep := range one.DependOn {
+			depID, exist := ids[dep]
+			if !exist {
+				continue
+			}
+			fmt.Fprintf(b, "  %s --> %s\n", depID, ids[one.ID])
+		}
+	}
+
+	for index, one := range nodes {
+		fmt.Fprintf(b, "  style n%d fill:%s\n", index, nodeColor(one.State))
+	}
+
+	return b.String()
+}
+
+func escapeMermaid(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+func parseTime(value string) time.Time {
+	t, err := time.Parse(constant.TimeStdFormat, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+func positive(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+
+	return d
+}
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package graph
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestFromTasks(t *testing.T) {
+	flow := coreasync.AsyncFlow{
+		ID:       "0000000p",
+		Name:     "create_cvm",
+		StartAt:  "2023-08-30T11:00:00Z",
+		Revision: core.Revision{CreatedAt: "2023-08-30T10:59:00Z"},
+	}
+	tasks := []coreasync.AsyncFlowTask{
+		{
+			ActionID:   "1",
+			ActionName: "create_cvm",
+			State:      enumor.TaskSuccess,
+			Revision:   core.Revision{UpdatedAt: "2023-08-30T11:00:30Z"},
+		},
+		{
+			ActionID:   "2",
+			ActionName: "sync_cvm",
+			State:      enumor.TaskRunning,
+			DependOn:   []string{"1"},
+			Revision:   core.Revision{UpdatedAt: "2023-08-30T11:00:40Z"},
+		},
+		{
+			ActionID:   "3",
+			ActionName: "notify",
+			State:      enumor.TaskPending,
+			DependOn:   []string{"2"},
+		},
+	}
+
+	now, _ := time.Parse(time.RFC3339, "2023-08-30T11:02:30Z")
+	nodes := FromTasks(flow, tasks, now)
+	if len(nodes) != 3 {
+		t.Fatalf("node count should be 3, but got %d", len(nodes))
+	}
+
+	if nodes[0].Duration != 30*time.Second {
+		t.Errorf("finished task duration should be 30s, but got %s", nodes[0].Duration)
+	}
+
+	if nodes[1].Duration != 2*time.Minute {
+		t.Errorf("running task duration should be 2m, but got %s", nodes[1].Duration)
+	}
+
+	if nodes[2].Duration != 0 {
+		t.Errorf("pending task duration should be 0, but got %s", nodes[2].Duration)
+	}
+}
+
+func TestRender(t *testing.T) {
+	nodes := []Node{
+		{ID: "1", ActionName: "create_cvm", State: enumor.TaskSuccess, Duration: 30 * time.Second},
+		{ID: `2"x`, ActionName: "sync_cvm", State: enumor.TaskRunning, DependOn: []string{"1"}},
+	}
+
+	dot, err := Render(enumor.FlowGraphDot, "create_cvm", nodes)
+	if err != nil {
+		t.Fatalf("render dot failed, err: %v", err)
+	}
+
+	for _, want := range []string{`digraph "create_cvm" {`,
+		`"1" [label="1: create_cvm\nsuccess 30s", fillcolor="#2DCB56"];`, `"1" -> "2\"x";`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("dot should contain %s, but got:\n%s", want, dot)
+		}
+	}
+
+	mermaid, err := Render(enumor.FlowGraphMermaid, "create_cvm", nodes)
+	if err != nil {
+		t.Fatalf("render mermaid failed, err: %v", err)
+	}
+
+	for _, want := range []string{"flowchart LR", `n1["2#quot;x: sync_cvm<br/>running"]`, "n0 --> n1",
+		"style n0 fill:#2DCB56"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid should contain %s, but got:\n%s", want, mermaid)
+		}
+	}
+
+	if _, err = Render("svg", "create_cvm", nodes); err == nil {
+		t.Errorf("render unsupported format should return error")
+	}
+}
@@ -40,9 +40,16 @@ func (p *producer) AddCustomFlow(kt *kit.Kit, opt *AddCustomFlowOption) (id stri
 		return "", err
 	}
 
+	// 构建任务流时会设置请求的默认值，需要在构建前计算请求内容的摘要
+	digest, err := payloadDigest(opt)
+	if err != nil {
+		return "", err
+	}
+
 	flow := buildCustomFlow(opt)
+	flow.PayloadDigest = digest
 
-	id, err = p.backend.CreateFlow(kt, flow)
+	id, err = p.createFlow(kt, flow)
 	if err != nil {
 		logs.Errorf("create flow failed, err: %v, rid: %s", err, kt.Rid)
 		return "", err
@@ -108,10 +115,16 @@ func buildCustomFlow(opt *AddCustomFlowOption) *model.Flow {
 	}
 
 	flow := &model.Flow{
-		Name:      opt.Name,
-		ShareData: opt.ShareData,
-		Memo:      opt.Memo,
-		Tasks:     make([]model.Task, 0, len(opt.Tasks)),
+		Name:           opt.Name,
+		ShareData:      opt.ShareData,
+		Memo:           opt.Memo,
+		StartAt:        opt.StartAt,
+		Priority:       opt.Priority,
+		AccountID:      opt.AccountID,
+		Compensate:     opt.Compensate,
+		Callbacks:      opt.Callbacks,
+		IdempotencyKey: opt.IdempotencyKey,
+		Tasks:          make([]model.Task, 0, len(opt.Tasks)),
 	}
 
 	for _, one := range opt.Tasks {
@@ -120,12 +133,16 @@ func buildCustomFlow(opt *AddCustomFlowOption) *model.Flow {
 		}
 
 		flow.Tasks = append(flow.Tasks, model.Task{
-			FlowName:   opt.Name,
-			ActionID:   one.ActionID,
-			ActionName: one.ActionName,
-			Params:     one.Params,
-			Retry:      one.Retry,
-			DependOn:   one.DependOn,
+			FlowName:     opt.Name,
+			ActionID:     one.ActionID,
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			Retry:        one.Retry,
+			DependOn:     one.DependOn,
+			TimeoutSec:   action.GetTimeoutSec(one.ActionName, one.TimeoutSec),
+			AllowFailure: one.AllowFailure,
+			TriggerRule:  one.TriggerRule,
+			RunCondition: one.RunCondition,
 		})
 	}
 
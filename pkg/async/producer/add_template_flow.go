@@ -46,9 +46,15 @@ func (p *producer) AddTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (id
 		return "", err
 	}
 
+	digest, err := payloadDigest(opt)
+	if err != nil {
+		return "", err
+	}
+
 	flow := buildFlow(tpl, opt)
+	flow.PayloadDigest = digest
 
-	id, err = p.backend.CreateFlow(kt, flow)
+	id, err = p.createFlow(kt, flow)
 	if err != nil {
 		logs.Errorf("create flow failed, err: %v, rid: %s", err, kt.Rid)
 		return "", err
@@ -59,15 +65,25 @@ func (p *producer) AddTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (id
 
 func buildFlow(tpl action.FlowTemplate, opt *AddTemplateFlowOption) *model.Flow {
 	flow := &model.Flow{
-		Name:      tpl.Name,
-		ShareData: tpl.ShareData,
-		Memo:      opt.Memo,
-		Tasks:     make([]model.Task, 0, len(tpl.Tasks)),
+		Name:           tpl.Name,
+		ShareData:      tpl.ShareData,
+		Memo:           opt.Memo,
+		StartAt:        opt.StartAt,
+		Priority:       opt.Priority,
+		AccountID:      opt.AccountID,
+		Compensate:     tpl.Compensate || opt.Compensate,
+		ParentFlowID:   opt.ParentFlowID,
+		ParentTaskID:   opt.ParentTaskID,
+		Callbacks:      opt.Callbacks,
+		IdempotencyKey: opt.IdempotencyKey,
+		Tasks:          make([]model.Task, 0, len(tpl.Tasks)),
 	}
 
 	m := make(map[action.ActIDType]types.JsonField, len(opt.Tasks))
+	timeoutMap := make(map[action.ActIDType]uint, len(opt.Tasks))
 	for _, one := range opt.Tasks {
 		m[one.ActionID] = one.Params
+		timeoutMap[one.ActionID] = one.TimeoutSec
 	}
 
 	for _, one := range tpl.Tasks {
@@ -75,13 +91,27 @@ func buildFlow(tpl action.FlowTemplate, opt *AddTemplateFlowOption) *model.Flow
 			one.Retry = new(tableasync.Retry)
 		}
 
+		// 声明式任务流模版的任务未指定参数时，使用模版中定义的参数模版，并标记到任务上，执行时使用任务流共享数据渲染。
+		// 用户传入的参数不会被渲染。
+		params, exist := m[one.ActionID]
+		render := false
+		if !exist && len(one.ParamsTemplate) != 0 {
+			params = types.JsonField(one.ParamsTemplate)
+			render = true
+		}
+
 		flow.Tasks = append(flow.Tasks, model.Task{
-			FlowName:   tpl.Name,
-			ActionID:   one.ActionID,
-			ActionName: one.ActionName,
-			Params:     m[one.ActionID],
-			Retry:      one.Retry,
-			DependOn:   one.DependOn,
+			FlowName:     tpl.Name,
+			ActionID:     one.ActionID,
+			ActionName:   one.ActionName,
+			Params:       params,
+			ParamsRender: render,
+			Retry:        one.Retry,
+			DependOn:     one.DependOn,
+			TimeoutSec:   action.GetTimeoutSec(one.ActionName, timeoutMap[one.ActionID]),
+			AllowFailure: one.AllowFailure,
+			TriggerRule:  one.TriggerRule,
+			RunCondition: one.RunCondition,
 		})
 	}
 
@@ -105,8 +135,9 @@ func validateTplUseParam(kt *kit.Kit, template action.FlowTemplate, opt *AddTemp
 			return fmt.Errorf("action: %s not exist", task.ActionName)
 		}
 
-		// Task 参数校验
-		if task.Params != nil && task.Params.Type != nil {
+		// Task 参数校验，未指定参数时使用声明式任务流模版的参数模版，参数模版已经在加载时校验
+		_, provided := m[task.ActionID]
+		if task.Params != nil && task.Params.Type != nil && (provided || len(task.ParamsTemplate) == 0) {
 			fields, exist := m[task.ActionID]
 			if !exist {
 				return fmt.Errorf("action: %s need params", task.ActionName)
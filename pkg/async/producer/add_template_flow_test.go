@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"testing"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+)
+
+func TestBuildFlowParamsRender(t *testing.T) {
+	tpl := action.FlowTemplate{
+		Name: "render_test_flow",
+		Tasks: []action.TaskTemplate{
+			{ActionID: "1", ActionName: enumor.ActionSleep, ParamsTemplate: `{"vpc_id": "{{ .vpc_id }}"}`},
+			{ActionID: "2", ActionName: enumor.ActionSleep, ParamsTemplate: `{"vpc_id": "{{ .vpc_id }}"}`},
+		},
+	}
+
+	// 用户传入的参数中包含模版语法，不能被标记为需要渲染
+	userParams := types.JsonField(`{"vpc_id": "{{ .secret }}"}`)
+	opt := &AddTemplateFlowOption{
+		Name:  tpl.Name,
+		Tasks: []TemplateFlowTask{{ActionID: "2", Params: userParams}},
+	}
+
+	flow := buildFlow(tpl, opt)
+	if len(flow.Tasks) != 2 {
+		t.Fatalf("flow tasks count should be 2, but got %d", len(flow.Tasks))
+	}
+
+	if !flow.Tasks[0].ParamsRender || flow.Tasks[0].Params != types.JsonField(tpl.Tasks[0].ParamsTemplate) {
+		t.Errorf("task using params template should be marked to render, task: %+v", flow.Tasks[0])
+	}
+
+	if flow.Tasks[1].ParamsRender || flow.Tasks[1].Params != userParams {
+		t.Errorf("task using user params should not be marked to render, task: %+v", flow.Tasks[1])
+	}
+}
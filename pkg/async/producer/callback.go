@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+)
+
+// ReplayCallback 重新投递任务流回调。投递记录重置为 pending 状态并清空投递次数，由主节点按照记录中的事件内容重新发送。
+func (p *producer) ReplayCallback(kt *kit.Kit, id string) error {
+	deliveries, err := p.backend.ListCallbackDelivery(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		logs.Errorf("list callback delivery failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	if len(deliveries) == 0 {
+		return errf.Newf(errf.RecordNotFound, "callback delivery: %s not found", id)
+	}
+
+	if deliveries[0].State == enumor.CallbackPending {
+		return errf.Newf(errf.InvalidParameter, "callback delivery: %s is delivering, can not replay", id)
+	}
+
+	md := &model.CallbackDelivery{
+		ID:       id,
+		State:    enumor.CallbackPending,
+		Attempts: converter.ValToPtr(uint(0)),
+		NextAt:   times.ConvStdTimeFormat(times.ConvStdTimeNow()),
+		Reason:   new(tableasync.Reason),
+	}
+	if err = p.backend.UpdateCallbackDelivery(kt, md); err != nil {
+		logs.Errorf("reset callback delivery failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"fmt"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/cron"
+	"hcm/pkg/tools/times"
+)
+
+// AddCronFlow 添加周期任务流，由主节点的派发器按照cron表达式定时生成普通任务流。
+func (p *producer) AddCronFlow(kt *kit.Kit, opt *AddCronFlowOption) (id string, err error) {
+	if err = opt.Validate(); err != nil {
+		return "", err
+	}
+
+	flow, err := buildCronFlowTpl(kt, opt)
+	if err != nil {
+		return "", err
+	}
+
+	sch, err := cron.Parse(opt.Spec)
+	if err != nil {
+		return "", err
+	}
+
+	next := sch.Next(times.ConvStdTimeNow())
+	if next.IsZero() {
+		return "", fmt.Errorf("cron spec: %s will never be triggered", opt.Spec)
+	}
+
+	cronFlow := &model.CronFlow{
+		Name:       flow.Name,
+		Spec:       opt.Spec,
+		ShareData:  flow.ShareData,
+		Memo:       flow.Memo,
+		Priority:   flow.Priority,
+		AccountID:  flow.AccountID,
+		Compensate: flow.Compensate,
+		Tasks:      flow.Tasks,
+		NextRunAt:  times.ConvStdTimeFormat(next),
+	}
+	id, err = p.backend.CreateCronFlow(kt, cronFlow)
+	if err != nil {
+		logs.Errorf("create cron flow failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	return id, nil
+}
+
+// buildCronFlowTpl 校验并构建周期任务流每次生成任务流时使用的任务流定义
+func buildCronFlowTpl(kt *kit.Kit, opt *AddCronFlowOption) (*model.Flow, error) {
+	if opt.Custom != nil {
+		if err := validateCustomFlowParam(kt, opt.Custom); err != nil {
+			logs.Errorf("validate custom flow param failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		return buildCustomFlow(opt.Custom), nil
+	}
+
+	tpl, exist := action.GetTpl(opt.Template.Name)
+	if !exist {
+		return nil, fmt.Errorf("flow tempalte: %s not found", opt.Template.Name)
+	}
+
+	if err := validateTplUseParam(kt, tpl, opt.Template); err != nil {
+		logs.Errorf("validate flow template use param failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return buildFlow(tpl, opt.Template), nil
+}
+
+// DeleteCronFlow 删除周期任务流，已经生成的任务流不受影响。
+func (p *producer) DeleteCronFlow(kt *kit.Kit, id string) error {
+	if err := p.backend.DeleteCronFlow(kt, id); err != nil {
+		logs.Errorf("delete cron flow failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
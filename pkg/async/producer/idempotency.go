@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/json"
+)
+
+// payloadDigest 计算提交任务流请求内容的摘要，用于判断相同幂等键的请求内容是否一致。
+func payloadDigest(opt interface{}) (string, error) {
+	data, err := json.Marshal(opt)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// createFlow 创建任务流。设置了幂等键时，相同幂等键且请求内容一致的重复提交返回已经创建的任务流ID，
+// 相同幂等键但请求内容不一致时返回冲突错误。
+func (p *producer) createFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
+	if len(flow.IdempotencyKey) == 0 {
+		return p.backend.CreateFlow(kt, flow)
+	}
+
+	id, err := p.getIdempotentFlow(kt, flow)
+	if err != nil {
+		return "", err
+	}
+
+	if len(id) != 0 {
+		logs.Infof("flow with idempotency_key: %s already exists, flow: %s, rid: %s", flow.IdempotencyKey, id,
+			kt.Rid)
+		return id, nil
+	}
+
+	id, err = p.backend.CreateFlow(kt, flow)
+	if err == nil {
+		return id, nil
+	}
+
+	// 并发提交相同幂等键的任务流时，后提交的请求因唯一索引冲突创建失败，此时返回先创建成功的任务流
+	existID, getErr := p.getIdempotentFlow(kt, flow)
+	if getErr != nil {
+		return "", getErr
+	}
+
+	if len(existID) != 0 {
+		return existID, nil
+	}
+
+	return "", err
+}
+
+// getIdempotentFlow 查询幂等键对应的任务流ID，不存在时返回空字符串。
+func (p *producer) getIdempotentFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("idempotency_key", flow.IdempotencyKey),
+		Page:   core.NewDefaultBasePage(),
+	}
+	flows, err := p.backend.ListFlow(kt, input)
+	if err != nil {
+		logs.Errorf("list flow by idempotency_key failed, err: %v, key: %s, rid: %s", err, flow.IdempotencyKey,
+			kt.Rid)
+		return "", err
+	}
+
+	if len(flows) == 0 {
+		return "", nil
+	}
+
+	if flows[0].PayloadDigest != flow.PayloadDigest {
+		return "", errf.Newf(errf.RecordConflict, "idempotency_key: %s is already used by flow: %s with "+
+			"different payload", flow.IdempotencyKey, flows[0].ID)
+	}
+
+	return flows[0].ID, nil
+}
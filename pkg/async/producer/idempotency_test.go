@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"testing"
+
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCreateFlowIdempotent(t *testing.T) {
+	kt := kit.New()
+	kt.User = "test"
+
+	pdr, err := NewProducer(backend.NewMemory(), prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("new producer failed, err: %v", err)
+	}
+	p := pdr.(*producer)
+
+	newFlow := func(memo string) *model.Flow {
+		opt := &AddCustomFlowOption{Name: "test_flow", Memo: memo, IdempotencyKey: "delete_cvm_001"}
+		digest, err := payloadDigest(opt)
+		if err != nil {
+			t.Fatalf("payload digest failed, err: %v", err)
+		}
+
+		flow := buildCustomFlow(opt)
+		flow.PayloadDigest = digest
+		return flow
+	}
+
+	id, err := p.createFlow(kt, newFlow("first"))
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	// 相同幂等键且请求内容一致，返回已经创建的任务流
+	retryID, err := p.createFlow(kt, newFlow("first"))
+	if err != nil {
+		t.Fatalf("create flow with same idempotency key failed, err: %v", err)
+	}
+	if retryID != id {
+		t.Errorf("retry submission should return flow %s, but got %s", id, retryID)
+	}
+
+	// 相同幂等键但请求内容不一致，返回冲突错误
+	_, err = p.createFlow(kt, newFlow("second"))
+	if err == nil {
+		t.Fatalf("same idempotency key with different payload should be conflict")
+	}
+	if ef := errf.Error(err); ef.Code != errf.RecordConflict {
+		t.Errorf("error code should be %d, but got %d", errf.RecordConflict, ef.Code)
+	}
+}
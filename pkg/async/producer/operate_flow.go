@@ -0,0 +1,318 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+const (
+	// flowCanceledReason 任务流被取消的原因
+	flowCanceledReason = "flow canceled by user"
+)
+
+// CancelFlow 取消任务流。任务流状态通过CAS更新为 cancel，未执行的任务同时更新为 cancel，
+// 执行中的任务由执行任务流的节点的调度器负责中断。
+func (p *producer) CancelFlow(kt *kit.Kit, flowID string) error {
+	flow, err := getFlow(kt, p.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	switch flow.State {
+	case enumor.FlowPending, enumor.FlowScheduled, enumor.FlowRunning:
+	default:
+		return errf.Newf(errf.InvalidParameter, "flow: %s state is %s, can not cancel", flowID, flow.State)
+	}
+
+	info := backend.UpdateFlowInfo{
+		ID:     flowID,
+		Source: flow.State,
+		Target: enumor.FlowCancel,
+		Reason: &tableasync.Reason{Message: flowCanceledReason},
+	}
+	if err = p.backend.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{info}); err != nil {
+		logs.Errorf("update flow state to cancel failed, err: %v, id: %s, rid: %s", err, flowID, kt.Rid)
+		return err
+	}
+
+	tasks, err := listTaskByFlowID(kt, p.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		// 等待中和重试等待中的任务不占用执行器，同样在这里取消
+		if task.State != enumor.TaskPending && task.State != enumor.TaskWaiting && task.State != enumor.TaskRetrying {
+			continue
+		}
+
+		taskInfo := &backend.UpdateTaskInfo{
+			ID:     task.ID,
+			Source: task.State,
+			Target: enumor.TaskCancel,
+			Reason: &tableasync.Reason{Message: flowCanceledReason},
+		}
+		if err = p.backend.UpdateTaskStateByCAS(kt, taskInfo); err != nil {
+			// 任务可能刚好被调度执行，此时由调度器中断该任务，这里不需要返回错误。
+			logs.Warnf("update task state to cancel failed, err: %v, id: %s, rid: %s", err, task.ID, kt.Rid)
+		}
+	}
+
+	return p.cancelSubFlow(kt, flowID)
+}
+
+// cancelSubFlow 取消任务流中子任务流Action创建的还未结束的子任务流
+func (p *producer) cancelSubFlow(kt *kit.Kit, flowID string) error {
+	input := &backend.ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "parent_flow_id", Op: filter.Equal.Factory(), Value: flowID},
+				&filter.AtomRule{Field: "state", Op: filter.In.Factory(), Value: []enumor.FlowState{
+					enumor.FlowPending, enumor.FlowScheduled, enumor.FlowRunning}},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	subFlows, err := p.backend.ListFlow(kt, input)
+	if err != nil {
+		logs.Errorf("list sub flow failed, err: %v, parent: %s, rid: %s", err, flowID, kt.Rid)
+		return err
+	}
+
+	for _, one := range subFlows {
+		if err = p.CancelFlow(kt, one.ID); err != nil {
+			// 子任务流可能刚好执行结束，这里不需要返回错误。
+			logs.Warnf("cancel sub flow failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+		}
+	}
+
+	return nil
+}
+
+// RetryFlow 重试失败的任务流。失败和被取消的任务会重置为 pending 重新执行，执行成功的任务保持不变。
+// 补偿失败的任务流，重试时重新进行补偿，已经补偿过的任务不会重复补偿。
+func (p *producer) RetryFlow(kt *kit.Kit, flowID string) error {
+	flow, err := getFlow(kt, p.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	if flow.State == enumor.FlowCompensateFailed {
+		info := backend.UpdateFlowInfo{
+			ID:     flowID,
+			Source: enumor.FlowCompensateFailed,
+			Target: enumor.FlowCompensating,
+		}
+		if err = p.backend.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{info}); err != nil {
+			logs.Errorf("update flow state to compensating failed, err: %v, id: %s, rid: %s", err, flowID, kt.Rid)
+			return err
+		}
+
+		return p.resetCallbackPending(kt, flow)
+	}
+
+	if flow.State != enumor.FlowFailed {
+		return errf.Newf(errf.InvalidParameter, "flow: %s state is %s, only failed flow can retry", flowID,
+			flow.State)
+	}
+
+	targets := map[enumor.TaskState]enumor.TaskState{
+		enumor.TaskFailed: enumor.TaskPending,
+		enumor.TaskCancel: enumor.TaskPending,
+	}
+	return p.restartFlow(kt, flow, targets)
+}
+
+// ResumeFlow 人工修复后恢复任务流。失败的任务视为已经被人工处理，状态更新为 success，被取消的任务重置为 pending，
+// 之后任务流从中断的地方继续执行。
+func (p *producer) ResumeFlow(kt *kit.Kit, flowID string) error {
+	flow, err := getFlow(kt, p.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	switch flow.State {
+	case enumor.FlowFailed, enumor.FlowCancel:
+	default:
+		return errf.Newf(errf.InvalidParameter, "flow: %s state is %s, only failed or cancel flow can resume",
+			flowID, flow.State)
+	}
+
+	targets := map[enumor.TaskState]enumor.TaskState{
+		enumor.TaskFailed: enumor.TaskSuccess,
+		enumor.TaskCancel: enumor.TaskPending,
+	}
+	return p.restartFlow(kt, flow, targets)
+}
+
+// restartFlow 按照 targets 的映射关系通过CAS更新任务状态，全部成功后再将任务流更新为 pending，等待重新派发。
+// 先更新任务再更新任务流，避免任务流被派发后，调度器读取到尚未重置的任务。
+func (p *producer) restartFlow(kt *kit.Kit, flow *model.Flow, targets map[enumor.TaskState]enumor.TaskState) error {
+	tasks, err := listTaskByFlowID(kt, p.backend, flow.ID)
+	if err != nil {
+		return err
+	}
+
+	reverts := make([]backend.UpdateTaskInfo, 0)
+	for _, task := range tasks {
+		target, exist := targets[task.State]
+		if !exist {
+			continue
+		}
+
+		info := backend.UpdateTaskInfo{
+			ID:     task.ID,
+			Source: task.State,
+			Target: target,
+		}
+		// 重新执行的任务，需要清理上一次执行失败的原因和重试状态
+		if target == enumor.TaskPending {
+			info.Reason = new(tableasync.Reason)
+			info.RetryState = new(tableasync.RetryState)
+		}
+
+		if err = p.backend.UpdateTaskStateByCAS(kt, &info); err != nil {
+			logs.Errorf("update task state by cas failed, err: %v, info: %+v, rid: %s", err, info, kt.Rid)
+			p.revertTaskState(kt, reverts)
+			return err
+		}
+
+		reverts = append(reverts, buildRevertTaskInfo(task, info))
+	}
+
+	info := backend.UpdateFlowInfo{
+		ID:     flow.ID,
+		Source: flow.State,
+		Target: enumor.FlowPending,
+		Reason: new(tableasync.Reason),
+	}
+	if err = p.backend.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{info}); err != nil {
+		logs.Errorf("update flow state to pending failed, err: %v, id: %s, rid: %s", err, flow.ID, kt.Rid)
+		p.revertTaskState(kt, reverts)
+		return err
+	}
+
+	return p.resetCallbackPending(kt, flow)
+}
+
+// resetCallbackPending 订阅了回调的任务流重新执行后，再次结束时需要重新生成回调投递记录。
+// 需要在任务流状态更新后再重置，避免主节点按照重新执行前的状态重复发送回调。
+func (p *producer) resetCallbackPending(kt *kit.Kit, flow *model.Flow) error {
+	if len(flow.Callbacks) == 0 {
+		return nil
+	}
+
+	md := model.Flow{ID: flow.ID, CallbackPending: converter.ValToPtr(true)}
+	if err := p.backend.BatchUpdateFlow(kt, []model.Flow{md}); err != nil {
+		logs.Errorf("reset flow callback pending failed, err: %v, id: %s, rid: %s", err, flow.ID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// buildRevertTaskInfo 构造任务流重启失败后还原任务的更新信息，除任务状态外，还需要还原重启时清理的失败原因和重试状态。
+func buildRevertTaskInfo(task model.Task, updated backend.UpdateTaskInfo) backend.UpdateTaskInfo {
+	info := backend.UpdateTaskInfo{
+		ID:     task.ID,
+		Source: updated.Target,
+		Target: updated.Source,
+	}
+
+	if updated.Reason != nil {
+		info.Reason = task.Reason
+		if info.Reason == nil {
+			info.Reason = new(tableasync.Reason)
+		}
+	}
+
+	if updated.RetryState != nil {
+		info.RetryState = task.RetryState
+		if info.RetryState == nil {
+			info.RetryState = new(tableasync.RetryState)
+		}
+	}
+
+	return info
+}
+
+// revertTaskState 任务流重启失败后，将已经更新的任务还原。
+func (p *producer) revertTaskState(kt *kit.Kit, infos []backend.UpdateTaskInfo) {
+	for index := range infos {
+		if err := p.backend.UpdateTaskStateByCAS(kt, &infos[index]); err != nil {
+			logs.Errorf("revert task state failed, err: %v, info: %+v, rid: %s", err, infos[index], kt.Rid)
+		}
+	}
+}
+
+func getFlow(kt *kit.Kit, bd backend.Backend, flowID string) (*model.Flow, error) {
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	flows, err := bd.ListFlow(kt, input)
+	if err != nil {
+		logs.Errorf("list flow failed, err: %v, id: %s, rid: %s", err, flowID, kt.Rid)
+		return nil, err
+	}
+
+	if len(flows) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "flow: %s not found", flowID)
+	}
+
+	return &flows[0], nil
+}
+
+func listTaskByFlowID(kt *kit.Kit, bd backend.Backend, flowID string) ([]model.Task, error) {
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("flow_id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	tasks := make([]model.Task, 0)
+	for {
+		result, err := bd.ListTask(kt, input)
+		if err != nil {
+			logs.Errorf("list task failed, err: %v, flow: %s, rid: %s", err, flowID, kt.Rid)
+			return nil, err
+		}
+
+		tasks = append(tasks, result...)
+
+		if len(result) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		input.Page.Start += uint32(input.Page.Limit)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,194 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"testing"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func prepareFailedFlow(t *testing.T) (*producer, *kit.Kit, string) {
+	kt := kit.New()
+	kt.User = "test"
+
+	bd := backend.NewMemory()
+	pdr, err := NewProducer(bd, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("new producer failed, err: %v", err)
+	}
+
+	flow := &model.Flow{
+		Name:      "test_flow",
+		ShareData: tableasync.NewShareData(),
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: "test_action"},
+			{FlowName: "test_flow", ActionID: "2", ActionName: "test_action", DependOn: []action.ActIDType{"1"}},
+		},
+	}
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	// 第一个任务执行成功，第二个任务执行失败，任务流失败。
+	states := map[action.ActIDType]enumor.TaskState{"1": enumor.TaskSuccess, "2": enumor.TaskFailed}
+	for _, one := range tasks {
+		if err = bd.UpdateTask(kt, &model.Task{ID: one.ID, State: states[one.ActionID]}); err != nil {
+			t.Fatalf("update task failed, err: %v", err)
+		}
+	}
+	if err = bd.BatchUpdateFlow(kt, []model.Flow{{ID: flowID, State: enumor.FlowFailed}}); err != nil {
+		t.Fatalf("update flow failed, err: %v", err)
+	}
+
+	return pdr.(*producer), kt, flowID
+}
+
+func TestRetryFlow(t *testing.T) {
+	pdr, kt, flowID := prepareFailedFlow(t)
+
+	if err := pdr.CancelFlow(kt, flowID); err == nil {
+		t.Fatalf("failed flow should not be canceled")
+	}
+
+	if err := pdr.RetryFlow(kt, flowID); err != nil {
+		t.Fatalf("retry flow failed, err: %v", err)
+	}
+
+	flow, err := getFlow(kt, pdr.backend, flowID)
+	if err != nil {
+		t.Fatalf("get flow failed, err: %v", err)
+	}
+	if flow.State != enumor.FlowPending {
+		t.Fatalf("flow state should be pending after retry, but got %s", flow.State)
+	}
+
+	tasks, err := listTaskByFlowID(kt, pdr.backend, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	expects := map[action.ActIDType]enumor.TaskState{"1": enumor.TaskSuccess, "2": enumor.TaskPending}
+	for _, one := range tasks {
+		if one.State != expects[one.ActionID] {
+			t.Fatalf("task %s state should be %s, but got %s", one.ActionID, expects[one.ActionID], one.State)
+		}
+	}
+
+	// 任务流已经不是失败状态，CAS 校验不通过，不允许再次重试。
+	if err = pdr.RetryFlow(kt, flowID); err == nil {
+		t.Fatalf("pending flow should not be retried")
+	}
+}
+
+func TestCancelAndResumeFlow(t *testing.T) {
+	pdr, kt, flowID := prepareFailedFlow(t)
+
+	if err := pdr.ResumeFlow(kt, flowID); err != nil {
+		t.Fatalf("resume flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, pdr.backend, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	for _, one := range tasks {
+		if one.State != enumor.TaskSuccess {
+			t.Fatalf("task %s state should be success after resume, but got %s", one.ActionID, one.State)
+		}
+	}
+
+	if err = pdr.CancelFlow(kt, flowID); err != nil {
+		t.Fatalf("cancel flow failed, err: %v", err)
+	}
+
+	flow, err := getFlow(kt, pdr.backend, flowID)
+	if err != nil {
+		t.Fatalf("get flow failed, err: %v", err)
+	}
+	if flow.State != enumor.FlowCancel {
+		t.Fatalf("flow state should be cancel, but got %s", flow.State)
+	}
+}
+
+func TestRestartFlowRevertTask(t *testing.T) {
+	pdr, kt, flowID := prepareFailedFlow(t)
+
+	tasks, err := listTaskByFlowID(kt, pdr.backend, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	reason := &tableasync.Reason{Message: "execute failed"}
+	retryState := &tableasync.RetryState{Attempts: 3, FirstFailedAt: "2023-12-13T10:00:00Z"}
+	for _, one := range tasks {
+		if one.ActionID != "2" {
+			continue
+		}
+		if err = pdr.backend.UpdateTask(kt, &model.Task{ID: one.ID, Reason: reason, RetryState: retryState}); err != nil {
+			t.Fatalf("update task failed, err: %v", err)
+		}
+	}
+
+	flow, err := getFlow(kt, pdr.backend, flowID)
+	if err != nil {
+		t.Fatalf("get flow failed, err: %v", err)
+	}
+
+	// 任务流状态已经被其他请求修改，任务流CAS更新失败，已经重置的任务需要还原状态、失败原因和重试状态
+	flow.State = enumor.FlowRunning
+	targets := map[enumor.TaskState]enumor.TaskState{enumor.TaskFailed: enumor.TaskPending}
+	if err = pdr.restartFlow(kt, flow, targets); err == nil {
+		t.Fatalf("restart flow with stale state should fail")
+	}
+
+	tasks, err = listTaskByFlowID(kt, pdr.backend, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	for _, one := range tasks {
+		if one.ActionID != "2" {
+			continue
+		}
+
+		if one.State != enumor.TaskFailed {
+			t.Errorf("task state should be reverted to failed, but got %s", one.State)
+		}
+		if one.Reason == nil || *one.Reason != *reason {
+			t.Errorf("task reason should be reverted to %+v, but got %+v", reason, one.Reason)
+		}
+		if one.RetryState == nil || *one.RetryState != *retryState {
+			t.Errorf("task retry state should be reverted to %+v, but got %+v", retryState, one.RetryState)
+		}
+	}
+}
@@ -33,6 +33,13 @@ import (
 type Producer interface {
 	AddTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (id string, err error)
 	AddCustomFlow(kt *kit.Kit, opt *AddCustomFlowOption) (id string, err error)
+	CancelFlow(kt *kit.Kit, flowID string) error
+	RetryFlow(kt *kit.Kit, flowID string) error
+	ResumeFlow(kt *kit.Kit, flowID string) error
+	AddCronFlow(kt *kit.Kit, opt *AddCronFlowOption) (id string, err error)
+	DeleteCronFlow(kt *kit.Kit, id string) error
+	SignalTask(kt *kit.Kit, opt *SignalTaskOption) error
+	ReplayCallback(kt *kit.Kit, id string) error
 }
 
 var _ Producer = new(producer)
@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action/waitsignal"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// SignalTask 向等待信号的任务发送审批信号。信号记录在任务的执行结果中，由执行任务流的节点的调度器轮询到信号后，
+// 将任务更新为成功或失败状态，并继续调度任务流。
+func (p *producer) SignalTask(kt *kit.Kit, opt *SignalTaskOption) error {
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tasks, err := p.backend.ListTask(kt, &backend.ListInput{
+		Filter: tools.EqualExpression("id", opt.TaskID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		logs.Errorf("list task failed, err: %v, id: %s, rid: %s", err, opt.TaskID, kt.Rid)
+		return err
+	}
+
+	if len(tasks) == 0 {
+		return errf.Newf(errf.RecordNotFound, "task: %s not found", opt.TaskID)
+	}
+
+	task := tasks[0]
+	if task.ActionName != enumor.ActionWaitSignal {
+		return errf.Newf(errf.InvalidParameter, "task: %s action is %s, can not signal", task.ID, task.ActionName)
+	}
+
+	if task.State != enumor.TaskWaiting {
+		return errf.Newf(errf.InvalidParameter, "task: %s state is %s, can not signal", task.ID, task.State)
+	}
+
+	res, err := waitsignal.ParseResult(task.Result)
+	if err != nil {
+		return err
+	}
+
+	if res.Signal != nil {
+		return errf.Newf(errf.InvalidParameter, "task: %s already signaled by %s", task.ID, res.Signal.Operator)
+	}
+
+	res.Signal = &waitsignal.Signal{
+		Decision: opt.Decision,
+		Operator: kt.User,
+		Memo:     opt.Memo,
+		SignalAt: time.Now().Format(constant.TimeStdFormat),
+	}
+	result, err := types.NewJsonField(res)
+	if err != nil {
+		return err
+	}
+
+	// 仅当任务仍处于等待状态且执行结果未被修改（未收到其他信号）时写入信号，避免并发信号相互覆盖
+	info := &backend.UpdateTaskResultInfo{
+		ID:     task.ID,
+		State:  enumor.TaskWaiting,
+		Source: task.Result,
+		Target: result,
+	}
+	if err = p.backend.UpdateTaskResultByCAS(kt, info); err != nil {
+		if errf.Error(err).Code == errf.RecordNotUpdate {
+			return errf.Newf(errf.RecordConflict, "task: %s has been signaled or is not waiting", task.ID)
+		}
+
+		logs.Errorf("update task signal failed, err: %v, id: %s, rid: %s", err, task.ID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/waitsignal"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSignalTask(t *testing.T) {
+	kt := kit.New()
+	kt.User = "approver"
+
+	bd := backend.NewMemory()
+	pdr, err := NewProducer(bd, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("new producer failed, err: %v", err)
+	}
+
+	flow := &model.Flow{
+		Name:      "test_flow",
+		ShareData: tableasync.NewShareData(),
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: enumor.ActionWaitSignal},
+		},
+	}
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("list task failed, tasks: %d, err: %v", len(tasks), err)
+	}
+	taskID := tasks[0].ID
+
+	opt := &SignalTaskOption{TaskID: taskID, Decision: waitsignal.Approve, Memo: "ok"}
+	if err = pdr.SignalTask(kt, opt); err == nil {
+		t.Errorf("pending task should not be signaled")
+	}
+
+	act := waitsignal.WaitSignalAction{}
+	result, err := act.Run(nil, &waitsignal.Params{TimeoutSec: 60})
+	if err != nil {
+		t.Fatalf("run wait signal action failed, err: %v", err)
+	}
+	field, err := types.NewJsonField(result.(*action.Waiting).Result)
+	if err != nil {
+		t.Fatalf("marshal waiting result failed, err: %v", err)
+	}
+	if err = bd.UpdateTask(kt, &model.Task{ID: taskID, State: enumor.TaskWaiting, Result: field}); err != nil {
+		t.Fatalf("update task failed, err: %v", err)
+	}
+
+	if done, _, err := act.Poll(nil, nil, field); done || err != nil {
+		t.Fatalf("task without signal should keep waiting, done: %v, err: %v", done, err)
+	}
+
+	if err = pdr.SignalTask(kt, opt); err != nil {
+		t.Fatalf("signal task failed, err: %v", err)
+	}
+
+	if err = pdr.SignalTask(kt, &SignalTaskOption{TaskID: taskID, Decision: waitsignal.Reject}); err == nil {
+		t.Errorf("signaled task should not be signaled again")
+	}
+
+	tasks, err = listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+
+	done, _, err := act.Poll(nil, nil, tasks[0].Result)
+	if !done || err != nil {
+		t.Errorf("approved task should be done without error, done: %v, err: %v", done, err)
+	}
+}
+
+func TestWaitSignalTimeout(t *testing.T) {
+	act := waitsignal.WaitSignalAction{}
+	res := &waitsignal.Result{Deadline: time.Now().Add(-time.Second).Format(constant.TimeStdFormat)}
+	field, err := types.NewJsonField(res)
+	if err != nil {
+		t.Fatalf("marshal result failed, err: %v", err)
+	}
+
+	if done, _, err := act.Poll(nil, nil, field); !done || err == nil {
+		t.Errorf("expired task should be done with error, done: %v, err: %v", done, err)
+	}
+
+	res.Signal = &waitsignal.Signal{Decision: waitsignal.Reject, Operator: "approver"}
+	if field, err = types.NewJsonField(res); err != nil {
+		t.Fatalf("marshal result failed, err: %v", err)
+	}
+
+	if done, _, err := act.Poll(nil, nil, field); !done || err == nil {
+		t.Errorf("rejected task should be done with error, done: %v, err: %v", done, err)
+	}
+}
+
+func TestSignalTaskConcurrent(t *testing.T) {
+	kt := kit.New()
+	bd := backend.NewMemory()
+	pdr, err := NewProducer(bd, prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("new producer failed, err: %v", err)
+	}
+
+	flow := &model.Flow{
+		Name:      "test_flow",
+		ShareData: tableasync.NewShareData(),
+		Tasks: []model.Task{
+			{FlowName: "test_flow", ActionID: "1", ActionName: enumor.ActionWaitSignal},
+		},
+	}
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	tasks, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("list task failed, tasks: %d, err: %v", len(tasks), err)
+	}
+	taskID := tasks[0].ID
+
+	field, err := types.NewJsonField(&waitsignal.Result{
+		Deadline: time.Now().Add(time.Minute).Format(constant.TimeStdFormat)})
+	if err != nil {
+		t.Fatalf("marshal result failed, err: %v", err)
+	}
+	if err = bd.UpdateTask(kt, &model.Task{ID: taskID, State: enumor.TaskWaiting, Result: field}); err != nil {
+		t.Fatalf("update task failed, err: %v", err)
+	}
+
+	// 并发发送信号，只有一个信号能够写入成功
+	count := 10
+	var wg sync.WaitGroup
+	var success int32
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			opt := &SignalTaskOption{TaskID: taskID, Decision: waitsignal.Approve, Memo: strconv.Itoa(i)}
+			if err := pdr.SignalTask(kit.New(), opt); err == nil {
+				atomic.AddInt32(&success, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if success != 1 {
+		t.Errorf("only one signal should succeed, but got %d", success)
+	}
+
+	// 任务离开等待状态后不能再写入信号
+	if err = bd.UpdateTask(kt, &model.Task{ID: taskID, State: enumor.TaskSuccess}); err != nil {
+		t.Fatalf("update task failed, err: %v", err)
+	}
+	info := &backend.UpdateTaskResultInfo{ID: taskID, State: enumor.TaskWaiting, Source: field, Target: field}
+	if err = bd.UpdateTaskResultByCAS(kt, info); err == nil {
+		t.Errorf("task not in waiting state should not be updated")
+	}
+}
@@ -21,12 +21,17 @@ package producer
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/waitsignal"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/dal/table/types"
+	"hcm/pkg/tools/cron"
 )
 
 // AddTemplateFlowOption define add flow option.
@@ -37,6 +42,22 @@ type AddTemplateFlowOption struct {
 	Memo string `json:"memo" validate:"omitempty"`
 	// Tasks 任务私有化参数设置
 	Tasks []TemplateFlowTask `json:"tasks" validate:"omitempty"`
+	// StartAt 任务流开始执行时间，标准格式：2006-01-02T15:04:05Z07:00，不设置则立即执行
+	StartAt string `json:"start_at" validate:"omitempty"`
+	// Priority 任务流优先级，值越大越优先派发和调度，默认为0
+	Priority int `json:"priority" validate:"omitempty"`
+	// AccountID 任务流操作的云账号，用于按账号限制同时执行的任务流数量
+	AccountID string `json:"account_id" validate:"omitempty,max=64"`
+	// Compensate 任务流执行失败后，是否按照任务依赖的逆序对执行成功的任务进行补偿，清理已经创建的资源
+	Compensate bool `json:"compensate" validate:"omitempty"`
+	// ParentFlowID 作为子任务流创建时，所属的父任务流ID
+	ParentFlowID string `json:"parent_flow_id" validate:"omitempty,max=64"`
+	// ParentTaskID 作为子任务流创建时，创建该子任务流的父任务流中的任务ID
+	ParentTaskID string `json:"parent_task_id" validate:"omitempty,max=64"`
+	// Callbacks 任务流执行结束后的回调订阅，任务流进入success、failed、cancel等最终状态后发送通知
+	Callbacks tableasync.Callbacks `json:"callbacks" validate:"omitempty"`
+	// IdempotencyKey 任务流提交的幂等键，相同幂等键且请求内容一致的重复提交返回已经创建的任务流ID，请求内容不一致时返回冲突错误
+	IdempotencyKey string `json:"idempotency_key" validate:"omitempty,max=64"`
 }
 
 // Validate AddTemplateFlowOption
@@ -46,7 +67,15 @@ func (opt *AddTemplateFlowOption) Validate() error {
 		return err
 	}
 
-	if err := opt.Name.Validate(); err != nil {
+	if err := action.ValidateTplName(opt.Name); err != nil {
+		return err
+	}
+
+	if err := validateStartAt(opt.StartAt); err != nil {
+		return err
+	}
+
+	if err := opt.Callbacks.Validate(); err != nil {
 		return err
 	}
 
@@ -65,6 +94,8 @@ type TemplateFlowTask struct {
 	ActionID action.ActIDType `json:"action_id" validate:"required"`
 	// Params 任务执行请求参数
 	Params types.JsonField `json:"params" validate:"required"`
+	// TimeoutSec 任务执行超时时间，单位：秒，不设置则使用Action定义的默认超时时间或全局配置的超时时间
+	TimeoutSec uint `json:"timeout_sec" validate:"omitempty"`
 }
 
 // Validate TemplateFlowTask
@@ -82,6 +113,18 @@ type AddCustomFlowOption struct {
 	ShareData *tableasync.ShareData `json:"share_data" validate:"omitempty"`
 	// Tasks 任务私有化参数设置
 	Tasks []CustomFlowTask `json:"tasks" validate:"required"`
+	// StartAt 任务流开始执行时间，标准格式：2006-01-02T15:04:05Z07:00，不设置则立即执行
+	StartAt string `json:"start_at" validate:"omitempty"`
+	// Priority 任务流优先级，值越大越优先派发和调度，默认为0
+	Priority int `json:"priority" validate:"omitempty"`
+	// AccountID 任务流操作的云账号，用于按账号限制同时执行的任务流数量
+	AccountID string `json:"account_id" validate:"omitempty,max=64"`
+	// Compensate 任务流执行失败后，是否按照任务依赖的逆序对执行成功的任务进行补偿，清理已经创建的资源
+	Compensate bool `json:"compensate" validate:"omitempty"`
+	// Callbacks 任务流执行结束后的回调订阅，任务流进入success、failed、cancel等最终状态后发送通知
+	Callbacks tableasync.Callbacks `json:"callbacks" validate:"omitempty"`
+	// IdempotencyKey 任务流提交的幂等键，相同幂等键且请求内容一致的重复提交返回已经创建的任务流ID，请求内容不一致时返回冲突错误
+	IdempotencyKey string `json:"idempotency_key" validate:"omitempty,max=64"`
 }
 
 // Validate AddCustomFlowOption
@@ -95,6 +138,14 @@ func (opt *AddCustomFlowOption) Validate() error {
 		return errors.New("tasks is required")
 	}
 
+	if err := validateStartAt(opt.StartAt); err != nil {
+		return err
+	}
+
+	if err := opt.Callbacks.Validate(); err != nil {
+		return err
+	}
+
 	for _, task := range opt.Tasks {
 		if err := task.Validate(); err != nil {
 			return err
@@ -116,6 +167,14 @@ type CustomFlowTask struct {
 	Params types.JsonField `json:"params" validate:"omitempty"`
 	// Retry 任务运行重试相关配置参数，如果不设置，默认不允许进行重试。
 	Retry *tableasync.Retry `json:"retry" validate:"omitempty"`
+	// TimeoutSec 任务执行超时时间，单位：秒，不设置则使用Action定义的默认超时时间或全局配置的超时时间
+	TimeoutSec uint `json:"timeout_sec" validate:"omitempty"`
+	// AllowFailure 是否允许任务执行失败，允许失败的任务执行失败后，不影响后续任务执行和任务流状态
+	AllowFailure bool `json:"allow_failure" validate:"omitempty"`
+	// TriggerRule 任务触发规则（all_success、on_failure、always），不设置默认为all_success
+	TriggerRule enumor.TaskTriggerRule `json:"trigger_rule" validate:"omitempty"`
+	// RunCondition 任务执行条件，根据任务流共享数据判断任务是否执行，不满足条件时任务会被跳过
+	RunCondition *tableasync.RunCondition `json:"run_condition" validate:"omitempty"`
 }
 
 // Validate CustomFlowTask
@@ -128,5 +187,102 @@ func (task *CustomFlowTask) Validate() error {
 		return err
 	}
 
+	if err := task.TriggerRule.Validate(); err != nil {
+		return err
+	}
+
+	if task.RunCondition != nil {
+		if err := task.RunCondition.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateStartAt 校验任务流开始执行时间格式
+func validateStartAt(startAt string) error {
+	if len(startAt) == 0 {
+		return nil
+	}
+
+	if _, err := time.Parse(constant.TimeStdFormat, startAt); err != nil {
+		return fmt.Errorf("start_at: %s is invalid, should be %s format", startAt, constant.TimeStdFormat)
+	}
+
 	return nil
 }
+
+// AddCronFlowOption define add cron flow option. Template 和 Custom 有且只能设置一个。
+type AddCronFlowOption struct {
+	// Spec 五段式cron表达式（分 时 日 月 星期），如 "0 2 * * *" 表示每天凌晨2点
+	Spec string `json:"spec" validate:"required"`
+	// Template 按照任务流模版生成任务流
+	Template *AddTemplateFlowOption `json:"template" validate:"omitempty"`
+	// Custom 按照自定义任务流生成任务流
+	Custom *AddCustomFlowOption `json:"custom" validate:"omitempty"`
+}
+
+// Validate AddCronFlowOption
+func (opt *AddCronFlowOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if _, err := cron.Parse(opt.Spec); err != nil {
+		return err
+	}
+
+	if (opt.Template == nil) == (opt.Custom == nil) {
+		return errors.New("one of template and custom must be set")
+	}
+
+	if opt.Template != nil {
+		if len(opt.Template.StartAt) != 0 {
+			return errors.New("cron flow not support start_at")
+		}
+
+		if len(opt.Template.Callbacks) != 0 {
+			return errors.New("cron flow not support callbacks")
+		}
+
+		if len(opt.Template.IdempotencyKey) != 0 {
+			return errors.New("cron flow not support idempotency_key")
+		}
+
+		return opt.Template.Validate()
+	}
+
+	if len(opt.Custom.StartAt) != 0 {
+		return errors.New("cron flow not support start_at")
+	}
+
+	if len(opt.Custom.Callbacks) != 0 {
+		return errors.New("cron flow not support callbacks")
+	}
+
+	if len(opt.Custom.IdempotencyKey) != 0 {
+		return errors.New("cron flow not support idempotency_key")
+	}
+
+	return opt.Custom.Validate()
+}
+
+// SignalTaskOption define signal waiting task option.
+type SignalTaskOption struct {
+	// TaskID 等待信号的任务ID
+	TaskID string `json:"task_id" validate:"required"`
+	// Decision 审批结果（approve、reject）
+	Decision waitsignal.Decision `json:"decision" validate:"required"`
+	// Memo 审批意见
+	Memo string `json:"memo" validate:"omitempty"`
+}
+
+// Validate SignalTaskOption
+func (opt *SignalTaskOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	return opt.Decision.Validate()
+}
@@ -120,6 +120,7 @@ type CloudServerSetting struct {
 	Recycle       Recycle       `yaml:"recycle"`
 	BillConfig    BillConfig    `yaml:"billConfig"`
 	Itsm          ApiGateway    `yaml:"itsm"`
+	DiskSnapshot  DiskSnapshot  `yaml:"diskSnapshot"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -170,6 +171,10 @@ func (s CloudServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.DiskSnapshot.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -225,9 +230,10 @@ func (s DataServiceSetting) Validate() error {
 
 // HCServiceSetting defines hc service used setting options.
 type HCServiceSetting struct {
-	Network Network   `yaml:"network"`
-	Service Service   `yaml:"service"`
-	Log     LogOption `yaml:"log"`
+	Network   Network   `yaml:"network"`
+	Service   Service   `yaml:"service"`
+	Log       LogOption `yaml:"log"`
+	FakeCloud FakeCloud `yaml:"fakeCloud"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -255,6 +261,10 @@ func (s HCServiceSetting) Validate() error {
 		return err
 	}
 
+	if err := s.FakeCloud.validate(); err != nil {
+		return fmt.Errorf("fakeCloud %v", err)
+	}
+
 	return nil
 }
 
@@ -373,6 +383,7 @@ func (s *TaskServerSetting) trySetDefault() {
 	s.Service.trySetDefault()
 	s.Database.trySetDefault()
 	s.Log.trySetDefault()
+	s.Async.trySetDefault()
 
 	return
 }
@@ -37,6 +37,8 @@ import (
 // Service defines Setting related runtime.
 type Service struct {
 	Etcd Etcd `yaml:"etcd"`
+	// Labels 当前节点注册到服务发现中的标签，如网络区域等，异步任务会根据任务流模版声明的节点标签选择执行节点
+	Labels map[string]string `yaml:"labels"`
 }
 
 // trySetDefault set the Setting default value if user not configured.
@@ -50,6 +52,12 @@ func (s Service) validate() error {
 		return err
 	}
 
+	for key := range s.Labels {
+		if len(key) == 0 {
+			return errors.New("service label key can not be empty")
+		}
+	}
+
 	return nil
 }
 
@@ -162,6 +170,18 @@ type Async struct {
 	Executor   Executor   `yaml:"executor"`
 	Dispatcher Dispatcher `yaml:"dispatcher"`
 	WatchDog   WatchDog   `yaml:"watchDog"`
+	Notifier   Notifier   `yaml:"notifier"`
+	Janitor    Janitor    `yaml:"janitor"`
+	Template   Template   `yaml:"template"`
+	RateLimit  RateLimit  `yaml:"rateLimit"`
+}
+
+// trySetDefault set the Async default value if user not configured.
+func (a *Async) trySetDefault() {
+	a.Notifier.trySetDefault()
+	a.Janitor.trySetDefault()
+	a.Template.trySetDefault()
+	a.RateLimit.trySetDefault()
 }
 
 // Validate Async
@@ -185,6 +205,10 @@ type Executor struct {
 // Dispatcher 主节点组件，负责派发任务
 type Dispatcher struct {
 	WatchIntervalSec uint `yaml:"watchIntervalSec"`
+	// FlowNameMaxRunning 每种任务流同时处于调度中和执行中的最大数量，未设置的任务流不限制
+	FlowNameMaxRunning map[string]uint `yaml:"flowNameMaxRunning"`
+	// AccountMaxRunning 每个云账号同时处于调度中和执行中的最大任务流数量，0表示不限制
+	AccountMaxRunning uint `yaml:"accountMaxRunning"`
 }
 
 // WatchDog 主节点组件，负责异常任务修正（超时任务，任务处理节点已经挂掉的任务等）
@@ -193,6 +217,107 @@ type WatchDog struct {
 	TaskTimeoutSec   uint `yaml:"taskTimeoutSec"`
 }
 
+// Notifier 主节点组件，负责发送任务流结束后的回调通知
+type Notifier struct {
+	WatchIntervalSec uint `yaml:"watchIntervalSec"`
+	// MaxAttempts 回调最大投递次数，超过后不再自动重试
+	MaxAttempts uint `yaml:"maxAttempts"`
+	// TimeoutSec 单次回调请求的超时时间
+	TimeoutSec uint `yaml:"timeoutSec"`
+	// Secret 回调请求的签名密钥
+	Secret string `yaml:"secret"`
+}
+
+// trySetDefault set the Notifier default value if user not configured.
+func (n *Notifier) trySetDefault() {
+	if n.WatchIntervalSec == 0 {
+		n.WatchIntervalSec = 5
+	}
+
+	if n.MaxAttempts == 0 {
+		n.MaxAttempts = 5
+	}
+
+	if n.TimeoutSec == 0 {
+		n.TimeoutSec = 10
+	}
+}
+
+// Janitor 主节点组件，负责归档或清理超过保留时间的已结束任务流
+type Janitor struct {
+	// RetentionDays 已结束任务流的保留天数，0表示不清理
+	RetentionDays uint `yaml:"retentionDays"`
+	// PurgeMode 清理方式，archive表示移动到归档表，delete表示直接删除
+	PurgeMode string `yaml:"purgeMode"`
+	// WatchIntervalSec 查看是否有待清理任务流的周期
+	WatchIntervalSec uint `yaml:"watchIntervalSec"`
+	// BatchSize 每批次清理的任务流数量
+	BatchSize uint `yaml:"batchSize"`
+}
+
+const (
+	// JanitorArchiveMode 归档已结束的任务流
+	JanitorArchiveMode = "archive"
+	// JanitorDeleteMode 直接删除已结束的任务流
+	JanitorDeleteMode = "delete"
+)
+
+// Template 公共组件，负责从目录加载声明式任务流模版（YAML/JSON），并周期性重新加载，不需要重新部署服务即可新增或修改模版
+type Template struct {
+	// Dir 声明式任务流模版所在目录，为空表示不加载声明式任务流模版
+	Dir string `yaml:"dir"`
+	// ReloadIntervalSec 重新加载声明式任务流模版的周期
+	ReloadIntervalSec uint `yaml:"reloadIntervalSec"`
+}
+
+// trySetDefault set the Template default value if user not configured.
+func (t *Template) trySetDefault() {
+	if t.ReloadIntervalSec == 0 {
+		t.ReloadIntervalSec = 30
+	}
+}
+
+// RateLimit 公共组件，负责按照账号、云厂商接口分组限制任务调用云厂商接口的速率，令牌桶由所有节点共享
+type RateLimit struct {
+	// Rules 限流规则，未设置的云厂商接口分组不限制
+	Rules []RateLimitRule `yaml:"rules"`
+}
+
+// RateLimitRule 限流规则，同一个账号下的每个云厂商接口分组使用一个令牌桶
+type RateLimitRule struct {
+	Vendor string `yaml:"vendor"`
+	// APIGroup 云厂商接口分组，如 cvm
+	APIGroup string `yaml:"apiGroup"`
+	// Rate 令牌桶每秒生成的令牌数
+	Rate float64 `yaml:"rate"`
+	// Burst 令牌桶容量，即允许的最大突发请求数
+	Burst uint `yaml:"burst"`
+}
+
+// trySetDefault set the RateLimit default value if user not configured.
+func (r *RateLimit) trySetDefault() {
+	for i := range r.Rules {
+		if r.Rules[i].Burst == 0 {
+			r.Rules[i].Burst = 1
+		}
+	}
+}
+
+// trySetDefault set the Janitor default value if user not configured.
+func (j *Janitor) trySetDefault() {
+	if len(j.PurgeMode) == 0 {
+		j.PurgeMode = JanitorArchiveMode
+	}
+
+	if j.WatchIntervalSec == 0 {
+		j.WatchIntervalSec = 600
+	}
+
+	if j.BatchSize == 0 {
+		j.BatchSize = 100
+	}
+}
+
 // DataBase defines database related runtime
 type DataBase struct {
 	Resource ResourceDB `yaml:"resource"`
@@ -655,6 +780,22 @@ func (c BillConfig) validate() error {
 	return nil
 }
 
+// DiskSnapshot 云盘快照配置
+type DiskSnapshot struct {
+	// UnitPrice 各云厂商快照每GB的单价，key为云厂商，未配置的云厂商快照费用按0计算
+	UnitPrice map[string]float64 `yaml:"unitPrice"`
+}
+
+func (d DiskSnapshot) validate() error {
+	for vendor, price := range d.UnitPrice {
+		if price < 0 {
+			return fmt.Errorf("diskSnapshot.unitPrice of %s must >= 0", vendor)
+		}
+	}
+
+	return nil
+}
+
 // ApiGateway defines the api gateway config.
 type ApiGateway struct {
 	// Endpoints is a seed list of host:port addresses of api gateway.
@@ -709,3 +850,64 @@ func (gt ApiGateway) GetAuthValue() string {
 
 	return fmt.Sprintf("{\"bk_app_code\": \"%s\", \"bk_app_secret\": \"%s\"}", gt.AppCode, gt.AppSecret)
 }
+
+// FakeCloud 内存模拟云配置，开启后hc-service不再请求真实的云API，仅用于本地开发和端到端测试
+type FakeCloud struct {
+	TCloud FakeTCloud `yaml:"tcloud"`
+}
+
+func (f FakeCloud) validate() error {
+	if err := f.TCloud.validate(); err != nil {
+		return fmt.Errorf("tcloud %v", err)
+	}
+
+	return nil
+}
+
+// FakeTCloud 内存模拟腾讯云配置
+type FakeTCloud struct {
+	Enable bool `yaml:"enable"`
+	// Regions 模拟云支持的地域，为空时默认为ap-guangzhou
+	Regions []string `yaml:"regions"`
+	// ZoneCount 每个地域的可用区数量，为0时默认为3
+	ZoneCount     int    `yaml:"zoneCount"`
+	MainAccountID string `yaml:"mainAccountID"`
+	SubAccountID  string `yaml:"subAccountID"`
+	// Quota 各类资源的配额，为0表示不限制
+	Quota FakeCloudQuota `yaml:"quota"`
+	// ThrottleRate 接口调用返回限频错误的概率，取值范围[0, 1]
+	ThrottleRate float64 `yaml:"throttleRate"`
+	// AsyncFailRate 异步创建的资源创建失败的概率，取值范围[0, 1]
+	AsyncFailRate float64 `yaml:"asyncFailRate"`
+}
+
+func (f FakeTCloud) validate() error {
+	if !f.Enable {
+		return nil
+	}
+
+	if f.ZoneCount < 0 {
+		return errors.New("zoneCount must >= 0")
+	}
+
+	if f.ThrottleRate < 0 || f.ThrottleRate > 1 {
+		return errors.New("throttleRate must be in [0, 1]")
+	}
+
+	if f.AsyncFailRate < 0 || f.AsyncFailRate > 1 {
+		return errors.New("asyncFailRate must be in [0, 1]")
+	}
+
+	return nil
+}
+
+// FakeCloudQuota 内存模拟云资源配额
+type FakeCloudQuota struct {
+	Vpc           uint `yaml:"vpc"`
+	Subnet        uint `yaml:"subnet"`
+	SecurityGroup uint `yaml:"securityGroup"`
+	Cvm           uint `yaml:"cvm"`
+	Disk          uint `yaml:"disk"`
+	Eip           uint `yaml:"eip"`
+	LoadBalancer  uint `yaml:"loadBalancer"`
+}
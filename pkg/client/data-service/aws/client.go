@@ -36,6 +36,8 @@ type Client struct {
 	RouteTable    *RouteTableClient
 	Bill          *BillClient
 	SubAccount    *SubAccountClient
+	LoadBalancer  *LoadBalancerClient
+	DiskSnapshot  *DiskSnapshotClient
 }
 
 type restClient struct {
@@ -56,5 +58,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		RouteTable:    NewRouteTableClient(client),
 		Bill:          NewBillClient(client),
 		SubAccount:    NewSubAccountClient(client),
+		LoadBalancer:  NewLoadBalancerClient(client),
+		DiskSnapshot:  NewDiskSnapshotClient(client),
 	}
 }
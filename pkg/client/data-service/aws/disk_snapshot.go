@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/disk-snapshot"
+	dssnapshot "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewDiskSnapshotClient create a new disk snapshot api client.
+func NewDiskSnapshotClient(client rest.ClientInterface) *DiskSnapshotClient {
+	return &DiskSnapshotClient{
+		client: client,
+	}
+}
+
+// DiskSnapshotClient is data service disk snapshot api client.
+type DiskSnapshotClient struct {
+	client rest.ClientInterface
+}
+
+// Get disk snapshot.
+func (cli *DiskSnapshotClient) Get(kt *kit.Kit, id string) (
+	*coresnapshot.DiskSnapshot[coresnapshot.AwsExtension], error) {
+
+	return common.Request[common.Empty, coresnapshot.DiskSnapshot[coresnapshot.AwsExtension]](cli.client, rest.GET,
+		kt, common.NoData, "/disk_snapshots/%s", id)
+}
+
+// ListExt list disk snapshot with extension.
+func (cli *DiskSnapshotClient) ListExt(kt *kit.Kit, req *core.ListReq) (
+	*dssnapshot.DiskSnapshotListExtResult[coresnapshot.AwsExtension], error) {
+
+	return common.Request[core.ListReq, dssnapshot.DiskSnapshotListExtResult[coresnapshot.AwsExtension]](cli.client,
+		rest.POST, kt, req, "/disk_snapshots/list")
+}
@@ -36,6 +36,7 @@ type Client struct {
 	RouteTable       *RouteTableClient
 	NetworkInterface *NetworkInterfaceClient
 	SubAccount       *SubAccountClient
+	DiskSnapshot     *DiskSnapshotClient
 }
 
 type restClient struct {
@@ -56,5 +57,6 @@ func NewClient(client rest.ClientInterface) *Client {
 		RouteTable:       NewRouteTableClient(client),
 		NetworkInterface: NewNetworkInterfaceClient(client),
 		SubAccount:       NewSubAccountClient(client),
+		DiskSnapshot:     NewDiskSnapshotClient(client),
 	}
 }
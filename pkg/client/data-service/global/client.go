@@ -41,6 +41,8 @@ type Client struct {
 	NetworkInterfaceCvmRel *NetworkInterfaceCvmRelClient
 	SubAccount             *SubAccountClient
 	AccountSyncDetail      *AccountSyncDetailClient
+	LoadBalancer           *LoadBalancerClient
+	DiskSnapshot           *DiskSnapshotClient
 
 	Auth          *AuthClient
 	Account       *AccountClient
@@ -76,6 +78,8 @@ func NewClient(client rest.ClientInterface) *Client {
 		NetworkInterfaceCvmRel: NewNetworkInterfaceCvmRelClient(client),
 		SubAccount:             NewSubAccountClient(client),
 		AccountSyncDetail:      NewAccountSyncDetailClient(client),
+		LoadBalancer:           NewLoadBalancerClient(client),
+		DiskSnapshot:           NewDiskSnapshotClient(client),
 
 		Auth:          NewAuthClient(client),
 		Account:       NewAccountClient(client),
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dssnapshot "hcm/pkg/api/data-service/cloud/disk-snapshot"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// DiskSnapshotClient is data service disk snapshot api client.
+type DiskSnapshotClient struct {
+	client rest.ClientInterface
+}
+
+// NewDiskSnapshotClient create a new disk snapshot api client.
+func NewDiskSnapshotClient(client rest.ClientInterface) *DiskSnapshotClient {
+	return &DiskSnapshotClient{
+		client: client,
+	}
+}
+
+// BatchCreate batch create disk snapshot.
+func (cli *DiskSnapshotClient) BatchCreate(kt *kit.Kit, req *dssnapshot.DiskSnapshotBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dssnapshot.DiskSnapshotBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt,
+		req, "/disk_snapshots/batch/create")
+}
+
+// BatchUpdate batch update disk snapshot.
+func (cli *DiskSnapshotClient) BatchUpdate(kt *kit.Kit, req *dssnapshot.DiskSnapshotBatchUpdateReq) error {
+	return common.RequestNoResp[dssnapshot.DiskSnapshotBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/disk_snapshots/batch/update")
+}
+
+// List disk snapshot.
+func (cli *DiskSnapshotClient) List(kt *kit.Kit, req *core.ListReq) (*dssnapshot.DiskSnapshotListResult, error) {
+	return common.Request[core.ListReq, dssnapshot.DiskSnapshotListResult](cli.client, rest.POST, kt, req,
+		"/disk_snapshots/list")
+}
+
+// BatchDelete batch delete disk snapshot.
+func (cli *DiskSnapshotClient) BatchDelete(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/disk_snapshots/batch")
+}
@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// LoadBalancerClient is data service load balancer api client.
+type LoadBalancerClient struct {
+	client rest.ClientInterface
+}
+
+// NewLoadBalancerClient create a new load balancer api client.
+func NewLoadBalancerClient(client rest.ClientInterface) *LoadBalancerClient {
+	return &LoadBalancerClient{
+		client: client,
+	}
+}
+
+// BatchCreate batch create load balancer.
+func (cli *LoadBalancerClient) BatchCreate(kt *kit.Kit, req *dslb.LoadBalancerBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dslb.LoadBalancerBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/batch/create")
+}
+
+// BatchUpdate batch update load balancer.
+func (cli *LoadBalancerClient) BatchUpdate(kt *kit.Kit, req *dslb.LoadBalancerBatchUpdateReq) error {
+	return common.RequestNoResp[dslb.LoadBalancerBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/load_balancers/batch/update")
+}
+
+// List load balancer.
+func (cli *LoadBalancerClient) List(kt *kit.Kit, req *core.ListReq) (*dslb.LoadBalancerListResult, error) {
+	return common.Request[core.ListReq, dslb.LoadBalancerListResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/list")
+}
+
+// BatchDelete batch delete load balancer, listeners, targets and cvm relations of the load balancer are
+// deleted together.
+func (cli *LoadBalancerClient) BatchDelete(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/load_balancers/batch")
+}
+
+// BatchCreateListener batch create load balancer listener.
+func (cli *LoadBalancerClient) BatchCreateListener(kt *kit.Kit, req *dslb.ListenerBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dslb.ListenerBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/listeners/batch/create")
+}
+
+// BatchUpdateListener batch update load balancer listener.
+func (cli *LoadBalancerClient) BatchUpdateListener(kt *kit.Kit, req *dslb.ListenerBatchUpdateReq) error {
+	return common.RequestNoResp[dslb.ListenerBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/load_balancers/listeners/batch/update")
+}
+
+// ListListener list load balancer listener.
+func (cli *LoadBalancerClient) ListListener(kt *kit.Kit, req *core.ListReq) (*dslb.ListenerListResult, error) {
+	return common.Request[core.ListReq, dslb.ListenerListResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/listeners/list")
+}
+
+// BatchDeleteListener batch delete load balancer listener.
+func (cli *LoadBalancerClient) BatchDeleteListener(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/load_balancers/listeners/batch")
+}
+
+// BatchCreateTargetGroup batch create load balancer target group.
+func (cli *LoadBalancerClient) BatchCreateTargetGroup(kt *kit.Kit, req *dslb.TargetGroupBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dslb.TargetGroupBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/target_groups/batch/create")
+}
+
+// BatchUpdateTargetGroup batch update load balancer target group.
+func (cli *LoadBalancerClient) BatchUpdateTargetGroup(kt *kit.Kit, req *dslb.TargetGroupBatchUpdateReq) error {
+	return common.RequestNoResp[dslb.TargetGroupBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/load_balancers/target_groups/batch/update")
+}
+
+// ListTargetGroup list load balancer target group.
+func (cli *LoadBalancerClient) ListTargetGroup(kt *kit.Kit, req *core.ListReq) (*dslb.TargetGroupListResult,
+	error) {
+
+	return common.Request[core.ListReq, dslb.TargetGroupListResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/target_groups/list")
+}
+
+// BatchDeleteTargetGroup batch delete load balancer target group.
+func (cli *LoadBalancerClient) BatchDeleteTargetGroup(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/load_balancers/target_groups/batch")
+}
+
+// BatchCreateTarget batch create load balancer target.
+func (cli *LoadBalancerClient) BatchCreateTarget(kt *kit.Kit, req *dslb.TargetBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dslb.TargetBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/targets/batch/create")
+}
+
+// BatchUpdateTarget batch update load balancer target.
+func (cli *LoadBalancerClient) BatchUpdateTarget(kt *kit.Kit, req *dslb.TargetBatchUpdateReq) error {
+	return common.RequestNoResp[dslb.TargetBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/load_balancers/targets/batch/update")
+}
+
+// ListTarget list load balancer target.
+func (cli *LoadBalancerClient) ListTarget(kt *kit.Kit, req *core.ListReq) (*dslb.TargetListResult, error) {
+	return common.Request[core.ListReq, dslb.TargetListResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/targets/list")
+}
+
+// BatchDeleteTarget batch delete load balancer target.
+func (cli *LoadBalancerClient) BatchDeleteTarget(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/load_balancers/targets/batch")
+}
+
+// BatchCreateCvmRel batch create load balancer and cvm relation.
+func (cli *LoadBalancerClient) BatchCreateCvmRel(kt *kit.Kit, req *dslb.CvmRelBatchCreateReq) error {
+	return common.RequestNoResp[dslb.CvmRelBatchCreateReq](cli.client, rest.POST, kt, req,
+		"/load_balancer_cvm_rels/batch/create")
+}
+
+// ListCvmRel list load balancer and cvm relation.
+func (cli *LoadBalancerClient) ListCvmRel(kt *kit.Kit, req *core.ListReq) (*dslb.CvmRelListResult, error) {
+	return common.Request[core.ListReq, dslb.CvmRelListResult](cli.client, rest.POST, kt, req,
+		"/load_balancer_cvm_rels/list")
+}
+
+// BatchDeleteCvmRel batch delete load balancer and cvm relation.
+func (cli *LoadBalancerClient) BatchDeleteCvmRel(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/load_balancer_cvm_rels/batch")
+}
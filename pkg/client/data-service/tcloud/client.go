@@ -35,6 +35,8 @@ type Client struct {
 	Cvm           *CvmClient
 	RouteTable    *RouteTableClient
 	SubAccount    *SubAccountClient
+	LoadBalancer  *LoadBalancerClient
+	DiskSnapshot  *DiskSnapshotClient
 }
 
 type restClient struct {
@@ -54,5 +56,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		Cvm:           NewCloudCvmClient(client),
 		RouteTable:    NewRouteTableClient(client),
 		SubAccount:    NewSubAccountClient(client),
+		LoadBalancer:  NewLoadBalancerClient(client),
+		DiskSnapshot:  NewDiskSnapshotClient(client),
 	}
 }
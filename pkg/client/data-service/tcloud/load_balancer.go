@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dslb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewLoadBalancerClient create a new load balancer api client.
+func NewLoadBalancerClient(client rest.ClientInterface) *LoadBalancerClient {
+	return &LoadBalancerClient{
+		client: client,
+	}
+}
+
+// LoadBalancerClient is data service load balancer api client.
+type LoadBalancerClient struct {
+	client rest.ClientInterface
+}
+
+// Get load balancer.
+func (cli *LoadBalancerClient) Get(kt *kit.Kit, id string) (*corelb.LoadBalancer[corelb.TCloudExtension], error) {
+	return common.Request[common.Empty, corelb.LoadBalancer[corelb.TCloudExtension]](cli.client, rest.GET, kt,
+		common.NoData, "/load_balancers/%s", id)
+}
+
+// ListExt list load balancer with extension.
+func (cli *LoadBalancerClient) ListExt(kt *kit.Kit, req *core.ListReq) (
+	*dslb.LoadBalancerListExtResult[corelb.TCloudExtension], error) {
+
+	return common.Request[core.ListReq, dslb.LoadBalancerListExtResult[corelb.TCloudExtension]](cli.client, rest.POST,
+		kt, req, "/load_balancers/list")
+}
+
+// ListListenerExt list load balancer listener with extension.
+func (cli *LoadBalancerClient) ListListenerExt(kt *kit.Kit, req *core.ListReq) (
+	*dslb.ListenerListExtResult[corelb.TCloudListenerExtension], error) {
+
+	return common.Request[core.ListReq, dslb.ListenerListExtResult[corelb.TCloudListenerExtension]](cli.client,
+		rest.POST, kt, req, "/load_balancers/listeners/list")
+}
+
+// ListTargetGroupExt list load balancer target group with extension.
+func (cli *LoadBalancerClient) ListTargetGroupExt(kt *kit.Kit, req *core.ListReq) (
+	*dslb.TargetGroupListExtResult[corelb.TCloudTargetGroupExtension], error) {
+
+	return common.Request[core.ListReq, dslb.TargetGroupListExtResult[corelb.TCloudTargetGroupExtension]](cli.client,
+		rest.POST, kt, req, "/load_balancers/target_groups/list")
+}
@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package fakeclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	dataservice "hcm/pkg/client/data-service"
+	restclient "hcm/pkg/rest/client"
+)
+
+const (
+	// DataServicePrefix data-service 接口路径前缀
+	DataServicePrefix = "/api/v1/data"
+	// HCServicePrefix hc-service 接口路径前缀
+	HCServicePrefix = "/api/v1/hc"
+)
+
+// handlerClient 直接在进程内调用服务端处理请求的 http client，请求的 host 为服务名称
+type handlerClient struct {
+	handlers map[cc.Name]http.Handler
+}
+
+// Do implements client.HTTPClient.
+func (c *handlerClient) Do(req *http.Request) (*http.Response, error) {
+	handler, exists := c.handlers[cc.Name(req.URL.Host)]
+	if !exists {
+		return nil, fmt.Errorf("service %s is not registered", req.URL.Host)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder.Result(), nil
+}
+
+// discover 将服务名称作为服务地址的服务发现
+type discover struct {
+	handlers map[cc.Name]http.Handler
+}
+
+// Discover implements serviced.Discover.
+func (d *discover) Discover(name cc.Name) ([]string, error) {
+	if _, exists := d.handlers[name]; !exists {
+		return nil, fmt.Errorf("service %s is not registered", name)
+	}
+
+	return []string{"http://" + string(name)}, nil
+}
+
+// Services implements serviced.Discover.
+func (d *discover) Services() []cc.Name {
+	names := make([]cc.Name, 0, len(d.handlers))
+	for name := range d.handlers {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// GetServiceAllNodeKeys implements serviced.Discover.
+func (d *discover) GetServiceAllNodeKeys(name cc.Name) ([]string, error) {
+	return d.Discover(name)
+}
+
+// GetServiceAllNodeLabels implements serviced.Discover.
+func (d *discover) GetServiceAllNodeLabels(cc.Name) (map[string]map[string]string, error) {
+	return make(map[string]map[string]string), nil
+}
+
+// NewClientSet 创建请求由 handlers 中对应服务的服务端处理的 client set
+func NewClientSet(handlers map[cc.Name]http.Handler) *client.ClientSet {
+	return client.NewClientSet(&handlerClient{handlers: handlers}, &discover{handlers: handlers})
+}
+
+// NewDataServiceClient 创建请求由 server 处理的 data-service client
+func NewDataServiceClient(server http.Handler) *dataservice.Client {
+	handlers := map[cc.Name]http.Handler{cc.DataServiceName: server}
+	return NewClientSet(handlers).DataService()
+}
+
+var _ restclient.HTTPClient = new(handlerClient)
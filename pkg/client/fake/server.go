@@ -0,0 +1,503 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package fakeclient 提供内存实现的 data-service、hc-service 等服务端，用于在单元测试中替代真实服务。
+package fakeclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+// record 资源记录，key 为字段名，value 为 json 解析后的字段值
+type record map[string]interface{}
+
+// HandlerFunc 自定义接口的处理函数，body 为请求体，返回值作为响应的 data 返回
+type HandlerFunc func(body []byte) (interface{}, error)
+
+// Server 内存实现的服务端，按照 data-service 的接口约定处理资源的增删改查:
+//   - POST {res}/list: 按照过滤条件查询资源
+//   - POST {res}/batch/create: 批量创建资源，返回资源ID
+//   - PATCH {res}、{res}/batch、{res}/batch/update: 按照资源ID批量更新资源，只更新请求中非空的字段
+//   - DELETE {res}/batch: 按照过滤条件删除资源
+//   - GET {res}/{id}: 查询单个资源
+//
+// 资源按照去掉 /vendors/{vendor} 和操作后的路径存储，例如 /vendors/tcloud/load_balancers/list 查询的资源为
+// load_balancers。关联关系资源(以 _rels 结尾)的ID为自增数字，其他资源的ID为字符串。
+// 其他接口需要通过 Handle 注册处理函数，未注册的接口返回错误。
+type Server struct {
+	lock     sync.Mutex
+	prefix   string
+	seq      int
+	tables   map[string][]record
+	handlers map[string]HandlerFunc
+}
+
+// NewServer new server, prefix 为接口路径前缀，例如 /api/v1/data
+func NewServer(prefix string) *Server {
+	return &Server{
+		prefix:   strings.TrimSuffix(prefix, "/"),
+		tables:   make(map[string][]record),
+		handlers: make(map[string]HandlerFunc),
+	}
+}
+
+// Handle 注册自定义接口的处理函数，path 为去掉前缀后的完整路径，例如 /vendors/tcloud/disks/create。
+// 自定义接口优先于资源的增删改查接口。
+func (s *Server) Handle(method, path string, fn HandlerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.handlers[method+" "+path] = fn
+}
+
+// Add 添加资源，资源ID为空时自动生成，返回资源ID
+func (s *Server) Add(table string, items ...interface{}) ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		one, err := toRecord(item)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, s.insert(table, one))
+	}
+
+	return ids, nil
+}
+
+// List 查询资源，result 需为资源切片的指针
+func (s *Server) List(table string, result interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	raw, err := json.Marshal(s.tables[table])
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, result)
+}
+
+// Count 查询资源数量
+func (s *Server) Count(table string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.tables[table])
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeResp(w, nil, err)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, s.prefix)
+
+	s.lock.Lock()
+	fn, exists := s.handlers[r.Method+" "+path]
+	s.lock.Unlock()
+	if exists {
+		data, err := fn(body)
+		writeResp(w, data, err)
+		return
+	}
+
+	s.lock.Lock()
+	data, err := s.serveResource(r.Method, path, body)
+	s.lock.Unlock()
+	writeResp(w, data, err)
+}
+
+func (s *Server) serveResource(method, path string, body []byte) (interface{}, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 2 && segments[0] == "vendors" {
+		segments = segments[2:]
+	}
+	last := len(segments) - 1
+
+	switch {
+	case method == http.MethodPost && segments[last] == "list":
+		return s.list(strings.Join(segments[:last], "/"), body)
+
+	case method == http.MethodPost && last > 1 && segments[last-1] == "batch" && segments[last] == "create":
+		return s.create(strings.Join(segments[:last-1], "/"), body)
+
+	case method == http.MethodPatch:
+		table := strings.Join(segments, "/")
+		table = strings.TrimSuffix(strings.TrimSuffix(table, "/update"), "/batch")
+		return nil, s.update(table, body)
+
+	case method == http.MethodDelete && segments[last] == "batch":
+		return nil, s.delete(strings.Join(segments[:last], "/"), body)
+
+	case method == http.MethodGet && last > 0:
+		return s.get(strings.Join(segments[:last], "/"), segments[last])
+	}
+
+	return nil, errf.Newf(errf.Unknown, "fake server not support %s %s", method, path)
+}
+
+func (s *Server) list(table string, body []byte) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	details := make([]record, 0)
+	for _, one := range s.tables[table] {
+		matched, err := matchExpression(req.Filter, one)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		if matched {
+			details = append(details, one)
+		}
+	}
+
+	if req.Page != nil && req.Page.Count {
+		return map[string]interface{}{"count": len(details)}, nil
+	}
+
+	if req.Page != nil && req.Page.Limit > 0 {
+		start := int(req.Page.Start)
+		if start > len(details) {
+			start = len(details)
+		}
+
+		end := start + int(req.Page.Limit)
+		if end > len(details) {
+			end = len(details)
+		}
+		details = details[start:end]
+	}
+
+	return map[string]interface{}{"details": details}, nil
+}
+
+func (s *Server) create(table string, body []byte) (interface{}, error) {
+	items, err := decodeItems(body)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, s.insert(table, item))
+	}
+
+	return map[string]interface{}{"ids": ids}, nil
+}
+
+func (s *Server) update(table string, body []byte) error {
+	items, err := decodeItems(body)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		id, _ := item["id"].(string)
+		one := s.find(table, id)
+		if one == nil {
+			return errf.Newf(errf.RecordNotFound, "%s: %s not found", table, id)
+		}
+
+		mergeRecord(one, item)
+	}
+
+	return nil
+}
+
+func (s *Server) delete(table string, body []byte) error {
+	req := new(struct {
+		Filter *filter.Expression `json:"filter"`
+	})
+	if err := json.Unmarshal(body, req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if req.Filter == nil || len(req.Filter.Rules) == 0 {
+		return errf.New(errf.InvalidParameter, "delete filter is required")
+	}
+
+	remains := make([]record, 0, len(s.tables[table]))
+	for _, one := range s.tables[table] {
+		matched, err := matchExpression(req.Filter, one)
+		if err != nil {
+			return errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		if !matched {
+			remains = append(remains, one)
+		}
+	}
+	s.tables[table] = remains
+
+	return nil
+}
+
+func (s *Server) get(table, id string) (interface{}, error) {
+	one := s.find(table, id)
+	if one == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "%s: %s not found", table, id)
+	}
+
+	return one, nil
+}
+
+func (s *Server) find(table, id string) record {
+	for _, one := range s.tables[table] {
+		if one["id"] == id {
+			return one
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) insert(table string, one record) string {
+	if id, exists := one["id"]; exists && id != "" && id != float64(0) {
+		s.tables[table] = append(s.tables[table], one)
+		return fmt.Sprint(id)
+	}
+
+	s.seq++
+	if strings.HasSuffix(table, "_rels") {
+		one["id"] = float64(s.seq)
+	} else {
+		one["id"] = fmt.Sprintf("%08d", s.seq)
+	}
+
+	s.tables[table] = append(s.tables[table], one)
+	return fmt.Sprint(one["id"])
+}
+
+// decodeItems 解析批量创建、更新请求中的资源列表，资源列表为请求体本身或请求体中第一个元素为对象的数组字段。
+func decodeItems(body []byte) ([]record, error) {
+	var req interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	// 请求体为资源数组，例如云盘的批量创建请求
+	if values, ok := req.([]interface{}); ok {
+		if items, ok := toRecords(values); ok {
+			return items, nil
+		}
+		return nil, errf.New(errf.InvalidParameter, "request items should be object")
+	}
+
+	fields, ok := req.(map[string]interface{})
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "request should be object or array")
+	}
+
+	// 请求只包含单个资源时，请求体即为资源
+	if _, exists := fields["id"]; exists {
+		return []record{fields}, nil
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		values, ok := fields[key].([]interface{})
+		if !ok {
+			continue
+		}
+
+		if items, ok := toRecords(values); ok {
+			return items, nil
+		}
+	}
+
+	return nil, errf.New(errf.InvalidParameter, "request items is required")
+}
+
+// toRecords 将非空且元素均为对象的数组转换为资源列表，不满足时返回false
+func toRecords(values []interface{}) ([]record, bool) {
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	items := make([]record, 0, len(values))
+	for _, value := range values {
+		item, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		items = append(items, item)
+	}
+
+	return items, true
+}
+
+// mergeRecord 将更新字段合并到资源中，空值字段不更新，对象字段递归合并。
+func mergeRecord(dst, src record) {
+	for key, value := range src {
+		switch val := value.(type) {
+		case nil:
+			continue
+		case string:
+			if len(val) == 0 {
+				continue
+			}
+		case map[string]interface{}:
+			if origin, ok := dst[key].(map[string]interface{}); ok {
+				mergeRecord(origin, val)
+				continue
+			}
+		}
+
+		dst[key] = value
+	}
+}
+
+func toRecord(item interface{}) (record, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	one := make(record)
+	if err = json.Unmarshal(raw, &one); err != nil {
+		return nil, err
+	}
+
+	return one, nil
+}
+
+func writeResp(w http.ResponseWriter, data interface{}, err error) {
+	resp := &core.BaseResp[interface{}]{Data: data}
+	if err != nil {
+		ef := errf.Error(err)
+		resp.BaseResp = rest.BaseResp{Code: ef.Code, Message: ef.Message}
+		resp.Data = nil
+	}
+
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(raw)
+}
+
+// matchExpression 判断资源是否满足过滤条件
+func matchExpression(expr *filter.Expression, one record) (bool, error) {
+	if expr == nil || len(expr.Rules) == 0 {
+		return true, nil
+	}
+
+	for _, rule := range expr.Rules {
+		matched, err := matchRule(rule, one)
+		if err != nil {
+			return false, err
+		}
+
+		if expr.Op == filter.Or && matched {
+			return true, nil
+		}
+
+		if expr.Op != filter.Or && !matched {
+			return false, nil
+		}
+	}
+
+	return expr.Op != filter.Or, nil
+}
+
+func matchRule(rule filter.RuleFactory, one record) (bool, error) {
+	switch r := rule.(type) {
+	case *filter.Expression:
+		return matchExpression(r, one)
+	case *filter.AtomRule:
+		return matchAtomRule(r, one)
+	case filter.AtomRule:
+		return matchAtomRule(&r, one)
+	default:
+		return false, fmt.Errorf("unsupported rule type: %T", rule)
+	}
+}
+
+func matchAtomRule(rule *filter.AtomRule, one record) (bool, error) {
+	value := one[rule.Field]
+
+	switch filter.OpType(rule.Op) {
+	case filter.Equal:
+		return isEqual(value, rule.Value), nil
+	case filter.NotEqual:
+		return !isEqual(value, rule.Value), nil
+	case filter.In, filter.NotIn:
+		hit := false
+		for _, candidate := range toSlice(rule.Value) {
+			if isEqual(value, candidate) {
+				hit = true
+				break
+			}
+		}
+		return hit == (filter.OpType(rule.Op) == filter.In), nil
+	default:
+		return false, fmt.Errorf("fake server not support filter operator: %s", rule.Op)
+	}
+}
+
+// isEqual 按照 json 序列化后的值比较，避免数字类型不同导致比较结果不一致
+func isEqual(a, b interface{}) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(rawA) == string(rawB)
+}
+
+func toSlice(value interface{}) []interface{} {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return []interface{}{value}
+	}
+
+	result := make([]interface{}, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		result = append(result, val.Index(i).Interface())
+	}
+
+	return result
+}
@@ -31,6 +31,7 @@ type Client struct {
 	Subnet           *SubnetClient
 	Eip              *EipClient
 	Disk             *DiskClient
+	DiskSnapshot     *DiskSnapshotClient
 	Region           *RegionClient
 	ResourceGroup    *ResourceGroupClient
 	Image            *ImageClient
@@ -50,6 +51,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		Subnet:           NewSubnetClient(client),
 		Eip:              NewEipClient(client),
 		Disk:             NewCloudDiskClient(client),
+		DiskSnapshot:     NewDiskSnapshotClient(client),
 		Region:           NewRegionClient(client),
 		ResourceGroup:    NewResourceGroupClient(client),
 		Cvm:              NewCvmClient(client),
@@ -30,6 +30,7 @@ type Client struct {
 	Vpc              *VpcClient
 	Subnet           *SubnetClient
 	Disk             *DiskClient
+	DiskSnapshot     *DiskSnapshotClient
 	Cvm              *CvmClient
 	Image            *ImageClient
 	RouteTable       *RouteTableClient
@@ -49,6 +50,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		Vpc:              NewVpcClient(client),
 		Subnet:           NewSubnetClient(client),
 		Disk:             NewCloudDiskClient(client),
+		DiskSnapshot:     NewDiskSnapshotClient(client),
 		Cvm:              NewCvmClient(client),
 		Image:            NewCloudPublicClient(client),
 		RouteTable:       NewRouteTableClient(client),
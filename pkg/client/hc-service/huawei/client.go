@@ -31,6 +31,7 @@ type Client struct {
 	Subnet           *SubnetClient
 	Eip              *EipClient
 	Disk             *DiskClient
+	DiskSnapshot     *DiskSnapshotClient
 	Zone             *ZoneClient
 	Region           *RegionClient
 	Cvm              *CvmClient
@@ -51,6 +52,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		SecurityGroup:    NewCloudSecurityGroupClient(client),
 		Eip:              NewEipClient(client),
 		Disk:             NewCloudDiskClient(client),
+		DiskSnapshot:     NewDiskSnapshotClient(client),
 		Zone:             NewZoneClient(client),
 		Region:           NewRegionClient(client),
 		Cvm:              NewCvmClient(client),
@@ -25,6 +25,7 @@ import (
 	"hcm/pkg/api/core"
 	coreasync "hcm/pkg/api/core/async"
 	apits "hcm/pkg/api/task-server"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
@@ -82,6 +83,122 @@ func (c *Client) CreateCustomFlow(kt *kit.Kit, request *apits.AddCustomFlowReq)
 	return resp.Data, err
 }
 
+// CreateCronFlow add cron flow.
+func (c *Client) CreateCronFlow(kt *kit.Kit, request *apits.AddCronFlowReq) (*core.CreateResult, error) {
+	resp := new(core.CreateResp)
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(request).
+		SubResourcef("/cron_flows/create").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
+// ListCronFlow list cron flow.
+func (c *Client) ListCronFlow(kt *kit.Kit, req *core.ListReq) (*apits.ListCronFlowResult, error) {
+	resp := new(core.BaseResp[*apits.ListCronFlowResult])
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/cron_flows/list").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
+// DeleteCronFlow delete cron flow.
+func (c *Client) DeleteCronFlow(kt *kit.Kit, id string) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Delete().
+		WithContext(kt.Ctx).
+		SubResourcef("/cron_flows/%s", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// CancelFlow cancel flow.
+func (c *Client) CancelFlow(kt *kit.Kit, id string) error {
+	return c.operateFlow(kt, id, "cancel")
+}
+
+// RetryFlow retry failed flow from its failed tasks.
+func (c *Client) RetryFlow(kt *kit.Kit, id string) error {
+	return c.operateFlow(kt, id, "retry")
+}
+
+// ResumeFlow resume failed or canceled flow after manual fix.
+func (c *Client) ResumeFlow(kt *kit.Kit, id string) error {
+	return c.operateFlow(kt, id, "resume")
+}
+
+func (c *Client) operateFlow(kt *kit.Kit, id string, operation string) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		SubResourcef("/flows/%s/%s", id, operation).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// SignalTask send approve or reject signal to waiting task.
+func (c *Client) SignalTask(kt *kit.Kit, id string, req *apits.SignalTaskReq) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/tasks/%s/signal", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
 // ListFlow list flow.
 func (c *Client) ListFlow(kt *kit.Kit, req *core.ListReq) (*apits.ListFlowResult, error) {
 	resp := new(core.BaseResp[*apits.ListFlowResult])
@@ -101,6 +218,25 @@ func (c *Client) ListFlow(kt *kit.Kit, req *core.ListReq) (*apits.ListFlowResult
 	return resp.Data, err
 }
 
+// ListArchivedFlow list archived flow.
+func (c *Client) ListArchivedFlow(kt *kit.Kit, req *core.ListReq) (*apits.ListFlowResult, error) {
+	resp := new(core.BaseResp[*apits.ListFlowResult])
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(&apits.ListAsyncReq{ListReq: req, Archived: true}).
+		SubResourcef("/flows/list").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
 // GetFlow get flow.
 func (c *Client) GetFlow(kt *kit.Kit, id string) (*coreasync.AsyncFlow, error) {
 	resp := new(core.BaseResp[*coreasync.AsyncFlow])
@@ -119,6 +255,66 @@ func (c *Client) GetFlow(kt *kit.Kit, id string) (*coreasync.AsyncFlow, error) {
 	return resp.Data, err
 }
 
+// GetFlowHierarchy get flow and its sub flows hierarchy.
+func (c *Client) GetFlowHierarchy(kt *kit.Kit, id string) (*apits.FlowHierarchy, error) {
+	resp := new(core.BaseResp[*apits.FlowHierarchy])
+
+	err := c.client.Get().
+		WithContext(kt.Ctx).
+		SubResourcef("/flows/%s/hierarchy", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
+// GetFlowGraph get flow task graph rendered as dot or mermaid.
+func (c *Client) GetFlowGraph(kt *kit.Kit, id string, format enumor.FlowGraphFormat) (*apits.FlowGraphResult,
+	error) {
+
+	resp := new(core.BaseResp[*apits.FlowGraphResult])
+
+	err := c.client.Get().
+		WithContext(kt.Ctx).
+		SubResourcef("/flows/%s/graph", id).
+		WithParam("format", string(format)).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
+// GetFlowTemplateGraph get registered flow template task graph rendered as dot or mermaid.
+func (c *Client) GetFlowTemplateGraph(kt *kit.Kit, name enumor.FlowName, format enumor.FlowGraphFormat) (
+	*apits.FlowGraphResult, error) {
+
+	resp := new(core.BaseResp[*apits.FlowGraphResult])
+
+	err := c.client.Get().
+		WithContext(kt.Ctx).
+		SubResourcef("/flow_templates/%s/graph", name).
+		WithParam("format", string(format)).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
 // ListTask list task.
 func (c *Client) ListTask(kt *kit.Kit, req *core.ListReq) (*apits.ListTaskResult, error) {
 	resp := new(core.BaseResp[*apits.ListTaskResult])
@@ -138,6 +334,25 @@ func (c *Client) ListTask(kt *kit.Kit, req *core.ListReq) (*apits.ListTaskResult
 	return resp.Data, err
 }
 
+// ListArchivedTask list archived task.
+func (c *Client) ListArchivedTask(kt *kit.Kit, req *core.ListReq) (*apits.ListTaskResult, error) {
+	resp := new(core.BaseResp[*apits.ListTaskResult])
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(&apits.ListAsyncReq{ListReq: req, Archived: true}).
+		SubResourcef("/tasks/list").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
 // GetTask get task.
 func (c *Client) GetTask(kt *kit.Kit, id string) (*coreasync.AsyncFlowTask, error) {
 	resp := new(core.BaseResp[*coreasync.AsyncFlowTask])
@@ -155,3 +370,61 @@ func (c *Client) GetTask(kt *kit.Kit, id string) (*coreasync.AsyncFlowTask, erro
 
 	return resp.Data, err
 }
+
+// GetTaskLogs get task execution logs.
+func (c *Client) GetTaskLogs(kt *kit.Kit, id string) (*apits.TaskLogsResult, error) {
+	resp := new(core.BaseResp[*apits.TaskLogsResult])
+
+	err := c.client.Get().
+		WithContext(kt.Ctx).
+		SubResourcef("/tasks/%s/logs", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
+// ListCallbackDelivery list flow callback delivery.
+func (c *Client) ListCallbackDelivery(kt *kit.Kit, req *core.ListReq) (*apits.ListCallbackDeliveryResult, error) {
+	resp := new(core.BaseResp[*apits.ListCallbackDeliveryResult])
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/callback_deliveries/list").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, err
+}
+
+// ReplayCallback replay flow callback delivery.
+func (c *Client) ReplayCallback(kt *kit.Kit, id string) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		SubResourcef("/callback_deliveries/%s/replay", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
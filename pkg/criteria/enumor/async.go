@@ -37,6 +37,37 @@ const (
 	TaskSuccess TaskState = "success"
 	// TaskFailed task state is failed
 	TaskFailed TaskState = "failed"
+	// TaskCompensated task state is compensated.
+	TaskCompensated TaskState = "compensated"
+	// TaskSkipped task state is skipped.
+	TaskSkipped TaskState = "skipped"
+	// TaskWaiting task state is waiting, task is waiting for external event and not hold executor worker.
+	TaskWaiting TaskState = "waiting"
+	// TaskRetrying task state is retrying, task is waiting for next retry time and not hold executor worker.
+	TaskRetrying TaskState = "retrying"
+)
+
+// TaskTriggerRule 任务触发规则，定义依赖的前置任务处于什么状态时，任务可以执行。
+type TaskTriggerRule string
+
+// Validate TaskTriggerRule.
+func (v TaskTriggerRule) Validate() error {
+	switch v {
+	case "", TriggerAllSuccess, TriggerOnFailure, TriggerAlways:
+	default:
+		return fmt.Errorf("unsupported task trigger rule: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// TriggerAllSuccess 前置任务全部执行成功（包括被跳过和允许失败的任务）才执行，默认规则
+	TriggerAllSuccess TaskTriggerRule = "all_success"
+	// TriggerOnFailure 前置任务全部结束，且至少一个前置任务执行失败时执行，用于清理、通知等
+	TriggerOnFailure TaskTriggerRule = "on_failure"
+	// TriggerAlways 前置任务全部结束后执行，无论前置任务成功或失败
+	TriggerAlways TaskTriggerRule = "always"
 )
 
 // FlowState is flow state.
@@ -55,6 +86,12 @@ const (
 	FlowSuccess FlowState = "success"
 	// FlowFailed flow state is failed
 	FlowFailed FlowState = "failed"
+	// FlowCompensating flow state is compensating
+	FlowCompensating FlowState = "compensating"
+	// FlowCompensated flow state is compensated
+	FlowCompensated FlowState = "compensated"
+	// FlowCompensateFailed flow state is compensate failed
+	FlowCompensateFailed FlowState = "compensate_failed"
 )
 
 // BackendType is backend type.
@@ -64,6 +101,7 @@ type BackendType string
 func (v BackendType) Validate() error {
 	switch v {
 	case BackendMysql:
+	case BackendMemory:
 	default:
 		return fmt.Errorf("unsupported backend type: %s", v)
 	}
@@ -74,4 +112,72 @@ func (v BackendType) Validate() error {
 const (
 	// BackendMysql mysql backend
 	BackendMysql BackendType = "mysql"
+	// BackendMemory memory backend, only used by unit test and single-node deployment.
+	BackendMemory BackendType = "memory"
+)
+
+// CallbackType is flow callback type.
+type CallbackType string
+
+// Validate CallbackType.
+func (v CallbackType) Validate() error {
+	switch v {
+	case CallbackHttp, CallbackRoute:
+	default:
+		return fmt.Errorf("unsupported callback type: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// CallbackHttp 通过HTTP请求回调指定的URL
+	CallbackHttp CallbackType = "http"
+	// CallbackRoute 通过服务发现回调内部服务的指定路由
+	CallbackRoute CallbackType = "route"
+)
+
+// CallbackState is flow callback delivery state.
+type CallbackState string
+
+const (
+	// CallbackPending callback delivery is waiting to be sent or retried
+	CallbackPending CallbackState = "pending"
+	// CallbackSuccess callback delivery is sent successfully
+	CallbackSuccess CallbackState = "success"
+	// CallbackFailed callback delivery is failed after all retries
+	CallbackFailed CallbackState = "failed"
+)
+
+// TaskLogLevel is async task log level.
+type TaskLogLevel string
+
+const (
+	// TaskLogInfo info level task log
+	TaskLogInfo TaskLogLevel = "info"
+	// TaskLogWarn warn level task log
+	TaskLogWarn TaskLogLevel = "warn"
+	// TaskLogError error level task log
+	TaskLogError TaskLogLevel = "error"
+)
+
+// FlowGraphFormat is flow task graph render format.
+type FlowGraphFormat string
+
+// Validate FlowGraphFormat.
+func (v FlowGraphFormat) Validate() error {
+	switch v {
+	case FlowGraphDot, FlowGraphMermaid:
+	default:
+		return fmt.Errorf("unsupported flow graph format: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// FlowGraphDot Graphviz DOT format
+	FlowGraphDot FlowGraphFormat = "dot"
+	// FlowGraphMermaid Mermaid flowchart format
+	FlowGraphMermaid FlowGraphFormat = "mermaid"
 )
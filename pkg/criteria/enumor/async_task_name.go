@@ -38,6 +38,7 @@ func (v ActionName) Validate() error {
 
 	case VirRoot:
 	case ActionCreateFactoryTest, ActionProduceTest, ActionAssembleTest, ActionSleep:
+	case ActionSubFlow, ActionWaitSignal:
 	default:
 		return fmt.Errorf("unsupported action name type: %s", v)
 	}
@@ -79,6 +80,11 @@ const (
 	ActionProduceTest       ActionName = "produce"
 	ActionAssembleTest      ActionName = "assemble"
 	ActionSleep             ActionName = "sleep"
+
+	// ActionSubFlow 子任务流Action，将任务流模版作为任务流中的一个任务执行
+	ActionSubFlow ActionName = "sub_flow"
+	// ActionWaitSignal 等待信号Action，任务进入等待状态，直到收到人工审批通过或拒绝的信号
+	ActionWaitSignal ActionName = "wait_signal"
 )
 
 // Security Group
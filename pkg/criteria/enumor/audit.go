@@ -40,6 +40,8 @@ const (
 	EipAuditResType               AuditResourceType = "eip"
 	GcpFirewallRuleAuditResType   AuditResourceType = "gcp_firewall_rule"
 	NetworkInterfaceAuditResType  AuditResourceType = "network_interface"
+	LoadBalancerAuditResType      AuditResourceType = "load_balancer"
+	DiskSnapshotAuditResType      AuditResourceType = "disk_snapshot"
 )
 
 // AuditResourceTypeEnums resource type map.
@@ -56,6 +58,8 @@ var AuditResourceTypeEnums = map[AuditResourceType]struct{}{
 	EipAuditResType:               {},
 	GcpFirewallRuleAuditResType:   {},
 	NetworkInterfaceAuditResType:  {},
+	LoadBalancerAuditResType:      {},
+	DiskSnapshotAuditResType:      {},
 }
 
 // Exist judge enum value exist.
@@ -62,6 +62,16 @@ func (rt CloudResourceType) ConvTableName() (table.Name, error) {
 		return table.ZoneTable, nil
 	case AzureResourceGroup:
 		return table.AzureRGTable, nil
+	case LoadBalancerCloudResType:
+		return table.LoadBalancerTable, nil
+	case ListenerCloudResType:
+		return table.LoadBalancerListenerTable, nil
+	case TargetGroupCloudResType:
+		return table.LoadBalancerTargetGroupTable, nil
+	case TargetCloudResType:
+		return table.LoadBalancerTargetTable, nil
+	case DiskSnapshotCloudResType:
+		return table.DiskSnapshotTable, nil
 	default:
 		return "", fmt.Errorf("%s does not have a corresponding table name", rt)
 	}
@@ -85,4 +95,9 @@ const (
 	ImageCloudResType            CloudResourceType = "image"
 	ZoneCloudResType             CloudResourceType = "zone"
 	AzureResourceGroup           CloudResourceType = "azure_resource_group"
+	LoadBalancerCloudResType     CloudResourceType = "load_balancer"
+	ListenerCloudResType         CloudResourceType = "load_balancer_listener"
+	TargetGroupCloudResType      CloudResourceType = "load_balancer_target_group"
+	TargetCloudResType           CloudResourceType = "load_balancer_target"
+	DiskSnapshotCloudResType     CloudResourceType = "disk_snapshot"
 )
@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import (
+	"fmt"
+)
+
+// LoadBalancerNetworkType is load balancer network type.
+type LoadBalancerNetworkType string
+
+// Validate the LoadBalancerNetworkType is valid or not
+func (t LoadBalancerNetworkType) Validate() error {
+	switch t {
+	case PublicLoadBalancer:
+	case InternalLoadBalancer:
+	default:
+		return fmt.Errorf("unsupported load balancer network type: %s", t)
+	}
+
+	return nil
+}
+
+const (
+	// PublicLoadBalancer 公网负载均衡
+	PublicLoadBalancer LoadBalancerNetworkType = "public"
+	// InternalLoadBalancer 内网负载均衡
+	InternalLoadBalancer LoadBalancerNetworkType = "internal"
+)
+
+// TargetInstType is load balancer backend target instance type.
+type TargetInstType string
+
+const (
+	// CvmTargetInstType 后端服务为主机
+	CvmTargetInstType TargetInstType = "CVM"
+	// EniTargetInstType 后端服务为弹性网卡
+	EniTargetInstType TargetInstType = "ENI"
+	// IpTargetInstType 后端服务为IP
+	IpTargetInstType TargetInstType = "IP"
+	// LambdaTargetInstType 后端服务为函数(aws)
+	LambdaTargetInstType TargetInstType = "LAMBDA"
+	// AlbTargetInstType 后端服务为应用型负载均衡(aws)
+	AlbTargetInstType TargetInstType = "ALB"
+)
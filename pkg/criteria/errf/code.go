@@ -59,4 +59,7 @@ const (
 	UserNoAppAccess int32 = 2000009
 	// RecordNotUpdate DB数据一行都没有被更新
 	RecordNotUpdate int32 = 2000010
+	// RecordConflict means the request conflicts with an existing record, such as the same idempotency key
+	// is used by a different request.
+	RecordConflict int32 = 2000011
 )
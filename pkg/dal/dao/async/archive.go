@@ -0,0 +1,183 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoasync
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesasync "hcm/pkg/dal/dao/types/async"
+	"hcm/pkg/dal/table"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AsyncArchive only used archived async flow and task, archive tables have the same columns as the
+// async_flow and async_flow_task tables.
+type AsyncArchive interface {
+	ArchiveFlowWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+	ArchiveTaskWithTx(kt *kit.Kit, tx *sqlx.Tx, flowIDs []string) error
+	ListFlow(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlows, error)
+	ListTask(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowTasks, error)
+}
+
+var _ AsyncArchive = new(AsyncArchiveDao)
+
+// AsyncArchiveDao archived async flow and task dao.
+type AsyncArchiveDao struct {
+	Orm orm.Interface
+}
+
+// ArchiveFlowWithTx copy async flows matched the filter expr to archive table with tx.
+func (dao *AsyncArchiveDao) ArchiveFlowWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	return dao.archiveWithTx(kt, tx, table.AsyncFlowTable, table.AsyncFlowArchiveTable,
+		tableasync.AsyncFlowColumns.ColumnExpr(), expr)
+}
+
+// ArchiveTaskWithTx copy async flow tasks belongs to flows to archive table with tx.
+func (dao *AsyncArchiveDao) ArchiveTaskWithTx(kt *kit.Kit, tx *sqlx.Tx, flowIDs []string) error {
+	return dao.archiveWithTx(kt, tx, table.AsyncFlowTaskTable, table.AsyncFlowTaskArchiveTable,
+		tableasync.AsyncFlowTaskColumns.ColumnExpr(), tools.ContainersExpression("flow_id", flowIDs))
+}
+
+func (dao *AsyncArchiveDao) archiveWithTx(kt *kit.Kit, tx *sqlx.Tx, source, target table.Name, columns string,
+	expr *filter.Expression) error {
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM %s %s`, target, columns, columns, source, whereExpr)
+	if _, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("archive %s failed, err: %v, filter: %s, rid: %s", source, err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListFlow archived async flow.
+func (dao *AsyncArchiveDao) ListFlow(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlows, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list archived async flow options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableasync.AsyncFlowColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncFlowArchiveTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count archived async flow failed, err: %v, filter: %s, rid: %s", err,
+				opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesasync.ListAsyncFlows{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableasync.AsyncFlowColumns.FieldsNamedExpr(opt.Fields),
+		table.AsyncFlowArchiveTable, whereExpr, pageExpr)
+
+	details := make([]tableasync.AsyncFlowTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select archived async flow failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql,
+			opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &typesasync.ListAsyncFlows{Count: 0, Details: details}, nil
+}
+
+// ListTask archived async flow task.
+func (dao *AsyncArchiveDao) ListTask(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowTasks, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list archived async flow task options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableasync.AsyncFlowTaskColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncFlowTaskArchiveTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count archived async flow task failed, err: %v, filter: %s, rid: %s", err,
+				opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesasync.ListAsyncFlowTasks{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableasync.AsyncFlowTaskColumns.FieldsNamedExpr(opt.Fields),
+		table.AsyncFlowTaskArchiveTable, whereExpr, pageExpr)
+
+	details := make([]tableasync.AsyncFlowTaskTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select archived async flow task failed, err: %v, sql: %s, filter: %v, rid: %s", err,
+			sql, opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &typesasync.ListAsyncFlowTasks{Count: 0, Details: details}, nil
+}
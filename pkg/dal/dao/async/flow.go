@@ -48,6 +48,7 @@ type AsyncFlow interface {
 	UpdateStateByCAS(kt *kit.Kit, tx *sqlx.Tx, info *typesasync.UpdateFlowInfo) error
 	List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlows, error)
 	ListWithTx(kt *kit.Kit, tx *sqlx.Tx, opt *types.ListOption) (*typesasync.ListAsyncFlows, error)
+	ListIDForUpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) ([]string, error)
 	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
 }
 
@@ -178,14 +179,21 @@ func (dao *AsyncFlowDao) UpdateStateByCAS(kt *kit.Kit, tx *sqlx.Tx, info *typesa
 		setSql += ", reason = :reason"
 	}
 
-	sql := fmt.Sprintf(`update %s %s where id = :id and state = :source`, table.AsyncFlowTable, setSql)
+	// 主节点组件更新时记录所在任期的fencing token，token的校验由AsyncLeaderFence在同一事务中完成
+	if info.FencingToken != 0 {
+		setSql += ", fencing_token = :fencing_token"
+	}
+
+	whereSql := "where id = :id and state = :source"
+	sql := fmt.Sprintf(`update %s %s %s`, table.AsyncFlowTable, setSql, whereSql)
 
 	whereValue := map[string]interface{}{
-		"id":     info.ID,
-		"source": info.Source,
-		"target": info.Target,
-		"worker": info.Worker,
-		"reason": info.Reason,
+		"id":            info.ID,
+		"source":        info.Source,
+		"target":        info.Target,
+		"worker":        info.Worker,
+		"reason":        info.Reason,
+		"fencing_token": info.FencingToken,
 	}
 	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, whereValue)
 	if err != nil {
@@ -194,8 +202,8 @@ func (dao *AsyncFlowDao) UpdateStateByCAS(kt *kit.Kit, tx *sqlx.Tx, info *typesa
 	}
 
 	if effected == 0 {
-		return errf.Newf(errf.RecordNotUpdate, "flow[%s: %s] update state: %s, worker: %s failed",
-			info.ID, info.Source, info.Target, info.Worker)
+		return errf.Newf(errf.RecordNotUpdate, "flow[%s: %s] update state: %s, worker: %s, fencing token: %d failed",
+			info.ID, info.Source, info.Target, info.Worker, info.FencingToken)
 	}
 
 	return nil
@@ -279,6 +287,27 @@ func (dao *AsyncFlowDao) ListWithTx(kt *kit.Kit, tx *sqlx.Tx,
 	return &typesasync.ListAsyncFlows{Count: 0, Details: details}, nil
 }
 
+// ListIDForUpdateWithTx list async flow ids matched the filter expr and lock these rows until tx end.
+func (dao *AsyncFlowDao) ListIDForUpdateWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) ([]string, error) {
+	if expr == nil {
+		return nil, errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT id FROM %s %s FOR UPDATE`, table.AsyncFlowTable, whereExpr)
+	ids := make([]string, 0)
+	if err = dao.Orm.Txn(tx).Select(kt.Ctx, &ids, sql, whereValue); err != nil {
+		logs.ErrorJson("select async flow for update failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 // List async flow.
 func (dao *AsyncFlowDao) List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlows, error) {
 	if opt == nil {
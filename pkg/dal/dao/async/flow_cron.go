@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoasync
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesasync "hcm/pkg/dal/dao/types/async"
+	"hcm/pkg/dal/table"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// AsyncFlowCron only used async flow cron.
+type AsyncFlowCron interface {
+	Create(kt *kit.Kit, model *tableasync.AsyncFlowCronTable) (string, error)
+	UpdateNextRunByCAS(kt *kit.Kit, info *typesasync.UpdateCronNextRunInfo) error
+	List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowCrons, error)
+	Delete(kt *kit.Kit, expr *filter.Expression) error
+}
+
+var _ AsyncFlowCron = new(AsyncFlowCronDao)
+
+// AsyncFlowCronDao async flow cron dao.
+type AsyncFlowCronDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create async flow cron.
+func (dao *AsyncFlowCronDao) Create(kt *kit.Kit, model *tableasync.AsyncFlowCronTable) (string, error) {
+
+	id, err := dao.IDGen.One(kt, table.AsyncFlowCronTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AsyncFlowCronTable,
+		tableasync.AsyncFlowCronColumns.ColumnExpr(), tableasync.AsyncFlowCronColumns.ColonNameExpr())
+
+	if err = dao.Orm.Do().Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.AsyncFlowCronTable, err, sql, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", table.AsyncFlowCronTable, err)
+	}
+
+	return id, nil
+}
+
+// UpdateNextRunByCAS update async flow cron next run time by CAS.
+func (dao *AsyncFlowCronDao) UpdateNextRunByCAS(kt *kit.Kit, info *typesasync.UpdateCronNextRunInfo) error {
+
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`update %s set next_run_at = :target where id = :id and next_run_at = :source`,
+		table.AsyncFlowCronTable)
+
+	whereValue := map[string]interface{}{
+		"id":     info.ID,
+		"source": info.Source,
+		"target": info.Target,
+	}
+	effected, err := dao.Orm.Do().Update(kt.Ctx, sql, whereValue)
+	if err != nil {
+		logs.Errorf("update async flow cron failed, err: %v, id: %s, sql: %s, rid: %v", err, info.ID, sql, kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		return errf.Newf(errf.RecordNotUpdate, "flow cron[%s: %s] update next run time: %s failed",
+			info.ID, info.Source, info.Target)
+	}
+
+	return nil
+}
+
+// List async flow cron.
+func (dao *AsyncFlowCronDao) List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowCrons, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list async flow cron options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableasync.AsyncFlowCronColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncFlowCronTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count async flow cron failed, err: %v, filter: %s, rid: %s", err,
+				opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesasync.ListAsyncFlowCrons{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableasync.AsyncFlowCronColumns.FieldsNamedExpr(opt.Fields),
+		table.AsyncFlowCronTable, whereExpr, pageExpr)
+
+	details := make([]tableasync.AsyncFlowCronTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select async flow cron failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql,
+			opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &typesasync.ListAsyncFlowCrons{Count: 0, Details: details}, nil
+}
+
+// Delete async flow cron.
+func (dao *AsyncFlowCronDao) Delete(kt *kit.Kit, filterExpr *filter.Expression) error {
+	if filterExpr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AsyncFlowCronTable, whereExpr)
+	if _, err = dao.Orm.Do().Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete async flow cron failed, err: %v, filter: %s, rid: %s", err, filterExpr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
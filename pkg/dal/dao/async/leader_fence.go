@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoasync
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	typesasync "hcm/pkg/dal/dao/types/async"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AsyncLeaderFence only used for async leader fencing token, the leader registers its fencing token after elected,
+// and the updates by leader components are checked with it in the same transaction.
+type AsyncLeaderFence interface {
+	Update(kt *kit.Kit, opt *typesasync.UpdateLeaderFenceOption) error
+	CheckWithTx(kt *kit.Kit, tx *sqlx.Tx, token int64) error
+}
+
+var _ AsyncLeaderFence = new(AsyncLeaderFenceDao)
+
+// AsyncLeaderFenceDao async leader fencing token dao.
+type AsyncLeaderFenceDao struct {
+	Orm orm.Interface
+}
+
+// Update 登记主节点当前任期的fencing token，只允许使用更大的token覆盖，已经存在更大的token时说明当前节点已经不是主节点。
+func (dao *AsyncLeaderFenceDao) Update(kt *kit.Kit, opt *typesasync.UpdateLeaderFenceOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "update leader fence option is nil")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	args := map[string]interface{}{
+		"id":     typesasync.LeaderFenceID,
+		"token":  opt.Token,
+		"worker": opt.Worker,
+	}
+
+	// on duplicate key update 按照顺序赋值，需要先根据原来的token更新worker，再更新token
+	sql := fmt.Sprintf(`INSERT INTO %s (id, token, worker) VALUES (:id, :token, :worker) ON DUPLICATE KEY UPDATE `+
+		`worker = IF(VALUES(token) >= token, VALUES(worker), worker), token = GREATEST(token, VALUES(token))`,
+		table.AsyncLeaderFenceTable)
+	if _, err := dao.Orm.Do().Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("update async leader fence failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	sql = fmt.Sprintf(`SELECT token FROM %s WHERE id = :id`, table.AsyncLeaderFenceTable)
+	tokens := make([]int64, 0)
+	if err := dao.Orm.Do().Select(kt.Ctx, &tokens, sql, args); err != nil {
+		logs.Errorf("get async leader fence failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if len(tokens) == 0 || tokens[0] != opt.Token {
+		return errf.Newf(errf.RecordNotUpdate, "leader fencing token: %d is stale", opt.Token)
+	}
+
+	return nil
+}
+
+// CheckWithTx 校验主节点组件携带的fencing token与当前登记的token一致，主节点组件的更新必须携带token，token为0时直接拒绝。
+// 校验时对登记记录加共享锁，新主节点登记token需要等待当前事务结束，事务提交前旧主节点的更新不会与新主节点交叉。
+func (dao *AsyncLeaderFenceDao) CheckWithTx(kt *kit.Kit, tx *sqlx.Tx, token int64) error {
+	if token <= 0 {
+		return errf.Newf(errf.InvalidParameter, "leader fencing token: %d is invalid", token)
+	}
+
+	sql := fmt.Sprintf(`SELECT token FROM %s WHERE id = :id LOCK IN SHARE MODE`, table.AsyncLeaderFenceTable)
+	tokens := make([]int64, 0)
+	args := map[string]interface{}{"id": typesasync.LeaderFenceID}
+	if err := dao.Orm.Txn(tx).Select(kt.Ctx, &tokens, sql, args); err != nil {
+		logs.Errorf("get async leader fence failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if len(tokens) == 0 || tokens[0] != token {
+		return errf.Newf(errf.RecordNotUpdate, "leader fencing token: %d is stale", token)
+	}
+
+	return nil
+}
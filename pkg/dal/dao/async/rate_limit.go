@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoasync
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	typesasync "hcm/pkg/dal/dao/types/async"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// AsyncRateLimit only used for async task rate limit token bucket, token bucket is shared between all
+// task-server nodes, so that the rate limit takes effect in the whole cluster.
+type AsyncRateLimit interface {
+	Acquire(kt *kit.Kit, opt *typesasync.AcquireTokenOption) (time.Duration, error)
+}
+
+var _ AsyncRateLimit = new(AsyncRateLimitDao)
+
+// AsyncRateLimitDao async task rate limit dao.
+type AsyncRateLimitDao struct {
+	Orm orm.Interface
+}
+
+// refillExpr 按照上次补充令牌到当前的时间补充令牌后，令牌桶中的令牌数，不超过令牌桶容量
+const refillExpr = `LEAST(:burst, tokens + TIMESTAMPDIFF(MICROSECOND, refreshed_at, NOW(6)) * :rate / 1000000)`
+
+// Acquire 从令牌桶中获取一个令牌，获取成功时返回0，令牌不足时返回需要等待的时间。
+// 令牌的补充和扣减在同一条sql中完成，多个节点并发获取令牌时由数据库行锁保证一致性。
+func (dao *AsyncRateLimitDao) Acquire(kt *kit.Kit, opt *typesasync.AcquireTokenOption) (time.Duration, error) {
+	if opt == nil {
+		return 0, errf.New(errf.InvalidParameter, "acquire token option is nil")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return 0, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	args := map[string]interface{}{
+		"id":    opt.Key,
+		"rate":  opt.Rate,
+		"burst": opt.Burst,
+	}
+
+	// 首次获取令牌时初始化令牌桶，令牌桶初始为满
+	sql := fmt.Sprintf(`INSERT IGNORE INTO %s (id, tokens, refreshed_at) VALUES (:id, :burst, NOW(6))`,
+		table.AsyncRateLimitBucketTable)
+	if _, err := dao.Orm.Do().Update(kt.Ctx, sql, args); err != nil {
+		logs.Errorf("init rate limit bucket failed, err: %v, key: %s, rid: %s", err, opt.Key, kt.Rid)
+		return 0, err
+	}
+
+	sql = fmt.Sprintf(`UPDATE %s SET tokens = %s - 1, refreshed_at = NOW(6) WHERE id = :id AND %s >= 1`,
+		table.AsyncRateLimitBucketTable, refillExpr, refillExpr)
+	effected, err := dao.Orm.Do().Update(kt.Ctx, sql, args)
+	if err != nil {
+		logs.Errorf("acquire rate limit token failed, err: %v, key: %s, rid: %s", err, opt.Key, kt.Rid)
+		return 0, err
+	}
+
+	if effected == 1 {
+		return 0, nil
+	}
+
+	sql = fmt.Sprintf(`SELECT %s FROM %s WHERE id = :id`, refillExpr, table.AsyncRateLimitBucketTable)
+	tokens := make([]float64, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &tokens, sql, args); err != nil {
+		logs.Errorf("get rate limit bucket tokens failed, err: %v, key: %s, rid: %s", err, opt.Key, kt.Rid)
+		return 0, err
+	}
+
+	if len(tokens) == 0 {
+		return 0, fmt.Errorf("rate limit bucket: %s not found", opt.Key)
+	}
+
+	return opt.TokenWait(tokens[0]), nil
+}
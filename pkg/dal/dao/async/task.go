@@ -45,7 +45,9 @@ type AsyncFlowTask interface {
 	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableasync.AsyncFlowTaskTable) ([]string, error)
 	Update(kt *kit.Kit, expr *filter.Expression, model *tableasync.AsyncFlowTaskTable) error
 	UpdateByID(kt *kit.Kit, id string, model *tableasync.AsyncFlowTaskTable) error
+	UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tableasync.AsyncFlowTaskTable) error
 	UpdateStateByCAS(kt *kit.Kit, info *typesasync.UpdateTaskInfo) error
+	UpdateResultByCAS(kt *kit.Kit, info *typesasync.UpdateTaskResultInfo) error
 	List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowTasks, error)
 	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
 	GenIDs(kt *kit.Kit, num int) ([]string, error)
@@ -141,14 +143,18 @@ func (dao *AsyncFlowTaskDao) UpdateStateByCAS(kt *kit.Kit, info *typesasync.Upda
 	if info.Reason != nil {
 		setSql += ",reason = :reason"
 	}
+	if info.RetryState != nil {
+		setSql += ",retry_state = :retry_state"
+	}
 
 	sql := fmt.Sprintf(`UPDATE %s %s where id = :id and state = :source`, table.AsyncFlowTaskTable, setSql)
 
 	values := map[string]interface{}{
-		"id":     info.ID,
-		"target": info.Target,
-		"source": info.Source,
-		"reason": info.Reason,
+		"id":          info.ID,
+		"target":      info.Target,
+		"source":      info.Source,
+		"reason":      info.Reason,
+		"retry_state": info.RetryState,
 	}
 	effect, err := dao.Orm.Do().Update(kt.Ctx, sql, values)
 	if err != nil {
@@ -164,6 +170,40 @@ func (dao *AsyncFlowTaskDao) UpdateStateByCAS(kt *kit.Kit, info *typesasync.Upda
 	return nil
 }
 
+// UpdateResultByCAS update async flow task result by cas, result is updated only when task state and result
+// are not changed.
+func (dao *AsyncFlowTaskDao) UpdateResultByCAS(kt *kit.Kit, info *typesasync.UpdateTaskResultInfo) error {
+
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	whereSql := "where id = :id and state = :state and result is null"
+	if len(info.Source) != 0 {
+		whereSql = "where id = :id and state = :state and result = cast(:source as json)"
+	}
+	sql := fmt.Sprintf(`UPDATE %s set result = :target %s`, table.AsyncFlowTaskTable, whereSql)
+
+	values := map[string]interface{}{
+		"id":     info.ID,
+		"state":  info.State,
+		"source": info.Source,
+		"target": info.Target,
+	}
+	effect, err := dao.Orm.Do().Update(kt.Ctx, sql, values)
+	if err != nil {
+		logs.Errorf("update async flow task result failed, err: %v, id: %s, sql: %s, rid: %v", err, info.ID, sql,
+			kt.Rid)
+		return err
+	}
+
+	if effect == 0 {
+		return errf.Newf(errf.RecordNotUpdate, "task[%s: %s] result has been changed", info.ID, info.State)
+	}
+
+	return nil
+}
+
 // UpdateByID async flow task.
 func (dao *AsyncFlowTaskDao) UpdateByID(kt *kit.Kit, id string, model *tableasync.AsyncFlowTaskTable) error {
 
@@ -193,6 +233,36 @@ func (dao *AsyncFlowTaskDao) UpdateByID(kt *kit.Kit, id string, model *tableasyn
 	return nil
 }
 
+// UpdateByIDWithTx async flow task with tx.
+func (dao *AsyncFlowTaskDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
+	model *tableasync.AsyncFlowTaskTable) error {
+
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+
+	toUpdate["id"] = id
+	_, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate)
+	if err != nil {
+		logs.Errorf("update async flow task failed, err: %v, id: %s, sql: %s, rid: %v", err, id, sql, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
 // GenIDs gen async flow task ids.
 func (dao *AsyncFlowTaskDao) GenIDs(kt *kit.Kit, num int) ([]string, error) {
 	ids, err := dao.IDGen.Batch(kt, table.AsyncFlowTaskTable, num)
@@ -0,0 +1,138 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daolb
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typeslb "hcm/pkg/dal/dao/types/load-balancer"
+	"hcm/pkg/dal/table"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CvmRel only used for load balancer and cvm relation.
+type CvmRel interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rels []tablelb.CvmRelTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*typeslb.ListCvmRelDetails, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ CvmRel = new(CvmRelDao)
+
+// CvmRelDao load balancer and cvm relation dao.
+type CvmRelDao struct {
+	Orm orm.Interface
+}
+
+// BatchCreateWithTx load balancer and cvm relations with tx.
+func (dao CvmRelDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, rels []tablelb.CvmRelTable) error {
+	if len(rels) == 0 {
+		return errf.New(errf.InvalidParameter, "rels to create cannot be empty")
+	}
+
+	for _, rel := range rels {
+		if err := rel.InsertValidate(); err != nil {
+			return errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s) VALUES(%s)`, table.LoadBalancerCvmRelTable,
+		tablelb.CvmRelColumns.ColumnExpr(), tablelb.CvmRelColumns.ColonNameExpr())
+	if err := dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, rels); err != nil {
+		logs.Errorf("batch create load balancer cvm rels failed, err: %v, rels: %v, rid: %s", err, rels, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.LoadBalancerCvmRelTable, err)
+	}
+
+	return nil
+}
+
+// List load balancer and cvm relations.
+func (dao CvmRelDao) List(kt *kit.Kit, opt *types.ListOption) (*typeslb.ListCvmRelDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list load balancer cvm rel options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablelb.CvmRelColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.LoadBalancerCvmRelTable, whereExpr)
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.Errorf("count load balancer cvm rels failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typeslb.ListCvmRelDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablelb.CvmRelColumns.FieldsNamedExpr(opt.Fields),
+		table.LoadBalancerCvmRelTable, whereExpr, pageExpr)
+
+	details := make([]tablelb.CvmRelTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.Errorf("list load balancer cvm rels failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &typeslb.ListCvmRelDetails{Details: details}, nil
+}
+
+// DeleteWithTx load balancer and cvm relations with tx.
+func (dao CvmRelDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.LoadBalancerCvmRelTable, whereExpr)
+	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.Errorf("delete load balancer cvm rels failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -35,9 +35,11 @@ import (
 	"hcm/pkg/dal/dao/cloud/cvm"
 	"hcm/pkg/dal/dao/cloud/disk"
 	diskcvmrel "hcm/pkg/dal/dao/cloud/disk-cvm-rel"
+	daosnapshot "hcm/pkg/dal/dao/cloud/disk-snapshot"
 	"hcm/pkg/dal/dao/cloud/eip"
 	eipcvmrel "hcm/pkg/dal/dao/cloud/eip-cvm-rel"
 	cimage "hcm/pkg/dal/dao/cloud/image"
+	daolb "hcm/pkg/dal/dao/cloud/load-balancer"
 	networkinterface "hcm/pkg/dal/dao/cloud/network-interface"
 	nicvmrel "hcm/pkg/dal/dao/cloud/network-interface-cvm-rel"
 	"hcm/pkg/dal/dao/cloud/region"
@@ -100,7 +102,18 @@ type Set interface {
 	AccountBillConfig() bill.Interface
 	AsyncFlow() daoasync.AsyncFlow
 	AsyncFlowTask() daoasync.AsyncFlowTask
+	AsyncFlowCron() daoasync.AsyncFlowCron
+	AsyncFlowCallback() daoasync.AsyncFlowCallback
+	AsyncArchive() daoasync.AsyncArchive
+	AsyncRateLimit() daoasync.AsyncRateLimit
+	AsyncLeaderFence() daoasync.AsyncLeaderFence
 	UserCollection() daouser.Interface
+	LoadBalancer() daolb.LoadBalancer
+	LbListener() daolb.Listener
+	LbTargetGroup() daolb.TargetGroup
+	LbTarget() daolb.Target
+	LbCvmRel() daolb.CvmRel
+	DiskSnapshot() daosnapshot.DiskSnapshot
 
 	Txn() *Txn
 }
@@ -485,3 +498,89 @@ func (s *set) AsyncFlowTask() daoasync.AsyncFlowTask {
 		IDGen: s.idGen,
 	}
 }
+
+// AsyncFlowCron return AsyncFlowCron dao.
+func (s *set) AsyncFlowCron() daoasync.AsyncFlowCron {
+	return &daoasync.AsyncFlowCronDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AsyncFlowCallback return AsyncFlowCallback dao.
+func (s *set) AsyncFlowCallback() daoasync.AsyncFlowCallback {
+	return &daoasync.AsyncFlowCallbackDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AsyncArchive return AsyncArchive dao.
+func (s *set) AsyncArchive() daoasync.AsyncArchive {
+	return &daoasync.AsyncArchiveDao{
+		Orm: s.orm,
+	}
+}
+
+// AsyncRateLimit return AsyncRateLimit dao.
+func (s *set) AsyncRateLimit() daoasync.AsyncRateLimit {
+	return &daoasync.AsyncRateLimitDao{
+		Orm: s.orm,
+	}
+}
+
+// AsyncLeaderFence return AsyncLeaderFence dao.
+func (s *set) AsyncLeaderFence() daoasync.AsyncLeaderFence {
+	return &daoasync.AsyncLeaderFenceDao{
+		Orm: s.orm,
+	}
+}
+
+// LoadBalancer return load balancer dao.
+func (s *set) LoadBalancer() daolb.LoadBalancer {
+	return &daolb.LoadBalancerDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// LbListener return load balancer listener dao.
+func (s *set) LbListener() daolb.Listener {
+	return &daolb.ListenerDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// LbTargetGroup return load balancer target group dao.
+func (s *set) LbTargetGroup() daolb.TargetGroup {
+	return &daolb.TargetGroupDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// LbTarget return load balancer target dao.
+func (s *set) LbTarget() daolb.Target {
+	return &daolb.TargetDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// LbCvmRel return load balancer and cvm relation dao.
+func (s *set) LbCvmRel() daolb.CvmRel {
+	return &daolb.CvmRelDao{
+		Orm: s.orm,
+	}
+}
+
+// DiskSnapshot return disk snapshot dao.
+func (s *set) DiskSnapshot() daosnapshot.DiskSnapshot {
+	return &daosnapshot.DiskSnapshotDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
@@ -38,6 +38,8 @@ type UpdateFlowInfo struct {
 	Target enumor.FlowState   `json:"target" validate:"required"`
 	Reason *tableasync.Reason `json:"reason" validate:"omitempty"`
 	Worker string             `json:"worker" validate:"omitempty"`
+	// FencingToken 主节点组件更新任务流时所在任期的fencing token，会记录到任务流上，token的校验由主节点组件的更新接口完成
+	FencingToken int64 `json:"fencing_token" validate:"omitempty"`
 }
 
 // Validate UpdateFlowInfo.
@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package typesasync
+
+import (
+	"errors"
+	"time"
+
+	tableasync "hcm/pkg/dal/table/async"
+)
+
+// ListAsyncFlowCrons list async flow crons.
+type ListAsyncFlowCrons struct {
+	Count   uint64                          `json:"count,omitempty"`
+	Details []tableasync.AsyncFlowCronTable `json:"details,omitempty"`
+}
+
+// UpdateCronNextRunInfo define update cron flow next run time info.
+type UpdateCronNextRunInfo struct {
+	ID     string    `json:"id"`
+	Source time.Time `json:"source"`
+	Target time.Time `json:"target"`
+}
+
+// Validate UpdateCronNextRunInfo.
+func (info *UpdateCronNextRunInfo) Validate() error {
+	if len(info.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if info.Source.IsZero() {
+		return errors.New("source is required")
+	}
+
+	if !info.Target.After(info.Source) {
+		return errors.New("target should be after source")
+	}
+
+	return nil
+}
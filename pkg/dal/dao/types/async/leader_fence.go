@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package typesasync
+
+import (
+	"errors"
+)
+
+// LeaderFenceID 异步任务框架主节点fencing token记录的唯一标识
+const LeaderFenceID = "async"
+
+// UpdateLeaderFenceOption define update leader fencing token option.
+type UpdateLeaderFenceOption struct {
+	// Worker 主节点的唯一标识
+	Worker string `json:"worker"`
+	// Token 主节点当前任期的fencing token，随着每次选主单调递增
+	Token int64 `json:"token"`
+}
+
+// Validate UpdateLeaderFenceOption.
+func (opt *UpdateLeaderFenceOption) Validate() error {
+	if len(opt.Worker) == 0 {
+		return errors.New("worker is required")
+	}
+
+	if opt.Token <= 0 {
+		return errors.New("token should be greater than 0")
+	}
+
+	return nil
+}
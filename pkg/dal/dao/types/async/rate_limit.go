@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package typesasync
+
+import (
+	"errors"
+	"time"
+)
+
+// AcquireTokenOption define acquire rate limit token option.
+type AcquireTokenOption struct {
+	// Key 令牌桶唯一标识，如账号ID与云厂商接口分组的组合
+	Key string `json:"key"`
+	// Rate 令牌桶每秒生成的令牌数
+	Rate float64 `json:"rate"`
+	// Burst 令牌桶容量，即允许的最大突发请求数
+	Burst uint `json:"burst"`
+}
+
+// Validate AcquireTokenOption.
+func (opt *AcquireTokenOption) Validate() error {
+	if len(opt.Key) == 0 {
+		return errors.New("key is required")
+	}
+
+	if len(opt.Key) > 255 {
+		return errors.New("key should less than 255")
+	}
+
+	if opt.Rate <= 0 {
+		return errors.New("rate should be greater than 0")
+	}
+
+	if opt.Burst == 0 {
+		return errors.New("burst should be greater than 0")
+	}
+
+	return nil
+}
+
+// TokenWait 计算令牌桶中的令牌数补充到1个所需要等待的时间，并发获取令牌失败时令牌可能已经足够，此时至少等待1毫秒。
+func (opt *AcquireTokenOption) TokenWait(tokens float64) time.Duration {
+	wait := time.Duration((1 - tokens) / opt.Rate * float64(time.Second))
+	if wait < time.Millisecond {
+		wait = time.Millisecond
+	}
+
+	return wait
+}
@@ -23,6 +23,7 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
 )
 
 // ListAsyncFlowTasks list async flow tasks.
@@ -37,9 +38,26 @@ type UpdateTaskInfo struct {
 	Source enumor.TaskState   `json:"source" validate:"required"`
 	Target enumor.TaskState   `json:"target" validate:"required"`
 	Reason *tableasync.Reason `json:"reason,omitempty"`
+	// RetryState 不为空时同时更新任务的重试状态
+	RetryState *tableasync.RetryState `json:"retry_state,omitempty"`
 }
 
 // Validate UpdateTaskInfo.
 func (info *UpdateTaskInfo) Validate() error {
 	return validator.Validate.Struct(info)
 }
+
+// UpdateTaskResultInfo define update task result info, result is updated only when task is still in the state
+// and its result is still the source result.
+type UpdateTaskResultInfo struct {
+	ID    string           `json:"id" validate:"required"`
+	State enumor.TaskState `json:"state" validate:"required"`
+	// Source 任务当前的执行结果，为空表示任务当前没有执行结果
+	Source types.JsonField `json:"source"`
+	Target types.JsonField `json:"target" validate:"required"`
+}
+
+// Validate UpdateTaskResultInfo.
+func (info *UpdateTaskResultInfo) Validate() error {
+	return validator.Validate.Struct(info)
+}
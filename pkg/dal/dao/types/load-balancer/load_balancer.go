@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package typeslb defines load balancer dao result types.
+package typeslb
+
+import (
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+)
+
+// ListLoadBalancerDetails list load balancer details.
+type ListLoadBalancerDetails struct {
+	Count   uint64                      `json:"count,omitempty"`
+	Details []tablelb.LoadBalancerTable `json:"details,omitempty"`
+}
+
+// ListListenerDetails list load balancer listener details.
+type ListListenerDetails struct {
+	Count   uint64                  `json:"count,omitempty"`
+	Details []tablelb.ListenerTable `json:"details,omitempty"`
+}
+
+// ListTargetGroupDetails list load balancer target group details.
+type ListTargetGroupDetails struct {
+	Count   uint64                     `json:"count,omitempty"`
+	Details []tablelb.TargetGroupTable `json:"details,omitempty"`
+}
+
+// ListTargetDetails list load balancer target details.
+type ListTargetDetails struct {
+	Count   uint64                `json:"count,omitempty"`
+	Details []tablelb.TargetTable `json:"details,omitempty"`
+}
+
+// ListCvmRelDetails list load balancer cvm relation details.
+type ListCvmRelDetails struct {
+	Count   uint64                `json:"count,omitempty"`
+	Details []tablelb.CvmRelTable `json:"details,omitempty"`
+}
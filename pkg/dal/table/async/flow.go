@@ -21,6 +21,7 @@ package tableasync
 
 import (
 	"errors"
+	"time"
 
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
@@ -41,6 +42,17 @@ var AsyncFlowTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "memo", NamedC: "memo", Type: enumor.String},
 	{Column: "share_data", NamedC: "share_data", Type: enumor.Json},
 	{Column: "worker", NamedC: "worker", Type: enumor.String},
+	{Column: "start_at", NamedC: "start_at", Type: enumor.Time},
+	{Column: "priority", NamedC: "priority", Type: enumor.Numeric},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "compensate", NamedC: "compensate", Type: enumor.Boolean},
+	{Column: "parent_flow_id", NamedC: "parent_flow_id", Type: enumor.String},
+	{Column: "parent_task_id", NamedC: "parent_task_id", Type: enumor.String},
+	{Column: "callbacks", NamedC: "callbacks", Type: enumor.Json},
+	{Column: "callback_pending", NamedC: "callback_pending", Type: enumor.Boolean},
+	{Column: "idempotency_key", NamedC: "idempotency_key", Type: enumor.String},
+	{Column: "payload_digest", NamedC: "payload_digest", Type: enumor.String},
+	{Column: "fencing_token", NamedC: "fencing_token", Type: enumor.Numeric},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -49,17 +61,28 @@ var AsyncFlowTableColumnDescriptor = utils.ColumnDescriptors{
 
 // AsyncFlowTable define async_flow table.
 type AsyncFlowTable struct {
-	ID        string           `db:"id" json:"id" validate:"lte=64"`
-	Name      enumor.FlowName  `db:"name" json:"name"`
-	State     enumor.FlowState `db:"state" json:"state"`
-	Reason    *Reason          `db:"reason" json:"reason"`
-	ShareData *ShareData       `db:"share_data" json:"share_data"`
-	Memo      string           `db:"memo" json:"memo"`
-	Worker    *string          `db:"worker" json:"worker"`
-	Creator   string           `db:"creator" json:"creator" validate:"lte=64"`
-	Reviser   string           `db:"reviser" json:"reviser" validate:"lte=64"`
-	CreatedAt types.Time       `db:"created_at" json:"created_at" validate:"excluded_unless"`
-	UpdatedAt types.Time       `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+	ID              string           `db:"id" json:"id" validate:"lte=64"`
+	Name            enumor.FlowName  `db:"name" json:"name"`
+	State           enumor.FlowState `db:"state" json:"state"`
+	Reason          *Reason          `db:"reason" json:"reason"`
+	ShareData       *ShareData       `db:"share_data" json:"share_data"`
+	Memo            string           `db:"memo" json:"memo"`
+	Worker          *string          `db:"worker" json:"worker"`
+	StartAt         time.Time        `db:"start_at" json:"start_at"`
+	Priority        int              `db:"priority" json:"priority"`
+	AccountID       string           `db:"account_id" json:"account_id" validate:"lte=64"`
+	Compensate      *bool            `db:"compensate" json:"compensate"`
+	ParentFlowID    string           `db:"parent_flow_id" json:"parent_flow_id" validate:"lte=64"`
+	ParentTaskID    string           `db:"parent_task_id" json:"parent_task_id" validate:"lte=64"`
+	Callbacks       Callbacks        `db:"callbacks" json:"callbacks"`
+	CallbackPending *bool            `db:"callback_pending" json:"callback_pending"`
+	IdempotencyKey  *string          `db:"idempotency_key" json:"idempotency_key" validate:"omitempty,lte=64"`
+	PayloadDigest   string           `db:"payload_digest" json:"payload_digest" validate:"lte=64"`
+	FencingToken    int64            `db:"fencing_token" json:"fencing_token"`
+	Creator         string           `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser         string           `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt       types.Time       `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt       types.Time       `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
 }
 
 // TableName return async_flow table name.
@@ -86,6 +109,10 @@ func (a AsyncFlowTable) InsertValidate() error {
 		return errors.New("state is required")
 	}
 
+	if a.StartAt.IsZero() {
+		return errors.New("start_at is required")
+	}
+
 	if len(a.Creator) == 0 {
 		return errors.New("creator is required")
 	}
@@ -112,5 +139,13 @@ func (a AsyncFlowTable) UpdateValidate() error {
 		return errors.New("creator can not update")
 	}
 
+	if a.IdempotencyKey != nil {
+		return errors.New("idempotency_key can not update")
+	}
+
+	if len(a.PayloadDigest) != 0 {
+		return errors.New("payload_digest can not update")
+	}
+
 	return nil
 }
@@ -0,0 +1,228 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// Callback 任务流状态变更回调订阅，任务流执行结束后（success、failed、cancel等最终状态）向订阅方发送通知。
+type Callback struct {
+	// Type 回调类型（http、route）
+	Type enumor.CallbackType `json:"type" validate:"required"`
+	// URL http类型回调的地址
+	URL string `json:"url,omitempty" validate:"omitempty,max=255"`
+	// Service route类型回调的内部服务名称，如 cloud-server
+	Service string `json:"service,omitempty" validate:"omitempty,max=64"`
+	// Path route类型回调的内部服务路由，如 /api/v1/cloud/async/callback
+	Path string `json:"path,omitempty" validate:"omitempty,max=255"`
+}
+
+// Validate Callback.
+func (c Callback) Validate() error {
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	if err := c.Type.Validate(); err != nil {
+		return err
+	}
+
+	switch c.Type {
+	case enumor.CallbackHttp:
+		u, err := url.Parse(c.URL)
+		if err != nil {
+			return fmt.Errorf("callback url: %s is invalid, err: %v", c.URL, err)
+		}
+
+		if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return fmt.Errorf("callback url: %s is invalid, should be http or https url", c.URL)
+		}
+	case enumor.CallbackRoute:
+		if len(c.Service) == 0 {
+			return errors.New("route callback service is required")
+		}
+
+		if !strings.HasPrefix(c.Path, "/") {
+			return fmt.Errorf("route callback path: %s should start with /", c.Path)
+		}
+	}
+
+	return nil
+}
+
+// Callbacks define flow callbacks.
+type Callbacks []Callback
+
+// Validate Callbacks.
+func (c Callbacks) Validate() error {
+	for _, one := range c {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Scan is used to decode raw message which is read from db into Callbacks.
+func (c *Callbacks) Scan(raw interface{}) error {
+	return types.Scan(raw, c)
+}
+
+// Value encode the Callbacks to a json raw, so that it can be stored to db with json raw.
+func (c Callbacks) Value() (driver.Value, error) {
+	return types.Value(c)
+}
+
+// Scan is used to decode raw message which is read from db into Callback.
+func (c *Callback) Scan(raw interface{}) error {
+	return types.Scan(raw, c)
+}
+
+// Value encode the Callback to a json raw, so that it can be stored to db with json raw.
+func (c Callback) Value() (driver.Value, error) {
+	return types.Value(c)
+}
+
+// AsyncFlowCallbackColumns defines all the async_flow_callback table's columns.
+var AsyncFlowCallbackColumns = utils.MergeColumns(nil, AsyncFlowCallbackTableColumnDescriptor)
+
+// AsyncFlowCallbackTableColumnDescriptor is async_flow_callback's column descriptors.
+var AsyncFlowCallbackTableColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "flow_id", NamedC: "flow_id", Type: enumor.String},
+	{Column: "flow_state", NamedC: "flow_state", Type: enumor.String},
+	{Column: "callback", NamedC: "callback", Type: enumor.Json},
+	{Column: "payload", NamedC: "payload", Type: enumor.Json},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "attempts", NamedC: "attempts", Type: enumor.Numeric},
+	{Column: "next_at", NamedC: "next_at", Type: enumor.Time},
+	{Column: "reason", NamedC: "reason", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AsyncFlowCallbackTable define async_flow_callback table, 任务流回调的投递记录，投递失败的记录可以重新投递。
+type AsyncFlowCallbackTable struct {
+	ID        string               `db:"id" json:"id" validate:"lte=64"`
+	FlowID    string               `db:"flow_id" json:"flow_id" validate:"lte=64"`
+	FlowState enumor.FlowState     `db:"flow_state" json:"flow_state"`
+	Callback  *Callback            `db:"callback" json:"callback"`
+	Payload   types.JsonField      `db:"payload" json:"payload"`
+	State     enumor.CallbackState `db:"state" json:"state"`
+	Attempts  *uint                `db:"attempts" json:"attempts"`
+	NextAt    time.Time            `db:"next_at" json:"next_at"`
+	Reason    *Reason              `db:"reason" json:"reason"`
+	Creator   string               `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser   string               `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt types.Time           `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt types.Time           `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return async_flow_callback table name.
+func (a AsyncFlowCallbackTable) TableName() table.Name {
+	return table.AsyncFlowCallbackTable
+}
+
+// InsertValidate async_flow_callback table when insert.
+func (a AsyncFlowCallbackTable) InsertValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(a.FlowID) == 0 {
+		return errors.New("flow_id is required")
+	}
+
+	if len(a.FlowState) == 0 {
+		return errors.New("flow_state is required")
+	}
+
+	if a.Callback == nil {
+		return errors.New("callback is required")
+	}
+
+	if len(a.Payload) == 0 {
+		return errors.New("payload is required")
+	}
+
+	if len(a.State) == 0 {
+		return errors.New("state is required")
+	}
+
+	if a.NextAt.IsZero() {
+		return errors.New("next_at is required")
+	}
+
+	if len(a.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(a.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate async_flow_callback table when update.
+func (a AsyncFlowCallbackTable) UpdateValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.FlowID) != 0 {
+		return errors.New("flow_id can not update")
+	}
+
+	if a.Callback != nil {
+		return errors.New("callback can not update")
+	}
+
+	if len(a.Payload) != 0 {
+		return errors.New("payload can not update")
+	}
+
+	if len(a.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AsyncFlowCronColumns defines all the async_flow_cron table's columns.
+var AsyncFlowCronColumns = utils.MergeColumns(nil, AsyncFlowCronTableColumnDescriptor)
+
+// AsyncFlowCronTableColumnDescriptor is async_flow_cron's column descriptors.
+var AsyncFlowCronTableColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "spec", NamedC: "spec", Type: enumor.String},
+	{Column: "share_data", NamedC: "share_data", Type: enumor.Json},
+	{Column: "tasks", NamedC: "tasks", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "next_run_at", NamedC: "next_run_at", Type: enumor.Time},
+	{Column: "priority", NamedC: "priority", Type: enumor.Numeric},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "compensate", NamedC: "compensate", Type: enumor.Boolean},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AsyncFlowCronTable define async_flow_cron table, 周期任务流定义，由主节点按照cron表达式生成普通任务流。
+type AsyncFlowCronTable struct {
+	ID         string          `db:"id" json:"id" validate:"lte=64"`
+	Name       enumor.FlowName `db:"name" json:"name"`
+	Spec       string          `db:"spec" json:"spec" validate:"lte=64"`
+	ShareData  *ShareData      `db:"share_data" json:"share_data"`
+	Tasks      CronFlowTasks   `db:"tasks" json:"tasks"`
+	Memo       string          `db:"memo" json:"memo"`
+	NextRunAt  time.Time       `db:"next_run_at" json:"next_run_at"`
+	Priority   int             `db:"priority" json:"priority"`
+	AccountID  string          `db:"account_id" json:"account_id" validate:"lte=64"`
+	Compensate *bool           `db:"compensate" json:"compensate"`
+	Creator    string          `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser    string          `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt  types.Time      `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt  types.Time      `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return async_flow_cron table name.
+func (a AsyncFlowCronTable) TableName() table.Name {
+	return table.AsyncFlowCronTable
+}
+
+// InsertValidate async_flow_cron table when insert.
+func (a AsyncFlowCronTable) InsertValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(a.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(a.Spec) == 0 {
+		return errors.New("spec is required")
+	}
+
+	if len(a.Tasks) == 0 {
+		return errors.New("tasks is required")
+	}
+
+	if a.NextRunAt.IsZero() {
+		return errors.New("next_run_at is required")
+	}
+
+	if len(a.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(a.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// CronFlowTask 周期任务流中的任务定义，生成任务流时按照该定义创建任务。
+type CronFlowTask struct {
+	ActionID     string                 `json:"action_id"`
+	ActionName   enumor.ActionName      `json:"action_name"`
+	Params       types.JsonField        `json:"params"`
+	ParamsRender bool                   `json:"params_render"`
+	Retry        *Retry                 `json:"retry"`
+	DependOn     []string               `json:"depend_on"`
+	TimeoutSec   uint                   `json:"timeout_sec"`
+	AllowFailure bool                   `json:"allow_failure"`
+	TriggerRule  enumor.TaskTriggerRule `json:"trigger_rule"`
+	RunCondition *RunCondition          `json:"run_condition"`
+}
+
+// CronFlowTasks define cron flow tasks.
+type CronFlowTasks []CronFlowTask
+
+// Scan is used to decode raw message which is read from db into CronFlowTasks.
+func (c *CronFlowTasks) Scan(raw interface{}) error {
+	return types.Scan(raw, c)
+}
+
+// Value encode the CronFlowTasks to a json raw, so that it can be stored to db with json raw.
+func (c CronFlowTasks) Value() (driver.Value, error) {
+	return types.Value(c)
+}
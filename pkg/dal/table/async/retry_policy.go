@@ -22,11 +22,13 @@ package tableasync
 import (
 	"database/sql/driver"
 	"errors"
-	"fmt"
+	"math"
+	"math/rand"
+	"time"
 
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/dal/table/types"
-	"hcm/pkg/tools/retry"
 )
 
 // Retry define retry relation setting.
@@ -42,35 +44,6 @@ func (r Retry) IsEnable() bool {
 	return r.Enable
 }
 
-// Run retry run func.
-func (r Retry) Run(do func() (result interface{}, err error)) (result interface{}, err error) {
-	if !r.IsEnable() {
-		return nil, errors.New("retry not enable")
-	}
-
-	rp := retry.NewRetryPolicy(r.Policy.Count, r.Policy.SleepRangeMS)
-	var lastErr error
-	var lastResult interface{}
-	for {
-		if rp.RetryCount() == uint32(r.Policy.Count) {
-			break
-		}
-
-		result, err = do()
-		if err != nil {
-			lastErr = err
-			lastResult = result
-			rp.Sleep()
-			continue
-		}
-
-		return result, nil
-	}
-
-	return lastResult, fmt.Errorf("retry exceed the max number of retryable times: %d, lastErr: %v",
-		r.Policy.Count, lastErr)
-}
-
 // Validate retry.
 func (r Retry) Validate() error {
 	if !r.Enable && r.Policy != nil {
@@ -100,15 +73,131 @@ func (r Retry) Value() (driver.Value, error) {
 	return types.Value(r)
 }
 
-// RetryPolicy define retry policy.
+// RetryPolicy define retry policy. 任务执行失败后不会在执行器中等待，而是进入重试等待状态并释放执行器，
+// 到达下次重试时间后由调度器重新执行。
 type RetryPolicy struct {
-	// Count 重试次数
+	// Count 最大执行次数，包括第一次执行
 	Count uint `json:"count" validate:"required"`
-	// SleepRangeMS 重试睡眠周期随机数范围
-	SleepRangeMS [2]uint `json:"sleep_range_ms" validate:"required,min=2"`
+	// SleepRangeMS 固定重试间隔的随机数范围，和Backoff二选一
+	SleepRangeMS [2]uint `json:"sleep_range_ms" validate:"omitempty"`
+	// Backoff 指数退避重试间隔，和SleepRangeMS二选一
+	Backoff *BackoffPolicy `json:"backoff,omitempty" validate:"omitempty"`
+	// DeadlineSec 从第一次执行失败开始计算的重试总时长，超过后不再重试，0表示不限制
+	DeadlineSec uint `json:"deadline_sec" validate:"omitempty"`
+	// RetryableOnly 为true时只重试明确可重试的错误（如云上接口限频），否则除不可重试的错误外都进行重试
+	RetryableOnly bool `json:"retryable_only" validate:"omitempty"`
 }
 
 // Validate RetryPolicy.
 func (rp RetryPolicy) Validate() error {
-	return validator.Validate.Struct(rp)
+	if err := validator.Validate.Struct(rp); err != nil {
+		return err
+	}
+
+	if rp.Backoff != nil {
+		if rp.SleepRangeMS != [2]uint{} {
+			return errors.New("sleep_range_ms and backoff can not set at the same time")
+		}
+
+		return rp.Backoff.Validate()
+	}
+
+	if rp.SleepRangeMS[1] == 0 || rp.SleepRangeMS[0] > rp.SleepRangeMS[1] {
+		return errors.New("sleep_range_ms or backoff is required, sleep_range_ms should be [min, max]")
+	}
+
+	return nil
+}
+
+// NextDelay 返回第attempts次执行失败后，距离下一次重试的等待时间
+func (rp RetryPolicy) NextDelay(attempts uint) time.Duration {
+	if rp.Backoff != nil {
+		return rp.Backoff.Delay(attempts)
+	}
+
+	min, max := rp.SleepRangeMS[0], rp.SleepRangeMS[1]
+	return time.Duration(min+uint(rand.Int63n(int64(max-min+1)))) * time.Millisecond
+}
+
+// DeadlineExceeded 判断从第一次执行失败开始，是否已经超过了重试总时长
+func (rp RetryPolicy) DeadlineExceeded(firstFailedAt, now time.Time) bool {
+	if rp.DeadlineSec == 0 {
+		return false
+	}
+
+	return now.Sub(firstFailedAt) >= time.Duration(rp.DeadlineSec)*time.Second
+}
+
+const (
+	// defaultBackoffMultiplier 指数退避默认的退避倍数
+	defaultBackoffMultiplier = 2
+)
+
+// BackoffPolicy define exponential backoff retry policy. 第n次重试的等待时间为 InitialMS * Multiplier^(n-1)，
+// 最大不超过MaxMS，并在此基础上按照Jitter比例随机减少，避免大量任务同时重试。
+type BackoffPolicy struct {
+	// InitialMS 第一次重试的等待时间
+	InitialMS uint `json:"initial_ms" validate:"required"`
+	// MaxMS 重试的最大等待时间
+	MaxMS uint `json:"max_ms" validate:"required"`
+	// Multiplier 退避倍数，不设置时为2
+	Multiplier float64 `json:"multiplier" validate:"omitempty,gte=1"`
+	// Jitter 随机抖动比例，取值范围[0, 1]，等待时间会在[delay*(1-jitter), delay]之间随机
+	Jitter float64 `json:"jitter" validate:"omitempty,gte=0,lte=1"`
+}
+
+// Validate BackoffPolicy.
+func (bp BackoffPolicy) Validate() error {
+	if err := validator.Validate.Struct(bp); err != nil {
+		return err
+	}
+
+	if bp.InitialMS > bp.MaxMS {
+		return errors.New("backoff initial_ms should <= max_ms")
+	}
+
+	return nil
+}
+
+// Delay 返回第attempts次执行失败后的退避等待时间
+func (bp BackoffPolicy) Delay(attempts uint) time.Duration {
+	multiplier := bp.Multiplier
+	if multiplier == 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+
+	delay := float64(bp.InitialMS)
+	for i := uint(1); i < attempts && delay < float64(bp.MaxMS); i++ {
+		delay *= multiplier
+	}
+	delay = math.Min(delay, float64(bp.MaxMS))
+
+	if bp.Jitter > 0 {
+		delay -= delay * bp.Jitter * rand.Float64()
+	}
+
+	return time.Duration(delay) * time.Millisecond
+}
+
+// RetryState 任务的重试状态，记录已经执行失败的次数和下次重试时间
+type RetryState struct {
+	// Attempts 已经执行失败的次数
+	Attempts uint `json:"attempts"`
+	// FirstFailedAt 第一次执行失败的时间
+	FirstFailedAt string `json:"first_failed_at,omitempty"`
+	// NextAt 下次重试时间
+	NextAt string `json:"next_at,omitempty"`
+	// ResumeState 到达下次重试时间后任务恢复的状态，为空时恢复为回滚状态，先回滚再执行。
+	// 因为限流而等待的任务还没有执行，恢复为等待前的状态即可。
+	ResumeState enumor.TaskState `json:"resume_state,omitempty"`
+}
+
+// Scan is used to decode raw message which is read from db into RetryState.
+func (r *RetryState) Scan(raw interface{}) error {
+	return types.Scan(raw, r)
+}
+
+// Value encode the RetryState to a json raw, so that it can be stored to db with json raw.
+func (r RetryState) Value() (driver.Value, error) {
+	return types.Value(r)
 }
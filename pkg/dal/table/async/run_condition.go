@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+)
+
+// ConditionOp define run condition operator.
+type ConditionOp string
+
+const (
+	// ConditionEqual 共享数据中的值等于Expect
+	ConditionEqual ConditionOp = "eq"
+	// ConditionNotEqual 共享数据中的值不等于Expect，或者共享数据中不存在该键
+	ConditionNotEqual ConditionOp = "neq"
+	// ConditionExist 共享数据中存在该键
+	ConditionExist ConditionOp = "exist"
+	// ConditionNotExist 共享数据中不存在该键
+	ConditionNotExist ConditionOp = "not_exist"
+)
+
+// RunCondition 任务执行条件，根据任务流共享数据判断任务是否执行，不满足条件时任务会被跳过。
+type RunCondition struct {
+	// Key 共享数据的键
+	Key string `json:"key" validate:"required"`
+	// Op 比较操作符
+	Op ConditionOp `json:"op" validate:"required"`
+	// Expect 期望的值，exist、not_exist 操作符不需要设置
+	Expect string `json:"expect"`
+}
+
+// Validate RunCondition.
+func (c RunCondition) Validate() error {
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	switch c.Op {
+	case ConditionEqual, ConditionNotEqual:
+	case ConditionExist, ConditionNotExist:
+		if len(c.Expect) != 0 {
+			return fmt.Errorf("run condition op: %s not support expect", c.Op)
+		}
+	default:
+		return fmt.Errorf("unsupported run condition op: %s", c.Op)
+	}
+
+	return nil
+}
+
+// Match 根据共享数据判断是否满足执行条件，get 为共享数据的查询函数。
+func (c RunCondition) Match(get func(key string) (string, bool)) (bool, error) {
+	val, exist := get(c.Key)
+
+	switch c.Op {
+	case ConditionEqual:
+		return exist && val == c.Expect, nil
+	case ConditionNotEqual:
+		return !exist || val != c.Expect, nil
+	case ConditionExist:
+		return exist, nil
+	case ConditionNotExist:
+		return !exist, nil
+	default:
+		return false, errors.New("unsupported run condition op: " + string(c.Op))
+	}
+}
+
+// Scan is used to decode raw message which is read from db into RunCondition.
+func (c *RunCondition) Scan(raw interface{}) error {
+	return types.Scan(raw, c)
+}
+
+// Value encode the RunCondition to a json raw, so that it can be stored to db with json raw.
+func (c RunCondition) Value() (driver.Value, error) {
+	return types.Value(c)
+}
@@ -84,6 +84,23 @@ func (d *ShareData) Get(key string) (string, bool) {
 	return v, ok
 }
 
+// Copy return a copy of all share data, it is thread-safe.
+func (d *ShareData) Copy() map[string]string {
+	if d == nil {
+		return make(map[string]string)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	dict := make(map[string]string, len(d.Dict))
+	for key, val := range d.Dict {
+		dict[key] = val
+	}
+
+	return dict
+}
+
 // Set value to share data, it is thread-safe.
 func (d *ShareData) Set(kt *kit.Kit, key string, val string) error {
 	d.mutex.Lock()
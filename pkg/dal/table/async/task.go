@@ -40,11 +40,19 @@ var AsyncFlowTaskTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "action_id", NamedC: "action_id", Type: enumor.String},
 	{Column: "action_name", NamedC: "action_name", Type: enumor.String},
 	{Column: "params", NamedC: "params", Type: enumor.Json},
+	{Column: "params_render", NamedC: "params_render", Type: enumor.Boolean},
 	{Column: "retry", NamedC: "retry", Type: enumor.Json},
 	{Column: "depend_on", NamedC: "depend_on", Type: enumor.Json},
+	{Column: "timeout_sec", NamedC: "timeout_sec", Type: enumor.Numeric},
+	{Column: "allow_failure", NamedC: "allow_failure", Type: enumor.Boolean},
+	{Column: "trigger_rule", NamedC: "trigger_rule", Type: enumor.String},
+	{Column: "run_condition", NamedC: "run_condition", Type: enumor.Json},
+	{Column: "parent_id", NamedC: "parent_id", Type: enumor.String},
 	{Column: "state", NamedC: "state", Type: enumor.String},
 	{Column: "reason", NamedC: "reason", Type: enumor.Json},
 	{Column: "result", NamedC: "result", Type: enumor.Json},
+	{Column: "logs", NamedC: "logs", Type: enumor.Json},
+	{Column: "retry_state", NamedC: "retry_state", Type: enumor.Json},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -53,21 +61,29 @@ var AsyncFlowTaskTableColumnDescriptor = utils.ColumnDescriptors{
 
 // AsyncFlowTaskTable define async_flow_task table.
 type AsyncFlowTaskTable struct {
-	ID         string            `db:"id" json:"id" validate:"lte=64"`
-	FlowID     string            `db:"flow_id" json:"flow_id"`
-	FlowName   enumor.FlowName   `db:"flow_name" json:"flow_name"`
-	ActionID   string            `db:"action_id" json:"action_id"`
-	ActionName enumor.ActionName `db:"action_name" json:"action_name"`
-	Params     types.JsonField   `db:"params" json:"params"`
-	Retry      *Retry            `db:"retry" json:"retry"`
-	DependOn   types.StringArray `db:"depend_on" json:"depend_on"`
-	State      enumor.TaskState  `db:"state" json:"state"`
-	Reason     *Reason           `db:"reason" json:"reason"`
-	Result     types.JsonField   `db:"result" json:"result"`
-	Creator    string            `db:"creator" json:"creator" validate:"lte=64"`
-	Reviser    string            `db:"reviser" json:"reviser" validate:"lte=64"`
-	CreatedAt  types.Time        `db:"created_at" json:"created_at" validate:"excluded_unless"`
-	UpdatedAt  types.Time        `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+	ID           string                 `db:"id" json:"id" validate:"lte=64"`
+	FlowID       string                 `db:"flow_id" json:"flow_id"`
+	FlowName     enumor.FlowName        `db:"flow_name" json:"flow_name"`
+	ActionID     string                 `db:"action_id" json:"action_id"`
+	ActionName   enumor.ActionName      `db:"action_name" json:"action_name"`
+	Params       types.JsonField        `db:"params" json:"params"`
+	ParamsRender *bool                  `db:"params_render" json:"params_render"`
+	Retry        *Retry                 `db:"retry" json:"retry"`
+	DependOn     types.StringArray      `db:"depend_on" json:"depend_on"`
+	TimeoutSec   uint                   `db:"timeout_sec" json:"timeout_sec"`
+	AllowFailure *bool                  `db:"allow_failure" json:"allow_failure"`
+	TriggerRule  enumor.TaskTriggerRule `db:"trigger_rule" json:"trigger_rule"`
+	RunCondition *RunCondition          `db:"run_condition" json:"run_condition"`
+	ParentID     string                 `db:"parent_id" json:"parent_id"`
+	State        enumor.TaskState       `db:"state" json:"state"`
+	Reason       *Reason                `db:"reason" json:"reason"`
+	Result       types.JsonField        `db:"result" json:"result"`
+	Logs         TaskLogs               `db:"logs" json:"logs"`
+	RetryState   *RetryState            `db:"retry_state" json:"retry_state"`
+	Creator      string                 `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser      string                 `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt    types.Time             `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt    types.Time             `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
 }
 
 // TableName return async_flow_task table name.
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"unicode/utf8"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+)
+
+const (
+	// MaxTaskLogEntries 每个任务保存的最大日志条数，超过后丢弃最早的日志
+	MaxTaskLogEntries = 200
+	// MaxTaskLogMessageLen 单条任务日志的最大长度，超过后截断
+	MaxTaskLogMessageLen = 1024
+)
+
+// TaskLogEntry define task log entry.
+type TaskLogEntry struct {
+	Level   enumor.TaskLogLevel `json:"level"`
+	Message string              `json:"message"`
+	// Time 日志记录时间，标准格式：2006-01-02T15:04:05Z07:00
+	Time string `json:"time"`
+}
+
+// TaskLogs define task logs, sorted by time asc.
+type TaskLogs []TaskLogEntry
+
+// Append 追加任务日志，超长的日志会被截断，超过最大条数时丢弃最早的日志，返回新的任务日志。
+func (l TaskLogs) Append(entries ...TaskLogEntry) TaskLogs {
+	result := make(TaskLogs, 0, len(l)+len(entries))
+	result = append(result, l...)
+	for _, one := range entries {
+		if len(one.Message) > MaxTaskLogMessageLen {
+			// 在字符边界处截断，避免截断多字节字符产生非法的UTF-8内容
+			end := MaxTaskLogMessageLen
+			for end > 0 && !utf8.RuneStart(one.Message[end]) {
+				end--
+			}
+			one.Message = fmt.Sprintf("%s...(truncated)", one.Message[:end])
+		}
+		result = append(result, one)
+	}
+
+	if len(result) > MaxTaskLogEntries {
+		result = result[len(result)-MaxTaskLogEntries:]
+	}
+
+	return result
+}
+
+// Scan is used to decode raw message which is read from db into TaskLogs.
+func (l *TaskLogs) Scan(raw interface{}) error {
+	return types.Scan(raw, l)
+}
+
+// Value encode the TaskLogs to a json raw, so that it can be stored to db with json raw.
+func (l TaskLogs) Value() (driver.Value, error) {
+	return types.Value(l)
+}
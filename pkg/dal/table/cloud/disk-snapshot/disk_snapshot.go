@@ -0,0 +1,154 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablesnapshot defines disk snapshot table.
+package tablesnapshot
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// DiskSnapshotColumns defines all the disk snapshot table's columns.
+var DiskSnapshotColumns = utils.MergeColumns(nil, DiskSnapshotColumnDescriptor)
+
+// DiskSnapshotColumnDescriptor is disk snapshot's column descriptors.
+var DiskSnapshotColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "zone", NamedC: "zone", Type: enumor.String},
+	{Column: "disk_id", NamedC: "disk_id", Type: enumor.String},
+	{Column: "cloud_disk_id", NamedC: "cloud_disk_id", Type: enumor.String},
+	{Column: "size", NamedC: "size", Type: enumor.Numeric},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// DiskSnapshotTable 云盘快照表
+type DiskSnapshotTable struct {
+	// ID 快照ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// CloudID 云上快照ID，azure为快照的资源ID
+	CloudID string `db:"cloud_id" json:"cloud_id" validate:"lte=255"`
+	// Name 快照名称
+	Name string `db:"name" json:"name" validate:"lte=255"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// BkBizID 业务ID
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Region 地域
+	Region string `db:"region" json:"region" validate:"lte=20"`
+	// Zone 可用区
+	Zone string `db:"zone" json:"zone" validate:"lte=64"`
+	// DiskID 源云盘ID，源云盘不在hcm中时为空
+	DiskID string `db:"disk_id" json:"disk_id" validate:"lte=64"`
+	// CloudDiskID 源云盘的云上ID
+	CloudDiskID string `db:"cloud_disk_id" json:"cloud_disk_id" validate:"lte=255"`
+	// Size 快照大小，单位GB
+	Size uint64 `db:"size" json:"size"`
+	// Status 快照状态
+	Status string `db:"status" json:"status" validate:"lte=32"`
+	// CloudCreatedTime 云上创建时间
+	CloudCreatedTime string `db:"cloud_created_time" json:"cloud_created_time" validate:"lte=64"`
+	// Extension 云厂商差异扩展字段
+	Extension types.JsonField `db:"extension" json:"extension"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"lte=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"lte=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName return disk snapshot table name.
+func (t DiskSnapshotTable) TableName() table.Name {
+	return table.DiskSnapshotTable
+}
+
+// InsertValidate validate disk snapshot table on insert.
+func (t DiskSnapshotTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.Extension) == 0 {
+		return errors.New("extension is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate disk snapshot table on update.
+func (t DiskSnapshotTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.UpdatedAt) != 0 {
+		return errors.New("updated_at can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
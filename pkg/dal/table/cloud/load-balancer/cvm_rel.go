@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tablelb
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// CvmRelColumns defines all the load balancer cvm relation table's columns.
+var CvmRelColumns = utils.MergeColumns(utils.InsertWithoutPrimaryID, CvmRelColumnDescriptor)
+
+// CvmRelColumnDescriptor is load balancer cvm relation's column descriptors.
+var CvmRelColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "lb_id", NamedC: "lb_id", Type: enumor.String},
+	{Column: "cvm_id", NamedC: "cvm_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// CvmRelTable 负载均衡和主机关联关系表，主机作为负载均衡的后端服务时建立关联
+type CvmRelTable struct {
+	ID        uint64     `db:"id" json:"id"`
+	LbID      string     `db:"lb_id" validate:"required,lte=64" json:"lb_id"`
+	CvmID     string     `db:"cvm_id" validate:"required,lte=64" json:"cvm_id"`
+	Creator   string     `db:"creator" validate:"required,lte=64" json:"creator"`
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+}
+
+// TableName return load balancer cvm relation table name.
+func (t CvmRelTable) TableName() table.Name {
+	return table.LoadBalancerCvmRelTable
+}
+
+// InsertValidate validate load balancer cvm relation table on insert.
+func (t CvmRelTable) InsertValidate() error {
+	return validator.Validate.Struct(t)
+}
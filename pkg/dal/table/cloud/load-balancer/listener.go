@@ -0,0 +1,146 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tablelb
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ListenerColumns defines all the load balancer listener table's columns.
+var ListenerColumns = utils.MergeColumns(nil, ListenerColumnDescriptor)
+
+// ListenerColumnDescriptor is load balancer listener's column descriptors.
+var ListenerColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "lb_id", NamedC: "lb_id", Type: enumor.String},
+	{Column: "cloud_lb_id", NamedC: "cloud_lb_id", Type: enumor.String},
+	{Column: "protocol", NamedC: "protocol", Type: enumor.String},
+	{Column: "port", NamedC: "port", Type: enumor.Numeric},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ListenerTable 负载均衡监听器表
+type ListenerTable struct {
+	// ID 监听器ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// CloudID 云上监听器ID，aws为监听器arn
+	CloudID string `db:"cloud_id" json:"cloud_id" validate:"lte=255"`
+	// Name 监听器名称
+	Name string `db:"name" json:"name" validate:"lte=255"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// LbID 所属负载均衡ID
+	LbID string `db:"lb_id" json:"lb_id" validate:"lte=64"`
+	// CloudLbID 所属负载均衡云上ID
+	CloudLbID string `db:"cloud_lb_id" json:"cloud_lb_id" validate:"lte=255"`
+	// Protocol 监听协议
+	Protocol string `db:"protocol" json:"protocol" validate:"lte=32"`
+	// Port 监听端口
+	Port int64 `db:"port" json:"port"`
+	// Extension 云厂商差异扩展字段
+	Extension types.JsonField `db:"extension" json:"extension"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"lte=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"lte=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName return load balancer listener table name.
+func (t ListenerTable) TableName() table.Name {
+	return table.LoadBalancerListenerTable
+}
+
+// InsertValidate validate load balancer listener table on insert.
+func (t ListenerTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.LbID) == 0 || len(t.CloudLbID) == 0 {
+		return errors.New("lb_id and cloud_lb_id is required")
+	}
+
+	if len(t.Extension) == 0 {
+		return errors.New("extension is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate load balancer listener table on update.
+func (t ListenerTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.LbID) != 0 || len(t.CloudLbID) != 0 {
+		return errors.New("lb_id and cloud_lb_id can not update")
+	}
+
+	if len(t.UpdatedAt) != 0 {
+		return errors.New("updated_at can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
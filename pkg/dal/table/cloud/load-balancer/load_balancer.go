@@ -0,0 +1,170 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablelb defines load balancer, listener, target group, target and load balancer cvm relation tables.
+package tablelb
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// LoadBalancerColumns defines all the load balancer table's columns.
+var LoadBalancerColumns = utils.MergeColumns(nil, LoadBalancerColumnDescriptor)
+
+// LoadBalancerColumnDescriptor is load balancer's column descriptors.
+var LoadBalancerColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "zones", NamedC: "zones", Type: enumor.Json},
+	{Column: "vpc_id", NamedC: "vpc_id", Type: enumor.String},
+	{Column: "cloud_vpc_id", NamedC: "cloud_vpc_id", Type: enumor.String},
+	{Column: "network_type", NamedC: "network_type", Type: enumor.String},
+	{Column: "ip_version", NamedC: "ip_version", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "domain", NamedC: "domain", Type: enumor.String},
+	{Column: "public_ips", NamedC: "public_ips", Type: enumor.Json},
+	{Column: "private_ips", NamedC: "private_ips", Type: enumor.Json},
+	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// LoadBalancerTable 负载均衡表
+type LoadBalancerTable struct {
+	// ID 负载均衡ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// CloudID 云上负载均衡ID，aws为负载均衡arn
+	CloudID string `db:"cloud_id" json:"cloud_id" validate:"lte=255"`
+	// Name 负载均衡名称
+	Name string `db:"name" json:"name" validate:"lte=255"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// BkBizID 业务ID
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Region 地域
+	Region string `db:"region" json:"region" validate:"lte=20"`
+	// Zones 可用区
+	Zones types.StringArray `db:"zones" json:"zones"`
+	// VpcID 负载均衡所在vpc的ID
+	VpcID string `db:"vpc_id" json:"vpc_id" validate:"lte=64"`
+	// CloudVpcID 负载均衡所在vpc的云上ID
+	CloudVpcID string `db:"cloud_vpc_id" json:"cloud_vpc_id" validate:"lte=255"`
+	// NetworkType 网络类型，公网或内网
+	NetworkType enumor.LoadBalancerNetworkType `db:"network_type" json:"network_type" validate:"lte=16"`
+	// IPVersion IP版本
+	IPVersion string `db:"ip_version" json:"ip_version" validate:"lte=32"`
+	// Status 状态
+	Status string `db:"status" json:"status" validate:"lte=32"`
+	// Domain 域名
+	Domain string `db:"domain" json:"domain" validate:"lte=255"`
+	// PublicIPs 公网VIP
+	PublicIPs types.StringArray `db:"public_ips" json:"public_ips"`
+	// PrivateIPs 内网VIP
+	PrivateIPs types.StringArray `db:"private_ips" json:"private_ips"`
+	// CloudCreatedTime 云上创建时间
+	CloudCreatedTime string `db:"cloud_created_time" json:"cloud_created_time" validate:"lte=64"`
+	// Extension 云厂商差异扩展字段
+	Extension types.JsonField `db:"extension" json:"extension"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"lte=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"lte=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName return load balancer table name.
+func (t LoadBalancerTable) TableName() table.Name {
+	return table.LoadBalancerTable
+}
+
+// InsertValidate validate load balancer table on insert.
+func (t LoadBalancerTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.Region) == 0 {
+		return errors.New("region is required")
+	}
+
+	if len(t.Extension) == 0 {
+		return errors.New("extension is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate load balancer table on update.
+func (t LoadBalancerTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.UpdatedAt) != 0 {
+		return errors.New("updated_at can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
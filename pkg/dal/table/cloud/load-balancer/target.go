@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tablelb
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// TargetColumns defines all the load balancer target table's columns.
+var TargetColumns = utils.MergeColumns(nil, TargetColumnDescriptor)
+
+// TargetColumnDescriptor is load balancer target's column descriptors.
+var TargetColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "lb_id", NamedC: "lb_id", Type: enumor.String},
+	{Column: "cloud_lb_id", NamedC: "cloud_lb_id", Type: enumor.String},
+	{Column: "listener_id", NamedC: "listener_id", Type: enumor.String},
+	{Column: "cloud_listener_id", NamedC: "cloud_listener_id", Type: enumor.String},
+	{Column: "cloud_rule_id", NamedC: "cloud_rule_id", Type: enumor.String},
+	{Column: "target_group_id", NamedC: "target_group_id", Type: enumor.String},
+	{Column: "cloud_target_group_id", NamedC: "cloud_target_group_id", Type: enumor.String},
+	{Column: "inst_type", NamedC: "inst_type", Type: enumor.String},
+	{Column: "cloud_inst_id", NamedC: "cloud_inst_id", Type: enumor.String},
+	{Column: "private_ip_addresses", NamedC: "private_ip_addresses", Type: enumor.Json},
+	{Column: "public_ip_addresses", NamedC: "public_ip_addresses", Type: enumor.Json},
+	{Column: "port", NamedC: "port", Type: enumor.Numeric},
+	{Column: "weight", NamedC: "weight", Type: enumor.Numeric},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// TargetTable 负载均衡后端服务表，腾讯云后端服务绑定在监听器或转发规则上，aws后端服务注册在目标组上。
+type TargetTable struct {
+	// ID 后端服务ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// CloudID 后端服务在云上没有ID，由所属监听器/转发规则(或目标组)、实例和端口拼接而成，用于同步时比对
+	CloudID string `db:"cloud_id" json:"cloud_id" validate:"lte=1024"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" json:"account_id" validate:"lte=64"`
+	// LbID 所属负载均衡ID，aws目标组未关联负载均衡时为空
+	LbID string `db:"lb_id" json:"lb_id" validate:"lte=64"`
+	// CloudLbID 所属负载均衡云上ID
+	CloudLbID string `db:"cloud_lb_id" json:"cloud_lb_id" validate:"lte=255"`
+	// ListenerID 所属监听器ID
+	ListenerID string `db:"listener_id" json:"listener_id" validate:"lte=64"`
+	// CloudListenerID 所属监听器云上ID
+	CloudListenerID string `db:"cloud_listener_id" json:"cloud_listener_id" validate:"lte=255"`
+	// CloudRuleID 所属转发规则云上ID，仅腾讯云七层监听器有值
+	CloudRuleID string `db:"cloud_rule_id" json:"cloud_rule_id" validate:"lte=255"`
+	// TargetGroupID 所属目标组ID
+	TargetGroupID string `db:"target_group_id" json:"target_group_id" validate:"lte=64"`
+	// CloudTargetGroupID 所属目标组云上ID
+	CloudTargetGroupID string `db:"cloud_target_group_id" json:"cloud_target_group_id" validate:"lte=255"`
+	// InstType 后端服务类型
+	InstType enumor.TargetInstType `db:"inst_type" json:"inst_type" validate:"lte=16"`
+	// CloudInstID 后端服务实例云上ID，后端服务为IP时为IP地址
+	CloudInstID string `db:"cloud_inst_id" json:"cloud_inst_id" validate:"lte=255"`
+	// PrivateIPAddresses 后端服务内网IP
+	PrivateIPAddresses types.StringArray `db:"private_ip_addresses" json:"private_ip_addresses"`
+	// PublicIPAddresses 后端服务公网IP
+	PublicIPAddresses types.StringArray `db:"public_ip_addresses" json:"public_ip_addresses"`
+	// Port 后端服务端口
+	Port int64 `db:"port" json:"port"`
+	// Weight 后端服务权重
+	Weight *int64 `db:"weight" json:"weight"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"lte=64"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" json:"reviser" validate:"lte=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName return load balancer target table name.
+func (t TargetTable) TableName() table.Name {
+	return table.LoadBalancerTargetTable
+}
+
+// InsertValidate validate load balancer target table on insert.
+func (t TargetTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.CloudListenerID) == 0 && len(t.CloudTargetGroupID) == 0 {
+		return errors.New("one of cloud_listener_id and cloud_target_group_id is required")
+	}
+
+	if len(t.CloudInstID) == 0 {
+		return errors.New("cloud_inst_id is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate load balancer target table on update.
+func (t TargetTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.CloudID) != 0 {
+		return errors.New("cloud_id can not update")
+	}
+
+	if len(t.UpdatedAt) != 0 {
+		return errors.New("updated_at can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
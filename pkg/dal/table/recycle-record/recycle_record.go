@@ -155,7 +155,7 @@ func (r RecycleRecordTable) UpdateValidate() error {
 		return err
 	}
 
-	if len(r.Status) == 0 && len(r.Detail) == 0 {
+	if len(r.Status) == 0 && len(r.Detail) == 0 && r.RecycledAt.IsZero() {
 		return errors.New("one of the update fields must be set")
 	}
 
@@ -127,6 +127,19 @@ const (
 	// AccountBillConfigTable is account bill config table's name.
 	AccountBillConfigTable Name = "account_bill_config"
 
+	// LoadBalancerTable is load balancer table's name.
+	LoadBalancerTable Name = "load_balancer"
+	// LoadBalancerListenerTable is load balancer listener table's name.
+	LoadBalancerListenerTable Name = "load_balancer_listener"
+	// LoadBalancerTargetGroupTable is load balancer target group table's name.
+	LoadBalancerTargetGroupTable Name = "load_balancer_target_group"
+	// LoadBalancerTargetTable is load balancer backend target table's name.
+	LoadBalancerTargetTable Name = "load_balancer_target"
+	// LoadBalancerCvmRelTable is load balancer and cvm rel table's name.
+	LoadBalancerCvmRelTable Name = "load_balancer_cvm_rel"
+	// DiskSnapshotTable is disk snapshot table's name.
+	DiskSnapshotTable Name = "disk_snapshot"
+
 	// RecycleRecordTableTaskID is recycle record table's task id.
 	// TODO: 之后考虑非表id的id_generator如何更优雅的使用
 	RecycleRecordTableTaskID Name = "recycle_record_task_id"
@@ -138,6 +151,18 @@ const (
 	AsyncFlowTable Name = "async_flow"
 	// AsyncFlowTaskTable is async flow task table's name.
 	AsyncFlowTaskTable Name = "async_flow_task"
+	// AsyncFlowCronTable is async flow cron table's name.
+	AsyncFlowCronTable Name = "async_flow_cron"
+	// AsyncFlowCallbackTable is async flow callback delivery table's name.
+	AsyncFlowCallbackTable Name = "async_flow_callback"
+	// AsyncFlowArchiveTable is archived async flow table's name.
+	AsyncFlowArchiveTable Name = "async_flow_archive"
+	// AsyncFlowTaskArchiveTable is archived async flow task table's name.
+	AsyncFlowTaskArchiveTable Name = "async_flow_task_archive"
+	// AsyncRateLimitBucketTable is async task rate limit token bucket table's name.
+	AsyncRateLimitBucketTable Name = "async_rate_limit_bucket"
+	// AsyncLeaderFenceTable is async leader fencing token table's name.
+	AsyncLeaderFenceTable Name = "async_leader_fence"
 )
 
 // Validate whether the table name is valid or not.
@@ -201,12 +226,24 @@ var TableMap = map[Name]struct{}{
 	AccountBillConfigTable:       {},
 	UserCollectionTable:          {},
 	AccountSyncDetailTable:       {},
+	LoadBalancerTable:            {},
+	LoadBalancerListenerTable:    {},
+	LoadBalancerTargetGroupTable: {},
+	LoadBalancerTargetTable:      {},
+	LoadBalancerCvmRelTable:      {},
+	DiskSnapshotTable:            {},
 
 	// TODO: 临时方案
 	RecycleRecordTableTaskID: {},
 
-	AsyncFlowTable:     {},
-	AsyncFlowTaskTable: {},
+	AsyncFlowTable:            {},
+	AsyncFlowTaskTable:        {},
+	AsyncFlowCronTable:        {},
+	AsyncFlowCallbackTable:    {},
+	AsyncFlowArchiveTable:     {},
+	AsyncFlowTaskArchiveTable: {},
+	AsyncRateLimitBucketTable: {},
+	AsyncLeaderFenceTable:     {},
 }
 
 // Register 注册表名
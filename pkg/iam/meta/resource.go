@@ -57,6 +57,10 @@ const (
 	RecycleBin ResourceType = "recycle_bin"
 	// NetworkInterface defines eip hcm network_interface resource type
 	NetworkInterface ResourceType = "network_interface"
+	// LoadBalancer defines load balancer's hcm auth resource type
+	LoadBalancer ResourceType = "load_balancer"
+	// DiskSnapshot defines disk snapshot's hcm auth resource type
+	DiskSnapshot ResourceType = "disk_snapshot"
 	// Audit defines audit log's hcm auth resource type
 	Audit ResourceType = "biz_audit"
 	// Biz defines biz's hcm auth resource type
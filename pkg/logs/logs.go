@@ -49,7 +49,8 @@ var (
 	Infof      = glog.Infof
 	InfoDepthf = glog.InfoDepthf
 
-	Warnf = glog.Warningf
+	Warnf      = glog.Warningf
+	WarnDepthf = glog.WarningDepthf
 
 	Errorf = glog.Errorf
 	// ErrorJson compared with other log output methods, this method consumes
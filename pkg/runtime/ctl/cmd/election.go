@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+)
+
+// WithLeaderHandover init and returns the leader handover command.
+func WithLeaderHandover(el serviced.Election) Cmd {
+	cmd := &electionCmd{
+		cmd: &Command{
+			Name:  "leader-handover",
+			Usage: "resign leadership of current node so that another node takes over as leader",
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				if err := el.Resign(); err != nil {
+					logs.Errorf("resign leadership of current node failed, err: %v, rid: %s", err, kt.Rid)
+					return nil, err
+				}
+
+				logs.Infof("successfully resigned leadership of current node, rid: %s", kt.Rid)
+				return nil, nil
+			},
+		},
+		el: el,
+	}
+
+	return cmd
+}
+
+// electionCmd leader election related Cmd.
+type electionCmd struct {
+	cmd *Command
+	el  serviced.Election
+}
+
+// GetCommand get leader election related Command.
+func (c *electionCmd) GetCommand() *Command {
+	return c.cmd
+}
+
+// Validate leader election related Command.
+func (c *electionCmd) Validate() error {
+	if c.el == nil {
+		return errors.New("election is not set")
+	}
+
+	return c.cmd.Validate()
+}
@@ -21,7 +21,9 @@ package serviced
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 
 	"hcm/pkg/cc"
@@ -42,6 +44,8 @@ type Discover interface {
 type Node interface {
 	// GetServiceAllNodeKeys 获取当前服务全部节点Key
 	GetServiceAllNodeKeys(name cc.Name) ([]string, error)
+	// GetServiceAllNodeLabels 获取当前服务全部注册了标签的节点的标签，key为节点的UID
+	GetServiceAllNodeLabels(name cc.Name) (map[string]map[string]string, error)
 }
 
 // NewDiscovery create a service discovery instance.
@@ -102,6 +106,28 @@ func (d *discovery) GetServiceAllNodeKeys(name cc.Name) ([]string, error) {
 	return keys, nil
 }
 
+// GetServiceAllNodeLabels 获取当前服务全部注册了标签的节点的标签，key为节点的UID
+func (d *discovery) GetServiceAllNodeLabels(name cc.Name) (map[string]map[string]string, error) {
+
+	resp, err := d.cli.Get(context.Background(), ServiceLabelName(name)+"/", etcd3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]map[string]string, len(resp.Kvs))
+	for _, one := range resp.Kvs {
+		nodeLabels := make(map[string]string)
+		if err = json.Unmarshal(one.Value, &nodeLabels); err != nil {
+			return nil, fmt.Errorf("unmarshal node labels failed, key: %s, err: %v", one.Key, err)
+		}
+
+		split := strings.Split(string(one.Key), "/")
+		labels[split[len(split)-1]] = nodeLabels
+	}
+
+	return labels, nil
+}
+
 // Services returns the being discovered services
 func (d *discovery) Services() []cc.Name {
 	return d.discOpt.Services
@@ -0,0 +1,272 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package serviced
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/logs"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// Election defines the lease based leader election related operations. each leader term has a fencing token
+// which increases monotonically, so that the operations of a stale leader can be rejected.
+type Election interface {
+	// IsLeader test if this service instance is leader or not.
+	IsLeader() bool
+	// FencingToken return the fencing token of current leader term, 0 means this service instance is not leader.
+	FencingToken() int64
+	// Changed return a channel which is notified when the leadership of this service instance changes.
+	Changed() <-chan struct{}
+	// Resign give up the leadership and campaign again, so that other service instances can take over.
+	Resign() error
+	// Close stop campaigning and give up the leadership.
+	Close()
+}
+
+// ElectionOption defines the leader election related options.
+type ElectionOption struct {
+	Name cc.Name
+	// Uid is a service's unique identity.
+	Uid string
+	// TTLSec is the ttl of election lease, the leader is deemed to be lost after ttl if it is crashed.
+	TTLSec int
+}
+
+// Validate the election option
+func (eo ElectionOption) Validate() error {
+	if len(eo.Name) == 0 {
+		return errors.New("service name is empty")
+	}
+
+	if len(eo.Uid) == 0 {
+		return errors.New("invalid service uid")
+	}
+
+	if eo.TTLSec < 0 {
+		return errors.New("invalid election ttl")
+	}
+
+	return nil
+}
+
+// ElectionName return the service's leader election path in etcd.
+func ElectionName(serviceName cc.Name) string {
+	return fmt.Sprintf("/hcm/election/%s", serviceName)
+}
+
+// NewElection create a leader election instance, and start to campaign.
+func NewElection(config cc.Service, opt ElectionOption) (Election, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opt.TTLSec == 0 {
+		opt.TTLSec = defaultGrantLeaseTTL
+	}
+
+	etcdOpt, err := config.Etcd.ToConfig()
+	if err != nil {
+		return nil, fmt.Errorf("get etcd config failed, err: %v", err)
+	}
+
+	cli, err := etcd3.New(etcdOpt)
+	if err != nil {
+		return nil, fmt.Errorf("new etcd client failed, err: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &election{
+		cli:       cli,
+		opt:       opt,
+		changedCh: make(chan struct{}, 1),
+		resignCh:  make(chan struct{}, 1),
+		doneCh:    make(chan struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	go e.run()
+
+	return e, nil
+}
+
+type election struct {
+	cli *etcd3.Client
+	opt ElectionOption
+
+	// isLeaderFlag service instance leader state, token is the fencing token of current leader term.
+	isLeaderFlag bool
+	token        int64
+	stateRWMux   sync.RWMutex
+
+	changedCh chan struct{}
+	resignCh  chan struct{}
+	doneCh    chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// IsLeader test if this service instance is leader or not.
+func (e *election) IsLeader() bool {
+	e.stateRWMux.RLock()
+	defer e.stateRWMux.RUnlock()
+
+	return e.isLeaderFlag
+}
+
+// FencingToken return the fencing token of current leader term, 0 means this service instance is not leader.
+func (e *election) FencingToken() int64 {
+	e.stateRWMux.RLock()
+	defer e.stateRWMux.RUnlock()
+
+	return e.token
+}
+
+// Changed return a channel which is notified when the leadership of this service instance changes.
+func (e *election) Changed() <-chan struct{} {
+	return e.changedCh
+}
+
+// Resign give up the leadership and campaign again, so that other service instances can take over.
+func (e *election) Resign() error {
+	if !e.IsLeader() {
+		return errors.New("current service instance is not leader")
+	}
+
+	select {
+	case e.resignCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Close stop campaigning and give up the leadership.
+func (e *election) Close() {
+	e.cancel()
+	<-e.doneCh
+
+	if err := e.cli.Close(); err != nil {
+		logs.Errorf("close election etcd client failed, err: %v", err)
+	}
+}
+
+// run keep campaigning until the election is closed.
+func (e *election) run() {
+	defer close(e.doneCh)
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		default:
+		}
+
+		if err := e.campaign(); err != nil {
+			logs.Errorf("service: %s campaign leader failed, err: %v", e.opt.Name, err)
+			time.Sleep(defaultErrSleepTime)
+		}
+	}
+}
+
+// campaign create a lease session and campaign for leader, after elected, keep the leadership until the lease
+// session is expired, the leadership is resigned or the election is closed.
+func (e *election) campaign() error {
+	session, err := concurrency.NewSession(e.cli, concurrency.WithTTL(e.opt.TTLSec), concurrency.WithContext(e.ctx))
+	if err != nil {
+		return fmt.Errorf("new election session failed, err: %v", err)
+	}
+	defer session.Close()
+
+	// campaign blocks until elected, it should be canceled when the lease session is expired.
+	ctx, cancel := context.WithCancel(e.ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-session.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	el := concurrency.NewElection(session, ElectionName(e.opt.Name))
+	if err = el.Campaign(ctx, e.opt.Uid); err != nil {
+		return fmt.Errorf("campaign failed, err: %v", err)
+	}
+
+	// drop the resign request which is sent in the last leader term.
+	select {
+	case <-e.resignCh:
+	default:
+	}
+
+	// the create revision of leader key increases monotonically with each leader term, use it as fencing token.
+	e.updateLeaderState(true, el.Rev())
+	logs.Infof("service: %s become leader, fencing token: %d", e.opt.Name, el.Rev())
+
+	select {
+	case <-session.Done():
+		e.updateLeaderState(false, 0)
+		return errors.New("election session is expired, leadership is lost")
+
+	case <-e.resignCh:
+		logs.Infof("service: %s resign leadership, fencing token: %d", e.opt.Name, el.Rev())
+
+	case <-e.ctx.Done():
+	}
+
+	// stop to act as leader before the leader key is deleted, so that it will not overlap with the next leader.
+	e.updateLeaderState(false, 0)
+
+	resignCtx, resignCancel := context.WithTimeout(context.Background(), defaultErrSleepTime*5)
+	defer resignCancel()
+	if err = el.Resign(resignCtx); err != nil {
+		return fmt.Errorf("resign leadership failed, err: %v", err)
+	}
+
+	return nil
+}
+
+// updateLeaderState update leader state and notify the leadership changes.
+func (e *election) updateLeaderState(isLeader bool, token int64) {
+	e.stateRWMux.Lock()
+	changed := e.isLeaderFlag != isLeader
+	e.isLeaderFlag = isLeader
+	e.token = token
+	e.stateRWMux.Unlock()
+
+	if !changed {
+		return
+	}
+
+	select {
+	case e.changedCh <- struct{}{}:
+	default:
+	}
+}
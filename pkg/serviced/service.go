@@ -21,6 +21,7 @@ package serviced
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -146,6 +147,9 @@ func (s *service) Register() error {
 		logs.Errorf("put kv with lease failed, key: %s, value: %s, err: %v", key, value, err)
 		return err
 	}
+	if err = s.putLabels(leaseResp.ID); err != nil {
+		return err
+	}
 	s.updateLeaseID(leaseResp.ID)
 	s.updateRegisterFlag(true)
 
@@ -179,6 +183,10 @@ func (s *service) keepAlive(key string, value string) {
 						time.Sleep(defaultErrSleepTime)
 						continue
 					}
+					if err = s.putLabels(leaseResp.ID); err != nil {
+						time.Sleep(defaultErrSleepTime)
+						continue
+					}
 
 					s.updateRegisterFlag(true)
 					s.updateLeaseID(leaseResp.ID)
@@ -209,6 +217,28 @@ func (s *service) keepAlive(key string, value string) {
 	}()
 }
 
+// putLabels put service node's labels with the same lease as service key, so that labels are removed when the
+// service node is offline.
+func (s *service) putLabels(leaseID etcd3.LeaseID) error {
+	if len(s.svcOpt.Labels) == 0 {
+		return nil
+	}
+
+	labelKey := key(ServiceLabelName(s.svcOpt.Name), s.svcOpt.Uid)
+	value, err := json.Marshal(s.svcOpt.Labels)
+	if err != nil {
+		logs.Errorf("marshal service labels failed, labels: %v, err: %v", s.svcOpt.Labels, err)
+		return err
+	}
+
+	if _, err = s.cli.Put(s.ctx, labelKey, string(value), etcd3.WithLease(leaseID)); err != nil {
+		logs.Errorf("put labels with lease failed, key: %s, value: %s, err: %v", labelKey, value, err)
+		return err
+	}
+
+	return nil
+}
+
 // keepAliveFailed keep alive lease failed, need to exec action.
 func (s *service) keepAliveFailed() {
 	s.updateRegisterFlag(false)
@@ -225,6 +255,13 @@ func (s *service) Deregister() error {
 		return err
 	}
 
+	if len(s.svcOpt.Labels) != 0 {
+		if _, err := s.cli.Delete(context.Background(), key(ServiceLabelName(s.svcOpt.Name),
+			s.svcOpt.Uid)); err != nil {
+			return err
+		}
+	}
+
 	s.updateRegisterFlag(false)
 	return nil
 }
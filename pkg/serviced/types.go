@@ -47,6 +47,8 @@ type ServiceOption struct {
 	Scheme string
 	// Uid is a service's unique identity.
 	Uid string
+	// Labels is the service node's labels, which is registered with the same lease as service key.
+	Labels map[string]string
 }
 
 // Validate the service option
@@ -109,6 +111,12 @@ func ServiceDiscoveryName(serviceName cc.Name) string {
 	return fmt.Sprintf("/hcm/services/%s", serviceName)
 }
 
+// ServiceLabelName return the service's node labels register path in etcd. it can not be under the service
+// discovery path, otherwise labels key will be treated as a service node.
+func ServiceLabelName(serviceName cc.Name) string {
+	return fmt.Sprintf("/hcm/labels/%s", serviceName)
+}
+
 // key return service's register key in etcd.
 // e.g: /hcm/services/data-service/0fa709f2-8e35-11ec-83f6-acde48001122
 func key(path, uid string) string {
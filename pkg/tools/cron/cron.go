@@ -0,0 +1,261 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cron 提供标准五段式cron表达式的解析与下次触发时间的计算。
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears 计算下次触发时间时最多向后查找的年数，避免如"0 0 30 2 *"这种永远无法触发的表达式导致死循环。
+const maxSearchYears = 5
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type bound struct {
+	min uint
+	max uint
+}
+
+var (
+	minuteBound = bound{min: 0, max: 59}
+	hourBound   = bound{min: 0, max: 23}
+	domBound    = bound{min: 1, max: 31}
+	monthBound  = bound{min: 1, max: 12}
+	// dowBound 星期字段允许7，等同于0（周日）
+	dowBound = bound{min: 0, max: 7}
+)
+
+// Schedule 解析后的cron表达式，每个字段使用位图记录允许的取值。
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domStar/dowStar 标记日、星期字段是否以"*"或"?"开头，用于处理日和星期同时设置时"或"的语义
+	domStar bool
+	dowStar bool
+}
+
+// Parse 解析五段式cron表达式（分 时 日 月 星期），支持"*"、"?"、","、"-"、"/"及@daily等描述符。
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if len(spec) == 0 {
+		return nil, fmt.Errorf("cron spec is empty")
+	}
+
+	if strings.HasPrefix(spec, "@") {
+		expanded, exist := descriptors[spec]
+		if !exist {
+			return nil, fmt.Errorf("unsupported cron descriptor: %s", spec)
+		}
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron spec: %s should have 5 fields, but got %d", spec, len(fields))
+	}
+
+	var err error
+	sch := new(Schedule)
+	if sch.minute, err = parseField(fields[0], minuteBound); err != nil {
+		return nil, fmt.Errorf("parse minute field failed, err: %v", err)
+	}
+
+	if sch.hour, err = parseField(fields[1], hourBound); err != nil {
+		return nil, fmt.Errorf("parse hour field failed, err: %v", err)
+	}
+
+	if sch.dom, err = parseField(fields[2], domBound); err != nil {
+		return nil, fmt.Errorf("parse day of month field failed, err: %v", err)
+	}
+
+	if sch.month, err = parseField(fields[3], monthBound); err != nil {
+		return nil, fmt.Errorf("parse month field failed, err: %v", err)
+	}
+
+	if sch.dow, err = parseField(fields[4], dowBound); err != nil {
+		return nil, fmt.Errorf("parse day of week field failed, err: %v", err)
+	}
+	// 7 和 0 都表示周日
+	if sch.dow&(1<<7) != 0 {
+		sch.dow |= 1
+	}
+
+	sch.domStar = isStarField(fields[2])
+	sch.dowStar = isStarField(fields[4])
+
+	return sch, nil
+}
+
+func isStar(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// isStarField 字段以"*"或"?"开头时（如"*/2"），与标准cron一致视为未限定，不适用日和星期"或"的语义。
+func isStarField(field string) bool {
+	return strings.HasPrefix(field, "*") || strings.HasPrefix(field, "?")
+}
+
+// parseField 解析单个字段，返回允许取值的位图。
+func parseField(field string, b bound) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		one, err := parseRange(expr, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= one
+	}
+
+	return bits, nil
+}
+
+// parseRange 解析"*"、"a"、"a-b"、"*/n"、"a-b/n"、"a/n"形式的表达式。
+func parseRange(expr string, b bound) (uint64, error) {
+	if len(expr) == 0 {
+		return 0, fmt.Errorf("empty expression")
+	}
+
+	rangeAndStep := strings.Split(expr, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("invalid expression: %s", expr)
+	}
+
+	var start, end uint
+	var err error
+	switch lowAndHigh := strings.Split(rangeAndStep[0], "-"); {
+	case isStar(rangeAndStep[0]):
+		start, end = b.min, b.max
+
+	case len(lowAndHigh) == 1:
+		if start, err = parseNumber(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+		end = start
+		// "a/n" 表示从a开始到最大值，每隔n
+		if len(rangeAndStep) == 2 {
+			end = b.max
+		}
+
+	case len(lowAndHigh) == 2:
+		if start, err = parseNumber(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+
+		if end, err = parseNumber(lowAndHigh[1], b); err != nil {
+			return 0, err
+		}
+
+	default:
+		return 0, fmt.Errorf("invalid expression: %s", expr)
+	}
+
+	if start > end {
+		return 0, fmt.Errorf("invalid range: %s, start is greater than end", expr)
+	}
+
+	step := uint(1)
+	if len(rangeAndStep) == 2 {
+		val, err := strconv.ParseUint(rangeAndStep[1], 10, 32)
+		if err != nil || val == 0 {
+			return 0, fmt.Errorf("invalid step: %s", rangeAndStep[1])
+		}
+		step = uint(val)
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+
+	return bits, nil
+}
+
+func parseNumber(s string, b bound) (uint, error) {
+	val, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: %s", s)
+	}
+
+	if uint(val) < b.min || uint(val) > b.max {
+		return 0, fmt.Errorf("value: %d out of range [%d, %d]", val, b.min, b.max)
+	}
+
+	return uint(val), nil
+}
+
+// Next 返回严格晚于t的下一次触发时间（精确到分钟，使用t所在的时区），如果在5年内找不到则返回零值。
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + maxSearchYears
+
+	for t.Year() <= yearLimit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches 日和星期字段均有限定时，满足任意一个即可（与标准cron保持一致）。
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
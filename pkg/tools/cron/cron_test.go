@@ -0,0 +1,95 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	specs := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"*/0 * * * *", "5-1 * * * *", "@every", "a * * * *"}
+	for _, spec := range specs {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("parse spec: %q should failed", spec)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	base := time.Date(2023, 12, 15, 10, 30, 20, 0, time.UTC) // 周五
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2023, 12, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2023, 12, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2023, 12, 16, 2, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2023, 12, 16, 0, 0, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2023, 12, 16, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2023, 12, 18, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2023, 12, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// 日和星期同时设置时满足任意一个即可
+		{"0 0 20 * 6", time.Date(2023, 12, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		sch, err := Parse(tt.spec)
+		if err != nil {
+			t.Fatalf("parse spec: %s failed, err: %v", tt.spec, err)
+		}
+
+		if got := sch.Next(base); !got.Equal(tt.want) {
+			t.Errorf("spec: %s, next: %v, want: %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestNextDayOfMonthAndWeek(t *testing.T) {
+	base := time.Date(2023, 12, 15, 10, 30, 20, 0, time.UTC) // 周五
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		// 日和星期均有限定时满足任意一个即可
+		{"0 0 1,15 * 1", time.Date(2023, 12, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 20 * 6", time.Date(2023, 12, 16, 0, 0, 0, 0, time.UTC)},
+		// 以"*"开头的字段视为未限定，需要同时满足日和星期
+		{"0 0 */2 * 1", time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * */2", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 ? * 1", time.Date(2023, 12, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * ?", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		sch, err := Parse(tt.spec)
+		if err != nil {
+			t.Fatalf("parse spec: %s failed, err: %v", tt.spec, err)
+		}
+
+		if got := sch.Next(base); !got.Equal(tt.want) {
+			t.Errorf("spec: %s, next: %v, want: %v", tt.spec, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+/*
+    SQLVER=0013,HCMVER=v1.2.2
+
+    Notes:
+        1. 任务流表增加开始执行时间start_at字段，支持延时任务流
+        2. 新增周期任务流表
+        3. 任务流表增加优先级priority、云账号account_id字段，支持按优先级派发和按任务流、账号限制并发
+        4. 任务表增加执行超时时间timeout_sec字段，支持按任务设置执行超时时间
+        5. 任务流表增加是否补偿compensate字段，支持任务流执行失败后对执行成功的任务进行补偿
+        6. 任务表增加允许失败allow_failure、触发规则trigger_rule、执行条件run_condition字段，支持条件执行任务
+        7. 任务表增加扇出任务ID parent_id字段，支持动态扇出子任务
+        8. 任务流表增加父任务流ID parent_flow_id、父任务ID parent_task_id字段，支持子任务流
+        9. 任务流表增加回调订阅callbacks、待生成回调标记callback_pending字段，新增任务流回调投递记录表，支持任务流结束后回调通知
+        10. 任务表增加执行日志logs字段，保存任务执行过程中记录的日志
+        11. 任务流表增加幂等键idempotency_key、请求内容摘要payload_digest字段，支持任务流幂等提交，未设置幂等键时为NULL，不受唯一索引限制
+        12. 新增任务流归档表、任务归档表，超过保留时间的已结束任务流及其任务由主节点移动到归档表或直接删除
+        13. 任务表、任务归档表增加重试状态retry_state字段，任务执行失败后进入重试等待状态，到达下次重试时间后重新执行
+        14. 新增异步任务限流令牌桶表，按照账号、云厂商接口分组限制多个task-server节点执行任务调用云厂商接口的速率
+        15. 新增异步任务主节点fencing token表，主节点每个任期的fencing token单调递增，旧主节点携带过期的token更新任务流状态时失败
+        16. 新增负载均衡、负载均衡监听器、目标组、后端服务以及负载均衡和主机关联关系表
+        17. 新增云盘快照表，回收云盘时可以选择保留云盘快照
+        18. 任务表、任务归档表增加参数渲染标记params_render字段，创建任务流时使用声明式模版参数模版的任务才会在执行时渲染参数
+        19. 任务流表、任务流归档表增加fencing_token字段，记录主节点组件最近一次更新任务流时所在任期的fencing token
+*/
+start transaction;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.2.2' as `hcm_ver`, '0013' as `sql_ver`;
+
+-- 1. 任务流表增加开始执行时间start_at字段，支持延时任务流
+alter table async_flow
+    add column `start_at` timestamp not null default current_timestamp;
+
+-- 2. 新增周期任务流表
+create table if not exists `async_flow_cron`
+(
+    `id`          varchar(64) not null,
+    `name`        varchar(64) not null,
+    `spec`        varchar(64) not null,
+    `share_data`  json                 default null,
+    `tasks`       json        not null,
+    `memo`        varchar(64) not null,
+    `next_run_at` timestamp   not null,
+    `priority`    int         not null default 0,
+    `account_id`  varchar(64)          default '',
+    `compensate`  boolean     not null default false,
+    `creator`     varchar(64) not null,
+    `reviser`     varchar(64) not null,
+    `created_at`  timestamp   not null default current_timestamp,
+    `updated_at`  timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    index `idx_next_run_at` (`next_run_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+insert into id_generator(`resource`, `max_id`)
+values ('async_flow_cron', '0');
+
+-- 3. 任务流表增加优先级priority、云账号account_id字段，支持按优先级派发和按任务流、账号限制并发
+alter table async_flow
+    add column `priority` int not null default 0;
+alter table async_flow
+    add column `account_id` varchar(64) default '';
+alter table async_flow
+    add index `idx_state_priority` (`state`, `priority`);
+
+-- 4. 任务表增加执行超时时间timeout_sec字段，支持按任务设置执行超时时间
+alter table async_flow_task
+    add column `timeout_sec` int unsigned not null default 0;
+
+-- 5. 任务流表增加是否补偿compensate字段，支持任务流执行失败后对执行成功的任务进行补偿
+alter table async_flow
+    add column `compensate` boolean not null default false;
+
+-- 6. 任务表增加允许失败allow_failure、触发规则trigger_rule、执行条件run_condition字段，支持条件执行任务
+alter table async_flow_task
+    add column `allow_failure` boolean     not null default false,
+    add column `trigger_rule`  varchar(32) not null default '',
+    add column `run_condition` json                 default null;
+
+-- 7. 任务表增加扇出任务ID parent_id字段，支持动态扇出子任务
+alter table async_flow_task
+    add column `parent_id` varchar(64) not null default '',
+    add index `idx_parent_id` (`parent_id`);
+
+-- 8. 任务流表增加父任务流ID parent_flow_id、父任务ID parent_task_id字段，支持子任务流
+alter table async_flow
+    add column `parent_flow_id` varchar(64) not null default '',
+    add column `parent_task_id` varchar(64) not null default '',
+    add index `idx_parent_flow_id` (`parent_flow_id`),
+    add index `idx_parent_task_id` (`parent_task_id`);
+
+-- 9. 任务流表增加回调订阅callbacks、待生成回调标记callback_pending字段，新增任务流回调投递记录表，支持任务流结束后回调通知
+alter table async_flow
+    add column `callbacks`        json             default null,
+    add column `callback_pending` boolean not null default false,
+    add index `idx_callback_pending` (`callback_pending`);
+
+create table if not exists `async_flow_callback`
+(
+    `id`         varchar(64)  not null,
+    `flow_id`    varchar(64)  not null,
+    `flow_state` varchar(32)  not null,
+    `callback`   json         not null,
+    `payload`    json         not null,
+    `state`      varchar(32)  not null,
+    `attempts`   int unsigned not null default 0,
+    `next_at`    timestamp    not null,
+    `reason`     json                  default null,
+    `creator`    varchar(64)  not null,
+    `reviser`    varchar(64)  not null,
+    `created_at` timestamp    not null default current_timestamp,
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    index `idx_flow_id` (`flow_id`),
+    index `idx_state_next_at` (`state`, `next_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+insert into id_generator(`resource`, `max_id`)
+values ('async_flow_callback', '0');
+
+-- 10. 任务表增加执行日志logs字段，保存任务执行过程中记录的日志
+alter table async_flow_task
+    add column `logs` json default null;
+
+-- 11. 任务流表增加幂等键idempotency_key、请求内容摘要payload_digest字段，支持任务流幂等提交，未设置幂等键时为NULL，不受唯一索引限制
+alter table async_flow
+    add column `idempotency_key` varchar(64)          default null,
+    add column `payload_digest`  varchar(64) not null default '',
+    add unique index `idx_uk_idempotency_key` (`idempotency_key`);
+
+-- 12. 新增任务流归档表、任务归档表，超过保留时间的已结束任务流及其任务由主节点移动到归档表或直接删除
+alter table async_flow
+    add index `idx_state_updated_at` (`state`, `updated_at`);
+alter table async_flow_task
+    add index `idx_flow_id` (`flow_id`);
+
+create table if not exists `async_flow_archive` like `async_flow`;
+alter table async_flow_archive
+    drop index `idx_uk_idempotency_key`,
+    add index `idx_idempotency_key` (`idempotency_key`);
+
+create table if not exists `async_flow_task_archive` like `async_flow_task`;
+
+-- 13. 任务表、任务归档表增加重试状态retry_state字段，任务执行失败后进入重试等待状态，到达下次重试时间后重新执行
+alter table async_flow_task
+    add column `retry_state` json default null;
+alter table async_flow_task_archive
+    add column `retry_state` json default null;
+
+-- 14. 新增异步任务限流令牌桶表，按照账号、云厂商接口分组限制多个task-server节点执行任务调用云厂商接口的速率
+create table if not exists `async_rate_limit_bucket`
+(
+    `id`           varchar(255) not null,
+    `tokens`       double       not null,
+    `refreshed_at` datetime(6)  not null,
+    primary key (`id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+-- 15. 新增异步任务主节点fencing token表，主节点每个任期的fencing token单调递增，旧主节点携带过期的token更新任务流状态时失败
+create table if not exists `async_leader_fence`
+(
+    `id`         varchar(64) not null,
+    `token`      bigint      not null,
+    `worker`     varchar(64) not null,
+    `updated_at` timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin;
+
+-- 16. 新增负载均衡、负载均衡监听器、目标组、后端服务以及负载均衡和主机关联关系表
+create table if not exists `load_balancer`
+(
+    `id`                 varchar(64)  not null,
+    `cloud_id`           varchar(255) not null,
+    `name`               varchar(255) not null,
+    `vendor`             varchar(16)  not null,
+    `account_id`         varchar(64)  not null,
+    `bk_biz_id`          bigint(1)    not null default -1,
+    `region`             varchar(20)  not null,
+    `zones`              json         not null,
+    `vpc_id`             varchar(64)  not null default '',
+    `cloud_vpc_id`       varchar(255) not null default '',
+    `network_type`       varchar(16)  not null default '',
+    `ip_version`         varchar(32)  not null default '',
+    `status`             varchar(32)  not null default '',
+    `domain`             varchar(255) not null default '',
+    `public_ips`         json         not null,
+    `private_ips`        json         not null,
+    `cloud_created_time` varchar(64)  not null default '',
+    `extension`          json         not null,
+    `memo`               varchar(255)          default '',
+    `creator`            varchar(64)  not null,
+    `reviser`            varchar(64)  not null,
+    `created_at`         timestamp    not null default current_timestamp,
+    `updated_at`         timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_cloud_id_vendor` (`cloud_id`, `vendor`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `load_balancer_listener`
+(
+    `id`          varchar(64)  not null,
+    `cloud_id`    varchar(255) not null,
+    `name`        varchar(255) not null default '',
+    `vendor`      varchar(16)  not null,
+    `account_id`  varchar(64)  not null,
+    `lb_id`       varchar(64)  not null,
+    `cloud_lb_id` varchar(255) not null,
+    `protocol`    varchar(32)  not null,
+    `port`        bigint(1)    not null,
+    `extension`   json         not null,
+    `creator`     varchar(64)  not null,
+    `reviser`     varchar(64)  not null,
+    `created_at`  timestamp    not null default current_timestamp,
+    `updated_at`  timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_cloud_id_vendor` (`cloud_id`, `vendor`),
+    index `idx_lb_id` (`lb_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `load_balancer_target_group`
+(
+    `id`           varchar(64)  not null,
+    `cloud_id`     varchar(255) not null,
+    `name`         varchar(255) not null default '',
+    `vendor`       varchar(16)  not null,
+    `account_id`   varchar(64)  not null,
+    `region`       varchar(20)  not null,
+    `vpc_id`       varchar(64)  not null default '',
+    `cloud_vpc_id` varchar(255) not null default '',
+    `protocol`     varchar(32)  not null default '',
+    `port`         bigint(1)    not null default 0,
+    `target_type`  varchar(32)  not null default '',
+    `extension`    json         not null,
+    `creator`      varchar(64)  not null,
+    `reviser`      varchar(64)  not null,
+    `created_at`   timestamp    not null default current_timestamp,
+    `updated_at`   timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_cloud_id_vendor` (`cloud_id`, `vendor`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `load_balancer_target`
+(
+    `id`                    varchar(64)   not null,
+    `cloud_id`              varchar(1024) not null,
+    `vendor`                varchar(16)   not null,
+    `account_id`            varchar(64)   not null,
+    `lb_id`                 varchar(64)   not null default '',
+    `cloud_lb_id`           varchar(255)  not null default '',
+    `listener_id`           varchar(64)   not null default '',
+    `cloud_listener_id`     varchar(255)  not null default '',
+    `cloud_rule_id`         varchar(255)  not null default '',
+    `target_group_id`       varchar(64)   not null default '',
+    `cloud_target_group_id` varchar(255)  not null default '',
+    `inst_type`             varchar(16)   not null,
+    `cloud_inst_id`         varchar(255)  not null,
+    `private_ip_addresses`  json          not null,
+    `public_ip_addresses`   json          not null,
+    `port`                  bigint(1)     not null,
+    `weight`                bigint(1)              default null,
+    `creator`               varchar(64)   not null,
+    `reviser`               varchar(64)   not null,
+    `created_at`            timestamp     not null default current_timestamp,
+    `updated_at`            timestamp     not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    index `idx_lb_id` (`lb_id`),
+    index `idx_target_group_id` (`target_group_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+create table if not exists `load_balancer_cvm_rel`
+(
+    `id`         bigint(1) unsigned not null auto_increment,
+    `lb_id`      varchar(64)        not null,
+    `cvm_id`     varchar(64)        not null,
+    `creator`    varchar(64)        not null,
+    `created_at` timestamp          not null default current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_lb_id_cvm_id` (`lb_id`, `cvm_id`),
+    constraint load_balancer_cvm_rel_lb_id foreign key (lb_id) REFERENCES load_balancer (id) ON DELETE CASCADE,
+    constraint load_balancer_cvm_rel_cvm_id foreign key (cvm_id) REFERENCES cvm (id) ON DELETE CASCADE
+) engine = innodb
+  default charset = utf8mb4;
+
+insert into id_generator(`resource`, `max_id`)
+values ('load_balancer', '0'),
+       ('load_balancer_listener', '0'),
+       ('load_balancer_target_group', '0'),
+       ('load_balancer_target', '0');
+
+-- 17. 新增云盘快照表，回收云盘时可以选择保留云盘快照
+create table if not exists `disk_snapshot`
+(
+    `id`                 varchar(64)         not null,
+    `cloud_id`           varchar(255)        not null,
+    `name`               varchar(255)        not null default '',
+    `vendor`             varchar(16)         not null,
+    `account_id`         varchar(64)         not null,
+    `bk_biz_id`          bigint(1)           not null default -1,
+    `region`             varchar(20)         not null default '',
+    `zone`               varchar(64)         not null default '',
+    `disk_id`            varchar(64)         not null default '',
+    `cloud_disk_id`      varchar(255)        not null default '',
+    `size`               bigint(1) unsigned  not null default 0,
+    `status`             varchar(32)         not null default '',
+    `cloud_created_time` varchar(64)         not null default '',
+    `extension`          json                not null,
+    `memo`               varchar(255)                 default '',
+    `creator`            varchar(64)         not null,
+    `reviser`            varchar(64)         not null,
+    `created_at`         timestamp           not null default current_timestamp,
+    `updated_at`         timestamp           not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_cloud_id_vendor` (`cloud_id`, `vendor`),
+    index `idx_disk_id` (`disk_id`)
+) engine = innodb
+  default charset = utf8mb4;
+
+insert into id_generator(`resource`, `max_id`)
+values ('disk_snapshot', '0');
+
+-- 18. 任务表、任务归档表增加参数渲染标记params_render字段，创建任务流时使用声明式模版参数模版的任务才会在执行时渲染参数
+alter table async_flow_task
+    add column `params_render` boolean not null default false;
+alter table async_flow_task_archive
+    add column `params_render` boolean not null default false;
+
+-- 19. 任务流表、任务流归档表增加fencing_token字段，记录主节点组件最近一次更新任务流时所在任期的fencing token
+alter table async_flow
+    add column `fencing_token` bigint not null default 0;
+alter table async_flow_archive
+    add column `fencing_token` bigint not null default 0;
+
+commit;